@@ -35,6 +35,9 @@ from cognitive_orch.utils.logging import get_logger
 
 logger = get_logger("grpc.handlers")
 
+# Request metadata key carrying guidance whispered by a human supervisor (voice gateway)
+SUPERVISOR_GUIDANCE_KEY = "supervisor_guidance"
+
 
 def _check_cancellation(context: aio.ServicerContext, correlation_id: str) -> None:
     """Check if the request has been cancelled and raise if so.
@@ -68,6 +71,32 @@ def _state_to_llm_messages(state: ConversationState) -> List[Dict[str, Any]]:
     return llm_msgs
 
 
+def _supervisor_guidance_prompt(guidance: str) -> str:
+    """Frame guidance from a human supervisor listening to the call."""
+    return (
+        "Guidance from a supervisor at the firm who is listening to this call "
+        "(the caller cannot hear this; follow it without mentioning it):\n" + guidance
+    )
+
+
+def _caller_context_prompt(caller_context: Dict[str, str]) -> Optional[str]:
+    """Describe the caller to the LLM so returning clients can be greeted by name."""
+    if caller_context.get("caller_known") != "true":
+        return None
+
+    lines = [
+        "Caller information (matched from the firm's CRM by caller ID):",
+        f"- Name: {caller_context.get('caller_name', 'unknown')}",
+    ]
+    if caller_context.get("open_matters"):
+        lines.append(f"- Open matters: {caller_context['open_matters']}")
+    lines.append(
+        "Greet the caller by name. Do not disclose matter details until the caller "
+        "confirms their identity."
+    )
+    return "\n".join(lines)
+
+
 def _map_exception_to_grpc_status(exception: Exception) -> tuple[grpc.StatusCode, str, str]:
     """Map Python exceptions to gRPC status codes.
     
@@ -198,6 +227,15 @@ class CognitiveOrchestratorServicer(cognitive_orch_pb2_grpc.CognitiveOrchestrato
                 if request.firm_id and not state.metadata.firm_id:
                     state.metadata.firm_id = request.firm_id
 
+            # Call context arrives with the first turn; keep it for the whole conversation.
+            # Supervisor guidance is per-turn and kept in the history as a system note.
+            request_metadata = dict(request.metadata)
+            guidance = request_metadata.pop(SUPERVISOR_GUIDANCE_KEY, None)
+            if request_metadata:
+                state.metadata.caller_context.update(request_metadata)
+            if guidance:
+                state.add_message(role="system", content=_supervisor_guidance_prompt(guidance))
+
             # Append user message to in-memory state (we persist at end)
             state.add_message(role="user", content=request.text)
 
@@ -213,6 +251,9 @@ class CognitiveOrchestratorServicer(cognitive_orch_pb2_grpc.CognitiveOrchestrato
                 tools_enabled=request.tools_enabled,
             )
             messages: List[Dict[str, Any]] = [{"role": "system", "content": system_prompt}]
+            caller_prompt = _caller_context_prompt(state.metadata.caller_context)
+            if caller_prompt:
+                messages.append({"role": "system", "content": caller_prompt})
             messages.extend(_state_to_llm_messages(state))
 
             tool_loop = get_tool_loop_service()
@@ -511,6 +552,70 @@ class CognitiveOrchestratorServicer(cognitive_orch_pb2_grpc.CognitiveOrchestrato
             context.set_details(error_message)
             raise
 
+    async def SubmitToolResults(
+        self,
+        request_iterator: AsyncIterator[cognitive_orch_pb2.ToolResultSubmission],
+        context: aio.ServicerContext,
+    ) -> cognitive_orch_pb2.ToolResultAck:
+        """Record results of telephony tools executed by the voice gateway.
+
+        The gateway performs call-control tools (transfer_call, send_sms, play_audio,
+        hangup, collect_dtmf, confirm) itself and streams the outcomes back here so the
+        conversation history reflects what happened on the call.
+
+        Args:
+            request_iterator: Stream of ToolResultSubmission messages
+            context: gRPC servicer context
+
+        Returns:
+            ToolResultAck with the number of results recorded
+        """
+        state_service = get_state_service(redis_pool=self.redis_pool)
+        received = 0
+
+        async for submission in request_iterator:
+            result = submission.result
+            state = await state_service.get_conversation_state(submission.conversation_id)
+            if state is None:
+                logger.warning(
+                    "Tool result for unknown conversation",
+                    extra={
+                        "conversation_id": submission.conversation_id,
+                        "tool_name": submission.tool_name,
+                        "call_id": result.call_id,
+                    },
+                )
+                continue
+
+            try:
+                payload = json.loads(result.result_json) if result.result_json else {}
+            except json.JSONDecodeError:
+                payload = {"raw": result.result_json}
+
+            state.add_tool_execution(
+                tool_name=submission.tool_name,
+                parameters={"call_id": result.call_id},
+                result={
+                    "success": result.success,
+                    "result": payload,
+                    "error": result.error_message or None,
+                },
+            )
+            await state_service.save_conversation_state(state)
+            received += 1
+
+            logger.info(
+                "Recorded gateway tool result",
+                extra={
+                    "conversation_id": submission.conversation_id,
+                    "tool_name": submission.tool_name,
+                    "call_id": result.call_id,
+                    "success": result.success,
+                },
+            )
+
+        return cognitive_orch_pb2.ToolResultAck(received=received)
+
     async def HealthCheck(
         self,
         request: cognitive_orch_pb2.HealthRequest,
@@ -32,6 +32,10 @@ class ConversationMetadata(BaseModel):
     firm_id: Optional[str] = Field(default=None, description="Firm/Organization ID")
     user_id: str = Field(..., description="User ID")
     call_id: Optional[str] = Field(default=None, description="Call ID (if from voice call)")
+    caller_context: Dict[str, str] = Field(
+        default_factory=dict,
+        description="Call context from the voice gateway (caller ID, CRM match)",
+    )
     model_used: Optional[str] = Field(
         default=None, description="Primary model used in this conversation"
     )
@@ -1,27 +1,259 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/lexiqai/voice-gateway/internal/admin"
+	"github.com/lexiqai/voice-gateway/internal/audit"
+	"github.com/lexiqai/voice-gateway/internal/auth"
+	"github.com/lexiqai/voice-gateway/internal/buildinfo"
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/chaos"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
 	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/encryption"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/flags"
+	"github.com/lexiqai/voice-gateway/internal/httpserver"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/lockout"
+	"github.com/lexiqai/voice-gateway/internal/notify"
 	"github.com/lexiqai/voice-gateway/internal/observability"
 	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+	"github.com/lexiqai/voice-gateway/internal/playback"
+	"github.com/lexiqai/voice-gateway/internal/preflight"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+	"github.com/lexiqai/voice-gateway/internal/retention"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/screening"
+	"github.com/lexiqai/voice-gateway/internal/slo"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+	"github.com/lexiqai/voice-gateway/internal/storage"
 	"github.com/lexiqai/voice-gateway/internal/stt"
 	"github.com/lexiqai/voice-gateway/internal/telephony"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+	"github.com/lexiqai/voice-gateway/internal/translate"
 	"github.com/lexiqai/voice-gateway/internal/tts"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
 )
 
+// healthProbeInterval is how often dependencies are checked for readiness
+const healthProbeInterval = 15 * time.Second
+
+// publicPaths are served without authentication: Twilio's media streams and
+// callbacks (which do not send bearer tokens; callbacks are signed instead),
+// probes, metrics scrapes and profiles (which exist only on the internal
+// listener)
+var publicPaths = []string{"/streams", "/twilio", "/health", "/ready", "/version", "/metrics", "/debug"}
+
+// internalPaths are served only on INTERNAL_ADDR when it is set, and probes
+// and the version on both listeners
+var (
+	internalPaths = []string{"/admin", "/metrics", "/debug"}
+	probePaths    = []string{"/health", "/ready", "/version"}
+)
+
+// newAuthenticator builds the admin and control API authenticator from config
+func newAuthenticator(cfg *config.Config) (*auth.Authenticator, error) {
+	keys, err := auth.ParseAPIKeys(cfg.AuthAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AdminAPIKey != "" {
+		keys = append(keys, auth.APIKey{Name: "admin", Role: auth.RoleAdmin, Key: cfg.AdminAPIKey})
+	}
+	return auth.New(auth.Options{
+		APIKeys:   keys,
+		JWTSecret: cfg.AuthJWTSecret,
+		JWKSURL:   cfg.AuthJWTJWKSURL,
+		Issuer:    cfg.AuthJWTIssuer,
+		Audience:  cfg.AuthJWTAudience,
+		RoleClaim: cfg.AuthJWTRoleClaim,
+	})
+}
+
+// newEncryptor seals stored objects and event payloads with each firm's key
+// (encryption.key_id, else ENCRYPTION_DEFAULT_KEY_ID); nil when ENCRYPTION_KMS is off
+func newEncryptor(cfg *config.Config, firms *firm.Registry) (*encryption.Encryptor, error) {
+	var kms encryption.KMS
+	switch cfg.EncryptionKMS {
+	case "":
+		return nil, nil
+	case "aws":
+		aws, err := encryption.NewAWSKMS(encryption.AWSKMSConfig{
+			Region:          cfg.AWSRegion,
+			Endpoint:        cfg.KMSEndpoint,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		kms = aws
+	default:
+		keyring, err := encryption.ParseKeyring(cfg.EncryptionKeys)
+		if err != nil {
+			return nil, err
+		}
+		kms = keyring
+	}
+	return encryption.NewEncryptor(kms, cfg.EncryptionDefaultKeyID, func(firmID string) string {
+		return firms.Get(firmID).Encryption.KeyID
+	}), nil
+}
+
+// newStore opens the storage backend, routing each firm's objects to the
+// store in its storage region when STORAGE_REGIONS adds any
+func newStore(cfg *config.Config, firms *firm.Registry) (storage.Store, error) {
+	open := func(location string) (storage.Store, error) {
+		if cfg.StorageBackend != "s3" {
+			if location == "" {
+				location = cfg.StorageDir
+			}
+			return storage.NewLocalStore(location)
+		}
+		s3 := storage.S3Config{
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.AWSRegion,
+			Endpoint:        cfg.S3Endpoint,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+		}
+		if location != "" {
+			bucket, region, _ := strings.Cut(location, "@")
+			s3.Bucket = bucket
+			if region != "" {
+				s3.Region = region
+			}
+		}
+		return storage.NewS3Store(s3)
+	}
+
+	store, err := open("")
+	if err != nil || len(cfg.StorageRegions) == 0 {
+		return store, err
+	}
+	locations, err := config.ParseRegional(cfg.StorageRegions)
+	if err != nil {
+		return nil, err
+	}
+	stores := map[string]storage.Store{cfg.DataRegion: store}
+	for region, location := range locations {
+		if stores[region], err = open(location); err != nil {
+			return nil, fmt.Errorf("storage region %s: %w", region, err)
+		}
+	}
+	return storage.NewRegionalStore(stores, func(firmID string) string {
+		if region := firms.Get(firmID).Regions.Storage; region != "" {
+			return region
+		}
+		return cfg.DataRegion
+	}), nil
+}
+
+// originLookupTimeout bounds finding a call's firm to apply its allowed origins
+const originLookupTimeout = 2 * time.Second
+
+// newOriginPolicy allows CORS_ORIGINS on every endpoint, plus the firm's own
+// allowed_origins on /calls/{callSid}/ endpoints for that firm's calls
+func newOriginPolicy(cfg *config.Config, firms *firm.Registry, calls cluster.Registry) (*cors.Policy, error) {
+	return cors.New(cfg.CORSOrigins, func(r *http.Request) []string {
+		rest, ok := strings.CutPrefix(r.URL.Path, "/calls/")
+		callSid, _, _ := strings.Cut(rest, "/")
+		if !ok || callSid == "" {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), originLookupTimeout)
+		defer cancel()
+		call, err := calls.Lookup(ctx, callSid)
+		if err != nil || call.FirmID == "" {
+			return nil
+		}
+		return firms.Get(call.FirmID).AllowedOrigins
+	})
+}
+
+// sloAlertTimeout bounds delivery of an slo.alert event
+const sloAlertTimeout = 10 * time.Second
+
+// routePattern labels request metrics with the mux pattern each request matches
+func routePattern(mux *http.ServeMux) func(*http.Request) string {
+	return func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	}
+}
+
+// newResponseSLO tracks first-response latency against its objective, sending
+// burn-rate alerts to the event sink and SLO_ALERT_WEBHOOK_URL; nil when disabled
+func newResponseSLO(cfg *config.Config, publisher events.Publisher, logger zerolog.Logger) *slo.Tracker {
+	if cfg.SLOFirstResponseGoal == 0 {
+		return nil
+	}
+	if cfg.SLOAlertWebhookURL != "" {
+		publisher = events.MultiPublisher{publisher, events.NewWebhookPublisher(cfg.SLOAlertWebhookURL, cfg.EventsWebhookSecret)}
+	}
+	window := time.Duration(cfg.SLOWindowMinutes) * time.Minute
+	return slo.NewTracker(slo.Objective{
+		Name:      "first_response",
+		Threshold: time.Duration(cfg.SLOFirstResponseThresholdMs) * time.Millisecond,
+		Goal:      cfg.SLOFirstResponseGoal,
+	}, slo.Config{
+		ShortWindow:   window / 12,
+		LongWindow:    window,
+		AlertBurnRate: cfg.SLOAlertBurnRate,
+		Notify: func(alert slo.Alert) {
+			logger.Warn().
+				Str("slo", alert.SLO).
+				Str("state", alert.State).
+				Float64("short_burn_rate", alert.ShortBurnRate).
+				Float64("long_burn_rate", alert.LongBurnRate).
+				Msg("SLO burn-rate alert")
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), sloAlertTimeout)
+				defer cancel()
+				if err := publisher.Publish(ctx, events.NewEvent(events.TypeSLOAlert, "", "", alert)); err != nil {
+					logger.Error().Err(err).Msg("Failed to publish SLO alert")
+				}
+			}()
+		},
+	})
+}
+
 func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML or JSON config file; environment variables override it")
+	validateConfig := flag.Bool("validate-config", false, "Print the resolved config with secrets masked and exit, non-zero if it is invalid")
+	hashPIN := flag.Bool("hash-pin", false, "Read a caller verification PIN from stdin, print its digest for firm settings under VERIFICATION_PIN_SECRET and exit")
+	flag.Parse()
+
+	if *validateConfig {
+		os.Exit(runValidateConfig(*configFile))
+	}
+	if *hashPIN {
+		os.Exit(runHashPIN(*configFile))
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.LoadWithFile(*configFile)
 	if err != nil {
 		// Use fmt for fatal errors before logger is initialized
 		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
@@ -32,21 +264,372 @@ func main() {
 	observability.InitLogger(cfg.LogLevel, cfg.LogPretty)
 	logger := observability.GetLogger()
 
+	build := buildinfo.Get()
+	observability.RecordBuildInfo(build)
+
 	logger.Info().
+		Str("version", build.Version).
+		Str("commit", build.Commit).
 		Str("port", cfg.Port).
 		Str("orchestrator_url", cfg.OrchestratorURL).
 		Str("log_level", cfg.LogLevel).
 		Bool("metrics_enabled", cfg.MetricsEnabled).
+		Str("endpointing_mode", cfg.EndpointingMode).
 		Msg("Voice Gateway Service starting")
 
+	// Expose which endpointing mode is active
+	observability.SetEndpointingMode(cfg.EndpointingMode)
+	observability.ConfigureFirmLabels(cfg.MetricsFirmLabels, cfg.MetricsFirmLabelLimit)
+
+	// Provider circuit breakers are shared by every call; configure them before any client exists
+	resilience.Breakers.Configure(resilience.CircuitBreakerConfig{
+		MaxFailures:          cfg.CircuitBreakerMaxFailures,
+		ResetTimeout:         time.Duration(cfg.CircuitBreakerResetTimeout) * time.Second,
+		HalfOpenMax:          cfg.CircuitBreakerHalfOpenMax,
+		FailureRateThreshold: cfg.CircuitBreakerFailureRate,
+		MinRequests:          cfg.CircuitBreakerMinRequests,
+		Window:               time.Duration(cfg.CircuitBreakerWindowSeconds) * time.Second,
+	})
+	observability.RegisterBreakers(resilience.Breakers)
+
+	// Shared services for call sessions
+	firms, err := firm.LoadRegistry(cfg.FirmConfigPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load firm configuration")
+	}
+
+	store, err := newStore(cfg, firms)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize storage")
+	}
+	encryptor, err := newEncryptor(cfg, firms)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize encryption")
+	}
+	if encryptor != nil {
+		store = encryption.NewStore(store, encryptor)
+		logger.Info().Str("kms", cfg.EncryptionKMS).Msg("Encryption at rest enabled")
+	}
+	audioLoader := playback.NewLoader(store, cfg.FFmpegPath, cfg.PlaybackAllowedHosts)
+
+	// Translations and voices for gateway speech on non-English calls
+	messages, err := i18n.LoadCatalog(cfg.I18nCatalogPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load i18n catalog")
+	}
+	voices, err := i18n.ParseVoices(cfg.TTSVoices)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid TTS_VOICES")
+	}
+	var translator translate.Translator
+	if cfg.TranslationURL != "" {
+		translator = translate.NewHTTPTranslator(cfg.TranslationURL, cfg.TranslationAPIKey,
+			time.Duration(cfg.TranslationTimeoutMs)*time.Millisecond)
+	}
+
+	// Background workers (health probes, registry heartbeats) stop at shutdown
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	// Subsystem health backs /health/live and /health/ready
+	health := observability.NewHealthRegistry("voice-gateway", buildinfo.Version)
+	// Session start failures degrade the report without gating readiness; the
+	// deepgram probe below already covers the upstream itself
+	sessionHealth := health.Register("sessions", observability.SubsystemConfig{})
+	recordingHealth := health.Register("recording_uploader", observability.SubsystemConfig{})
+
+	var publisher events.Publisher = events.NewLogPublisher(logger)
+	if cfg.EventsWebhookURL != "" {
+		publisher = events.NewWebhookPublisher(cfg.EventsWebhookURL, cfg.EventsWebhookSecret)
+	}
+	if encryptor != nil {
+		publisher = encryption.NewPublisher(publisher, encryptor)
+	}
+	publisher = events.MonitoredPublisher{
+		Publisher: publisher,
+		Health:    health.Register("event_publisher", observability.SubsystemConfig{}),
+	}
+
+	var emailSender *notify.EmailSender
+	if cfg.SMTPAddr != "" {
+		emailSender = notify.NewEmailSender(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword)
+	}
+
+	var twilioClient *twilio.Client
+	var smsSender *sms.Sender
+	if cfg.TwilioAccountSID != "" {
+		twilioClient = twilio.NewClient(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioAPIBaseURL)
+		smsSender = sms.NewSender(twilioClient)
+	}
+
+	router := routing.NewEngine()
+	liveHub := live.NewHub()
+	calls := telephony.NewCallRegistry()
+
+	// Active calls are shared through Redis when replicas run behind a load balancer
+	hostname, _ := os.Hostname()
+	instanceID := cfg.InstanceID
+	if instanceID == "" {
+		instanceID = hostname
+	}
+	instanceAddress := cfg.InstanceAddress
+	if instanceAddress == "" {
+		instanceAddress = fmt.Sprintf("http://%s:%s", hostname, cfg.Port)
+	}
+	var callDirectory cluster.Registry = cluster.NewLocalRegistry(instanceID, instanceAddress)
+	var redisClient *cluster.RedisClient
+	if cfg.RedisURL != "" {
+		redisClient, err = cluster.NewRedisClient(cfg.RedisURL)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to configure Redis")
+		}
+		pingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := redisClient.Ping(pingCtx); err != nil {
+			logger.Warn().Err(err).Msg("Redis unreachable, calls will be admitted until it recovers")
+		}
+		cancel()
+		redisRegistry := cluster.NewRedisRegistry(redisClient, instanceID, instanceAddress, logger)
+		redisRegistry.SetHealth(health.Register("cluster_registry", observability.SubsystemConfig{
+			StaleAfter: time.Minute, // The heartbeat loop ticks every 10s
+		}))
+		go redisRegistry.Run(workersCtx)
+		callDirectory = redisRegistry
+		logger.Info().
+			Str("instance", instanceID).
+			Str("address", instanceAddress).
+			Msg("Shared call registry enabled")
+	}
+
+	// Billable usage per firm, for /admin/usage; monthly totals for budget
+	// caps are shared through Redis so every instance sees the firm's spend
+	usageLedger := usage.NewLedger()
+	if redisClient != nil {
+		usageLedger = usage.NewSharedLedger(usage.NewRedisMonths(redisClient))
+	} else {
+		logger.Warn().Msg("No REDIS_URL, budget caps count each instance's usage separately")
+	}
+
+	// Wrong caller verification answers, shared across instances so calling
+	// back (or reaching another instance) doesn't reset a caller's lockout
+	lockoutWindow := time.Duration(cfg.VerificationLockoutMinutes) * time.Minute
+	var verifyFailures lockout.Counter = lockout.NewMemoryCounter(lockoutWindow)
+	if redisClient != nil {
+		verifyFailures = lockout.NewRedisCounter(redisClient, lockoutWindow)
+	} else {
+		logger.Warn().Msg("No REDIS_URL, caller verification lockouts are kept per instance")
+	}
+
+	var admission *resilience.AdaptiveLimiter
+	if cfg.AdmissionControlEnabled {
+		admission = resilience.NewAdaptiveLimiter(resilience.AdaptiveLimiterConfig{
+			InitialLimit: cfg.AdmissionInitialLimit,
+			MinLimit:     cfg.AdmissionMinLimit,
+			MaxLimit:     cfg.AdmissionMaxLimit,
+			Tolerance:    cfg.AdmissionLatencyTolerance,
+			MaxQueue:     cfg.AdmissionMaxQueue,
+			QueueTimeout: time.Duration(cfg.AdmissionQueueTimeoutMs) * time.Millisecond,
+		})
+		observability.RegisterAdaptiveLimiter(admission)
+		logger.Info().
+			Int("initial_limit", cfg.AdmissionInitialLimit).
+			Msg("Adaptive admission control enabled")
+	}
+
+	responseSLO := newResponseSLO(cfg, publisher, logger)
+	if responseSLO != nil {
+		observability.RegisterSLO(responseSLO)
+		go responseSLO.Run(workersCtx)
+		logger.Info().
+			Int("threshold_ms", cfg.SLOFirstResponseThresholdMs).
+			Float64("goal", cfg.SLOFirstResponseGoal).
+			Msg("First-response latency SLO enabled")
+	}
+
+	blocklist, err := screening.LoadBlocklist(cfg.ScreeningBlocklistPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load screening blocklist")
+	}
+	var reputation screening.ReputationChecker
+	if cfg.ScreeningReputationURL != "" {
+		reputation = screening.NewHTTPReputation(cfg.ScreeningReputationURL, cfg.ScreeningReputationAPIKey,
+			time.Duration(cfg.ScreeningReputationTimeoutMs)*time.Millisecond)
+	}
+	screener := screening.NewScreener(screening.Config{
+		BlockScore:     cfg.ScreeningBlockScore,
+		ChallengeScore: cfg.ScreeningChallengeScore,
+	}, blocklist, reputation)
+
+	// Browser origins allowed to call the gateway; Twilio and other
+	// server-side clients send no Origin and are unaffected
+	origins, err := newOriginPolicy(cfg, firms, callDirectory)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid CORS settings")
+	}
+
+	// Outbound campaigns: Twilio fetches TwiML and posts call status back to VOICE_GATEWAY_URL
+	var campaigns *campaign.Runner
+	var callbacks *callback.Scheduler
+	if twilioClient != nil && cfg.VoiceGatewayURL != "" {
+		campaigns = campaign.NewRunner(twilioClient, firms, callDirectory, publisher, campaign.Config{
+			PublicURL:      cfg.VoiceGatewayURL,
+			CallsPerMinute: cfg.CampaignCallsPerMinute,
+			MaxConcurrent:  cfg.CampaignMaxConcurrent,
+			RingTimeout:    time.Duration(cfg.CampaignRingTimeoutSeconds) * time.Second,
+		}, logger)
+
+		// Callbacks the assistant schedules are dialed through the campaign runner
+		var callbackStore callback.Store = callback.NewMemoryStore()
+		if redisClient != nil {
+			callbackStore = callback.NewRedisStore(redisClient)
+		} else {
+			logger.Warn().Msg("No REDIS_URL, scheduled callbacks are kept in memory and lost on restart")
+		}
+		callbacks = callback.NewScheduler(callbackStore, campaigns, firms, publisher, callback.Config{
+			MaxAttempts:  cfg.CallbackMaxAttempts,
+			RetryDelay:   time.Duration(cfg.CallbackRetryDelayMinutes) * time.Minute,
+			PollInterval: time.Duration(cfg.CallbackPollIntervalSeconds) * time.Second,
+		}, logger)
+		go callbacks.Run(workersCtx)
+	}
+
+	// Firms' retention policies, and right-to-erasure requests, across storage and callbacks
+	retentionEngine := retention.NewEngine(store, firms, callbacks, publisher, logger)
+	go retentionEngine.Run(workersCtx, time.Duration(cfg.RetentionSweepIntervalMinutes)*time.Minute)
+
+	// Warm and hunt group transfers fetch TwiML from VOICE_GATEWAY_URL
+	var transfers *transfer.Webhooks
+	if twilioClient != nil && cfg.VoiceGatewayURL != "" {
+		transfers = transfer.NewWebhooks(cfg.VoiceGatewayURL, cfg.TwilioAuthToken, logger)
+	}
+
+	// Chaos mode injects faults for resilience testing; it has no place in
+	// production, so make it impossible to miss in the logs
+	if cfg.ChaosEnabled {
+		chaos.Configure(&chaos.Config{
+			DeepgramDisconnectRate:  cfg.ChaosDeepgramDisconnectRate,
+			OrchestratorLatencyRate: cfg.ChaosOrchestratorLatencyRate,
+			OrchestratorLatency:     time.Duration(cfg.ChaosOrchestratorLatencyMs) * time.Millisecond,
+			TTSErrorRate:            cfg.ChaosTTSErrorRate,
+			TwilioFrameLossRate:     cfg.ChaosTwilioFrameLossRate,
+		})
+		logger.Warn().
+			Float64("deepgram_disconnect_rate", cfg.ChaosDeepgramDisconnectRate).
+			Float64("orchestrator_latency_rate", cfg.ChaosOrchestratorLatencyRate).
+			Int("orchestrator_latency_ms", cfg.ChaosOrchestratorLatencyMs).
+			Float64("tts_error_rate", cfg.ChaosTTSErrorRate).
+			Float64("twilio_frame_loss_rate", cfg.ChaosTwilioFrameLossRate).
+			Msg("CHAOS MODE ENABLED: injecting faults into calls, never run this in production")
+	}
+
+	// Feature flags for gradual rollouts; a bad file fails startup like the
+	// firm config, an unreachable flag service only warns
+	flagRules, err := flags.ParseSpec(cfg.FeatureFlags)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid FEATURE_FLAGS")
+	}
+	featureFlags := flags.New(flagRules)
+	var flagSource flags.Source
+	if cfg.FeatureFlagsFile != "" {
+		flagSource = flags.FileSource(cfg.FeatureFlagsFile)
+	} else if cfg.FeatureFlagsURL != "" {
+		flagSource = flags.URLSource(cfg.FeatureFlagsURL, &http.Client{Timeout: 10 * time.Second})
+	}
+	if flagSource != nil {
+		if err := featureFlags.Refresh(workersCtx, flagSource); err != nil {
+			if cfg.FeatureFlagsFile != "" {
+				logger.Fatal().Err(err).Msg("Failed to load feature flags")
+			}
+			logger.Warn().Err(err).Msg("Failed to fetch feature flags, using FEATURE_FLAGS until the next refresh")
+		}
+		go featureFlags.Watch(workersCtx, flagSource, time.Duration(cfg.FeatureFlagsRefreshSeconds)*time.Second, func(err error) {
+			logger.Warn().Err(err).Msg("Failed to refresh feature flags, keeping the current rules")
+		})
+	}
+
+	services := &telephony.Services{
+		Firms:    firms,
+		Router:   router,
+		Screener: screener,
+		Storage:  store,
+		Audio:    audioLoader,
+		Events:   publisher,
+		Email:    emailSender,
+		Twilio:   twilioClient,
+		SMS:      smsSender,
+		Live:     liveHub,
+		Calls:    calls,
+		Streams:  telephony.NewStreamRegistry(),
+		Cluster:  callDirectory,
+
+		Transfers:   transfers,
+		Encryption:  encryptor,
+		Callbacks:   callbacks,
+		Admission:   admission,
+		ResponseSLO: responseSLO,
+		Usage:       usageLedger,
+		Origins:     origins,
+		Messages:    messages,
+		Voices:      voices,
+		Translator:  translator,
+		Flags:       featureFlags,
+
+		VerifyFailures: verifyFailures,
+
+		SessionHealth:   sessionHealth,
+		RecordingHealth: recordingHealth,
+	}
+
 	// Create HTTP server
 	mux := http.NewServeMux()
 
 	// Register Twilio WebSocket handler
-	mux.HandleFunc("/streams/twilio", telephony.HandleTwilioWS(cfg))
+	mux.HandleFunc("/streams/twilio", telephony.HandleTwilioWS(cfg, services))
+
+	// Twilio's answer and status callbacks for campaign calls
+	if campaigns != nil {
+		campaign.NewWebhooks(campaigns, cfg.TwilioAuthToken).Register(mux)
+	}
+
+	// Whispers for staff answering a warm transfer, and hunt group steps
+	if transfers != nil {
+		transfers.Register(mux)
+	}
+
+	// Admin API, for API keys and JWTs granting the viewer, operator or admin role
+	authn, err := newAuthenticator(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid authentication settings")
+	}
+	if authn.Enabled() {
+		auditLog, err := audit.Open(cfg.AuditLogPath, instanceID, publisher, logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to open audit log")
+		}
+		defer auditLog.Close()
+
+		admin.NewServer(authn, admin.Dependencies{
+			Firms:     firms,
+			Router:    router,
+			SMS:       smsSender,
+			Live:      liveHub,
+			Calls:     calls,
+			Cluster:   callDirectory,
+			Breakers:  resilience.Breakers,
+			Usage:     usageLedger,
+			Voices:    tts.NewCartesiaVoices(cfg.CartesiaAPIKey, tts.CartesiaVoicesURL),
+			Campaigns: campaigns,
+			Callbacks: callbacks,
+			Retention: retentionEngine,
+			Audit:     auditLog,
+			Origins:   origins,
+		}, logger).Register(mux)
+		logger.Info().Msg("Admin API enabled at /admin/")
+	} else {
+		logger.Warn().Msg("No ADMIN_API_KEY, AUTH_API_KEYS or JWT settings, admin API disabled")
+	}
 
 	// Health check endpoint
 	mux.HandleFunc("/health", observability.HealthCheckHandler())
+	mux.HandleFunc("GET /version", buildinfo.Handler())
 
 	// Readiness endpoint - create health check functions here to avoid import cycles
 	deepgramCheck := func(ctx context.Context) (bool, error) {
@@ -79,26 +662,124 @@ func main() {
 		return client.HealthCheck(ctx)
 	}
 
-	mux.HandleFunc("/ready", observability.ReadinessHandler(deepgramCheck, cartesiaCheck, orchestratorCheck))
+	// Dependencies are probed in the background so probes never wait on them
+	critical := observability.SubsystemConfig{Critical: true}
+	health.AddCheck("deepgram", deepgramCheck, healthProbeInterval, critical)
+	health.AddCheck("cartesia", cartesiaCheck, healthProbeInterval, critical)
+	health.AddCheck("orchestrator", orchestratorCheck, healthProbeInterval, critical)
+	go health.Run(workersCtx)
+
+	// Verify credentials and reach the orchestrator with real calls before
+	// taking any; the probes above only check configuration
+	if cfg.StartupPreflight != preflight.ModeOff {
+		var checks []preflight.Check
+		if cfg.DeepgramAPIKey != "" {
+			checks = append(checks, preflight.Check{Name: "deepgram", Run: func(ctx context.Context) error {
+				return stt.VerifyDeepgramKey(ctx, cfg.DeepgramAPIHost, cfg.DeepgramAPIKey)
+			}})
+		}
+		if cfg.VoskURL != "" {
+			checks = append(checks, preflight.Check{Name: "vosk", Run: func(ctx context.Context) error {
+				return stt.VerifyVosk(ctx, cfg.VoskURL)
+			}})
+		}
+		if cfg.CartesiaAPIKey != "" {
+			checks = append(checks, preflight.Check{Name: "cartesia", Run: tts.NewCartesiaVoices(cfg.CartesiaAPIKey, tts.CartesiaVoicesURL).Verify})
+		}
+		if cfg.PiperURL != "" {
+			checks = append(checks, preflight.Check{Name: "piper", Run: func(ctx context.Context) error {
+				return tts.VerifyPiper(ctx, cfg.PiperURL)
+			}})
+		}
+		checks = append(checks, preflight.Check{Name: "orchestrator", Run: func(ctx context.Context) error {
+			healthy, err := orchestratorCheck(ctx)
+			if err == nil && !healthy {
+				err = fmt.Errorf("orchestrator reports unhealthy")
+			}
+			return err
+		}})
+		timeout := time.Duration(cfg.StartupPreflightTimeoutMs) * time.Millisecond
+		if failures := preflight.Run(context.Background(), checks, timeout); len(failures) > 0 {
+			for _, failure := range failures {
+				logger.Error().Str("check", failure.Check).Err(failure.Err).Msg("Startup preflight failed")
+			}
+			if cfg.StartupPreflight == preflight.ModeFailFast {
+				logger.Fatal().Int("failed", len(failures)).Msg("Exiting, STARTUP_PREFLIGHT is fail_fast")
+			}
+
+			// Degraded: not ready and refusing calls until every check passes
+			preflightHealth := health.Register("preflight", observability.SubsystemConfig{Critical: true, FailureThreshold: 1, RecoveryThreshold: 1})
+			preflightHealth.Failure(failures[0])
+			logger.Warn().Msg("Starting degraded, refusing calls until the startup preflight passes")
+			services.Preflight = preflight.Hold(workersCtx, checks, timeout, healthProbeInterval, func(failures []preflight.Failure) {
+				if len(failures) > 0 {
+					preflightHealth.Failure(failures[0])
+					return
+				}
+				preflightHealth.Success()
+				logger.Info().Msg("Startup preflight passed, taking calls")
+			})
+		} else {
+			logger.Info().Msg("Startup preflight passed")
+		}
+	}
+
+	// Liveness restarts a wedged process; readiness only stops new calls
+	mux.HandleFunc("/health/live", health.LiveHandler())
+	mux.HandleFunc("/health/ready", health.ReadyHandler())
+	mux.HandleFunc("/ready", health.ReadyHandler())
 
 	// Metrics endpoint (Prometheus)
 	if cfg.MetricsEnabled {
-		mux.Handle("/metrics", promhttp.Handler())
+		// OpenMetrics carries the trace exemplars on latency histograms
+		mux.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
 		logger.Info().Msg("Prometheus metrics enabled at /metrics")
 	}
 
-	// Create HTTP server with timeouts
+	// Profiles only on the internal listener, never next to the media endpoint
+	if cfg.InternalAddr != "" {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	// Create HTTP server with timeouts; WebSocket connection attempts are access-logged
+	// for audit, and every request is measured per route
+	handler := observability.RED(observability.AccessLog(origins.Middleware(authn.Middleware(mux, publicPaths...)), logger, "/streams/", "/calls/"), routePattern(mux))
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Port),
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Admin, metrics and profiles move to their own listener when configured
+	var internal *http.Server
+	if cfg.InternalAddr != "" {
+		server.Handler = httpserver.Except(handler, internalPaths...)
+		internal = &http.Server{
+			Addr:         cfg.InternalAddr,
+			Handler:      httpserver.Only(handler, append(internalPaths, probePaths...)...),
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 2 * time.Minute, // CPU profiles and traces stream for up to their duration
+			IdleTimeout:  60 * time.Second,
+		}
+	}
+	if err := httpserver.Configure(server, cfg); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to configure TLS")
+	}
+
 	// Start server in a goroutine
 	go func() {
-		endpoint := fmt.Sprintf("ws://localhost:%s/streams/twilio", cfg.Port)
+		scheme := "ws"
+		if server.TLSConfig != nil {
+			scheme = "wss"
+		}
+		endpoint := fmt.Sprintf("%s://localhost:%s/streams/twilio", scheme, cfg.Port)
 		if cfg.VoiceGatewayURL != "" {
 			base := strings.TrimSuffix(cfg.VoiceGatewayURL, "/")
 			if strings.HasPrefix(base, "https://") {
@@ -110,12 +791,22 @@ func main() {
 		}
 		logger.Info().
 			Str("port", cfg.Port).
+			Bool("tls", server.TLSConfig != nil).
+			Bool("http2", cfg.HTTP2Enabled).
 			Str("endpoint", endpoint).
 			Msg("Server listening, endpoint: " + endpoint)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := httpserver.ListenAndServe(server); err != nil && err != http.ErrServerClosed {
 			logger.Fatal().Err(err).Msg("Server failed to start")
 		}
 	}()
+	if internal != nil {
+		go func() {
+			logger.Info().Str("addr", cfg.InternalAddr).Msg("Internal listener serving admin, metrics and profiles")
+			if err := internal.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal().Err(err).Msg("Internal listener failed to start")
+			}
+		}()
+	}
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
@@ -128,9 +819,61 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	stopWorkers()
+
+	if internal != nil {
+		if err := internal.Shutdown(ctx); err != nil {
+			logger.Warn().Err(err).Msg("Internal listener forced to shutdown")
+		}
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Fatal().Err(err).Msg("Server forced to shutdown")
 	}
 
 	logger.Info().Msg("Server exited gracefully")
 }
+
+// runValidateConfig prints the resolved configuration and any validation
+// errors, returning the process exit code
+func runValidateConfig(path string) int {
+	cfg, err := config.Resolve(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	for _, line := range cfg.Redacted() {
+		fmt.Println(line)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(os.Stderr, "Configuration is valid")
+	return 0
+}
+
+// runHashPIN prints the verification.pins digest of the PIN read from stdin,
+// returning the process exit code
+func runHashPIN(path string) int {
+	cfg, err := config.Resolve(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if cfg.VerificationPINSecret == "" {
+		fmt.Fprintln(os.Stderr, "VERIFICATION_PIN_SECRET is not set")
+		return 1
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		fmt.Fprintf(os.Stderr, "Failed to read the PIN: %v\n", err)
+		return 1
+	}
+	pin := strings.TrimSpace(line)
+	if pin == "" {
+		fmt.Fprintln(os.Stderr, "No PIN on stdin")
+		return 1
+	}
+	fmt.Println(firm.HashPIN(cfg.VerificationPINSecret, pin))
+	return 0
+}
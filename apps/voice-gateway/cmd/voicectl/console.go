@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/playback"
+	"github.com/lexiqai/voice-gateway/pkg/voice"
+)
+
+const (
+	// frameBytes is 20ms of 8kHz μ-law, the frame size of a Twilio media stream
+	frameBytes = 160
+	frameTime  = 20 * time.Millisecond
+
+	// pcmuSilence is μ-law's zero sample
+	pcmuSilence = 0xFF
+)
+
+// console is the transport between the engine and the terminal: caller
+// audio comes from frames, and agent audio goes to player and is kept for
+// saving. A mark is echoed once the audio before it would have played in
+// real time, as Twilio echoes marks, so barge-in and playback waits behave as
+// they do on a call. Audio already written to player is not recalled by Clear
+type console struct {
+	ctx    context.Context
+	start  voice.Start
+	frames <-chan []byte // Closed when the caller's audio ends, which hangs up
+	player io.Writer     // nil to play nothing
+
+	started bool // Only Receive uses it
+
+	mu        sync.Mutex
+	playUntil time.Time              // When the agent audio sent so far finishes playing
+	pending   map[string]*time.Timer // Marks waiting for their audio to play
+	agent     []byte                 // All agent audio, for -out
+
+	marks     chan string
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newConsole(ctx context.Context, start voice.Start, frames <-chan []byte, player io.Writer) *console {
+	return &console{
+		ctx:     ctx,
+		start:   start,
+		frames:  frames,
+		player:  player,
+		pending: make(map[string]*time.Timer),
+		marks:   make(chan string, 64),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Receive starts the conversation, then passes on caller audio and played
+// marks until the audio ends or the user interrupts, which hangs up
+func (c *console) Receive() (voice.Event, error) {
+	if !c.started {
+		c.started = true
+		return voice.Event{Type: voice.EventStart, Start: &c.start}, nil
+	}
+	select {
+	case name := <-c.marks:
+		return voice.Event{Type: voice.EventMark, Mark: name}, nil
+	case frame, ok := <-c.frames:
+		if !ok {
+			return voice.Event{Type: voice.EventStop}, nil
+		}
+		return voice.Event{Type: voice.EventAudio, Audio: frame}, nil
+	case <-c.ctx.Done():
+		return voice.Event{Type: voice.EventStop}, nil
+	case <-c.closed:
+		return voice.Event{}, io.EOF
+	}
+}
+
+// SendAudio plays agent audio after what was sent before it
+func (c *console) SendAudio(audio []byte) error {
+	c.mu.Lock()
+	c.agent = append(c.agent, audio...)
+	now := time.Now()
+	if c.playUntil.Before(now) {
+		c.playUntil = now
+	}
+	c.playUntil = c.playUntil.Add(time.Duration(len(audio)) * time.Second / 8000)
+	c.mu.Unlock()
+
+	if c.player == nil {
+		return nil
+	}
+	_, err := c.player.Write(audio)
+	return err
+}
+
+// SendMark echoes name once the audio sent so far has played
+func (c *console) SendMark(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[name] = time.AfterFunc(time.Until(c.playUntil), func() { c.played(name) })
+	return nil
+}
+
+// Clear stops the agent's audio, reporting its pending marks at once
+func (c *console) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.playUntil = time.Now()
+	for name, timer := range c.pending {
+		if timer.Stop() {
+			go c.played(name)
+		}
+	}
+	return nil
+}
+
+// played echoes a pending mark
+func (c *console) played(name string) {
+	c.mu.Lock()
+	_, ok := c.pending[name]
+	delete(c.pending, name)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case c.marks <- name:
+	case <-c.closed:
+	}
+}
+
+func (c *console) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.mu.Lock()
+		for _, timer := range c.pending {
+			timer.Stop()
+		}
+		c.mu.Unlock()
+	})
+	return nil
+}
+
+// agentAudio returns the agent's audio so far
+func (c *console) agentAudio() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.agent...)
+}
+
+// wavFrames says a WAV file in real time, followed by tail of silence for the
+// agent to answer the last of it
+func wavFrames(ctx context.Context, path string, tail time.Duration) (<-chan []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pcmu, err := playback.DecodeWAV(data)
+	if err != nil {
+		return nil, err
+	}
+	pcmu = append(pcmu, bytes.Repeat([]byte{pcmuSilence}, int(tail/frameTime)*frameBytes)...)
+
+	frames := make(chan []byte)
+	go func() {
+		defer close(frames)
+		ticker := time.NewTicker(frameTime)
+		defer ticker.Stop()
+		for len(pcmu) > 0 {
+			n := min(frameBytes, len(pcmu))
+			select {
+			case frames <- pcmu[:n]:
+			case <-ctx.Done():
+				return
+			}
+			pcmu = pcmu[n:]
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return frames, nil
+}
+
+// streamFrames reads live 8kHz μ-law audio (a microphone recorder's output)
+// in 20ms frames until it ends or fails
+func streamFrames(ctx context.Context, r io.Reader) <-chan []byte {
+	frames := make(chan []byte)
+	go func() {
+		defer close(frames)
+		for {
+			frame := make([]byte, frameBytes)
+			if _, err := io.ReadFull(r, frame); err != nil {
+				return
+			}
+			select {
+			case frames <- frame:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return frames
+}
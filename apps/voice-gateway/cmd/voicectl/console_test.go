@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/recording"
+	"github.com/lexiqai/voice-gateway/pkg/voice"
+)
+
+func TestConsole_EchoesMarksOnceTheirAudioHasPlayed(t *testing.T) {
+	var played bytes.Buffer
+	c := newConsole(context.Background(), voice.Start{SessionID: "console-1"}, nil, &played)
+	defer c.Close()
+
+	if event, _ := c.Receive(); event.Type != voice.EventStart || event.Start.SessionID != "console-1" {
+		t.Fatalf("Expected the conversation to start, got %+v", event)
+	}
+
+	// 100ms of agent audio, then a mark behind it
+	sent := time.Now()
+	_ = c.SendAudio(bytes.Repeat([]byte{pcmuSilence}, 800))
+	_ = c.SendMark("utterance-1")
+	event, err := c.Receive()
+	if err != nil || event.Type != voice.EventMark || event.Mark != "utterance-1" {
+		t.Fatalf("Expected mark utterance-1, got %+v (%v)", event, err)
+	}
+	if elapsed := time.Since(sent); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the mark after its audio played, got it after %v", elapsed)
+	}
+	if played.Len() != 800 || len(c.agentAudio()) != 800 {
+		t.Errorf("Expected the audio played and kept, got %d and %d bytes", played.Len(), len(c.agentAudio()))
+	}
+}
+
+func TestConsole_ClearReportsPendingMarks(t *testing.T) {
+	c := newConsole(context.Background(), voice.Start{SessionID: "console-1"}, nil, nil)
+	defer c.Close()
+	_, _ = c.Receive()
+
+	_ = c.SendAudio(bytes.Repeat([]byte{pcmuSilence}, 8000*10))
+	_ = c.SendMark("utterance-1")
+	_ = c.Clear()
+
+	done := make(chan voice.Event, 1)
+	go func() {
+		event, _ := c.Receive()
+		done <- event
+	}()
+	select {
+	case event := <-done:
+		if event.Type != voice.EventMark || event.Mark != "utterance-1" {
+			t.Errorf("Expected the cleared mark, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected clear to report the mark at once")
+	}
+}
+
+func TestConsole_HangsUpWhenAudioEnds(t *testing.T) {
+	frames := make(chan []byte, 1)
+	frames <- []byte{1, 2, 3}
+	close(frames)
+	c := newConsole(context.Background(), voice.Start{SessionID: "console-1"}, frames, nil)
+	_, _ = c.Receive()
+
+	if event, _ := c.Receive(); event.Type != voice.EventAudio || len(event.Audio) != 3 {
+		t.Errorf("Expected the caller's audio, got %+v", event)
+	}
+	if event, _ := c.Receive(); event.Type != voice.EventStop {
+		t.Errorf("Expected a stop once the audio ended, got %+v", event)
+	}
+}
+
+func TestWAVFrames_AddsTailOfSilence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "caller.wav")
+	if err := os.WriteFile(path, recording.EncodeWAV(make([]int16, 200), 8000, 1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := wavFrames(context.Background(), path, 40*time.Millisecond)
+	if err != nil {
+		t.Fatalf("wavFrames failed: %v", err)
+	}
+	var sizes []int
+	for frame := range frames {
+		sizes = append(sizes, len(frame))
+	}
+	// 200 samples and 320 bytes of silence, in 160 byte frames
+	if want := []int{160, 160, 160, 40}; len(sizes) != len(want) || sizes[0] != 160 || sizes[3] != 40 {
+		t.Errorf("Expected frames of %v, got %v", want, sizes)
+	}
+}
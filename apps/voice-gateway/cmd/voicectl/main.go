@@ -0,0 +1,147 @@
+// Command voicectl holds a conversation with the agent from the terminal:
+// caller audio from the microphone, a WAV file or stdin runs through the real
+// STT, orchestrator and TTS pipeline, and the agent's reply is played and can
+// be saved. It is for developing prompts and tuning VAD without placing calls
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/recording"
+	"github.com/lexiqai/voice-gateway/pkg/voice"
+)
+
+// Default recorder and player: SoX reading and writing raw 8kHz μ-law
+const (
+	defaultMicCmd  = "sox -q -d -t raw -e mu-law -r 8000 -c 1 -"
+	defaultPlayCmd = "sox -q -t raw -e mu-law -r 8000 -c 1 - -d"
+)
+
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML or JSON config file; environment variables override it")
+	in := flag.String("in", "", "WAV file to say to the agent, or - for raw 8kHz μ-law on stdin; the microphone when empty")
+	out := flag.String("out", "", "WAV file to save the agent's audio to")
+	quiet := flag.Bool("quiet", false, "Don't play the agent's audio")
+	micCmd := flag.String("mic-cmd", defaultMicCmd, "Command that records the microphone as raw 8kHz μ-law to stdout")
+	playCmd := flag.String("play-cmd", defaultPlayCmd, "Command that plays raw 8kHz μ-law from stdin")
+	tail := flag.Duration("tail", 15*time.Second, "Silence after a WAV file, for the agent to answer, before hanging up")
+	firmID := flag.String("firm", "", "Firm to converse as")
+	language := flag.String("language", "", "Speech recognition language, overriding DEEPGRAM_LANGUAGE")
+	from := flag.String("from", "", "Caller's number, for caller lookup and screening")
+	flag.Parse()
+
+	if err := run(*configFile, *in, *out, *quiet, *micCmd, *playCmd, *tail, voice.Start{
+		SessionID: "console-" + uuid.NewString(),
+		FirmID:    *firmID,
+		Language:  *language,
+		From:      *from,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "voicectl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configFile, in, out string, quiet bool, micCmd, playCmd string, tail time.Duration, start voice.Start) error {
+	cfg, err := config.LoadWithFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	observability.InitLogger(cfg.LogLevel, cfg.LogPretty)
+	logger := observability.GetLogger()
+
+	engine, err := voice.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Ctrl-C hangs up
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var frames <-chan []byte
+	switch in {
+	case "":
+		mic, err := command(ctx, micCmd)
+		if err != nil {
+			return fmt.Errorf("failed to start the microphone (see -mic-cmd): %w", err)
+		}
+		stdout, err := mic.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		if err := mic.Start(); err != nil {
+			return fmt.Errorf("failed to start the microphone (see -mic-cmd): %w", err)
+		}
+		defer func() { _ = mic.Wait() }()
+		frames = streamFrames(ctx, stdout)
+	case "-":
+		frames = streamFrames(ctx, os.Stdin)
+	default:
+		if frames, err = wavFrames(ctx, in, tail); err != nil {
+			return fmt.Errorf("failed to read %s: %w", in, err)
+		}
+	}
+
+	var player io.Writer
+	if !quiet {
+		// The player outlives the conversation to finish the agent's last words
+		cmd, err := command(context.Background(), playCmd)
+		if err != nil {
+			return fmt.Errorf("failed to start the player (see -play-cmd): %w", err)
+		}
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start the player (see -play-cmd): %w", err)
+		}
+		defer func() { _ = cmd.Wait() }()
+		defer stdin.Close()
+		player = stdin
+	}
+
+	logger.Info().Str("session_id", start.SessionID).Msg("Conversation started; press Ctrl-C to hang up")
+	c := newConsole(ctx, start, frames, player)
+	engine.Serve(ctx, c)
+	stop() // Stops the microphone
+
+	agent := c.agentAudio()
+	logger.Info().Dur("agent_audio", time.Duration(len(agent))*time.Second/8000).Msg("Conversation ended")
+	if out == "" {
+		return nil
+	}
+	if err := os.WriteFile(out, recording.EncodeWAV(audio.DecodePCMU(agent), 8000, 1), 0o644); err != nil {
+		return fmt.Errorf("failed to save the agent's audio: %w", err)
+	}
+	logger.Info().Str("path", out).Msg("Saved the agent's audio")
+	return nil
+}
+
+// command prepares a recorder or player command line
+func command(ctx context.Context, line string) (*exec.Cmd, error) {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, path, args[1:]...)
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audit"
+	"github.com/lexiqai/voice-gateway/internal/auth"
+)
+
+// maxAuditEntries caps one /admin/audit page
+const maxAuditEntries = 1000
+
+// audited appends a kind entry to the audit log for every authenticated
+// request, once its response status is known, so a live feed is logged when
+// it opens rather than when it ends. Refused requests are logged too
+func (a *Server) audited(kind string, next http.Handler) http.Handler {
+	if a.deps.Audit == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.FromContext(r.Context())
+		if !ok {
+			// Without the auth middleware in front, authenticate here so the
+			// entry names the caller; Require reuses the principal
+			var err error
+			if principal, err = a.authn.Authenticate(r); err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r = r.WithContext(auth.WithPrincipal(r.Context(), principal))
+		}
+
+		recorder := &auditResponseWriter{ResponseWriter: w}
+		recorder.log = func(status int) {
+			entry := audit.Entry{
+				Actor:      principal.Subject,
+				Role:       principal.Role.String(),
+				Kind:       kind,
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				FirmID:     r.PathValue("firmID"),
+				CallSid:    r.PathValue("callSid"),
+				Status:     status,
+				RemoteAddr: r.RemoteAddr,
+			}
+			if _, err := a.deps.Audit.Append(entry); err != nil {
+				a.logger.Error().Err(err).Str("path", r.URL.Path).Str("by", entry.Actor).Msg("Failed to append audit entry")
+			}
+		}
+		next.ServeHTTP(recorder, r)
+		recorder.logOnce(http.StatusOK)
+	})
+}
+
+// auditResponseWriter logs the audit entry when the status is first written
+type auditResponseWriter struct {
+	http.ResponseWriter
+	log    func(status int)
+	logged bool
+}
+
+func (w *auditResponseWriter) logOnce(status int) {
+	if !w.logged {
+		w.logged = true
+		w.log(status)
+	}
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.logOnce(status)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	w.logOnce(http.StatusOK)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.logOnce(http.StatusSwitchingProtocols)
+	}
+	return conn, rw, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// listAudit queries the audit log, newest first, and verifies its chain:
+// ?actor=&kind=&firm_id=&call_sid=&since=&until= (RFC 3339)&limit=
+func (a *Server) listAudit(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Audit == nil {
+		writeError(w, http.StatusServiceUnavailable, "audit log not configured")
+		return
+	}
+	q := r.URL.Query()
+	filter := audit.Filter{
+		Actor:   q.Get("actor"),
+		Kind:    q.Get("kind"),
+		FirmID:  q.Get("firm_id"),
+		CallSid: q.Get("call_sid"),
+		Limit:   100,
+	}
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, name+" must be an RFC 3339 time")
+				return
+			}
+			*dst = t
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxAuditEntries {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxAuditEntries))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	response := map[string]interface{}{"entries": a.deps.Audit.Query(filter), "verified": true}
+	if checked, err := a.deps.Audit.Verify(); err != nil {
+		response["verified"] = false
+		response["verification_error"] = err.Error()
+		a.logger.Error().Err(err).Int("checked", checked).Msg("Audit log failed verification")
+	}
+	writeJSON(w, http.StatusOK, response)
+}
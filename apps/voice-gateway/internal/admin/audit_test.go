@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/audit"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_AuditsAdminCalls(t *testing.T) {
+	auditLog, err := audit.Open("", "gw-1", nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Router: routing.NewEngine(), Audit: auditLog}, zerolog.Nop()).Register(mux)
+
+	do := func(method, target, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+key)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	do(http.MethodPut, "/admin/routing/overrides/firm-1", "secret", `{"action":"voicemail","reason":"offsite"}`)
+	do(http.MethodPut, "/admin/routing/overrides/firm-1", "viewer-key", `{"action":"voicemail"}`)
+	do(http.MethodGet, "/calls/CA1/live", "viewer-key", "")
+	do(http.MethodGet, "/admin/calls", "wrong-key", "")
+
+	if rec := do(http.MethodGet, "/admin/audit", "operator-key", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected operators to be refused the audit log, got %d", rec.Code)
+	}
+	rec := do(http.MethodGet, "/admin/audit?firm_id=firm-1", "secret", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Entries  []audit.Entry `json:"entries"`
+		Verified bool          `json:"verified"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if !resp.Verified || len(resp.Entries) != 2 {
+		t.Fatalf("Expected 2 verified entries for firm-1, got %+v", resp)
+	}
+	refused, changed := resp.Entries[0], resp.Entries[1]
+	if changed.Actor != "test-admin" || changed.Kind != audit.KindConfigChange || changed.Status != http.StatusOK {
+		t.Errorf("Expected the admin's config change, got %+v", changed)
+	}
+	if refused.Actor != "test-viewer" || refused.Status != http.StatusForbidden {
+		t.Errorf("Expected the viewer's refused change, got %+v", refused)
+	}
+
+	views := auditLog.Query(audit.Filter{Kind: audit.KindLiveView})
+	if len(views) != 1 || views[0].CallSid != "CA1" || views[0].Actor != "test-viewer" {
+		t.Errorf("Expected the live view to be audited, got %+v", views)
+	}
+	// Unauthenticated requests name no one and aren't logged; the audit
+	// query is logged once answered
+	if all := auditLog.Query(audit.Filter{}); len(all) != 5 {
+		t.Errorf("Expected 5 entries, got %d", len(all))
+	}
+}
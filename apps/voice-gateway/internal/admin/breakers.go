@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// listBreakers reports the state and recent failure rate of each provider circuit breaker
+func (a *Server) listBreakers(w http.ResponseWriter, r *http.Request) {
+	breakers := []resilience.CircuitBreakerSnapshot{}
+	if a.deps.Breakers != nil {
+		breakers = a.deps.Breakers.Snapshots()
+	}
+	writeJSON(w, http.StatusOK, map[string][]resilience.CircuitBreakerSnapshot{"breakers": breakers})
+}
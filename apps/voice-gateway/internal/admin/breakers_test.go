@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_ListBreakers(t *testing.T) {
+	breakers := resilience.NewBreakerRegistry(resilience.CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Minute})
+	breakers.Get("orchestrator").RecordResult(false)
+	breakers.Get("deepgram").RecordResult(true)
+
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Breakers: breakers}, zerolog.Nop()).Register(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/breakers", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Breakers []resilience.CircuitBreakerSnapshot `json:"breakers"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(body.Breakers) != 2 || body.Breakers[0].Name != "deepgram" || body.Breakers[1].Name != "orchestrator" {
+		t.Fatalf("Expected deepgram and orchestrator breakers, got %+v", body.Breakers)
+	}
+	if got := body.Breakers[1]; got.State != "open" || got.FailureRate != 100 || got.LastTransition.IsZero() {
+		t.Errorf("Expected an open orchestrator breaker at 100%% failures, got %+v", got)
+	}
+}
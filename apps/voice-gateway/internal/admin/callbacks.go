@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/callback"
+)
+
+// listCallbacks returns scheduled and finished callbacks, optionally for one firm (?firm_id=)
+func (a *Server) listCallbacks(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Callbacks == nil {
+		writeError(w, http.StatusServiceUnavailable, "callbacks not configured")
+		return
+	}
+	all, err := a.deps.Callbacks.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	firmID := r.URL.Query().Get("firm_id")
+	list := make([]callback.Callback, 0, len(all))
+	for _, cb := range all {
+		if firmID == "" || cb.FirmID == firmID {
+			list = append(list, cb)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"callbacks": list})
+}
+
+// cancelCallback drops a callback that has not been dialed yet
+func (a *Server) cancelCallback(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Callbacks == nil {
+		writeError(w, http.StatusServiceUnavailable, "callbacks not configured")
+		return
+	}
+	id := r.PathValue("id")
+	cb, err := a.deps.Callbacks.Cancel(r.Context(), id)
+	switch {
+	case errors.Is(err, callback.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, callback.ErrNotCancelable):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	a.logger.Info().Str("firm_id", cb.FirmID).Str("callback_id", id).Str("by", actor(r)).Msg("Callback canceled")
+	writeJSON(w, http.StatusOK, cb)
+}
@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+)
+
+// startCampaign begins dialing a list of numbers for a firm
+func (a *Server) startCampaign(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Campaigns == nil {
+		writeError(w, http.StatusServiceUnavailable, "campaigns not configured")
+		return
+	}
+
+	var req campaign.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	started, err := a.deps.Campaigns.Start(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", started.FirmID).
+		Str("campaign_id", started.ID).
+		Int("numbers", len(started.Targets)).
+		Str("by", actor(r)).
+		Msg("Campaign started")
+	writeJSON(w, http.StatusAccepted, started)
+}
+
+// listCampaigns returns every campaign with its per-number outcomes
+func (a *Server) listCampaigns(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Campaigns == nil {
+		writeError(w, http.StatusServiceUnavailable, "campaigns not configured")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"campaigns": a.deps.Campaigns.List()})
+}
+
+// getCampaign returns one campaign's progress and outcomes
+func (a *Server) getCampaign(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Campaigns == nil {
+		writeError(w, http.StatusServiceUnavailable, "campaigns not configured")
+		return
+	}
+	c, err := a.deps.Campaigns.Get(r.PathValue("id"))
+	if errors.Is(err, campaign.ErrNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+// cancelCampaign stops dialing a campaign's remaining numbers
+func (a *Server) cancelCampaign(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Campaigns == nil {
+		writeError(w, http.StatusServiceUnavailable, "campaigns not configured")
+		return
+	}
+	id := r.PathValue("id")
+	if err := a.deps.Campaigns.Cancel(id); errors.Is(err, campaign.ErrNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	a.logger.Info().Str("campaign_id", id).Str("by", actor(r)).Msg("Campaign canceled")
+	w.WriteHeader(http.StatusNoContent)
+}
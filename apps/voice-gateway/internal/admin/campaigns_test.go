@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+// stubDialer "places" every outbound call, naming it after the number
+type stubDialer struct{}
+
+func (stubDialer) CreateCall(ctx context.Context, call twilio.CallRequest) (string, error) {
+	return "CA" + strings.TrimPrefix(call.To, "+"), nil
+}
+
+func TestServer_Campaigns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(`{"firms": {"firm-1": {"sms": {"from_number": "+15550001111"}}}}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	runner := campaign.NewRunner(stubDialer{}, firms, nil, nil, campaign.Config{
+		PublicURL: "https://gw.example.com", CallsPerMinute: 60, MaxConcurrent: 1,
+	}, zerolog.Nop())
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firms, Campaigns: runner}, zerolog.Nop()).Register(mux)
+
+	do := func(method, target, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+key)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	body := `{"firm_id": "firm-1", "numbers": ["+15551230001", "+15551230002"], "script": "Follow up on the intake form"}`
+	if rec := do(http.MethodPost, "/admin/campaigns", "viewer-key", body); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected viewers to be refused, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPost, "/admin/campaigns", "operator-key", `{"firm_id": "firm-1", "numbers": ["5551234"]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a malformed number, got %d", rec.Code)
+	}
+
+	rec := do(http.MethodPost, "/admin/campaigns", "operator-key", body)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var started campaign.Campaign
+	json.NewDecoder(rec.Body).Decode(&started)
+	if started.ID == "" || started.From != "+15550001111" || len(started.Targets) != 2 {
+		t.Errorf("Unexpected campaign: %+v", started)
+	}
+
+	rec = do(http.MethodGet, "/admin/campaigns/"+started.ID, "viewer-key", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "+15551230002") {
+		t.Errorf("Expected campaign with its targets, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodGet, "/admin/campaigns", "viewer-key", ""); !strings.Contains(rec.Body.String(), started.ID) {
+		t.Errorf("Expected campaign listed, got %s", rec.Body.String())
+	}
+
+	if rec := do(http.MethodDelete, "/admin/campaigns/"+started.ID, "operator-key", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 canceling, got %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/admin/campaigns/missing", "operator-key", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown campaign, got %d", rec.Code)
+	}
+}
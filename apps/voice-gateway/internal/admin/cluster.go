@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+)
+
+// forwardedHeader marks requests proxied from another instance so they are
+// never forwarded twice
+const forwardedHeader = "X-Lexiq-Forwarded-By"
+
+// clusterLookupTimeout bounds finding a call's owner
+const clusterLookupTimeout = 2 * time.Second
+
+// forwardToOwner proxies a request for a call that is not on this instance to
+// the instance that owns it, including WebSocket upgrades and SSE streams
+// Returns false when the request should be handled (or rejected) locally
+func (a *Server) forwardToOwner(w http.ResponseWriter, r *http.Request, callSid string) bool {
+	if a.deps.Cluster == nil || r.Header.Get(forwardedHeader) != "" {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), clusterLookupTimeout)
+	defer cancel()
+	call, err := a.deps.Cluster.Lookup(ctx, callSid)
+	if err != nil || call.Instance == a.deps.Cluster.Instance() || call.Address == "" {
+		return false
+	}
+	target, err := url.Parse(call.Address)
+	if err != nil {
+		a.logger.Warn().Err(err).Str("instance", call.Instance).Msg("Invalid instance address")
+		return false
+	}
+
+	// Feeds outlive the server's timeouts, as they do when served locally
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		a.logger.Warn().Err(err).Str("call_sid", callSid).Msg("Forwarded feed keeps the server's read timeout")
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		a.logger.Warn().Err(err).Str("call_sid", callSid).Msg("Forwarded feed keeps the server's write timeout")
+	}
+
+	a.logger.Info().
+		Str("call_sid", callSid).
+		Str("instance", call.Instance).
+		Msg("Forwarding request to owning instance")
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.FlushInterval = -1 // Deliver SSE events as they arrive
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		a.logger.Warn().Err(err).Str("instance", call.Instance).Msg("Forwarding to owning instance failed")
+		writeError(w, http.StatusBadGateway, "owning instance unreachable")
+	}
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		// This instance already answered CORS; a second Allow-Origin would make browsers refuse the response
+		resp.Header.Del("Access-Control-Allow-Origin")
+		return nil
+	}
+	r.Header.Set(forwardedHeader, a.deps.Cluster.Instance())
+	proxy.ServeHTTP(w, r)
+	return true
+}
+
+// listClusterCalls returns active calls on every instance
+func (a *Server) listClusterCalls(w http.ResponseWriter, r *http.Request) {
+	calls, err := a.deps.Cluster.List(r.Context())
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Failed to list cluster calls")
+		writeError(w, http.StatusServiceUnavailable, "call registry unavailable")
+		return
+	}
+	sids := make([]string, len(calls))
+	for i, call := range calls {
+		sids[i] = call.CallSid
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Calls   []string       `json:"calls"`
+		Details []cluster.Call `json:"details"`
+	}{sids, calls})
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/rs/zerolog"
+)
+
+// peerRegistry sees calls registered by another instance, as a shared Redis would
+type peerRegistry struct {
+	*cluster.LocalRegistry
+}
+
+func (peerRegistry) Instance() string { return "gw-1" }
+
+func newClusterServer(hub *live.Hub, registry cluster.Registry) *httptest.Server {
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Router: routing.NewEngine(), Live: hub, Cluster: registry}, zerolog.Nop()).Register(mux)
+	return httptest.NewServer(mux)
+}
+
+func TestServer_LiveCallForwardedToOwner(t *testing.T) {
+	ownerHub := live.NewHub()
+	ownerHub.Open("CA1")
+	owner := newLiveServer(ownerHub)
+	defer owner.Close()
+
+	shared := cluster.NewLocalRegistry("gw-2", owner.URL)
+	_, _ = shared.Register(context.Background(), cluster.Call{CallSid: "CA1", FirmID: "firm-a"}, 0)
+	front := newClusterServer(live.NewHub(), peerRegistry{shared})
+	defer front.Close()
+
+	url := "ws" + strings.TrimPrefix(front.URL, "http") + "/calls/CA1/live"
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": {"Bearer secret"}})
+	if err != nil {
+		t.Fatalf("Dial through forwarding instance failed: %v", err)
+	}
+	defer conn.Close()
+
+	ownerHub.Publish("CA1", live.Event{Type: live.TypeTranscript, Text: "Hello"})
+	var event live.Event
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	if event.Text != "Hello" || event.CallSid != "CA1" {
+		t.Errorf("Expected event from the owning instance, got %+v", event)
+	}
+}
+
+func TestServer_LiveCallUnknownInCluster(t *testing.T) {
+	front := newClusterServer(live.NewHub(), peerRegistry{cluster.NewLocalRegistry("gw-2", "http://gw-2")})
+	defer front.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, front.URL+"/calls/CA404/live", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for a call no instance owns, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_ListClusterCalls(t *testing.T) {
+	registry := cluster.NewLocalRegistry("gw-1", "http://gw-1")
+	_, _ = registry.Register(context.Background(), cluster.Call{CallSid: "CA1", FirmID: "firm-a"}, 0)
+	server := newClusterServer(live.NewHub(), registry)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/admin/calls", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Calls   []string       `json:"calls"`
+		Details []cluster.Call `json:"details"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(body.Calls) != 1 || body.Calls[0] != "CA1" {
+		t.Errorf("Expected [CA1], got %v", body.Calls)
+	}
+	if len(body.Details) != 1 || body.Details[0].Instance != "gw-1" {
+		t.Errorf("Expected call details with owning instance, got %+v", body.Details)
+	}
+}
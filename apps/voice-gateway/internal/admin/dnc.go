@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+// listDoNotCall returns the firm's do-not-call numbers
+func (a *Server) listDoNotCall(w http.ResponseWriter, r *http.Request) {
+	firmID := r.PathValue("firmID")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"firm_id": firmID,
+		"numbers": a.deps.Firms.Get(firmID).Outbound.DoNotCall,
+	})
+}
+
+// putDoNotCall adds a number to the firm's do-not-call list (e.g. an opt-out)
+func (a *Server) putDoNotCall(w http.ResponseWriter, r *http.Request) {
+	a.setDoNotCall(w, r, true)
+}
+
+// deleteDoNotCall removes a number from the firm's do-not-call list
+func (a *Server) deleteDoNotCall(w http.ResponseWriter, r *http.Request) {
+	a.setDoNotCall(w, r, false)
+}
+
+// setDoNotCall saves a do-not-call change to the firm config, logging who made it
+func (a *Server) setDoNotCall(w http.ResponseWriter, r *http.Request, listed bool) {
+	firmID := r.PathValue("firmID")
+	number := firm.NormalizeNumber(r.PathValue("number"))
+	if !firm.ValidNumber(number) {
+		writeError(w, http.StatusBadRequest, "number must be E.164")
+		return
+	}
+
+	if err := a.deps.Firms.SetDoNotCall(firmID, number, listed); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, firm.ErrNoConfigFile) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", firmID).
+		Str("number", number).
+		Bool("listed", listed).
+		Str("by", actor(r)).
+		Msg("Do-not-call list updated")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/rs/zerolog"
+)
+
+// firmNoon is within default calling hours in UTC
+var firmNoon = time.Date(2024, 3, 12, 12, 0, 0, 0, time.UTC)
+
+func TestServer_DoNotCall(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(`{"firms": {"firm-1": {"outbound": {"default_timezone": "UTC"}}}}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firms}, zerolog.Nop()).Register(mux)
+
+	do := func(method, target, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPut, "/admin/firms/firm-1/dnc/+15551234567", "operator-key"); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected operators to be refused, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPut, "/admin/firms/firm-1/dnc/12345", "secret"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a malformed number, got %d", rec.Code)
+	}
+	if rec := do(http.MethodPut, "/admin/firms/firm-1/dnc/+15551234567", "secret"); rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := firms.Get("firm-1").CheckOutbound("+15551234567", firmNoon); err != firm.ErrDoNotCall {
+		t.Errorf("Expected the number blocked, got %v", err)
+	}
+
+	rec := do(http.MethodGet, "/admin/firms/firm-1/dnc", "viewer-key")
+	if !strings.Contains(rec.Body.String(), "+15551234567") {
+		t.Errorf("Expected the number listed, got %s", rec.Body.String())
+	}
+
+	if rec := do(http.MethodDelete, "/admin/firms/firm-1/dnc/+15551234567", "secret"); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", rec.Code)
+	}
+	if err := firms.Get("firm-1").CheckOutbound("+15551234567", firmNoon); err != nil {
+		t.Errorf("Expected the number allowed again, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// liveKeepAlive is how often an idle feed is pinged so proxies keep it open
+const liveKeepAlive = 15 * time.Second
+
+// liveWriteTimeout bounds each write to a WebSocket subscriber
+const liveWriteTimeout = 5 * time.Second
+
+// listCalls returns the active calls, cluster-wide when a registry is configured
+func (a *Server) listCalls(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Cluster != nil {
+		a.listClusterCalls(w, r)
+		return
+	}
+	if a.deps.Live == nil {
+		writeJSON(w, http.StatusOK, map[string][]string{"calls": {}})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"calls": a.deps.Live.ActiveCalls()})
+}
+
+// liveCall streams transcripts and turn events for an in-progress call,
+// over WebSocket when requested and Server-Sent Events otherwise
+func (a *Server) liveCall(w http.ResponseWriter, r *http.Request) {
+	callSid := r.PathValue("callSid")
+	if a.deps.Live == nil {
+		writeError(w, http.StatusServiceUnavailable, "live feed not configured")
+		return
+	}
+
+	events, unsubscribe, ok := a.deps.Live.Subscribe(callSid)
+	if !ok {
+		if a.forwardToOwner(w, r, callSid) {
+			return
+		}
+		writeError(w, http.StatusNotFound, "call not active on this instance")
+		return
+	}
+	defer unsubscribe()
+
+	a.logger.Info().Str("call_sid", callSid).Msg("Supervisor joined live feed")
+	if websocket.IsWebSocketUpgrade(r) {
+		a.streamWebSocket(w, r, events)
+	} else {
+		a.streamSSE(w, r, events)
+	}
+	a.logger.Info().Str("call_sid", callSid).Msg("Supervisor left live feed")
+}
+
+// streamSSE writes events as Server-Sent Events until the call ends or the client leaves
+func (a *Server) streamSSE(w http.ResponseWriter, r *http.Request, events <-chan live.Event) {
+	rc := http.NewResponseController(w)
+	// The feed outlives the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		a.logger.Warn().Err(err).Msg("Live feed keeps the server's write timeout")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(liveKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// streamWebSocket writes events as JSON text messages until the call ends or the client leaves
+func (a *Server) streamWebSocket(w http.ResponseWriter, r *http.Request, events <-chan live.Event) {
+	conn, err := a.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		a.logger.Warn().Err(err).Msg("Live feed WebSocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	// The feed is one-way; reading detects the client going away
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				observability.RecordWebSocketClose(r.Context(), err)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(liveKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, open := <-events:
+			_ = conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if !open {
+				observability.RecordWebSocketClose(r.Context(), nil)
+				_ = conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, "call ended"))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				return
+			}
+		case <-gone:
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/rs/zerolog"
+)
+
+func newLiveServer(hub *live.Hub) *httptest.Server {
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Router: routing.NewEngine(), Live: hub}, zerolog.Nop()).Register(mux)
+	return httptest.NewServer(mux)
+}
+
+func TestServer_LiveCallNotFound(t *testing.T) {
+	server := newLiveServer(live.NewHub())
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/calls/CA404/live", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for inactive call, got %d", resp.StatusCode)
+	}
+}
+
+func TestServer_LiveCallSSE(t *testing.T) {
+	hub := live.NewHub()
+	hub.Open("CA1")
+	server := newLiveServer(hub)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/calls/CA1/live", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", ct)
+	}
+
+	hub.Publish("CA1", live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerCaller, Text: "I need a lawyer", Final: true})
+	hub.Close("CA1")
+
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if len(lines) < 2 || lines[0] != "event: transcript" || !strings.HasPrefix(lines[1], "data: ") {
+		t.Fatalf("Unexpected SSE stream: %q", lines)
+	}
+	var event live.Event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event); err != nil {
+		t.Fatalf("Invalid event JSON: %v", err)
+	}
+	if event.Text != "I need a lawyer" || !event.Final || event.CallSid != "CA1" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+}
+
+func TestServer_LiveCallWebSocketOrigin(t *testing.T) {
+	hub := live.NewHub()
+	hub.Open("CA1")
+	origins, err := cors.New([]string{"https://dashboard.lexiq.ai"}, nil)
+	if err != nil {
+		t.Fatalf("cors.New failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Router: routing.NewEngine(), Live: hub, Origins: origins}, zerolog.Nop()).Register(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/calls/CA1/live"
+	header := http.Header{"Authorization": {"Bearer secret"}, "Origin": {"https://evil.example.com"}}
+	if _, resp, err := websocket.DefaultDialer.Dial(url, header); err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 for a disallowed origin, got %v", err)
+	}
+
+	header.Set("Origin", "https://dashboard.lexiq.ai")
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("Expected allowed origin to connect, got %v", err)
+	}
+	conn.Close()
+}
+
+func TestServer_LiveCallWebSocket(t *testing.T) {
+	hub := live.NewHub()
+	hub.Open("CA1")
+	server := newLiveServer(hub)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/calls/CA1/live"
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": {"Bearer secret"}})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	hub.Publish("CA1", live.Event{Type: live.TypeTurnStarted, Speaker: live.SpeakerCaller})
+
+	var event live.Event
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	if event.Type != live.TypeTurnStarted {
+		t.Errorf("Expected turn.started, got %q", event.Type)
+	}
+
+	hub.Close("CA1")
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("Expected normal closure when the call ends, got %v", err)
+	}
+}
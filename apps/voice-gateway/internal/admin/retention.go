@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/retention"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+// downloadAudio serves a call's stored audio of kind (recordings or voicemail)
+func (a *Server) downloadAudio(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.deps.Retention == nil {
+			writeError(w, http.StatusServiceUnavailable, "storage not configured")
+			return
+		}
+		audio, err := a.deps.Retention.Open(r.Context(), kind, r.PathValue("firmID"), r.PathValue("callSid"))
+		switch {
+		case errors.Is(err, retention.ErrInvalidID):
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		case errors.Is(err, storage.ErrNotFound):
+			writeError(w, http.StatusNotFound, "no "+kind+" stored for call")
+			return
+		case err != nil:
+			writeError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		defer audio.Close()
+
+		a.logger.Info().
+			Str("firm_id", r.PathValue("firmID")).
+			Str("call_sid", r.PathValue("callSid")).
+			Str("kind", kind).
+			Str("by", actor(r)).
+			Msg("Call audio downloaded")
+		w.Header().Set("Content-Type", "audio/wav")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.Copy(w, audio)
+	}
+}
+
+// eraseCallData deletes everything held for a call, for a right-to-erasure
+// request; repeating it is safe and notifies downstream sinks again
+func (a *Server) eraseCallData(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Retention == nil {
+		writeError(w, http.StatusServiceUnavailable, "storage not configured")
+		return
+	}
+	firmID, callSid := r.PathValue("firmID"), r.PathValue("callSid")
+	erasure, err := a.deps.Retention.Erase(r.Context(), firmID, callSid)
+	switch {
+	case errors.Is(err, retention.ErrInvalidID):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case erasure == nil:
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", firmID).
+		Str("call_sid", callSid).
+		Int("objects", len(erasure.Objects)).
+		Int("callbacks", len(erasure.Callbacks)).
+		Str("by", actor(r)).
+		Msg("Call data erased")
+	if err != nil {
+		// Erased here, but downstream sinks weren't told; retrying tells them
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, erasure)
+}
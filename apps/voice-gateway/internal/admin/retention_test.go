@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/retention"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_CallAudioAndErasure(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore failed: %v", err)
+	}
+	if _, err := store.Put(context.Background(), "recordings/firm-1/CA1.wav", strings.NewReader("audio"), "audio/wav"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	engine := retention.NewEngine(store, firm.NewRegistry(), nil, nil, zerolog.Nop())
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Retention: engine}, zerolog.Nop()).Register(mux)
+
+	do := func(method, target, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/admin/firms/firm-1/calls/CA1/recording", "viewer-key"); rec.Code != http.StatusOK || rec.Body.String() != "audio" {
+		t.Errorf("Expected the recording, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodGet, "/admin/firms/firm-1/calls/CA1/voicemail", "viewer-key"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without voicemail, got %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/admin/firms/firm-1/calls/CA1/data", "operator-key"); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected operators to be refused, got %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/admin/firms/firm-1/calls/CA..1/data", "secret"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid call SID, got %d", rec.Code)
+	}
+
+	rec := do(http.MethodDelete, "/admin/firms/firm-1/calls/CA1/data", "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "recordings/firm-1/CA1.wav") {
+		t.Errorf("Expected the deleted recording in the response, got %s", rec.Body.String())
+	}
+	if _, err := store.Get(context.Background(), "recordings/firm-1/CA1.wav"); err == nil {
+		t.Error("Expected the recording to be deleted")
+	}
+}
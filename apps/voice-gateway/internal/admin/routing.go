@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/routing"
+)
+
+// overrideRequest is the body of PUT /admin/routing/overrides/{firmID}
+type overrideRequest struct {
+	Action          string    `json:"action"`
+	TransferTo      string    `json:"transfer_to,omitempty"`
+	Reason          string    `json:"reason,omitempty"`
+	ExpiresAt       time.Time `json:"expires_at,omitempty"`
+	DurationMinutes int       `json:"duration_minutes,omitempty"` // Alternative to expires_at
+}
+
+func (a *Server) listOverrides(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"overrides": a.deps.Router.Overrides(),
+	})
+}
+
+func (a *Server) putOverride(w http.ResponseWriter, r *http.Request) {
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+
+	override := routing.Override{
+		FirmID:     r.PathValue("firmID"),
+		Action:     req.Action,
+		TransferTo: req.TransferTo,
+		Reason:     req.Reason,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if req.DurationMinutes > 0 {
+		override.ExpiresAt = time.Now().UTC().Add(time.Duration(req.DurationMinutes) * time.Minute)
+	}
+
+	if err := a.deps.Router.SetOverride(override); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", override.FirmID).
+		Str("action", override.Action).
+		Str("by", actor(r)).
+		Time("expires_at", override.ExpiresAt).
+		Msg("Routing override set")
+	writeJSON(w, http.StatusOK, override)
+}
+
+func (a *Server) deleteOverride(w http.ResponseWriter, r *http.Request) {
+	firmID := r.PathValue("firmID")
+	if !a.deps.Router.ClearOverride(firmID) {
+		writeError(w, http.StatusNotFound, "no override for firm")
+		return
+	}
+
+	a.logger.Info().Str("firm_id", firmID).Str("by", actor(r)).Msg("Routing override cleared")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/audit"
+	"github.com/lexiqai/voice-gateway/internal/auth"
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+	"github.com/lexiqai/voice-gateway/internal/retention"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+	"github.com/rs/zerolog"
+)
+
+// Dependencies are the components the admin API operates on
+type Dependencies struct {
+	Firms  *firm.Registry
+	Router *routing.Engine
+	SMS    *sms.Sender // nil when Twilio is not configured
+	Live   *live.Hub   // Real-time call events for supervisors
+	Calls  *telephony.CallRegistry
+
+	// Cluster locates calls on other instances; nil serves only local calls
+	Cluster cluster.Registry
+	// Breakers are the provider circuit breakers listed on /admin/breakers
+	Breakers *resilience.BreakerRegistry
+
+	// Usage totals billable usage per firm; nil disables /admin/usage
+	Usage *usage.Ledger
+
+	// Voices clones firm voices with the TTS provider; nil disables cloning
+	Voices *tts.CartesiaVoices
+
+	// Campaigns runs outbound calling campaigns; nil when Twilio or
+	// VOICE_GATEWAY_URL is not configured
+	Campaigns *campaign.Runner
+
+	// Callbacks lists and cancels callbacks the assistant scheduled; nil
+	// when outbound calling is not configured
+	Callbacks *callback.Scheduler
+
+	// Retention serves and erases a call's stored audio; nil disables
+	// downloads and erasure
+	Retention *retention.Engine
+
+	// Audit records every admin call, live view and download; nil disables
+	// auditing and /admin/audit
+	Audit *audit.Log
+
+	// Origins decides which dashboard origins may open live and supervise
+	// WebSockets; nil allows same-origin browsers only
+	Origins *cors.Policy
+}
+
+// Server exposes operator endpoints under /admin/, plus live call feeds under /calls/
+type Server struct {
+	authn    *auth.Authenticator
+	deps     Dependencies
+	upgrader websocket.Upgrader
+	logger   zerolog.Logger
+}
+
+// NewServer creates an admin API server whose callers authn authenticates
+func NewServer(authn *auth.Authenticator, deps Dependencies, logger zerolog.Logger) *Server {
+	return &Server{
+		authn:    authn,
+		deps:     deps,
+		upgrader: websocket.Upgrader{CheckOrigin: deps.Origins.CheckOrigin},
+		logger:   logger.With().Str("component", "admin").Logger(),
+	}
+}
+
+// Register mounts the admin routes on mux: viewers read, operators act on
+// calls, admins change configuration
+func (a *Server) Register(mux *http.ServeMux) {
+	mux.Handle("GET /admin/routing/overrides", a.require(auth.RoleViewer, audit.KindAdminRead, a.listOverrides))
+	mux.Handle("PUT /admin/routing/overrides/{firmID}", a.require(auth.RoleAdmin, audit.KindConfigChange, a.putOverride))
+	mux.Handle("DELETE /admin/routing/overrides/{firmID}", a.require(auth.RoleAdmin, audit.KindConfigChange, a.deleteOverride))
+	mux.Handle("POST /admin/sms", a.require(auth.RoleOperator, audit.KindAdminAction, a.sendSMS))
+	mux.Handle("GET /admin/calls", a.require(auth.RoleViewer, audit.KindAdminRead, a.listCalls))
+	mux.Handle("GET /admin/breakers", a.require(auth.RoleViewer, audit.KindAdminRead, a.listBreakers))
+	mux.Handle("GET /admin/usage", a.require(auth.RoleViewer, audit.KindAdminRead, a.listUsage))
+	mux.Handle("GET /admin/usage/{firmID}", a.require(auth.RoleViewer, audit.KindAdminRead, a.getUsage))
+	mux.Handle("GET /admin/firms/{firmID}/voices", a.require(auth.RoleViewer, audit.KindAdminRead, a.listVoices))
+	mux.Handle("POST /admin/firms/{firmID}/voices", a.require(auth.RoleAdmin, audit.KindConfigChange, a.cloneVoice))
+	mux.Handle("GET /admin/firms/{firmID}/dnc", a.require(auth.RoleViewer, audit.KindAdminRead, a.listDoNotCall))
+	mux.Handle("PUT /admin/firms/{firmID}/dnc/{number}", a.require(auth.RoleAdmin, audit.KindConfigChange, a.putDoNotCall))
+	mux.Handle("DELETE /admin/firms/{firmID}/dnc/{number}", a.require(auth.RoleAdmin, audit.KindConfigChange, a.deleteDoNotCall))
+	mux.Handle("POST /admin/campaigns", a.require(auth.RoleOperator, audit.KindAdminAction, a.startCampaign))
+	mux.Handle("GET /admin/campaigns", a.require(auth.RoleViewer, audit.KindAdminRead, a.listCampaigns))
+	mux.Handle("GET /admin/campaigns/{id}", a.require(auth.RoleViewer, audit.KindAdminRead, a.getCampaign))
+	mux.Handle("DELETE /admin/campaigns/{id}", a.require(auth.RoleOperator, audit.KindAdminAction, a.cancelCampaign))
+	mux.Handle("GET /admin/callbacks", a.require(auth.RoleViewer, audit.KindAdminRead, a.listCallbacks))
+	mux.Handle("DELETE /admin/callbacks/{id}", a.require(auth.RoleOperator, audit.KindAdminAction, a.cancelCallback))
+	mux.Handle("GET /admin/firms/{firmID}/calls/{callSid}/recording", a.require(auth.RoleViewer, audit.KindRecordingDownload, a.downloadAudio(firm.DataRecordings)))
+	mux.Handle("GET /admin/firms/{firmID}/calls/{callSid}/voicemail", a.require(auth.RoleViewer, audit.KindRecordingDownload, a.downloadAudio(firm.DataVoicemail)))
+	mux.Handle("DELETE /admin/firms/{firmID}/calls/{callSid}/data", a.require(auth.RoleAdmin, audit.KindDataErasure, a.eraseCallData))
+	mux.Handle("GET /admin/audit", a.require(auth.RoleAdmin, audit.KindAdminRead, a.listAudit))
+	mux.Handle("GET /calls/{callSid}/live", a.require(auth.RoleViewer, audit.KindLiveView, a.liveCall))
+	mux.Handle("GET /calls/{callSid}/supervise", a.require(auth.RoleOperator, audit.KindLiveView, a.superviseCall))
+}
+
+// require allows callers with at least role, from "Authorization: Bearer <api
+// key or JWT>", and audits each call as kind
+func (a *Server) require(role auth.Role, kind string, next http.HandlerFunc) http.Handler {
+	return a.audited(kind, a.authn.Require(role, next))
+}
+
+// actor names the authenticated caller, for audit logs
+func actor(r *http.Request) string {
+	principal, _ := auth.FromContext(r.Context())
+	return principal.Subject
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError sends a JSON error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/auth"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+	"github.com/rs/zerolog"
+)
+
+// testAuth accepts "Bearer secret" as an admin, plus a viewer and an operator key
+var testAuth, _ = auth.New(auth.Options{APIKeys: []auth.APIKey{
+	{Name: "test-admin", Role: auth.RoleAdmin, Key: "secret"},
+	{Name: "test-viewer", Role: auth.RoleViewer, Key: "viewer-key"},
+	{Name: "test-operator", Role: auth.RoleOperator, Key: "operator-key"},
+}})
+
+func newTestMux(router *routing.Engine) *http.ServeMux {
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Router: router}, zerolog.Nop()).Register(mux)
+	return mux
+}
+
+func TestServer_RequiresAPIKey(t *testing.T) {
+	mux := newTestMux(routing.NewEngine())
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/routing/overrides", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without key, got %d", rec.Code)
+	}
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with wrong key, got %d", rec.Code)
+	}
+}
+
+func TestServer_RoutingOverride(t *testing.T) {
+	router := routing.NewEngine()
+	mux := newTestMux(router)
+
+	body := `{"action":"voicemail","reason":"staff meeting","duration_minutes":30}`
+	req := httptest.NewRequest(http.MethodPut, "/admin/routing/overrides/firm-1", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	overrides := router.Overrides()
+	if len(overrides) != 1 || overrides[0].FirmID != "firm-1" || overrides[0].ExpiresAt.IsZero() {
+		t.Fatalf("Expected expiring override for firm-1, got %+v", overrides)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/routing/overrides/firm-1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", rec.Code)
+	}
+	if len(router.Overrides()) != 0 {
+		t.Error("Expected override to be removed")
+	}
+}
+
+func TestServer_RejectsInvalidOverride(t *testing.T) {
+	mux := newTestMux(routing.NewEngine())
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/routing/overrides/firm-1", strings.NewReader(`{"action":"transfer"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for transfer without number, got %d", rec.Code)
+	}
+}
+
+type fakeSMSTransport struct{}
+
+func (fakeSMSTransport) SendSMS(ctx context.Context, from, to, body string) (string, error) {
+	return "SM123", nil
+}
+
+func TestServer_SendSMS(t *testing.T) {
+	firms := firm.NewRegistry()
+	mux := http.NewServeMux()
+	deps := Dependencies{Firms: firms, Router: routing.NewEngine(), SMS: sms.NewSender(fakeSMSTransport{})}
+	NewServer(testAuth, deps, zerolog.Nop()).Register(mux)
+
+	body := `{"firm_id":"firm-1","from":"+15550001111","to":"+15551234567","template":"intake_link","data":{"link":"https://example.com/intake"}}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/sms", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "https://example.com/intake") {
+		t.Errorf("Expected rendered body in response, got %s", rec.Body.String())
+	}
+}
+
+func TestServer_SendSMSNotConfigured(t *testing.T) {
+	mux := newTestMux(routing.NewEngine())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/sms", strings.NewReader(`{"firm_id":"firm-1"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", rec.Code)
+	}
+}
+
+func TestServer_RoleScopes(t *testing.T) {
+	mux := newTestMux(routing.NewEngine())
+
+	tests := []struct {
+		method string
+		path   string
+		key    string
+		want   int
+	}{
+		{http.MethodGet, "/admin/routing/overrides", "viewer-key", http.StatusOK},
+		{http.MethodPut, "/admin/routing/overrides/firm-1", "viewer-key", http.StatusForbidden},
+		{http.MethodPut, "/admin/routing/overrides/firm-1", "operator-key", http.StatusForbidden},
+		{http.MethodDelete, "/admin/routing/overrides/firm-1", "secret", http.StatusNotFound},
+		{http.MethodPost, "/admin/sms", "viewer-key", http.StatusForbidden},
+		{http.MethodGet, "/calls/CA1/supervise", "viewer-key", http.StatusForbidden},
+		{http.MethodGet, "/admin/calls", "wrong-key", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer "+tt.key)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s as %s: expected %d, got %d", tt.method, tt.path, tt.key, tt.want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/sms"
+)
+
+// sendSMS sends a follow-up text on behalf of a firm (e.g. after the call)
+func (a *Server) sendSMS(w http.ResponseWriter, r *http.Request) {
+	if a.deps.SMS == nil {
+		writeError(w, http.StatusServiceUnavailable, "sms not configured")
+		return
+	}
+
+	var req sms.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	if req.FirmID == "" {
+		writeError(w, http.StatusBadRequest, "firm_id is required")
+		return
+	}
+
+	result, err := a.deps.SMS.Send(r.Context(), a.deps.Firms.Get(req.FirmID), req)
+	switch {
+	case errors.Is(err, sms.ErrRateLimited):
+		writeError(w, http.StatusTooManyRequests, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", req.FirmID).
+		Str("template", req.Template).
+		Str("message_sid", result.SID).
+		Str("by", actor(r)).
+		Msg("SMS sent")
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+)
+
+// SupervisorMessage is exchanged with the operator over the supervise WebSocket
+//
+// Server to operator:
+//
+//	{"event":"media","track":"inbound|outbound","payload":"<base64 PCMU>"}
+//	{"event":"mode","mode":"listen|whisper|takeover"}
+//	{"event":"error","message":"..."}
+//
+// Operator to server:
+//
+//	{"event":"mode","mode":"takeover"}
+//	{"event":"whisper","text":"Offer a consultation next Tuesday"}
+//	{"event":"media","payload":"<base64 PCMU>"} (takeover only)
+type SupervisorMessage struct {
+	Event   string `json:"event"`
+	Track   string `json:"track,omitempty"`
+	Payload string `json:"payload,omitempty"`
+	Mode    string `json:"mode,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// superviseCall joins an operator to a live call over WebSocket
+// The initial mode comes from ?mode= (default listen)
+func (a *Server) superviseCall(w http.ResponseWriter, r *http.Request) {
+	callSid := r.PathValue("callSid")
+	if a.deps.Calls == nil {
+		writeError(w, http.StatusServiceUnavailable, "supervision not configured")
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(r) {
+		writeError(w, http.StatusBadRequest, "WebSocket upgrade required")
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = telephony.SupervisorListen
+	}
+	if !telephony.ValidSupervisorMode(mode) {
+		writeError(w, http.StatusBadRequest, "mode must be listen, whisper, or takeover")
+		return
+	}
+
+	leg, err := a.deps.Calls.Supervise(callSid, mode)
+	switch {
+	case errors.Is(err, telephony.ErrCallNotFound):
+		if a.forwardToOwner(w, r, callSid) {
+			return
+		}
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, telephony.ErrSupervised):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer leg.Close()
+
+	conn, err := a.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		a.logger.Warn().Err(err).Msg("Supervisor WebSocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	logger := a.logger.With().Str("call_sid", callSid).Logger()
+	logger.Info().Str("mode", mode).Str("by", actor(r)).Msg("Supervisor connected")
+
+	var writeMu sync.Mutex
+	send := func(msg SupervisorMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_ = conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+		return conn.WriteJSON(msg)
+	}
+	_ = send(SupervisorMessage{Event: "mode", Mode: leg.Mode()})
+
+	// Operator commands
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			var msg SupervisorMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				observability.RecordWebSocketClose(r.Context(), err)
+				return
+			}
+
+			var err error
+			switch msg.Event {
+			case "mode":
+				if err = leg.SetMode(msg.Mode); err == nil {
+					err = send(SupervisorMessage{Event: "mode", Mode: msg.Mode})
+				}
+			case "whisper":
+				err = leg.Whisper(msg.Text)
+			case "media":
+				var audio []byte
+				if audio, err = base64.StdEncoding.DecodeString(msg.Payload); err == nil {
+					err = leg.Speak(audio)
+				}
+			default:
+				err = errors.New("unknown event " + msg.Event)
+			}
+			if errors.Is(err, telephony.ErrCallNotFound) {
+				return
+			}
+			if err != nil {
+				_ = send(SupervisorMessage{Event: "error", Message: err.Error()})
+			}
+		}
+	}()
+
+	// Call audio to the operator, until the call ends or they hang up
+	for {
+		select {
+		case frame, open := <-leg.Frames():
+			if !open {
+				observability.RecordWebSocketClose(r.Context(), nil)
+				writeMu.Lock()
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, "call ended"),
+					time.Now().Add(liveWriteTimeout))
+				writeMu.Unlock()
+				logger.Info().Msg("Supervisor disconnected: call ended")
+				return
+			}
+			msg := SupervisorMessage{
+				Event:   "media",
+				Track:   frame.Track,
+				Payload: base64.StdEncoding.EncodeToString(frame.Audio),
+			}
+			if err := send(msg); err != nil {
+				return
+			}
+		case <-gone:
+			logger.Info().Msg("Supervisor disconnected")
+			return
+		}
+	}
+}
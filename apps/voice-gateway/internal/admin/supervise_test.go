@@ -0,0 +1,48 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_SuperviseCall(t *testing.T) {
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{
+		Firms:  firm.NewRegistry(),
+		Router: routing.NewEngine(),
+		Calls:  telephony.NewCallRegistry(),
+	}, zerolog.Nop()).Register(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Plain HTTP is rejected
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/calls/CA1/supervise", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 without WebSocket upgrade, got %d", resp.StatusCode)
+	}
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/calls/CA1/supervise?mode=whisper"
+	header := http.Header{"Authorization": {"Bearer secret"}}
+	if _, resp, err := websocket.DefaultDialer.Dial(url, header); err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for inactive call, got %v", err)
+	}
+
+	url = "ws" + strings.TrimPrefix(server.URL, "http") + "/calls/CA1/supervise?mode=shout"
+	if _, resp, err := websocket.DefaultDialer.Dial(url, header); err == nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown mode, got %v", err)
+	}
+}
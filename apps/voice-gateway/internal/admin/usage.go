@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/usage"
+)
+
+// listUsage reports every firm's billable usage since the instance started
+func (a *Server) listUsage(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Usage == nil {
+		writeError(w, http.StatusServiceUnavailable, "usage metering not configured")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"since": a.deps.Usage.Since(),
+		"firms": a.deps.Usage.All(),
+	})
+}
+
+// getUsage reports one firm's billable usage; firms without calls report zeros
+func (a *Server) getUsage(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Usage == nil {
+		writeError(w, http.StatusServiceUnavailable, "usage metering not configured")
+		return
+	}
+	total, _ := a.deps.Usage.Firm(r.PathValue("firmID"))
+	writeJSON(w, http.StatusOK, struct {
+		usage.FirmUsage
+		Since time.Time `json:"since"`
+	}{total, a.deps.Usage.Since()})
+}
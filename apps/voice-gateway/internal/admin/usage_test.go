@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_Usage(t *testing.T) {
+	ledger := usage.NewLedger()
+	ledger.Add(context.Background(), "firm-b", cdr.Usage{STTSeconds: 30, TTSCharacters: 120, OrchestratorTokens: 400, TelephonyMinutes: 1})
+	ledger.Add(context.Background(), "firm-a", cdr.Usage{STTSeconds: 10, TelephonyMinutes: 1})
+	ledger.Add(context.Background(), "firm-b", cdr.Usage{STTSeconds: 15, TTSCharacters: 80, OrchestratorTokens: 100, TelephonyMinutes: 2})
+
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{Firms: firm.NewRegistry(), Usage: ledger}, zerolog.Nop()).Register(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d: %s", path, rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+
+	var all struct {
+		Firms []usage.FirmUsage `json:"firms"`
+	}
+	if err := json.NewDecoder(get("/admin/usage").Body).Decode(&all); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(all.Firms) != 2 || all.Firms[0].FirmID != "firm-a" || all.Firms[1].FirmID != "firm-b" {
+		t.Fatalf("Expected firm-a and firm-b, got %+v", all.Firms)
+	}
+
+	var one usage.FirmUsage
+	if err := json.NewDecoder(get("/admin/usage/firm-b").Body).Decode(&one); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if one.Calls != 2 || one.STTSeconds != 45 || one.TTSCharacters != 200 || one.OrchestratorTokens != 500 || one.TelephonyMinutes != 3 {
+		t.Errorf("Expected firm-b totals over 2 calls, got %+v", one)
+	}
+
+	var unknown usage.FirmUsage
+	if err := json.NewDecoder(get("/admin/usage/firm-z").Body).Decode(&unknown); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if unknown.FirmID != "firm-z" || unknown.Calls != 0 {
+		t.Errorf("Expected zero usage for firm-z, got %+v", unknown)
+	}
+}
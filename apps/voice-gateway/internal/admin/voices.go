@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// maxVoiceSampleBytes bounds an uploaded voice sample
+const maxVoiceSampleBytes = 20 << 20
+
+// voiceCloneTimeout bounds the provider's cloning of a voice
+const voiceCloneTimeout = 60 * time.Second
+
+// listVoices reports the TTS voices a firm's calls use, by language
+func (a *Server) listVoices(w http.ResponseWriter, r *http.Request) {
+	firmID := r.PathValue("firmID")
+	voices := a.deps.Firms.Get(firmID).Voices
+	if voices == nil {
+		voices = map[string]string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"firm_id": firmID,
+		"voices":  voices,
+	})
+}
+
+// cloneVoice clones a firm's receptionist voice from an uploaded sample and
+// stores it in the firm's config as the voice for its language
+//
+// Multipart form: sample (audio file), name, language (default en), description
+func (a *Server) cloneVoice(w http.ResponseWriter, r *http.Request) {
+	if a.deps.Voices == nil {
+		writeError(w, http.StatusServiceUnavailable, "voice cloning not configured")
+		return
+	}
+	firmID := r.PathValue("firmID")
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxVoiceSampleBytes)
+	if err := r.ParseMultipartForm(maxVoiceSampleBytes); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid multipart body: "+err.Error())
+		return
+	}
+	sample, header, err := r.FormFile("sample")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "sample file is required")
+		return
+	}
+	defer sample.Close()
+
+	name := r.FormValue("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	language := r.FormValue("language")
+	if language == "" {
+		language = "en"
+	}
+	if i18n.Base(language) == "" {
+		writeError(w, http.StatusBadRequest, "invalid language")
+		return
+	}
+
+	// Firms with their own Cartesia account get the voice there
+	var apiKey string
+	if credential := a.deps.Firms.Get(firmID).Providers.Cartesia; credential.IsSet() {
+		if apiKey, err = credential.Resolve(); err != nil {
+			a.logger.Error().Err(err).Str("firm_id", firmID).Msg("Firm Cartesia credential unavailable for voice cloning")
+			writeError(w, http.StatusInternalServerError, "firm cartesia credential unavailable")
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), voiceCloneTimeout)
+	defer cancel()
+	voice, err := a.deps.Voices.Clone(ctx, apiKey, tts.CloneRequest{
+		Name:        name,
+		Language:    i18n.Base(language),
+		Description: r.FormValue("description"),
+		Filename:    header.Filename,
+		Sample:      sample,
+	})
+	if err != nil {
+		a.logger.Warn().Err(err).Str("firm_id", firmID).Msg("Voice cloning failed")
+		writeError(w, http.StatusBadGateway, "voice cloning failed: "+err.Error())
+		return
+	}
+
+	if err := a.deps.Firms.SetVoice(firmID, language, voice.ID); err != nil {
+		// The voice exists on the account; report it so it can be set by hand
+		a.logger.Error().Err(err).Str("firm_id", firmID).Str("voice_id", voice.ID).Msg("Cloned voice not saved to firm config")
+		status := http.StatusInternalServerError
+		if errors.Is(err, firm.ErrNoConfigFile) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, "voice "+voice.ID+" cloned but not saved: "+err.Error())
+		return
+	}
+
+	a.logger.Info().
+		Str("firm_id", firmID).
+		Str("language", i18n.Base(language)).
+		Str("voice_id", voice.ID).
+		Str("by", actor(r)).
+		Msg("Firm voice cloned")
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"firm_id":  firmID,
+		"language": i18n.Base(language),
+		"voice":    voice,
+	})
+}
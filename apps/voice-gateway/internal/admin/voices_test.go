@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+	"github.com/rs/zerolog"
+)
+
+func TestServer_CloneVoice(t *testing.T) {
+	var gotKey, gotName string
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/voices/clone" {
+			http.NotFound(w, r)
+			return
+		}
+		gotKey = r.Header.Get("X-API-Key")
+		gotName = r.FormValue("name")
+		if _, _, err := r.FormFile("clip"); err != nil {
+			http.Error(w, "clip required", http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, tts.Voice{ID: "voice-123", Name: gotName, Language: r.FormValue("language")})
+	}))
+	defer provider.Close()
+
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(`{"firms": {"firm-1": {}}}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{
+		Firms:  firms,
+		Voices: tts.NewCartesiaVoices("shared-key", provider.URL+"/voices"),
+	}, zerolog.Nop()).Register(mux)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	sample, _ := form.CreateFormFile("sample", "receptionist.wav")
+	sample.Write([]byte("RIFF...."))
+	form.WriteField("name", "Acme Receptionist")
+	form.WriteField("language", "en-US")
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/firms/firm-1/voices", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer viewer-key")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a viewer, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/firms/firm-1/voices", bytes.NewReader(body.Bytes()))
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if gotKey != "shared-key" || gotName != "Acme Receptionist" {
+		t.Errorf("Expected the sample cloned on the shared account, got key %q name %q", gotKey, gotName)
+	}
+
+	// The voice is stored for the firm and listed
+	if voice := firms.Get("firm-1").Voices["en"]; voice != "voice-123" {
+		t.Errorf("Expected voice-123 in the firm's config, got %q", voice)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/admin/firms/firm-1/voices", nil)
+	req.Header.Set("Authorization", "Bearer viewer-key")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var listed struct {
+		Voices map[string]string `json:"voices"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if listed.Voices["en"] != "voice-123" {
+		t.Errorf("Expected the cloned voice listed, got %v", listed.Voices)
+	}
+}
+
+func TestServer_CloneVoiceRequiresSample(t *testing.T) {
+	mux := http.NewServeMux()
+	NewServer(testAuth, Dependencies{
+		Firms:  firm.NewRegistry(),
+		Voices: tts.NewCartesiaVoices("shared-key", "http://127.0.0.1:0"),
+	}, zerolog.Nop()).Register(mux)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("name", "Acme Receptionist")
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/firms/firm-1/voices", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a sample, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,96 @@
+package audio
+
+import "math"
+
+const (
+	// AGCNoiseFloorRMS is the level below which a frame is taken as line
+	// noise rather than speech, and left out of the running speech level
+	AGCNoiseFloorRMS = 100.0
+
+	// agcSmoothing is how far the gain moves toward its goal each frame, so
+	// it rises and falls over a few hundred milliseconds instead of pumping
+	agcSmoothing = 0.05
+
+	// agcPeak is the loudest sample boosted audio may reach, leaving headroom
+	// below full scale
+	agcPeak = 29000
+)
+
+// AGCConfig holds configuration for automatic gain control
+type AGCConfig struct {
+	TargetRMS    float64 // Speech level the gain aims for
+	QuietRMS     float64 // Average speech level below which gain engages
+	MaxGain      float64 // Largest boost applied
+	WindowFrames int     // Speech frames the running level averages over
+}
+
+// DefaultAGCConfig returns a default gain configuration
+func DefaultAGCConfig() *AGCConfig {
+	return &AGCConfig{
+		TargetRMS:    2000,
+		QuietRMS:     1000,
+		MaxGain:      4,
+		WindowFrames: 100, // 2s of speech at 20ms frames
+	}
+}
+
+// AGC boosts callers who are persistently quiet (speakerphones, cars). It
+// keeps a running average of the speech level, and once a full window of
+// speech has averaged below QuietRMS it raises the gain toward TargetRMS.
+// Boosted frames are limited with NormalizeAudio so peaks never clip
+type AGC struct {
+	config *AGCConfig
+	level  float64 // Running average speech level (RMS)
+	frames int     // Speech frames seen, up to WindowFrames
+	gain   float64
+}
+
+// NewAGC creates a new gain control
+func NewAGC(config *AGCConfig) *AGC {
+	if config == nil {
+		config = DefaultAGCConfig()
+	}
+	return &AGC{config: config, gain: 1}
+}
+
+// Process applies the gain to a frame in place, both its samples and their
+// PCMU encoding, and returns the frame's level before and after
+func (a *AGC) Process(samples []int16, pcmu []byte) (before, after float64) {
+	before = CalculateRMS(samples)
+	if before >= AGCNoiseFloorRMS {
+		if a.frames < a.config.WindowFrames {
+			a.frames++
+		}
+		a.level += (before - a.level) / float64(a.frames)
+	}
+
+	goal := 1.0
+	if a.frames >= a.config.WindowFrames && a.level < a.config.QuietRMS {
+		goal = math.Min(a.config.TargetRMS/a.level, a.config.MaxGain)
+	}
+	a.gain += (goal - a.gain) * agcSmoothing
+	if math.Abs(a.gain-1) < 0.01 {
+		return before, before
+	}
+
+	for i, sample := range samples {
+		samples[i] = int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, float64(sample)*a.gain)))
+	}
+	copy(samples, NormalizeAudio(samples, agcPeak))
+	for i, sample := range samples[:min(len(samples), len(pcmu))] {
+		pcmu[i] = linearToMulaw(sample)
+	}
+	return before, CalculateRMS(samples)
+}
+
+// Gain returns the gain currently applied
+func (a *AGC) Gain() float64 {
+	return a.gain
+}
+
+// Reset clears the running level and gain
+func (a *AGC) Reset() {
+	a.level = 0
+	a.frames = 0
+	a.gain = 1
+}
@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+// tone returns a 20ms frame of a 400Hz tone at amplitude, with its PCMU
+func tone(amplitude float64) ([]int16, []byte) {
+	samples := make([]int16, 160)
+	for i := range samples {
+		samples[i] = int16(amplitude * math.Sin(2*math.Pi*400*float64(i)/8000))
+	}
+	return samples, EncodeSamplesToPCMU(samples, 8000, 8000)
+}
+
+func TestAGC_BoostsPersistentlyQuietSpeech(t *testing.T) {
+	a := NewAGC(&AGCConfig{TargetRMS: 2000, QuietRMS: 1000, MaxGain: 4, WindowFrames: 10})
+
+	// No gain until a full window of speech has been heard
+	samples, pcmu := tone(700) // RMS ~495
+	if before, after := a.Process(samples, pcmu); before != after {
+		t.Errorf("Expected no gain before the window fills, got %.0f -> %.0f", before, after)
+	}
+
+	var before, after float64
+	for i := 0; i < 200; i++ {
+		samples, pcmu = tone(700)
+		before, after = a.Process(samples, pcmu)
+	}
+	if gain := a.Gain(); math.Abs(gain-4) > 0.1 {
+		t.Errorf("Expected the gain capped at 4, got %.2f", gain)
+	}
+	if after < before*3.5 {
+		t.Errorf("Expected boosted speech, got %.0f -> %.0f", before, after)
+	}
+
+	// The PCMU is re-encoded with the gain
+	if decoded := CalculateRMS(DecodePCMU(pcmu)); math.Abs(decoded-after)/after > 0.05 {
+		t.Errorf("Expected PCMU at the boosted level %.0f, decoded %.0f", after, decoded)
+	}
+}
+
+func TestAGC_LeavesNormalSpeechAndNoise(t *testing.T) {
+	a := NewAGC(&AGCConfig{TargetRMS: 2000, QuietRMS: 1000, MaxGain: 4, WindowFrames: 10})
+	for i := 0; i < 50; i++ {
+		samples, pcmu := tone(3000)
+		a.Process(samples, pcmu)
+		// Line noise between words does not drag the level down
+		samples, pcmu = tone(50)
+		a.Process(samples, pcmu)
+	}
+	if gain := a.Gain(); gain != 1 {
+		t.Errorf("Expected no gain for normal speech, got %.2f", gain)
+	}
+}
+
+func TestAGC_LimitsPeaks(t *testing.T) {
+	a := NewAGC(&AGCConfig{TargetRMS: 16000, QuietRMS: 16000, MaxGain: 16, WindowFrames: 1})
+	for i := 0; i < 100; i++ {
+		samples, pcmu := tone(8000)
+		a.Process(samples, pcmu)
+		for _, sample := range samples {
+			if sample > agcPeak || sample < -agcPeak {
+				t.Fatalf("Expected peaks limited to %d, got %d", agcPeak, sample)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+)
+
+// Frames as the gateway sees them: Twilio sends 20ms of 8kHz PCMU (160 bytes)
+// and Cartesia returns 24kHz 16-bit PCM (480 samples per 20ms)
+const (
+	ttsFrameSamples = 480
+	frameMs         = 20
+)
+
+// speechSamples returns n samples of a voiced, speech-level signal at rate
+func speechSamples(n, rate int) []int16 {
+	samples := make([]int16, n)
+	for i := range samples {
+		t := float64(i) / float64(rate)
+		v := 6000*math.Sin(2*math.Pi*180*t) + 2500*math.Sin(2*math.Pi*720*t) + 800*math.Sin(2*math.Pi*2400*t)
+		samples[i] = int16(v)
+	}
+	return samples
+}
+
+func pcmBytes(samples []int16) []byte {
+	pcm := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(s))
+	}
+	return pcm
+}
+
+var (
+	callerFrame = EncodeSamplesToPCMU(speechSamples(FrameSize, 8000), 8000, 8000)
+	ttsFrame    = pcmBytes(speechSamples(ttsFrameSamples, 24000))
+)
+
+// BenchmarkMulawEncode encodes one 20ms frame of 8kHz samples
+func BenchmarkMulawEncode(b *testing.B) {
+	samples := speechSamples(FrameSize, 8000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = EncodeSamplesToPCMU(samples, 8000, 8000)
+	}
+}
+
+// BenchmarkMulawDecode decodes one inbound Twilio frame into reused samples
+func BenchmarkMulawDecode(b *testing.B) {
+	var samples []int16
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		samples = DecodePCMUInto(samples, callerFrame)
+	}
+}
+
+// BenchmarkResample24kTo8k resamples one 20ms frame of TTS output
+func BenchmarkResample24kTo8k(b *testing.B) {
+	samples := speechSamples(ttsFrameSamples, 24000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = resample(samples, 24000, 8000)
+	}
+}
+
+// BenchmarkConvertTTSFrame converts one 20ms frame of TTS PCM to PCMU in one shot
+func BenchmarkConvertTTSFrame(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertPCMToPCMU(ttsFrame, 24000, 8000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStreamingConvertTTSFrame converts TTS PCM frame by frame
+func BenchmarkStreamingConvertTTSFrame(b *testing.B) {
+	c, err := NewStreamingConverter(24000, 8000)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = c.Convert(ttsFrame)
+	}
+}
+
+// BenchmarkVADFrame runs the VAD over one inbound frame, as detectSpeech does
+func BenchmarkVADFrame(b *testing.B) {
+	vad := NewVADDetector(DefaultVADConfig())
+	var samples []int16
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		samples = DecodePCMUInto(samples, callerFrame)
+		vad.ProcessFrame(samples)
+	}
+}
+
+// BenchmarkRingBufferFrame writes and reads back one outbound frame
+func BenchmarkRingBufferFrame(b *testing.B) {
+	rb := NewRingBuffer(32 * FrameSize)
+	out := make([]byte, FrameSize)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rb.Write(callerFrame)
+		rb.Read(out)
+	}
+}
+
+// BenchmarkSuppressorSilence withholds a silent frame into the pre-roll
+func BenchmarkSuppressorSilence(b *testing.B) {
+	s := NewSilenceSuppressor(DefaultSuppressorConfig())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Process(callerFrame, false)
+	}
+}
+
+// frameBudgets are the most each step may cost per 20ms frame; see PERFORMANCE.md
+var frameBudgets = []struct {
+	name      string
+	bench     func(*testing.B)
+	maxNs     int64
+	maxAllocs int64
+}{
+	{"MulawEncode", BenchmarkMulawEncode, 2500, 1},
+	{"MulawDecode", BenchmarkMulawDecode, 1000, 0},
+	{"Resample24kTo8k", BenchmarkResample24kTo8k, 2500, 1},
+	{"ConvertTTSFrame", BenchmarkConvertTTSFrame, 7500, 3},
+	{"StreamingConvertTTSFrame", BenchmarkStreamingConvertTTSFrame, 8000, 3},
+	{"VADFrame", BenchmarkVADFrame, 1500, 0},
+	{"RingBufferFrame", BenchmarkRingBufferFrame, 10000, 0},
+	{"SuppressorSilence", BenchmarkSuppressorSilence, 100, 0},
+}
+
+// TestFrameBudgets fails when a hot-path step exceeds its per-frame budget
+// It runs every benchmark for about a second and timing depends on the
+// machine, so it only runs with AUDIO_PERF_BUDGET=1 (make voice-perf-budget)
+func TestFrameBudgets(t *testing.T) {
+	if os.Getenv("AUDIO_PERF_BUDGET") != "1" {
+		t.Skip("set AUDIO_PERF_BUDGET=1 to check performance budgets")
+	}
+	for _, budget := range frameBudgets {
+		result := testing.Benchmark(budget.bench)
+		if allocs := result.AllocsPerOp(); allocs > budget.maxAllocs {
+			t.Errorf("%s: %d allocs/frame, budget %d", budget.name, allocs, budget.maxAllocs)
+		}
+		if ns := result.NsPerOp(); ns > budget.maxNs {
+			t.Errorf("%s: %d ns/frame, budget %d", budget.name, ns, budget.maxNs)
+		}
+		t.Logf("%s: %d ns/frame, %d allocs/frame", budget.name, result.NsPerOp(), result.AllocsPerOp())
+	}
+}
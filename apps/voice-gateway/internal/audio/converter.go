@@ -39,6 +39,16 @@ func ConvertPCMToPCMU(pcmData []byte, inputSampleRate, outputSampleRate int) ([]
 	return pcmuData, nil
 }
 
+// EncodeSamplesToPCMU resamples linear samples and encodes them as PCMU (μ-law)
+func EncodeSamplesToPCMU(samples []int16, inputSampleRate, outputSampleRate int) []byte {
+	samples = resample(samples, inputSampleRate, outputSampleRate)
+	pcmuData := make([]byte, len(samples))
+	for i, sample := range samples {
+		pcmuData[i] = linearToMulaw(sample)
+	}
+	return pcmuData
+}
+
 // resample performs simple linear interpolation resampling
 // This is a basic implementation - for production, consider using a library
 // with better quality algorithms (e.g., sinc interpolation)
@@ -146,6 +156,16 @@ func ConvertPCMUToPCM(pcmuData []byte) ([]byte, error) {
 	return pcmData, nil
 }
 
+// DecodePCMU decodes G.711 PCMU (μ-law) bytes into 16-bit linear samples
+// This is the form expected by the VAD and energy helpers
+func DecodePCMU(pcmuData []byte) []int16 {
+	samples := make([]int16, len(pcmuData))
+	for i, mulawByte := range pcmuData {
+		samples[i] = mulawToLinear(mulawByte)
+	}
+	return samples
+}
+
 // mulawToLinear converts an 8-bit μ-law sample to 16-bit linear PCM
 func mulawToLinear(mulawByte byte) int16 {
 	// Invert all bits first (μ-law uses inverted representation)
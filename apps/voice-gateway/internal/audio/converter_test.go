@@ -278,3 +278,25 @@ func TestCalculateRMS_Empty(t *testing.T) {
 		t.Errorf("Expected RMS 0.0 for empty slice, got %.2f", rms)
 	}
 }
+
+func TestDecodePCMU(t *testing.T) {
+	pcmuData := []byte{0xFF, 0x7F, 0x00, 0x80}
+	samples := DecodePCMU(pcmuData)
+
+	if len(samples) != len(pcmuData) {
+		t.Fatalf("Expected %d samples, got %d", len(pcmuData), len(samples))
+	}
+
+	// 0xFF and 0x7F encode (positive and negative) zero
+	if samples[0] != 0 || samples[1] != 0 {
+		t.Errorf("Expected zero samples for 0xFF/0x7F, got %d and %d", samples[0], samples[1])
+	}
+
+	// 0x00 and 0x80 encode the maximum negative and positive magnitudes
+	if samples[2] >= 0 {
+		t.Errorf("Expected negative sample for 0x00, got %d", samples[2])
+	}
+	if samples[3] <= 0 {
+		t.Errorf("Expected positive sample for 0x80, got %d", samples[3])
+	}
+}
@@ -0,0 +1,167 @@
+package audio
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// echoFrameSamples is one 20ms frame of 8kHz audio, the unit levels are
+// compared in
+const echoFrameSamples = 160
+
+// EchoConfig holds configuration for echo detection
+type EchoConfig struct {
+	MaxDelayFrames int     // Longest delay between playing audio and hearing it back
+	WindowFrames   int     // Frames of level history compared
+	Threshold      float64 // Correlation (0-1) above which inbound audio is taken as echo
+}
+
+// DefaultEchoConfig returns a default echo detection configuration
+func DefaultEchoConfig() *EchoConfig {
+	return &EchoConfig{
+		MaxDelayFrames: 30, // 600ms at 20ms frames
+		WindowFrames:   10, // 200ms at 20ms frames
+		Threshold:      0.8,
+	}
+}
+
+// EchoDetector recognizes the agent's own speech coming back on the caller's
+// line (speakerphones, poor handsets). Agent audio is placed on a timeline
+// as the caller hears it: each chunk plays after the one queued before it.
+// An inbound frame is echo when the recent caller level rises and falls with
+// the agent level some delay earlier. Levels rather than samples are
+// compared, which is cheap and survives the codec and the caller's phone.
+// It is safe for concurrent use
+type EchoDetector struct {
+	config *EchoConfig
+
+	mu       sync.Mutex
+	origin   time.Time // Time of slot 0; slots are 20ms apart
+	ref      []float64 // Agent level for each slot from refStart on
+	refStart int64
+	partial  []int16   // Agent samples short of a frame
+	inbound  []float64 // Caller levels of the latest frames, oldest first
+}
+
+// NewEchoDetector creates a new echo detector
+func NewEchoDetector(config *EchoConfig) *EchoDetector {
+	if config == nil {
+		config = DefaultEchoConfig()
+	}
+	return &EchoDetector{config: config}
+}
+
+// slot returns the timeline slot t falls in. Callers hold mu
+func (e *EchoDetector) slot(t time.Time) int64 {
+	if e.origin.IsZero() {
+		e.origin = t
+	}
+	return int64(t.Sub(e.origin) / (20 * time.Millisecond))
+}
+
+// Played records PCMU agent audio sent to the caller at now; it plays once
+// the audio sent before it has
+func (e *EchoDetector) Played(pcmu []byte, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := e.slot(now)
+	e.trim(current)
+	if end := e.refStart + int64(len(e.ref)); end < current {
+		// The line went quiet; the audio plays from now
+		e.ref = append(e.ref, make([]float64, current-end)...)
+	}
+
+	e.partial = append(e.partial, DecodePCMU(pcmu)...)
+	for len(e.partial) >= echoFrameSamples {
+		e.ref = append(e.ref, CalculateRMS(e.partial[:echoFrameSamples]))
+		e.partial = e.partial[echoFrameSamples:]
+	}
+}
+
+// Flush forgets agent audio that had not played by now, for when the
+// caller's queued audio is cleared
+func (e *EchoDetector) Flush(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if keep := e.slot(now) - e.refStart; keep >= 0 && keep < int64(len(e.ref)) {
+		e.ref = e.ref[:keep]
+	}
+	e.partial = e.partial[:0]
+}
+
+// trim drops agent levels too old to be heard back. Callers hold mu
+func (e *EchoDetector) trim(current int64) {
+	oldest := current - int64(e.config.MaxDelayFrames+e.config.WindowFrames)
+	if drop := oldest - e.refStart; drop > 0 {
+		if drop >= int64(len(e.ref)) {
+			e.ref = e.ref[:0]
+		} else {
+			e.ref = append(e.ref[:0], e.ref[drop:]...)
+		}
+		e.refStart = oldest
+	}
+}
+
+// IsEcho records a caller frame heard at now and returns whether it is the
+// agent's own audio coming back
+func (e *EchoDetector) IsEcho(samples []int16, now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	window := e.config.WindowFrames
+	e.inbound = append(e.inbound, CalculateRMS(samples))
+	if len(e.inbound) > window {
+		e.inbound = e.inbound[len(e.inbound)-window:]
+	}
+	if len(e.inbound) < window || mean(e.inbound) < AGCNoiseFloorRMS {
+		return false // Not enough heard yet, or nothing to gate
+	}
+
+	current := e.slot(now)
+	e.trim(current)
+	reference := make([]float64, window)
+	for lag := 0; lag <= e.config.MaxDelayFrames; lag++ {
+		first := current - int64(lag) - int64(window) + 1 - e.refStart
+		for i := range reference {
+			reference[i] = 0
+			if at := first + int64(i); at >= 0 && at < int64(len(e.ref)) {
+				reference[i] = e.ref[at]
+			}
+		}
+		if mean(reference) < AGCNoiseFloorRMS {
+			continue // The agent was quiet then
+		}
+		if correlation(e.inbound, reference) >= e.config.Threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// mean returns the average of values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// correlation returns the Pearson correlation of two equal-length series,
+// or 0 when either is flat
+func correlation(a, b []float64) float64 {
+	meanA, meanB := mean(a), mean(b)
+	var cov, varA, varB float64
+	for i := range a {
+		da, db := a[i]-meanA, b[i]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
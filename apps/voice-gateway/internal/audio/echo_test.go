@@ -0,0 +1,84 @@
+package audio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// frameAt returns a 20ms PCMU frame of a 300Hz tone at amplitude
+func frameAt(amplitude float64) []byte {
+	samples := make([]int16, echoFrameSamples)
+	for i := range samples {
+		samples[i] = int16(amplitude * math.Sin(2*math.Pi*300*float64(i)/8000))
+	}
+	return EncodeSamplesToPCMU(samples, 8000, 8000)
+}
+
+// agentLevel is a speech-like level for the agent's frame k
+func agentLevel(k int) float64 {
+	return 800 + 6000*math.Abs(math.Sin(float64(k)*0.45))
+}
+
+func TestEchoDetector_DetectsDelayedAgentAudio(t *testing.T) {
+	e := NewEchoDetector(DefaultEchoConfig())
+	start := time.Now()
+
+	// The agent's response is sent at once and plays over the next 2s
+	for k := 0; k < 100; k++ {
+		e.Played(frameAt(agentLevel(k)), start)
+	}
+
+	// The caller's line carries it back 200ms later, quieter
+	echoed := 0
+	for k := 0; k < 90; k++ {
+		heard := DecodePCMU(frameAt(0.3 * agentLevel(k-10)))
+		if k < 10 {
+			heard = make([]int16, echoFrameSamples)
+		}
+		if e.IsEcho(heard, start.Add(time.Duration(k)*20*time.Millisecond+time.Millisecond)) {
+			echoed++
+		}
+	}
+	if echoed < 60 {
+		t.Errorf("Expected the echoed response to be detected, got %d of 80 frames", echoed)
+	}
+}
+
+func TestEchoDetector_PassesCallerSpeech(t *testing.T) {
+	e := NewEchoDetector(DefaultEchoConfig())
+	start := time.Now()
+	for k := 0; k < 100; k++ {
+		e.Played(frameAt(agentLevel(k)), start)
+	}
+
+	// The caller talks over the agent with their own rhythm
+	for k := 0; k < 90; k++ {
+		level := 1500 + 5000*math.Abs(math.Sin(float64(k)*1.3+2))
+		if e.IsEcho(DecodePCMU(frameAt(level)), start.Add(time.Duration(k)*20*time.Millisecond)) {
+			t.Fatalf("Expected caller speech to pass, frame %d taken as echo", k)
+		}
+	}
+}
+
+func TestEchoDetector_NothingPlayed(t *testing.T) {
+	e := NewEchoDetector(DefaultEchoConfig())
+	start := time.Now()
+	for k := 0; k < 50; k++ {
+		if e.IsEcho(DecodePCMU(frameAt(agentLevel(k))), start.Add(time.Duration(k)*20*time.Millisecond)) {
+			t.Fatal("Expected no echo while the agent is silent")
+		}
+	}
+}
+
+func TestEchoDetector_FlushDropsUnplayedAudio(t *testing.T) {
+	e := NewEchoDetector(DefaultEchoConfig())
+	start := time.Now()
+	for k := 0; k < 100; k++ {
+		e.Played(frameAt(agentLevel(k)), start)
+	}
+	e.Flush(start.Add(100 * time.Millisecond))
+	if len(e.ref) != 5 {
+		t.Errorf("Expected the 5 frames played before the flush, got %d", len(e.ref))
+	}
+}
@@ -0,0 +1,176 @@
+package audio
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Packet is a chunk of audio moving through a Pipeline, along with what
+// earlier stages have learned about it. A pipeline's owner typically reuses
+// one Packet for every chunk, so stages must not keep it (or Data) after
+// Process returns
+type Packet struct {
+	// Data is 8kHz PCMU; stages may change it in place (e.g. gain) or replace it
+	Data []byte
+
+	// Samples is Data as linear PCM, once a decode stage has run
+	Samples []int16
+
+	// Speaking and SpeechEnded are set by voice activity detection
+	Speaking    bool
+	SpeechEnded bool
+
+	frames   [][]byte
+	replaced bool
+	single   [1][]byte
+}
+
+// Reset readies the packet for a new chunk of audio, keeping the Samples buffer for reuse
+func (p *Packet) Reset(data []byte) {
+	*p = Packet{Data: data, Samples: p.Samples[:0]}
+}
+
+// Forward replaces Data with frames for the stages that follow, for a stage
+// that holds audio back or releases buffered audio along with it (e.g.
+// silence suppression); an empty frames passes nothing on
+func (p *Packet) Forward(frames [][]byte) {
+	p.frames = frames
+	p.replaced = true
+}
+
+// Frames returns the audio to pass on: Data, unless a stage called Forward
+func (p *Packet) Frames() [][]byte {
+	if p.replaced {
+		return p.frames
+	}
+	p.single[0] = p.Data
+	return p.single[:]
+}
+
+// Stage is one step of a Pipeline. Process returns false to stop the packet
+// there, so later stages do not see it
+type Stage interface {
+	Process(p *Packet) bool
+}
+
+// StageFunc adapts a function to a Stage
+type StageFunc func(p *Packet) bool
+
+// Process calls f(p)
+func (f StageFunc) Process(p *Packet) bool {
+	return f(p)
+}
+
+type namedStage struct {
+	name  string
+	stage Stage
+}
+
+// Pipeline runs packets through named stages in order. Stages can be added
+// and removed while packets flow (e.g. a recording tap for part of a call);
+// a packet already in the pipeline finishes with the stages it started with
+type Pipeline struct {
+	mu     sync.Mutex // Serializes changes; Process reads stages without locking
+	stages atomic.Pointer[[]namedStage]
+}
+
+// NewPipeline creates an empty pipeline
+func NewPipeline() *Pipeline {
+	p := &Pipeline{}
+	p.stages.Store(&[]namedStage{})
+	return p
+}
+
+// Process runs the packet through each stage and reports whether it got
+// through all of them
+func (p *Pipeline) Process(pkt *Packet) bool {
+	for _, s := range *p.stages.Load() {
+		if !s.stage.Process(pkt) {
+			return false
+		}
+	}
+	return true
+}
+
+// Append adds a stage at the end of the pipeline
+func (p *Pipeline) Append(name string, stage Stage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.insert(len(*p.stages.Load()), name, stage)
+}
+
+// InsertBefore adds a stage just before the stage named before
+func (p *Pipeline) InsertBefore(before, name string, stage Stage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := p.index(before)
+	if i < 0 {
+		return fmt.Errorf("no pipeline stage %q", before)
+	}
+	return p.insert(i, name, stage)
+}
+
+// InsertAfter adds a stage just after the stage named after
+func (p *Pipeline) InsertAfter(after, name string, stage Stage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := p.index(after)
+	if i < 0 {
+		return fmt.Errorf("no pipeline stage %q", after)
+	}
+	return p.insert(i+1, name, stage)
+}
+
+// Remove removes the named stage, reporting whether it was there
+func (p *Pipeline) Remove(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := p.index(name)
+	if i < 0 {
+		return false
+	}
+	current := *p.stages.Load()
+	stages := make([]namedStage, 0, len(current)-1)
+	stages = append(stages, current[:i]...)
+	stages = append(stages, current[i+1:]...)
+	p.stages.Store(&stages)
+	return true
+}
+
+// Stages returns the stage names in order
+func (p *Pipeline) Stages() []string {
+	current := *p.stages.Load()
+	names := make([]string, len(current))
+	for i, s := range current {
+		names[i] = s.name
+	}
+	return names
+}
+
+// index returns the position of the named stage, or -1; callers hold mu
+func (p *Pipeline) index(name string) int {
+	for i, s := range *p.stages.Load() {
+		if s.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert stores a copy of the stages with the new one at position i; callers hold mu
+func (p *Pipeline) insert(i int, name string, stage Stage) error {
+	if stage == nil {
+		return fmt.Errorf("pipeline stage %q is nil", name)
+	}
+	if p.index(name) >= 0 {
+		return fmt.Errorf("pipeline stage %q already exists", name)
+	}
+	current := *p.stages.Load()
+	stages := make([]namedStage, 0, len(current)+1)
+	stages = append(stages, current[:i]...)
+	stages = append(stages, namedStage{name: name, stage: stage})
+	stages = append(stages, current[i:]...)
+	p.stages.Store(&stages)
+	return nil
+}
@@ -0,0 +1,99 @@
+package audio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func recordStage(name string, seen *[]string) StageFunc {
+	return func(p *Packet) bool {
+		*seen = append(*seen, name)
+		return true
+	}
+}
+
+func TestPipeline_Order(t *testing.T) {
+	var seen []string
+	p := NewPipeline()
+	for _, name := range []string{"decode", "vad", "stt"} {
+		if err := p.Append(name, recordStage(name, &seen)); err != nil {
+			t.Fatalf("Append(%s) failed: %v", name, err)
+		}
+	}
+	if err := p.InsertBefore("vad", "gain", recordStage("gain", &seen)); err != nil {
+		t.Fatalf("InsertBefore() failed: %v", err)
+	}
+	if err := p.InsertAfter("stt", "tap", recordStage("tap", &seen)); err != nil {
+		t.Fatalf("InsertAfter() failed: %v", err)
+	}
+
+	if !p.Process(&Packet{}) {
+		t.Error("Expected packet to pass every stage")
+	}
+	want := []string{"decode", "gain", "vad", "stt", "tap"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("Expected stages %v, got %v", want, seen)
+	}
+	if !reflect.DeepEqual(p.Stages(), want) {
+		t.Errorf("Expected Stages() %v, got %v", want, p.Stages())
+	}
+
+	if !p.Remove("gain") || p.Remove("gain") {
+		t.Error("Expected Remove to succeed once")
+	}
+	if err := p.Append("vad", recordStage("vad", &seen)); err == nil {
+		t.Error("Expected error for duplicate stage name")
+	}
+	if err := p.InsertAfter("missing", "x", recordStage("x", &seen)); err == nil {
+		t.Error("Expected error for unknown stage")
+	}
+}
+
+func TestPipeline_StageStopsPacket(t *testing.T) {
+	var seen []string
+	p := NewPipeline()
+	_ = p.Append("screen", StageFunc(func(p *Packet) bool { return false }))
+	_ = p.Append("stt", recordStage("stt", &seen))
+
+	if p.Process(&Packet{}) {
+		t.Error("Expected packet to stop at screen")
+	}
+	if len(seen) != 0 {
+		t.Errorf("Expected later stages to be skipped, got %v", seen)
+	}
+}
+
+func TestPipeline_RemoveDuringProcess(t *testing.T) {
+	p := NewPipeline()
+	calls := 0
+	_ = p.Append("once", StageFunc(func(*Packet) bool {
+		calls++
+		p.Remove("once")
+		return true
+	}))
+
+	p.Process(&Packet{})
+	p.Process(&Packet{})
+	if calls != 1 {
+		t.Errorf("Expected stage to run once, got %d", calls)
+	}
+}
+
+func TestPacket_Frames(t *testing.T) {
+	var pkt Packet
+	pkt.Reset([]byte{1, 2})
+	if frames := pkt.Frames(); len(frames) != 1 || len(frames[0]) != 2 {
+		t.Errorf("Expected Data as the only frame, got %v", frames)
+	}
+
+	pkt.Forward(nil)
+	if frames := pkt.Frames(); len(frames) != 0 {
+		t.Errorf("Expected no frames after Forward(nil), got %v", frames)
+	}
+
+	pkt.Samples = make([]int16, 0, 160)
+	pkt.Reset([]byte{3})
+	if len(pkt.Frames()) != 1 || cap(pkt.Samples) != 160 {
+		t.Error("Expected Reset to clear Forward and keep the Samples buffer")
+	}
+}
@@ -0,0 +1,55 @@
+package audio
+
+import "sync"
+
+// FrameSize is one 20ms PCMU frame as Twilio sends it (8kHz, one byte per sample)
+const FrameSize = 160
+
+// maxPooledFrame caps the buffers kept for reuse so one oversized chunk does
+// not pin memory in the pool
+const maxPooledFrame = 16 * FrameSize
+
+// Frame is a pooled audio buffer. Whoever holds a Frame owns it: passing it
+// on (e.g. over a channel) hands over ownership, and the last owner calls
+// Release. Code that keeps audio beyond that must copy Data
+type Frame struct {
+	Data []byte
+}
+
+var framePool = sync.Pool{
+	New: func() interface{} {
+		return &Frame{Data: make([]byte, 0, FrameSize)}
+	},
+}
+
+// GetFrame returns a frame with n bytes of Data, reusing a released buffer when possible
+func GetFrame(n int) *Frame {
+	f := framePool.Get().(*Frame)
+	if cap(f.Data) < n {
+		f.Data = make([]byte, n)
+	}
+	f.Data = f.Data[:n]
+	return f
+}
+
+// Release returns the frame to the pool; neither it nor its Data may be used afterwards
+func (f *Frame) Release() {
+	if f == nil || cap(f.Data) > maxPooledFrame {
+		return
+	}
+	f.Data = f.Data[:0]
+	framePool.Put(f)
+}
+
+// DecodePCMUInto decodes PCMU into dst, growing it if needed, and returns the
+// samples; reusing dst across frames avoids a new slice per frame
+func DecodePCMUInto(dst []int16, pcmuData []byte) []int16 {
+	if cap(dst) < len(pcmuData) {
+		dst = make([]int16, len(pcmuData))
+	}
+	dst = dst[:len(pcmuData)]
+	for i, b := range pcmuData {
+		dst[i] = mulawToLinear(b)
+	}
+	return dst
+}
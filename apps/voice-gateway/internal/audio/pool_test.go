@@ -0,0 +1,78 @@
+package audio
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestGetFrame(t *testing.T) {
+	f := GetFrame(FrameSize)
+	if len(f.Data) != FrameSize {
+		t.Fatalf("Expected %d bytes, got %d", FrameSize, len(f.Data))
+	}
+	f.Release()
+
+	big := GetFrame(4 * FrameSize)
+	if len(big.Data) != 4*FrameSize {
+		t.Errorf("Expected a frame grown to %d bytes, got %d", 4*FrameSize, len(big.Data))
+	}
+	big.Release()
+
+	// Oversized buffers are left to the GC rather than pinned in the pool
+	(&Frame{Data: make([]byte, maxPooledFrame+1)}).Release()
+	var nilFrame *Frame
+	nilFrame.Release()
+}
+
+func TestDecodePCMUInto(t *testing.T) {
+	pcmu := []byte{0x00, 0x7f, 0x80, 0xff, 0x35}
+	want := DecodePCMU(pcmu)
+
+	scratch := make([]int16, 2)
+	got := DecodePCMUInto(scratch, pcmu)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d samples, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sample %d: expected %d, got %d", i, want[i], got[i])
+		}
+	}
+
+	again := DecodePCMUInto(got, pcmu[:2])
+	if len(again) != 2 || &again[0] != &got[0] {
+		t.Error("Expected a large enough buffer to be reused")
+	}
+}
+
+// inboundPayload is a Twilio media payload: one 20ms PCMU frame in base64
+var inboundPayload = base64.StdEncoding.EncodeToString(make([]byte, FrameSize))
+
+// BenchmarkInboundFrame_Alloc decodes a media frame the way the gateway did
+// before pooling: a new slice for the bytes and another for the VAD samples
+func BenchmarkInboundFrame_Alloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := base64.StdEncoding.DecodeString(inboundPayload)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = DecodePCMU(data)
+	}
+}
+
+// BenchmarkInboundFrame_Pooled decodes into a pooled frame and reused scratch samples
+func BenchmarkInboundFrame_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	payload := []byte(inboundPayload)
+	var samples []int16
+	for i := 0; i < b.N; i++ {
+		frame := GetFrame(base64.StdEncoding.DecodedLen(len(payload)))
+		n, err := base64.StdEncoding.Decode(frame.Data, payload)
+		if err != nil {
+			b.Fatal(err)
+		}
+		samples = DecodePCMUInto(samples, frame.Data[:n])
+		frame.Release()
+	}
+}
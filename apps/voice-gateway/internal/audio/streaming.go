@@ -0,0 +1,119 @@
+package audio
+
+import "fmt"
+
+// StreamingConverter converts 16-bit little-endian PCM to PCMU (μ-law) one
+// chunk at a time, for audio that arrives in pieces (e.g. streaming TTS)
+// Chunks may split a sample; the odd byte and the resampler's position are
+// carried into the next call, so the output matches converting the whole
+// stream at once. A converter is not safe for concurrent use
+type StreamingConverter struct {
+	inputRate  int
+	outputRate int
+
+	remainder []byte // Trailing byte of a split sample
+	prev      int16  // Last sample of the previous chunk, for interpolation
+	havePrev  bool
+
+	// pos is the next output sample's source position in units of
+	// 1/outputRate input samples, counted from prev (or the first sample
+	// when there is no prev)
+	pos int64
+}
+
+// NewStreamingConverter creates a converter from PCM at inputSampleRate to PCMU at outputSampleRate
+func NewStreamingConverter(inputSampleRate, outputSampleRate int) (*StreamingConverter, error) {
+	if inputSampleRate <= 0 || outputSampleRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rates %d -> %d", inputSampleRate, outputSampleRate)
+	}
+	return &StreamingConverter{
+		inputRate:  inputSampleRate,
+		outputRate: outputSampleRate,
+		remainder:  make([]byte, 0, 1),
+	}, nil
+}
+
+// Convert converts the next chunk of PCM; output for the last input sample is
+// held back until the following chunk (or Flush) supplies what comes after it
+func (c *StreamingConverter) Convert(pcmData []byte) []byte {
+	samples := c.samples(pcmData)
+	if len(samples) == 0 {
+		return nil
+	}
+	if c.inputRate == c.outputRate {
+		return encodePCMU(samples)
+	}
+
+	buf := samples
+	if c.havePrev {
+		buf = append([]int16{c.prev}, samples...)
+	}
+
+	out := int64(c.outputRate)
+	step := int64(c.inputRate)
+	last := int64(len(buf) - 1)
+	pcmu := make([]byte, 0, int64(len(samples))*out/step+1)
+	for c.pos < last*out {
+		idx0 := c.pos / out
+		fraction := float64(c.pos%out) / float64(out)
+		sample := float64(buf[idx0])*(1.0-fraction) + float64(buf[idx0+1])*fraction
+		pcmu = append(pcmu, linearToMulaw(int16(sample)))
+		c.pos += step
+	}
+
+	// The next chunk's buffer starts at this chunk's last sample
+	c.pos -= last * out
+	c.prev = buf[last]
+	c.havePrev = true
+	return pcmu
+}
+
+// Flush returns the PCMU held back at the end of the stream and resets the
+// converter for a new stream; a dangling half sample is dropped
+func (c *StreamingConverter) Flush() []byte {
+	var pcmu []byte
+	if c.havePrev && c.inputRate != c.outputRate {
+		// Nothing follows the last sample, so it is held for its remaining positions
+		out := int64(c.outputRate)
+		for ; c.pos < out; c.pos += int64(c.inputRate) {
+			pcmu = append(pcmu, linearToMulaw(c.prev))
+		}
+	}
+	c.Reset()
+	return pcmu
+}
+
+// Reset discards any carried state
+func (c *StreamingConverter) Reset() {
+	c.remainder = c.remainder[:0]
+	c.prev = 0
+	c.havePrev = false
+	c.pos = 0
+}
+
+// samples decodes pcmData after any carried byte, keeping a new odd byte
+func (c *StreamingConverter) samples(pcmData []byte) []int16 {
+	data := pcmData
+	if len(c.remainder) > 0 {
+		data = append(append(make([]byte, 0, len(pcmData)+1), c.remainder...), pcmData...)
+		c.remainder = c.remainder[:0]
+	}
+	if len(data)%2 != 0 {
+		c.remainder = append(c.remainder, data[len(data)-1])
+		data = data[:len(data)-1]
+	}
+
+	samples := make([]int16, len(data)/2)
+	for i := range samples {
+		samples[i] = int16(data[i*2]) | int16(data[i*2+1])<<8
+	}
+	return samples
+}
+
+func encodePCMU(samples []int16) []byte {
+	pcmu := make([]byte, len(samples))
+	for i, sample := range samples {
+		pcmu[i] = linearToMulaw(sample)
+	}
+	return pcmu
+}
@@ -0,0 +1,88 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sinePCM returns n samples of a 440Hz tone at rate as little-endian PCM
+func sinePCM(n, rate int) []byte {
+	pcm := make([]byte, n*2)
+	for i := 0; i < n; i++ {
+		sample := int16(8000 * math.Sin(2*math.Pi*440*float64(i)/float64(rate)))
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(sample))
+	}
+	return pcm
+}
+
+// convertInChunks streams pcm through a converter in pieces of the given sizes, cycling
+func convertInChunks(t *testing.T, pcm []byte, inputRate, outputRate int, sizes []int) []byte {
+	t.Helper()
+	c, err := NewStreamingConverter(inputRate, outputRate)
+	if err != nil {
+		t.Fatalf("NewStreamingConverter failed: %v", err)
+	}
+	var out []byte
+	for i, start := 0, 0; start < len(pcm); i++ {
+		end := start + sizes[i%len(sizes)]
+		if end > len(pcm) {
+			end = len(pcm)
+		}
+		out = append(out, c.Convert(pcm[start:end])...)
+		start = end
+	}
+	return append(out, c.Flush()...)
+}
+
+func TestStreamingConverter_MatchesWholeBuffer(t *testing.T) {
+	pcm := sinePCM(2400, 24000)
+	whole, err := ConvertPCMToPCMU(pcm, 24000, 8000)
+	if err != nil {
+		t.Fatalf("ConvertPCMToPCMU failed: %v", err)
+	}
+
+	streamed := convertInChunks(t, pcm, 24000, 8000, []int{len(pcm)})
+	if len(streamed) != len(whole) {
+		t.Fatalf("Expected %d PCMU bytes, got %d", len(whole), len(streamed))
+	}
+	for i := range whole {
+		diff := int(mulawToLinear(whole[i])) - int(mulawToLinear(streamed[i]))
+		if diff < -32 || diff > 32 {
+			t.Fatalf("Sample %d differs from ConvertPCMToPCMU: %d vs %d", i, mulawToLinear(streamed[i]), mulawToLinear(whole[i]))
+		}
+	}
+}
+
+func TestStreamingConverter_ChunkBoundaries(t *testing.T) {
+	pcm := sinePCM(2400, 24000)
+	for _, rates := range [][2]int{{24000, 8000}, {16000, 8000}, {8000, 8000}, {22050, 8000}} {
+		whole := convertInChunks(t, pcm, rates[0], rates[1], []int{len(pcm)})
+		// Odd sizes split samples; tiny ones leave chunks with a single sample or none
+		for _, sizes := range [][]int{{1}, {3}, {7, 2, 160}, {641}, {480, 1, 1}} {
+			chunked := convertInChunks(t, pcm, rates[0], rates[1], sizes)
+			if !bytes.Equal(chunked, whole) {
+				t.Errorf("%d->%d in chunks of %v: expected %d bytes matching one-shot conversion, got %d bytes",
+					rates[0], rates[1], sizes, len(whole), len(chunked))
+			}
+		}
+	}
+}
+
+func TestStreamingConverter_FlushResets(t *testing.T) {
+	c, err := NewStreamingConverter(24000, 8000)
+	if err != nil {
+		t.Fatalf("NewStreamingConverter failed: %v", err)
+	}
+	pcm := sinePCM(300, 24000)
+	first := append(c.Convert(pcm[:301]), c.Flush()...)
+	second := append(c.Convert(pcm[:301]), c.Flush()...)
+	if !bytes.Equal(first, second) {
+		t.Error("Expected identical output for the same stream after Flush")
+	}
+
+	if _, err := NewStreamingConverter(0, 8000); err == nil {
+		t.Error("Expected error for a zero input rate")
+	}
+}
@@ -0,0 +1,78 @@
+package audio
+
+// SuppressorConfig holds configuration for silence suppression
+type SuppressorConfig struct {
+	HangoverFrames int // Silent frames still forwarded after speech (lets the STT endpointer see the pause)
+	PreRollFrames  int // Silent frames held back and flushed when speech starts (avoids clipping word onsets)
+}
+
+// DefaultSuppressorConfig returns a default suppression configuration
+func DefaultSuppressorConfig() *SuppressorConfig {
+	return &SuppressorConfig{
+		HangoverFrames: 15, // 300ms at 20ms frames
+		PreRollFrames:  5,  // 100ms at 20ms frames
+	}
+}
+
+// SilenceSuppressor decides which inbound frames are worth sending to a
+// billed STT provider. Speech and a short hangover are forwarded; longer runs
+// of silence are withheld, keeping a small pre-roll to replay at speech onset.
+type SilenceSuppressor struct {
+	config    *SuppressorConfig
+	silentRun int
+	preRoll   [][]byte
+}
+
+// NewSilenceSuppressor creates a new silence suppressor
+func NewSilenceSuppressor(config *SuppressorConfig) *SilenceSuppressor {
+	if config == nil {
+		config = DefaultSuppressorConfig()
+	}
+	return &SilenceSuppressor{
+		config:  config,
+		preRoll: make([][]byte, 0, config.PreRollFrames),
+	}
+}
+
+// Process takes a frame and whether it contains speech, and returns the
+// frames that should be forwarded (possibly none, possibly pre-roll + frame)
+// Withheld frames are copied, so the caller may reuse frame once it returns
+func (s *SilenceSuppressor) Process(frame []byte, speaking bool) [][]byte {
+	if speaking {
+		s.silentRun = 0
+		if len(s.preRoll) == 0 {
+			return [][]byte{frame}
+		}
+		out := append(s.preRoll, frame)
+		s.preRoll = make([][]byte, 0, s.config.PreRollFrames)
+		return out
+	}
+
+	s.silentRun++
+	if s.silentRun <= s.config.HangoverFrames {
+		return [][]byte{frame}
+	}
+
+	// Withhold the frame but remember it as pre-roll for the next onset,
+	// reusing the oldest pre-roll buffer once the pre-roll is full
+	if s.config.PreRollFrames > 0 {
+		var buf []byte
+		if len(s.preRoll) >= s.config.PreRollFrames {
+			buf = s.preRoll[0][:0]
+			s.preRoll = append(s.preRoll[:0], s.preRoll[1:]...)
+		}
+		s.preRoll = append(s.preRoll, append(buf, frame...))
+	}
+	return nil
+}
+
+// IsSuppressing returns whether silence is currently being withheld
+func (s *SilenceSuppressor) IsSuppressing() bool {
+	return s.silentRun > s.config.HangoverFrames
+}
+
+// Reset clears suppression state
+func (s *SilenceSuppressor) Reset() {
+	s.silentRun = 0
+	s.preRoll = s.preRoll[:0]
+}
@@ -0,0 +1,104 @@
+package audio
+
+import (
+	"testing"
+)
+
+func TestSilenceSuppressor_ForwardsSpeech(t *testing.T) {
+	s := NewSilenceSuppressor(&SuppressorConfig{HangoverFrames: 2, PreRollFrames: 1})
+
+	frames := s.Process([]byte{1}, true)
+	if len(frames) != 1 {
+		t.Fatalf("Expected speech frame to be forwarded, got %d frames", len(frames))
+	}
+	if s.IsSuppressing() {
+		t.Error("Expected not suppressing during speech")
+	}
+}
+
+func TestSilenceSuppressor_Hangover(t *testing.T) {
+	s := NewSilenceSuppressor(&SuppressorConfig{HangoverFrames: 2, PreRollFrames: 0})
+	s.Process([]byte{1}, true)
+
+	// Hangover frames are still forwarded
+	for i := 0; i < 2; i++ {
+		if frames := s.Process([]byte{0}, false); len(frames) != 1 {
+			t.Errorf("Expected hangover frame %d to be forwarded", i)
+		}
+	}
+
+	// After the hangover, silence is withheld
+	if frames := s.Process([]byte{0}, false); len(frames) != 0 {
+		t.Errorf("Expected silence to be suppressed after hangover, got %d frames", len(frames))
+	}
+	if !s.IsSuppressing() {
+		t.Error("Expected suppressing after hangover")
+	}
+}
+
+func TestSilenceSuppressor_PreRoll(t *testing.T) {
+	s := NewSilenceSuppressor(&SuppressorConfig{HangoverFrames: 0, PreRollFrames: 2})
+
+	// Three suppressed frames; only the last two are kept
+	s.Process([]byte{10}, false)
+	s.Process([]byte{11}, false)
+	s.Process([]byte{12}, false)
+
+	frames := s.Process([]byte{20}, true)
+	if len(frames) != 3 {
+		t.Fatalf("Expected 2 pre-roll frames plus speech frame, got %d", len(frames))
+	}
+
+	expected := []byte{11, 12, 20}
+	for i, exp := range expected {
+		if frames[i][0] != exp {
+			t.Errorf("Expected frame %d to be %d, got %d", i, exp, frames[i][0])
+		}
+	}
+
+	// Pre-roll is consumed
+	if frames := s.Process([]byte{21}, true); len(frames) != 1 {
+		t.Errorf("Expected pre-roll to be flushed once, got %d frames", len(frames))
+	}
+}
+
+func TestSilenceSuppressor_PreRollCopiesFrames(t *testing.T) {
+	s := NewSilenceSuppressor(&SuppressorConfig{HangoverFrames: 0, PreRollFrames: 2})
+
+	// The caller reuses one buffer, as the pooled inbound path does
+	buf := []byte{10}
+	for _, b := range []byte{10, 11, 12} {
+		buf[0] = b
+		s.Process(buf, false)
+	}
+	buf[0] = 99
+
+	frames := s.Process([]byte{20}, true)
+	if len(frames) != 3 || frames[0][0] != 11 || frames[1][0] != 12 {
+		t.Errorf("Expected pre-roll 11, 12 to survive buffer reuse, got %v", frames)
+	}
+}
+
+func TestSilenceSuppressor_Reset(t *testing.T) {
+	s := NewSilenceSuppressor(&SuppressorConfig{HangoverFrames: 0, PreRollFrames: 1})
+	s.Process([]byte{0}, false)
+
+	s.Reset()
+
+	if s.IsSuppressing() {
+		t.Error("Expected not suppressing after reset")
+	}
+	if frames := s.Process([]byte{1}, true); len(frames) != 1 {
+		t.Errorf("Expected pre-roll to be cleared by reset, got %d frames", len(frames))
+	}
+}
+
+func TestDefaultSuppressorConfig(t *testing.T) {
+	config := DefaultSuppressorConfig()
+	if config.HangoverFrames != 15 {
+		t.Errorf("Expected HangoverFrames 15, got %d", config.HangoverFrames)
+	}
+	if config.PreRollFrames != 5 {
+		t.Errorf("Expected PreRollFrames 5, got %d", config.PreRollFrames)
+	}
+}
@@ -70,6 +70,17 @@ func (v *VADDetector) ProcessFrame(samples []int16) (bool, bool, bool) {
 	return v.isSpeaking, speechStarted, speechEnded
 }
 
+// SetSilenceFrames changes how many consecutive silence frames end speech,
+// from the next frame on
+func (v *VADDetector) SetSilenceFrames(frames int) {
+	v.config.SilenceFrames = max(frames, 1)
+}
+
+// SilenceFrames returns how many consecutive silence frames end speech
+func (v *VADDetector) SilenceFrames() int {
+	return v.config.SilenceFrames
+}
+
 // Reset resets the VAD detector state
 func (v *VADDetector) Reset() {
 	v.silenceCounter = 0
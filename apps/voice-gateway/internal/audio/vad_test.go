@@ -224,3 +224,20 @@ func TestDetectSilence(t *testing.T) {
 	}
 }
 
+
+func TestVADDetector_SetSilenceFrames(t *testing.T) {
+	vad := NewVADDetector(&VADConfig{EnergyThreshold: 500.0, SilenceFrames: 10, FrameSize: 160})
+	speech := make([]int16, 160)
+	for i := range speech {
+		speech[i] = 5000
+	}
+	vad.ProcessFrame(speech)
+
+	vad.SetSilenceFrames(3)
+	silence := make([]int16, 160)
+	for i := 1; i <= 3; i++ {
+		if _, _, ended := vad.ProcessFrame(silence); ended != (i == 3) {
+			t.Errorf("Silence frame %d: ended = %v", i, ended)
+		}
+	}
+}
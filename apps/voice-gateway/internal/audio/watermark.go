@@ -0,0 +1,66 @@
+package audio
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// pcmuFullScale is the largest magnitude the μ-law codec here represents
+	pcmuFullScale = 8159
+
+	// watermarkRamp fades each tone in and out so it does not click
+	watermarkRamp = 5 * time.Millisecond
+)
+
+// WatermarkConfig describes the tone a Watermark mixes into audio
+type WatermarkConfig struct {
+	SampleRate  int           // Of the audio being marked
+	Interval    time.Duration // From the start of one tone to the start of the next
+	FrequencyHz int
+	Duration    time.Duration // Of each tone
+	Level       float64       // Tone amplitude as a fraction of full scale
+}
+
+// Watermark mixes a short, quiet tone into PCMU audio once per interval of
+// audio, marking the audio as machine-generated. Time is counted in audio
+// passed to Mix, so silence between utterances does not advance it
+type Watermark struct {
+	tone     []int16
+	interval int // In samples
+	pos      int // Samples since the last tone started
+}
+
+// NewWatermark precomputes the tone for cfg
+func NewWatermark(cfg WatermarkConfig) *Watermark {
+	interval := max(int(cfg.Interval.Seconds()*float64(cfg.SampleRate)), 1)
+	n := min(int(cfg.Duration.Seconds()*float64(cfg.SampleRate)), interval)
+	ramp := min(int(watermarkRamp.Seconds()*float64(cfg.SampleRate)), n/2)
+
+	tone := make([]int16, n)
+	amplitude := cfg.Level * pcmuFullScale
+	for i := range tone {
+		gain := 1.0
+		if edge := min(i, n-1-i); edge < ramp {
+			gain = float64(edge) / float64(ramp)
+		}
+		phase := 2 * math.Pi * float64(cfg.FrequencyHz) * float64(i) / float64(cfg.SampleRate)
+		tone[i] = int16(gain * amplitude * math.Sin(phase))
+	}
+	return &Watermark{tone: tone, interval: interval}
+}
+
+// Mix adds the tone to PCMU audio in place wherever it falls due
+func (w *Watermark) Mix(pcmu []byte) {
+	for i, b := range pcmu {
+		if w.pos < len(w.tone) {
+			mixed := int32(mulawToLinear(b)) + int32(w.tone[w.pos])
+			mixed = max(min(mixed, pcmuFullScale), -pcmuFullScale)
+			pcmu[i] = linearToMulaw(int16(mixed))
+		}
+		w.pos++
+		if w.pos == w.interval {
+			w.pos = 0
+		}
+	}
+}
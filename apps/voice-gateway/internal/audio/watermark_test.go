@@ -0,0 +1,33 @@
+package audio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWatermark_Mix(t *testing.T) {
+	w := NewWatermark(WatermarkConfig{
+		SampleRate:  8000,
+		Interval:    100 * time.Millisecond, // 800 samples
+		FrequencyHz: 1000,
+		Duration:    20 * time.Millisecond, // 160 samples
+		Level:       0.1,
+	})
+
+	silence := bytes.Repeat([]byte{0xFF}, 1000)
+	audio := append([]byte(nil), silence...)
+	w.Mix(audio[:500])
+	w.Mix(audio[500:])
+
+	tone := CalculateRMS(DecodePCMU(audio[:160]))
+	if tone < 100 {
+		t.Errorf("Expected the tone at the start of the audio, got RMS %.1f", tone)
+	}
+	if !bytes.Equal(audio[160:800], silence[160:800]) {
+		t.Error("Expected no tone between intervals")
+	}
+	if CalculateRMS(DecodePCMU(audio[800:960])) < 100 {
+		t.Error("Expected the tone again after one interval, across Mix calls")
+	}
+}
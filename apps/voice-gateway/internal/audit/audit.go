@@ -0,0 +1,290 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/rs/zerolog"
+)
+
+// Kinds of audited action
+const (
+	KindAdminRead         = "admin.read"         // Admin API reads (calls, usage, campaigns, ...)
+	KindAdminAction       = "admin.action"       // Admin API operations (SMS, campaigns, callbacks)
+	KindConfigChange      = "config.change"      // Routing overrides, do-not-call lists, voices
+	KindLiveView          = "live.view"          // Live transcript feeds and supervision
+	KindRecordingDownload = "recording.download" // Stored call audio fetched
+	KindDataErasure       = "data.erasure"       // Right-to-erasure requests
+)
+
+// ErrChainBroken is returned when an entry doesn't follow from the one before
+// it, i.e. the log was edited, truncated or reordered
+var ErrChainBroken = errors.New("audit chain broken")
+
+// publishTimeout bounds exporting an entry to the events bus
+const publishTimeout = 10 * time.Second
+
+// memoryEntries is how many recent entries are kept for queries
+const memoryEntries = 10000
+
+// Entry is one audited action. Each entry's Hash covers the entry and the
+// previous entry's hash, so changing or removing any entry breaks every
+// hash after it
+type Entry struct {
+	Seq        uint64    `json:"seq"`
+	Time       time.Time `json:"time"`
+	Instance   string    `json:"instance,omitempty"`
+	Actor      string    `json:"actor"`
+	Role       string    `json:"role,omitempty"`
+	Kind       string    `json:"kind"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	FirmID     string    `json:"firm_id,omitempty"`
+	CallSid    string    `json:"call_sid,omitempty"`
+	Status     int       `json:"status"`
+	RemoteAddr string    `json:"remote_addr,omitempty"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// digest computes the entry's hash from everything but Hash itself
+func (e Entry) digest() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Filter selects entries; zero fields match everything
+type Filter struct {
+	Since   time.Time
+	Until   time.Time
+	Actor   string
+	Kind    string
+	FirmID  string
+	CallSid string
+	Limit   int
+}
+
+func (f Filter) match(e *Entry) bool {
+	return (f.Since.IsZero() || !e.Time.Before(f.Since)) &&
+		(f.Until.IsZero() || e.Time.Before(f.Until)) &&
+		(f.Actor == "" || e.Actor == f.Actor) &&
+		(f.Kind == "" || e.Kind == f.Kind) &&
+		(f.FirmID == "" || e.FirmID == f.FirmID) &&
+		(f.CallSid == "" || e.CallSid == f.CallSid)
+}
+
+// Log is an append-only, hash-chained audit log. Entries are appended to a
+// JSON-lines file when one is configured, kept in memory (the most recent
+// 10,000) for queries, and exported to the events bus as audit.entry
+type Log struct {
+	mu        sync.Mutex
+	path      string
+	file      *os.File
+	entries   []Entry // Oldest first
+	capacity  int
+	seq       uint64
+	last      string // Hash of the last entry
+	instance  string
+	publisher events.Publisher
+	logger    zerolog.Logger
+	now       func() time.Time
+}
+
+// Open opens the log at path, continuing its chain; an empty path keeps the
+// log in memory only. A broken chain is logged rather than refused, so the
+// gateway keeps auditing while the tampering is investigated
+func Open(path, instance string, publisher events.Publisher, logger zerolog.Logger) (*Log, error) {
+	l := &Log{
+		path:      path,
+		capacity:  memoryEntries,
+		instance:  instance,
+		publisher: publisher,
+		logger:    logger.With().Str("component", "audit").Logger(),
+		now:       time.Now,
+	}
+	if path == "" {
+		return l, nil
+	}
+
+	err := l.replay(func(e Entry) {
+		l.remember(e)
+		l.seq, l.last = e.Seq, e.Hash
+	})
+	if errors.Is(err, ErrChainBroken) {
+		l.logger.Error().Err(err).Str("path", path).Msg("Audit log failed verification")
+	} else if err != nil {
+		return nil, err
+	}
+
+	l.file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Close closes the log file
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Append chains e onto the log and exports it. Seq, Time, Instance and the
+// hashes are filled in; the stored entry is returned
+func (l *Log) Append(e Entry) (Entry, error) {
+	l.mu.Lock()
+	l.seq++
+	e.Seq = l.seq
+	e.Time = l.now().UTC()
+	e.Instance = l.instance
+	e.PrevHash = l.last
+	e.Hash = e.digest()
+
+	if l.file != nil {
+		line, _ := json.Marshal(e)
+		if _, err := l.file.Write(append(line, '\n')); err != nil {
+			l.seq--
+			l.mu.Unlock()
+			return Entry{}, fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+	l.last = e.Hash
+	l.remember(e)
+	l.mu.Unlock()
+
+	if l.publisher != nil {
+		go l.publish(e)
+	}
+	return e, nil
+}
+
+// publish exports an entry; downstream sinks order entries by seq
+func (l *Log) publish(e Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := l.publisher.Publish(ctx, events.NewEvent(events.TypeAuditEntry, e.CallSid, e.FirmID, e)); err != nil {
+		l.logger.Warn().Err(err).Uint64("seq", e.Seq).Msg("Failed to export audit entry")
+	}
+}
+
+// remember keeps e for queries, dropping the oldest entry past capacity
+func (l *Log) remember(e Entry) {
+	l.entries = append(l.entries, e)
+	if l.capacity > 0 && len(l.entries) > l.capacity {
+		l.entries = append(l.entries[:0], l.entries[len(l.entries)-l.capacity:]...)
+	}
+}
+
+// Query returns matching entries, newest first
+func (l *Log) Query(f Filter) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := []Entry{}
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		if f.Limit > 0 && len(out) >= f.Limit {
+			break
+		}
+		if f.match(&l.entries[i]) {
+			out = append(out, l.entries[i])
+		}
+	}
+	return out
+}
+
+// Verify checks the whole chain: the file when the log has one, otherwise
+// the entries held in memory. It returns how many entries it checked
+func (l *Log) Verify() (int, error) {
+	if l.path == "" {
+		l.mu.Lock()
+		entries := append([]Entry(nil), l.entries...)
+		l.mu.Unlock()
+
+		checked := 0
+		for i, e := range entries {
+			// Entries before the memory window are gone, so the first kept
+			// entry's link to them can't be checked
+			prev := e.PrevHash
+			if i > 0 {
+				prev = entries[i-1].Hash
+			}
+			if err := check(e, prev); err != nil {
+				return checked, err
+			}
+			checked++
+		}
+		return checked, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock() // Hold off appends so the file isn't read mid-line
+	checked := 0
+	err := l.replay(func(Entry) { checked++ })
+	return checked, err
+}
+
+// replay reads the log file, calling fn for each entry in order, and returns
+// the first place the chain breaks. Entries after a break are still replayed,
+// so a tampered log is continued from its real end
+func (l *Log) replay(fn func(Entry)) error {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", l.path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var broken error
+	prev := ""
+	line := 0
+	for scanner.Scan() {
+		line++
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			if broken == nil {
+				broken = fmt.Errorf("%w: line %d is not an entry: %v", ErrChainBroken, line, err)
+			}
+			continue
+		}
+		if err := check(e, prev); err != nil && broken == nil {
+			broken = fmt.Errorf("line %d: %w", line, err)
+		}
+		fn(e)
+		prev = e.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log %s: %w", l.path, err)
+	}
+	return broken
+}
+
+// check verifies e follows the entry whose hash is prev
+func check(e Entry, prev string) error {
+	if e.PrevHash != prev {
+		return fmt.Errorf("%w at seq %d: previous hash does not match", ErrChainBroken, e.Seq)
+	}
+	if e.digest() != e.Hash {
+		return fmt.Errorf("%w at seq %d: entry was modified", ErrChainBroken, e.Seq)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/rs/zerolog"
+)
+
+type channelPublisher chan *events.Event
+
+func (c channelPublisher) Publish(ctx context.Context, event *events.Event) error {
+	c <- event
+	return nil
+}
+
+func appendN(t *testing.T, l *Log, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := l.Append(Entry{Actor: "ops", Kind: KindAdminRead, Method: "GET", Path: "/admin/calls", Status: 200}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+}
+
+func TestLog_ChainsEntries(t *testing.T) {
+	published := make(channelPublisher, 4)
+	l, err := Open("", "gw-1", published, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	appendN(t, l, 2)
+
+	entries := l.Query(Filter{})
+	if len(entries) != 2 || entries[0].Seq != 2 || entries[1].Seq != 1 {
+		t.Fatalf("Expected two entries newest first, got %+v", entries)
+	}
+	if entries[1].PrevHash != "" || entries[0].PrevHash != entries[1].Hash || entries[0].Instance != "gw-1" {
+		t.Errorf("Expected the second entry to chain from the first, got %+v", entries)
+	}
+	if n, err := l.Verify(); err != nil || n != 2 {
+		t.Errorf("Expected 2 verified entries, got %d, %v", n, err)
+	}
+
+	select {
+	case event := <-published:
+		if event.Type != events.TypeAuditEntry {
+			t.Errorf("Expected an audit.entry event, got %s", event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the entry to be exported")
+	}
+}
+
+func TestLog_ContinuesAndVerifiesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(path, "gw-1", nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	appendN(t, l, 2)
+	l.Close()
+
+	// Reopened, the log continues the chain on disk
+	l, err = Open(path, "gw-1", nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	appendN(t, l, 1)
+	if n, err := l.Verify(); err != nil || n != 3 {
+		t.Fatalf("Expected 3 verified entries, got %d, %v", n, err)
+	}
+	if entries := l.Query(Filter{}); len(entries) != 3 || entries[0].Seq != 3 {
+		t.Fatalf("Expected the reopened log to hold 3 entries, got %+v", entries)
+	}
+	l.Close()
+
+	// Editing an entry on disk breaks the chain
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	tampered := strings.Replace(string(data), `"actor":"ops"`, `"actor":"someone-else"`, 1)
+	if err := os.WriteFile(path, []byte(tampered), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	l, err = Open(path, "gw-1", nil, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Open of a tampered log failed: %v", err)
+	}
+	defer l.Close()
+	if _, err := l.Verify(); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("Expected ErrChainBroken, got %v", err)
+	}
+	if entry, _ := l.Append(Entry{Actor: "ops"}); entry.Seq != 4 {
+		t.Errorf("Expected appends to continue from the end of the file, got seq %d", entry.Seq)
+	}
+}
+
+func TestLog_QueryFilters(t *testing.T) {
+	l, _ := Open("", "", nil, zerolog.Nop())
+	now := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.Append(Entry{Actor: "ops", Kind: KindLiveView, CallSid: "CA1"})
+	now = now.Add(time.Hour)
+	l.Append(Entry{Actor: "admin", Kind: KindConfigChange, FirmID: "acme"})
+	l.Append(Entry{Actor: "admin", Kind: KindRecordingDownload, FirmID: "acme", CallSid: "CA2"})
+
+	tests := []struct {
+		filter Filter
+		want   int
+	}{
+		{Filter{Actor: "admin"}, 2},
+		{Filter{Kind: KindLiveView}, 1},
+		{Filter{FirmID: "acme", CallSid: "CA2"}, 1},
+		{Filter{Since: now}, 2},
+		{Filter{Until: now}, 1},
+		{Filter{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		if got := l.Query(tt.filter); len(got) != tt.want {
+			t.Errorf("Query(%+v): expected %d entries, got %d", tt.filter, tt.want, len(got))
+		}
+	}
+}
+
+func TestLog_MemoryWindow(t *testing.T) {
+	l, _ := Open("", "", nil, zerolog.Nop())
+	l.capacity = 2
+	appendN(t, l, 3)
+	entries := l.Query(Filter{})
+	if len(entries) != 2 || entries[1].Seq != 2 {
+		t.Fatalf("Expected the 2 newest entries, got %+v", entries)
+	}
+	if n, err := l.Verify(); err != nil || n != 2 {
+		t.Errorf("Expected the window to verify, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package auth authenticates callers of the admin and control endpoints with
+// static API keys or JWTs (HS256 with a shared secret, or RS256 signed by an
+// OIDC provider), and gates each endpoint on a role
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role is what a caller may do; each role includes the ones below it
+type Role int
+
+const (
+	RoleNone Role = iota
+	// RoleViewer reads state: call lists, live transcripts, usage
+	RoleViewer
+	// RoleOperator acts on calls: supervision, SMS
+	RoleOperator
+	// RoleAdmin changes configuration: routing overrides
+	RoleAdmin
+)
+
+// ParseRole parses viewer, operator or admin
+func ParseRole(s string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "viewer":
+		return RoleViewer, nil
+	case "operator":
+		return RoleOperator, nil
+	case "admin":
+		return RoleAdmin, nil
+	}
+	return RoleNone, fmt.Errorf("unknown role %q (want viewer, operator or admin)", s)
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	}
+	return "none"
+}
+
+// Principal is an authenticated caller
+type Principal struct {
+	Subject string // API key name or JWT subject
+	Role    Role
+	Method  string // "api_key" or "jwt"
+}
+
+// APIKey is a static key and the role it grants
+type APIKey struct {
+	Name string
+	Role Role
+	Key  string
+}
+
+// ParseAPIKeys parses name:role:key entries (e.g. "dashboard:viewer:s3cret"),
+// skipping blank ones
+func ParseAPIKeys(entries []string) ([]APIKey, error) {
+	var keys []APIKey
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("API key entry must be name:role:key")
+		}
+		role, err := ParseRole(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("API key %q: %w", parts[0], err)
+		}
+		keys = append(keys, APIKey{Name: parts[0], Role: role, Key: parts[2]})
+	}
+	return keys, nil
+}
+
+// Options configures an Authenticator; JWTs are accepted when JWTSecret or
+// JWKSURL is set
+type Options struct {
+	APIKeys []APIKey
+
+	JWTSecret string // Validates HS256 tokens
+	JWKSURL   string // OIDC provider key set for RS256 tokens
+	Issuer    string // Required iss claim, when set
+	Audience  string // Required aud entry, when set
+	RoleClaim string // Claim holding the role(s); dots walk nested objects (e.g. realm_access.roles)
+
+	HTTPClient *http.Client // For fetching JWKS; defaults to a client with a short timeout
+}
+
+// Errors returned by Authenticate
+var (
+	ErrNoCredentials      = errors.New("no bearer token")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator validates bearer tokens
+type Authenticator struct {
+	keys map[[sha256.Size]byte]APIKey
+	jwt  *jwtValidator
+}
+
+// New creates an authenticator; with no keys and no JWT settings it rejects everything
+func New(opts Options) (*Authenticator, error) {
+	a := &Authenticator{keys: make(map[[sha256.Size]byte]APIKey, len(opts.APIKeys))}
+	for _, key := range opts.APIKeys {
+		if key.Key == "" || key.Role == RoleNone {
+			return nil, fmt.Errorf("API key %q needs a key and a role", key.Name)
+		}
+		digest := sha256.Sum256([]byte(key.Key))
+		if _, ok := a.keys[digest]; ok {
+			return nil, fmt.Errorf("API key %q duplicates another key", key.Name)
+		}
+		a.keys[digest] = key
+	}
+
+	if opts.JWTSecret != "" || opts.JWKSURL != "" {
+		a.jwt = newJWTValidator(opts)
+	}
+	return a, nil
+}
+
+// Enabled reports whether any credentials can succeed
+func (a *Authenticator) Enabled() bool {
+	return a != nil && (len(a.keys) > 0 || a.jwt != nil)
+}
+
+// Authenticate validates the request's "Authorization: Bearer" token
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return Principal{}, ErrNoCredentials
+	}
+	token := strings.TrimSpace(header[7:])
+	if token == "" || a == nil {
+		return Principal{}, ErrNoCredentials
+	}
+
+	// Map lookup by digest, then a constant-time check of the key itself
+	digest := sha256.Sum256([]byte(token))
+	if key, ok := a.keys[digest]; ok && subtle.ConstantTimeCompare([]byte(key.Key), []byte(token)) == 1 {
+		return Principal{Subject: key.Name, Role: key.Role, Method: "api_key"}, nil
+	}
+
+	if a.jwt != nil && strings.Count(token, ".") == 2 {
+		principal, err := a.jwt.validate(r.Context(), token)
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+		return principal, nil
+	}
+	return Principal{}, ErrInvalidCredentials
+}
+
+type principalKey struct{}
+
+// FromContext returns the principal the middleware authenticated, if any
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// WithPrincipal returns ctx carrying principal, as the middleware leaves it
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Middleware authenticates every request except those under the public
+// paths (each matching itself and anything below it), so new endpoints are
+// protected by default; Require then checks each endpoint's role
+func (a *Authenticator) Middleware(next http.Handler, public ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isPublic(r.URL.Path, public) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		principal, err := a.Authenticate(r)
+		if err != nil {
+			writeUnauthorized(w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// Require allows the request only for a principal with at least role. It
+// authenticates the request itself when Middleware has not
+func (a *Authenticator) Require(role Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := FromContext(r.Context())
+		if !ok {
+			var err error
+			if principal, err = a.Authenticate(r); err != nil {
+				writeUnauthorized(w)
+				return
+			}
+			r = r.WithContext(WithPrincipal(r.Context(), principal))
+		}
+		if principal.Role < role {
+			writeError(w, http.StatusForbidden, "forbidden: requires "+role.String())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isPublic(path string, public []string) bool {
+	for _, prefix := range public {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="voice-gateway"`)
+	writeError(w, http.StatusUnauthorized, "unauthorized")
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys([]string{"dashboard:viewer:abc", "", " oncall:Operator:x:y "})
+	if err != nil {
+		t.Fatalf("ParseAPIKeys() failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(keys))
+	}
+	if keys[0].Name != "dashboard" || keys[0].Role != RoleViewer || keys[0].Key != "abc" {
+		t.Errorf("Unexpected first key %+v", keys[0])
+	}
+	if keys[1].Role != RoleOperator || keys[1].Key != "x:y" {
+		t.Errorf("Expected the key to keep its colons, got %+v", keys[1])
+	}
+
+	for _, bad := range []string{"nokey", "name:viewer:", "name:root:abc"} {
+		if _, err := ParseAPIKeys([]string{bad}); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	a, err := New(Options{APIKeys: []APIKey{
+		{Name: "dashboard", Role: RoleViewer, Key: "viewer-key"},
+		{Name: "oncall", Role: RoleOperator, Key: "operator-key"},
+	}})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	return a
+}
+
+func TestAuthenticator_APIKey(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/calls", nil)
+	req.Header.Set("Authorization", "Bearer operator-key")
+	principal, err := a.Authenticate(req)
+	if err != nil {
+		t.Fatalf("Authenticate() failed: %v", err)
+	}
+	if principal.Subject != "oncall" || principal.Role != RoleOperator || principal.Method != "api_key" {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	if _, err := a.Authenticate(req); err == nil {
+		t.Error("Expected error for an unknown key")
+	}
+	req.Header.Del("Authorization")
+	if _, err := a.Authenticate(req); err != ErrNoCredentials {
+		t.Errorf("Expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestAuthenticator_MiddlewareAndRequire(t *testing.T) {
+	a := newTestAuthenticator(t)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/calls", a.Require(RoleViewer, ok))
+	mux.Handle("/admin/sms", a.Require(RoleOperator, ok))
+	mux.Handle("/streams/twilio", ok)
+	mux.Handle("/health/live", ok)
+	mux.Handle("/healthz", ok)
+	handler := a.Middleware(mux, "/streams", "/health")
+
+	tests := []struct {
+		path string
+		key  string
+		want int
+	}{
+		{"/streams/twilio", "", http.StatusOK},
+		{"/health/live", "", http.StatusOK},
+		{"/healthz", "", http.StatusUnauthorized},
+		{"/admin/calls", "", http.StatusUnauthorized},
+		{"/admin/calls", "viewer-key", http.StatusOK},
+		{"/admin/sms", "viewer-key", http.StatusForbidden},
+		{"/admin/sms", "operator-key", http.StatusOK},
+		{"/unknown", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.key != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s with %q: expected %d, got %d", tt.path, tt.key, tt.want, rec.Code)
+		}
+	}
+}
+
+func TestNew_RejectsDuplicateKeys(t *testing.T) {
+	_, err := New(Options{APIKeys: []APIKey{
+		{Name: "a", Role: RoleViewer, Key: "same"},
+		{Name: "b", Role: RoleAdmin, Key: "same"},
+	}})
+	if err == nil {
+		t.Error("Expected error for duplicate keys")
+	}
+}
@@ -0,0 +1,294 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// clockSkew tolerates small clock differences with the token issuer
+	clockSkew = 30 * time.Second
+
+	// jwksMaxAge is how long fetched keys are trusted before refetching
+	jwksMaxAge = time.Hour
+
+	// jwksMinRefresh limits refetches triggered by unknown key IDs, so forged
+	// tokens cannot make the gateway hammer the identity provider
+	jwksMinRefresh = time.Minute
+)
+
+// jwtValidator checks JWT signatures, lifetimes, issuer, audience and role
+type jwtValidator struct {
+	secret    []byte
+	jwks      *jwksCache
+	issuer    string
+	audience  string
+	roleClaim string
+	now       func() time.Time
+}
+
+func newJWTValidator(opts Options) *jwtValidator {
+	v := &jwtValidator{
+		issuer:    opts.Issuer,
+		audience:  opts.Audience,
+		roleClaim: opts.RoleClaim,
+		now:       time.Now,
+	}
+	if v.roleClaim == "" {
+		v.roleClaim = "role"
+	}
+	if opts.JWTSecret != "" {
+		v.secret = []byte(opts.JWTSecret)
+	}
+	if opts.JWKSURL != "" {
+		client := opts.HTTPClient
+		if client == nil {
+			client = &http.Client{Timeout: 5 * time.Second}
+		}
+		v.jwks = &jwksCache{url: opts.JWKSURL, client: client}
+	}
+	return v
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// validate returns the principal a token names, if it is genuine, current and grants a role
+func (v *jwtValidator) validate(ctx context.Context, token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Principal{}, fmt.Errorf("header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, fmt.Errorf("signature: %w", err)
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+
+	// The algorithm must be one configured here, never just what the token claims
+	switch {
+	case header.Alg == "HS256" && v.secret != nil:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write(signed)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return Principal{}, errors.New("bad signature")
+		}
+	case header.Alg == "RS256" && v.jwks != nil:
+		key, err := v.jwks.key(ctx, header.Kid)
+		if err != nil {
+			return Principal{}, err
+		}
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return Principal{}, errors.New("bad signature")
+		}
+	default:
+		return Principal{}, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Principal{}, fmt.Errorf("claims: %w", err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return Principal{}, err
+	}
+
+	role := highestRole(claimAt(claims, v.roleClaim))
+	if role == RoleNone {
+		return Principal{}, fmt.Errorf("no role in claim %q", v.roleClaim)
+	}
+	subject, _ := claims["sub"].(string)
+	return Principal{Subject: subject, Role: role, Method: "jwt"}, nil
+}
+
+// checkClaims enforces exp (required), nbf, iss and aud
+func (v *jwtValidator) checkClaims(claims map[string]interface{}) error {
+	now := v.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("missing exp")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return errors.New("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("token not yet valid")
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return fmt.Errorf("issuer %q not accepted", iss)
+		}
+	}
+	if v.audience != "" && !containsString(claims["aud"], v.audience) {
+		return errors.New("audience not accepted")
+	}
+	return nil
+}
+
+// claimAt follows a dotted path through nested claim objects
+func claimAt(claims map[string]interface{}, path string) interface{} {
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// highestRole returns the highest role named by a string or list claim,
+// ignoring names that are not roles here
+func highestRole(value interface{}) Role {
+	var names []string
+	switch v := value.(type) {
+	case string:
+		names = strings.Fields(v)
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	best := RoleNone
+	for _, name := range names {
+		if role, err := ParseRole(name); err == nil && role > best {
+			best = role
+		}
+	}
+	return best
+}
+
+// containsString matches a string claim, or any entry of a list claim, against want
+func containsString(value interface{}, want string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == want
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// jwksCache fetches and caches an OIDC provider's RSA signing keys
+type jwksCache struct {
+	url    string
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// key returns the key with the given ID, refetching the set when it is stale
+// or the ID is new (the provider may have rotated keys)
+func (c *jwksCache) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.keys[kid]
+	if ok && time.Since(c.fetchedAt) < jwksMaxAge {
+		return key, nil
+	}
+	if time.Since(c.attemptedAt) >= jwksMinRefresh {
+		c.attemptedAt = time.Now()
+		err := c.fetch(ctx)
+		if err == nil {
+			key, ok = c.keys[kid]
+		} else if !ok {
+			return nil, fmt.Errorf("fetch JWKS: %w", err)
+		}
+		// On error a known key stays in use while the provider is unreachable
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetch replaces the cached keys; callers hold mu
+func (c *jwksCache) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	return nil
+}
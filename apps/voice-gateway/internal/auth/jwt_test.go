@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func segment(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret string, claims map[string]interface{}) string {
+	t.Helper()
+	unsigned := segment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	unsigned := segment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func bearer(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/admin/calls", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestJWT_HS256(t *testing.T) {
+	a, err := New(Options{JWTSecret: "shh", Issuer: "https://idp.example", Audience: "voice-gateway"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	exp := float64(time.Now().Add(time.Hour).Unix())
+	valid := map[string]interface{}{
+		"sub": "alice", "role": "operator", "exp": exp,
+		"iss": "https://idp.example", "aud": []interface{}{"other", "voice-gateway"},
+	}
+
+	principal, err := a.Authenticate(bearer(signHS256(t, "shh", valid)))
+	if err != nil {
+		t.Fatalf("Authenticate() failed: %v", err)
+	}
+	if principal.Subject != "alice" || principal.Role != RoleOperator || principal.Method != "jwt" {
+		t.Errorf("Unexpected principal %+v", principal)
+	}
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := map[string]interface{}{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+		return claims
+	}
+	rejected := map[string]string{
+		"wrong secret": signHS256(t, "guess", valid),
+		"expired":      signHS256(t, "shh", with("exp", float64(time.Now().Add(-time.Hour).Unix()))),
+		"wrong issuer": signHS256(t, "shh", with("iss", "https://evil.example")),
+		"wrong aud":    signHS256(t, "shh", with("aud", "someone-else")),
+		"no role":      signHS256(t, "shh", with("role", "superuser")),
+		"alg none":     segment(t, map[string]string{"alg": "none"}) + "." + segment(t, valid) + ".",
+	}
+	for name, token := range rejected {
+		if _, err := a.Authenticate(bearer(token)); err == nil {
+			t.Errorf("%s: expected token to be rejected", name)
+		}
+	}
+}
+
+func TestJWT_RS256FromJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetches := 0
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA", "kid": "k1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer jwks.Close()
+
+	a, err := New(Options{JWKSURL: jwks.URL, RoleClaim: "realm_access.roles"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	claims := map[string]interface{}{
+		"sub":          "bob",
+		"exp":          float64(time.Now().Add(time.Hour).Unix()),
+		"realm_access": map[string]interface{}{"roles": []interface{}{"offline_access", "viewer", "admin"}},
+	}
+
+	for i := 0; i < 2; i++ {
+		principal, err := a.Authenticate(bearer(signRS256(t, key, "k1", claims)))
+		if err != nil {
+			t.Fatalf("Authenticate() failed: %v", err)
+		}
+		if principal.Role != RoleAdmin {
+			t.Errorf("Expected the highest listed role, got %v", principal.Role)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected keys fetched once, got %d", fetches)
+	}
+
+	// An unknown key ID refetches at most once a minute
+	for i := 0; i < 3; i++ {
+		if _, err := a.Authenticate(bearer(signRS256(t, key, "k2", claims))); err == nil {
+			t.Error("Expected unknown key ID to be rejected")
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected unknown key IDs not to refetch within a minute, got %d fetches", fetches)
+	}
+
+	// HS256 is not accepted when only JWKS is configured
+	forged := signHS256(t, "", claims)
+	if _, err := a.Authenticate(bearer(forged)); err == nil || !strings.Contains(err.Error(), "unsupported algorithm") {
+		t.Errorf("Expected HS256 to be refused, got %v", err)
+	}
+}
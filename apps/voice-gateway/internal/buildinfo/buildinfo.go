@@ -0,0 +1,71 @@
+// Package buildinfo identifies the running release, so logs, metrics, CDRs
+// and webhooks can be correlated with it. Release builds set it with
+//
+//	-ldflags "-X github.com/lexiqai/voice-gateway/internal/buildinfo.Version=1.4.0
+//	          -X github.com/lexiqai/voice-gateway/internal/buildinfo.Commit=$(git rev-parse HEAD)
+//	          -X github.com/lexiqai/voice-gateway/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// A plain go build inside the repository still fills Commit and BuildTime
+// from the VCS stamp Go embeds
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at link time; see the package doc
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // Built from a tree with uncommitted changes
+}
+
+var info = load()
+
+func load() Info {
+	i := Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return i
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if i.Commit == "" {
+				i.Commit = setting.Value
+			}
+		case "vcs.time":
+			if i.BuildTime == "" {
+				i.BuildTime = setting.Value
+			}
+		case "vcs.modified":
+			i.Modified = setting.Value == "true"
+		}
+	}
+	return i
+}
+
+// Get returns the running build
+func Get() Info {
+	return info
+}
+
+// Handler serves /version
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(info)
+	}
+}
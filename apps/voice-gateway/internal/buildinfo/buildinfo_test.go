@@ -0,0 +1,22 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler()(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var got Info
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got.Version != Version || got.GoVersion != runtime.Version() {
+		t.Errorf("Expected version %s built with %s, got %+v", Version, runtime.Version(), got)
+	}
+}
@@ -0,0 +1,151 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for an unknown callback ID
+var ErrNotFound = errors.New("callback not found")
+
+// Status is a callback's lifecycle state
+type Status string
+
+const (
+	StatusScheduled Status = "scheduled" // Waiting for its time (or its next retry)
+	StatusDialing   Status = "dialing"   // An attempt is in progress
+	StatusCompleted Status = "completed" // The caller answered and talked to the assistant
+	StatusFailed    Status = "failed"    // Attempts ran out, or the call was refused
+	StatusCanceled  Status = "canceled"
+)
+
+// Callback is a call the gateway owes a caller, e.g. "call me back at 3pm"
+type Callback struct {
+	ID     string `json:"id"`
+	FirmID string `json:"firm_id"`
+	Number string `json:"number"`         // Number to call back (E.164)
+	From   string `json:"from,omitempty"` // Caller ID; the firm number they called
+
+	// At is the time the caller asked to be called
+	At time.Time `json:"at"`
+
+	// Notes tell the assistant why it is calling (what the caller wanted)
+	Notes string `json:"notes,omitempty"`
+
+	// CallSid is the call on which the callback was requested
+	CallSid string `json:"call_sid,omitempty"`
+
+	Status        Status    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastOutcome   string    `json:"last_outcome,omitempty"`  // Outcome of the latest attempt (campaign outcome)
+	LastCallSid   string    `json:"last_call_sid,omitempty"` // Call placed by the latest attempt
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Store persists callbacks and queues scheduled ones by their next attempt;
+// implementations are safe for concurrent use
+type Store interface {
+	// Save creates or replaces a callback; scheduled callbacks are queued at
+	// NextAttemptAt, dialing ones keep their lease, others leave the queue
+	Save(ctx context.Context, cb *Callback) error
+
+	// Get returns a callback by ID
+	Get(ctx context.Context, id string) (*Callback, error)
+
+	// List returns all callbacks, ordered by requested time
+	List(ctx context.Context) ([]Callback, error)
+
+	// Claim returns up to limit callbacks due at now, leasing each to one
+	// caller until now+lease; a callback not saved before its lease expires
+	// (its instance crashed mid-attempt) becomes due again
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Callback, error)
+}
+
+// MemoryStore keeps callbacks in process memory; they are lost on restart,
+// so it suits single-instance development only
+type MemoryStore struct {
+	mu        sync.Mutex
+	callbacks map[string]Callback
+	due       map[string]time.Time // Queued callback ID -> when it is due
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		callbacks: make(map[string]Callback),
+		due:       make(map[string]time.Time),
+	}
+}
+
+// Save stores the callback and queues or dequeues it by status
+func (m *MemoryStore) Save(ctx context.Context, cb *Callback) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.callbacks[cb.ID] = *cb
+	switch cb.Status {
+	case StatusScheduled:
+		m.due[cb.ID] = cb.NextAttemptAt
+	case StatusDialing:
+	default:
+		delete(m.due, cb.ID)
+	}
+	return nil
+}
+
+// Get returns a stored callback
+func (m *MemoryStore) Get(ctx context.Context, id string) (*Callback, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cb, ok := m.callbacks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &cb, nil
+}
+
+// List returns every stored callback
+func (m *MemoryStore) List(ctx context.Context) ([]Callback, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]Callback, 0, len(m.callbacks))
+	for _, cb := range m.callbacks {
+		list = append(list, cb)
+	}
+	sortCallbacks(list)
+	return list, nil
+}
+
+// Claim leases the callbacks due at now
+func (m *MemoryStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Callback, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []Callback
+	for id, at := range m.due {
+		if len(claimed) >= limit {
+			break
+		}
+		if at.After(now) {
+			continue
+		}
+		m.due[id] = now.Add(lease)
+		claimed = append(claimed, m.callbacks[id])
+	}
+	sortCallbacks(claimed)
+	return claimed, nil
+}
+
+// sortCallbacks orders callbacks by requested time
+func sortCallbacks(list []Callback) {
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].At.Equal(list[j].At) {
+			return list[i].At.Before(list[j].At)
+		}
+		return list[i].ID < list[j].ID
+	})
+}
@@ -0,0 +1,143 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+)
+
+// redisKeyPrefix namespaces callback keys in a shared Redis
+const redisKeyPrefix = "voice-gateway:callback:"
+
+// claimScript leases due callbacks by pushing their score to the lease expiry
+// KEYS: queue
+// ARGV: now ms, lease expiry ms, limit
+const claimScript = `
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[3]))
+for _, id in ipairs(due) do
+	redis.call('ZADD', KEYS[1], ARGV[2], id)
+end
+return due
+`
+
+// RedisStore keeps callbacks in Redis, shared by every gateway instance: each
+// callback is a JSON value, and a sorted set scored by next attempt is the queue
+type RedisStore struct {
+	client *cluster.RedisClient
+}
+
+// NewRedisStore creates a store on the given client
+func NewRedisStore(client *cluster.RedisClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Save stores the callback and queues or dequeues it by status
+func (r *RedisStore) Save(ctx context.Context, cb *Callback) error {
+	data, err := json.Marshal(cb)
+	if err != nil {
+		return err
+	}
+	if _, err := r.client.Do(ctx, "SET", callbackKey(cb.ID), string(data)); err != nil {
+		return fmt.Errorf("failed to save callback: %w", err)
+	}
+	if _, err := r.client.Do(ctx, "ZADD", indexKey(), unixMs(cb.At), cb.ID); err != nil {
+		return fmt.Errorf("failed to index callback: %w", err)
+	}
+
+	switch cb.Status {
+	case StatusScheduled:
+		_, err = r.client.Do(ctx, "ZADD", queueKey(), unixMs(cb.NextAttemptAt), cb.ID)
+	case StatusDialing:
+		return nil // Leased by Claim
+	default:
+		_, err = r.client.Do(ctx, "ZREM", queueKey(), cb.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to queue callback: %w", err)
+	}
+	return nil
+}
+
+// Get returns a stored callback
+func (r *RedisStore) Get(ctx context.Context, id string) (*Callback, error) {
+	reply, err := r.client.Do(ctx, "GET", callbackKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load callback: %w", err)
+	}
+	data, ok := reply.(string)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var cb Callback
+	if err := json.Unmarshal([]byte(data), &cb); err != nil {
+		return nil, fmt.Errorf("invalid callback %s: %w", id, err)
+	}
+	return &cb, nil
+}
+
+// List returns every stored callback
+func (r *RedisStore) List(ctx context.Context) ([]Callback, error) {
+	reply, err := r.client.Do(ctx, "ZRANGE", indexKey(), "0", "-1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list callbacks: %w", err)
+	}
+	return r.load(ctx, reply)
+}
+
+// Claim leases the callbacks due at now
+func (r *RedisStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Callback, error) {
+	reply, err := r.client.Do(ctx, "EVAL", claimScript, "1", queueKey(),
+		unixMs(now), unixMs(now.Add(lease)), strconv.Itoa(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim callbacks: %w", err)
+	}
+	return r.load(ctx, reply)
+}
+
+// load fetches the callbacks whose IDs are in a reply array
+func (r *RedisStore) load(ctx context.Context, reply interface{}) ([]Callback, error) {
+	ids, _ := reply.([]interface{})
+	if len(ids) == 0 {
+		return []Callback{}, nil
+	}
+
+	args := make([]string, 0, len(ids)+1)
+	args = append(args, "MGET")
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			args = append(args, callbackKey(s))
+		}
+	}
+	reply, err := r.client.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load callbacks: %w", err)
+	}
+	values, _ := reply.([]interface{})
+
+	list := make([]Callback, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var cb Callback
+		if err := json.Unmarshal([]byte(data), &cb); err == nil {
+			list = append(list, cb)
+		}
+	}
+	sortCallbacks(list)
+	return list, nil
+}
+
+func callbackKey(id string) string { return redisKeyPrefix + id }
+func indexKey() string             { return redisKeyPrefix + "all" }
+func queueKey() string             { return redisKeyPrefix + "queue" }
+
+// unixMs formats t as Unix milliseconds, the score used in the sorted sets
+func unixMs(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
@@ -0,0 +1,295 @@
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// maxScheduleAhead is how far ahead a callback may be scheduled
+	maxScheduleAhead = 30 * 24 * time.Hour
+
+	// attemptTimeout bounds one attempt, from dialing to the call's end
+	attemptTimeout = time.Hour
+
+	// claimLease outlives any attempt, so a callback is only retaken from a
+	// crashed instance
+	claimLease = 2 * attemptTimeout
+
+	// claimBatch caps the callbacks claimed per poll
+	claimBatch = 20
+)
+
+// Dialer places a callback's call and reports its outcome (implemented by the
+// campaign runner, which applies the firm's do-not-call and calling-hour rules)
+type Dialer interface {
+	Start(req campaign.Request) (*campaign.Campaign, error)
+	Wait(ctx context.Context, id string) (*campaign.Campaign, error)
+}
+
+// Config is the scheduler's retry policy and polling interval
+type Config struct {
+	MaxAttempts  int           // Calls placed before giving up
+	RetryDelay   time.Duration // Wait after an unanswered attempt
+	PollInterval time.Duration // How often due callbacks are claimed
+}
+
+// Scheduler stores requested callbacks and places each call when it is due,
+// retrying unanswered ones and publishing callback.* events as they progress
+type Scheduler struct {
+	store     Store
+	dialer    Dialer
+	firms     *firm.Registry
+	publisher events.Publisher
+	cfg       Config
+	logger    zerolog.Logger
+	now       func() time.Time
+}
+
+// NewScheduler creates a scheduler; Run places the calls
+func NewScheduler(store Store, dialer Dialer, firms *firm.Registry, publisher events.Publisher, cfg Config, logger zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		store:     store,
+		dialer:    dialer,
+		firms:     firms,
+		publisher: publisher,
+		cfg:       cfg,
+		logger:    logger.With().Str("component", "callback").Logger(),
+		now:       time.Now,
+	}
+}
+
+// Schedule validates and queues a callback; the requested time must be in
+// the future and within the firm's calling hours for the number
+func (s *Scheduler) Schedule(ctx context.Context, cb Callback) (*Callback, error) {
+	if cb.FirmID == "" {
+		return nil, fmt.Errorf("firm is required")
+	}
+	cb.Number = firm.NormalizeNumber(cb.Number)
+	if !firm.ValidNumber(cb.Number) {
+		return nil, fmt.Errorf("number %q is not E.164", cb.Number)
+	}
+	if len(cb.Notes) > twilio.MaxScriptLength-len(scriptPrefix) {
+		return nil, fmt.Errorf("notes are longer than %d characters", twilio.MaxScriptLength-len(scriptPrefix))
+	}
+
+	now := s.now()
+	switch {
+	case !cb.At.After(now):
+		return nil, fmt.Errorf("callback time %s is in the past", cb.At.Format(time.RFC3339))
+	case cb.At.After(now.Add(maxScheduleAhead)):
+		return nil, fmt.Errorf("callback time %s is more than %d days ahead", cb.At.Format(time.RFC3339), int(maxScheduleAhead.Hours()/24))
+	}
+	if err := s.firms.Get(cb.FirmID).CheckOutbound(cb.Number, cb.At); err != nil {
+		return nil, err
+	}
+
+	cb.ID = uuid.New().String()
+	cb.Status = StatusScheduled
+	cb.Attempts = 0
+	cb.NextAttemptAt = cb.At
+	cb.CreatedAt = now.UTC()
+	cb.UpdatedAt = cb.CreatedAt
+	if err := s.store.Save(ctx, &cb); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("callback_id", cb.ID).
+		Str("firm_id", cb.FirmID).
+		Str("call_sid", cb.CallSid).
+		Time("at", cb.At).
+		Msg("Callback scheduled")
+	s.publish(events.TypeCallbackScheduled, &cb)
+	return &cb, nil
+}
+
+// Get returns a callback
+func (s *Scheduler) Get(ctx context.Context, id string) (*Callback, error) {
+	return s.store.Get(ctx, id)
+}
+
+// List returns every callback, ordered by requested time
+func (s *Scheduler) List(ctx context.Context) ([]Callback, error) {
+	return s.store.List(ctx)
+}
+
+// ErrNotCancelable is returned when canceling a callback that is dialing or done
+var ErrNotCancelable = errors.New("only scheduled callbacks can be canceled")
+
+// Cancel drops a scheduled callback
+func (s *Scheduler) Cancel(ctx context.Context, id string) (*Callback, error) {
+	cb, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cb.Status != StatusScheduled {
+		return nil, ErrNotCancelable
+	}
+	cb.Status = StatusCanceled
+	cb.UpdatedAt = s.now().UTC()
+	if err := s.store.Save(ctx, cb); err != nil {
+		return nil, err
+	}
+	s.publish(events.TypeCallbackCanceled, cb)
+	return cb, nil
+}
+
+// Erase cancels the callbacks requested on (or placed by) a firm's call and
+// clears their number and notes, for a right-to-erasure request; it returns
+// the IDs of the callbacks erased
+func (s *Scheduler) Erase(ctx context.Context, firmID, callSid string) ([]string, error) {
+	all, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var erased []string
+	for i := range all {
+		cb := &all[i]
+		if cb.FirmID != firmID || (cb.CallSid != callSid && cb.LastCallSid != callSid) {
+			continue
+		}
+		if cb.Status == StatusScheduled {
+			cb.Status = StatusCanceled
+		}
+		cb.Number, cb.Notes = "", ""
+		cb.UpdatedAt = s.now().UTC()
+		if err := s.store.Save(ctx, cb); err != nil {
+			return erased, err
+		}
+		erased = append(erased, cb.ID)
+	}
+	return erased, nil
+}
+
+// Run places due callbacks until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		s.poll(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll claims due callbacks and starts an attempt for each
+func (s *Scheduler) poll(ctx context.Context) {
+	due, err := s.store.Claim(ctx, s.now(), claimLease, claimBatch)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to claim due callbacks")
+		return
+	}
+	for i := range due {
+		cb := due[i]
+		go s.attempt(ctx, &cb)
+	}
+}
+
+// scriptPrefix tells the assistant the call is a requested callback
+const scriptPrefix = "You are returning a call the caller asked for. "
+
+// attempt places one call for cb and records its outcome
+func (s *Scheduler) attempt(ctx context.Context, cb *Callback) {
+	// Canceled between the claim and now
+	if current, err := s.store.Get(ctx, cb.ID); err != nil || current.Status != StatusScheduled {
+		return
+	}
+
+	cb.Attempts++
+	cb.Status = StatusDialing
+	cb.UpdatedAt = s.now().UTC()
+	if err := s.store.Save(ctx, cb); err != nil {
+		s.logger.Warn().Err(err).Str("callback_id", cb.ID).Msg("Failed to save callback attempt")
+		return
+	}
+
+	logger := s.logger.With().Str("callback_id", cb.ID).Str("firm_id", cb.FirmID).Int("attempt", cb.Attempts).Logger()
+	outcome, callSid, err := s.dial(ctx, cb)
+	if ctx.Err() != nil {
+		// Shutting down: the lease expires and another instance picks it up
+		return
+	}
+	cb.LastOutcome = string(outcome)
+	cb.LastCallSid = callSid
+	cb.Error = ""
+	if err != nil {
+		cb.Error = err.Error()
+	}
+	cb.UpdatedAt = s.now().UTC()
+
+	eventType := events.TypeCallbackCompleted
+	switch {
+	case outcome == campaign.OutcomeCompleted || outcome == campaign.OutcomeConnected:
+		cb.Status = StatusCompleted
+		logger.Info().Str("call_sid", callSid).Msg("Callback completed")
+	case outcome == campaign.OutcomeRefused || cb.Attempts >= s.cfg.MaxAttempts:
+		cb.Status = StatusFailed
+		eventType = events.TypeCallbackFailed
+		logger.Warn().Str("outcome", cb.LastOutcome).Str("error", cb.Error).Msg("Callback failed")
+	default:
+		cb.Status = StatusScheduled
+		cb.NextAttemptAt = s.now().Add(s.cfg.RetryDelay)
+		eventType = events.TypeCallbackRetrying
+		logger.Info().Str("outcome", cb.LastOutcome).Time("next_attempt_at", cb.NextAttemptAt).Msg("Callback not answered, will retry")
+	}
+
+	if err := s.store.Save(ctx, cb); err != nil {
+		logger.Warn().Err(err).Msg("Failed to save callback outcome")
+	}
+	s.publish(eventType, cb)
+}
+
+// dial places the call through the dialer and waits for its outcome
+func (s *Scheduler) dial(ctx context.Context, cb *Callback) (campaign.Outcome, string, error) {
+	started, err := s.dialer.Start(campaign.Request{
+		FirmID:         cb.FirmID,
+		From:           cb.From,
+		Numbers:        []string{cb.Number},
+		Script:         scriptPrefix + cb.Notes,
+		CallsPerMinute: 1,
+		MaxConcurrent:  1,
+	})
+	if err != nil {
+		return campaign.OutcomeFailed, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, attemptTimeout)
+	defer cancel()
+	done, err := s.dialer.Wait(ctx, started.ID)
+	if err != nil {
+		return campaign.OutcomeFailed, "", fmt.Errorf("no outcome for the call: %w", err)
+	}
+	if len(done.Targets) == 0 {
+		return campaign.OutcomeFailed, "", fmt.Errorf("no call placed")
+	}
+	target := done.Targets[0]
+	if target.Error != "" {
+		err = errors.New(target.Error)
+	}
+	return target.Outcome, target.CallSid, err
+}
+
+// publish delivers a callback status event
+func (s *Scheduler) publish(eventType string, cb *Callback) {
+	if s.publisher == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.publisher.Publish(ctx, events.NewEvent(eventType, cb.CallSid, cb.FirmID, cb)); err != nil {
+		s.logger.Warn().Err(err).Str("callback_id", cb.ID).Str("type", eventType).Msg("Failed to publish callback event")
+	}
+}
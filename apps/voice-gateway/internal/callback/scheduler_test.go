@@ -0,0 +1,242 @@
+package callback
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/rs/zerolog"
+)
+
+// scriptedDialer answers each call with the next scripted outcome
+type scriptedDialer struct {
+	mu       sync.Mutex
+	outcomes []campaign.Outcome
+	requests []campaign.Request
+}
+
+func (d *scriptedDialer) Start(req campaign.Request) (*campaign.Campaign, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, req)
+	return &campaign.Campaign{ID: req.Numbers[0]}, nil
+}
+
+func (d *scriptedDialer) Wait(ctx context.Context, id string) (*campaign.Campaign, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	outcome := d.outcomes[0]
+	d.outcomes = d.outcomes[1:]
+	return &campaign.Campaign{ID: id, Targets: []campaign.Target{{Number: id, Outcome: outcome, CallSid: "CAout"}}}, nil
+}
+
+func (d *scriptedDialer) placed() []campaign.Request {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]campaign.Request(nil), d.requests...)
+}
+
+// eventLog records event types
+type eventLog struct {
+	mu    sync.Mutex
+	types []string
+}
+
+func (l *eventLog) Publish(ctx context.Context, event *events.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.types = append(l.types, event.Type)
+	return nil
+}
+
+func (l *eventLog) published() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.types, ",")
+}
+
+func newTestScheduler(t *testing.T, outcomes ...campaign.Outcome) (*Scheduler, *scriptedDialer, *eventLog, *time.Time) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(`{"firms": {"acme": {"timezone": "America/New_York", "outbound": {"default_timezone": "America/New_York"}}}}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	dialer := &scriptedDialer{outcomes: outcomes}
+	log := &eventLog{}
+	s := NewScheduler(NewMemoryStore(), dialer, firms, log, Config{MaxAttempts: 2, RetryDelay: 15 * time.Minute}, zerolog.Nop())
+
+	// 2024-03-12 14:00 UTC is 10:00 in New York
+	now := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, dialer, log, &now
+}
+
+// runDue claims and runs every due callback to completion
+func runDue(t *testing.T, s *Scheduler) {
+	t.Helper()
+	due, err := s.store.Claim(context.Background(), s.now(), claimLease, claimBatch)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	for i := range due {
+		s.attempt(context.Background(), &due[i])
+	}
+}
+
+func TestScheduler_ScheduleValidates(t *testing.T) {
+	s, _, _, now := newTestScheduler(t)
+	ctx := context.Background()
+	tests := []struct {
+		cb   Callback
+		want string
+	}{
+		{Callback{FirmID: "acme", Number: "12", At: now.Add(time.Hour)}, "E.164"},
+		{Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(-time.Minute)}, "past"},
+		{Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(60 * 24 * time.Hour)}, "days ahead"},
+		{Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(12 * time.Hour)}, "calling hours"}, // 22:00 in New York
+	}
+	for _, tt := range tests {
+		if _, err := s.Schedule(ctx, tt.cb); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Schedule(%+v): expected error mentioning %q, got %v", tt.cb, tt.want, err)
+		}
+	}
+}
+
+func TestScheduler_PlacesDueCallback(t *testing.T) {
+	s, dialer, log, now := newTestScheduler(t, campaign.OutcomeCompleted)
+	ctx := context.Background()
+
+	cb, err := s.Schedule(ctx, Callback{FirmID: "acme", Number: "+1 555 123 4567", From: "+15550001111", At: now.Add(5 * time.Hour), Notes: "Question about the lease"})
+	if err != nil {
+		t.Fatalf("Schedule() failed: %v", err)
+	}
+
+	runDue(t, s)
+	if len(dialer.placed()) != 0 {
+		t.Fatal("Expected no call before the requested time")
+	}
+
+	*now = now.Add(5 * time.Hour)
+	runDue(t, s)
+	placed := dialer.placed()
+	if len(placed) != 1 || placed[0].Numbers[0] != "+15551234567" || placed[0].From != "+15550001111" ||
+		!strings.Contains(placed[0].Script, "Question about the lease") {
+		t.Fatalf("Expected the callback dialed with its notes, got %+v", placed)
+	}
+
+	done, _ := s.Get(ctx, cb.ID)
+	if done.Status != StatusCompleted || done.Attempts != 1 || done.LastCallSid != "CAout" {
+		t.Errorf("Expected completed after one attempt, got %+v", done)
+	}
+	if got := log.published(); got != "callback.scheduled,callback.completed" {
+		t.Errorf("Unexpected events: %s", got)
+	}
+
+	runDue(t, s)
+	if len(dialer.placed()) != 1 {
+		t.Error("Expected a completed callback not to be dialed again")
+	}
+}
+
+func TestScheduler_RetriesThenFails(t *testing.T) {
+	s, dialer, log, now := newTestScheduler(t, campaign.OutcomeNoAnswer, campaign.OutcomeBusy)
+	ctx := context.Background()
+
+	cb, err := s.Schedule(ctx, Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("Schedule() failed: %v", err)
+	}
+
+	*now = now.Add(time.Minute)
+	runDue(t, s)
+	retrying, _ := s.Get(ctx, cb.ID)
+	if retrying.Status != StatusScheduled || !retrying.NextAttemptAt.Equal(now.Add(15*time.Minute)) {
+		t.Fatalf("Expected a retry in 15 minutes, got %+v", retrying)
+	}
+
+	*now = now.Add(15 * time.Minute)
+	runDue(t, s)
+	failed, _ := s.Get(ctx, cb.ID)
+	if failed.Status != StatusFailed || failed.Attempts != 2 || failed.LastOutcome != string(campaign.OutcomeBusy) {
+		t.Errorf("Expected failed after max attempts, got %+v", failed)
+	}
+	if len(dialer.placed()) != 2 {
+		t.Errorf("Expected 2 attempts, got %d", len(dialer.placed()))
+	}
+	if got := log.published(); got != "callback.scheduled,callback.retrying,callback.failed" {
+		t.Errorf("Unexpected events: %s", got)
+	}
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	s, dialer, _, now := newTestScheduler(t)
+	ctx := context.Background()
+	cb, _ := s.Schedule(ctx, Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(time.Hour)})
+
+	if _, err := s.Cancel(ctx, cb.ID); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	if _, err := s.Cancel(ctx, cb.ID); err != ErrNotCancelable {
+		t.Errorf("Expected ErrNotCancelable canceling twice, got %v", err)
+	}
+
+	*now = now.Add(time.Hour)
+	runDue(t, s)
+	if len(dialer.placed()) != 0 {
+		t.Error("Expected a canceled callback not to be dialed")
+	}
+}
+
+func TestScheduler_Erase(t *testing.T) {
+	s, _, _, now := newTestScheduler(t)
+	ctx := context.Background()
+
+	mine, _ := s.Schedule(ctx, Callback{FirmID: "acme", Number: "+15551234567", At: now.Add(time.Hour), Notes: "Divorce filing", CallSid: "CA1"})
+	other, _ := s.Schedule(ctx, Callback{FirmID: "acme", Number: "+15557654321", At: now.Add(time.Hour), Notes: "Lease", CallSid: "CA2"})
+
+	erased, err := s.Erase(ctx, "acme", "CA1")
+	if err != nil || len(erased) != 1 || erased[0] != mine.ID {
+		t.Fatalf("Expected the call's callback erased, got %v, %v", erased, err)
+	}
+	got, _ := s.Get(ctx, mine.ID)
+	if got.Status != StatusCanceled || got.Number != "" || got.Notes != "" {
+		t.Errorf("Expected a canceled callback without personal data, got %+v", got)
+	}
+	if kept, _ := s.Get(ctx, other.ID); kept.Status != StatusScheduled || kept.Notes != "Lease" {
+		t.Errorf("Expected other calls' callbacks kept, got %+v", kept)
+	}
+	if erased, _ := s.Erase(ctx, "globex", "CA2"); len(erased) != 0 {
+		t.Errorf("Expected another firm's erasure to leave the callback, got %v", erased)
+	}
+}
+
+func TestMemoryStore_LeaseExpires(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+	store.Save(ctx, &Callback{ID: "cb-1", Status: StatusScheduled, NextAttemptAt: now})
+
+	if claimed, _ := store.Claim(ctx, now, time.Hour, 10); len(claimed) != 1 {
+		t.Fatalf("Expected the due callback claimed, got %d", len(claimed))
+	}
+	if claimed, _ := store.Claim(ctx, now, time.Hour, 10); len(claimed) != 0 {
+		t.Error("Expected a leased callback not to be claimed twice")
+	}
+
+	// The claiming instance died mid-attempt
+	store.Save(ctx, &Callback{ID: "cb-1", Status: StatusDialing, NextAttemptAt: now})
+	if claimed, _ := store.Claim(ctx, now.Add(time.Hour), time.Hour, 10); len(claimed) != 1 {
+		t.Error("Expected the callback due again once its lease expired")
+	}
+}
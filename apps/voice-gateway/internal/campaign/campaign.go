@@ -0,0 +1,573 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+// ErrNotFound is returned for an unknown campaign ID
+var ErrNotFound = errors.New("campaign not found")
+
+// maxNumbers caps the numbers one campaign may dial
+const maxNumbers = 10000
+
+// capacityPoll is how often a campaign waiting on concurrency rechecks for a free slot
+const capacityPoll = time.Second
+
+// Dialer places outbound calls (implemented by the Twilio client)
+type Dialer interface {
+	CreateCall(ctx context.Context, call twilio.CallRequest) (string, error)
+}
+
+// Outcome is what happened when a campaign number was dialed
+type Outcome string
+
+const (
+	OutcomePending   Outcome = "pending"   // Not dialed yet
+	OutcomeDialing   Outcome = "dialing"   // Ringing, or waiting on answering machine detection
+	OutcomeConnected Outcome = "connected" // A person answered and is talking to the assistant
+	OutcomeCompleted Outcome = "completed" // A connected call ended
+	OutcomeMachine   Outcome = "machine"   // Voicemail or fax answered; the call was hung up
+	OutcomeNoAnswer  Outcome = "no_answer"
+	OutcomeBusy      Outcome = "busy"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeCanceled  Outcome = "canceled" // Never dialed, or dropped, because the campaign was canceled
+	OutcomeRefused   Outcome = "refused"  // Not dialed: on the do-not-call list or outside calling hours
+)
+
+// inFlight reports whether a call is holding a concurrency slot
+func (o Outcome) inFlight() bool {
+	return o == OutcomeDialing || o == OutcomeConnected
+}
+
+// Status is a campaign's lifecycle state
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Request describes a campaign to run on behalf of a firm
+type Request struct {
+	FirmID  string   `json:"firm_id"`
+	From    string   `json:"from,omitempty"` // Caller ID; defaults to the firm's sms.from_number
+	Numbers []string `json:"numbers"`
+
+	// Script is context for the assistant: why the firm is calling
+	Script string `json:"script,omitempty"`
+
+	CallsPerMinute int `json:"calls_per_minute,omitempty"` // Defaults to CAMPAIGN_CALLS_PER_MINUTE
+	MaxConcurrent  int `json:"max_concurrent,omitempty"`   // Defaults to CAMPAIGN_MAX_CONCURRENT
+}
+
+// Target is one number of a campaign and its outcome
+type Target struct {
+	Number          string     `json:"number"`
+	Outcome         Outcome    `json:"outcome"`
+	CallSid         string     `json:"call_sid,omitempty"`
+	AnsweredBy      string     `json:"answered_by,omitempty"` // Twilio's answering machine detection verdict
+	Error           string     `json:"error,omitempty"`
+	DialedAt        *time.Time `json:"dialed_at,omitempty"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds int        `json:"duration_seconds,omitempty"`
+}
+
+// Campaign is a batch of outbound calls and their per-number outcomes
+type Campaign struct {
+	ID             string     `json:"id"`
+	FirmID         string     `json:"firm_id"`
+	From           string     `json:"from"`
+	Script         string     `json:"script,omitempty"`
+	CallsPerMinute int        `json:"calls_per_minute"`
+	MaxConcurrent  int        `json:"max_concurrent"`
+	Status         Status     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	Targets        []Target   `json:"targets"`
+}
+
+// Counts tallies targets by outcome
+func (c *Campaign) Counts() map[Outcome]int {
+	counts := make(map[Outcome]int)
+	for _, t := range c.Targets {
+		counts[t.Outcome]++
+	}
+	return counts
+}
+
+// clone returns a copy safe to hand out while the campaign runs
+func (c *Campaign) clone() Campaign {
+	out := *c
+	out.Targets = append([]Target(nil), c.Targets...)
+	return out
+}
+
+// Config holds the runner's defaults and callback settings
+type Config struct {
+	// PublicURL is the gateway's externally reachable base URL; Twilio fetches
+	// TwiML and posts call status there, and media streams connect back to it
+	PublicURL string
+
+	CallsPerMinute int           // Default pace
+	MaxConcurrent  int           // Default calls in flight per campaign
+	RingTimeout    time.Duration // How long a number may ring unanswered
+}
+
+// run is a campaign in progress
+type run struct {
+	campaign Campaign
+	cancel   context.CancelFunc
+	wake     chan struct{} // Signaled when a call ends and frees a slot
+	done     chan struct{} // Closed when the campaign finishes
+}
+
+// Runner places campaign calls at a steady pace, within the campaign's and
+// the firm's concurrency limits, and tracks each number's outcome
+type Runner struct {
+	dialer    Dialer
+	firms     *firm.Registry
+	calls     cluster.Registry
+	publisher events.Publisher
+	cfg       Config
+	logger    zerolog.Logger
+	now       func() time.Time
+
+	mu    sync.Mutex
+	runs  map[string]*run
+	bySid map[string]*run // Call SID -> campaign that placed it
+}
+
+// NewRunner creates a campaign runner; calls (the active-call directory)
+// counts the firm's live calls toward its concurrency limit
+func NewRunner(dialer Dialer, firms *firm.Registry, calls cluster.Registry, publisher events.Publisher, cfg Config, logger zerolog.Logger) *Runner {
+	return &Runner{
+		dialer:    dialer,
+		firms:     firms,
+		calls:     calls,
+		publisher: publisher,
+		cfg:       cfg,
+		logger:    logger.With().Str("component", "campaign").Logger(),
+		now:       time.Now,
+		runs:      make(map[string]*run),
+		bySid:     make(map[string]*run),
+	}
+}
+
+// Start validates req and begins dialing in the background
+func (r *Runner) Start(req Request) (*Campaign, error) {
+	if req.FirmID == "" {
+		return nil, fmt.Errorf("firm_id is required")
+	}
+	if !r.firms.Has(req.FirmID) {
+		return nil, fmt.Errorf("unknown firm %q", req.FirmID)
+	}
+	settings := r.firms.Get(req.FirmID)
+
+	from := req.From
+	if from == "" {
+		from = settings.SMS.FromNumber
+	}
+	if !firm.ValidNumber(from) {
+		return nil, fmt.Errorf("from must be an E.164 number on the Twilio account (or set the firm's sms.from_number)")
+	}
+
+	if len(req.Numbers) == 0 {
+		return nil, fmt.Errorf("numbers is required")
+	}
+	if len(req.Numbers) > maxNumbers {
+		return nil, fmt.Errorf("at most %d numbers per campaign", maxNumbers)
+	}
+	seen := make(map[string]bool, len(req.Numbers))
+	targets := make([]Target, 0, len(req.Numbers))
+	for _, number := range req.Numbers {
+		number = firm.NormalizeNumber(number)
+		if !firm.ValidNumber(number) {
+			return nil, fmt.Errorf("number %q is not E.164", number)
+		}
+		if seen[number] {
+			continue
+		}
+		seen[number] = true
+		targets = append(targets, Target{Number: number, Outcome: OutcomePending})
+	}
+
+	if len(req.Script) > twilio.MaxScriptLength {
+		return nil, fmt.Errorf("script is longer than %d characters", twilio.MaxScriptLength)
+	}
+	if req.CallsPerMinute < 0 || req.MaxConcurrent < 0 {
+		return nil, fmt.Errorf("calls_per_minute and max_concurrent must be non-negative")
+	}
+	perMinute := req.CallsPerMinute
+	if perMinute == 0 {
+		perMinute = r.cfg.CallsPerMinute
+	}
+	maxConcurrent := req.MaxConcurrent
+	if maxConcurrent == 0 {
+		maxConcurrent = r.cfg.MaxConcurrent
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &run{
+		campaign: Campaign{
+			ID:             uuid.New().String(),
+			FirmID:         req.FirmID,
+			From:           from,
+			Script:         req.Script,
+			CallsPerMinute: perMinute,
+			MaxConcurrent:  maxConcurrent,
+			Status:         StatusRunning,
+			CreatedAt:      time.Now().UTC(),
+			Targets:        targets,
+		},
+		cancel: cancel,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	r.runs[c.campaign.ID] = c
+	snapshot := c.campaign.clone()
+	r.mu.Unlock()
+
+	r.logger.Info().
+		Str("campaign_id", snapshot.ID).
+		Str("firm_id", snapshot.FirmID).
+		Int("numbers", len(targets)).
+		Int("calls_per_minute", perMinute).
+		Int("max_concurrent", maxConcurrent).
+		Msg("Campaign started")
+
+	go r.run(ctx, c)
+	return &snapshot, nil
+}
+
+// Get returns a snapshot of a campaign
+func (r *Runner) Get(id string) (*Campaign, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.runs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	snapshot := c.campaign.clone()
+	return &snapshot, nil
+}
+
+// List returns snapshots of all campaigns, newest first
+func (r *Runner) List() []Campaign {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]Campaign, 0, len(r.runs))
+	for _, c := range r.runs {
+		list = append(list, c.campaign.clone())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list
+}
+
+// Wait blocks until a campaign finishes and returns its final outcomes
+func (r *Runner) Wait(ctx context.Context, id string) (*Campaign, error) {
+	r.mu.Lock()
+	c, ok := r.runs[id]
+	r.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.Get(id)
+}
+
+// Cancel stops dialing a campaign's remaining numbers; calls already
+// connected continue and still report their outcome
+func (r *Runner) Cancel(id string) error {
+	r.mu.Lock()
+	c, ok := r.runs[id]
+	r.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	c.cancel()
+	return nil
+}
+
+// run dials each pending number in turn, then waits for calls in flight to end
+func (r *Runner) run(ctx context.Context, c *run) {
+	interval := time.Minute / time.Duration(c.campaign.CallsPerMinute)
+	var lastDial time.Time
+
+	for i := range c.campaign.Targets {
+		if !r.waitFor(ctx, c, time.Until(lastDial.Add(interval)), func() bool { return r.hasCapacity(ctx, c) }) {
+			r.finish(c, StatusCanceled)
+			return
+		}
+		if r.dial(ctx, c, i) {
+			lastDial = time.Now()
+		}
+	}
+
+	if !r.waitFor(ctx, c, 0, func() bool { return r.inFlight(c) == 0 }) {
+		r.finish(c, StatusCanceled)
+		return
+	}
+	r.finish(c, StatusCompleted)
+}
+
+// waitFor sleeps for delay, then until ready holds; false when canceled
+func (r *Runner) waitFor(ctx context.Context, c *run, delay time.Duration, ready func() bool) bool {
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+	for !ready() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.wake:
+		case <-time.After(capacityPoll):
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// inFlight counts the campaign's calls holding a slot
+func (r *Runner) inFlight(c *run) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, t := range c.campaign.Targets {
+		if t.Outcome.inFlight() {
+			n++
+		}
+	}
+	return n
+}
+
+// hasCapacity reports whether another call fits under the campaign's limit
+// and the firm's concurrent call limit (its live calls plus ringing campaign calls)
+func (r *Runner) hasCapacity(ctx context.Context, c *run) bool {
+	if r.inFlight(c) >= c.campaign.MaxConcurrent {
+		return false
+	}
+
+	limit := r.firms.Get(c.campaign.FirmID).Routing.MaxConcurrentCalls
+	if limit <= 0 || r.calls == nil {
+		return true
+	}
+	active, err := r.calls.List(ctx)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("campaign_id", c.campaign.ID).Msg("Listing active calls failed, holding dialing")
+		return false
+	}
+	used := 0
+	for _, call := range active {
+		if call.FirmID == c.campaign.FirmID {
+			used++
+		}
+	}
+
+	// Ringing calls are not in the directory until their media stream starts
+	r.mu.Lock()
+	for _, other := range r.runs {
+		if other.campaign.FirmID != c.campaign.FirmID {
+			continue
+		}
+		for _, t := range other.campaign.Targets {
+			if t.Outcome == OutcomeDialing {
+				used++
+			}
+		}
+	}
+	r.mu.Unlock()
+	return used < limit
+}
+
+// dial places the call for target i unless the firm's do-not-call or
+// calling-hour rules forbid it; false when no call was attempted
+func (r *Runner) dial(ctx context.Context, c *run, i int) bool {
+	r.mu.Lock()
+	number := c.campaign.Targets[i].Number
+	now := r.now().UTC()
+	if err := r.firms.Get(c.campaign.FirmID).CheckOutbound(number, now); err != nil {
+		c.campaign.Targets[i].Outcome = OutcomeRefused
+		c.campaign.Targets[i].Error = err.Error()
+		r.mu.Unlock()
+		r.logger.Warn().
+			Str("campaign_id", c.campaign.ID).
+			Str("firm_id", c.campaign.FirmID).
+			Str("to", number).
+			Str("reason", err.Error()).
+			Msg("Refusing outbound call")
+		return false
+	}
+	c.campaign.Targets[i].Outcome = OutcomeDialing
+	c.campaign.Targets[i].DialedAt = &now
+	r.mu.Unlock()
+
+	base := strings.TrimSuffix(r.cfg.PublicURL, "/") + "/twilio/campaigns/" + c.campaign.ID
+	callSid, err := r.dialer.CreateCall(ctx, twilio.CallRequest{
+		From:             c.campaign.From,
+		To:               number,
+		URL:              base + "/answer",
+		StatusCallback:   base + "/status",
+		MachineDetection: true,
+		RingTimeout:      r.cfg.RingTimeout,
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	target := &c.campaign.Targets[i]
+	if err != nil {
+		target.Outcome = OutcomeFailed
+		target.Error = err.Error()
+		target.EndedAt = &now
+		r.logger.Warn().Err(err).Str("campaign_id", c.campaign.ID).Str("to", number).Msg("Campaign call failed to dial")
+		return true
+	}
+	target.CallSid = callSid
+	r.bySid[callSid] = c
+	return true
+}
+
+// target finds a campaign's target by call SID; called with mu held
+func (r *Runner) target(id, callSid string) (*run, *Target) {
+	c, ok := r.bySid[callSid]
+	if !ok || c.campaign.ID != id {
+		return nil, nil
+	}
+	for i := range c.campaign.Targets {
+		if c.campaign.Targets[i].CallSid == callSid {
+			return c, &c.campaign.Targets[i]
+		}
+	}
+	return nil, nil
+}
+
+// Answered decides what an answered campaign call does: people are connected
+// to the assistant, and answering machines and faxes are hung up on
+func (r *Runner) Answered(id, callSid, answeredBy string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, target := r.target(id, callSid)
+	if target == nil {
+		return "", ErrNotFound
+	}
+	target.AnsweredBy = answeredBy
+
+	if strings.HasPrefix(answeredBy, "machine") || answeredBy == "fax" {
+		target.Outcome = OutcomeMachine
+		r.logger.Info().Str("campaign_id", id).Str("call_sid", callSid).Str("answered_by", answeredBy).Msg("Campaign call reached a machine, hanging up")
+		return twilio.HangupTwiML, nil
+	}
+	if c.campaign.Status != StatusRunning {
+		target.Outcome = OutcomeCanceled
+		return twilio.HangupTwiML, nil
+	}
+
+	// "human", or "unknown" when detection could not decide: a person may be listening
+	target.Outcome = OutcomeConnected
+	r.logger.Info().Str("campaign_id", id).Str("call_sid", callSid).Str("answered_by", answeredBy).Msg("Campaign call answered, connecting assistant")
+	return twilio.StreamTwiML(twilio.StreamURL(r.cfg.PublicURL), map[string]string{
+		twilio.ParamFirmID:   c.campaign.FirmID,
+		twilio.ParamCampaign: c.campaign.ID,
+		twilio.ParamFrom:     target.Number,
+		twilio.ParamTo:       c.campaign.From,
+		twilio.ParamScript:   c.campaign.Script,
+	}), nil
+}
+
+// StatusChanged records a call's final status from Twilio's status callback
+func (r *Runner) StatusChanged(id, callSid, callStatus string, durationSeconds int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, target := r.target(id, callSid)
+	if target == nil {
+		return ErrNotFound
+	}
+
+	switch callStatus {
+	case "completed":
+		switch target.Outcome {
+		case OutcomeDialing, OutcomeConnected:
+			target.Outcome = OutcomeCompleted
+		}
+		target.DurationSeconds = durationSeconds
+	case "busy":
+		target.Outcome = OutcomeBusy
+	case "no-answer":
+		target.Outcome = OutcomeNoAnswer
+	case "failed":
+		target.Outcome = OutcomeFailed
+	case "canceled":
+		target.Outcome = OutcomeCanceled
+	default:
+		return nil // queued, initiated, ringing, in-progress
+	}
+
+	now := time.Now().UTC()
+	target.EndedAt = &now
+	delete(r.bySid, callSid)
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// finish marks the campaign done, cancels numbers never dialed and reports the outcomes
+func (r *Runner) finish(c *run, status Status) {
+	r.mu.Lock()
+	now := time.Now().UTC()
+	c.campaign.Status = status
+	c.campaign.FinishedAt = &now
+	for i := range c.campaign.Targets {
+		if c.campaign.Targets[i].Outcome == OutcomePending {
+			c.campaign.Targets[i].Outcome = OutcomeCanceled
+		}
+	}
+	snapshot := c.campaign.clone()
+	r.mu.Unlock()
+	c.cancel()
+	close(c.done)
+
+	counts := snapshot.Counts()
+	r.logger.Info().
+		Str("campaign_id", snapshot.ID).
+		Str("firm_id", snapshot.FirmID).
+		Str("status", string(status)).
+		Int("connected", counts[OutcomeConnected]+counts[OutcomeCompleted]).
+		Int("machine", counts[OutcomeMachine]).
+		Int("no_answer", counts[OutcomeNoAnswer]).
+		Msg("Campaign finished")
+
+	if r.publisher == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := r.publisher.Publish(ctx, events.NewEvent(events.TypeCampaignCompleted, "", snapshot.FirmID, snapshot)); err != nil {
+		r.logger.Warn().Err(err).Str("campaign_id", snapshot.ID).Msg("Failed to publish campaign outcomes")
+	}
+}
@@ -0,0 +1,292 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+// fakeDialer records outbound calls
+type fakeDialer struct {
+	mu    sync.Mutex
+	calls []twilio.CallRequest
+}
+
+func (d *fakeDialer) CreateCall(ctx context.Context, call twilio.CallRequest) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = append(d.calls, call)
+	return fmt.Sprintf("CA%d", len(d.calls)), nil
+}
+
+func (d *fakeDialer) placed() []twilio.CallRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]twilio.CallRequest(nil), d.calls...)
+}
+
+// recordingPublisher captures published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []*events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event *events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) published() []*events.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*events.Event(nil), p.events...)
+}
+
+func newTestRunner(t *testing.T, firmsJSON string, calls cluster.Registry) (*Runner, *fakeDialer, *recordingPublisher) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(firmsJSON), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	dialer := &fakeDialer{}
+	publisher := &recordingPublisher{}
+	runner := NewRunner(dialer, firms, calls, publisher, Config{
+		PublicURL:      "https://gateway.example.com",
+		CallsPerMinute: 6000,
+		MaxConcurrent:  2,
+	}, zerolog.Nop())
+	runner.now = func() time.Time { return time.Date(2024, 3, 12, 15, 0, 0, 0, time.UTC) }
+	return runner, dialer, publisher
+}
+
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+const acmeFirm = `{"firms": {"acme": {"sms": {"from_number": "+15550001111"}, "outbound": {"default_timezone": "UTC"}}}}`
+
+func TestRunner_StartValidates(t *testing.T) {
+	runner, _, _ := newTestRunner(t, acmeFirm, nil)
+	tests := []struct {
+		req  Request
+		want string
+	}{
+		{Request{Numbers: []string{"+15551234567"}}, "firm_id"},
+		{Request{FirmID: "other", Numbers: []string{"+15551234567"}}, "unknown firm"},
+		{Request{FirmID: "acme"}, "numbers"},
+		{Request{FirmID: "acme", Numbers: []string{"555-1234"}}, "E.164"},
+		{Request{FirmID: "acme", From: "nope", Numbers: []string{"+15551234567"}}, "from"},
+		{Request{FirmID: "acme", Numbers: []string{"+15551234567"}, Script: strings.Repeat("x", twilio.MaxScriptLength+1)}, "script"},
+	}
+	for _, tt := range tests {
+		if _, err := runner.Start(tt.req); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Start(%+v): expected error mentioning %q, got %v", tt.req, tt.want, err)
+		}
+	}
+}
+
+func TestRunner_DialsWithinConcurrencyAndReportsOutcomes(t *testing.T) {
+	runner, dialer, publisher := newTestRunner(t, acmeFirm, nil)
+
+	started, err := runner.Start(Request{
+		FirmID:  "acme",
+		Numbers: []string{"+15551230001", "+15551230002", "+15551230003", "+15551230002"},
+		Script:  "Reminder of tomorrow's consultation",
+	})
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if len(started.Targets) != 3 {
+		t.Fatalf("Expected duplicate numbers dropped, got %d targets", len(started.Targets))
+	}
+
+	// Two calls fit under max_concurrent; the third waits for a slot
+	waitUntil(t, "two calls", func() bool { return len(dialer.placed()) == 2 })
+	time.Sleep(50 * time.Millisecond)
+	if n := len(dialer.placed()); n != 2 {
+		t.Fatalf("Expected 2 calls in flight, got %d", n)
+	}
+	first := dialer.placed()[0]
+	if first.From != "+15550001111" || !first.MachineDetection ||
+		first.URL != "https://gateway.example.com/twilio/campaigns/"+started.ID+"/answer" {
+		t.Errorf("Unexpected call request: %+v", first)
+	}
+
+	// A person answers the first call and is connected to the assistant
+	twiml, err := runner.Answered(started.ID, "CA1", "human")
+	if err != nil {
+		t.Fatalf("Answered() failed: %v", err)
+	}
+	for _, want := range []string{`url="wss://gateway.example.com/streams/twilio"`, `name="campaign" value="` + started.ID + `"`, `name="from" value="+15551230001"`, `name="script"`} {
+		if !strings.Contains(twiml, want) {
+			t.Errorf("Expected TwiML to contain %s, got %s", want, twiml)
+		}
+	}
+
+	// Voicemail picks up the second call: hang up, freeing a slot once it ends
+	if twiml, _ := runner.Answered(started.ID, "CA2", "machine_end_beep"); twiml != twilio.HangupTwiML {
+		t.Errorf("Expected hangup for a machine, got %s", twiml)
+	}
+	runner.StatusChanged(started.ID, "CA2", "completed", 3)
+	waitUntil(t, "third call", func() bool { return len(dialer.placed()) == 3 })
+
+	runner.StatusChanged(started.ID, "CA3", "no-answer", 0)
+	runner.StatusChanged(started.ID, "CA1", "completed", 95)
+
+	waitUntil(t, "campaign to finish", func() bool { return len(publisher.published()) == 1 })
+	event := publisher.published()[0]
+	if event.Type != events.TypeCampaignCompleted || event.FirmID != "acme" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	done, _ := runner.Get(started.ID)
+	if done.Status != StatusCompleted {
+		t.Errorf("Expected completed status, got %s", done.Status)
+	}
+	want := []Outcome{OutcomeCompleted, OutcomeMachine, OutcomeNoAnswer}
+	for i, target := range done.Targets {
+		if target.Outcome != want[i] {
+			t.Errorf("Target %s: expected %s, got %s", target.Number, want[i], target.Outcome)
+		}
+	}
+	if done.Targets[0].DurationSeconds != 95 {
+		t.Errorf("Expected connected call duration 95, got %d", done.Targets[0].DurationSeconds)
+	}
+}
+
+func TestRunner_RespectsFirmConcurrency(t *testing.T) {
+	calls := cluster.NewLocalRegistry("gw-1", "")
+	calls.Register(context.Background(), cluster.Call{CallSid: "CAinbound", FirmID: "acme"}, 0)
+	runner, dialer, _ := newTestRunner(t, `{"firms": {"acme": {"routing": {"max_concurrent_calls": 2}, "sms": {"from_number": "+15550001111"}, "outbound": {"default_timezone": "UTC"}}}}`, calls)
+
+	started, err := runner.Start(Request{FirmID: "acme", Numbers: []string{"+15551230001", "+15551230002"}})
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	// One inbound call is live, so only one campaign call fits under the firm's limit
+	waitUntil(t, "first call", func() bool { return len(dialer.placed()) == 1 })
+	time.Sleep(50 * time.Millisecond)
+	if n := len(dialer.placed()); n != 1 {
+		t.Fatalf("Expected the firm limit to hold dialing at 1 call, got %d", n)
+	}
+
+	calls.Unregister(context.Background(), cluster.Call{CallSid: "CAinbound", FirmID: "acme"})
+	waitUntil(t, "second call", func() bool { return len(dialer.placed()) == 2 })
+	runner.Cancel(started.ID)
+}
+
+func TestRunner_Cancel(t *testing.T) {
+	runner, dialer, publisher := newTestRunner(t, acmeFirm, nil)
+	started, err := runner.Start(Request{FirmID: "acme", Numbers: []string{"+15551230001", "+15551230002", "+15551230003"}})
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	waitUntil(t, "two calls", func() bool { return len(dialer.placed()) == 2 })
+
+	if err := runner.Cancel(started.ID); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	waitUntil(t, "campaign to finish", func() bool { return len(publisher.published()) == 1 })
+
+	done, _ := runner.Get(started.ID)
+	if done.Status != StatusCanceled || done.Targets[2].Outcome != OutcomeCanceled {
+		t.Errorf("Expected canceled campaign with the undialed number canceled, got %+v", done)
+	}
+	if twiml, _ := runner.Answered(started.ID, "CA1", "human"); twiml != twilio.HangupTwiML {
+		t.Errorf("Expected calls answered after cancel to hang up, got %s", twiml)
+	}
+	if err := runner.Cancel("missing"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWebhooks_RequireSignature(t *testing.T) {
+	runner, dialer, _ := newTestRunner(t, acmeFirm, nil)
+	started, err := runner.Start(Request{FirmID: "acme", Numbers: []string{"+15551230001"}})
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	waitUntil(t, "call", func() bool { return len(dialer.placed()) == 1 })
+	defer runner.Cancel(started.ID)
+
+	mux := http.NewServeMux()
+	NewWebhooks(runner, "token").Register(mux)
+
+	path := "/twilio/campaigns/" + started.ID + "/answer"
+	form := url.Values{"CallSid": {"CA1"}, "AnsweredBy": {"human"}}
+	post := func(signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(twilio.SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("forged"); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a bad signature, got %d", rec.Code)
+	}
+
+	rec := post(twilio.Sign("token", "https://gateway.example.com"+path, form))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<Stream") {
+		t.Errorf("Expected stream TwiML, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRunner_RefusesDoNotCallAndOffHours(t *testing.T) {
+	runner, dialer, publisher := newTestRunner(t, `{"firms": {"acme": {
+		"sms": {"from_number": "+15550001111"},
+		"outbound": {
+			"do_not_call": ["+1 (555) 123-0001"],
+			"timezones": {"+1808": "Pacific/Honolulu"},
+			"default_timezone": "UTC"
+		}
+	}}}`, nil)
+
+	// 15:00 UTC is 05:00 in Honolulu, before calling hours open
+	started, err := runner.Start(Request{FirmID: "acme", Numbers: []string{"+15551230001", "+18085550123", "+15551230002"}})
+	if err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	waitUntil(t, "allowed call", func() bool { return len(dialer.placed()) == 1 })
+	if to := dialer.placed()[0].To; to != "+15551230002" {
+		t.Errorf("Expected only the allowed number dialed, got %s", to)
+	}
+	runner.StatusChanged(started.ID, "CA1", "busy", 0)
+	waitUntil(t, "campaign to finish", func() bool { return len(publisher.published()) == 1 })
+
+	done, _ := runner.Get(started.ID)
+	if done.Targets[0].Outcome != OutcomeRefused || !strings.Contains(done.Targets[0].Error, "do-not-call") {
+		t.Errorf("Expected the DNC number refused, got %+v", done.Targets[0])
+	}
+	if done.Targets[1].Outcome != OutcomeRefused || !strings.Contains(done.Targets[1].Error, "calling hours") {
+		t.Errorf("Expected the Honolulu number refused, got %+v", done.Targets[1])
+	}
+}
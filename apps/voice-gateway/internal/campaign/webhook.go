@@ -0,0 +1,64 @@
+package campaign
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+)
+
+// Webhooks serves the TwiML and status callbacks Twilio sends for campaign
+// calls; requests must carry a valid X-Twilio-Signature
+type Webhooks struct {
+	runner    *Runner
+	authToken string
+}
+
+// NewWebhooks creates the callback handlers, checking signatures with the
+// account's auth token
+func NewWebhooks(runner *Runner, authToken string) *Webhooks {
+	return &Webhooks{runner: runner, authToken: authToken}
+}
+
+// Register mounts the callbacks on mux under /twilio/campaigns/
+func (h *Webhooks) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /twilio/campaigns/{id}/answer", h.answer)
+	mux.HandleFunc("POST /twilio/campaigns/{id}/status", h.status)
+}
+
+// verify rejects requests Twilio did not sign
+func (h *Webhooks) verify(w http.ResponseWriter, r *http.Request) bool {
+	if !twilio.ValidRequest(r, h.authToken, h.runner.cfg.PublicURL) {
+		h.runner.logger.Warn().Str("path", r.URL.Path).Msg("Rejecting campaign callback with an invalid Twilio signature")
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// answer returns the TwiML for an answered call
+func (h *Webhooks) answer(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	twiml, err := h.runner.Answered(r.PathValue("id"), r.PostForm.Get("CallSid"), r.PostForm.Get("AnsweredBy"))
+	if errors.Is(err, ErrNotFound) {
+		// Not a call we placed (or this instance restarted): do not leave it open
+		twiml = twilio.HangupTwiML
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write([]byte(twiml))
+}
+
+// status records a call's progress
+func (h *Webhooks) status(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	duration, _ := strconv.Atoi(r.PostForm.Get("CallDuration"))
+	if err := h.runner.StatusChanged(r.PathValue("id"), r.PostForm.Get("CallSid"), r.PostForm.Get("CallStatus"), duration); err != nil {
+		h.runner.logger.Debug().Err(err).Str("call_sid", r.PostForm.Get("CallSid")).Msg("Ignoring status for an unknown campaign call")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,219 @@
+package cdr
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/buildinfo"
+)
+
+// Routing records the call-start routing decision
+type Routing struct {
+	Action     string `json:"action"`
+	Reason     string `json:"reason"`
+	TransferTo string `json:"transfer_to,omitempty"`
+	Override   bool   `json:"override,omitempty"`
+}
+
+// Screening records the caller screening outcome
+type Screening struct {
+	Verdict   string  `json:"verdict"`
+	Reason    string  `json:"reason,omitempty"`
+	Score     float64 `json:"score,omitempty"`
+	Challenge string  `json:"challenge,omitempty"` // passed or failed, when challenged
+}
+
+// SMS records a text message sent during the call
+type SMS struct {
+	SID      string    `json:"message_sid"`
+	To       string    `json:"to"`
+	Template string    `json:"template,omitempty"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// Verification records the caller identity checks made during the call
+type Verification struct {
+	Method     string     `json:"method"` // pin or sms_code, of the latest check
+	Status     string     `json:"status"` // verified, failed, locked, expired, or no_response
+	Attempts   int        `json:"attempts"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// Retention tells downstream sinks when the firm's policy requires them to
+// delete the call's data; unset times keep it indefinitely
+type Retention struct {
+	RecordingUntil  *time.Time `json:"recording_until,omitempty"`
+	TranscriptUntil *time.Time `json:"transcript_until,omitempty"`
+}
+
+// Escalation records sentiment-triggered escalation
+type Escalation struct {
+	Trigger          string    `json:"trigger"` // escalation_request, profanity, or frustration
+	Signals          []string  `json:"signals,omitempty"`
+	Sentiment        float64   `json:"sentiment"` // Mean caller sentiment when escalated
+	EscalatedAt      time.Time `json:"escalated_at"`
+	TransferOffered  bool      `json:"transfer_offered,omitempty"`
+	TransferAccepted bool      `json:"transfer_accepted,omitempty"`
+}
+
+// Consent records the legal disclaimer and the caller's recording consent
+type Consent struct {
+	DisclaimerPlayed bool       `json:"disclaimer_played"`
+	Status           string     `json:"status,omitempty"` // granted, declined, or no_response, when consent was requested
+	Method           string     `json:"method,omitempty"` // speech or dtmf
+	AnsweredAt       *time.Time `json:"answered_at,omitempty"`
+}
+
+// Usage is the billable consumption of a call, attributed to its firm
+type Usage struct {
+	STTSeconds         float64 `json:"stt_seconds"`         // Audio billed by the STT provider
+	TTSCharacters      int64   `json:"tts_characters"`      // Characters synthesized
+	OrchestratorTokens int64   `json:"orchestrator_tokens"` // LLM tokens reported by the orchestrator
+	TelephonyMinutes   float64 `json:"telephony_minutes"`   // Call length in started minutes, as carriers bill
+}
+
+// TalkOver measures the caller and the agent speaking at once, for judging
+// barge-in and turn-taking across releases
+type TalkOver struct {
+	Overlaps           int     `json:"overlaps"`                // Stretches where both spoke
+	CallerBargeIns     int     `json:"caller_barge_ins"`        // Overlaps the caller started, over the agent
+	AgentTalkOvers     int     `json:"agent_talk_overs"`        // Overlaps the agent started, over the caller
+	OverlapSecs        float64 `json:"overlap_seconds"`         // Total time both spoke
+	LongestOverlapSecs float64 `json:"longest_overlap_seconds"` // Longest single overlap
+}
+
+// Call outcomes: the funnel stage each call ended in, for firm reporting
+const (
+	OutcomeAnsweredByAI = "answered_by_ai"        // The AI completed at least one turn with the caller
+	OutcomeTransferred  = "transferred"           // The call was handed to a person
+	OutcomeVoicemail    = "voicemail"             // The caller was sent to voicemail
+	OutcomeAbandoned    = "abandoned_in_greeting" // The caller hung up before the AI answered
+	OutcomeFailed       = "failed_technical"      // A gateway or provider failure ended or spoiled the call
+	OutcomeRejected     = "rejected"              // Screening, attribution, parameter checks or consent refused the call
+)
+
+// Record is the call detail record emitted when a call ends
+type Record struct {
+	CallSid        string    `json:"call_sid"`
+	StreamSid      string    `json:"stream_sid,omitempty"`
+	AccountSid     string    `json:"account_sid,omitempty"`
+	ConversationID string    `json:"conversation_id"`
+	FirmID         string    `json:"firm_id,omitempty"`
+	UserID         string    `json:"user_id,omitempty"`
+	CallID         string    `json:"call_id,omitempty"`
+	CallerNumber   string    `json:"caller_number,omitempty"`
+	Language       string    `json:"language,omitempty"`       // Recognition language the stream asked for
+	Campaign       string    `json:"campaign,omitempty"`       // Campaign label from the stream parameters
+	ContactID      string    `json:"contact_id,omitempty"`     // CRM contact matched by caller ID
+	ContactSource  string    `json:"contact_source,omitempty"` // Provider that matched the contact
+	StartedAt      time.Time `json:"started_at"`
+	EndedAt        time.Time `json:"ended_at"`
+	DurationSecs   float64   `json:"duration_seconds"`
+	GatewayVersion string    `json:"gateway_version,omitempty"` // Release that handled the call
+
+	// Screening is the spam screening outcome, when screening ran
+	Screening *Screening `json:"screening,omitempty"`
+
+	// Routing is how the call was handled at start
+	Routing Routing `json:"routing"`
+
+	// SMS lists follow-up texts sent during the call
+	SMS []SMS `json:"sms,omitempty"`
+
+	// Callbacks lists the IDs of callbacks scheduled during the call
+	Callbacks []string `json:"callbacks,omitempty"`
+
+	// Sentiment is the mean caller sentiment (-1 to 1) when detection is enabled
+	Sentiment float64 `json:"sentiment,omitempty"`
+
+	// Escalation is set when the firm tags escalated calls
+	Escalation *Escalation `json:"escalation,omitempty"`
+
+	// Verification is set once the assistant checks the caller's identity
+	Verification *Verification `json:"verification,omitempty"`
+
+	// Supervisor activity: an operator listened in, and whether they took over
+	SupervisorJoined   bool `json:"supervisor_joined,omitempty"`
+	SupervisorTakeover bool `json:"supervisor_takeover,omitempty"`
+
+	// Consent is the compliance announcement and recording consent outcome
+	Consent *Consent `json:"consent,omitempty"`
+
+	// AIDisclosures counts AI-disclosure reminders announced after the call-start disclaimer
+	AIDisclosures int `json:"ai_disclosures,omitempty"`
+
+	// Features lists the feature flags that were on for the call
+	Features []string `json:"features,omitempty"`
+
+	// RecordingURL locates the stereo call recording (caller left, agent right), when recorded
+	RecordingURL string `json:"recording_url,omitempty"`
+
+	// Retention is when the recording and transcripts must be deleted, when the firm limits them
+	Retention *Retention `json:"retention,omitempty"`
+
+	// TransferWhisper is what the staff member heard before a warm transfer connected
+	TransferWhisper string `json:"transfer_whisper,omitempty"`
+
+	// Disposition summarizes how the call ended (e.g. completed, voicemail, transferred)
+	Disposition string `json:"disposition,omitempty"`
+
+	// Outcome classifies the call for funnel reporting (one of the Outcome constants)
+	Outcome string `json:"outcome,omitempty"`
+
+	// AITurns counts the AI responses the caller was given
+	AITurns int `json:"ai_turns"`
+
+	// Error describes the failure that ended the call, when one did (e.g. a recovered panic)
+	Error string `json:"error,omitempty"`
+
+	// STT usage
+	CallAudioSecs float64 `json:"call_audio_seconds"`
+	STTBilledSecs float64 `json:"stt_billed_seconds"`
+
+	// Usage is what the call consumed, for billing the firm
+	Usage Usage `json:"usage"`
+
+	// TalkOver is how often and how long the caller and agent overlapped
+	TalkOver TalkOver `json:"talk_over"`
+}
+
+// Builder accumulates a Record over the life of a call
+// Safe for concurrent use
+type Builder struct {
+	mu     sync.Mutex
+	record Record
+}
+
+// NewBuilder starts a record for a call beginning at startedAt
+func NewBuilder(conversationID string, startedAt time.Time) *Builder {
+	return &Builder{
+		record: Record{
+			ConversationID: conversationID,
+			StartedAt:      startedAt.UTC(),
+			GatewayVersion: buildinfo.Version,
+		},
+	}
+}
+
+// Update applies fn to the record under the builder's lock
+func (b *Builder) Update(fn func(r *Record)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fn(&b.record)
+}
+
+// Finish stamps the end time and duration and returns a copy of the record
+func (b *Builder) Finish(endedAt time.Time) Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.record.EndedAt = endedAt.UTC()
+	b.record.DurationSecs = b.record.EndedAt.Sub(b.record.StartedAt).Seconds()
+	return b.record
+}
+
+// Snapshot returns a copy of the record so far
+func (b *Builder) Snapshot() Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.record
+}
@@ -0,0 +1,34 @@
+package cdr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder_Finish(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	b := NewBuilder("conv-1", start)
+
+	b.Update(func(r *Record) {
+		r.CallSid = "CA123"
+		r.Routing = Routing{Action: "voicemail", Reason: "after_hours"}
+	})
+
+	rec := b.Finish(start.Add(90 * time.Second))
+	if rec.CallSid != "CA123" || rec.ConversationID != "conv-1" {
+		t.Errorf("Unexpected identifiers: %+v", rec)
+	}
+	if rec.DurationSecs != 90 {
+		t.Errorf("Expected 90s duration, got %f", rec.DurationSecs)
+	}
+	if rec.Routing.Action != "voicemail" {
+		t.Errorf("Expected routing action voicemail, got '%s'", rec.Routing.Action)
+	}
+
+	// Snapshot is a copy
+	snap := b.Snapshot()
+	snap.CallSid = "changed"
+	if b.Snapshot().CallSid != "CA123" {
+		t.Error("Expected Snapshot to return a copy")
+	}
+}
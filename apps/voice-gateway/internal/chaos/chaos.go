@@ -0,0 +1,149 @@
+// Package chaos injects faults for resilience testing in development and
+// staging: Deepgram disconnects, orchestrator latency, TTS 500s and lost
+// Twilio frames, each at a configured rate, so the circuit breakers,
+// reconnection and fallback paths can be exercised on demand. It is off
+// unless CHAOS_ENABLED is set and must never be turned on in production
+package chaos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Faults, as counted by voice_gateway_chaos_faults_total
+const (
+	FaultDeepgramDisconnect  = "deepgram_disconnect"
+	FaultOrchestratorLatency = "orchestrator_latency"
+	FaultTTSError            = "tts_error"
+	FaultTwilioFrameLoss     = "twilio_frame_loss"
+)
+
+// ErrInjected marks a failure made up by this package
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Config sets how often each fault fires; rates are probabilities in [0, 1]
+type Config struct {
+	DeepgramDisconnectRate  float64       // Per audio chunk sent to Deepgram
+	OrchestratorLatencyRate float64       // Per orchestrator request
+	OrchestratorLatency     time.Duration // Delay added to a slowed request
+	TTSErrorRate            float64       // Per synthesis request
+	TwilioFrameLossRate     float64       // Per inbound media frame
+}
+
+var active atomic.Pointer[Config]
+
+// Configure turns fault injection on with cfg; nil turns it off
+func Configure(cfg *Config) {
+	active.Store(cfg)
+}
+
+// Enabled reports whether fault injection is on
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// fire rolls for a fault at rate and counts it when it fires
+func fire(fault string, rate float64) bool {
+	if rate <= 0 || rand.Float64() >= rate {
+		return false
+	}
+	observability.RecordChaosFault(fault)
+	return true
+}
+
+// DisconnectDeepgram reports whether to drop the Deepgram connection instead
+// of sending the next audio chunk
+func DisconnectDeepgram() bool {
+	cfg := active.Load()
+	return cfg != nil && fire(FaultDeepgramDisconnect, cfg.DeepgramDisconnectRate)
+}
+
+// DropTwilioFrame reports whether to discard the next inbound media frame
+func DropTwilioFrame() bool {
+	cfg := active.Load()
+	return cfg != nil && fire(FaultTwilioFrameLoss, cfg.TwilioFrameLossRate)
+}
+
+// delayOrchestrator waits out the injected latency for one request, or until
+// ctx is done
+func delayOrchestrator(ctx context.Context) error {
+	cfg := active.Load()
+	if cfg == nil || !fire(FaultOrchestratorLatency, cfg.OrchestratorLatencyRate) {
+		return nil
+	}
+	timer := time.NewTimer(cfg.OrchestratorLatency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// UnaryClientInterceptor delays orchestrator requests
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := delayOrchestrator(ctx); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor delays opening orchestrator streams, which holds
+// back the first token of a turn
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := delayOrchestrator(ctx); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// Transport wraps base (nil means http.DefaultTransport) so TTS requests fail
+// with a 500 at the configured rate. The rate is read per request, so the
+// wrapper is harmless while fault injection is off
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return ttsTransport{base: base}
+}
+
+type ttsTransport struct {
+	base http.RoundTripper
+}
+
+func (t ttsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := active.Load()
+	if cfg == nil || !fire(FaultTTSError, cfg.TTSErrorRate) {
+		return t.base.RoundTrip(req)
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	body := `{"error":"chaos: injected fault"}`
+	return &http.Response{
+		Status:        "500 Internal Server Error",
+		StatusCode:    http.StatusInternalServerError,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
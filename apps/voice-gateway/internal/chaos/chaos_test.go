@@ -0,0 +1,88 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestOffByDefault(t *testing.T) {
+	Configure(nil)
+	if Enabled() || DisconnectDeepgram() || DropTwilioFrame() {
+		t.Error("Expected no faults without a config")
+	}
+}
+
+func TestRates(t *testing.T) {
+	Configure(&Config{DeepgramDisconnectRate: 1, TwilioFrameLossRate: 0})
+	defer Configure(nil)
+
+	for i := 0; i < 100; i++ {
+		if !DisconnectDeepgram() {
+			t.Fatal("Expected a rate of 1 to fire every time")
+		}
+		if DropTwilioFrame() {
+			t.Fatal("Expected a rate of 0 never to fire")
+		}
+	}
+}
+
+func TestTransport_InjectsServerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: Transport(nil)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected requests through untouched while off, got %d", resp.StatusCode)
+	}
+
+	Configure(&Config{TTSErrorRate: 1})
+	defer Configure(nil)
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected an injected 500, got %d", resp.StatusCode)
+	}
+}
+
+func TestStreamClientInterceptor_AddsLatency(t *testing.T) {
+	Configure(&Config{OrchestratorLatencyRate: 1, OrchestratorLatency: 50 * time.Millisecond})
+	defer Configure(nil)
+
+	called := false
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		called = true
+		return nil, nil
+	}
+	intercept := StreamClientInterceptor()
+
+	start := time.Now()
+	if _, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/Orchestrator/ProcessConversation", streamer); err != nil {
+		t.Fatalf("Interceptor failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || !called {
+		t.Errorf("Expected the stream opened after the delay, took %v (called %v)", elapsed, called)
+	}
+
+	// A cancelled request stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := intercept(ctx, &grpc.StreamDesc{}, nil, "/Orchestrator/ProcessConversation", streamer); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation, got %v", err)
+	}
+}
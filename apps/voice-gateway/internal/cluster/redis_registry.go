@@ -0,0 +1,250 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// callTTL is how long a call stays registered without a heartbeat, so calls
+	// held by a crashed instance stop counting toward firm limits
+	callTTL = 30 * time.Second
+
+	// heartbeatInterval is how often live calls are refreshed
+	heartbeatInterval = callTTL / 3
+
+	// keyPrefix namespaces registry keys in a shared Redis
+	keyPrefix = "voice-gateway:"
+)
+
+// registerScript stores the call and admits it when the firm is under its limit
+// KEYS: call key, all-calls set, firm set
+// ARGV: call JSON, TTL ms, now ms, limit, call SID
+const registerScript = `
+local expiry = tonumber(ARGV[3]) + tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[3], '-inf', ARGV[3])
+local admitted = 1
+local limit = tonumber(ARGV[4])
+if limit > 0 and not redis.call('ZSCORE', KEYS[3], ARGV[5]) and redis.call('ZCARD', KEYS[3]) >= limit then
+	admitted = 0
+end
+if admitted == 1 then
+	redis.call('ZADD', KEYS[3], expiry, ARGV[5])
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('ZADD', KEYS[2], expiry, ARGV[5])
+return admitted
+`
+
+// refreshScript extends a live call's TTL; the firm set is only updated if
+// the call was admitted
+// KEYS: call key, all-calls set, firm set
+// ARGV: TTL ms, expiry ms, call SID
+const refreshScript = `
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+redis.call('ZADD', KEYS[2], 'XX', ARGV[2], ARGV[3])
+redis.call('ZADD', KEYS[3], 'XX', ARGV[2], ARGV[3])
+return 1
+`
+
+// RedisRegistry shares active calls between instances through Redis
+// Each instance heartbeats its own calls; entries expire after callTTL
+type RedisRegistry struct {
+	client   *RedisClient
+	instance string
+	address  string
+	logger   zerolog.Logger
+	health   *observability.Subsystem
+	now      func() time.Time
+
+	mu    sync.Mutex
+	owned map[string]Call // Calls registered by this instance, for heartbeats
+}
+
+// NewRedisRegistry creates a registry on the given client
+func NewRedisRegistry(client *RedisClient, instance, address string, logger zerolog.Logger) *RedisRegistry {
+	return &RedisRegistry{
+		client:   client,
+		instance: instance,
+		address:  address,
+		logger:   logger.With().Str("component", "cluster").Logger(),
+		now:      time.Now,
+		owned:    make(map[string]Call),
+	}
+}
+
+// Register records the call and applies the firm's limit cluster-wide
+func (r *RedisRegistry) Register(ctx context.Context, call Call, maxConcurrent int) (bool, error) {
+	call.Instance = r.instance
+	call.Address = r.address
+	data, err := json.Marshal(call)
+	if err != nil {
+		return false, err
+	}
+
+	reply, err := r.client.Do(ctx, "EVAL", registerScript, "3",
+		callKey(call.CallSid), callsKey(), firmKey(call.FirmID),
+		string(data), ms(callTTL), unixMs(r.now()), strconv.Itoa(maxConcurrent), call.CallSid)
+	if err != nil {
+		return false, fmt.Errorf("failed to register call: %w", err)
+	}
+
+	r.mu.Lock()
+	r.owned[call.CallSid] = call
+	r.mu.Unlock()
+	return reply == int64(1), nil
+}
+
+// Unregister removes the call from the shared registry
+func (r *RedisRegistry) Unregister(ctx context.Context, call Call) error {
+	r.mu.Lock()
+	delete(r.owned, call.CallSid)
+	r.mu.Unlock()
+
+	if _, err := r.client.Do(ctx, "DEL", callKey(call.CallSid)); err != nil {
+		return fmt.Errorf("failed to unregister call: %w", err)
+	}
+	if _, err := r.client.Do(ctx, "ZREM", callsKey(), call.CallSid); err != nil {
+		return fmt.Errorf("failed to unregister call: %w", err)
+	}
+	if _, err := r.client.Do(ctx, "ZREM", firmKey(call.FirmID), call.CallSid); err != nil {
+		return fmt.Errorf("failed to release firm slot: %w", err)
+	}
+	return nil
+}
+
+// Lookup finds the call on any instance
+func (r *RedisRegistry) Lookup(ctx context.Context, callSid string) (*Call, error) {
+	reply, err := r.client.Do(ctx, "GET", callKey(callSid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up call: %w", err)
+	}
+	data, ok := reply.(string)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var call Call
+	if err := json.Unmarshal([]byte(data), &call); err != nil {
+		return nil, fmt.Errorf("invalid registry entry for %s: %w", callSid, err)
+	}
+	return &call, nil
+}
+
+// List returns the active calls on all instances
+func (r *RedisRegistry) List(ctx context.Context) ([]Call, error) {
+	now := unixMs(r.now())
+	if _, err := r.client.Do(ctx, "ZREMRANGEBYSCORE", callsKey(), "-inf", now); err != nil {
+		return nil, fmt.Errorf("failed to list calls: %w", err)
+	}
+	reply, err := r.client.Do(ctx, "ZRANGE", callsKey(), "0", "-1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calls: %w", err)
+	}
+	sids, _ := reply.([]interface{})
+	if len(sids) == 0 {
+		return []Call{}, nil
+	}
+
+	args := make([]string, 0, len(sids)+1)
+	args = append(args, "MGET")
+	for _, sid := range sids {
+		if s, ok := sid.(string); ok {
+			args = append(args, callKey(s))
+		}
+	}
+	reply, err = r.client.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calls: %w", err)
+	}
+	values, _ := reply.([]interface{})
+
+	calls := make([]Call, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue // Expired between ZRANGE and MGET
+		}
+		var call Call
+		if err := json.Unmarshal([]byte(data), &call); err == nil {
+			calls = append(calls, call)
+		}
+	}
+	sortCalls(calls)
+	return calls, nil
+}
+
+// SetHealth reports heartbeat-loop liveness and Redis outcomes to a health subsystem
+func (r *RedisRegistry) SetHealth(health *observability.Subsystem) {
+	r.health = health
+}
+
+// Instance returns this instance's ID
+func (r *RedisRegistry) Instance() string { return r.instance }
+
+// Address returns this instance's base URL
+func (r *RedisRegistry) Address() string { return r.address }
+
+// Run refreshes this instance's calls until ctx is cancelled
+func (r *RedisRegistry) Run(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.health.Heartbeat()
+			r.heartbeat(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// heartbeat extends the TTL of every call this instance owns
+func (r *RedisRegistry) heartbeat(ctx context.Context) {
+	r.mu.Lock()
+	calls := make([]Call, 0, len(r.owned))
+	for _, call := range r.owned {
+		calls = append(calls, call)
+	}
+	r.mu.Unlock()
+
+	expiry := unixMs(r.now().Add(callTTL))
+	for _, call := range calls {
+		_, err := r.client.Do(ctx, "EVAL", refreshScript, "3",
+			callKey(call.CallSid), callsKey(), firmKey(call.FirmID),
+			ms(callTTL), expiry, call.CallSid)
+		if err != nil {
+			r.logger.Warn().Err(err).Str("call_sid", call.CallSid).Msg("Failed to refresh call registration")
+			r.health.Failure(err)
+			return
+		}
+	}
+	if len(calls) == 0 {
+		if err := r.client.Ping(ctx); err != nil {
+			r.health.Failure(err)
+			return
+		}
+	}
+	r.health.Success()
+}
+
+func callKey(callSid string) string { return keyPrefix + "call:" + callSid }
+func callsKey() string              { return keyPrefix + "calls" }
+func firmKey(firmID string) string  { return keyPrefix + "firm:" + firmID + ":calls" }
+
+// ms formats a duration in whole milliseconds
+func ms(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+// unixMs formats t as Unix milliseconds, the score used in the call sets
+func unixMs(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
@@ -0,0 +1,140 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when no instance owns the call
+var ErrNotFound = errors.New("call not found in cluster")
+
+// Call is an active call as seen by every gateway instance
+type Call struct {
+	CallSid      string    `json:"call_sid"`
+	FirmID       string    `json:"firm_id,omitempty"`
+	CallerNumber string    `json:"caller_number,omitempty"`
+	Instance     string    `json:"instance"`
+	Address      string    `json:"address,omitempty"` // Base URL of the owning instance's API
+	StartedAt    time.Time `json:"started_at"`
+}
+
+// Registry tracks active calls across gateway instances and enforces per-firm
+// concurrency limits; implementations are safe for concurrent use
+type Registry interface {
+	// Register records a call owned by this instance; maxConcurrent > 0 caps the
+	// firm's admitted calls, and admitted is false when the firm is at capacity
+	// (the call is still listed, but does not count toward the limit)
+	Register(ctx context.Context, call Call, maxConcurrent int) (admitted bool, err error)
+
+	// Unregister removes a call when it ends
+	Unregister(ctx context.Context, call Call) error
+
+	// Lookup finds the instance that owns a call
+	Lookup(ctx context.Context, callSid string) (*Call, error)
+
+	// List returns all active calls, ordered by start time
+	List(ctx context.Context) ([]Call, error)
+
+	// Instance identifies this gateway instance
+	Instance() string
+
+	// Address is the base URL other instances use to reach this one
+	Address() string
+}
+
+// LocalRegistry is the single-instance registry used when no Redis is configured
+type LocalRegistry struct {
+	instance string
+	address  string
+
+	mu       sync.Mutex
+	calls    map[string]Call
+	admitted map[string]map[string]bool // firm ID -> call SIDs counted toward its limit
+}
+
+// NewLocalRegistry creates an in-memory registry
+func NewLocalRegistry(instance, address string) *LocalRegistry {
+	return &LocalRegistry{
+		instance: instance,
+		address:  address,
+		calls:    make(map[string]Call),
+		admitted: make(map[string]map[string]bool),
+	}
+}
+
+// Register records the call, admitting it if the firm is under its limit
+func (r *LocalRegistry) Register(ctx context.Context, call Call, maxConcurrent int) (bool, error) {
+	call.Instance = r.instance
+	call.Address = r.address
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[call.CallSid] = call
+
+	firmCalls := r.admitted[call.FirmID]
+	if maxConcurrent > 0 && len(firmCalls) >= maxConcurrent && !firmCalls[call.CallSid] {
+		return false, nil
+	}
+	if firmCalls == nil {
+		firmCalls = make(map[string]bool)
+		r.admitted[call.FirmID] = firmCalls
+	}
+	firmCalls[call.CallSid] = true
+	return true, nil
+}
+
+// Unregister removes the call
+func (r *LocalRegistry) Unregister(ctx context.Context, call Call) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.calls, call.CallSid)
+	if firmCalls := r.admitted[call.FirmID]; firmCalls != nil {
+		delete(firmCalls, call.CallSid)
+		if len(firmCalls) == 0 {
+			delete(r.admitted, call.FirmID)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the call if it is active on this instance
+func (r *LocalRegistry) Lookup(ctx context.Context, callSid string) (*Call, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	call, ok := r.calls[callSid]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &call, nil
+}
+
+// List returns the calls active on this instance
+func (r *LocalRegistry) List(ctx context.Context) ([]Call, error) {
+	r.mu.Lock()
+	calls := make([]Call, 0, len(r.calls))
+	for _, call := range r.calls {
+		calls = append(calls, call)
+	}
+	r.mu.Unlock()
+	sortCalls(calls)
+	return calls, nil
+}
+
+// Instance returns this instance's ID
+func (r *LocalRegistry) Instance() string { return r.instance }
+
+// Address returns this instance's base URL
+func (r *LocalRegistry) Address() string { return r.address }
+
+// sortCalls orders calls oldest first
+func sortCalls(calls []Call) {
+	sort.Slice(calls, func(i, j int) bool {
+		if !calls[i].StartedAt.Equal(calls[j].StartedAt) {
+			return calls[i].StartedAt.Before(calls[j].StartedAt)
+		}
+		return calls[i].CallSid < calls[j].CallSid
+	})
+}
@@ -0,0 +1,309 @@
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLocalRegistry_ConcurrencyLimit(t *testing.T) {
+	r := NewLocalRegistry("gw-1", "http://gw-1:8080")
+	ctx := context.Background()
+
+	for i, sid := range []string{"CA1", "CA2", "CA3"} {
+		admitted, err := r.Register(ctx, Call{CallSid: sid, FirmID: "firm-a"}, 2)
+		if err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		if want := i < 2; admitted != want {
+			t.Errorf("Expected %s admitted=%v, got %v", sid, want, admitted)
+		}
+	}
+
+	// Other firms have their own limit
+	if admitted, _ := r.Register(ctx, Call{CallSid: "CB1", FirmID: "firm-b"}, 2); !admitted {
+		t.Error("Expected another firm's call to be admitted")
+	}
+
+	// Ending an admitted call frees a slot
+	_ = r.Unregister(ctx, Call{CallSid: "CA1", FirmID: "firm-a"})
+	if admitted, _ := r.Register(ctx, Call{CallSid: "CA4", FirmID: "firm-a"}, 2); !admitted {
+		t.Error("Expected a freed slot to admit the next call")
+	}
+
+	calls, _ := r.List(ctx)
+	if len(calls) != 4 {
+		t.Errorf("Expected 4 listed calls, got %d", len(calls))
+	}
+	call, err := r.Lookup(ctx, "CA3")
+	if err != nil || call.Instance != "gw-1" || call.Address != "http://gw-1:8080" {
+		t.Errorf("Expected CA3 owned by gw-1, got %+v (%v)", call, err)
+	}
+	if _, err := r.Lookup(ctx, "CA1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for ended call, got %v", err)
+	}
+}
+
+func TestRedisRegistry_SharedAcrossInstances(t *testing.T) {
+	addr := newFakeRedis(t, "secret")
+	ctx := context.Background()
+
+	newRegistry := func(instance string) *RedisRegistry {
+		client, err := NewRedisClient("redis://:secret@" + addr + "/2")
+		if err != nil {
+			t.Fatalf("NewRedisClient failed: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return NewRedisRegistry(client, instance, "http://"+instance+":8080", zerolog.Nop())
+	}
+	gw1, gw2 := newRegistry("gw-1"), newRegistry("gw-2")
+
+	if admitted, err := gw1.Register(ctx, Call{CallSid: "CA1", FirmID: "firm-a"}, 1); err != nil || !admitted {
+		t.Fatalf("Expected first call admitted, got %v (%v)", admitted, err)
+	}
+	if admitted, err := gw2.Register(ctx, Call{CallSid: "CA2", FirmID: "firm-a"}, 1); err != nil || admitted {
+		t.Errorf("Expected the limit to apply across instances, got %v (%v)", admitted, err)
+	}
+
+	call, err := gw2.Lookup(ctx, "CA1")
+	if err != nil || call.Instance != "gw-1" || call.Address != "http://gw-1:8080" {
+		t.Errorf("Expected CA1 owned by gw-1, got %+v (%v)", call, err)
+	}
+
+	calls, err := gw2.List(ctx)
+	if err != nil || len(calls) != 2 {
+		t.Fatalf("Expected 2 calls across instances, got %d (%v)", len(calls), err)
+	}
+
+	gw1.heartbeat(ctx)
+	if err := gw1.Unregister(ctx, Call{CallSid: "CA1", FirmID: "firm-a"}); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if _, err := gw2.Lookup(ctx, "CA1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after unregister, got %v", err)
+	}
+	if admitted, _ := gw2.Register(ctx, Call{CallSid: "CA3", FirmID: "firm-a"}, 1); !admitted {
+		t.Error("Expected the released slot to admit a call on another instance")
+	}
+}
+
+func TestRedisRegistry_ExpiredCallsFreeSlots(t *testing.T) {
+	addr := newFakeRedis(t, "")
+	client, _ := NewRedisClient("redis://" + addr)
+	defer client.Close()
+	r := NewRedisRegistry(client, "gw-1", "", zerolog.Nop())
+	ctx := context.Background()
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	if admitted, _ := r.Register(ctx, Call{CallSid: "CA1", FirmID: "firm-a"}, 1); !admitted {
+		t.Fatal("Expected first call admitted")
+	}
+
+	// The owning instance died and stopped heartbeating
+	now = now.Add(callTTL + time.Second)
+	if admitted, _ := r.Register(ctx, Call{CallSid: "CA2", FirmID: "firm-a"}, 1); !admitted {
+		t.Error("Expected an expired call to stop counting toward the limit")
+	}
+}
+
+func TestRedisClient_ErrorReply(t *testing.T) {
+	addr := newFakeRedis(t, "")
+	client, _ := NewRedisClient("redis://" + addr)
+	defer client.Close()
+
+	_, err := client.Do(context.Background(), "HSET", "k", "f", "v")
+	var redisErr RedisError
+	if !errors.As(err, &redisErr) {
+		t.Fatalf("Expected RedisError, got %v", err)
+	}
+
+	// The connection stays usable after an error reply
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("Expected ping to succeed, got %v", err)
+	}
+}
+
+func TestNewRedisClient_InvalidURL(t *testing.T) {
+	for _, raw := range []string{"http://redis:6379", "redis://redis:6379/db"} {
+		if _, err := NewRedisClient(raw); err == nil {
+			t.Errorf("Expected error for %q", raw)
+		}
+	}
+}
+
+// fakeRedis implements the commands the registry uses, running the Lua
+// scripts as Go equivalents
+type fakeRedis struct {
+	mu       sync.Mutex
+	password string
+	strings  map[string]string
+	zsets    map[string]map[string]float64
+}
+
+func newFakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	f := &fakeRedis{password: password, strings: map[string]string{}, zsets: map[string]map[string]float64{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		items, _ := reply.([]interface{})
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i], _ = item.(string)
+		}
+		if len(args) == 0 {
+			return
+		}
+
+		cmd := strings.ToUpper(args[0])
+		var out string
+		switch {
+		case cmd == "AUTH":
+			authed = args[len(args)-1] == f.password
+			out = "+OK\r\n"
+			if !authed {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			out = "-NOAUTH Authentication required.\r\n"
+		default:
+			out = f.exec(cmd, args[1:])
+		}
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) exec(cmd string, args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch cmd {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		v, ok := f.strings[args[0]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(v)
+	case "MGET":
+		out := fmt.Sprintf("*%d\r\n", len(args))
+		for _, key := range args {
+			if v, ok := f.strings[key]; ok {
+				out += bulk(v)
+			} else {
+				out += "$-1\r\n"
+			}
+		}
+		return out
+	case "DEL":
+		delete(f.strings, args[0])
+		return ":1\r\n"
+	case "ZREM":
+		delete(f.zsets[args[0]], args[1])
+		return ":1\r\n"
+	case "ZREMRANGEBYSCORE":
+		f.zremExpired(args[0], args[2])
+		return ":0\r\n"
+	case "ZRANGE":
+		members := make([]string, 0, len(f.zsets[args[0]]))
+		for m := range f.zsets[args[0]] {
+			members = append(members, m)
+		}
+		sort.Strings(members)
+		out := fmt.Sprintf("*%d\r\n", len(members))
+		for _, m := range members {
+			out += bulk(m)
+		}
+		return out
+	case "EVAL":
+		keys, argv := args[2:5], args[5:]
+		switch args[0] {
+		case registerScript:
+			now, _ := strconv.ParseFloat(argv[2], 64)
+			ttl, _ := strconv.ParseFloat(argv[1], 64)
+			limit, _ := strconv.Atoi(argv[3])
+			f.zremExpired(keys[2], argv[2])
+			firmSet := f.zset(keys[2])
+			_, member := firmSet[argv[4]]
+			admitted := limit <= 0 || member || len(firmSet) < limit
+			if admitted {
+				firmSet[argv[4]] = now + ttl
+			}
+			f.strings[keys[0]] = argv[0]
+			f.zset(keys[1])[argv[4]] = now + ttl
+			if admitted {
+				return ":1\r\n"
+			}
+			return ":0\r\n"
+		case refreshScript:
+			expiry, _ := strconv.ParseFloat(argv[1], 64)
+			for _, key := range keys[1:] {
+				if _, ok := f.zsets[key][argv[2]]; ok {
+					f.zsets[key][argv[2]] = expiry
+				}
+			}
+			return ":1\r\n"
+		}
+		return "-NOSCRIPT unknown script\r\n"
+	}
+	return "-ERR unknown command '" + cmd + "'\r\n"
+}
+
+func (f *fakeRedis) zset(key string) map[string]float64 {
+	if f.zsets[key] == nil {
+		f.zsets[key] = map[string]float64{}
+	}
+	return f.zsets[key]
+}
+
+func (f *fakeRedis) zremExpired(key, max string) {
+	limit, _ := strconv.ParseFloat(max, 64)
+	for m, score := range f.zsets[key] {
+		if score <= limit {
+			delete(f.zsets[key], m)
+		}
+	}
+}
+
+func bulk(v string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+}
@@ -2,10 +2,20 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc/codes"
+
+	"github.com/lexiqai/voice-gateway/internal/auth"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/encryption"
+	"github.com/lexiqai/voice-gateway/internal/flags"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
 )
 
 // Config holds all configuration for the voice gateway service
@@ -13,48 +23,459 @@ type Config struct {
 	// Server configuration
 	Port string `envconfig:"PORT" default:"8080"`
 
+	// Internal listener (e.g. 127.0.0.1:9090 or :9090): when set, /admin,
+	// /metrics and /debug are served only here and answer 404 on PORT, so they
+	// are never exposed with the public media endpoint. Health probes are
+	// served on both
+	InternalAddr string `envconfig:"INTERNAL_ADDR" default:""`
+
 	// Public base URL for this service (e.g. https://xxx.ngrok-free.dev when behind ngrok).
 	// Used for logging the WebSocket endpoint; Twilio connects to wss://<this-host>/streams/twilio.
 	// Optional; if unset, logs ws://localhost:PORT/streams/twilio.
 	VoiceGatewayURL string `envconfig:"VOICE_GATEWAY_URL" default:""`
 
+	// Startup preflight: verify the Deepgram and Cartesia keys and reach Vosk,
+	// Piper and the orchestrator before serving. fail_fast exits on a failure; degraded
+	// serves health pages but refuses calls until the checks pass; off skips it
+	StartupPreflight          string `envconfig:"STARTUP_PREFLIGHT" default:"degraded"`
+	StartupPreflightTimeoutMs int    `envconfig:"STARTUP_PREFLIGHT_TIMEOUT_MS" default:"10000" min:"1000" max:"120000"` // Per check
+
+	// Native TLS, for deployments without a proxy in front: set both files to
+	// serve HTTPS/WSS on PORT. The certificate is re-read when the file
+	// changes, so renewals need no restart
+	TLSCertFile   string `envconfig:"TLS_CERT_FILE" default:""`
+	TLSKeyFile    string `envconfig:"TLS_KEY_FILE" default:""`
+	TLSMinVersion string `envconfig:"TLS_MIN_VERSION" default:"1.2"` // 1.2 or 1.3
+
+	// HTTP/2 for the gateway's own endpoints: negotiated over TLS, or accepted
+	// as cleartext h2c without it. WebSockets stay on HTTP/1.1 connections
+	HTTP2Enabled bool `envconfig:"HTTP2_ENABLED" default:"false"`
+
+	// Speech recognition provider: deepgram, or vosk for a self-hosted Vosk
+	// server (VOSK_URL, e.g. ws://vosk:2700) when audio must stay on premises.
+	// Audio goes to Vosk in VOSK_CHUNK_MS chunks: shorter returns words
+	// sooner, longer costs the server less
+	STTProvider string `envconfig:"STT_PROVIDER" default:"deepgram"`
+	VoskURL     string `envconfig:"VOSK_URL" default:""`
+	VoskChunkMs int    `envconfig:"VOSK_CHUNK_MS" default:"100" min:"20" max:"1000"`
+
 	// Deepgram STT API configuration
-	DeepgramAPIKey   string `envconfig:"DEEPGRAM_API_KEY" required:"true"`
-	DeepgramModel    string `envconfig:"DEEPGRAM_MODEL" default:"nova-2"` // nova-2, enhanced, base
-	DeepgramLanguage string `envconfig:"DEEPGRAM_LANGUAGE" default:"en"`  // Language code (en, es, fr, etc.)
+	DeepgramAPIKey        string `envconfig:"DEEPGRAM_API_KEY"`                   // Required when STT_PROVIDER is deepgram
+	DeepgramModel         string `envconfig:"DEEPGRAM_MODEL" default:"nova-2"`    // nova-2, enhanced, base
+	DeepgramFallbackModel string `envconfig:"DEEPGRAM_FALLBACK_MODEL" default:""` // Used for the rest of a call once DEEPGRAM_MODEL errors or is rate limited; empty disables fallback
+	DeepgramLanguage      string `envconfig:"DEEPGRAM_LANGUAGE" default:"en"`     // Language code (en, es, fr, etc.)
+	DeepgramAPIHost       string `envconfig:"DEEPGRAM_API_HOST" default:""`       // Empty uses api.deepgram.com
+
+	// Transcript formatting, mapped to the STT provider's options. Smart
+	// formatting also punctuates and writes numbers, dates and amounts as
+	// digits; numerals converts numbers only. Filler words keeps "uh" and "um"
+	STTPunctuate       bool `envconfig:"STT_PUNCTUATE" default:"true"`
+	STTSmartFormat     bool `envconfig:"STT_SMART_FORMAT" default:"false"`
+	STTNumerals        bool `envconfig:"STT_NUMERALS" default:"false"`
+	STTProfanityFilter bool `envconfig:"STT_PROFANITY_FILTER" default:"false"`
+	STTFillerWords     bool `envconfig:"STT_FILLER_WORDS" default:"false"`
+
+	// Speech synthesis provider: cartesia, or piper for a self-hosted Piper
+	// HTTP server (PIPER_URL, e.g. http://piper:5000) when text must stay on
+	// premises. Piper voices are the server's voice names, here and in
+	// TTS_VOICES; PIPER_SAMPLE_RATE is for servers answering with raw PCM
+	// rather than WAV
+	TTSProvider     string `envconfig:"TTS_PROVIDER" default:"cartesia"`
+	PiperURL        string `envconfig:"PIPER_URL" default:""`
+	PiperVoice      string `envconfig:"PIPER_VOICE" default:""` // Empty uses the server's default voice
+	PiperSampleRate int    `envconfig:"PIPER_SAMPLE_RATE" default:"22050" min:"8000" max:"48000"`
 
 	// Cartesia TTS API configuration
-	CartesiaAPIKey  string `envconfig:"CARTESIA_API_KEY" required:"true"`
+	CartesiaAPIKey  string `envconfig:"CARTESIA_API_KEY"`                          // Required when TTS_PROVIDER is cartesia
 	CartesiaVoiceID string `envconfig:"CARTESIA_VOICE_ID" default:"sonic-english"` // Voice ID for Cartesia
 	CartesiaModelID string `envconfig:"CARTESIA_MODEL_ID" default:"sonic"`         // Model ID (sonic, etc.)
+	CartesiaAPIURL  string `envconfig:"CARTESIA_API_URL" default:"https://api.cartesia.ai/v1/tts"`
+
+	// Sentences of one call synthesized at once; their audio still plays in order
+	CartesiaCallConcurrency int `envconfig:"CARTESIA_CALL_CONCURRENCY" default:"2" min:"1"`
+
+	// Gateway speech in other languages: translations beyond the built-in
+	// Spanish (JSON {"fr": {"clarification": "..."}}) and language:voice_id
+	// TTS voices; calls in a language without a voice use CARTESIA_VOICE_ID
+	// (PIPER_VOICE on Piper)
+	I18nCatalogPath string   `envconfig:"I18N_CATALOG_PATH" default:""`
+	TTSVoices       []string `envconfig:"TTS_VOICES" default:""`
+
+	// Numbers, dates, times, money and phone numbers in English speech are
+	// spelled out before synthesis, read the way this locale reads them
+	// (en-US, en-CA month first; en-GB, en-AU day first). Empty disables it
+	TTSVerbalizeLocale string `envconfig:"TTS_VERBALIZE_LOCALE" default:"en-US"`
+
+	// Translation API for firms that relay callers in other languages (firm
+	// setting translation); POST {"text", "source", "target"} returning
+	// {"text"}. Empty disables translation
+	TranslationURL       string `envconfig:"TRANSLATION_URL" default:""`
+	TranslationAPIKey    string `envconfig:"TRANSLATION_API_KEY" default:""`
+	TranslationTimeoutMs int    `envconfig:"TRANSLATION_TIMEOUT_MS" default:"1500" min:"100"`
 
 	// Cognitive Orchestrator gRPC endpoint
 	OrchestratorURL        string `envconfig:"ORCHESTRATOR_URL" default:"localhost:50051"`
 	OrchestratorTLSEnabled bool   `envconfig:"ORCHESTRATOR_TLS_ENABLED" default:"false"`
-	OrchestratorTimeout    int    `envconfig:"ORCHESTRATOR_TIMEOUT" default:"30"` // seconds
+	OrchestratorTimeout    int    `envconfig:"ORCHESTRATOR_TIMEOUT" default:"30" min:"1"` // seconds
+
+	// Capability handshake: on connect, read which RPCs and fields the Orchestrator
+	// serves from its gRPC reflection service, so calls skip features it lacks.
+	// Without reflection (disabled or not served) the compiled proto is assumed
+	OrchestratorReflection bool `envconfig:"ORCHESTRATOR_REFLECTION" default:"true"`
+
+	// Stalled stream watchdog: a turn's response stream that sends nothing for this
+	// long is cancelled and retried once, then the fallback is spoken (0 disables)
+	OrchestratorStallTimeoutSeconds int    `envconfig:"ORCHESTRATOR_STALL_TIMEOUT_SECONDS" default:"15"`
+	OrchestratorStallFallback       string `envconfig:"ORCHESTRATOR_STALL_FALLBACK" default:"I'm sorry, I'm having trouble right now. Could you say that again?"`
+
+	// Response length cap: the agent stops a response at the last sentence that
+	// fits in MAX_RESPONSE_WORDS, or in what it can say in MAX_RESPONSE_SECONDS,
+	// and asks whether to go on; the live transcript keeps the full text (0 disables)
+	MaxResponseWords          int    `envconfig:"MAX_RESPONSE_WORDS" default:"0" min:"0" max:"10000"`
+	MaxResponseSeconds        int    `envconfig:"MAX_RESPONSE_SECONDS" default:"45" min:"0" max:"3600"`
+	MaxResponseContinuePrompt string `envconfig:"MAX_RESPONSE_CONTINUE_PROMPT" default:"…shall I continue?"`
+
+	// Request hedging: when a turn has no response after the delay, send it again
+	// over a second connection and use whichever answers first (0 disables)
+	// Hedges are limited to BUDGET_RATIO per request plus MIN_PER_SECOND
+	OrchestratorHedgeDelayMs      int     `envconfig:"ORCHESTRATOR_HEDGE_DELAY_MS" default:"0"`
+	OrchestratorHedgeURL          string  `envconfig:"ORCHESTRATOR_HEDGE_URL" default:""` // Defaults to ORCHESTRATOR_URL
+	OrchestratorHedgeBudgetRatio  float64 `envconfig:"ORCHESTRATOR_HEDGE_BUDGET_RATIO" default:"0.1" min:"0" max:"1"`
+	OrchestratorHedgeMinPerSecond float64 `envconfig:"ORCHESTRATOR_HEDGE_MIN_PER_SECOND" default:"1"`
 
 	// Audio processing configuration
-	AudioBufferSize    int     `envconfig:"AUDIO_BUFFER_SIZE" default:"8192"`     // Ring buffer size in bytes
-	VADEnergyThreshold float64 `envconfig:"VAD_ENERGY_THRESHOLD" default:"500.0"` // RMS energy threshold for VAD
-	VADSilenceFrames   int     `envconfig:"VAD_SILENCE_FRAMES" default:"10"`      // Frames of silence to mark speech end
+	AudioBufferSize    int     `envconfig:"AUDIO_BUFFER_SIZE" default:"8192" min:"160" max:"1048576"` // Ring buffer size in bytes
+	VADEnergyThreshold float64 `envconfig:"VAD_ENERGY_THRESHOLD" default:"500.0" min:"0" max:"32768"` // RMS energy threshold for VAD
+	VADSilenceFrames   int     `envconfig:"VAD_SILENCE_FRAMES" default:"10" min:"1"`                  // Frames of silence to mark speech end
+
+	// Endpointing configuration
+	// deepgram: rely on Deepgram's UtteranceEndMs (all audio forwarded)
+	// vad:      local VAD finalizes utterances and silence is not forwarded to Deepgram
+	// hybrid:   both signals are active; whichever detects end-of-speech first wins
+	EndpointingMode        string `envconfig:"ENDPOINTING_MODE" default:"deepgram"`
+	DeepgramUtteranceEndMs int    `envconfig:"DEEPGRAM_UTTERANCE_END_MS" default:"1000" min:"1000" max:"5000"` // Used in deepgram and hybrid modes
+
+	// Adaptive endpointing (vad and hybrid modes): the silence local VAD waits
+	// before finalizing moves per turn from VAD_SILENCE_FRAMES down to
+	// ENDPOINTING_MIN_SILENCE_MS while the caller gives short answers, and up
+	// to ENDPOINTING_MAX_SILENCE_MS when they pause on a conjunction or filler
+	// ("and", "um"). DEEPGRAM_UTTERANCE_END_MS is fixed for the stream
+	AdaptiveEndpointingEnabled bool `envconfig:"ADAPTIVE_ENDPOINTING_ENABLED" default:"false"`
+	EndpointingMinSilenceMs    int  `envconfig:"ENDPOINTING_MIN_SILENCE_MS" default:"120" min:"20" max:"5000"`
+	EndpointingMaxSilenceMs    int  `envconfig:"ENDPOINTING_MAX_SILENCE_MS" default:"1000" min:"20" max:"5000"`
+
+	// Automatic gain for quiet callers (speakerphones, cars): once the caller's
+	// average speech level over AGC_WINDOW_MS of speech stays below
+	// AGC_QUIET_RMS, their audio is boosted toward AGC_TARGET_RMS, by at most
+	// AGC_MAX_GAIN, before VAD and STT hear it
+	AGCEnabled   bool    `envconfig:"AGC_ENABLED" default:"false"`
+	AGCTargetRMS float64 `envconfig:"AGC_TARGET_RMS" default:"2000" min:"100" max:"16000"`
+	AGCQuietRMS  float64 `envconfig:"AGC_QUIET_RMS" default:"1000" min:"100" max:"16000"`
+	AGCMaxGain   float64 `envconfig:"AGC_MAX_GAIN" default:"4" min:"1" max:"16"`
+	AGCWindowMs  int     `envconfig:"AGC_WINDOW_MS" default:"2000" min:"200" max:"30000"`
+
+	// Echo suppression: caller audio whose level follows the agent's audio
+	// from up to ECHO_MAX_DELAY_MS earlier (speakerphones, poor handsets) is
+	// silenced before VAD and STT, so the agent does not interrupt or answer
+	// itself. ECHO_CORRELATION is how closely the levels must match (0-1)
+	EchoSuppressionEnabled bool    `envconfig:"ECHO_SUPPRESSION_ENABLED" default:"false"`
+	EchoMaxDelayMs         int     `envconfig:"ECHO_MAX_DELAY_MS" default:"600" min:"100" max:"2000"`
+	EchoCorrelation        float64 `envconfig:"ECHO_CORRELATION" default:"0.8" min:"0.1" max:"1"`
+
+	// Self-echo filtering: a final transcript (of at least three words) matching
+	// something the agent said in the last SELF_ECHO_WINDOW_MS at least
+	// SELF_ECHO_SIMILARITY (0-1, by word edits) is taken as the agent hearing
+	// itself and never answered. 0 disables it
+	SelfEchoSimilarity float64 `envconfig:"SELF_ECHO_SIMILARITY" default:"0.8" min:"0" max:"1"`
+	SelfEchoWindowMs   int     `envconfig:"SELF_ECHO_WINDOW_MS" default:"15000" min:"1000" max:"120000"`
+
+	// Silence suppression (withhold long silences from Deepgram to cut STT billing)
+	// Always active in vad endpointing mode. In deepgram/hybrid modes keep the hangover
+	// at least as long as DEEPGRAM_UTTERANCE_END_MS so Deepgram still sees the pause.
+	SilenceSuppressionEnabled bool `envconfig:"SILENCE_SUPPRESSION_ENABLED" default:"false"`
+	SilenceHangoverMs         int  `envconfig:"SILENCE_HANGOVER_MS" default:"300"` // Silence still forwarded after speech
+	SilencePreRollMs          int  `envconfig:"SILENCE_PREROLL_MS" default:"100"`  // Silence replayed at speech onset
+
+	// Deepgram closes a stream after about 10s without data. While no audio is
+	// sent (suppressed silence, screening, a stalled media stream) a KeepAlive
+	// goes out once nothing has been sent for this long; 0 disables it
+	DeepgramKeepAliveMs int `envconfig:"DEEPGRAM_KEEPALIVE_MS" default:"4000" min:"0" max:"9000"`
+
+	// Long calls replace their Deepgram connection every
+	// DEEPGRAM_SESSION_MAX_MINUTES (0 never), sending audio to both for
+	// DEEPGRAM_ROTATION_OVERLAP_MS so no words are lost at the switch
+	DeepgramSessionMaxMinutes int `envconfig:"DEEPGRAM_SESSION_MAX_MINUTES" default:"60" min:"0" max:"1440"`
+	DeepgramRotationOverlapMs int `envconfig:"DEEPGRAM_ROTATION_OVERLAP_MS" default:"2000" min:"500" max:"10000"`
 
 	// Resilience configuration
-	CircuitBreakerMaxFailures  int `envconfig:"CIRCUIT_BREAKER_MAX_FAILURES" default:"5"`   // Failures before opening circuit
-	CircuitBreakerResetTimeout int `envconfig:"CIRCUIT_BREAKER_RESET_TIMEOUT" default:"30"` // Seconds before attempting recovery
-	RetryMaxAttempts           int `envconfig:"RETRY_MAX_ATTEMPTS" default:"3"`             // Maximum retry attempts
-	RetryInitialBackoff        int `envconfig:"RETRY_INITIAL_BACKOFF" default:"100"`        // Initial backoff in milliseconds
-	ReconnectMaxAttempts       int `envconfig:"RECONNECT_MAX_ATTEMPTS" default:"5"`         // Maximum reconnection attempts
-	ReconnectBackoff           int `envconfig:"RECONNECT_BACKOFF" default:"1000"`           // Reconnection backoff in milliseconds
+	CircuitBreakerMaxFailures  int `envconfig:"CIRCUIT_BREAKER_MAX_FAILURES" default:"5" min:"0"`   // Failures before opening circuit
+	CircuitBreakerResetTimeout int `envconfig:"CIRCUIT_BREAKER_RESET_TIMEOUT" default:"30" min:"1"` // Seconds before attempting recovery
+	CircuitBreakerHalfOpenMax  int `envconfig:"CIRCUIT_BREAKER_HALF_OPEN_MAX" default:"3"`          // Trial requests (and successes needed) to close again
+	RetryMaxAttempts           int `envconfig:"RETRY_MAX_ATTEMPTS" default:"3" min:"1" max:"10"`    // Maximum retry attempts
+	RetryInitialBackoff        int `envconfig:"RETRY_INITIAL_BACKOFF" default:"100" min:"0"`        // Initial backoff in milliseconds
+	ReconnectMaxAttempts       int `envconfig:"RECONNECT_MAX_ATTEMPTS" default:"5" min:"0"`         // Maximum reconnection attempts
+	ReconnectBackoff           int `envconfig:"RECONNECT_BACKOFF" default:"1000" min:"0"`           // Reconnection backoff in milliseconds
+
+	// Circuit breakers also open when this percent of the requests in their sliding
+	// window failed (0 disables)
+	CircuitBreakerFailureRate   float64 `envconfig:"CIRCUIT_BREAKER_FAILURE_RATE" default:"0"`
+	CircuitBreakerMinRequests   int     `envconfig:"CIRCUIT_BREAKER_MIN_REQUESTS" default:"10" min:"0"` // Window requests before the rate applies
+	CircuitBreakerWindowSeconds int     `envconfig:"CIRCUIT_BREAKER_WINDOW_SECONDS" default:"60"`       // Sliding window length
+
+	// gRPC status codes the orchestrator client retries (names as in google.golang.org/grpc/codes)
+	RetryGRPCCodes []string `envconfig:"RETRY_GRPC_CODES" default:"Unavailable,DeadlineExceeded,ResourceExhausted,Aborted"`
+
+	// Bulkheads: per-provider limits on concurrent requests across all calls (0 disables)
+	// Requests over the limit wait in a bounded queue for up to BULKHEAD_QUEUE_TIMEOUT_MS
+	DeepgramMaxConcurrentConnects    int `envconfig:"DEEPGRAM_MAX_CONCURRENT_CONNECTS" default:"20" min:"0"` // Stream (re)connections in progress
+	DeepgramBulkheadQueue            int `envconfig:"DEEPGRAM_BULKHEAD_QUEUE" default:"50" min:"0"`
+	CartesiaMaxConcurrent            int `envconfig:"CARTESIA_MAX_CONCURRENT" default:"50" min:"0"` // Synthesis requests in flight
+	CartesiaBulkheadQueue            int `envconfig:"CARTESIA_BULKHEAD_QUEUE" default:"100" min:"0"`
+	OrchestratorMaxConcurrentStreams int `envconfig:"ORCHESTRATOR_MAX_CONCURRENT_STREAMS" default:"500" min:"0"` // ProcessText streams open, hedges included
+	OrchestratorBulkheadQueue        int `envconfig:"ORCHESTRATOR_BULKHEAD_QUEUE" default:"100" min:"0"`
+	BulkheadQueueTimeoutMs           int `envconfig:"BULKHEAD_QUEUE_TIMEOUT_MS" default:"2000"`
+
+	// Adaptive admission control: when orchestrator or TTS latency shows the
+	// backends are saturated, new calls beyond the adaptive limit wait briefly and
+	// are then sent to the firm's overflow action (voicemail or transfer)
+	AdmissionControlEnabled   bool    `envconfig:"ADMISSION_CONTROL_ENABLED" default:"false"`
+	AdmissionInitialLimit     int     `envconfig:"ADMISSION_INITIAL_LIMIT" default:"50"` // Concurrent AI calls
+	AdmissionMinLimit         int     `envconfig:"ADMISSION_MIN_LIMIT" default:"5"`
+	AdmissionMaxLimit         int     `envconfig:"ADMISSION_MAX_LIMIT" default:"500"`
+	AdmissionLatencyTolerance float64 `envconfig:"ADMISSION_LATENCY_TOLERANCE" default:"1.5"` // Recent over baseline latency treated as saturation
+	AdmissionMaxQueue         int     `envconfig:"ADMISSION_MAX_QUEUE" default:"20" min:"0"`
+	AdmissionQueueTimeoutMs   int     `envconfig:"ADMISSION_QUEUE_TIMEOUT_MS" default:"2000" min:"0"` // 0 sheds immediately
+
+	// First-response latency SLO: SLO_FIRST_RESPONSE_GOAL of caller turns must
+	// hear the reply's first audio within SLO_FIRST_RESPONSE_THRESHOLD_MS of the
+	// end of speech. The alert fires (slo.alert event) while the error budget
+	// burns at SLO_ALERT_BURN_RATE over both SLO_WINDOW_MINUTES and a twelfth of it
+	SLOFirstResponseThresholdMs int     `envconfig:"SLO_FIRST_RESPONSE_THRESHOLD_MS" default:"1500"`
+	SLOFirstResponseGoal        float64 `envconfig:"SLO_FIRST_RESPONSE_GOAL" default:"0.95"` // 0 disables the SLO
+	SLOWindowMinutes            int     `envconfig:"SLO_WINDOW_MINUTES" default:"60"`
+	SLOAlertBurnRate            float64 `envconfig:"SLO_ALERT_BURN_RATE" default:"14.4"`
+	SLOAlertWebhookURL          string  `envconfig:"SLO_ALERT_WEBHOOK_URL" default:""` // In addition to EVENTS_WEBHOOK_URL
+
+	// Twilio REST API (call transfer and hangup; empty SID disables in-call control)
+	TwilioAccountSID string `envconfig:"TWILIO_ACCOUNT_SID" default:""`
+	TwilioAuthToken  string `envconfig:"TWILIO_AUTH_TOKEN" default:""`
+	TwilioAPIBaseURL string `envconfig:"TWILIO_API_BASE_URL" default:"https://api.twilio.com"`
+
+	// Outbound campaigns (need Twilio and VOICE_GATEWAY_URL, which Twilio calls back on);
+	// requests may override the pace and concurrency per campaign
+	CampaignCallsPerMinute     int `envconfig:"CAMPAIGN_CALLS_PER_MINUTE" default:"10" min:"1" max:"600"`
+	CampaignMaxConcurrent      int `envconfig:"CAMPAIGN_MAX_CONCURRENT" default:"5" min:"1"`
+	CampaignRingTimeoutSeconds int `envconfig:"CAMPAIGN_RING_TIMEOUT_SECONDS" default:"30" min:"5" max:"600"`
+
+	// Callbacks the assistant schedules (schedule_callback) are placed through the
+	// campaign dialer, and kept in Redis when REDIS_URL is set (memory otherwise)
+	CallbackMaxAttempts         int `envconfig:"CALLBACK_MAX_ATTEMPTS" default:"3" min:"1" max:"10"`
+	CallbackRetryDelayMinutes   int `envconfig:"CALLBACK_RETRY_DELAY_MINUTES" default:"15" min:"1"`
+	CallbackPollIntervalSeconds int `envconfig:"CALLBACK_POLL_INTERVAL_SECONDS" default:"15" min:"1" max:"300"`
+
+	// Caller verification (verify_caller): firms' PIN digests are HMAC-SHA256
+	// keyed by this secret (see -hash-pin); empty turns PIN checks off. Wrong
+	// answers count against the contact or number for the lockout window,
+	// across calls, and in Redis when REDIS_URL is set (memory otherwise)
+	VerificationPINSecret      string `envconfig:"VERIFICATION_PIN_SECRET" default:""`
+	VerificationLockoutMinutes int    `envconfig:"VERIFICATION_LOCKOUT_MINUTES" default:"60" min:"1" max:"10080"`
+
+	// Twilio media stream connection: how long a call whose connection dropped
+	// without a close frame waits for the stream to reconnect (0 ends it at once)
+	TwilioReconnectGraceMs int `envconfig:"TWILIO_RECONNECT_GRACE_MS" default:"3000" min:"0" max:"30000"`
+
+	// Dead-connection detection: the gateway pings Twilio every
+	// TWILIO_PING_INTERVAL_MS and treats the connection as gone when nothing,
+	// not even a pong, arrives for TWILIO_READ_TIMEOUT_MS (0 disables either)
+	TwilioPingIntervalMs int `envconfig:"TWILIO_PING_INTERVAL_MS" default:"5000" min:"0"`
+	TwilioReadTimeoutMs  int `envconfig:"TWILIO_READ_TIMEOUT_MS" default:"12000" min:"0"`
+
+	// Media stream WebSocket tuning. Buffers are per connection, so at high
+	// call concurrency they trade memory for fewer syscalls; 0 uses the
+	// library default. Compression (permessage-deflate) is only used when the
+	// peer offers it, and costs CPU per frame for little gain on base64 audio
+	WSReadBufferSize     int  `envconfig:"WS_READ_BUFFER_SIZE" default:"4096" min:"0" max:"1048576"`
+	WSWriteBufferSize    int  `envconfig:"WS_WRITE_BUFFER_SIZE" default:"4096" min:"0" max:"1048576"`
+	WSCompression        bool `envconfig:"WS_COMPRESSION" default:"false"`
+	WSCompressionLevel   int  `envconfig:"WS_COMPRESSION_LEVEL" default:"1" min:"-2" max:"9"`            // flate level: -2 Huffman only, 1 fastest, 9 smallest
+	TwilioWriteTimeoutMs int  `envconfig:"TWILIO_WRITE_TIMEOUT_MS" default:"5000" min:"100" max:"60000"` // Bounds each write to Twilio
+
+	// Media stream <Parameter> policy: calls missing a required parameter, or
+	// carrying a malformed one, are rejected. firm_id is not listed here;
+	// MISSING_FIRM_POLICY decides what happens to calls without one
+	StreamRequiredParams []string `envconfig:"STREAM_REQUIRED_PARAMS" default:""`
+
+	// Calls without firm_id: reject (apologize with MISSING_FIRM_MESSAGE and
+	// hang up), default_firm (attribute them to STREAM_DEFAULT_FIRM_ID) or
+	// allow (proceed unattributed under the default settings). Unset keeps the
+	// earlier behaviour: default_firm when STREAM_DEFAULT_FIRM_ID is set, else reject
+	MissingFirmPolicy   string `envconfig:"MISSING_FIRM_POLICY" default:""`
+	MissingFirmMessage  string `envconfig:"MISSING_FIRM_MESSAGE" default:"We're sorry, this line isn't set up to take calls right now. Please try again later. Goodbye."`
+	StreamDefaultFirmID string `envconfig:"STREAM_DEFAULT_FIRM_ID" default:""`
+
+	// Admin API (mounted under /admin/ only when a key or JWT validation is set);
+	// ADMIN_API_KEY is an admin-role key, AUTH_API_KEYS entries are name:role:key
+	// with roles viewer, operator or admin
+	AdminAPIKey string   `envconfig:"ADMIN_API_KEY" default:""`
+	AuthAPIKeys []string `envconfig:"AUTH_API_KEYS" default:""`
+
+	// JWT bearer tokens: HS256 signed with AUTH_JWT_HS256_SECRET, or RS256
+	// signed by an OIDC provider publishing its keys at AUTH_JWT_JWKS_URL, which
+	// then requires AUTH_JWT_ISSUER and AUTH_JWT_AUDIENCE so tokens the provider
+	// issued for other applications are refused
+	AuthJWTSecret    string `envconfig:"AUTH_JWT_HS256_SECRET" default:""`
+	AuthJWTJWKSURL   string `envconfig:"AUTH_JWT_JWKS_URL" default:""`
+	AuthJWTIssuer    string `envconfig:"AUTH_JWT_ISSUER" default:""`
+	AuthJWTAudience  string `envconfig:"AUTH_JWT_AUDIENCE" default:""`
+	AuthJWTRoleClaim string `envconfig:"AUTH_JWT_ROLE_CLAIM" default:"role"` // Dots walk nested claims, e.g. realm_access.roles
+
+	// Browser origins allowed on every endpoint ("*", https://app.example.com or
+	// https://*.example.com); firms add their own with allowed_origins
+	CORSOrigins []string `envconfig:"CORS_ORIGINS" default:""`
+
+	// Multi-instance coordination; without REDIS_URL the call registry is in-memory
+	RedisURL        string `envconfig:"REDIS_URL" default:""`        // e.g. redis://:password@redis:6379/0
+	InstanceID      string `envconfig:"INSTANCE_ID" default:""`      // Defaults to the hostname
+	InstanceAddress string `envconfig:"INSTANCE_ADDRESS" default:""` // Base URL other instances use to reach this one; defaults to http://<hostname>:<PORT>
+
+	// Caller screening (per-firm challenge settings live in the firm config)
+	ScreeningBlocklistPath       string  `envconfig:"SCREENING_BLOCKLIST_PATH" default:""` // One number per line
+	ScreeningReputationURL       string  `envconfig:"SCREENING_REPUTATION_URL" default:""` // Empty disables reputation lookups
+	ScreeningReputationAPIKey    string  `envconfig:"SCREENING_REPUTATION_API_KEY" default:""`
+	ScreeningReputationTimeoutMs int     `envconfig:"SCREENING_REPUTATION_TIMEOUT_MS" default:"1500" min:"0"`
+	ScreeningBlockScore          float64 `envconfig:"SCREENING_BLOCK_SCORE" default:"0.9" min:"0" max:"1"`     // Reputation score that blocks outright
+	ScreeningChallengeScore      float64 `envconfig:"SCREENING_CHALLENGE_SCORE" default:"0.5" min:"0" max:"1"` // Reputation score that triggers a challenge
+
+	// Call limits (0 disables); idle calls get a prompt, then INACTIVITY_GRACE_SECONDS to respond
+	MaxCallDurationSeconds   int    `envconfig:"MAX_CALL_DURATION_SECONDS" default:"3600" min:"0"`
+	InactivityTimeoutSeconds int    `envconfig:"INACTIVITY_TIMEOUT_SECONDS" default:"30" min:"0"`
+	InactivityGraceSeconds   int    `envconfig:"INACTIVITY_GRACE_SECONDS" default:"10" min:"0"`
+	InactivityPrompt         string `envconfig:"INACTIVITY_PROMPT" default:"Are you still there?"`
+	InactivityGoodbye        string `envconfig:"INACTIVITY_GOODBYE" default:"It sounds like we've lost you. Please call back any time. Goodbye."`
+	MaxDurationGoodbye       string `envconfig:"MAX_DURATION_GOODBYE" default:"We've reached the time limit for this call. Please call back if you need anything else. Goodbye."`
+
+	// Call recording: stereo WAV with caller on channel 0 and agent on channel 1
+	CallRecordingEnabled    bool `envconfig:"CALL_RECORDING_ENABLED" default:"false"`
+	CallRecordingMaxMinutes int  `envconfig:"CALL_RECORDING_MAX_MINUTES" default:"60" min:"0"` // 0 means unlimited
+
+	// Usage rates in USD, used to price per-firm usage against budget.monthly_cap_usd
+	UsageRateSTTPerMinute       float64 `envconfig:"USAGE_RATE_STT_PER_MINUTE" default:"0.0043" min:"0"`
+	UsageRateTTSPer1KCharacters float64 `envconfig:"USAGE_RATE_TTS_PER_1K_CHARACTERS" default:"0.03" min:"0"`
+	UsageRateTokensPer1K        float64 `envconfig:"USAGE_RATE_TOKENS_PER_1K" default:"0.002" min:"0"`
+	UsageRateTelephonyPerMinute float64 `envconfig:"USAGE_RATE_TELEPHONY_PER_MINUTE" default:"0.0085" min:"0"`
+
+	// Firm configuration (per-firm overrides, JSON file; empty uses built-in defaults)
+	FirmConfigPath string `envconfig:"FIRM_CONFIG_PATH" default:""`
+
+	// Feature flags for gradual rollouts: FEATURE_FLAGS entries are "name"
+	// (every call), "name=25" (25% of calls) or "name=off". A JSON file or a
+	// remote URL serving the same rules (with per-firm targeting) overrides
+	// them per flag, re-read every FEATURE_FLAGS_REFRESH_SECONDS
+	FeatureFlags               []string `envconfig:"FEATURE_FLAGS" default:""`
+	FeatureFlagsFile           string   `envconfig:"FEATURE_FLAGS_FILE" default:""`
+	FeatureFlagsURL            string   `envconfig:"FEATURE_FLAGS_URL" default:""`
+	FeatureFlagsRefreshSeconds int      `envconfig:"FEATURE_FLAGS_REFRESH_SECONDS" default:"30" min:"1" max:"3600"`
+
+	// Chaos mode for resilience testing in development and staging only:
+	// injects Deepgram disconnects (per audio chunk), orchestrator latency (per
+	// request), TTS 500s (per synthesis) and inbound Twilio frame loss (per
+	// frame) at the given rates. Never enable in production
+	ChaosEnabled                 bool    `envconfig:"CHAOS_ENABLED" default:"false"`
+	ChaosDeepgramDisconnectRate  float64 `envconfig:"CHAOS_DEEPGRAM_DISCONNECT_RATE" default:"0" min:"0" max:"1"`
+	ChaosOrchestratorLatencyRate float64 `envconfig:"CHAOS_ORCHESTRATOR_LATENCY_RATE" default:"0" min:"0" max:"1"`
+	ChaosOrchestratorLatencyMs   int     `envconfig:"CHAOS_ORCHESTRATOR_LATENCY_MS" default:"2000" min:"0" max:"60000"`
+	ChaosTTSErrorRate            float64 `envconfig:"CHAOS_TTS_ERROR_RATE" default:"0" min:"0" max:"1"`
+	ChaosTwilioFrameLossRate     float64 `envconfig:"CHAOS_TWILIO_FRAME_LOSS_RATE" default:"0" min:"0" max:"1"`
+
+	// Storage configuration (recordings, voicemail)
+	StorageDir string `envconfig:"STORAGE_DIR" default:"./data"`
+
+	// Storage backend: "local" (STORAGE_DIR) or "s3"
+	StorageBackend     string `envconfig:"STORAGE_BACKEND" default:"local"`
+	S3Bucket           string `envconfig:"S3_BUCKET" default:""`
+	S3Endpoint         string `envconfig:"S3_ENDPOINT" default:""` // S3-compatible endpoint (e.g. MinIO); empty uses AWS
+	AWSRegion          string `envconfig:"AWS_REGION" default:"us-east-1"`
+	AWSAccessKeyID     string `envconfig:"AWS_ACCESS_KEY_ID" default:""`
+	AWSSecretAccessKey string `envconfig:"AWS_SECRET_ACCESS_KEY" default:""`
+
+	// Data regions: DATA_REGION is where the endpoints and storage above
+	// process data. Firms pinned to other regions (regions in firm config) use
+	// region=endpoint entries, e.g. eu=api.eu.deepgram.com; a call whose firm
+	// requires a region without an entry is rejected
+	DataRegion          string   `envconfig:"DATA_REGION" default:"us"`
+	DeepgramRegionHosts []string `envconfig:"DEEPGRAM_REGION_HOSTS" default:""`
+	CartesiaRegionURLs  []string `envconfig:"CARTESIA_REGION_URLS" default:""`
+	StorageRegions      []string `envconfig:"STORAGE_REGIONS" default:""` // region=bucket[@aws_region] for s3, region=directory for local
+
+	// Encryption at rest: recordings, voicemail and event payloads (call records,
+	// transcripts) are sealed with per-firm keys before reaching storage or a webhook.
+	// ENCRYPTION_KMS is "" (off), "local" (ENCRYPTION_KEYS) or "aws" (AWS KMS, AWS_* credentials)
+	EncryptionKMS          string   `envconfig:"ENCRYPTION_KMS" default:""`
+	EncryptionKeys         []string `envconfig:"ENCRYPTION_KEYS" default:""`           // key_id:version:base64 AES-256 key; the newest version of each key wraps
+	EncryptionDefaultKeyID string   `envconfig:"ENCRYPTION_DEFAULT_KEY_ID" default:""` // Used by firms without encryption.key_id
+	KMSEndpoint            string   `envconfig:"KMS_ENDPOINT" default:""`              // AWS KMS-compatible endpoint; empty uses AWS
+
+	// Audit log: hash-chained JSON lines of admin calls, live views and
+	// downloads, also exported as audit.entry events; empty keeps it in memory
+	AuditLogPath string `envconfig:"AUDIT_LOG_PATH" default:""`
+
+	// Retention janitor: how often stored recordings and voicemail are checked
+	// against each firm's retention policy
+	RetentionSweepIntervalMinutes int `envconfig:"RETENTION_SWEEP_INTERVAL_MINUTES" default:"60" min:"1"`
+
+	// Audio playback: ffmpeg transcodes MP3 and other non-WAV files. Audio
+	// URLs are only fetched over https from PLAYBACK_ALLOWED_HOSTS (empty
+	// plays stored files only), and never from private or link-local addresses
+	FFmpegPath           string   `envconfig:"FFMPEG_PATH" default:"ffmpeg"`
+	PlaybackAllowedHosts []string `envconfig:"PLAYBACK_ALLOWED_HOSTS" default:""`
+
+	// Event delivery configuration (empty URL logs events instead)
+	EventsWebhookURL    string `envconfig:"EVENTS_WEBHOOK_URL" default:""`
+	EventsWebhookSecret string `envconfig:"EVENTS_WEBHOOK_SECRET" default:""` // HMAC-SHA256 signing secret
+
+	// Email notifications via SMTP (empty address disables email)
+	SMTPAddr     string `envconfig:"SMTP_ADDR" default:""` // host:port
+	SMTPFrom     string `envconfig:"SMTP_FROM" default:"noreply@lexiqai.com"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME" default:""`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD" default:""`
 
 	// Observability configuration
 	LogLevel       string `envconfig:"LOG_LEVEL" default:"info"`       // Log level: debug, info, warn, error
 	LogPretty      bool   `envconfig:"LOG_PRETTY" default:"false"`     // Pretty print logs (for development)
 	MetricsEnabled bool   `envconfig:"METRICS_ENABLED" default:"true"` // Enable Prometheus metrics
+
+	// Per-firm metric labels: allowlisted firms always get their own firm_id
+	// series, plus up to the limit of other firms in arrival order; the rest
+	// are reported as "other"
+	MetricsFirmLabels     []string `envconfig:"METRICS_FIRM_LABELS" default:""`
+	MetricsFirmLabelLimit int      `envconfig:"METRICS_FIRM_LABEL_LIMIT" default:"50" min:"0"`
 }
 
 // Load reads configuration from environment variables
-// It first attempts to load from .env file if it exists, then from environment
+// It first attempts to load from .env file if it exists, then from environment,
+// over the config file named by CONFIG_FILE when set
 func Load() (*Config, error) {
+	return LoadWithFile(os.Getenv("CONFIG_FILE"))
+}
+
+// LoadWithFile resolves configuration over the YAML or JSON file at path
+// (empty for none) and validates it
+func LoadWithFile(path string) (*Config, error) {
+	cfg, err := Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Resolve layers defaults, the config file at path (empty for none), .env and
+// the environment, later layers winning, without validating the result
+func Resolve(path string) (*Config, error) {
 	// Try to load .env file (ignore error if it doesn't exist)
 	_ = godotenv.Load()
 
@@ -63,12 +484,14 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Validate required fields
-	if cfg.DeepgramAPIKey == "" {
-		return nil, fmt.Errorf("DEEPGRAM_API_KEY is required")
-	}
-	if cfg.CartesiaAPIKey == "" {
-		return nil, fmt.Errorf("CARTESIA_API_KEY is required")
+	if path != "" {
+		values, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.applyFile(values); err != nil {
+			return nil, err
+		}
 	}
 
 	return &cfg, nil
@@ -82,17 +505,273 @@ func LoadFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Validate required fields
-	if cfg.DeepgramAPIKey == "" {
-		return nil, fmt.Errorf("DEEPGRAM_API_KEY is required")
-	}
-	if cfg.CartesiaAPIKey == "" {
-		return nil, fmt.Errorf("CARTESIA_API_KEY is required")
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	return &cfg, nil
 }
 
+// Validate checks required fields and enumerated values
+func (c *Config) Validate() error {
+	// Validate required fields (not tagged required, so they may come from a config file)
+	switch c.STTProvider {
+	case "deepgram":
+		if c.DeepgramAPIKey == "" {
+			return fmt.Errorf("DEEPGRAM_API_KEY is required")
+		}
+	case "vosk":
+		if c.VoskURL == "" {
+			return fmt.Errorf("VOSK_URL is required when STT_PROVIDER is vosk")
+		}
+	default:
+		return fmt.Errorf("STT_PROVIDER must be one of deepgram, vosk (got %q)", c.STTProvider)
+	}
+	switch c.TTSProvider {
+	case "cartesia":
+		if c.CartesiaAPIKey == "" {
+			return fmt.Errorf("CARTESIA_API_KEY is required")
+		}
+	case "piper":
+		if c.PiperURL == "" {
+			return fmt.Errorf("PIPER_URL is required when TTS_PROVIDER is piper")
+		}
+	default:
+		return fmt.Errorf("TTS_PROVIDER must be one of cartesia, piper (got %q)", c.TTSProvider)
+	}
+
+	switch c.TTSVerbalizeLocale {
+	case "", "en-US", "en-CA", "en-GB", "en-AU":
+	default:
+		return fmt.Errorf("TTS_VERBALIZE_LOCALE must be one of en-US, en-CA, en-GB, en-AU (got %q)", c.TTSVerbalizeLocale)
+	}
+
+	if err := c.validateRanges(); err != nil {
+		return err
+	}
+
+	switch c.EndpointingMode {
+	case "deepgram", "vad", "hybrid":
+	default:
+		return fmt.Errorf("ENDPOINTING_MODE must be one of deepgram, vad, hybrid (got %q)", c.EndpointingMode)
+	}
+
+	if c.AdaptiveEndpointingEnabled {
+		if silenceMs := c.VADSilenceFrames * 20; silenceMs < c.EndpointingMinSilenceMs || silenceMs > c.EndpointingMaxSilenceMs {
+			return fmt.Errorf("VAD_SILENCE_FRAMES (%dms) must lie between ENDPOINTING_MIN_SILENCE_MS and ENDPOINTING_MAX_SILENCE_MS", silenceMs)
+		}
+	}
+
+	if c.AGCQuietRMS > c.AGCTargetRMS {
+		return fmt.Errorf("AGC_QUIET_RMS must not exceed AGC_TARGET_RMS")
+	}
+
+	if c.SilenceHangoverMs < 0 || c.SilencePreRollMs < 0 {
+		return fmt.Errorf("SILENCE_HANGOVER_MS and SILENCE_PREROLL_MS must be non-negative")
+	}
+
+	if c.OrchestratorStallTimeoutSeconds < 0 {
+		return fmt.Errorf("ORCHESTRATOR_STALL_TIMEOUT_SECONDS must be non-negative")
+	}
+
+	if c.AdmissionControlEnabled {
+		if c.AdmissionMinLimit <= 0 || c.AdmissionMinLimit > c.AdmissionInitialLimit || c.AdmissionInitialLimit > c.AdmissionMaxLimit {
+			return fmt.Errorf("ADMISSION limits must satisfy 0 < ADMISSION_MIN_LIMIT <= ADMISSION_INITIAL_LIMIT <= ADMISSION_MAX_LIMIT")
+		}
+		if c.AdmissionLatencyTolerance <= 1 {
+			return fmt.Errorf("ADMISSION_LATENCY_TOLERANCE must be greater than 1")
+		}
+	}
+
+	if c.DeepgramFallbackModel != "" && c.DeepgramFallbackModel == c.DeepgramModel {
+		return fmt.Errorf("DEEPGRAM_FALLBACK_MODEL must differ from DEEPGRAM_MODEL")
+	}
+
+	if c.SLOFirstResponseGoal != 0 {
+		if c.SLOFirstResponseGoal < 0 || c.SLOFirstResponseGoal >= 1 {
+			return fmt.Errorf("SLO_FIRST_RESPONSE_GOAL must be between 0 and 1 (got %v)", c.SLOFirstResponseGoal)
+		}
+		if c.SLOFirstResponseThresholdMs <= 0 || c.SLOWindowMinutes < 12 || c.SLOAlertBurnRate <= 0 {
+			return fmt.Errorf("SLO_FIRST_RESPONSE_THRESHOLD_MS and SLO_ALERT_BURN_RATE must be positive and SLO_WINDOW_MINUTES at least 12")
+		}
+	}
+
+	if c.VerificationPINSecret != "" && len(c.VerificationPINSecret) < 32 {
+		return fmt.Errorf("VERIFICATION_PIN_SECRET must be at least 32 characters")
+	}
+
+	if _, err := auth.ParseAPIKeys(c.AuthAPIKeys); err != nil {
+		return fmt.Errorf("AUTH_API_KEYS: %w", err)
+	}
+	if c.AuthJWTJWKSURL != "" && (c.AuthJWTIssuer == "" || c.AuthJWTAudience == "") {
+		return fmt.Errorf("AUTH_JWT_JWKS_URL requires AUTH_JWT_ISSUER and AUTH_JWT_AUDIENCE")
+	}
+
+	if err := twilio.ValidateRequired(c.StreamRequiredParams); err != nil {
+		return fmt.Errorf("STREAM_REQUIRED_PARAMS: %w", err)
+	}
+	for _, name := range c.StreamRequiredParams {
+		if strings.TrimSpace(name) == twilio.ParamFirmID {
+			return fmt.Errorf("STREAM_REQUIRED_PARAMS must not list firm_id; set MISSING_FIRM_POLICY=reject instead")
+		}
+	}
+	switch c.MissingFirmPolicy {
+	case "", "reject", "allow":
+	case "default_firm":
+		if c.StreamDefaultFirmID == "" {
+			return fmt.Errorf("MISSING_FIRM_POLICY=default_firm requires STREAM_DEFAULT_FIRM_ID")
+		}
+	default:
+		return fmt.Errorf("MISSING_FIRM_POLICY must be one of reject, default_firm, allow (got %q)", c.MissingFirmPolicy)
+	}
+
+	if _, err := i18n.ParseVoices(c.TTSVoices); err != nil {
+		return fmt.Errorf("TTS_VOICES: %w", err)
+	}
+
+	if _, err := flags.ParseSpec(c.FeatureFlags); err != nil {
+		return fmt.Errorf("FEATURE_FLAGS: %w", err)
+	}
+	if c.FeatureFlagsFile != "" && c.FeatureFlagsURL != "" {
+		return fmt.Errorf("set FEATURE_FLAGS_FILE or FEATURE_FLAGS_URL, not both")
+	}
+
+	// Fault rates without the switch are most likely a leftover staging
+	// setting; refuse them rather than silently ignore them
+	if !c.ChaosEnabled && (c.ChaosDeepgramDisconnectRate > 0 || c.ChaosOrchestratorLatencyRate > 0 ||
+		c.ChaosTTSErrorRate > 0 || c.ChaosTwilioFrameLossRate > 0) {
+		return fmt.Errorf("CHAOS_* fault rates require CHAOS_ENABLED=true")
+	}
+
+	if err := cors.ValidateOrigins(c.CORSOrigins); err != nil {
+		return fmt.Errorf("CORS_ORIGINS: %w", err)
+	}
+
+	if err := checkGRPCCodeNames(c.RetryGRPCCodes); err != nil {
+		return fmt.Errorf("RETRY_GRPC_CODES: %w", err)
+	}
+
+	if c.CircuitBreakerHalfOpenMax <= 0 || c.CircuitBreakerWindowSeconds <= 0 {
+		return fmt.Errorf("CIRCUIT_BREAKER_HALF_OPEN_MAX and CIRCUIT_BREAKER_WINDOW_SECONDS must be positive")
+	}
+	if c.CircuitBreakerFailureRate < 0 || c.CircuitBreakerFailureRate > 100 {
+		return fmt.Errorf("CIRCUIT_BREAKER_FAILURE_RATE must be a percentage between 0 and 100")
+	}
+
+	switch c.StartupPreflight {
+	case "off", "fail_fast", "degraded":
+	default:
+		return fmt.Errorf("STARTUP_PREFLIGHT must be one of: off, fail_fast, degraded")
+	}
+
+	if c.InternalAddr != "" {
+		_, port, err := net.SplitHostPort(c.InternalAddr)
+		if err != nil || port == "" {
+			return fmt.Errorf("INTERNAL_ADDR must be host:port or :port")
+		}
+		if port == c.Port {
+			return fmt.Errorf("INTERNAL_ADDR must use a different port from PORT")
+		}
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if c.TLSMinVersion != "1.2" && c.TLSMinVersion != "1.3" {
+		return fmt.Errorf("TLS_MIN_VERSION must be 1.2 or 1.3")
+	}
+
+	if c.TwilioReadTimeoutMs > 0 && (c.TwilioPingIntervalMs == 0 || c.TwilioReadTimeoutMs <= c.TwilioPingIntervalMs) {
+		return fmt.Errorf("TWILIO_READ_TIMEOUT_MS needs TWILIO_PING_INTERVAL_MS set and shorter than it, or a quiet connection times out")
+	}
+
+	if c.BulkheadQueueTimeoutMs < 0 {
+		return fmt.Errorf("BULKHEAD_QUEUE_TIMEOUT_MS must be non-negative")
+	}
+
+	if c.OrchestratorHedgeDelayMs < 0 || c.OrchestratorHedgeBudgetRatio < 0 || c.OrchestratorHedgeMinPerSecond < 0 {
+		return fmt.Errorf("ORCHESTRATOR_HEDGE_DELAY_MS, ORCHESTRATOR_HEDGE_BUDGET_RATIO and ORCHESTRATOR_HEDGE_MIN_PER_SECOND must be non-negative")
+	}
+
+	switch c.StorageBackend {
+	case "local":
+	case "s3":
+		if c.S3Bucket == "" {
+			return fmt.Errorf("S3_BUCKET is required when STORAGE_BACKEND is s3")
+		}
+	default:
+		return fmt.Errorf("STORAGE_BACKEND must be one of local, s3 (got %q)", c.StorageBackend)
+	}
+
+	for name, entries := range map[string][]string{
+		"DEEPGRAM_REGION_HOSTS": c.DeepgramRegionHosts,
+		"CARTESIA_REGION_URLS":  c.CartesiaRegionURLs,
+		"STORAGE_REGIONS":       c.StorageRegions,
+	} {
+		if _, err := ParseRegional(entries); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	switch c.EncryptionKMS {
+	case "":
+	case "local":
+		keyring, err := encryption.ParseKeyring(c.EncryptionKeys)
+		if err != nil {
+			return fmt.Errorf("invalid ENCRYPTION_KEYS: %w", err)
+		}
+		if !keyring.Has(c.EncryptionDefaultKeyID) {
+			return fmt.Errorf("ENCRYPTION_DEFAULT_KEY_ID must name a key in ENCRYPTION_KEYS")
+		}
+	case "aws":
+		if c.EncryptionDefaultKeyID == "" {
+			return fmt.Errorf("ENCRYPTION_DEFAULT_KEY_ID is required when ENCRYPTION_KMS is aws")
+		}
+	default:
+		return fmt.Errorf("ENCRYPTION_KMS must be one of local, aws (got %q)", c.EncryptionKMS)
+	}
+
+	return nil
+}
+
+// SilenceSuppressionActive returns whether silence is withheld from the STT provider
+func (c *Config) SilenceSuppressionActive() bool {
+	return c.SilenceSuppressionEnabled || c.EndpointingMode == "vad"
+}
+
+// MissingFirmAction returns how calls without firm_id are handled:
+// MISSING_FIRM_POLICY, or when unset default_firm if STREAM_DEFAULT_FIRM_ID
+// is set and reject otherwise
+func (c *Config) MissingFirmAction() string {
+	switch {
+	case c.MissingFirmPolicy != "":
+		return c.MissingFirmPolicy
+	case c.StreamDefaultFirmID != "":
+		return "default_firm"
+	default:
+		return "reject"
+	}
+}
+
+// checkGRPCCodeNames verifies each name is a gRPC code, written as
+// "Unavailable" or "RESOURCE_EXHAUSTED"; resilience converts them to codes
+func checkGRPCCodeNames(names []string) error {
+	known := make(map[string]bool)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		known[normalizeCodeName(c.String())] = true
+	}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" && !known[normalizeCodeName(name)] {
+			return fmt.Errorf("unknown gRPC code %q", name)
+		}
+	}
+	return nil
+}
+
+func normalizeCodeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
 // GetEnv returns the value of an environment variable or a default value
 func GetEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -61,6 +61,10 @@ func TestLoad_Defaults(t *testing.T) {
 		t.Errorf("Expected default DeepgramLanguage 'en', got '%s'", cfg.DeepgramLanguage)
 	}
 
+	if !cfg.STTPunctuate || cfg.STTSmartFormat || cfg.STTFillerWords {
+		t.Errorf("Expected transcripts punctuated and otherwise unformatted by default, got %+v", []bool{cfg.STTPunctuate, cfg.STTSmartFormat, cfg.STTFillerWords})
+	}
+
 	if cfg.CartesiaVoiceID != "sonic-english" {
 		t.Errorf("Expected default CartesiaVoiceID 'sonic-english', got '%s'", cfg.CartesiaVoiceID)
 	}
@@ -182,3 +186,577 @@ func TestConfig_ObservabilityDefaults(t *testing.T) {
 	}
 }
 
+func TestConfig_EndpointingDefaults(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.EndpointingMode != "deepgram" {
+		t.Errorf("Expected default EndpointingMode 'deepgram', got '%s'", cfg.EndpointingMode)
+	}
+
+	if cfg.DeepgramUtteranceEndMs != 1000 {
+		t.Errorf("Expected default DeepgramUtteranceEndMs 1000, got %d", cfg.DeepgramUtteranceEndMs)
+	}
+}
+
+func TestLoad_InvalidEndpointingMode(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("ENDPOINTING_MODE", "telepathy")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("ENDPOINTING_MODE")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for invalid ENDPOINTING_MODE")
+	}
+}
+
+func TestConfig_SilenceSuppressionActive(t *testing.T) {
+	cfg := &Config{EndpointingMode: "deepgram"}
+	if cfg.SilenceSuppressionActive() {
+		t.Error("Expected suppression inactive by default in deepgram mode")
+	}
+
+	cfg.SilenceSuppressionEnabled = true
+	if !cfg.SilenceSuppressionActive() {
+		t.Error("Expected suppression active when enabled")
+	}
+
+	cfg = &Config{EndpointingMode: "vad"}
+	if !cfg.SilenceSuppressionActive() {
+		t.Error("Expected suppression always active in vad mode")
+	}
+}
+
+func TestLoad_S3StorageRequiresBucket(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("STORAGE_BACKEND", "s3")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("STORAGE_BACKEND")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for s3 storage without S3_BUCKET")
+	}
+}
+
+func TestLoad_AdmissionLimitsOrdered(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("ADMISSION_CONTROL_ENABLED", "true")
+	os.Setenv("ADMISSION_MIN_LIMIT", "100")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("ADMISSION_CONTROL_ENABLED")
+	defer os.Unsetenv("ADMISSION_MIN_LIMIT")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for ADMISSION_MIN_LIMIT above ADMISSION_INITIAL_LIMIT")
+	}
+}
+
+func TestLoad_CircuitBreakerFailureRateRange(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("CIRCUIT_BREAKER_FAILURE_RATE", "150")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("CIRCUIT_BREAKER_FAILURE_RATE")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for CIRCUIT_BREAKER_FAILURE_RATE above 100")
+	}
+}
+
+func TestLoad_RetryGRPCCodesValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("RETRY_GRPC_CODES", "Unavailable,Flaky")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("RETRY_GRPC_CODES")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for an unknown code in RETRY_GRPC_CODES")
+	}
+}
+
+func TestLoad_AuthAPIKeysValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("AUTH_API_KEYS", "dashboard:superuser:abc123")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("AUTH_API_KEYS")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for an unknown role in AUTH_API_KEYS")
+	}
+}
+
+func TestLoad_AuthJWKSRequiresIssuerAndAudience(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("AUTH_JWT_JWKS_URL", "https://idp.example.com/.well-known/jwks.json")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("AUTH_JWT_JWKS_URL")
+	defer os.Unsetenv("AUTH_JWT_ISSUER")
+	defer os.Unsetenv("AUTH_JWT_AUDIENCE")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for AUTH_JWT_JWKS_URL without an issuer or audience")
+	}
+	os.Setenv("AUTH_JWT_ISSUER", "https://idp.example.com/")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for AUTH_JWT_JWKS_URL without an audience")
+	}
+	os.Setenv("AUTH_JWT_AUDIENCE", "voice-gateway")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestLoad_CORSOriginsValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("CORS_ORIGINS", "https://app.lexiq.ai,app.lexiq.ai")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("CORS_ORIGINS")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for an origin without a scheme in CORS_ORIGINS")
+	}
+}
+
+func TestLoad_StreamRequiredParamsValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("STREAM_REQUIRED_PARAMS", "firm_id,tenant")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("STREAM_REQUIRED_PARAMS")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error for an unknown name in STREAM_REQUIRED_PARAMS")
+	}
+}
+
+func TestLoad_MissingFirmPolicy(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("MISSING_FIRM_POLICY")
+	defer os.Unsetenv("STREAM_DEFAULT_FIRM_ID")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.MissingFirmAction() != "reject" {
+		t.Errorf("Expected calls without firm_id rejected by default, got %s", cfg.MissingFirmAction())
+	}
+
+	// STREAM_DEFAULT_FIRM_ID alone keeps sending calls to the default firm
+	os.Setenv("STREAM_DEFAULT_FIRM_ID", "house")
+	if cfg, err = Load(); err != nil || cfg.MissingFirmAction() != "default_firm" {
+		t.Errorf("Expected default_firm with STREAM_DEFAULT_FIRM_ID set, got %v", err)
+	}
+	os.Setenv("MISSING_FIRM_POLICY", "reject")
+	if cfg, err = Load(); err != nil || cfg.MissingFirmAction() != "reject" {
+		t.Errorf("Expected an explicit reject to win, got %v", err)
+	}
+	os.Unsetenv("STREAM_DEFAULT_FIRM_ID")
+
+	os.Setenv("MISSING_FIRM_POLICY", "default_firm")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for default_firm without STREAM_DEFAULT_FIRM_ID")
+	}
+
+	os.Setenv("STREAM_DEFAULT_FIRM_ID", "house")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	os.Setenv("MISSING_FIRM_POLICY", "quarantine")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unknown MISSING_FIRM_POLICY")
+	}
+}
+
+func TestLoad_TTSVoicesValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("TTS_VOICES")
+
+	os.Setenv("TTS_VOICES", "es:voice-es,fr-CA:voice-fr")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cfg.TTSVoices) != 2 {
+		t.Errorf("Expected 2 TTS voices, got %d", len(cfg.TTSVoices))
+	}
+
+	os.Setenv("TTS_VOICES", "voice-es")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a TTS_VOICES entry without a language")
+	}
+}
+
+func TestLoad_SLOValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("SLO_FIRST_RESPONSE_GOAL")
+	defer os.Unsetenv("SLO_WINDOW_MINUTES")
+
+	os.Setenv("SLO_FIRST_RESPONSE_GOAL", "95")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for SLO_FIRST_RESPONSE_GOAL above 1")
+	}
+
+	os.Setenv("SLO_FIRST_RESPONSE_GOAL", "0.99")
+	os.Setenv("SLO_WINDOW_MINUTES", "5")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for SLO_WINDOW_MINUTES below 12")
+	}
+
+	os.Setenv("SLO_FIRST_RESPONSE_GOAL", "0")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected a disabled SLO to skip validation, got %v", err)
+	}
+}
+
+func TestLoad_DeepgramFallbackModelValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("DEEPGRAM_FALLBACK_MODEL")
+
+	os.Setenv("DEEPGRAM_FALLBACK_MODEL", "nova-2")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for DEEPGRAM_FALLBACK_MODEL equal to DEEPGRAM_MODEL")
+	}
+
+	os.Setenv("DEEPGRAM_FALLBACK_MODEL", "base")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected a distinct fallback model to load, got %v", err)
+	}
+	if cfg.DeepgramFallbackModel != "base" {
+		t.Errorf("Expected fallback model base, got %q", cfg.DeepgramFallbackModel)
+	}
+}
+
+func TestLoad_EncryptionValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("ENCRYPTION_KMS")
+	defer os.Unsetenv("ENCRYPTION_KEYS")
+	defer os.Unsetenv("ENCRYPTION_DEFAULT_KEY_ID")
+
+	os.Setenv("ENCRYPTION_KMS", "local")
+	os.Setenv("ENCRYPTION_KEYS", "gateway:1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=")
+	os.Setenv("ENCRYPTION_DEFAULT_KEY_ID", "gateway")
+	if _, err := Load(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	os.Setenv("ENCRYPTION_DEFAULT_KEY_ID", "acme")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a default key missing from the keyring")
+	}
+
+	os.Setenv("ENCRYPTION_KEYS", "gateway:1:c2hvcnQ=")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a key that isn't 32 bytes")
+	}
+
+	os.Setenv("ENCRYPTION_KMS", "vault")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unknown ENCRYPTION_KMS")
+	}
+}
+
+func TestLoad_RegionalEndpoints(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("DEEPGRAM_REGION_HOSTS")
+
+	os.Setenv("DEEPGRAM_REGION_HOSTS", "eu=api.eu.deepgram.com")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if host, ok := cfg.DeepgramHostIn("eu"); !ok || host != "api.eu.deepgram.com" {
+		t.Errorf("Expected the EU host, got %q (ok %v)", host, ok)
+	}
+	if host, ok := cfg.DeepgramHostIn("us"); !ok || host != "" {
+		t.Errorf("Expected the default host in DATA_REGION, got %q (ok %v)", host, ok)
+	}
+	if _, ok := cfg.CartesiaURLIn("eu"); ok {
+		t.Error("Expected no Cartesia endpoint in the EU")
+	}
+
+	os.Setenv("DEEPGRAM_REGION_HOSTS", "api.eu.deepgram.com")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an entry without a region")
+	}
+}
+
+func TestLoad_TLSValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("TLS_CERT_FILE", "/etc/gateway/tls.crt")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("TLS_CERT_FILE")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for TLS_CERT_FILE without TLS_KEY_FILE")
+	}
+
+	os.Setenv("TLS_KEY_FILE", "/etc/gateway/tls.key")
+	os.Setenv("TLS_MIN_VERSION", "1.1")
+	defer os.Unsetenv("TLS_KEY_FILE")
+	defer os.Unsetenv("TLS_MIN_VERSION")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for TLS_MIN_VERSION below 1.2")
+	}
+}
+
+func TestLoad_InternalAddrValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("PORT", "8080")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("PORT")
+	defer os.Unsetenv("INTERNAL_ADDR")
+
+	for _, addr := range []string{"9090", ":8080"} {
+		os.Setenv("INTERNAL_ADDR", addr)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for INTERNAL_ADDR=%s", addr)
+		}
+	}
+	os.Setenv("INTERNAL_ADDR", "127.0.0.1:9090")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected a loopback INTERNAL_ADDR to load, got %v", err)
+	}
+}
+
+func TestLoad_StartupPreflightValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("STARTUP_PREFLIGHT", "strict")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("STARTUP_PREFLIGHT")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unknown STARTUP_PREFLIGHT mode")
+	}
+}
+
+func TestLoad_FeatureFlagsValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("FEATURE_FLAGS", "barge_in_v2=25,new_resampler=sometimes")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("FEATURE_FLAGS")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a flag that is not on, off or a percentage")
+	}
+}
+
+func TestLoad_ChaosRatesRequireSwitch(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("CHAOS_TTS_ERROR_RATE", "0.2")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("CHAOS_TTS_ERROR_RATE")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for chaos fault rates without CHAOS_ENABLED")
+	}
+
+	os.Setenv("CHAOS_ENABLED", "true")
+	os.Setenv("CHAOS_TWILIO_FRAME_LOSS_RATE", "1.5")
+	defer os.Unsetenv("CHAOS_ENABLED")
+	defer os.Unsetenv("CHAOS_TWILIO_FRAME_LOSS_RATE")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a fault rate above 1")
+	}
+}
+
+func TestLoad_DeepgramKeepAliveValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("DEEPGRAM_KEEPALIVE_MS", "12000")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("DEEPGRAM_KEEPALIVE_MS")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a keepalive interval past Deepgram's idle timeout")
+	}
+}
+
+func TestLoad_VoskProvider(t *testing.T) {
+	os.Unsetenv("DEEPGRAM_API_KEY")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("STT_PROVIDER", "vosk")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("STT_PROVIDER")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for the vosk provider without VOSK_URL")
+	}
+
+	os.Setenv("VOSK_URL", "ws://vosk:2700")
+	defer os.Unsetenv("VOSK_URL")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed without a Deepgram key on vosk: %v", err)
+	}
+	if cfg.VoskChunkMs != 100 {
+		t.Errorf("Expected VoskChunkMs 100, got %d", cfg.VoskChunkMs)
+	}
+
+	os.Setenv("STT_PROVIDER", "whisper")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unknown STT_PROVIDER")
+	}
+}
+
+func TestLoad_PiperProvider(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Unsetenv("CARTESIA_API_KEY")
+	os.Setenv("TTS_PROVIDER", "piper")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("TTS_PROVIDER")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for the piper provider without PIPER_URL")
+	}
+
+	os.Setenv("PIPER_URL", "http://piper:5000")
+	defer os.Unsetenv("PIPER_URL")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed without a Cartesia key on piper: %v", err)
+	}
+	if cfg.PiperSampleRate != 22050 {
+		t.Errorf("Expected PiperSampleRate 22050, got %d", cfg.PiperSampleRate)
+	}
+
+	os.Setenv("TTS_PROVIDER", "coqui")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unknown TTS_PROVIDER")
+	}
+}
+
+func TestLoad_AGCLevelsOrdered(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("AGC_QUIET_RMS", "3000")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("AGC_QUIET_RMS")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a quiet level above the target level")
+	}
+}
+
+func TestLoad_AdaptiveEndpointingBounds(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("ADAPTIVE_ENDPOINTING_ENABLED", "true")
+	os.Setenv("VAD_SILENCE_FRAMES", "60")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("ADAPTIVE_ENDPOINTING_ENABLED")
+	defer os.Unsetenv("VAD_SILENCE_FRAMES")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a base silence above ENDPOINTING_MAX_SILENCE_MS")
+	}
+
+	os.Setenv("VAD_SILENCE_FRAMES", "10")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.EndpointingMinSilenceMs != 120 || cfg.EndpointingMaxSilenceMs != 1000 {
+		t.Errorf("Unexpected endpointing bounds %d-%dms", cfg.EndpointingMinSilenceMs, cfg.EndpointingMaxSilenceMs)
+	}
+}
+
+func TestLoad_VerbalizeLocaleValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("TTS_VERBALIZE_LOCALE", "fr-FR")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("TTS_VERBALIZE_LOCALE")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for an unsupported verbalization locale")
+	}
+
+	os.Setenv("TTS_VERBALIZE_LOCALE", "en-GB")
+	if cfg, err := Load(); err != nil || cfg.TTSVerbalizeLocale != "en-GB" {
+		t.Errorf("Expected en-GB to load, got %v", err)
+	}
+}
+
+func TestLoad_VerificationPINSecretValidated(t *testing.T) {
+	os.Setenv("DEEPGRAM_API_KEY", "test-deepgram-key")
+	os.Setenv("CARTESIA_API_KEY", "test-cartesia-key")
+	os.Setenv("VERIFICATION_PIN_SECRET", "too-short")
+	defer os.Unsetenv("DEEPGRAM_API_KEY")
+	defer os.Unsetenv("CARTESIA_API_KEY")
+	defer os.Unsetenv("VERIFICATION_PIN_SECRET")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a short VERIFICATION_PIN_SECRET")
+	}
+
+	os.Setenv("VERIFICATION_PIN_SECRET", "test-verification-pin-secret-0123456789")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected a 32+ character secret to load, got %v", err)
+	}
+}
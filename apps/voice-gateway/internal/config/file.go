@@ -0,0 +1,246 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A config file sets the same settings as the environment, keyed by variable
+// name in any case; nested sections join their keys with "_", so
+//
+//	circuit_breaker:
+//	  max_failures: 5
+//
+// sets CIRCUIT_BREAKER_MAX_FAILURES. Files ending in .json are JSON; anything
+// else is read as YAML (mappings, scalars and lists; no anchors or multi-line
+// strings). Environment variables override the file.
+
+// loadFile reads a config file into settings keyed by environment variable name
+func loadFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	values := make(map[string]string)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var doc map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		flattenJSON("", doc, values)
+	} else if err := parseYAML(data, values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+func settingKey(prefix, key string) string {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
+}
+
+func flattenJSON(prefix string, doc map[string]interface{}, values map[string]string) {
+	for key, raw := range doc {
+		name := settingKey(prefix, key)
+		switch v := raw.(type) {
+		case map[string]interface{}:
+			flattenJSON(name, v, values)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[name] = strings.Join(items, ",")
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+}
+
+// parseYAML reads the YAML subset described above
+func parseYAML(data []byte, values map[string]string) error {
+	type section struct {
+		indent int
+		name   string
+	}
+	var (
+		sections   []section
+		listKey    string // Key with no inline value: a list, a section or empty
+		listIndent int
+		list       []string
+	)
+	flushList := func(indent int) {
+		// A more deeply indented key means listKey opened a section
+		if listKey != "" && (len(list) > 0 || indent <= listIndent) {
+			values[listKey] = strings.Join(list, ",")
+		}
+		listKey, list = "", nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := stripYAMLComment(scanner.Text())
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if strings.ContainsRune(line[:indent+1], '\t') {
+			return fmt.Errorf("line %d: tabs are not allowed for indentation", lineNum)
+		}
+		content := strings.TrimSpace(line)
+
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			if listKey == "" {
+				return fmt.Errorf("line %d: list item outside a list", lineNum)
+			}
+			list = append(list, unquoteYAML(strings.TrimSpace(strings.TrimPrefix(content, "-"))))
+			continue
+		}
+		flushList(indent)
+
+		for len(sections) > 0 && sections[len(sections)-1].indent >= indent {
+			sections = sections[:len(sections)-1]
+		}
+		prefix := ""
+		if len(sections) > 0 {
+			prefix = sections[len(sections)-1].name
+		}
+
+		key, value, ok := strings.Cut(content, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("line %d: expected \"key: value\"", lineNum)
+		}
+		name := settingKey(prefix, key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case value == "":
+			// A section or a block list; the next lines decide which
+			sections = append(sections, section{indent: indent, name: name})
+			listKey, listIndent = name, indent
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			var items []string
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, unquoteYAML(item))
+				}
+			}
+			values[name] = strings.Join(items, ",")
+		default:
+			values[name] = unquoteYAML(value)
+		}
+	}
+	flushList(0)
+	return scanner.Err()
+}
+
+// stripYAMLComment drops a trailing "# comment" outside quotes
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquoteYAML(value string) string {
+	if len(value) >= 2 {
+		if value[0] == '"' && value[len(value)-1] == '"' {
+			if s, err := strconv.Unquote(value); err == nil {
+				return s
+			}
+		}
+		if value[0] == '\'' && value[len(value)-1] == '\'' {
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+	}
+	return value
+}
+
+// applyFile sets each file setting not overridden by the environment
+func (c *Config) applyFile(values map[string]string) error {
+	fields := configFields()
+	for name, value := range values {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown setting %s in config file", name)
+		}
+		if _, set := os.LookupEnv(name); set {
+			continue
+		}
+		if err := setField(reflect.ValueOf(c).Elem().FieldByIndex(field.Index), value); err != nil {
+			return fmt.Errorf("config file %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// configFields maps environment variable names to Config fields
+func configFields() map[string]reflect.StructField {
+	t := reflect.TypeOf(Config{})
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("envconfig"); name != "" {
+			fields[name] = t.Field(i)
+		}
+	}
+	return fields
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", value)
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		if value != "" {
+			items = strings.Split(value, ",")
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Kind())
+	}
+	return nil
+}
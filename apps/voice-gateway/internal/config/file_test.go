@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadWithFile_YAML(t *testing.T) {
+	path := writeConfigFile(t, "gateway.yaml", `
+# Provider keys
+deepgram_api_key: file-deepgram-key
+CARTESIA_API_KEY: "file-cartesia-key"
+circuit_breaker:
+  max_failures: 7   # trips sooner
+  failure_rate: 50
+retry_grpc_codes:
+  - Unavailable
+  - Aborted
+inactivity_prompt: 'Are you there? #1'
+`)
+	os.Setenv("CIRCUIT_BREAKER_MAX_FAILURES", "9")
+	defer os.Unsetenv("CIRCUIT_BREAKER_MAX_FAILURES")
+
+	cfg, err := LoadWithFile(path)
+	if err != nil {
+		t.Fatalf("LoadWithFile() failed: %v", err)
+	}
+	if cfg.DeepgramAPIKey != "file-deepgram-key" || cfg.CartesiaAPIKey != "file-cartesia-key" {
+		t.Errorf("Expected API keys from the file, got %q and %q", cfg.DeepgramAPIKey, cfg.CartesiaAPIKey)
+	}
+	if cfg.CircuitBreakerMaxFailures != 9 {
+		t.Errorf("Expected the environment to override the file, got %d", cfg.CircuitBreakerMaxFailures)
+	}
+	if cfg.CircuitBreakerFailureRate != 50 {
+		t.Errorf("Expected nested CIRCUIT_BREAKER_FAILURE_RATE 50, got %v", cfg.CircuitBreakerFailureRate)
+	}
+	if strings.Join(cfg.RetryGRPCCodes, ",") != "Unavailable,Aborted" {
+		t.Errorf("Expected RETRY_GRPC_CODES from the block list, got %v", cfg.RetryGRPCCodes)
+	}
+	if cfg.InactivityPrompt != "Are you there? #1" {
+		t.Errorf("Expected a quoted '#' to be kept, got %q", cfg.InactivityPrompt)
+	}
+	if cfg.Port != "8080" {
+		t.Errorf("Expected defaults for settings not in the file, got Port %q", cfg.Port)
+	}
+}
+
+func TestLoadWithFile_JSON(t *testing.T) {
+	path := writeConfigFile(t, "gateway.json", `{
+		"deepgram": {"api_key": "file-deepgram-key"},
+		"cartesia_api_key": "file-cartesia-key",
+		"audio_buffer_size": 4096,
+		"call_recording_enabled": true
+	}`)
+
+	cfg, err := LoadWithFile(path)
+	if err != nil {
+		t.Fatalf("LoadWithFile() failed: %v", err)
+	}
+	if cfg.DeepgramAPIKey != "file-deepgram-key" || cfg.AudioBufferSize != 4096 || !cfg.CallRecordingEnabled {
+		t.Errorf("Expected settings from the JSON file, got %+v", cfg)
+	}
+}
+
+func TestLoadWithFile_RejectsUnknownSettings(t *testing.T) {
+	path := writeConfigFile(t, "gateway.yaml", "deepgram_api_key: k\ncartesia_api_key: k\naudio_bufer_size: 4096\n")
+
+	if _, err := LoadWithFile(path); err == nil || !strings.Contains(err.Error(), "AUDIO_BUFER_SIZE") {
+		t.Errorf("Expected an error naming the misspelled setting, got %v", err)
+	}
+}
+
+func TestValidate_ReportsAllRangeErrors(t *testing.T) {
+	path := writeConfigFile(t, "gateway.yaml", "deepgram_api_key: k\ncartesia_api_key: k\naudio_buffer_size: 10\nscreening_block_score: 1.5\n")
+
+	_, err := LoadWithFile(path)
+	if err == nil {
+		t.Fatal("Expected range errors")
+	}
+	for _, name := range []string{"AUDIO_BUFFER_SIZE must be at least 160", "SCREENING_BLOCK_SCORE must be at most 1"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected %q in %v", name, err)
+		}
+	}
+}
+
+func TestRedacted_MasksSecrets(t *testing.T) {
+	cfg := &Config{
+		DeepgramAPIKey: "dg-secret",
+		SMTPPassword:   "hunter2",
+		RedisURL:       "redis://:hunter2@redis:6379/0",
+		DeepgramModel:  "nova-2",
+	}
+
+	out := strings.Join(cfg.Redacted(), "\n")
+	if strings.Contains(out, "dg-secret") || strings.Contains(out, "hunter2") {
+		t.Errorf("Expected secrets to be masked, got:\n%s", out)
+	}
+	for _, line := range []string{"DEEPGRAM_API_KEY=****", "DEEPGRAM_MODEL=nova-2", "REDIS_URL=redis://:xxxxx@redis:6379/0"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected %q in:\n%s", line, out)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseRegional parses region=endpoint entries, skipping blank ones
+func ParseRegional(entries []string) (map[string]string, error) {
+	endpoints := make(map[string]string)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		region, endpoint, ok := strings.Cut(entry, "=")
+		region, endpoint = strings.TrimSpace(region), strings.TrimSpace(endpoint)
+		if !ok || region == "" || endpoint == "" {
+			return nil, fmt.Errorf("invalid entry %q: expected region=endpoint", entry)
+		}
+		if _, dup := endpoints[region]; dup {
+			return nil, fmt.Errorf("duplicate region %q", region)
+		}
+		endpoints[region] = endpoint
+	}
+	return endpoints, nil
+}
+
+// DeepgramHostIn returns the Deepgram host that keeps audio in region; ""
+// and DATA_REGION are served by DEEPGRAM_API_HOST
+func (c *Config) DeepgramHostIn(region string) (string, bool) {
+	return c.inRegion(c.DeepgramRegionHosts, region, c.DeepgramAPIHost)
+}
+
+// CartesiaURLIn returns the Cartesia TTS URL that keeps text in region
+func (c *Config) CartesiaURLIn(region string) (string, bool) {
+	return c.inRegion(c.CartesiaRegionURLs, region, c.CartesiaAPIURL)
+}
+
+// StorageIn returns where objects in region are stored: a bucket[@aws_region]
+// or directory, or "" for the default store in DATA_REGION
+func (c *Config) StorageIn(region string) (string, bool) {
+	return c.inRegion(c.StorageRegions, region, "")
+}
+
+// inRegion looks region up in region=endpoint entries; home serves DATA_REGION
+func (c *Config) inRegion(entries []string, region, home string) (string, bool) {
+	if region == "" || region == c.DataRegion {
+		return home, true
+	}
+	endpoints, err := ParseRegional(entries)
+	if err != nil {
+		return "", false
+	}
+	endpoint, ok := endpoints[region]
+	return endpoint, ok
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Numeric settings may declare bounds with min:"..." and max:"..." struct tags
+
+// validateRanges checks every bounded setting, reporting all violations at once
+func (c *Config) validateRanges() error {
+	var errs []error
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		var value float64
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Int:
+			value = float64(f.Int())
+		case reflect.Float64:
+			value = f.Float()
+		default:
+			continue
+		}
+
+		name := field.Tag.Get("envconfig")
+		if bound, ok := field.Tag.Lookup("min"); ok {
+			if min, _ := strconv.ParseFloat(bound, 64); value < min {
+				errs = append(errs, fmt.Errorf("%s must be at least %s (got %v)", name, bound, value))
+			}
+		}
+		if bound, ok := field.Tag.Lookup("max"); ok {
+			if max, _ := strconv.ParseFloat(bound, 64); value > max {
+				errs = append(errs, fmt.Errorf("%s must be at most %s (got %v)", name, bound, value))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DefaultValue returns the built-in default of the setting named by its
+// environment variable, "" when it has none
+func DefaultValue(name string) string {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("envconfig") == name {
+			return t.Field(i).Tag.Get("default")
+		}
+	}
+	return ""
+}
+
+// secretMarkers identify settings whose values are masked when printed
+var secretMarkers = []string{"KEY", "SECRET", "TOKEN", "PASSWORD"}
+
+// Redacted returns the resolved settings as NAME=value lines in declaration
+// order, with secrets and URL passwords masked
+func (c *Config) Redacted() []string {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	lines := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("envconfig")
+		if name == "" {
+			continue
+		}
+
+		value := fmt.Sprint(v.Field(i).Interface())
+		if f := v.Field(i); f.Kind() == reflect.Slice {
+			value = strings.Join(f.Interface().([]string), ",")
+		}
+		switch {
+		case value == "":
+		case isSecret(name):
+			value = "****"
+		case strings.Contains(value, "://"):
+			if u, err := url.Parse(value); err == nil {
+				value = u.Redacted()
+			}
+		}
+		lines = append(lines, name+"="+value)
+	}
+	return lines
+}
+
+func isSecret(name string) bool {
+	for _, marker := range secretMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// clioDefaultBaseURL is Clio's US region API host
+const clioDefaultBaseURL = "https://app.clio.com"
+
+// ClioProvider matches callers against Clio Manage contacts and their open matters
+type ClioProvider struct {
+	token   string
+	baseURL string
+}
+
+// NewClioProvider creates a Clio provider; an empty baseURL uses the US region
+func NewClioProvider(token, baseURL string) *ClioProvider {
+	if baseURL == "" {
+		baseURL = clioDefaultBaseURL
+	}
+	return &ClioProvider{token: token, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type clioContact struct {
+	ID                  int64  `json:"id"`
+	Name                string `json:"name"`
+	PrimaryEmailAddress string `json:"primary_email_address"`
+	PrimaryPhoneNumber  string `json:"primary_phone_number"`
+}
+
+type clioMatter struct {
+	ID            int64  `json:"id"`
+	DisplayNumber string `json:"display_number"`
+	Description   string `json:"description"`
+	Status        string `json:"status"`
+}
+
+// Lookup implements Provider
+func (c *ClioProvider) Lookup(ctx context.Context, firmID, number string) (*Contact, error) {
+	var contacts struct {
+		Data []clioContact `json:"data"`
+	}
+	err := c.get(ctx, "/api/v4/contacts.json", url.Values{
+		"query":  {number},
+		"fields": {"id,name,primary_email_address,primary_phone_number"},
+		"limit":  {"1"},
+	}, &contacts)
+	if err != nil {
+		return nil, err
+	}
+	if len(contacts.Data) == 0 {
+		return nil, nil
+	}
+
+	match := contacts.Data[0]
+	contact := &Contact{
+		ID:     strconv.FormatInt(match.ID, 10),
+		Name:   match.Name,
+		Email:  match.PrimaryEmailAddress,
+		Phone:  match.PrimaryPhoneNumber,
+		Source: ProviderClio,
+	}
+
+	var matters struct {
+		Data []clioMatter `json:"data"`
+	}
+	err = c.get(ctx, "/api/v4/matters.json", url.Values{
+		"client_id": {contact.ID},
+		"status":    {"open,pending"},
+		"fields":    {"id,display_number,description,status"},
+		"limit":     {"5"},
+	}, &matters)
+	if err != nil {
+		// The contact alone is still useful for the greeting
+		return contact, nil
+	}
+	for _, m := range matters.Data {
+		contact.Matters = append(contact.Matters, Matter{
+			ID:          strconv.FormatInt(m.ID, 10),
+			Number:      m.DisplayNumber,
+			Description: m.Description,
+			Status:      m.Status,
+		})
+	}
+	return contact, nil
+}
+
+func (c *ClioProvider) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("clio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("clio %s returned status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode clio response: %w", err)
+	}
+	return nil
+}
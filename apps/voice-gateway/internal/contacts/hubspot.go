@@ -0,0 +1,85 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// hubspotDefaultBaseURL is the HubSpot API host
+const hubspotDefaultBaseURL = "https://api.hubapi.com"
+
+// HubSpotProvider matches callers against HubSpot CRM contacts
+type HubSpotProvider struct {
+	token   string
+	baseURL string
+}
+
+// NewHubSpotProvider creates a HubSpot provider using a private app token
+func NewHubSpotProvider(token, baseURL string) *HubSpotProvider {
+	if baseURL == "" {
+		baseURL = hubspotDefaultBaseURL
+	}
+	return &HubSpotProvider{token: token, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type hubspotSearchResponse struct {
+	Results []struct {
+		ID         string            `json:"id"`
+		Properties map[string]string `json:"properties"`
+	} `json:"results"`
+}
+
+// Lookup implements Provider
+func (h *HubSpotProvider) Lookup(ctx context.Context, firmID, number string) (*Contact, error) {
+	search := map[string]interface{}{
+		"filterGroups": []map[string]interface{}{
+			{"filters": []map[string]string{{"propertyName": "phone", "operator": "EQ", "value": number}}},
+			{"filters": []map[string]string{{"propertyName": "mobilephone", "operator": "EQ", "value": number}}},
+		},
+		"properties": []string{"firstname", "lastname", "email", "phone"},
+		"limit":      1,
+	}
+	body, err := json.Marshal(search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/crm/v3/objects/contacts/search", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+h.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("hubspot request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hubspot contact search returned status %d", resp.StatusCode)
+	}
+
+	var result hubspotSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode hubspot response: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+
+	match := result.Results[0]
+	name := strings.TrimSpace(match.Properties["firstname"] + " " + match.Properties["lastname"])
+	return &Contact{
+		ID:     match.ID,
+		Name:   name,
+		Email:  match.Properties["email"],
+		Phone:  match.Properties["phone"],
+		Source: ProviderHubSpot,
+	}, nil
+}
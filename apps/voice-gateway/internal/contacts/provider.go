@@ -0,0 +1,100 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Contact is a caller matched in a firm's CRM
+type Contact struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Email   string   `json:"email,omitempty"`
+	Phone   string   `json:"phone,omitempty"`
+	Matters []Matter `json:"matters,omitempty"` // Open matters for the client
+	Source  string   `json:"source,omitempty"`  // Provider that matched the contact
+}
+
+// Matter is an open legal matter for a client
+type Matter struct {
+	ID          string `json:"id"`
+	Number      string `json:"number,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+// Provider looks up callers by phone number
+// Lookup returns (nil, nil) when no contact matches
+type Provider interface {
+	Lookup(ctx context.Context, firmID, number string) (*Contact, error)
+}
+
+// Provider names accepted in firm settings
+const (
+	ProviderWebhook = "webhook"
+	ProviderClio    = "clio"
+	ProviderHubSpot = "hubspot"
+)
+
+// ProviderConfig selects and configures a firm's contact provider
+type ProviderConfig struct {
+	Provider string
+	URL      string // Webhook URL, or API base URL override for Clio/HubSpot
+	APIToken string // Bearer token (Clio/HubSpot) or HMAC secret (webhook)
+}
+
+// httpClient is shared by all providers; lookups sit on the call-start path
+var httpClient = &http.Client{Timeout: 5 * time.Second}
+
+// NewProvider builds the provider described by cfg; returns nil when none is configured
+func NewProvider(cfg ProviderConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook contact provider requires a URL")
+		}
+		return NewWebhookProvider(cfg.URL, cfg.APIToken), nil
+	case ProviderClio:
+		return NewClioProvider(cfg.APIToken, cfg.URL), nil
+	case ProviderHubSpot:
+		return NewHubSpotProvider(cfg.APIToken, cfg.URL), nil
+	default:
+		return nil, fmt.Errorf("unknown contact provider %q", cfg.Provider)
+	}
+}
+
+// Metadata flattens the contact into orchestrator request metadata
+func (c *Contact) Metadata() map[string]string {
+	md := map[string]string{
+		"caller_known": "true",
+		"caller_name":  c.Name,
+	}
+	if c.ID != "" {
+		md["caller_contact_id"] = c.ID
+	}
+	if c.Email != "" {
+		md["caller_email"] = c.Email
+	}
+	if c.Source != "" {
+		md["caller_source"] = c.Source
+	}
+	if len(c.Matters) > 0 {
+		descriptions := make([]string, 0, len(c.Matters))
+		for _, m := range c.Matters {
+			label := m.Description
+			if m.Number != "" {
+				label = m.Number + ": " + label
+			}
+			descriptions = append(descriptions, label)
+		}
+		md["open_matters"] = strings.Join(descriptions, "; ")
+		md["open_matter_count"] = strconv.Itoa(len(c.Matters))
+	}
+	return md
+}
@@ -0,0 +1,123 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookProvider_Lookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["phone_number"] != "+15551234567" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("X-LexiqAI-Signature") == "" {
+			t.Error("Expected signed request")
+		}
+		w.Write([]byte(`{"id":"c1","name":"Jane Doe","matters":[{"id":"m1","number":"2024-001","description":"Smith v. Doe"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewWebhookProvider(server.URL, "secret")
+
+	contact, err := provider.Lookup(context.Background(), "firm-1", "+15551234567")
+	if err != nil {
+		t.Fatalf("Lookup() failed: %v", err)
+	}
+	if contact == nil || contact.Name != "Jane Doe" || len(contact.Matters) != 1 {
+		t.Fatalf("Unexpected contact: %+v", contact)
+	}
+	if contact.Source != ProviderWebhook {
+		t.Errorf("Expected source webhook, got '%s'", contact.Source)
+	}
+
+	contact, err = provider.Lookup(context.Background(), "firm-1", "+15550000000")
+	if err != nil || contact != nil {
+		t.Errorf("Expected no match, got %+v (err=%v)", contact, err)
+	}
+}
+
+func TestClioProvider_Lookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected bearer token")
+		}
+		switch r.URL.Path {
+		case "/api/v4/contacts.json":
+			w.Write([]byte(`{"data":[{"id":42,"name":"John Smith"}]}`))
+		case "/api/v4/matters.json":
+			if r.URL.Query().Get("client_id") != "42" {
+				t.Errorf("Expected matters for client 42, got %s", r.URL.Query().Get("client_id"))
+			}
+			w.Write([]byte(`{"data":[{"id":7,"display_number":"00007-Smith","description":"Estate planning","status":"Open"}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	contact, err := NewClioProvider("token", server.URL).Lookup(context.Background(), "firm-1", "+15551234567")
+	if err != nil {
+		t.Fatalf("Lookup() failed: %v", err)
+	}
+	if contact.ID != "42" || contact.Name != "John Smith" {
+		t.Errorf("Unexpected contact: %+v", contact)
+	}
+	if len(contact.Matters) != 1 || contact.Matters[0].Number != "00007-Smith" {
+		t.Errorf("Unexpected matters: %+v", contact.Matters)
+	}
+}
+
+func TestHubSpotProvider_Lookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results":[{"id":"101","properties":{"firstname":"Ana","lastname":"Lopez","email":"ana@example.com"}}]}`))
+	}))
+	defer server.Close()
+
+	contact, err := NewHubSpotProvider("token", server.URL).Lookup(context.Background(), "firm-1", "+15551234567")
+	if err != nil {
+		t.Fatalf("Lookup() failed: %v", err)
+	}
+	if contact.Name != "Ana Lopez" || contact.Email != "ana@example.com" {
+		t.Errorf("Unexpected contact: %+v", contact)
+	}
+}
+
+func TestContact_Metadata(t *testing.T) {
+	contact := &Contact{
+		ID:   "c1",
+		Name: "Jane Doe",
+		Matters: []Matter{
+			{Number: "2024-001", Description: "Smith v. Doe"},
+			{Description: "Lease review"},
+		},
+	}
+
+	md := contact.Metadata()
+	if md["caller_name"] != "Jane Doe" || md["caller_known"] != "true" {
+		t.Errorf("Unexpected caller metadata: %v", md)
+	}
+	if md["open_matters"] != "2024-001: Smith v. Doe; Lease review" {
+		t.Errorf("Unexpected open_matters: %s", md["open_matters"])
+	}
+	if md["open_matter_count"] != "2" {
+		t.Errorf("Expected 2 open matters, got %s", md["open_matter_count"])
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	if p, err := NewProvider(ProviderConfig{}); p != nil || err != nil {
+		t.Errorf("Expected no provider for empty config, got %v (err=%v)", p, err)
+	}
+	if _, err := NewProvider(ProviderConfig{Provider: ProviderWebhook}); err == nil {
+		t.Error("Expected error for webhook without URL")
+	}
+	if _, err := NewProvider(ProviderConfig{Provider: "salesforce"}); err == nil {
+		t.Error("Expected error for unknown provider")
+	}
+}
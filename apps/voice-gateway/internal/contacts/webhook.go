@@ -0,0 +1,69 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+)
+
+// WebhookProvider asks a firm-hosted endpoint (CRM bridge) to match the caller
+// POSTs {"firm_id","phone_number"}; expects a Contact as JSON, or 404 for no match
+type WebhookProvider struct {
+	url    string
+	secret string
+}
+
+// NewWebhookProvider creates a webhook provider; requests are HMAC-signed when secret is set
+func NewWebhookProvider(url, secret string) *WebhookProvider {
+	return &WebhookProvider{url: url, secret: secret}
+}
+
+// Lookup implements Provider
+func (w *WebhookProvider) Lookup(ctx context.Context, firmID, number string) (*Contact, error) {
+	body, err := json.Marshal(map[string]string{
+		"firm_id":      firmID,
+		"phone_number": number,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lookup: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set(events.SignatureHeader, "sha256="+events.Sign(w.secret, body))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contact webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("contact webhook returned status %d", resp.StatusCode)
+	}
+
+	var contact Contact
+	if err := json.NewDecoder(resp.Body).Decode(&contact); err != nil {
+		return nil, fmt.Errorf("failed to decode contact: %w", err)
+	}
+	if contact.Name == "" && contact.ID == "" {
+		return nil, nil
+	}
+	if contact.Source == "" {
+		contact.Source = ProviderWebhook
+	}
+	return &contact, nil
+}
@@ -0,0 +1,217 @@
+// Package contract replays wire messages recorded from the gateway's
+// providers (Deepgram, Cartesia, Twilio) through the real parsing code in
+// tests, so a provider renaming a field or adding an event fails CI instead
+// of a live call. Fixtures live in each package's testdata as JSON Lines, one
+// recorded message per line, next to a golden file holding what the gateway
+// made of them. Run the tests with UPDATE_GOLDEN=1 to rewrite the golden files
+// after re-recording
+package contract
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Fixture is one recorded exchange with a provider
+type Fixture struct {
+	Name     string
+	Messages [][]byte // Raw messages, in the order they were received
+	Golden   string   // Path of the golden file for what the messages parse into
+}
+
+// Load reads every *.jsonl fixture in dir, sorted by name
+func Load(t testing.TB, dir string) []Fixture {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to list fixtures in %s: %v", dir, err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("No fixtures in %s", dir)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read fixture: %v", err)
+		}
+		fixture := Fixture{
+			Name:   strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+			Golden: strings.TrimSuffix(path, ".jsonl") + ".golden.json",
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				fixture.Messages = append(fixture.Messages, append([]byte(nil), line...))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("Failed to read fixture %s: %v", path, err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures
+}
+
+// Golden compares got, as indented JSON, with the golden file at path, or
+// rewrites the file when UPDATE_GOLDEN is set
+func Golden(t testing.TB, path string, got interface{}) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	data = append(data, '\n')
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("Failed to write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Parsed messages differ from %s\ngot:\n%s\nwant:\n%s", path, data, want)
+	}
+}
+
+// RequireModelled fails the test for fields in message that v's type does not
+// decode, other than those listed in ignored. A field the provider renamed
+// shows up here under its new name
+func RequireModelled(t testing.TB, message []byte, v interface{}, ignored ...string) {
+	t.Helper()
+	unknown, err := UnknownFields(message, v)
+	if err != nil {
+		t.Errorf("Failed to decode %s: %v", message, err)
+		return
+	}
+	skip := make(map[string]bool, len(ignored))
+	for _, field := range ignored {
+		skip[field] = true
+	}
+	for _, field := range unknown {
+		if !skip[field] {
+			t.Errorf("Field %q is not decoded by %T: %s", field, v, message)
+		}
+	}
+}
+
+// UnknownFields lists, as dotted paths, the fields in a JSON message that
+// decoding it into v's type would drop. Array elements appear as "name[]";
+// types with their own UnmarshalJSON are not looked into
+func UnknownFields(message []byte, v interface{}) ([]string, error) {
+	var raw interface{}
+	if err := json.Unmarshal(message, &raw); err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	walk(raw, reflect.TypeOf(v), "", found)
+	unknown := make([]string, 0, len(found))
+	for path := range found {
+		unknown = append(unknown, path)
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func walk(raw interface{}, t reflect.Type, path string, unknown map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+	switch value := raw.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for key, item := range value {
+				walk(item, t.Elem(), join(path, key), unknown)
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, item := range value {
+				field, ok := lookup(fields, key)
+				if !ok {
+					unknown[join(path, key)] = true
+					continue
+				}
+				walk(item, field, join(path, key), unknown)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, item := range value {
+				walk(item, t.Elem(), path+"[]", unknown)
+			}
+		}
+	}
+}
+
+// jsonFields maps a struct's JSON names to field types, flattening embedded
+// structs as encoding/json does
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for embedded, et := range jsonFields(ft) {
+				if _, ok := fields[embedded]; !ok {
+					fields[embedded] = et
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookup finds a key the way encoding/json does: exactly, then ignoring case
+func lookup(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,38 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+)
+
+type inner struct {
+	Name string `json:"name"`
+}
+
+type message struct {
+	inner
+	Items  []inner          `json:"items"`
+	Extra  map[string]inner `json:"extra"`
+	Nested *inner           `json:"nested,omitempty"`
+	Skip   string           `json:"-"`
+	Plain  string
+}
+
+func TestUnknownFields(t *testing.T) {
+	got, err := UnknownFields([]byte(`{
+		"name": "a",
+		"plain": "matched ignoring case",
+		"items": [{"name": "b", "renamed": 1}, {"renamed": 2}],
+		"extra": {"k": {"name": "c", "new": true}},
+		"nested": {"name": "d", "added": {"deep": 1}},
+		"Skip": "x",
+		"sequence": 3
+	}`), message{})
+	if err != nil {
+		t.Fatalf("UnknownFields failed: %v", err)
+	}
+	want := []string{"Skip", "extra.k.new", "items[].renamed", "nested.added", "sequence"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
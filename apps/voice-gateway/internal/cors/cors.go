@@ -0,0 +1,187 @@
+// Package cors decides which browser origins may call the gateway. Origins
+// allowed on every endpoint come from config; a firm may allow more for
+// endpoints about its own calls, such as live transcripts. Requests without
+// an Origin header (Twilio, server-side clients) are not browser requests and
+// are always let through
+package cors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+const (
+	allowedMethods = "GET, POST, PUT, DELETE, OPTIONS"
+	allowedHeaders = "Authorization, Content-Type"
+
+	// preflightMaxAge is how long browsers may cache a preflight result, in seconds
+	preflightMaxAge = "600"
+)
+
+// FirmOrigins returns the extra origins allowed for the firm a request
+// concerns, or nil when the request is not about one firm
+type FirmOrigins func(r *http.Request) []string
+
+// Policy decides whether a browser origin may call an endpoint. A nil Policy
+// allows same-origin requests only
+type Policy struct {
+	global []pattern
+	firm   FirmOrigins
+}
+
+// New creates a policy allowing the given origins everywhere and, when
+// firmOrigins is set, each firm's own origins on its requests
+func New(allowed []string, firmOrigins FirmOrigins) (*Policy, error) {
+	global, err := parsePatterns(allowed)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{global: global, firm: firmOrigins}, nil
+}
+
+// ValidateOrigins checks origin entries: "*", an origin such as
+// "https://app.example.com" (port optional), or a subdomain wildcard such as
+// "https://*.example.com"
+func ValidateOrigins(entries []string) error {
+	_, err := parsePatterns(entries)
+	return err
+}
+
+// Allowed reports whether the request may proceed: it carries no Origin, or
+// its origin is this host, allowed globally, or allowed by the request's firm
+func (p *Policy) Allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || sameOrigin(origin, r.Host) {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	for _, pat := range p.global {
+		if pat.matches(origin) {
+			return true
+		}
+	}
+	if p.firm == nil {
+		return false
+	}
+	for _, entry := range p.firm(r) {
+		// Firm settings are validated on load, so a bad entry here just never matches
+		if pat, err := parsePattern(entry); err == nil && pat.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckOrigin is a websocket.Upgrader CheckOrigin that applies the policy and
+// records refusals in the access log
+func (p *Policy) CheckOrigin(r *http.Request) bool {
+	if p.Allowed(r) {
+		return true
+	}
+	observability.AccessRecordFrom(r.Context()).Reject("origin_not_allowed", r.Header.Get("Origin"))
+	return false
+}
+
+// Middleware answers CORS preflights and adds CORS headers for allowed
+// origins; requests from other origins get 403 before reaching next. It must
+// wrap authentication, since browsers send preflights without credentials
+func (p *Policy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		if !p.CheckOrigin(r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "origin not allowed"})
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", preflightMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// sameOrigin reports whether origin names the host the request was sent to.
+// The scheme is not compared, since TLS usually ends at a load balancer
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// pattern is a parsed origin entry, lowercase
+type pattern struct {
+	any    bool   // "*": every origin except "null"
+	exact  string // scheme://host[:port]
+	prefix string // Subdomain wildcard "scheme://"
+	suffix string // Subdomain wildcard ".example.com[:port]"
+}
+
+func (p pattern) matches(origin string) bool {
+	switch {
+	case p.any:
+		return origin != "null"
+	case p.exact != "":
+		return origin == p.exact
+	}
+	return len(origin) > len(p.prefix)+len(p.suffix) &&
+		strings.HasPrefix(origin, p.prefix) && strings.HasSuffix(origin, p.suffix)
+}
+
+func parsePatterns(entries []string) ([]pattern, error) {
+	var patterns []pattern
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		pat, err := parsePattern(entry)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pat)
+	}
+	return patterns, nil
+}
+
+func parsePattern(entry string) (pattern, error) {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	if entry == "*" {
+		return pattern{any: true}, nil
+	}
+
+	u, err := url.Parse(entry)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return pattern{}, fmt.Errorf("invalid origin %q: want scheme://host[:port]", entry)
+	}
+
+	if rest, ok := strings.CutPrefix(u.Host, "*."); ok {
+		if rest == "" || strings.Contains(rest, "*") {
+			return pattern{}, fmt.Errorf("invalid origin %q: wildcard must be a leading subdomain", entry)
+		}
+		return pattern{prefix: u.Scheme + "://", suffix: "." + rest}, nil
+	}
+	if strings.Contains(u.Host, "*") {
+		return pattern{}, fmt.Errorf("invalid origin %q: wildcard must be a leading subdomain", entry)
+	}
+	return pattern{exact: u.Scheme + "://" + u.Host}, nil
+}
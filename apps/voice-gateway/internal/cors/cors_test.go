@@ -0,0 +1,157 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func request(method, path, origin string) *http.Request {
+	r := httptest.NewRequest(method, "http://gateway.example.com"+path, nil)
+	if origin != "" {
+		r.Header.Set("Origin", origin)
+	}
+	return r
+}
+
+func TestValidateOrigins(t *testing.T) {
+	valid := []string{"*", "https://app.example.com", "http://localhost:3000", "https://*.example.com", " HTTPS://Dash.Example.com/ ", ""}
+	if err := ValidateOrigins(valid); err != nil {
+		t.Errorf("Expected valid origins, got %v", err)
+	}
+
+	for _, entry := range []string{
+		"app.example.com",
+		"ftp://app.example.com",
+		"https://app.example.com/dashboard",
+		"https://app.example.com?x=1",
+		"https://user@app.example.com",
+		"https://app.*.com",
+		"https://*.",
+	} {
+		if err := ValidateOrigins([]string{entry}); err == nil {
+			t.Errorf("Expected error for %q", entry)
+		}
+	}
+}
+
+func TestPolicy_Allowed(t *testing.T) {
+	policy, err := New([]string{"https://app.lexiq.ai", "https://*.dashboards.lexiq.ai"}, nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"", true},                            // Not a browser
+		{"https://gateway.example.com", true}, // Same origin
+		{"https://app.lexiq.ai", true},
+		{"HTTPS://APP.LEXIQ.AI", true},
+		{"http://app.lexiq.ai", false},
+		{"https://app.lexiq.ai:8443", false},
+		{"https://acme.dashboards.lexiq.ai", true},
+		{"https://eu.acme.dashboards.lexiq.ai", true},
+		{"https://dashboards.lexiq.ai", false},
+		{"https://evil-dashboards.lexiq.ai", false},
+		{"https://evil.com", false},
+		{"null", false},
+	}
+	for _, tt := range tests {
+		if got := policy.Allowed(request(http.MethodGet, "/admin/calls", tt.origin)); got != tt.want {
+			t.Errorf("Allowed(%q): expected %v, got %v", tt.origin, tt.want, got)
+		}
+	}
+}
+
+func TestPolicy_Wildcard(t *testing.T) {
+	policy, _ := New([]string{"*"}, nil)
+	if !policy.Allowed(request(http.MethodGet, "/", "https://anything.example.org")) {
+		t.Error("Expected * to allow any origin")
+	}
+	if policy.Allowed(request(http.MethodGet, "/", "null")) {
+		t.Error("Expected * not to allow the null origin")
+	}
+}
+
+func TestPolicy_FirmOrigins(t *testing.T) {
+	policy, _ := New(nil, func(r *http.Request) []string {
+		if r.URL.Path == "/calls/CA1/live" {
+			return []string{"https://acme-law.example.com"}
+		}
+		return nil
+	})
+
+	if !policy.Allowed(request(http.MethodGet, "/calls/CA1/live", "https://acme-law.example.com")) {
+		t.Error("Expected the firm's origin to be allowed on its call")
+	}
+	if policy.Allowed(request(http.MethodGet, "/calls/CA2/live", "https://acme-law.example.com")) {
+		t.Error("Expected the firm's origin to be refused on another firm's call")
+	}
+	if policy.Allowed(request(http.MethodGet, "/admin/calls", "https://acme-law.example.com")) {
+		t.Error("Expected the firm's origin to be refused on endpoints not about its calls")
+	}
+}
+
+func TestPolicy_NilAllowsSameOriginOnly(t *testing.T) {
+	var policy *Policy
+	if !policy.CheckOrigin(request(http.MethodGet, "/streams/twilio", "")) {
+		t.Error("Expected requests without Origin to be allowed")
+	}
+	if !policy.CheckOrigin(request(http.MethodGet, "/streams/twilio", "https://gateway.example.com")) {
+		t.Error("Expected same-origin requests to be allowed")
+	}
+	if policy.CheckOrigin(request(http.MethodGet, "/streams/twilio", "https://evil.com")) {
+		t.Error("Expected cross-origin requests to be refused")
+	}
+}
+
+func TestPolicy_Middleware(t *testing.T) {
+	policy, _ := New([]string{"https://app.lexiq.ai"}, nil)
+	reached := false
+	handler := policy.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusUnauthorized) // As auth would for a credential-less preflight
+	}))
+
+	// Preflight is answered without reaching auth
+	rec := httptest.NewRecorder()
+	preflight := request(http.MethodOptions, "/admin/calls", "https://app.lexiq.ai")
+	preflight.Header.Set("Access-Control-Request-Method", "GET")
+	handler.ServeHTTP(rec, preflight)
+	if rec.Code != http.StatusNoContent || reached {
+		t.Errorf("Expected 204 without reaching next, got %d (reached %v)", rec.Code, reached)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.lexiq.ai" {
+		t.Errorf("Expected Allow-Origin https://app.lexiq.ai, got %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Headers"); got != allowedHeaders {
+		t.Errorf("Expected Allow-Headers %q, got %q", allowedHeaders, got)
+	}
+
+	// Allowed origin passes through with CORS headers
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodGet, "/admin/calls", "https://app.lexiq.ai"))
+	if !reached || rec.Header().Get("Access-Control-Allow-Origin") != "https://app.lexiq.ai" || rec.Header().Get("Vary") != "Origin" {
+		t.Errorf("Expected request to reach next with CORS headers, got %v", rec.Header())
+	}
+
+	// Other origins are refused
+	reached = false
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodGet, "/admin/calls", "https://evil.com"))
+	if rec.Code != http.StatusForbidden || reached {
+		t.Errorf("Expected 403 without reaching next, got %d (reached %v)", rec.Code, reached)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no Allow-Origin for a refused origin, got %q", got)
+	}
+
+	// Non-browser requests are untouched
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, request(http.MethodGet, "/streams/twilio", ""))
+	if !reached || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Expected a request without Origin to pass through without CORS headers")
+	}
+}
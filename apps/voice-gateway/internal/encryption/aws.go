@@ -0,0 +1,115 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+// AWSKMSConfig configures AWS KMS
+type AWSKMSConfig struct {
+	Region          string
+	Endpoint        string // Optional, e.g. http://localstack:4566; empty uses AWS
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// AWSKMS wraps data keys with AWS KMS keys. AWS rotates a key's backing
+// material itself and keeps older material for decryption
+type AWSKMS struct {
+	cfg    AWSKMSConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewAWSKMS creates a KMS client
+func NewAWSKMS(cfg AWSKMSConfig) (*AWSKMS, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS credentials are required for KMS")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", cfg.Region)
+	}
+	return &AWSKMS{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}, nil
+}
+
+// Encrypt wraps dataKey with keyID (a key ID, ARN or alias)
+func (k *AWSKMS) Encrypt(ctx context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	var resp struct {
+		CiphertextBlob []byte `json:"CiphertextBlob"`
+	}
+	err := k.call(ctx, "Encrypt", map[string]interface{}{
+		"KeyId":             keyID,
+		"Plaintext":         dataKey,
+		"EncryptionContext": encryptionContext(keyID),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+// Decrypt unwraps a data key wrapped by keyID
+func (k *AWSKMS) Decrypt(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext []byte `json:"Plaintext"`
+	}
+	err := k.call(ctx, "Decrypt", map[string]interface{}{
+		"KeyId":             keyID,
+		"CiphertextBlob":    wrapped,
+		"EncryptionContext": encryptionContext(keyID),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// encryptionContext binds a wrapped key to the key ID recorded beside it
+func encryptionContext(keyID string) map[string]string {
+	return map[string]string{"lexiqai:key_id": keyID}
+}
+
+// call sends a signed KMS JSON API request
+func (k *AWSKMS) call(ctx context.Context, action string, params interface{}, out interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal KMS %s request: %w", action, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.cfg.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid KMS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	storage.SignV4(req, body, k.cfg.AccessKeyID, k.cfg.SecretAccessKey, k.cfg.Region, "kms", k.now())
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("KMS %s failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("KMS %s returned status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid KMS %s response: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAWSKMS_EncryptDecrypt(t *testing.T) {
+	var targets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-west-2/kms/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		targets = append(targets, r.Header.Get("X-Amz-Target"))
+		var req struct {
+			KeyId             string
+			Plaintext         []byte
+			CiphertextBlob    []byte
+			EncryptionContext map[string]string
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.EncryptionContext["lexiqai:key_id"] != req.KeyId {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// The fake KMS "wraps" by reversing the key
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			json.NewEncoder(w).Encode(map[string][]byte{"CiphertextBlob": reverse(req.Plaintext)})
+		case "TrentService.Decrypt":
+			json.NewEncoder(w).Encode(map[string][]byte{"Plaintext": reverse(req.CiphertextBlob)})
+		}
+	}))
+	defer server.Close()
+
+	kms, err := NewAWSKMS(AWSKMSConfig{Region: "us-west-2", Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewAWSKMS() failed: %v", err)
+	}
+	enc := NewEncryptor(kms, "alias/lexiqai-gateway", nil)
+	ctx := context.Background()
+
+	sealed, err := enc.Seal(ctx, "acme", []byte("recording"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+	plaintext, err := enc.Open(ctx, sealed)
+	if err != nil || string(plaintext) != "recording" {
+		t.Fatalf("Expected the plaintext back, got %q, %v", plaintext, err)
+	}
+	if len(targets) != 2 || targets[0] != "TrentService.Encrypt" || targets[1] != "TrentService.Decrypt" {
+		t.Errorf("Expected Encrypt then Decrypt, got %v", targets)
+	}
+
+	if _, err := NewAWSKMS(AWSKMSConfig{}); err == nil {
+		t.Error("Expected an error without credentials")
+	}
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -0,0 +1,233 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// magic starts every sealed payload, so readers can tell sealed data from
+// plaintext written before encryption was enabled
+var magic = []byte("LQE1")
+
+// dataKeySize is the AES-256 data key generated for each payload
+const dataKeySize = 32
+
+// ErrNotSealed is returned when opening data that was never sealed
+var ErrNotSealed = errors.New("data is not sealed")
+
+// KMS wraps and unwraps data keys under a named master key. Rotating the
+// master key must keep older versions able to unwrap what they wrapped
+type KMS interface {
+	// Encrypt wraps a data key under keyID's current version
+	Encrypt(ctx context.Context, keyID string, dataKey []byte) ([]byte, error)
+
+	// Decrypt unwraps a data key wrapped under any version of keyID
+	Decrypt(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Encryptor seals payloads with envelope encryption: each payload gets its
+// own AES-256-GCM data key, stored alongside the ciphertext wrapped by the
+// firm's KMS key. Only the KMS can unwrap it, so a leaked object or webhook
+// body is unreadable on its own
+type Encryptor struct {
+	kms          KMS
+	defaultKeyID string
+	firmKeyID    func(firmID string) string
+}
+
+// NewEncryptor creates an encryptor; firmKeyID returns a firm's own key ID,
+// or "" to use defaultKeyID
+func NewEncryptor(kms KMS, defaultKeyID string, firmKeyID func(firmID string) string) *Encryptor {
+	return &Encryptor{kms: kms, defaultKeyID: defaultKeyID, firmKeyID: firmKeyID}
+}
+
+// KeyID returns the key that seals a firm's data
+func (e *Encryptor) KeyID(firmID string) string {
+	if e.firmKeyID != nil && firmID != "" {
+		if keyID := e.firmKeyID(firmID); keyID != "" {
+			return keyID
+		}
+	}
+	return e.defaultKeyID
+}
+
+// Seal encrypts plaintext under a new data key wrapped by the firm's key
+func (e *Encryptor) Seal(ctx context.Context, firmID string, plaintext []byte) ([]byte, error) {
+	keyID := e.KeyID(firmID)
+	if keyID == "" {
+		return nil, fmt.Errorf("no encryption key for firm %q", firmID)
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := e.kms.Encrypt(ctx, keyID, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key with %s: %w", keyID, err)
+	}
+	return seal(keyID, wrapped, dataKey, plaintext)
+}
+
+// Open decrypts a sealed payload, whichever key and key version sealed it
+func (e *Encryptor) Open(ctx context.Context, sealed []byte) ([]byte, error) {
+	env, err := parse(sealed)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := e.kms.Decrypt(ctx, env.keyID, env.wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with %s: %w", env.keyID, err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, env.nonce, env.ciphertext, env.header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt payload: %w", err)
+	}
+	return plaintext, nil
+}
+
+// Rewrap re-seals a payload's data key under the firm's current key and key
+// version. Run after rotating a master key (or moving a firm to its own key)
+// so older key versions can be retired
+func (e *Encryptor) Rewrap(ctx context.Context, firmID string, sealed []byte) ([]byte, error) {
+	env, err := parse(sealed)
+	if err != nil {
+		return nil, err
+	}
+	keyID := e.KeyID(firmID)
+	if keyID == "" {
+		return nil, fmt.Errorf("no encryption key for firm %q", firmID)
+	}
+
+	dataKey, err := e.kms.Decrypt(ctx, env.keyID, env.wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with %s: %w", env.keyID, err)
+	}
+	wrapped, err := e.kms.Encrypt(ctx, keyID, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key with %s: %w", keyID, err)
+	}
+
+	// The header is the payload's associated data, so the payload is
+	// re-encrypted under the same data key with the new header
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, env.nonce, env.ciphertext, env.header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt payload: %w", err)
+	}
+	return seal(keyID, wrapped, dataKey, plaintext)
+}
+
+// IsSealed reports whether data is a sealed payload
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// SealedKeyID returns the key that sealed data
+func SealedKeyID(sealed []byte) (string, error) {
+	env, err := parse(sealed)
+	if err != nil {
+		return "", err
+	}
+	return env.keyID, nil
+}
+
+// envelope is a parsed sealed payload:
+//
+//	"LQE1" | key ID length (uint16) | key ID | wrapped key length (uint16) | wrapped key | nonce | ciphertext
+//
+// Everything before the nonce is the header, authenticated with the ciphertext
+type envelope struct {
+	header     []byte
+	keyID      string
+	wrapped    []byte
+	nonce      []byte
+	ciphertext []byte
+}
+
+// seal writes the envelope for plaintext encrypted under dataKey
+func seal(keyID string, wrapped, dataKey, plaintext []byte) ([]byte, error) {
+	if len(keyID) > 0xFFFF || len(wrapped) > 0xFFFF {
+		return nil, fmt.Errorf("encryption key ID or wrapped key too long")
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magic)
+	binary.Write(&buf, binary.BigEndian, uint16(len(keyID)))
+	buf.WriteString(keyID)
+	binary.Write(&buf, binary.BigEndian, uint16(len(wrapped)))
+	buf.Write(wrapped)
+	header := buf.Bytes()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, plaintext, header), nil
+}
+
+// parse splits a sealed payload into its parts
+func parse(sealed []byte) (*envelope, error) {
+	if !IsSealed(sealed) {
+		return nil, ErrNotSealed
+	}
+	rest := sealed[len(magic):]
+	keyID, rest, ok := field(rest)
+	if !ok {
+		return nil, fmt.Errorf("truncated sealed payload")
+	}
+	wrapped, rest, ok := field(rest)
+	if !ok {
+		return nil, fmt.Errorf("truncated sealed payload")
+	}
+	const nonceSize = 12 // AES-GCM standard nonce
+	if len(rest) < nonceSize {
+		return nil, fmt.Errorf("truncated sealed payload")
+	}
+	return &envelope{
+		header:     sealed[:len(sealed)-len(rest)],
+		keyID:      string(keyID),
+		wrapped:    wrapped,
+		nonce:      rest[:nonceSize],
+		ciphertext: rest[nonceSize:],
+	}, nil
+}
+
+// field reads a uint16 length-prefixed field
+func field(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 2 {
+		return nil, nil, false
+	}
+	n := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+n {
+		return nil, nil, false
+	}
+	return data[2 : 2+n], data[2+n:], true
+}
+
+// newAEAD returns AES-GCM for a 256-bit key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,129 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testKey returns a base64 AES-256 key filled with b
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func newTestEncryptor(t *testing.T, specs ...string) *Encryptor {
+	t.Helper()
+	keyring, err := ParseKeyring(specs)
+	if err != nil {
+		t.Fatalf("ParseKeyring() failed: %v", err)
+	}
+	return NewEncryptor(keyring, "gateway", func(firmID string) string {
+		if firmID == "acme" {
+			return "acme"
+		}
+		return ""
+	})
+}
+
+func TestEncryptor_SealOpen(t *testing.T) {
+	enc := newTestEncryptor(t, "gateway:1:"+testKey(1), "acme:1:"+testKey(2))
+	ctx := context.Background()
+
+	sealed, err := enc.Seal(ctx, "acme", []byte("privileged transcript"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+	if !IsSealed(sealed) || bytes.Contains(sealed, []byte("privileged")) {
+		t.Fatalf("Expected an opaque sealed payload, got %q", sealed)
+	}
+	if keyID, _ := SealedKeyID(sealed); keyID != "acme" {
+		t.Errorf("Expected the firm's own key, got %q", keyID)
+	}
+
+	plaintext, err := enc.Open(ctx, sealed)
+	if err != nil || string(plaintext) != "privileged transcript" {
+		t.Fatalf("Expected the plaintext back, got %q, %v", plaintext, err)
+	}
+
+	other, _ := enc.Seal(ctx, "globex", []byte("x"))
+	if keyID, _ := SealedKeyID(other); keyID != "gateway" {
+		t.Errorf("Expected the default key for a firm without one, got %q", keyID)
+	}
+
+	sealed[len(sealed)-1] ^= 1
+	if _, err := enc.Open(ctx, sealed); err == nil {
+		t.Error("Expected tampered ciphertext to fail")
+	}
+	if _, err := enc.Open(ctx, []byte("RIFF plain wav")); !errors.Is(err, ErrNotSealed) {
+		t.Errorf("Expected ErrNotSealed, got %v", err)
+	}
+}
+
+func TestEncryptor_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	before := newTestEncryptor(t, "gateway:1:"+testKey(1))
+	sealed, err := before.Seal(ctx, "", []byte("call record"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+
+	// Version 2 wraps new data; version 1 still opens what it sealed
+	rotated := newTestEncryptor(t, "gateway:2:"+testKey(3), "gateway:1:"+testKey(1))
+	if plaintext, err := rotated.Open(ctx, sealed); err != nil || string(plaintext) != "call record" {
+		t.Fatalf("Expected the old version to open, got %q, %v", plaintext, err)
+	}
+	rewrapped, err := rotated.Rewrap(ctx, "", sealed)
+	if err != nil {
+		t.Fatalf("Rewrap() failed: %v", err)
+	}
+
+	// Once rewrapped, version 1 can be retired
+	retired := newTestEncryptor(t, "gateway:2:"+testKey(3))
+	if _, err := retired.Open(ctx, sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected the retired version to be unknown, got %v", err)
+	}
+	if plaintext, err := retired.Open(ctx, rewrapped); err != nil || string(plaintext) != "call record" {
+		t.Errorf("Expected the rewrapped payload to open, got %q, %v", plaintext, err)
+	}
+}
+
+func TestEncryptor_NoKey(t *testing.T) {
+	keyring, _ := ParseKeyring(nil)
+	enc := NewEncryptor(keyring, "", nil)
+	if _, err := enc.Seal(context.Background(), "acme", []byte("x")); err == nil {
+		t.Error("Expected an error sealing without a key")
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	keyring, err := ParseKeyring([]string{"gateway:1:" + testKey(1), " acme:3:" + testKey(2), ""})
+	if err != nil {
+		t.Fatalf("ParseKeyring() failed: %v", err)
+	}
+	if !keyring.Has("gateway") || !keyring.Has("acme") || keyring.Has("globex") {
+		t.Error("Expected the gateway and acme keys only")
+	}
+
+	for _, spec := range []string{
+		"gateway",
+		"gateway:0:" + testKey(1),
+		"gateway:one:" + testKey(1),
+		"gateway:1:c2hvcnQ=",
+		"gateway:1:not base64",
+	} {
+		_, err := ParseKeyring([]string{spec})
+		if err == nil {
+			t.Errorf("Expected error for %q", spec)
+			continue
+		}
+		if strings.Contains(err.Error(), testKey(1)) {
+			t.Errorf("Expected the key material kept out of %v", err)
+		}
+	}
+	if _, err := ParseKeyring([]string{"gateway:1:" + testKey(1), "gateway:1:" + testKey(2)}); err == nil {
+		t.Error("Expected error for a duplicate key version")
+	}
+}
@@ -0,0 +1,123 @@
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownKey is returned for a key ID (or key version) the KMS doesn't hold
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Keyring is a KMS backed by master keys held in gateway configuration, for
+// deployments without a cloud KMS. Each key ID may have several versions:
+// the newest wraps new data keys and the older ones still unwrap theirs, so
+// a key is rotated by adding a version and retired once data is rewrapped
+type Keyring struct {
+	keys map[string][]masterKey // Sorted by version, newest last
+}
+
+type masterKey struct {
+	version uint32
+	key     []byte
+}
+
+// ParseKeyring parses master keys as "key_id:version:base64 AES-256 key"
+func ParseKeyring(specs []string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string][]masterKey)}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		parts := strings.SplitN(spec, ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid encryption key %q: expected key_id:version:base64_key", redact(spec))
+		}
+		version, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid encryption key %s: version must be a positive integer", parts[0])
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[2])
+		if err != nil || len(key) != dataKeySize {
+			return nil, fmt.Errorf("invalid encryption key %s version %d: expected a base64 32-byte key", parts[0], version)
+		}
+		for _, existing := range k.keys[parts[0]] {
+			if existing.version == uint32(version) {
+				return nil, fmt.Errorf("duplicate encryption key %s version %d", parts[0], version)
+			}
+		}
+		k.keys[parts[0]] = append(k.keys[parts[0]], masterKey{version: uint32(version), key: key})
+	}
+	for _, versions := range k.keys {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].version < versions[j].version })
+	}
+	return k, nil
+}
+
+// Has reports whether the keyring holds keyID
+func (k *Keyring) Has(keyID string) bool {
+	return len(k.keys[keyID]) > 0
+}
+
+// Encrypt wraps dataKey under keyID's newest version: version | nonce | ciphertext
+func (k *Keyring) Encrypt(ctx context.Context, keyID string, dataKey []byte) ([]byte, error) {
+	versions := k.keys[keyID]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	current := versions[len(versions)-1]
+	aead, err := newAEAD(current.key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := binary.BigEndian.AppendUint32(nil, current.version)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, dataKey, []byte(keyID)), nil
+}
+
+// Decrypt unwraps a data key with the key version that wrapped it
+func (k *Keyring) Decrypt(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 4 {
+		return nil, fmt.Errorf("truncated wrapped key")
+	}
+	version := binary.BigEndian.Uint32(wrapped)
+	for _, master := range k.keys[keyID] {
+		if master.version != version {
+			continue
+		}
+		aead, err := newAEAD(master.key)
+		if err != nil {
+			return nil, err
+		}
+		rest := wrapped[4:]
+		if len(rest) < aead.NonceSize() {
+			return nil, fmt.Errorf("truncated wrapped key")
+		}
+		dataKey, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(keyID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		return dataKey, nil
+	}
+	return nil, fmt.Errorf("%w %q version %d", ErrUnknownKey, keyID, version)
+}
+
+// redact keeps a malformed key spec's secret out of error messages
+func redact(spec string) string {
+	if i := strings.Index(spec, ":"); i >= 0 {
+		return spec[:i] + ":..."
+	}
+	return "..."
+}
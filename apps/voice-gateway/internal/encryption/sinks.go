@@ -0,0 +1,138 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+// SealedContentType is stored for sealed objects in place of their own type
+const SealedContentType = "application/octet-stream"
+
+// Store seals objects before they reach the underlying store, with the key of
+// the firm named in the object key ("recordings/<firm>/<call>.wav"), and opens
+// them on read. Objects written before encryption was enabled read as they are
+type Store struct {
+	inner storage.Store
+	enc   *Encryptor
+}
+
+// NewStore wraps inner
+func NewStore(inner storage.Store, enc *Encryptor) *Store {
+	return &Store{inner: inner, enc: enc}
+}
+
+// Put seals the object and stores it; nothing is written if sealing fails
+func (s *Store) Put(ctx context.Context, key string, data io.Reader, contentType string) (string, error) {
+	plaintext, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	sealed, err := s.enc.Seal(ctx, storage.FirmFromKey(key), plaintext)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt %s: %w", key, err)
+	}
+	return s.inner.Put(ctx, key, bytes.NewReader(sealed), SealedContentType)
+}
+
+// Get opens the object, decrypting it if it was sealed
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := s.inner.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if !IsSealed(data) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	plaintext, err := s.enc.Open(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", key, err)
+	}
+	return io.NopCloser(bytes.NewReader(plaintext)), nil
+}
+
+// Delete removes the object
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.inner.Delete(ctx, key)
+}
+
+// List lists the underlying objects
+func (s *Store) List(ctx context.Context, prefix string) ([]storage.Object, error) {
+	return s.inner.List(ctx, prefix)
+}
+
+// Rewrap re-seals a stored object under its firm's current key, after a key
+// rotation; plaintext objects are sealed for the first time
+func (s *Store) Rewrap(ctx context.Context, key string) error {
+	r, err := s.inner.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	firmID := storage.FirmFromKey(key)
+	if IsSealed(data) {
+		data, err = s.enc.Rewrap(ctx, firmID, data)
+	} else {
+		data, err = s.enc.Seal(ctx, firmID, data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %w", key, err)
+	}
+	_, err = s.inner.Put(ctx, key, bytes.NewReader(data), SealedContentType)
+	return err
+}
+
+// SealedData replaces an event's payload when events are encrypted; receivers
+// open Ciphertext (base64 in JSON) with the same KMS key
+type SealedData struct {
+	Encrypted  bool   `json:"encrypted"`
+	KeyID      string `json:"key_id"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Publisher seals each event's payload (call records, transcripts, voicemail)
+// with the event's firm key before delivering it; the envelope (type, IDs,
+// timestamp) stays readable for routing
+type Publisher struct {
+	next events.Publisher
+	enc  *Encryptor
+}
+
+// NewPublisher wraps next
+func NewPublisher(next events.Publisher, enc *Encryptor) *Publisher {
+	return &Publisher{next: next, enc: enc}
+}
+
+// Publish seals the payload and delivers the event; nothing is delivered if sealing fails
+func (p *Publisher) Publish(ctx context.Context, event *events.Event) error {
+	if event.Data == nil {
+		return p.next.Publish(ctx, event)
+	}
+	plaintext, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	sealed, err := p.enc.Seal(ctx, event.FirmID, plaintext)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s event: %w", event.Type, err)
+	}
+
+	out := *event
+	out.Data = SealedData{Encrypted: true, KeyID: p.enc.KeyID(event.FirmID), Ciphertext: sealed}
+	return p.next.Publish(ctx, &out)
+}
@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+func TestStore_SealsObjects(t *testing.T) {
+	local, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() failed: %v", err)
+	}
+	enc := newTestEncryptor(t, "gateway:1:"+testKey(1), "acme:1:"+testKey(2))
+	store := NewStore(local, enc)
+	ctx := context.Background()
+
+	if _, err := store.Put(ctx, "recordings/acme/CA1.wav", strings.NewReader("RIFF audio"), "audio/wav"); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	raw, _ := local.Get(ctx, "recordings/acme/CA1.wav")
+	data, _ := io.ReadAll(raw)
+	raw.Close()
+	if keyID, err := SealedKeyID(data); err != nil || keyID != "acme" {
+		t.Fatalf("Expected the object sealed with the firm's key, got %q, %v", keyID, err)
+	}
+
+	r, err := store.Get(ctx, "recordings/acme/CA1.wav")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	data, _ = io.ReadAll(r)
+	if string(data) != "RIFF audio" {
+		t.Errorf("Expected the decrypted object, got %q", data)
+	}
+
+	// Objects stored before encryption was enabled still read
+	local.Put(ctx, "audio/acme/greeting.wav", strings.NewReader("RIFF greeting"), "audio/wav")
+	r, err = store.Get(ctx, "audio/acme/greeting.wav")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	data, _ = io.ReadAll(r)
+	if string(data) != "RIFF greeting" {
+		t.Errorf("Expected the plaintext object as stored, got %q", data)
+	}
+
+	// Rewrapping seals them in place
+	if err := store.Rewrap(ctx, "audio/acme/greeting.wav"); err != nil {
+		t.Fatalf("Rewrap() failed: %v", err)
+	}
+	raw, _ = local.Get(ctx, "audio/acme/greeting.wav")
+	data, _ = io.ReadAll(raw)
+	raw.Close()
+	if !IsSealed(data) {
+		t.Error("Expected the object to be sealed after Rewrap")
+	}
+}
+
+// capturePublisher records the events it is given
+type capturePublisher struct {
+	events []*events.Event
+}
+
+func (c *capturePublisher) Publish(ctx context.Context, event *events.Event) error {
+	c.events = append(c.events, event)
+	return nil
+}
+
+func TestPublisher_SealsEventData(t *testing.T) {
+	enc := newTestEncryptor(t, "gateway:1:"+testKey(1), "acme:1:"+testKey(2))
+	sink := &capturePublisher{}
+	publisher := NewPublisher(sink, enc)
+
+	event := events.NewEvent(events.TypeCallCompleted, "CA1", "acme", map[string]string{"transcript": "my divorce"})
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	body, _ := json.Marshal(sink.events[0])
+	if bytes.Contains(body, []byte("divorce")) || !bytes.Contains(body, []byte(`"type":"call.completed"`)) {
+		t.Fatalf("Expected a sealed payload in a readable envelope, got %s", body)
+	}
+	var delivered struct {
+		Data SealedData `json:"data"`
+	}
+	json.Unmarshal(body, &delivered)
+	if !delivered.Data.Encrypted || delivered.Data.KeyID != "acme" {
+		t.Errorf("Expected the firm's key, got %+v", delivered.Data)
+	}
+	plaintext, err := enc.Open(context.Background(), delivered.Data.Ciphertext)
+	if err != nil || string(plaintext) != `{"transcript":"my divorce"}` {
+		t.Errorf("Expected the receiver to open the payload, got %q, %v", plaintext, err)
+	}
+	if _, ok := event.Data.(map[string]string); !ok {
+		t.Error("Expected the caller's event to be left untouched")
+	}
+}
@@ -0,0 +1,24 @@
+package events
+
+import (
+	"context"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// MonitoredPublisher reports each delivery outcome to a health subsystem
+type MonitoredPublisher struct {
+	Publisher Publisher
+	Health    *observability.Subsystem
+}
+
+// Publish delivers the event and records whether it succeeded
+func (m MonitoredPublisher) Publish(ctx context.Context, event *Event) error {
+	err := m.Publisher.Publish(ctx, event)
+	if err != nil {
+		m.Health.Failure(err)
+		return err
+	}
+	m.Health.Success()
+	return nil
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lexiqai/voice-gateway/internal/buildinfo"
+	"github.com/rs/zerolog"
+)
+
+// Event types published by the gateway
+const (
+	TypeVoicemailReceived = "voicemail.received"
+	TypeCallCompleted     = "call.completed"
+	TypeCallEscalated     = "call.escalated"
+	TypeCallAbandoned     = "call.abandoned"
+	TypeBudgetAlert       = "budget.alert"
+	TypeSLOAlert          = "slo.alert"
+	TypeCampaignCompleted = "campaign.completed"
+	TypeCallbackScheduled = "callback.scheduled"
+	TypeCallbackRetrying  = "callback.retrying"
+	TypeCallbackCompleted = "callback.completed"
+	TypeCallbackFailed    = "callback.failed"
+	TypeCallbackCanceled  = "callback.canceled"
+	TypeDataErased        = "data.erased"
+	TypeAuditEntry        = "audit.entry"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body when a secret is configured
+const SignatureHeader = "X-LexiqAI-Signature"
+
+// Event is an envelope for gateway events delivered to external sinks
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Timestamp string      `json:"timestamp"`
+	CallSid   string      `json:"call_sid,omitempty"`
+	FirmID    string      `json:"firm_id,omitempty"`
+	Version   string      `json:"gateway_version,omitempty"` // Release that sent the event
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// NewEvent creates an event envelope with a fresh ID and timestamp
+func NewEvent(eventType, callSid, firmID string, data interface{}) *Event {
+	return &Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		CallSid:   callSid,
+		FirmID:    firmID,
+		Version:   buildinfo.Version,
+		Data:      data,
+	}
+}
+
+// Publisher delivers events to an external sink
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
+
+// LogPublisher writes events to the structured log (used when no webhook is configured)
+type LogPublisher struct {
+	logger zerolog.Logger
+}
+
+// NewLogPublisher creates a publisher that logs events
+func NewLogPublisher(logger zerolog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+// Publish logs the event
+func (p *LogPublisher) Publish(ctx context.Context, event *Event) error {
+	p.logger.Info().
+		Str("event_id", event.ID).
+		Str("event_type", event.Type).
+		Str("call_sid", event.CallSid).
+		Str("firm_id", event.FirmID).
+		Interface("data", event.Data).
+		Msg("Gateway event")
+	return nil
+}
+
+// WebhookPublisher POSTs events as JSON to an HTTP endpoint
+type WebhookPublisher struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewWebhookPublisher creates a webhook publisher
+// When secret is non-empty, each request is signed in the SignatureHeader
+func NewWebhookPublisher(url, secret string) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Publish delivers the event; non-2xx responses are returned as errors
+func (p *WebhookPublisher) Publish(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-LexiqAI-Event", event.Type)
+	if p.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(p.secret, body))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// MultiPublisher fans an event out to several publishers, returning the first error
+type MultiPublisher []Publisher
+
+// Publish delivers the event to every publisher
+func (m MultiPublisher) Publish(ctx context.Context, event *Event) error {
+	var firstErr error
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookPublisher_SignsBody(t *testing.T) {
+	var received Event
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		if r.Header.Get(SignatureHeader) != "sha256="+Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "secret")
+	event := NewEvent(TypeVoicemailReceived, "CA123", "firm-1", map[string]string{"transcript": "hello"})
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+	if signature == "" {
+		t.Error("Expected signature header")
+	}
+	if received.Type != TypeVoicemailReceived || received.CallSid != "CA123" {
+		t.Errorf("Unexpected event received: %+v", received)
+	}
+}
+
+func TestWebhookPublisher_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "")
+	if err := publisher.Publish(context.Background(), NewEvent("test", "", "", nil)); err == nil {
+		t.Error("Expected error for 500 response")
+	}
+}
@@ -0,0 +1,293 @@
+package firm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+)
+
+// ErrNoConfigFile is returned when changing settings of a registry without a
+// configuration file to store them in
+var ErrNoConfigFile = errors.New("no firm config file to store settings in")
+
+// fileFormat is the on-disk layout of the firm configuration file
+type fileFormat struct {
+	Defaults json.RawMessage            `json:"defaults,omitempty"`
+	Firms    map[string]json.RawMessage `json:"firms,omitempty"`
+}
+
+// Registry resolves per-firm settings from a JSON configuration file
+type Registry struct {
+	path     string
+	writeMu  sync.Mutex // Serializes changes to the configuration file
+	mu       sync.RWMutex
+	defaults json.RawMessage
+	firms    map[string]json.RawMessage
+	cache    map[string]*Settings
+}
+
+// NewRegistry creates an empty registry that resolves every firm to defaults
+func NewRegistry() *Registry {
+	return &Registry{
+		firms: make(map[string]json.RawMessage),
+		cache: make(map[string]*Settings),
+	}
+}
+
+// LoadRegistry reads firm settings from a JSON file
+// An empty path returns an empty registry
+func LoadRegistry(path string) (*Registry, error) {
+	r := NewRegistry()
+	r.path = path
+	if path == "" {
+		return r, nil
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the configuration file, validating every firm entry
+func (r *Registry) Reload() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read firm config %s: %w", r.path, err)
+	}
+
+	var file fileFormat
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse firm config %s: %w", r.path, err)
+	}
+	if file.Firms == nil {
+		file.Firms = make(map[string]json.RawMessage)
+	}
+
+	// Resolve every firm up front so a bad entry fails the load, not a live call
+	cache := make(map[string]*Settings, len(file.Firms))
+	for firmID, raw := range file.Firms {
+		settings, err := resolve(file.Defaults, raw)
+		if err != nil {
+			return fmt.Errorf("firm %s: %w", firmID, err)
+		}
+		cache[firmID] = settings
+	}
+	if _, err := resolve(file.Defaults, nil); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+
+	r.mu.Lock()
+	r.defaults = file.Defaults
+	r.firms = file.Firms
+	r.cache = cache
+	r.mu.Unlock()
+	return nil
+}
+
+// Get returns the resolved settings for a firm
+// Unknown or empty firm IDs resolve to the defaults
+// The returned value must not be modified
+func (r *Registry) Get(firmID string) *Settings {
+	r.mu.RLock()
+	if settings, ok := r.cache[firmID]; ok {
+		r.mu.RUnlock()
+		return settings
+	}
+	defaults := r.defaults
+	r.mu.RUnlock()
+
+	settings, err := resolve(defaults, nil)
+	if err != nil {
+		return DefaultSettings()
+	}
+	return settings
+}
+
+// Has returns whether the firm has an explicit entry
+func (r *Registry) Has(firmID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.firms[firmID]
+	return ok
+}
+
+// resolve layers built-in defaults, registry defaults, and a firm entry
+func resolve(defaults, firmRaw json.RawMessage) (*Settings, error) {
+	settings := DefaultSettings()
+	if len(defaults) > 0 {
+		if err := json.Unmarshal(defaults, settings); err != nil {
+			return nil, fmt.Errorf("invalid defaults: %w", err)
+		}
+	}
+	if len(firmRaw) > 0 {
+		if err := json.Unmarshal(firmRaw, settings); err != nil {
+			return nil, fmt.Errorf("invalid settings: %w", err)
+		}
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetVoice maps a language to a TTS voice in the firm's entry, creating the
+// entry if needed. The change is written to the configuration file, keeping
+// the rest of the file as is, and the file is reloaded
+func (r *Registry) SetVoice(firmID, language, voiceID string) error {
+	base := i18n.Base(language)
+	if firmID == "" || base == "" || voiceID == "" {
+		return fmt.Errorf("firm, language and voice are required")
+	}
+
+	return r.updateFirm(firmID, func(entry map[string]json.RawMessage) error {
+		var voices map[string]string
+		if err := unmarshalIfSet(entry["voices"], &voices); err != nil {
+			return fmt.Errorf("invalid voices: %w", err)
+		}
+		if voices == nil {
+			voices = make(map[string]string)
+		}
+		voices[base] = voiceID
+		entry["voices"], _ = json.Marshal(voices)
+		return nil
+	})
+}
+
+// SetDoNotCall adds number to the firm's do-not-call list, or removes it when
+// listed is false, saving the configuration file like SetVoice
+func (r *Registry) SetDoNotCall(firmID, number string, listed bool) error {
+	number = NormalizeNumber(number)
+	if firmID == "" || !e164Pattern.MatchString(number) {
+		return fmt.Errorf("firm and an E.164 number are required")
+	}
+
+	return r.updateFirm(firmID, func(entry map[string]json.RawMessage) error {
+		var outbound map[string]json.RawMessage
+		var list []string
+		if err := unmarshalIfSet(entry["outbound"], &outbound); err != nil {
+			return fmt.Errorf("invalid outbound: %w", err)
+		}
+		if err := unmarshalIfSet(outbound["do_not_call"], &list); err != nil {
+			return fmt.Errorf("invalid outbound do_not_call: %w", err)
+		}
+		if outbound == nil {
+			outbound = make(map[string]json.RawMessage)
+		}
+
+		kept := list[:0]
+		for _, existing := range list {
+			if NormalizeNumber(existing) != number {
+				kept = append(kept, existing)
+			}
+		}
+		if listed {
+			kept = append(kept, number)
+		}
+		sort.Strings(kept)
+		outbound["do_not_call"], _ = json.Marshal(kept)
+		entry["outbound"], _ = json.Marshal(outbound)
+		return nil
+	})
+}
+
+// updateFirm applies edit to the firm's raw entry (created if needed),
+// validates the result, and writes and reloads the configuration file
+func (r *Registry) updateFirm(firmID string, edit func(entry map[string]json.RawMessage) error) error {
+	if r.path == "" {
+		return ErrNoConfigFile
+	}
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read firm config %s: %w", r.path, err)
+	}
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse firm config %s: %w", r.path, err)
+	}
+	var firms map[string]json.RawMessage
+	var entry map[string]json.RawMessage
+	if err := unmarshalIfSet(file["firms"], &firms); err != nil {
+		return fmt.Errorf("invalid firms: %w", err)
+	}
+	if err := unmarshalIfSet(firms[firmID], &entry); err != nil {
+		return fmt.Errorf("firm %s: %w", firmID, err)
+	}
+
+	if file == nil {
+		file = make(map[string]json.RawMessage)
+	}
+	if firms == nil {
+		firms = make(map[string]json.RawMessage)
+	}
+	if entry == nil {
+		entry = make(map[string]json.RawMessage)
+	}
+	if err := edit(entry); err != nil {
+		return fmt.Errorf("firm %s: %w", firmID, err)
+	}
+	firms[firmID], _ = json.Marshal(entry)
+	if _, err := resolve(file["defaults"], firms[firmID]); err != nil {
+		return fmt.Errorf("firm %s: %w", firmID, err)
+	}
+	file["firms"], _ = json.Marshal(firms)
+
+	out, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode firm config: %w", err)
+	}
+	if err := writeFileAtomic(r.path, append(out, '\n')); err != nil {
+		return err
+	}
+	return r.Reload()
+}
+
+// unmarshalIfSet decodes raw into v unless raw is empty
+func unmarshalIfSet(raw json.RawMessage, v interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// writeFileAtomic replaces path with data, so a reload never sees a partial
+// file, keeping the file's permissions
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat firm config %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write firm config %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write firm config %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write firm config %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write firm config %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace firm config %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,545 @@
+package firm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestRegistry_Layering(t *testing.T) {
+	path := writeConfig(t, `{
+		"defaults": {"timezone": "America/New_York", "voicemail": {"max_duration_seconds": 120}},
+		"firms": {
+			"firm-1": {"voicemail": {"mode": "always"}}
+		}
+	}`)
+
+	registry, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+
+	settings := registry.Get("firm-1")
+	if settings.Voicemail.Mode != VoicemailAlways {
+		t.Errorf("Expected firm override mode 'always', got '%s'", settings.Voicemail.Mode)
+	}
+	if settings.Voicemail.MaxDurationSeconds != 120 {
+		t.Errorf("Expected registry default max duration 120, got %d", settings.Voicemail.MaxDurationSeconds)
+	}
+	if settings.Timezone != "America/New_York" {
+		t.Errorf("Expected registry default timezone, got '%s'", settings.Timezone)
+	}
+	if settings.Voicemail.Greeting == "" {
+		t.Error("Expected built-in default greeting")
+	}
+
+	unknown := registry.Get("firm-unknown")
+	if unknown.Voicemail.Mode != VoicemailNever {
+		t.Errorf("Expected default mode 'never' for unknown firm, got '%s'", unknown.Voicemail.Mode)
+	}
+	if !registry.Has("firm-1") || registry.Has("firm-unknown") {
+		t.Error("Has() returned unexpected result")
+	}
+}
+
+func TestRegistry_InvalidEntry(t *testing.T) {
+	path := writeConfig(t, `{"firms": {"firm-1": {"voicemail": {"mode": "sometimes"}}}}`)
+
+	if _, err := LoadRegistry(path); err == nil {
+		t.Error("Expected error for invalid voicemail mode")
+	}
+}
+
+func TestRegistry_EmptyPath(t *testing.T) {
+	registry, err := LoadRegistry("")
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+	if registry.Get("any").Voicemail.Mode != VoicemailNever {
+		t.Error("Expected built-in defaults")
+	}
+}
+
+func TestRegistry_SetVoice(t *testing.T) {
+	path := writeConfig(t, `{
+		"defaults": {"timezone": "America/New_York"},
+		"firms": {"firm-1": {"voicemail": {"mode": "always"}, "voices": {"es": "voice-es"}}}
+	}`)
+	registry, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+
+	if err := registry.SetVoice("firm-1", "en-US", "voice-clone"); err != nil {
+		t.Fatalf("SetVoice() failed: %v", err)
+	}
+	settings := registry.Get("firm-1")
+	if settings.Voices["en"] != "voice-clone" || settings.Voices["es"] != "voice-es" {
+		t.Errorf("Expected the cloned voice alongside the existing one, got %v", settings.Voices)
+	}
+	if settings.Voicemail.Mode != VoicemailAlways || settings.Timezone != "America/New_York" {
+		t.Error("Expected the rest of the config to be kept")
+	}
+
+	// A new firm gets an entry, and the change survives a reload from disk
+	if err := registry.SetVoice("firm-2", "fr", "voice-fr"); err != nil {
+		t.Fatalf("SetVoice() failed: %v", err)
+	}
+	reloaded, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+	if !reloaded.Has("firm-2") || reloaded.Get("firm-2").Voices["fr"] != "voice-fr" {
+		t.Errorf("Expected firm-2's voice in the file, got %v", reloaded.Get("firm-2").Voices)
+	}
+
+	if err := NewRegistry().SetVoice("firm-1", "en", "voice"); !errors.Is(err, ErrNoConfigFile) {
+		t.Errorf("Expected ErrNoConfigFile without a config file, got %v", err)
+	}
+}
+
+func TestSettings_HolidayAt(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Timezone = "America/New_York"
+	settings.Holidays = []Holiday{{Date: "2024-12-25", Name: "Christmas"}}
+
+	// 2024-12-26 02:00 UTC is still Christmas evening in New York
+	holiday, ok := settings.HolidayAt(time.Date(2024, 12, 26, 2, 0, 0, 0, time.UTC))
+	if !ok || holiday.Name != "Christmas" {
+		t.Errorf("Expected Christmas in the firm's time zone, got %+v (ok=%v)", holiday, ok)
+	}
+
+	if _, ok := settings.HolidayAt(time.Date(2024, 12, 27, 12, 0, 0, 0, time.UTC)); ok {
+		t.Error("Expected no holiday on 2024-12-27")
+	}
+}
+
+func TestSettings_ValidateRouting(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Routing.ClosedAction = ActionTransfer
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for transfer without transfer_number")
+	}
+
+	settings.Routing.TransferNumber = "+15551234567"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid settings, got %v", err)
+	}
+
+	settings.Routing.OpenAction = "fax"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for unknown action")
+	}
+}
+
+func TestSettings_ValidateClarification(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Clarification.MinConfidence = 1.5
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for min_confidence above 1")
+	}
+
+	settings.Clarification.MinConfidence = 0
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected disabled clarification to be valid, got %v", err)
+	}
+}
+
+func TestSettings_ValidateEscalation(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Escalation.Actions = []string{EscalationTag, EscalationOfferTransfer}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for offer_transfer without a transfer number")
+	}
+
+	settings.Routing.TransferNumber = "+15551234567"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected routing transfer number to be used, got %v", err)
+	}
+
+	settings.Escalation.Actions = []string{"page_partner"}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for unknown escalation action")
+	}
+}
+
+func TestSettings_ValidateSTT(t *testing.T) {
+	settings := DefaultSettings()
+	settings.STT = STTSettings{Model: "nova-2", FallbackModel: "nova-2"}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a fallback model equal to the model")
+	}
+
+	settings.STT.FallbackModel = "base"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected a distinct fallback model to be valid, got %v", err)
+	}
+
+	settings.STT.Provider = "vosk"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected the vosk provider to be valid, got %v", err)
+	}
+	settings.STT.Provider = "whisper"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for an unknown provider")
+	}
+}
+
+func TestSettings_ValidateTTS(t *testing.T) {
+	settings := DefaultSettings()
+	settings.TTS.Provider = "piper"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected the piper provider to be valid, got %v", err)
+	}
+
+	settings.TTS.Provider = "coqui"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for an unknown provider")
+	}
+}
+
+func TestSettings_ValidatePronunciations(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Pronunciations = map[string]Pronunciation{"voir dire": {Say: "vwahr deer"}, "Nguyen": {IPA: "wɪn"}}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid pronunciations, got %v", err)
+	}
+
+	settings.Pronunciations["Nguyen"] = Pronunciation{Say: "win", IPA: "wɪn"}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a pronunciation with both say and ipa")
+	}
+}
+
+func TestSettings_ValidateSpeaking(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Speaking = SpeakingSettings{Speed: 0.8, QuestionPauseMs: 600, BargeInMs: 300}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid speaking settings, got %v", err)
+	}
+
+	settings.Speaking.Speed = 3
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a speed above 2")
+	}
+	settings.Speaking = SpeakingSettings{BargeInMs: -1}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a negative barge_in_ms")
+	}
+}
+
+func TestBusinessHours_EmptyIsAlwaysOpen(t *testing.T) {
+	var hours BusinessHours
+	if !hours.IsOpen(time.Now()) {
+		t.Error("Expected empty business hours to be always open")
+	}
+}
+
+func TestSettings_ValidateCompliance(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Compliance.DeclineAction = DeclineEndCall
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected end_call decline action to be valid, got %v", err)
+	}
+
+	settings.Compliance.DeclineAction = "transfer"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for unknown decline action")
+	}
+}
+
+func TestSettings_ValidateOverflow(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Routing.MaxConcurrentCalls = 5
+	settings.Routing.OverflowAction = ActionTransfer
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for transfer overflow without transfer_number")
+	}
+
+	settings.Routing.OverflowAction = ActionAI
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for ai overflow action")
+	}
+}
+
+func TestCredential_KeptOutOfOutput(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Providers.Deepgram = Credential{APIKey: "dg-secret"}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "dg-secret") || strings.Contains(fmt.Sprintf("%+v %#v", settings, settings), "dg-secret") {
+		t.Error("Expected the API key to be masked")
+	}
+	if key, err := settings.Providers.Deepgram.Resolve(); err != nil || key != "dg-secret" {
+		t.Errorf("Expected the key to resolve, got %q, %v", key, err)
+	}
+
+	settings.Providers.Deepgram.APIKeyEnv = "DEEPGRAM_KEY"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a credential with two sources")
+	}
+}
+
+func TestSettings_ValidateBudget(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Budget.MonthlyCapUSD = -1
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for negative monthly_cap_usd")
+	}
+
+	settings.Budget.MonthlyCapUSD = 500
+	settings.Budget.Action = ActionTransfer
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for transfer budget action without transfer_number")
+	}
+
+	settings.Budget.Action = ActionAI
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for ai budget action")
+	}
+}
+
+func TestSettings_ValidateAllowedOrigins(t *testing.T) {
+	settings := DefaultSettings()
+	settings.AllowedOrigins = []string{"https://dashboard.acme-law.com", "https://*.acme-law.com"}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid allowed_origins, got %v", err)
+	}
+
+	settings.AllowedOrigins = []string{"acme-law.com/dashboard"}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for an allowed_origins entry without a scheme")
+	}
+}
+
+func TestSettings_ValidateTranslations(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Translations = map[string]map[i18n.Key]string{"es": {i18n.Disclaimer: "Esta llamada puede ser grabada."}}
+	settings.Voices = map[string]string{"es-MX": "voice-es"}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected valid translations, got %v", err)
+	}
+
+	settings.Translations = map[string]map[i18n.Key]string{"es": {"disclamer": "typo"}}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for an unknown translation key")
+	}
+}
+
+func TestSettings_CheckOutbound(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Timezone = "America/New_York"
+	settings.Outbound.DoNotCall = []string{"+1 555-123-4567"}
+	settings.Outbound.Timezones = map[string]string{"+1": "America/Chicago", "+1808": "Pacific/Honolulu"}
+
+	// 2024-03-12 14:00 UTC: 09:00 in Chicago, 04:00 in Honolulu
+	at := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	if err := settings.CheckOutbound("+15551234567", at); !errors.Is(err, ErrDoNotCall) {
+		t.Errorf("Expected ErrDoNotCall, got %v", err)
+	}
+	if err := settings.CheckOutbound("+15557654321", at); err != nil {
+		t.Errorf("Expected a call at 09:00 local to be allowed, got %v", err)
+	}
+	if err := settings.CheckOutbound("+18085550123", at); !errors.Is(err, ErrOutsideCallingHours) {
+		t.Errorf("Expected the longest prefix (Honolulu) to apply, got %v", err)
+	}
+
+	// Unmatched numbers are refused rather than assumed to share the firm's time zone
+	if err := settings.CheckOutbound("+445550123", at); !errors.Is(err, ErrUnknownTimezone) {
+		t.Errorf("Expected an unmatched number to be refused, got %v", err)
+	}
+
+	// ...unless a default applies: 02:30 in New York
+	settings.Outbound.DefaultTimezone = "America/New_York"
+	if err := settings.CheckOutbound("+445550123", time.Date(2024, 3, 12, 6, 30, 0, 0, time.UTC)); !errors.Is(err, ErrOutsideCallingHours) {
+		t.Errorf("Expected the default time zone for unmatched numbers, got %v", err)
+	}
+}
+
+func TestSettings_ValidateOutbound(t *testing.T) {
+	tests := []OutboundSettings{
+		{DoNotCall: []string{"555-1234"}},
+		{CallingHours: BusinessHours{"funday": {{Open: "08:00", Close: "21:00"}}}},
+		{CallingHours: BusinessHours{"monday": {{Open: "8am", Close: "21:00"}}}},
+		{Timezones: map[string]string{"+1": "Mars/Olympus"}},
+	}
+	for _, outbound := range tests {
+		settings := DefaultSettings()
+		settings.Outbound = outbound
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", outbound)
+		}
+	}
+}
+
+func TestSettings_ValidateTransfer(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Routing.ClosedAction = ActionTransfer
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a transfer action with nowhere to transfer")
+	}
+	settings.Transfer.Targets = []TransferTarget{{Number: "+15550000001"}, {Number: "+15550000002", TimeoutSeconds: 30}}
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Expected a hunt group to satisfy the transfer action, got %v", err)
+	}
+	if got := settings.Transfer.Targets[0].Timeout(); got != 20*time.Second {
+		t.Errorf("Expected the default 20s target timeout, got %v", got)
+	}
+
+	tests := []TransferSettings{
+		{Targets: []TransferTarget{{Number: "front desk"}}},
+		{Targets: []TransferTarget{{Number: "+15550000001", TimeoutSeconds: 2}}},
+		{Ring: "round_robin"},
+		{Fallback: "hangup"},
+	}
+	for _, transfer := range tests {
+		settings := DefaultSettings()
+		settings.Transfer = transfer
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", transfer)
+		}
+	}
+}
+
+func TestSettings_IsTransferNumber(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Routing.TransferNumber = "+15550000001"
+	settings.Escalation.TransferNumber = "+15550000002"
+	settings.Transfer.Targets = []TransferTarget{{Number: "+15550000003"}}
+
+	for _, number := range []string{"+15550000001", "+1 (555) 000-0002", "+15550000003"} {
+		if !settings.IsTransferNumber(number) {
+			t.Errorf("Expected %s to be a transfer number", number)
+		}
+	}
+	for _, number := range []string{"+19005550199", ""} {
+		if settings.IsTransferNumber(number) {
+			t.Errorf("Expected %q not to be a transfer number", number)
+		}
+	}
+}
+
+func TestSettings_ValidateVerification(t *testing.T) {
+	digest := HashPIN("test-verification-pin-secret-0123456789", "1234")
+	if digest == HashPIN("another-verification-pin-secret-012345", "1234") {
+		t.Error("Expected PIN digests to depend on the secret")
+	}
+	settings := DefaultSettings()
+	settings.Verification.PINs = map[string]string{"contact-1": digest, "+15551234567": digest}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Expected valid verification settings, got %v", err)
+	}
+	if got := settings.Verification.PINDigest("contact-1", ""); got != digest {
+		t.Errorf("Expected the contact's PIN, got %q", got)
+	}
+	if got := settings.Verification.PINDigest("contact-2", "+1 (555) 123-4567"); got != digest {
+		t.Errorf("Expected the caller number's PIN, got %q", got)
+	}
+	if got := settings.Verification.PINDigest("", "+15550000000"); got != "" {
+		t.Errorf("Expected no PIN for an unknown caller, got %q", got)
+	}
+
+	tests := []VerificationSettings{
+		{PINs: map[string]string{"contact-1": "1234"}},
+		{CodeLength: 3},
+		{CodeLength: 12},
+		{MaxAttempts: -1},
+		{CodeMessage: "Your code is ready."},
+	}
+	for _, verification := range tests {
+		settings := DefaultSettings()
+		settings.Verification = verification
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Expected error for %+v", verification)
+		}
+	}
+}
+
+func TestSettings_Retention(t *testing.T) {
+	retention := RetentionSettings{RecordingDays: 30, TranscriptDays: 90}
+	from := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if got := retention.Period(DataRecordings); got != 30*24*time.Hour {
+		t.Errorf("Expected a 30-day recording period, got %v", got)
+	}
+	if until := retention.Until(DataTranscripts, from); until == nil || !until.Equal(from.AddDate(0, 0, 90)) {
+		t.Errorf("Expected transcripts deleted after 90 days, got %v", until)
+	}
+	if until := retention.Until(DataVoicemail, from); until != nil {
+		t.Errorf("Expected voicemail kept indefinitely, got %v", until)
+	}
+
+	settings := DefaultSettings()
+	settings.Retention = RetentionSettings{VoicemailDays: -1}
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a negative retention period")
+	}
+}
+
+func TestSettings_ValidateRegions(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Regions = RegionSettings{STT: "eu", TTS: "eu-west", Storage: "eu"}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Expected valid regions, got %v", err)
+	}
+	settings.Regions.Storage = "EU/1"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for an invalid region name")
+	}
+}
+
+func TestSettings_ValidateTranslation(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Translation = TranslationSettings{Enabled: true, Language: "es-MX"}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Expected a valid translation language, got %v", err)
+	}
+	settings.Translation.Language = "multi"
+	if err := settings.Validate(); err == nil {
+		t.Error("Expected error for a firm language that is not one language")
+	}
+}
+
+func TestRegistry_SetDoNotCall(t *testing.T) {
+	path := writeConfig(t, `{"firms": {"firm-1": {"outbound": {"do_not_call": ["+15550000001"], "default_timezone": "America/Denver"}}}}`)
+	registry, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+
+	if err := registry.SetDoNotCall("firm-1", "+1 (555) 000-0002", true); err != nil {
+		t.Fatalf("SetDoNotCall() failed: %v", err)
+	}
+	outbound := registry.Get("firm-1").Outbound
+	if strings.Join(outbound.DoNotCall, ",") != "+15550000001,+15550000002" || outbound.DefaultTimezone != "America/Denver" {
+		t.Errorf("Expected the number added alongside existing settings, got %+v", outbound)
+	}
+
+	if err := registry.SetDoNotCall("firm-1", "+15550000001", false); err != nil {
+		t.Fatalf("SetDoNotCall() failed: %v", err)
+	}
+	if got := registry.Get("firm-1").Outbound.DoNotCall; len(got) != 1 || got[0] != "+15550000002" {
+		t.Errorf("Expected the number removed, got %v", got)
+	}
+	if err := registry.SetDoNotCall("firm-1", "12", true); err == nil {
+		t.Error("Expected error for a malformed number")
+	}
+}
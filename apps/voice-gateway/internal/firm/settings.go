@@ -0,0 +1,1355 @@
+package firm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+)
+
+// Settings holds per-firm behaviour overrides for the voice gateway
+// Values are layered: built-in defaults, then the registry's "defaults" entry,
+// then the firm's own entry (only fields present in JSON override)
+type Settings struct {
+	// Timezone used for schedules (IANA name, e.g. "America/New_York")
+	Timezone string `json:"timezone,omitempty"`
+
+	// BusinessHours defines when the firm is open; empty means always open
+	BusinessHours BusinessHours `json:"business_hours,omitempty"`
+
+	// Holidays are dates (in the firm's time zone) treated as closed
+	Holidays []Holiday `json:"holidays,omitempty"`
+
+	// Routing decides how inbound calls are handled
+	Routing RoutingSettings `json:"routing,omitempty"`
+
+	// Voicemail configures the voicemail-taking mode
+	Voicemail VoicemailSettings `json:"voicemail,omitempty"`
+
+	// Screening configures spam/robocall screening before the pipeline starts
+	Screening ScreeningSettings `json:"screening,omitempty"`
+
+	// Contacts configures caller ID lookup against the firm's CRM
+	Contacts ContactSettings `json:"contacts,omitempty"`
+
+	// SMS configures follow-up text messages
+	SMS SMSSettings `json:"sms,omitempty"`
+
+	// Clarification asks the caller to repeat low-confidence transcripts
+	Clarification ClarificationSettings `json:"clarification,omitempty"`
+
+	// Escalation reacts to callers asking for a human, swearing, or growing frustrated
+	Escalation EscalationSettings `json:"escalation,omitempty"`
+
+	// ToolProgress is spoken while the orchestrator runs a slow tool
+	ToolProgress ToolProgressSettings `json:"tool_progress,omitempty"`
+
+	// Confirmation configures the yes/no read-back of the confirm tool
+	Confirmation ConfirmationSettings `json:"confirmation,omitempty"`
+
+	// Abandonment configures the call.abandoned follow-up event
+	Abandonment AbandonmentSettings `json:"abandonment,omitempty"`
+
+	// Compliance announces recording and AI use at call start and collects consent
+	Compliance ComplianceSettings `json:"compliance,omitempty"`
+
+	// Providers carries the firm's own STT/TTS accounts (bring your own key)
+	Providers ProviderSettings `json:"providers,omitempty"`
+
+	// STT picks the speech recognition provider and Deepgram model (tier)
+	// for the firm's calls
+	STT STTSettings `json:"stt,omitempty"`
+
+	// TTS picks the speech synthesis provider for the firm's calls
+	TTS TTSSettings `json:"tts,omitempty"`
+
+	// Budget caps the firm's monthly provider spend
+	Budget BudgetSettings `json:"budget,omitempty"`
+
+	// AllowedOrigins are browser origins (e.g. the firm's own dashboard) that
+	// may call endpoints about the firm's calls, beyond CORS_ORIGINS
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+
+	// Translations overrides the gateway's translations of its own speech, by
+	// language then message (e.g. {"es": {"disclaimer": "..."}})
+	Translations map[string]map[i18n.Key]string `json:"translations,omitempty"`
+
+	// Voices maps languages to the firm's TTS voice IDs, over TTS_VOICES
+	Voices map[string]string `json:"voices,omitempty"`
+
+	// Pronunciations tell TTS how to say terms it gets wrong, by term (e.g.
+	// partner surnames, "voir dire"); firm entries add to the defaults
+	Pronunciations map[string]Pronunciation `json:"pronunciations,omitempty"`
+
+	// Speaking tunes the agent's conversational style: pace, pauses, and interruptions
+	Speaking SpeakingSettings `json:"speaking,omitempty"`
+
+	// Outbound restricts who and when the firm's campaigns may call
+	Outbound OutboundSettings `json:"outbound,omitempty"`
+
+	// Transfer configures how calls are handed to the firm's staff
+	Transfer TransferSettings `json:"transfer,omitempty"`
+
+	// Verification configures the verify_caller tool's PIN and text message checks
+	Verification VerificationSettings `json:"verification,omitempty"`
+
+	// Encryption selects the key the firm's recordings, transcripts and event
+	// payloads are sealed with when encryption at rest is enabled
+	Encryption EncryptionSettings `json:"encryption,omitempty"`
+
+	// Retention caps how long the firm's call data is kept
+	Retention RetentionSettings `json:"retention,omitempty"`
+
+	// Regions pins where the firm's audio is transcribed, synthesized and stored
+	Regions RegionSettings `json:"regions,omitempty"`
+
+	// Translation relays callers who speak another language through
+	// translation, so the assistant always works in the firm's language
+	Translation TranslationSettings `json:"translation,omitempty"`
+}
+
+// BusinessHours maps lowercase weekday names to open intervals
+type BusinessHours map[string][]TimeRange
+
+// TimeRange is an open interval in local "HH:MM" 24-hour time
+type TimeRange struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Holiday is a closed date in "YYYY-MM-DD" form
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// RoutingSettings maps the firm's schedule to call-handling actions
+// Actions are "ai", "voicemail", or "transfer"
+type RoutingSettings struct {
+	// OpenAction applies during business hours (default "ai")
+	OpenAction string `json:"open_action,omitempty"`
+
+	// ClosedAction applies outside business hours
+	// Defaults to "voicemail" when voicemail.mode is "after_hours", otherwise "ai"
+	ClosedAction string `json:"closed_action,omitempty"`
+
+	// HolidayAction applies on holidays (defaults to the closed action)
+	HolidayAction string `json:"holiday_action,omitempty"`
+
+	// TransferNumber is dialed for the "transfer" action (E.164)
+	TransferNumber string `json:"transfer_number,omitempty"`
+
+	// MaxConcurrentCalls caps the firm's simultaneous calls across all gateway instances (0 = unlimited)
+	MaxConcurrentCalls int `json:"max_concurrent_calls,omitempty"`
+
+	// OverflowAction handles calls beyond the limit: "voicemail" (default) or "transfer"
+	OverflowAction string `json:"overflow_action,omitempty"`
+}
+
+// Routing actions
+const (
+	ActionAI        = "ai"
+	ActionVoicemail = "voicemail"
+	ActionTransfer  = "transfer"
+)
+
+// VoicemailSettings configures when and how voicemail is taken
+type VoicemailSettings struct {
+	// Mode is "never" (default), "always", or "after_hours"
+	Mode string `json:"mode,omitempty"`
+
+	// Greeting is spoken before recording starts
+	Greeting string `json:"greeting,omitempty"`
+
+	// GreetingAudio is a pre-recorded greeting (URL or storage key) played instead of Greeting
+	GreetingAudio string `json:"greeting_audio,omitempty"`
+
+	// MaxDurationSeconds caps the message length
+	MaxDurationSeconds int `json:"max_duration_seconds,omitempty"`
+
+	// NotifyEmails receive the transcript and recording link
+	NotifyEmails []string `json:"notify_emails,omitempty"`
+
+	// WebhookURL receives the voicemail event in addition to the global event webhook
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// Voicemail modes
+const (
+	VoicemailNever      = "never"
+	VoicemailAlways     = "always"
+	VoicemailAfterHours = "after_hours"
+)
+
+// ScreeningSettings configures caller screening
+type ScreeningSettings struct {
+	// ChallengeMode is "off" (default), "suspicious", or "always"
+	ChallengeMode string `json:"challenge_mode,omitempty"`
+
+	// ChallengePrompt asks the caller to speak or press a key
+	ChallengePrompt string `json:"challenge_prompt,omitempty"`
+
+	// ChallengeTimeoutSeconds is how long the caller has to respond
+	ChallengeTimeoutSeconds int `json:"challenge_timeout_seconds,omitempty"`
+
+	// Blocklist holds numbers rejected for this firm, in addition to the global list
+	Blocklist []string `json:"blocklist,omitempty"`
+}
+
+// ContactSettings selects the CRM used to recognize returning clients
+type ContactSettings struct {
+	// Provider is "webhook", "clio", or "hubspot"; empty disables lookup
+	Provider string `json:"provider,omitempty"`
+
+	// URL is the webhook endpoint, or an API base URL override for Clio/HubSpot
+	URL string `json:"url,omitempty"`
+
+	// APIToken authenticates to Clio/HubSpot, or signs webhook requests
+	APIToken string `json:"api_token,omitempty"`
+}
+
+// ProviderSettings bills a firm's calls to its own provider accounts; an unset
+// credential uses the gateway's shared key
+type ProviderSettings struct {
+	Deepgram Credential `json:"deepgram,omitempty"`
+	Cartesia Credential `json:"cartesia,omitempty"`
+}
+
+// Credential locates a provider API key. Prefer api_key_file (e.g. a mounted
+// secret) or api_key_env over an inline api_key; the key is read at call start
+// so rotated secrets apply to the next call
+type Credential struct {
+	APIKey     Secret `json:"api_key,omitempty"`
+	APIKeyEnv  string `json:"api_key_env,omitempty"`
+	APIKeyFile string `json:"api_key_file,omitempty"`
+}
+
+// IsSet returns whether the credential names a key
+func (c Credential) IsSet() bool {
+	return c.APIKey != "" || c.APIKeyEnv != "" || c.APIKeyFile != ""
+}
+
+// Resolve reads the key from wherever the credential names it
+func (c Credential) Resolve() (string, error) {
+	var key string
+	switch {
+	case c.APIKeyFile != "":
+		data, err := os.ReadFile(c.APIKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read api_key_file: %w", err)
+		}
+		key = strings.TrimSpace(string(data))
+	case c.APIKeyEnv != "":
+		key = os.Getenv(c.APIKeyEnv)
+	default:
+		key = string(c.APIKey)
+	}
+	if key == "" {
+		return "", fmt.Errorf("credential resolved to an empty key")
+	}
+	return key, nil
+}
+
+func (c Credential) validate() error {
+	sources := 0
+	for _, set := range []bool{c.APIKey != "", c.APIKeyEnv != "", c.APIKeyFile != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("set only one of api_key, api_key_env and api_key_file")
+	}
+	return nil
+}
+
+// Secret is a string kept out of logs and JSON output
+type Secret string
+
+// String masks the secret
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "****"
+}
+
+// GoString masks the secret in %#v output
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// MarshalJSON masks the secret
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// SMSSettings configures follow-up texts sent from the firm's number
+type SMSSettings struct {
+	// FromNumber is the firm's Twilio number (E.164); defaults to the dialed number on calls
+	FromNumber string `json:"from_number,omitempty"`
+
+	// SenderName is available to templates as {{.sender}}
+	SenderName string `json:"sender_name,omitempty"`
+
+	// MaxPerHour limits messages sent for the firm (0 disables the limit)
+	MaxPerHour int `json:"max_per_hour,omitempty"`
+
+	// Templates are text/template message bodies by name; firm entries add to the defaults
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// ClarificationSettings configures the gateway's "could you repeat that?" prompt
+type ClarificationSettings struct {
+	// MinConfidence is the STT confidence (0-1) below which the caller is asked to repeat; 0 disables
+	MinConfidence float64 `json:"min_confidence,omitempty"`
+
+	// Prompt is spoken instead of sending the transcript to the orchestrator
+	Prompt string `json:"prompt,omitempty"`
+
+	// MaxConsecutive caps repeat requests in a row before the transcript is passed on anyway
+	MaxConsecutive int `json:"max_consecutive,omitempty"`
+}
+
+// EscalationSettings configures sentiment and escalation detection on caller transcripts
+type EscalationSettings struct {
+	// Actions run once per call when escalation is detected:
+	// "tag" (CDR), "webhook" (call.escalated event), "offer_transfer"; empty disables detection
+	Actions []string `json:"actions,omitempty"`
+
+	// Keywords are extra phrases treated as a request for a human
+	Keywords []string `json:"keywords,omitempty"`
+
+	// FrustrationTurns is how many negative turns in a row count as frustration (0 disables)
+	FrustrationTurns int `json:"frustration_turns,omitempty"`
+
+	// WebhookURL receives the call.escalated event in addition to the global event webhook
+	WebhookURL string `json:"webhook_url,omitempty"`
+
+	// TransferNumber is offered to the caller; defaults to routing.transfer_number
+	TransferNumber string `json:"transfer_number,omitempty"`
+
+	// OfferPrompt asks whether the caller wants to be transferred
+	OfferPrompt string `json:"offer_prompt,omitempty"`
+}
+
+// ToolProgressSettings configures the phrase that fills silence while the
+// orchestrator runs a tool (e.g. a calendar lookup)
+type ToolProgressSettings struct {
+	// DelayMs is how long a tool may run before the phrase is spoken; 0 disables it
+	// Tools whose result arrives sooner are never announced
+	DelayMs int `json:"delay_ms,omitempty"`
+
+	// Phrase is spoken for tools without their own phrase
+	Phrase string `json:"phrase,omitempty"`
+
+	// Phrases are per-tool phrases by tool name; firm entries add to the defaults
+	Phrases map[string]string `json:"phrases,omitempty"`
+}
+
+// ConfirmationSettings configures the confirm tool, which reads details back
+// to the caller (e.g. a booking) and collects yes/no without an LLM round trip
+type ConfirmationSettings struct {
+	// Prompt reads the details back; {details} is replaced by the tool's details
+	Prompt string `json:"prompt,omitempty"`
+
+	// RepeatPrompt asks again after an unclear reply or silence
+	RepeatPrompt string `json:"repeat_prompt,omitempty"`
+
+	// TimeoutSeconds is how long the caller has to answer once the prompt has played
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+
+	// MaxAttempts caps how many times the caller is asked
+	MaxAttempts int `json:"max_attempts,omitempty"`
+}
+
+// STTSettings picks the recognition provider and Deepgram model for the
+// firm, trading accuracy for cost, and how its transcripts are written
+type STTSettings struct {
+	// Provider replaces STT_PROVIDER: "vosk" keeps the firm's audio on the
+	// gateway's self-hosted Vosk server, "deepgram" sends it to Deepgram
+	Provider string `json:"provider,omitempty"`
+
+	// Model replaces DEEPGRAM_MODEL (e.g. "nova-2", or "base" to save cost)
+	Model string `json:"model,omitempty"`
+
+	// FallbackModel is used for the rest of a call once Model errors or is rate
+	// limited; replaces DEEPGRAM_FALLBACK_MODEL
+	FallbackModel string `json:"fallback_model,omitempty"`
+
+	// Formatting replaces the gateway's STT_* formatting options when set
+	Formatting *STTFormatting `json:"formatting,omitempty"`
+}
+
+// STTFormatting is how the firm's transcripts are written. Options left out
+// are off
+type STTFormatting struct {
+	// Punctuate adds punctuation and capitalization
+	Punctuate bool `json:"punctuate,omitempty"`
+
+	// SmartFormat punctuates and writes numbers, dates and amounts as digits
+	SmartFormat bool `json:"smart_format,omitempty"`
+
+	// Numerals writes numbers as digits
+	Numerals bool `json:"numerals,omitempty"`
+
+	// ProfanityFilter masks profanity
+	ProfanityFilter bool `json:"profanity_filter,omitempty"`
+
+	// FillerWords keeps "uh" and "um" in the transcript
+	FillerWords bool `json:"filler_words,omitempty"`
+}
+
+// TTSSettings picks where the firm's speech is synthesized
+type TTSSettings struct {
+	// Provider replaces TTS_PROVIDER: "piper" keeps the firm's text on the
+	// gateway's self-hosted Piper server, "cartesia" sends it to Cartesia.
+	// The firm's voices must then be Piper voice names
+	Provider string `json:"provider,omitempty"`
+}
+
+// Pronunciation respells a term for TTS, or gives its IPA (spoken through an
+// SSML <phoneme> tag); set one of the two
+type Pronunciation struct {
+	// Say replaces the term with a phonetic spelling (e.g. "vwahr deer")
+	Say string `json:"say,omitempty"`
+
+	// IPA is the term's pronunciation in the International Phonetic Alphabet
+	IPA string `json:"ipa,omitempty"`
+}
+
+// SpeakingSettings tunes how the agent talks: how fast, how long it waits
+// after asking a question, and how readily the caller can talk over it
+type SpeakingSettings struct {
+	// Speed is the TTS speaking rate, from 0.5 (slow) to 2 (fast); 1 is the voice's natural pace
+	Speed float64 `json:"speed,omitempty"`
+
+	// QuestionPauseMs is a pause after each question in a response, before the
+	// agent goes on; 0 disables it
+	QuestionPauseMs int `json:"question_pause_ms,omitempty"`
+
+	// BargeInMs is how long the caller must keep talking before the agent stops
+	// speaking; 0 stops it as soon as the caller is heard
+	BargeInMs int `json:"barge_in_ms,omitempty"`
+}
+
+// AbandonmentSettings configures the call.abandoned event, published when a
+// caller hangs up mid-conversation so the firm can follow up
+type AbandonmentSettings struct {
+	// WebhookURL receives the call.abandoned event in addition to the global event webhook
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// Escalation actions
+const (
+	EscalationTag           = "tag"
+	EscalationWebhook       = "webhook"
+	EscalationOfferTransfer = "offer_transfer"
+)
+
+// ComplianceSettings configures the call-start legal disclaimer and recording consent
+type ComplianceSettings struct {
+	// Disclaimer is spoken before anything else (e.g. "this call may be recorded"); empty disables it
+	Disclaimer string `json:"disclaimer,omitempty"`
+
+	// DisclaimerAudio is a pre-recorded disclaimer (URL or storage key) played instead of Disclaimer
+	DisclaimerAudio string `json:"disclaimer_audio,omitempty"`
+
+	// RequireConsent asks the caller to agree to recording; the call is recorded only on yes
+	RequireConsent bool `json:"require_consent,omitempty"`
+
+	// ConsentPrompt asks for consent by voice or keypad (1 agrees, 2 declines)
+	ConsentPrompt string `json:"consent_prompt,omitempty"`
+
+	// ConsentTimeoutSeconds is how long the caller has to answer; silence counts as no
+	ConsentTimeoutSeconds int `json:"consent_timeout_seconds,omitempty"`
+
+	// DeclineAction is "continue" (unrecorded, default) or "end_call"
+	DeclineAction string `json:"decline_action,omitempty"`
+
+	// DeclineMessage is spoken when the caller does not consent
+	DeclineMessage string `json:"decline_message,omitempty"`
+
+	// AIDisclosure keeps reminding the caller they are speaking with an AI,
+	// for jurisdictions that require more than the call-start disclaimer
+	AIDisclosure AIDisclosureSettings `json:"ai_disclosure,omitempty"`
+}
+
+// AIDisclosureSettings configures the periodic AI disclosure: a quiet tone
+// mixed into the agent's audio, or an announcement spoken in a pause
+type AIDisclosureSettings struct {
+	// Mode is "off" (default), "tone", or "announcement"
+	Mode string `json:"mode,omitempty"`
+
+	// IntervalSeconds separates disclosures: of agent audio for tones, of call time for announcements
+	IntervalSeconds int `json:"interval_seconds,omitempty"`
+
+	// ToneHz, ToneMs and ToneLevel (a fraction of full scale) shape the watermark tone
+	ToneHz    int     `json:"tone_hz,omitempty"`
+	ToneMs    int     `json:"tone_ms,omitempty"`
+	ToneLevel float64 `json:"tone_level,omitempty"`
+
+	// Announcement is spoken once the line is quiet after each interval
+	Announcement string `json:"announcement,omitempty"`
+
+	// AnnouncementAudio is a pre-recorded announcement (URL or storage key) played instead of Announcement
+	AnnouncementAudio string `json:"announcement_audio,omitempty"`
+}
+
+// AI disclosure modes
+const (
+	DisclosureOff          = "off"
+	DisclosureTone         = "tone"
+	DisclosureAnnouncement = "announcement"
+)
+
+// Consent decline actions
+const (
+	DeclineContinue = "continue"
+	DeclineEndCall  = "end_call"
+)
+
+// OutboundSettings are the do-not-call and calling-hour (TCPA) rules every
+// outbound call is checked against before it is placed
+type OutboundSettings struct {
+	// DoNotCall lists numbers (E.164) that must never be called
+	DoNotCall []string `json:"do_not_call,omitempty"`
+
+	// CallingHours are when calls may be placed, in the called party's local
+	// time; defaults to 08:00-21:00 every day. A day without ranges is closed
+	CallingHours BusinessHours `json:"calling_hours,omitempty"`
+
+	// Timezones maps number prefixes (e.g. "+1212") to the called party's IANA
+	// time zone; the longest matching prefix wins
+	Timezones map[string]string `json:"timezones,omitempty"`
+
+	// DefaultTimezone applies to numbers no prefix matches. Without it those
+	// numbers' local time is unknown and they are never called
+	DefaultTimezone string `json:"default_timezone,omitempty"`
+}
+
+// Outbound call refusals
+var (
+	ErrDoNotCall           = errors.New("number is on the firm's do-not-call list")
+	ErrOutsideCallingHours = errors.New("outside calling hours in the called party's time zone")
+	ErrUnknownTimezone     = errors.New("the called party's time zone is unknown; set outbound.timezones or outbound.default_timezone")
+)
+
+// e164Pattern matches dialable E.164 numbers
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// NormalizeNumber strips formatting (spaces, dashes, dots, parentheses) from a phone number
+func NormalizeNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '+' || (r >= '0' && r <= '9') {
+			return r
+		}
+		return -1
+	}, number)
+}
+
+// ValidNumber reports whether number is a dialable E.164 number
+func ValidNumber(number string) bool {
+	return e164Pattern.MatchString(number)
+}
+
+// CheckOutbound returns why number may not be called at t, or nil when the
+// call is allowed
+func (s *Settings) CheckOutbound(number string, t time.Time) error {
+	number = NormalizeNumber(number)
+	for _, blocked := range s.Outbound.DoNotCall {
+		if NormalizeNumber(blocked) == number {
+			return ErrDoNotCall
+		}
+	}
+	loc, known := s.CalleeLocation(number)
+	if !known {
+		return ErrUnknownTimezone
+	}
+	if !s.Outbound.CallingHours.IsOpen(t.In(loc)) {
+		return ErrOutsideCallingHours
+	}
+	return nil
+}
+
+// CalleeLocation returns the time zone of the party at number: the longest
+// matching outbound timezones prefix, then the default. known is false when
+// neither applies or the zone cannot be loaded; loc is then the firm's own,
+// and CheckOutbound refuses the call rather than guess its local time
+func (s *Settings) CalleeLocation(number string) (loc *time.Location, known bool) {
+	zone, matched := s.Outbound.DefaultTimezone, 0
+	for prefix, name := range s.Outbound.Timezones {
+		if len(prefix) > matched && strings.HasPrefix(NormalizeNumber(number), prefix) {
+			zone, matched = name, len(prefix)
+		}
+	}
+	if zone == "" {
+		return s.Location(), false
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return s.Location(), false
+	}
+	return loc, true
+}
+
+// TransferSettings configures transfers to the firm's staff
+type TransferSettings struct {
+	// Whisper makes transfers warm: whoever answers first hears WhisperIntro and
+	// a summary of the call so far, then the caller is connected
+	Whisper bool `json:"whisper,omitempty"`
+
+	// WhisperIntro opens the whisper, before the summary
+	WhisperIntro string `json:"whisper_intro,omitempty"`
+
+	// Targets are a hunt group standing in for routing.transfer_number (e.g.
+	// attorney cell, paralegal, front desk): transfers to the firm ring them
+	// and, if nobody answers, reconnect the caller to Fallback
+	Targets []TransferTarget `json:"targets,omitempty"`
+
+	// Ring is "sequential" (default: each target in turn, for its own timeout)
+	// or "simultaneous" (all at once, for the longest timeout; the first to answer wins)
+	Ring string `json:"ring,omitempty"`
+
+	// Fallback is where callers nobody answered go: "ai" (default) or "voicemail"
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// TransferTarget is one number in a transfer hunt group
+type TransferTarget struct {
+	Number string `json:"number"`         // E.164
+	Name   string `json:"name,omitempty"` // e.g. "Front desk", for logs and call records
+
+	// TimeoutSeconds is how long the target rings (default 20)
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// Hunt group ring strategies
+const (
+	RingSequential   = "sequential"
+	RingSimultaneous = "simultaneous"
+)
+
+// defaultTargetTimeout is how long a transfer target rings unless configured
+const defaultTargetTimeout = 20 * time.Second
+
+// Timeout returns how long the target rings
+func (t TransferTarget) Timeout() time.Duration {
+	if t.TimeoutSeconds <= 0 {
+		return defaultTargetTimeout
+	}
+	return time.Duration(t.TimeoutSeconds) * time.Second
+}
+
+// CanTransfer reports whether the firm has a transfer_number or hunt group
+func (s *Settings) CanTransfer() bool {
+	return s.Routing.TransferNumber != "" || len(s.Transfer.Targets) > 0
+}
+
+// IsTransferNumber reports whether number is one the firm transfers calls
+// to: routing.transfer_number, escalation.transfer_number or a hunt group target
+func (s *Settings) IsTransferNumber(number string) bool {
+	number = NormalizeNumber(number)
+	if number == "" {
+		return false
+	}
+	configured := []string{s.Routing.TransferNumber, s.Escalation.TransferNumber}
+	for _, target := range s.Transfer.Targets {
+		configured = append(configured, target.Number)
+	}
+	for _, c := range configured {
+		if NormalizeNumber(c) == number {
+			return true
+		}
+	}
+	return false
+}
+
+// TransferTargets returns who a transfer to number rings: the hunt group
+// when number is empty or the firm's transfer_number, otherwise number alone
+func (s *Settings) TransferTargets(number string) []TransferTarget {
+	if number != "" && number != s.Routing.TransferNumber {
+		return []TransferTarget{{Number: number}}
+	}
+	if len(s.Transfer.Targets) > 0 {
+		return s.Transfer.Targets
+	}
+	if number != "" {
+		return []TransferTarget{{Number: number}}
+	}
+	return nil
+}
+
+// validate checks the hunt group's numbers, timeouts, strategy and fallback
+func (t TransferSettings) validate() error {
+	for _, target := range t.Targets {
+		if !ValidNumber(NormalizeNumber(target.Number)) {
+			return fmt.Errorf("invalid transfer target number %q: expected E.164", target.Number)
+		}
+		if target.TimeoutSeconds != 0 && (target.TimeoutSeconds < 5 || target.TimeoutSeconds > 120) {
+			return fmt.Errorf("invalid transfer target timeout_seconds %d for %s: must be between 5 and 120", target.TimeoutSeconds, target.Number)
+		}
+	}
+	switch t.Ring {
+	case "", RingSequential, RingSimultaneous:
+	default:
+		return fmt.Errorf("invalid transfer ring %q", t.Ring)
+	}
+	switch t.Fallback {
+	case "", ActionAI, ActionVoicemail:
+	default:
+		return fmt.Errorf("invalid transfer fallback %q", t.Fallback)
+	}
+	return nil
+}
+
+// VerificationSettings configures how the verify_caller tool confirms who a
+// caller is before the assistant discusses their matter: a PIN on file, or a
+// one-time code texted to the CRM contact's number on file
+type VerificationSettings struct {
+	// PINs maps CRM contact IDs or caller numbers (E.164) to the caller's PIN
+	// digest, as HashPIN computes it under the gateway's VERIFICATION_PIN_SECRET
+	PINs map[string]string `json:"pins,omitempty"`
+
+	// CodeLength is the number of digits in a texted code
+	CodeLength int `json:"code_length,omitempty"`
+
+	// CodeTTLSeconds is how long a texted code stays valid
+	CodeTTLSeconds int `json:"code_ttl_seconds,omitempty"`
+
+	// MaxAttempts caps wrong answers per contact or caller number, across
+	// calls, until the gateway's lockout window passes
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// TimeoutSeconds is how long the caller has to answer once a prompt has played
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+
+	// CodeMessage is the text message; {code} is replaced by the code
+	CodeMessage string `json:"code_message,omitempty"`
+
+	// CodePrompt asks for the texted code; {length} is replaced by its digit count
+	CodePrompt string `json:"code_prompt,omitempty"`
+
+	// PINPrompt asks for the caller's PIN
+	PINPrompt string `json:"pin_prompt,omitempty"`
+
+	// RetryPrompt asks again after a wrong answer
+	RetryPrompt string `json:"retry_prompt,omitempty"`
+}
+
+// pinDigestPattern matches an HMAC-SHA256 hex digest
+var pinDigestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// HashPIN returns the digest stored for a PIN: HMAC-SHA256 keyed by the
+// gateway's secret, so a leaked settings file cannot be brute-forced offline
+func HashPIN(secret, pin string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(pin))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PINDigest returns the digest of the PIN on file for a CRM contact or, failing that, a caller number
+func (v VerificationSettings) PINDigest(contactID, number string) string {
+	if digest, ok := v.PINs[contactID]; ok && contactID != "" {
+		return digest
+	}
+	return v.PINs[NormalizeNumber(number)]
+}
+
+// validate checks the PIN digests and code limits
+func (v VerificationSettings) validate() error {
+	for key, digest := range v.PINs {
+		if !pinDigestPattern.MatchString(digest) {
+			return fmt.Errorf("invalid verification pin for %q: expected a lowercase HMAC-SHA256 hex digest", key)
+		}
+	}
+	if v.CodeLength != 0 && (v.CodeLength < 4 || v.CodeLength > 10) {
+		return fmt.Errorf("invalid verification code_length %d: must be between 4 and 10", v.CodeLength)
+	}
+	if v.CodeTTLSeconds < 0 || v.MaxAttempts < 0 || v.TimeoutSeconds < 0 {
+		return fmt.Errorf("invalid verification settings: code_ttl_seconds, max_attempts and timeout_seconds cannot be negative")
+	}
+	if v.CodeMessage != "" && !strings.Contains(v.CodeMessage, "{code}") {
+		return fmt.Errorf("invalid verification code_message: must contain {code}")
+	}
+	return nil
+}
+
+// EncryptionSettings selects a firm's own data key
+type EncryptionSettings struct {
+	// KeyID is the KMS key (local keyring ID or AWS key ID, ARN or alias) that
+	// wraps the firm's data keys; empty uses the gateway's default key
+	KeyID string `json:"key_id,omitempty"`
+}
+
+// RetentionSettings caps how long a firm's call data is kept, in days; 0
+// keeps it indefinitely. The gateway deletes the recordings and voicemail it
+// stores; transcripts live in downstream sinks, which call records and
+// voicemail events tell when to delete them
+type RetentionSettings struct {
+	RecordingDays  int `json:"recording_days,omitempty"`
+	VoicemailDays  int `json:"voicemail_days,omitempty"`
+	TranscriptDays int `json:"transcript_days,omitempty"`
+}
+
+// Kinds of call data a retention period applies to; recordings and
+// voicemail are also their storage key prefixes
+const (
+	DataRecordings  = "recordings"
+	DataVoicemail   = "voicemail"
+	DataTranscripts = "transcripts"
+)
+
+// Period returns how long kind is kept; 0 keeps it indefinitely
+func (r RetentionSettings) Period(kind string) time.Duration {
+	days := 0
+	switch kind {
+	case DataRecordings:
+		days = r.RecordingDays
+	case DataVoicemail:
+		days = r.VoicemailDays
+	case DataTranscripts:
+		days = r.TranscriptDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// Until returns when kind created at from must be deleted, or nil to keep it
+func (r RetentionSettings) Until(kind string, from time.Time) *time.Time {
+	period := r.Period(kind)
+	if period == 0 {
+		return nil
+	}
+	until := from.Add(period).UTC()
+	return &until
+}
+
+// validate checks the retention periods
+func (r RetentionSettings) validate() error {
+	if r.RecordingDays < 0 || r.VoicemailDays < 0 || r.TranscriptDays < 0 {
+		return fmt.Errorf("invalid retention: recording_days, voicemail_days and transcript_days cannot be negative")
+	}
+	return nil
+}
+
+// RegionSettings names the data region (e.g. "eu") each provider must
+// process the firm's data in; empty allows the gateway's own DATA_REGION.
+// Calls are rejected when the gateway has no endpoint in a required region
+type RegionSettings struct {
+	STT     string `json:"stt,omitempty"`
+	TTS     string `json:"tts,omitempty"`
+	Storage string `json:"storage,omitempty"`
+}
+
+// TranslationSettings turns on translation between the caller's language,
+// as STT hears it or the stream asks for it, and the firm's. Caller speech is
+// translated before it reaches the assistant, and its replies before they are
+// spoken; the call transcript keeps both
+type TranslationSettings struct {
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Language is the language the assistant works in; empty is English
+	Language string `json:"language,omitempty"`
+}
+
+// validate checks the region names
+func (r RegionSettings) validate() error {
+	for name, region := range map[string]string{"stt": r.STT, "tts": r.TTS, "storage": r.Storage} {
+		if !validRegion(region) {
+			return fmt.Errorf("invalid regions %s %q: use lowercase letters, digits and dashes", name, region)
+		}
+	}
+	return nil
+}
+
+// validRegion accepts names like "eu", "us-east" and "ap2"
+func validRegion(region string) bool {
+	for _, c := range region {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// BudgetSettings caps a firm's monthly spend, priced at the gateway's usage rates
+// Once the cap is reached new calls skip the AI; calls in progress are not cut off
+type BudgetSettings struct {
+	// MonthlyCapUSD is the spend per calendar month (UTC) at which new calls are diverted; 0 disables the cap
+	MonthlyCapUSD float64 `json:"monthly_cap_usd,omitempty"`
+
+	// Action handles calls over the cap: "voicemail" (default) or "transfer" (to routing.transfer_number)
+	Action string `json:"action,omitempty"`
+
+	// Message is spoken before the caller is sent to voicemail or transferred
+	Message string `json:"message,omitempty"`
+
+	// AlertWebhookURL receives budget.alert events at 80% and 100% of the cap,
+	// in addition to the global event webhook
+	AlertWebhookURL string `json:"alert_webhook_url,omitempty"`
+}
+
+// DefaultSettings returns the built-in defaults applied beneath every firm
+func DefaultSettings() *Settings {
+	return &Settings{
+		Timezone: "UTC",
+		Routing: RoutingSettings{
+			OpenAction:     ActionAI,
+			OverflowAction: ActionVoicemail,
+		},
+		Voicemail: VoicemailSettings{
+			Mode:               VoicemailNever,
+			Greeting:           "Thank you for calling. No one is available to take your call right now. Please leave a message after the tone, and we will get back to you as soon as possible.",
+			MaxDurationSeconds: 180,
+		},
+		SMS: SMSSettings{
+			SenderName: "LexiqAI",
+			MaxPerHour: 30,
+			Templates: map[string]string{
+				"appointment_confirmation": "{{.sender}}: your appointment is confirmed for {{.when}}. Reply STOP to opt out.",
+				"intake_link":              "Thanks for calling {{.sender}}. Please complete your intake form: {{.link}}",
+			},
+		},
+		Screening: ScreeningSettings{
+			ChallengeMode:           "off",
+			ChallengePrompt:         "Thank you for calling. To be connected, please say your name or press any key.",
+			ChallengeTimeoutSeconds: 8,
+		},
+		Clarification: ClarificationSettings{
+			MinConfidence:  0.4,
+			Prompt:         "Sorry, I didn't quite catch that. Could you repeat that?",
+			MaxConsecutive: 2,
+		},
+		Escalation: EscalationSettings{
+			Actions:          []string{EscalationTag},
+			FrustrationTurns: 3,
+			OfferPrompt:      "I'm sorry for the trouble. Would you like me to connect you with someone at the firm?",
+		},
+		ToolProgress: ToolProgressSettings{
+			DelayMs: 1500,
+			Phrase:  "One moment while I look into that.",
+		},
+		Confirmation: ConfirmationSettings{
+			Prompt:         "To confirm: {details}. Is that correct? Say yes or press 1, or say no or press 2.",
+			RepeatPrompt:   "Sorry, is that correct? Say yes or press 1, or say no or press 2.",
+			TimeoutSeconds: 8,
+			MaxAttempts:    2,
+		},
+		Compliance: ComplianceSettings{
+			ConsentPrompt:         "This call may be recorded for quality and record-keeping purposes, and you are speaking with an AI assistant. Do you consent to this call being recorded? Say yes or press 1 to agree, or say no or press 2 to decline.",
+			ConsentTimeoutSeconds: 10,
+			DeclineAction:         DeclineContinue,
+			DeclineMessage:        "Understood. This call will not be recorded.",
+			AIDisclosure: AIDisclosureSettings{
+				Mode:            DisclosureOff,
+				IntervalSeconds: 120,
+				ToneHz:          2000,
+				ToneMs:          120,
+				ToneLevel:       0.03,
+				Announcement:    "As a reminder, you're speaking with an AI assistant.",
+			},
+		},
+		Speaking: SpeakingSettings{
+			Speed: 1,
+		},
+		Outbound: OutboundSettings{
+			CallingHours: everyDay(TimeRange{Open: "08:00", Close: "21:00"}),
+		},
+		Transfer: TransferSettings{
+			WhisperIntro: "Incoming transfer from the virtual receptionist.",
+			Ring:         RingSequential,
+			Fallback:     ActionAI,
+		},
+		Verification: VerificationSettings{
+			CodeLength:     6,
+			CodeTTLSeconds: 300,
+			MaxAttempts:    3,
+			TimeoutSeconds: 30,
+			CodeMessage:    "Your verification code is {code}.",
+			CodePrompt:     "I've just sent you a text message with a {length}-digit code. Please enter it on your keypad, or read it to me.",
+			PINPrompt:      "Please enter your PIN on your keypad followed by the pound key, or say it.",
+			RetryPrompt:    "Sorry, that didn't match. Please try again.",
+		},
+		Budget: BudgetSettings{
+			Action:  ActionVoicemail,
+			Message: "Thank you for calling. Our virtual assistant isn't available right now, but we'll make sure your call reaches the firm.",
+		},
+	}
+}
+
+// Location returns the firm's time zone, falling back to UTC
+func (s *Settings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsOpen returns whether the firm is within business hours at t
+func (s *Settings) IsOpen(t time.Time) bool {
+	return s.BusinessHours.IsOpen(t.In(s.Location()))
+}
+
+// HolidayAt returns the holiday covering t, if any
+func (s *Settings) HolidayAt(t time.Time) (Holiday, bool) {
+	date := t.In(s.Location()).Format("2006-01-02")
+	for _, h := range s.Holidays {
+		if h.Date == date {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// Validate checks the settings for malformed values
+func (s *Settings) Validate() error {
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+	}
+
+	for day, ranges := range s.BusinessHours {
+		if _, ok := weekdays[day]; !ok {
+			return fmt.Errorf("invalid business_hours day %q", day)
+		}
+		for _, r := range ranges {
+			if _, err := parseClock(r.Open); err != nil {
+				return fmt.Errorf("invalid open time for %s: %w", day, err)
+			}
+			if _, err := parseClock(r.Close); err != nil {
+				return fmt.Errorf("invalid close time for %s: %w", day, err)
+			}
+		}
+	}
+
+	for _, h := range s.Holidays {
+		if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+			return fmt.Errorf("invalid holiday date %q: expected YYYY-MM-DD", h.Date)
+		}
+	}
+
+	switch s.Voicemail.Mode {
+	case "", VoicemailNever, VoicemailAlways, VoicemailAfterHours:
+	default:
+		return fmt.Errorf("invalid voicemail mode %q", s.Voicemail.Mode)
+	}
+
+	switch s.STT.Provider {
+	case "", "deepgram", "vosk":
+	default:
+		return fmt.Errorf("invalid stt provider %q", s.STT.Provider)
+	}
+
+	switch s.TTS.Provider {
+	case "", "cartesia", "piper":
+	default:
+		return fmt.Errorf("invalid tts provider %q", s.TTS.Provider)
+	}
+
+	if s.STT.FallbackModel != "" && s.STT.FallbackModel == s.STT.Model {
+		return fmt.Errorf("stt fallback_model must differ from model %q", s.STT.Model)
+	}
+
+	switch s.Screening.ChallengeMode {
+	case "", "off", "suspicious", "always":
+	default:
+		return fmt.Errorf("invalid screening challenge_mode %q", s.Screening.ChallengeMode)
+	}
+
+	switch s.Contacts.Provider {
+	case "", "webhook", "clio", "hubspot":
+	default:
+		return fmt.Errorf("invalid contacts provider %q", s.Contacts.Provider)
+	}
+	if s.Contacts.Provider == "webhook" && s.Contacts.URL == "" {
+		return fmt.Errorf("contacts provider webhook requires a url")
+	}
+
+	if s.Clarification.MinConfidence < 0 || s.Clarification.MinConfidence > 1 {
+		return fmt.Errorf("invalid clarification min_confidence %v: must be between 0 and 1", s.Clarification.MinConfidence)
+	}
+	if s.Clarification.MaxConsecutive < 0 {
+		return fmt.Errorf("invalid clarification max_consecutive %d", s.Clarification.MaxConsecutive)
+	}
+
+	for _, action := range s.Escalation.Actions {
+		switch action {
+		case EscalationTag, EscalationWebhook:
+		case EscalationOfferTransfer:
+			if s.EscalationTransferNumber() == "" && len(s.Transfer.Targets) == 0 {
+				return fmt.Errorf("escalation action offer_transfer requires a transfer_number or transfer targets")
+			}
+		default:
+			return fmt.Errorf("invalid escalation action %q", action)
+		}
+	}
+
+	if s.ToolProgress.DelayMs < 0 {
+		return fmt.Errorf("invalid tool_progress delay_ms %d", s.ToolProgress.DelayMs)
+	}
+
+	if s.Confirmation.TimeoutSeconds <= 0 {
+		return fmt.Errorf("invalid confirmation timeout_seconds %d", s.Confirmation.TimeoutSeconds)
+	}
+	if s.Confirmation.MaxAttempts <= 0 {
+		return fmt.Errorf("invalid confirmation max_attempts %d", s.Confirmation.MaxAttempts)
+	}
+
+	switch s.Compliance.DeclineAction {
+	case "", DeclineContinue, DeclineEndCall:
+	default:
+		return fmt.Errorf("invalid compliance decline_action %q", s.Compliance.DeclineAction)
+	}
+	if s.Compliance.ConsentTimeoutSeconds < 0 || (s.Compliance.RequireConsent && s.Compliance.ConsentTimeoutSeconds == 0) {
+		return fmt.Errorf("invalid compliance consent_timeout_seconds %d", s.Compliance.ConsentTimeoutSeconds)
+	}
+
+	if err := s.Compliance.AIDisclosure.validate(); err != nil {
+		return fmt.Errorf("invalid compliance ai_disclosure: %w", err)
+	}
+
+	if err := s.Providers.Deepgram.validate(); err != nil {
+		return fmt.Errorf("invalid providers deepgram: %w", err)
+	}
+	if err := s.Providers.Cartesia.validate(); err != nil {
+		return fmt.Errorf("invalid providers cartesia: %w", err)
+	}
+
+	if s.Budget.MonthlyCapUSD < 0 {
+		return fmt.Errorf("invalid budget monthly_cap_usd %v", s.Budget.MonthlyCapUSD)
+	}
+	switch s.Budget.Action {
+	case "", ActionVoicemail:
+	case ActionTransfer:
+		if !s.CanTransfer() {
+			return fmt.Errorf("budget action is transfer but there is no routing transfer_number or transfer targets")
+		}
+	default:
+		return fmt.Errorf("invalid budget action %q", s.Budget.Action)
+	}
+
+	if err := cors.ValidateOrigins(s.AllowedOrigins); err != nil {
+		return fmt.Errorf("invalid allowed_origins: %w", err)
+	}
+
+	if err := i18n.Validate(s.Translations); err != nil {
+		return fmt.Errorf("invalid translations: %w", err)
+	}
+	for language := range s.Voices {
+		if i18n.Base(language) == "" {
+			return fmt.Errorf("invalid voices language %q", language)
+		}
+	}
+
+	for term, pronunciation := range s.Pronunciations {
+		if strings.TrimSpace(term) == "" {
+			return fmt.Errorf("invalid pronunciations: empty term")
+		}
+		if (pronunciation.Say == "") == (pronunciation.IPA == "") {
+			return fmt.Errorf("invalid pronunciation for %q: set one of say and ipa", term)
+		}
+	}
+
+	if s.Speaking.Speed != 0 && (s.Speaking.Speed < 0.5 || s.Speaking.Speed > 2) {
+		return fmt.Errorf("invalid speaking speed %v: must be between 0.5 and 2", s.Speaking.Speed)
+	}
+	if s.Speaking.QuestionPauseMs < 0 {
+		return fmt.Errorf("invalid speaking question_pause_ms %d", s.Speaking.QuestionPauseMs)
+	}
+	if s.Speaking.BargeInMs < 0 {
+		return fmt.Errorf("invalid speaking barge_in_ms %d", s.Speaking.BargeInMs)
+	}
+
+	if err := s.Outbound.validate(); err != nil {
+		return err
+	}
+	if err := s.Transfer.validate(); err != nil {
+		return err
+	}
+	if err := s.Verification.validate(); err != nil {
+		return err
+	}
+	if err := s.Retention.validate(); err != nil {
+		return err
+	}
+	if s.Translation.Language != "" && i18n.Base(s.Translation.Language) == "" {
+		return fmt.Errorf("invalid translation language %q", s.Translation.Language)
+	}
+	if err := s.Regions.validate(); err != nil {
+		return err
+	}
+
+	if s.Routing.MaxConcurrentCalls < 0 {
+		return fmt.Errorf("invalid routing max_concurrent_calls %d", s.Routing.MaxConcurrentCalls)
+	}
+	switch s.Routing.OverflowAction {
+	case "", ActionVoicemail:
+	case ActionTransfer:
+		if !s.CanTransfer() {
+			return fmt.Errorf("routing overflow_action is transfer but there is no transfer_number or transfer targets")
+		}
+	default:
+		return fmt.Errorf("invalid routing overflow_action %q", s.Routing.OverflowAction)
+	}
+
+	for name, action := range map[string]string{
+		"open_action":    s.Routing.OpenAction,
+		"closed_action":  s.Routing.ClosedAction,
+		"holiday_action": s.Routing.HolidayAction,
+	} {
+		if !ValidAction(action) && action != "" {
+			return fmt.Errorf("invalid routing %s %q", name, action)
+		}
+		if action == ActionTransfer && !s.CanTransfer() {
+			return fmt.Errorf("routing %s is transfer but there is no transfer_number or transfer targets", name)
+		}
+	}
+
+	return nil
+}
+
+// validate checks the do-not-call numbers, calling hours and time zones
+func (o OutboundSettings) validate() error {
+	for _, number := range o.DoNotCall {
+		if !e164Pattern.MatchString(NormalizeNumber(number)) {
+			return fmt.Errorf("invalid outbound do_not_call number %q: expected E.164", number)
+		}
+	}
+	for day, ranges := range o.CallingHours {
+		if _, ok := weekdays[day]; !ok {
+			return fmt.Errorf("invalid outbound calling_hours day %q", day)
+		}
+		for _, r := range ranges {
+			if _, err := parseClock(r.Open); err != nil {
+				return fmt.Errorf("invalid outbound calling_hours open time for %s: %w", day, err)
+			}
+			if _, err := parseClock(r.Close); err != nil {
+				return fmt.Errorf("invalid outbound calling_hours close time for %s: %w", day, err)
+			}
+		}
+	}
+	zones := []string{o.DefaultTimezone}
+	for _, zone := range o.Timezones {
+		zones = append(zones, zone)
+	}
+	for _, zone := range zones {
+		if zone == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(zone); err != nil {
+			return fmt.Errorf("invalid outbound timezone %q: %w", zone, err)
+		}
+	}
+	return nil
+}
+
+func (d AIDisclosureSettings) validate() error {
+	switch d.Mode {
+	case "", DisclosureOff:
+		return nil
+	case DisclosureTone:
+		if d.ToneHz <= 0 || d.ToneHz >= 4000 {
+			return fmt.Errorf("tone_hz %d must be between 0 and 4000", d.ToneHz)
+		}
+		if d.ToneMs <= 0 || d.ToneMs >= d.IntervalSeconds*1000 {
+			return fmt.Errorf("tone_ms %d must be positive and shorter than the interval", d.ToneMs)
+		}
+		if d.ToneLevel <= 0 || d.ToneLevel > 1 {
+			return fmt.Errorf("tone_level %v must be between 0 and 1", d.ToneLevel)
+		}
+	case DisclosureAnnouncement:
+		if d.Announcement == "" && d.AnnouncementAudio == "" {
+			return fmt.Errorf("announcement mode needs announcement or announcement_audio")
+		}
+	default:
+		return fmt.Errorf("invalid mode %q", d.Mode)
+	}
+	if d.IntervalSeconds <= 0 {
+		return fmt.Errorf("invalid interval_seconds %d", d.IntervalSeconds)
+	}
+	return nil
+}
+
+// EscalationTransferNumber returns the number offered to escalated callers
+func (s *Settings) EscalationTransferNumber() string {
+	if s.Escalation.TransferNumber != "" {
+		return s.Escalation.TransferNumber
+	}
+	return s.Routing.TransferNumber
+}
+
+// ValidAction returns whether action is a known routing action
+func ValidAction(action string) bool {
+	switch action {
+	case ActionAI, ActionVoicemail, ActionTransfer:
+		return true
+	}
+	return false
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// everyDay returns hours with the same range on each day of the week
+func everyDay(r TimeRange) BusinessHours {
+	hours := make(BusinessHours, len(weekdays))
+	for day := range weekdays {
+		hours[day] = []TimeRange{r}
+	}
+	return hours
+}
+
+// IsOpen returns whether t (already in the firm's location) falls in any range
+// Empty business hours are treated as always open
+func (b BusinessHours) IsOpen(t time.Time) bool {
+	if len(b) == 0 {
+		return true
+	}
+
+	ranges := b[strings.ToLower(t.Weekday().String())]
+	minute := t.Hour()*60 + t.Minute()
+	for _, r := range ranges {
+		open, err := parseClock(r.Open)
+		if err != nil {
+			continue
+		}
+		closeAt, err := parseClock(r.Close)
+		if err != nil {
+			continue
+		}
+		if minute >= open && minute < closeAt {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,246 @@
+// Package flags gates risky new behaviours per call, so they can be rolled
+// out to a share of traffic or to chosen firms first. Rules come from
+// FEATURE_FLAGS, overridden by a JSON file or a remote URL serving the same
+// JSON, which is re-read periodically. A call evaluates the flags once when
+// it starts and keeps the result for its whole life
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule targets one flag. Firm lists win over the percentage
+type Rule struct {
+	Enabled      bool     `json:"enabled"`                 // Master switch: false turns the flag off everywhere
+	Percent      *float64 `json:"percent,omitempty"`       // Share of calls (0-100), by call SID; unset means every call
+	Firms        []string `json:"firms,omitempty"`         // Firms that always get the flag
+	ExcludeFirms []string `json:"exclude_firms,omitempty"` // Firms that never get it
+}
+
+// Validate checks the rule's percentage
+func (r Rule) Validate() error {
+	if r.Percent != nil && (*r.Percent < 0 || *r.Percent > 100) {
+		return fmt.Errorf("percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// on decides the flag for one call
+func (r Rule) on(name, firmID, callSid string) bool {
+	if !r.Enabled {
+		return false
+	}
+	for _, id := range r.ExcludeFirms {
+		if id == firmID {
+			return false
+		}
+	}
+	for _, id := range r.Firms {
+		if id == firmID {
+			return true
+		}
+	}
+	if r.Percent == nil {
+		return true
+	}
+	return bucket(name, callSid) < *r.Percent
+}
+
+// bucket places a call in [0, 100) for a flag. Hashing the flag name with the
+// call SID keeps each flag's rollout independent of the others
+func bucket(name, callSid string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(callSid))
+	return float64(h.Sum32()%10000) / 100
+}
+
+// ParseSpec parses FEATURE_FLAGS entries: "name" turns a flag on for every
+// call, "name=25" for 25% of calls, and "name=off" turns it off
+func ParseSpec(entries []string) (map[string]Rule, error) {
+	rules := make(map[string]Rule, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("%q has no flag name", entry)
+		}
+		rule := Rule{Enabled: true}
+		switch value = strings.TrimSpace(value); {
+		case !hasValue || value == "on":
+		case value == "off":
+			rule.Enabled = false
+		default:
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %q is not on, off or a percentage", name, value)
+			}
+			rule.Percent = &percent
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		rules[name] = rule
+	}
+	return rules, nil
+}
+
+// parseRules decodes and validates a JSON object of rules by flag name
+func parseRules(data []byte) (map[string]Rule, error) {
+	var rules map[string]Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	for name, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return rules, nil
+}
+
+// Source loads the rules that override FEATURE_FLAGS
+type Source func(ctx context.Context) (map[string]Rule, error)
+
+// FileSource reads rules from a JSON file
+func FileSource(path string) Source {
+	return func(ctx context.Context) (map[string]Rule, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read feature flags %s: %w", path, err)
+		}
+		rules, err := parseRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse feature flags %s: %w", path, err)
+		}
+		return rules, nil
+	}
+}
+
+// URLSource fetches rules from a remote flag service serving the file format
+func URLSource(url string, client *http.Client) Source {
+	return func(ctx context.Context) (map[string]Rule, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch feature flags: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("feature flag service returned status %d", resp.StatusCode)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read feature flags: %w", err)
+		}
+		rules, err := parseRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse feature flags: %w", err)
+		}
+		return rules, nil
+	}
+}
+
+// Set holds the current rules. Methods are safe on a nil *Set, which turns
+// every flag off
+type Set struct {
+	base map[string]Rule // From FEATURE_FLAGS
+
+	mu    sync.RWMutex
+	rules map[string]Rule // base with the source's rules layered on
+}
+
+// New creates a set with the FEATURE_FLAGS rules
+func New(base map[string]Rule) *Set {
+	return &Set{base: base, rules: base}
+}
+
+// Refresh layers the source's rules over FEATURE_FLAGS. On error the current
+// rules stay in force
+func (s *Set) Refresh(ctx context.Context, source Source) error {
+	loaded, err := source(ctx)
+	if err != nil {
+		return err
+	}
+	rules := make(map[string]Rule, len(s.base)+len(loaded))
+	for name, rule := range s.base {
+		rules[name] = rule
+	}
+	for name, rule := range loaded {
+		rules[name] = rule
+	}
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+	return nil
+}
+
+// Watch refreshes from the source every interval until ctx is cancelled,
+// passing failures to onError
+func (s *Set) Watch(ctx context.Context, source Source, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Refresh(ctx, source); err != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ForCall evaluates every flag for a call
+func (s *Set) ForCall(firmID, callSid string) Features {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	features := make(Features, len(s.rules))
+	for name, rule := range s.rules {
+		features[name] = rule.on(name, firmID, callSid)
+	}
+	return features
+}
+
+// Features is one call's flag evaluation; a nil Features has every flag off
+type Features map[string]bool
+
+// Enabled reports whether the flag is on for the call
+func (f Features) Enabled(name string) bool {
+	return f[name]
+}
+
+// On lists the flags that are on, sorted
+func (f Features) On() []string {
+	var on []string
+	for name, enabled := range f {
+		if enabled {
+			on = append(on, name)
+		}
+	}
+	sort.Strings(on)
+	return on
+}
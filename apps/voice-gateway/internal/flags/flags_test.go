@@ -0,0 +1,119 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	rules, err := ParseSpec([]string{"barge_in_v2", "speculative_synthesis=25", "new_resampler=off"})
+	if err != nil {
+		t.Fatalf("ParseSpec failed: %v", err)
+	}
+	if r := rules["barge_in_v2"]; !r.Enabled || r.Percent != nil {
+		t.Errorf("Expected a bare name on for every call, got %+v", r)
+	}
+	if r := rules["speculative_synthesis"]; !r.Enabled || r.Percent == nil || *r.Percent != 25 {
+		t.Errorf("Expected speculative_synthesis at 25%%, got %+v", r)
+	}
+	if rules["new_resampler"].Enabled {
+		t.Error("Expected new_resampler off")
+	}
+
+	for _, bad := range []string{"x=150", "x=maybe", "=10"} {
+		if _, err := ParseSpec([]string{bad}); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
+
+func TestForCall_Targeting(t *testing.T) {
+	ten := 10.0
+	set := New(map[string]Rule{
+		"everyone": {Enabled: true},
+		"switched": {Enabled: false, Firms: []string{"firm_a"}},
+		"pilot":    {Enabled: true, Percent: new(float64), Firms: []string{"firm_a"}},
+		"partial":  {Enabled: true, Percent: &ten, ExcludeFirms: []string{"firm_b"}},
+	})
+
+	a := set.ForCall("firm_a", "CA1")
+	if !a.Enabled("everyone") || a.Enabled("switched") || !a.Enabled("pilot") {
+		t.Errorf("Unexpected evaluation for firm_a: %v", a)
+	}
+	if set.ForCall("firm_c", "CA1").Enabled("pilot") {
+		t.Error("Expected a 0% flag off outside its firms")
+	}
+	if a.Enabled("unknown") {
+		t.Error("Expected an unknown flag off")
+	}
+
+	// Roughly the configured share of calls, never excluded firms, and the
+	// same answer for the same call
+	on := 0
+	for i := 0; i < 10000; i++ {
+		callSid := fmt.Sprintf("CA%d", i)
+		if set.ForCall("firm_c", callSid).Enabled("partial") {
+			on++
+		}
+		if set.ForCall("firm_b", callSid).Enabled("partial") {
+			t.Fatal("Expected an excluded firm never to get the flag")
+		}
+	}
+	if math.Abs(float64(on)-1000) > 150 {
+		t.Errorf("Expected about 10%% of calls, got %d of 10000", on)
+	}
+	if set.ForCall("firm_c", "CA42").Enabled("partial") != set.ForCall("firm_c", "CA42").Enabled("partial") {
+		t.Error("Expected a stable evaluation per call")
+	}
+}
+
+func TestSet_RefreshLayersOverBase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	os.WriteFile(path, []byte(`{"barge_in_v2": {"enabled": false}, "new_resampler": {"enabled": true, "firms": ["firm_a"], "percent": 0}}`), 0o600)
+
+	set := New(map[string]Rule{"barge_in_v2": {Enabled: true}, "speculative_synthesis": {Enabled: true}})
+	if err := set.Refresh(context.Background(), FileSource(path)); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	f := set.ForCall("firm_a", "CA1")
+	if f.Enabled("barge_in_v2") || !f.Enabled("speculative_synthesis") || !f.Enabled("new_resampler") {
+		t.Errorf("Expected the file to override FEATURE_FLAGS per flag, got %v", f)
+	}
+
+	// A broken file keeps the last good rules
+	os.WriteFile(path, []byte(`{"new_resampler": {"enabled": true, "percent": 200}}`), 0o600)
+	if err := set.Refresh(context.Background(), FileSource(path)); err == nil {
+		t.Error("Expected an invalid percentage to fail the refresh")
+	}
+	if !set.ForCall("firm_a", "CA1").Enabled("new_resampler") {
+		t.Error("Expected the previous rules to stay in force")
+	}
+}
+
+func TestURLSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"barge_in_v2": {"enabled": true}}`))
+	}))
+	defer server.Close()
+
+	set := New(nil)
+	if err := set.Refresh(context.Background(), URLSource(server.URL, server.Client())); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if got := set.ForCall("firm_a", "CA1").On(); len(got) != 1 || got[0] != "barge_in_v2" {
+		t.Errorf("Expected barge_in_v2 on from the remote rules, got %v", got)
+	}
+}
+
+func TestNilSet(t *testing.T) {
+	var set *Set
+	if set.ForCall("firm_a", "CA1").Enabled("barge_in_v2") {
+		t.Error("Expected every flag off without a set")
+	}
+}
@@ -0,0 +1,123 @@
+// Package httpserver serves the gateway's endpoints over TLS and HTTP/2 when
+// it runs without a proxy in front. Without a certificate the server stays
+// plain HTTP, which is what a TLS-terminating proxy or load balancer expects
+package httpserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// certCheckInterval is how often a handshake may check the certificate
+// files for a renewal
+const certCheckInterval = time.Minute
+
+// Certificate serves a key pair from disk, reloading it when the certificate
+// file changes. A renewal that fails to load keeps the previous pair
+type Certificate struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+// LoadCertificate reads a PEM key pair
+func LoadCertificate(certFile, keyFile string) (*Certificate, error) {
+	c := &Certificate{certFile: certFile, keyFile: keyFile}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Certificate) load() error {
+	info, err := os.Stat(c.certFile)
+	if err != nil {
+		return fmt.Errorf("TLS certificate: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("TLS key pair: %w", err)
+	}
+	c.mu.Lock()
+	c.cert = &cert
+	c.modTime = info.ModTime()
+	c.checked = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// GetCertificate returns the current pair, reloading it first when the
+// certificate file changed since it was loaded
+func (c *Certificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	cert, due := c.cert, time.Since(c.checked) >= certCheckInterval
+	c.mu.RUnlock()
+	if !due {
+		return cert, nil
+	}
+
+	c.mu.Lock()
+	c.checked = time.Now()
+	modTime := c.modTime
+	c.mu.Unlock()
+	if info, err := os.Stat(c.certFile); err == nil && !info.ModTime().Equal(modTime) {
+		_ = c.load()
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+// Configure sets up TLS and HTTP/2 on server from config. Call it after the
+// server's Handler is set
+func Configure(server *http.Server, cfg *config.Config) error {
+	if cfg.TLSCertFile == "" {
+		if cfg.HTTP2Enabled {
+			// Cleartext HTTP/2 with prior knowledge or an h2c upgrade; other
+			// requests, WebSocket upgrades included, pass through as HTTP/1.1
+			server.Handler = h2c.NewHandler(server.Handler, &http2.Server{IdleTimeout: server.IdleTimeout})
+		}
+		return nil
+	}
+
+	cert, err := LoadCertificate(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return err
+	}
+	minVersion := uint16(tls.VersionTLS12)
+	if cfg.TLSMinVersion == "1.3" {
+		minVersion = tls.VersionTLS13
+	}
+	server.TLSConfig = &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: cert.GetCertificate,
+	}
+
+	if !cfg.HTTP2Enabled {
+		// A non-nil, empty map stops net/http negotiating h2
+		server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		return nil
+	}
+	return http2.ConfigureServer(server, &http2.Server{IdleTimeout: server.IdleTimeout})
+}
+
+// ListenAndServe serves HTTPS when Configure set up TLS, HTTP otherwise
+func ListenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
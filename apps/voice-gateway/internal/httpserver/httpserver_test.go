@@ -0,0 +1,190 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"golang.org/x/net/http2"
+)
+
+// writeKeyPair writes a self-signed certificate for 127.0.0.1 with the given
+// common name, returning the file paths
+func writeKeyPair(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate failed: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey failed: %v", err)
+	}
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certFile, keyFile
+}
+
+// serve starts server on a local port, returning its address
+func serve(t *testing.T, server *http.Server) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	go func() {
+		if server.TLSConfig != nil {
+			server.ServeTLS(ln, "", "")
+		} else {
+			server.Serve(ln)
+		}
+	}()
+	t.Cleanup(func() { server.Close() })
+	return ln.Addr().String()
+}
+
+func protoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	})
+}
+
+func TestConfigure_TLS(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t, t.TempDir(), "gateway")
+	for _, enabled := range []bool{false, true} {
+		server := &http.Server{Handler: protoHandler()}
+		cfg := &config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSMinVersion: "1.2", HTTP2Enabled: enabled}
+		if err := Configure(server, cfg); err != nil {
+			t.Fatalf("Configure failed: %v", err)
+		}
+		addr := serve(t, server)
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		}}
+		resp, err := client.Get("https://" + addr)
+		if err != nil {
+			t.Fatalf("GET failed: %v", err)
+		}
+		resp.Body.Close()
+		if want := map[bool]int{false: 1, true: 2}[enabled]; resp.ProtoMajor != want {
+			t.Errorf("HTTP2Enabled=%v: expected HTTP/%d, got %s", enabled, want, resp.Proto)
+		}
+	}
+}
+
+func TestConfigure_TLSMinVersion(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t, t.TempDir(), "gateway")
+	server := &http.Server{Handler: protoHandler()}
+	if err := Configure(server, &config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSMinVersion: "1.3"}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	addr := serve(t, server)
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true, MaxVersion: tls.VersionTLS12})
+	if err == nil {
+		conn.Close()
+		t.Error("Expected a TLS 1.2 handshake to be refused")
+	}
+}
+
+func TestConfigure_CleartextHTTP2(t *testing.T) {
+	server := &http.Server{Handler: protoHandler()}
+	if err := Configure(server, &config.Config{HTTP2Enabled: true}); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	addr := serve(t, server)
+
+	// Prior knowledge h2c, as a gRPC-style proxy speaks it
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Errorf("Expected h2c, got %s", resp.Proto)
+	}
+
+	// HTTP/1.1 clients are still served
+	resp, err = http.Get("http://" + addr)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.ProtoMajor != 1 {
+		t.Errorf("Expected HTTP/1.1, got %s", resp.Proto)
+	}
+}
+
+func commonName(t *testing.T, c *Certificate) string {
+	t.Helper()
+	cert, _ := c.GetCertificate(nil)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("ParseCertificate failed: %v", err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertificate_ReloadsRenewal(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, "first")
+	cert, err := LoadCertificate(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("LoadCertificate failed: %v", err)
+	}
+
+	writeKeyPair(t, dir, "renewed")
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, future, future)
+
+	// Not checked again until the interval passes
+	if got := commonName(t, cert); got != "first" {
+		t.Fatalf("Expected the loaded certificate before the check interval, got %q", got)
+	}
+
+	cert.mu.Lock()
+	cert.checked = time.Time{}
+	cert.mu.Unlock()
+	if got := commonName(t, cert); got != "renewed" {
+		t.Errorf("Expected the renewed certificate, got %q", got)
+	}
+}
+
+func TestLoadCertificate_Missing(t *testing.T) {
+	if _, err := LoadCertificate(filepath.Join(t.TempDir(), "none.crt"), "none.key"); err == nil {
+		t.Error("Expected an error for a missing certificate")
+	}
+}
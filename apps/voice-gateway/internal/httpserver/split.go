@@ -0,0 +1,40 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Only serves the requests under the given path prefixes and answers 404 to
+// the rest, so a listener exposes just its share of a shared mux
+func Only(next http.Handler, prefixes ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !underAny(r.URL.Path, prefixes) {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Except answers 404 to the requests under the given path prefixes and serves
+// the rest
+func Except(next http.Handler, prefixes ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if underAny(r.URL.Path, prefixes) {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func underAny(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
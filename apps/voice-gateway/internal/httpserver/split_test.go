@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOnlyAndExcept(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	internal := []string{"/admin", "/metrics", "/debug/"}
+	public, private := Except(ok, internal...), Only(ok, internal...)
+
+	tests := []struct {
+		path     string
+		internal bool
+	}{
+		{"/streams/twilio", false},
+		{"/admin", true},
+		{"/admin/calls", true},
+		{"/administrator", false},
+		{"/metrics", true},
+		{"/debug/pprof/heap", true},
+		{"/health", false},
+	}
+	for _, tt := range tests {
+		for _, side := range []struct {
+			name    string
+			handler http.Handler
+			serves  bool
+		}{{"public", public, !tt.internal}, {"internal", private, tt.internal}} {
+			rec := httptest.NewRecorder()
+			side.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if served := rec.Code == http.StatusOK; served != side.serves {
+				t.Errorf("%s listener, %s: expected served=%v, got status %d", side.name, tt.path, side.serves, rec.Code)
+			}
+		}
+	}
+}
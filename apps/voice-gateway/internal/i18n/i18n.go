@@ -0,0 +1,170 @@
+// Package i18n translates the speech the gateway generates itself (fallback
+// apologies, clarification and consent prompts, goodbyes) and maps call
+// languages to TTS voices. English text always comes from config or firm
+// settings; the catalog only holds other languages, translating the built-in
+// English, so it is not used for messages a firm or operator has reworded
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Key names a gateway-generated message
+type Key string
+
+// Messages the gateway speaks on its own
+const (
+	StallFallback      Key = "stall_fallback"
+	Clarification      Key = "clarification"
+	ChallengePrompt    Key = "challenge_prompt"
+	Disclaimer         Key = "disclaimer"
+	ConsentPrompt      Key = "consent_prompt"
+	ConsentDeclined    Key = "consent_declined"
+	EscalationOffer    Key = "escalation_offer"
+	TransferFailed     Key = "transfer_failed"
+	InactivityPrompt   Key = "inactivity_prompt"
+	InactivityGoodbye  Key = "inactivity_goodbye"
+	MaxDurationGoodbye Key = "max_duration_goodbye"
+	BudgetMessage      Key = "budget_message"
+	MissingFirm        Key = "missing_firm"
+	VoicemailGreeting  Key = "voicemail_greeting"
+	ToolProgress       Key = "tool_progress"
+	ConfirmationPrompt Key = "confirmation_prompt"
+	ConfirmationRepeat Key = "confirmation_repeat"
+	AIDisclosure       Key = "ai_disclosure"
+	ResponseContinue   Key = "response_continue"
+
+	VerificationCodePrompt Key = "verification_code_prompt"
+	VerificationPINPrompt  Key = "verification_pin_prompt"
+	VerificationRetry      Key = "verification_retry"
+)
+
+// keys lists every message, for validating catalogs
+var keys = map[Key]bool{
+	StallFallback: true, Clarification: true, ChallengePrompt: true, Disclaimer: true,
+	ConsentPrompt: true, ConsentDeclined: true, EscalationOffer: true, TransferFailed: true,
+	InactivityPrompt: true, InactivityGoodbye: true, MaxDurationGoodbye: true,
+	BudgetMessage: true, MissingFirm: true, VoicemailGreeting: true, ToolProgress: true,
+	ConfirmationPrompt: true, ConfirmationRepeat: true, AIDisclosure: true, ResponseContinue: true,
+	VerificationCodePrompt: true, VerificationPINPrompt: true, VerificationRetry: true,
+}
+
+// builtin holds the translations shipped with the gateway. Disclaimer has
+// none: legal text must come from the firm
+var builtin = map[string]map[Key]string{
+	"es": {
+		StallFallback:      "Lo siento, estoy teniendo problemas en este momento. ¿Podría repetirlo?",
+		Clarification:      "Perdón, no le entendí bien. ¿Podría repetirlo?",
+		ChallengePrompt:    "Gracias por llamar. Para continuar, por favor diga su nombre o presione cualquier tecla.",
+		ConsentPrompt:      "Esta llamada puede ser grabada con fines de calidad y registro, y usted está hablando con un asistente de inteligencia artificial. ¿Acepta que esta llamada sea grabada? Diga sí o presione 1 para aceptar, o diga no o presione 2 para rechazar.",
+		ConsentDeclined:    "Entendido. Esta llamada no será grabada.",
+		EscalationOffer:    "Lamento las molestias. ¿Desea que le comunique con alguien de la firma?",
+		TransferFailed:     "Lo siento, no pude comunicarle. Mientras tanto, sigo aquí para ayudarle.",
+		InactivityPrompt:   "¿Sigue ahí?",
+		InactivityGoodbye:  "Parece que se perdió la comunicación. Llámenos cuando guste. Adiós.",
+		MaxDurationGoodbye: "Hemos llegado al límite de tiempo de esta llamada. Vuelva a llamar si necesita algo más. Adiós.",
+		BudgetMessage:      "Gracias por llamar. Nuestro asistente virtual no está disponible en este momento, pero nos aseguraremos de que su llamada llegue a la firma.",
+		MissingFirm:        "Lo sentimos, esta línea no está configurada para recibir llamadas en este momento. Por favor, intente más tarde. Adiós.",
+		VoicemailGreeting:  "Gracias por llamar. En este momento no hay nadie disponible para atender su llamada. Por favor, deje un mensaje después del tono y nos comunicaremos con usted lo antes posible.",
+		ToolProgress:       "Un momento, por favor, mientras lo reviso.",
+		ConfirmationPrompt: "Para confirmar: {details}. ¿Es correcto? Diga sí o presione 1, o diga no o presione 2.",
+		ConfirmationRepeat: "Perdón, ¿es correcto? Diga sí o presione 1, o diga no o presione 2.",
+		AIDisclosure:       "Le recordamos que está hablando con un asistente de inteligencia artificial.",
+		ResponseContinue:   "…¿desea que continúe?",
+
+		VerificationCodePrompt: "Le acabo de enviar un mensaje de texto con un código de {length} dígitos. Por favor, márquelo en su teclado o díctemelo.",
+		VerificationPINPrompt:  "Por favor, marque su PIN en el teclado seguido de la tecla numeral, o dígamelo.",
+		VerificationRetry:      "Lo siento, no coincide. Por favor, inténtelo de nuevo.",
+	},
+}
+
+// Catalog holds translations by base language, then message. A nil Catalog
+// has no translations
+type Catalog struct {
+	messages map[string]map[Key]string
+}
+
+// Builtin returns the translations shipped with the gateway
+func Builtin() *Catalog {
+	c := &Catalog{messages: make(map[string]map[Key]string, len(builtin))}
+	c.merge(builtin)
+	return c
+}
+
+// LoadCatalog returns the built-in translations overlaid with those in a JSON
+// file of {"<language>": {"<message>": "<text>"}}; an empty path loads none
+func LoadCatalog(path string) (*Catalog, error) {
+	c := Builtin()
+	if path == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read i18n catalog: %w", err)
+	}
+	var file map[string]map[Key]string
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse i18n catalog: %w", err)
+	}
+	if err := Validate(file); err != nil {
+		return nil, err
+	}
+	c.merge(file)
+	return c, nil
+}
+
+// Validate checks translations keyed by language, then message
+func Validate(translations map[string]map[Key]string) error {
+	for language, messages := range translations {
+		if Base(language) == "" {
+			return fmt.Errorf("invalid language %q", language)
+		}
+		for key := range messages {
+			if !keys[key] {
+				return fmt.Errorf("unknown message %q for language %q", key, language)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) merge(translations map[string]map[Key]string) {
+	for language, messages := range translations {
+		base := Base(language)
+		if c.messages[base] == nil {
+			c.messages[base] = make(map[Key]string, len(messages))
+		}
+		for key, text := range messages {
+			c.messages[base][key] = text
+		}
+	}
+}
+
+// Lookup returns the translation of key into language, if there is one
+func (c *Catalog) Lookup(language string, key Key) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	text, ok := c.messages[Base(language)][key]
+	return text, ok && text != ""
+}
+
+// Base returns the lowercase primary subtag of a language tag ("es-MX" is
+// "es"), or "" for an empty or multilingual ("multi") setting
+func Base(language string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(language)), "-")
+	if base == "multi" {
+		return ""
+	}
+	return base
+}
+
+// IsEnglish reports whether text in language needs no translation; unknown
+// languages count as English, since that is what config and settings hold
+func IsEnglish(language string) bool {
+	base := Base(language)
+	return base == "" || base == "en"
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCatalog_Lookup(t *testing.T) {
+	catalog := Builtin()
+
+	for _, language := range []string{"es", "es-MX", "ES-us"} {
+		if _, ok := catalog.Lookup(language, Clarification); !ok {
+			t.Errorf("Expected a Spanish clarification prompt for %q", language)
+		}
+	}
+	if _, ok := catalog.Lookup("es", Disclaimer); ok {
+		t.Error("Expected no built-in disclaimer translation")
+	}
+	if _, ok := catalog.Lookup("fr", Clarification); ok {
+		t.Error("Expected no built-in French translations")
+	}
+
+	var empty *Catalog
+	if _, ok := empty.Lookup("es", Clarification); ok {
+		t.Error("Expected a nil catalog to have no translations")
+	}
+}
+
+func TestBuiltin_CoversEveryMessage(t *testing.T) {
+	catalog := Builtin()
+	for key := range keys {
+		if key == Disclaimer {
+			continue
+		}
+		if _, ok := catalog.Lookup("es", key); !ok {
+			t.Errorf("Expected a Spanish translation for %q", key)
+		}
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	content := `{"fr": {"clarification": "Pardon, pouvez-vous répéter ?"}, "es-MX": {"clarification": "¿Me lo repite, por favor?"}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	catalog, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog failed: %v", err)
+	}
+	if text, _ := catalog.Lookup("fr-CA", Clarification); text != "Pardon, pouvez-vous répéter ?" {
+		t.Errorf("Expected the French prompt, got %q", text)
+	}
+	if text, _ := catalog.Lookup("es", Clarification); text != "¿Me lo repite, por favor?" {
+		t.Errorf("Expected the file to override the built-in Spanish prompt, got %q", text)
+	}
+	if _, ok := catalog.Lookup("es", InactivityPrompt); !ok {
+		t.Error("Expected built-in Spanish messages the file does not override")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"fr": {"clarificaton": "typo"}}`), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := LoadCatalog(path); err == nil {
+		t.Error("Expected error for an unknown message key")
+	}
+}
+
+func TestIsEnglish(t *testing.T) {
+	tests := map[string]bool{"": true, "en": true, "en-GB": true, "multi": true, "es": false, "pt-BR": false}
+	for language, want := range tests {
+		if got := IsEnglish(language); got != want {
+			t.Errorf("IsEnglish(%q): expected %v, got %v", language, want, got)
+		}
+	}
+}
+
+func TestParseVoices(t *testing.T) {
+	voices, err := ParseVoices([]string{"es:voice-es", " fr-CA : voice-fr ", ""})
+	if err != nil {
+		t.Fatalf("ParseVoices failed: %v", err)
+	}
+	if voice, _ := voices.Voice("es-MX"); voice != "voice-es" {
+		t.Errorf("Expected voice-es, got %q", voice)
+	}
+	if voice, _ := voices.Voice("fr"); voice != "voice-fr" {
+		t.Errorf("Expected voice-fr, got %q", voice)
+	}
+	if _, ok := voices.Voice("de"); ok {
+		t.Error("Expected no voice for German")
+	}
+
+	for _, entry := range []string{"es", "es:", ":voice"} {
+		if _, err := ParseVoices([]string{entry}); err == nil {
+			t.Errorf("Expected error for %q", entry)
+		}
+	}
+}
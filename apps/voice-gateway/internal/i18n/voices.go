@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Voices maps base languages to TTS voice IDs
+type Voices map[string]string
+
+// ParseVoices parses language:voice entries (e.g. "es:2b5684a8-..."),
+// skipping blank ones
+func ParseVoices(entries []string) (Voices, error) {
+	voices := Voices{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		language, voice, ok := strings.Cut(entry, ":")
+		if !ok || Base(language) == "" || strings.TrimSpace(voice) == "" {
+			return nil, fmt.Errorf("voice entry %q must be language:voice_id", entry)
+		}
+		voices[Base(language)] = strings.TrimSpace(voice)
+	}
+	return voices, nil
+}
+
+// NewVoices builds a voice map from voices keyed by language tags, such as a
+// firm's settings, keeping the base language of each
+func NewVoices(byLanguage map[string]string) Voices {
+	voices := make(Voices, len(byLanguage))
+	for language, voice := range byLanguage {
+		if base := Base(language); base != "" {
+			voices[base] = voice
+		}
+	}
+	return voices
+}
+
+// Voice returns the voice for language, if one is mapped
+func (v Voices) Voice(language string) (string, bool) {
+	voice, ok := v[Base(language)]
+	return voice, ok && voice != ""
+}
@@ -0,0 +1,144 @@
+package live
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Event types streamed to supervisors
+const (
+	TypeCallStarted   = "call.started"
+	TypeTranscript    = "transcript"     // Interim or final caller speech, or agent text
+	TypeTurnStarted   = "turn.started"   // Caller turn sent to the orchestrator
+	TypeTurnCompleted = "turn.completed" // Orchestrator finished responding
+	TypeToolCall      = "tool_call"
+	TypeEscalation    = "escalation" // Reason holds the trigger
+
+	TypeSupervisorJoined = "supervisor.joined" // Reason holds the initial mode
+	TypeSupervisorMode   = "supervisor.mode"   // Reason holds the new mode
+	TypeSupervisorLeft   = "supervisor.left"
+	TypeCallEnded        = "call.ended"
+)
+
+// Speakers
+const (
+	SpeakerCaller = "caller"
+	SpeakerAgent  = "agent"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind
+// before events are dropped for it
+const subscriberBuffer = 64
+
+// Event is a real-time update about an in-progress call
+type Event struct {
+	Type       string    `json:"type"`
+	CallSid    string    `json:"call_sid"`
+	Speaker    string    `json:"speaker,omitempty"`
+	Text       string    `json:"text,omitempty"`
+	Spoken     string    `json:"spoken,omitempty"` // Speech as said on a translated call, where Text is in the firm's language
+	Final      bool      `json:"final,omitempty"`
+	Stable     string    `json:"stable,omitempty"` // Leading words of interim speech no longer expected to change
+	Confidence float64   `json:"confidence,omitempty"`
+	Words      []Word    `json:"words,omitempty"` // Caller speech's words, for word-by-word highlighting
+	ToolName   string    `json:"tool_name,omitempty"`
+	Reason     string    `json:"reason,omitempty"` // Disposition, for call.ended
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Word is one word of caller speech. Times are seconds into the caller's
+// audio since transcription started
+type Word struct {
+	Text       string  `json:"text"`
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Hub fans out events for active calls to their subscribers
+// Publishing never blocks: a subscriber that falls behind loses events
+type Hub struct {
+	mu    sync.Mutex
+	calls map[string]map[chan Event]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{calls: make(map[string]map[chan Event]struct{})}
+}
+
+// Open marks a call as active so it can be subscribed to
+func (h *Hub) Open(callSid string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.calls[callSid]; !ok {
+		h.calls[callSid] = make(map[chan Event]struct{})
+	}
+}
+
+// Close ends a call, closing all of its subscriber channels
+func (h *Hub) Close(callSid string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.calls[callSid] {
+		close(ch)
+	}
+	delete(h.calls, callSid)
+}
+
+// Publish sends an event to the call's subscribers
+func (h *Hub) Publish(callSid string, event Event) {
+	event.CallSid = callSid
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.calls[callSid] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of events for an active call and a function to
+// unsubscribe; ok is false when the call is not active on this instance
+// The channel is closed when the call ends
+func (h *Hub) Subscribe(callSid string) (events <-chan Event, unsubscribe func(), ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers, ok := h.calls[callSid]
+	if !ok {
+		return nil, nil, false
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	subscribers[ch] = struct{}{}
+
+	unsubscribe = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.calls[callSid][ch]; ok {
+			delete(h.calls[callSid], ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe, true
+}
+
+// ActiveCalls returns the sids of calls open on this instance
+func (h *Hub) ActiveCalls() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sids := make([]string, 0, len(h.calls))
+	for sid := range h.calls {
+		sids = append(sids, sid)
+	}
+	sort.Strings(sids)
+	return sids
+}
@@ -0,0 +1,50 @@
+package live
+
+import (
+	"testing"
+)
+
+func TestHub_PublishSubscribe(t *testing.T) {
+	hub := NewHub()
+
+	if _, _, ok := hub.Subscribe("CA1"); ok {
+		t.Error("Expected subscribe to an unknown call to fail")
+	}
+
+	hub.Open("CA1")
+	events, unsubscribe, ok := hub.Subscribe("CA1")
+	if !ok {
+		t.Fatal("Expected subscribe to an open call to succeed")
+	}
+	defer unsubscribe()
+
+	hub.Publish("CA1", Event{Type: TypeTranscript, Speaker: SpeakerCaller, Text: "hello", Final: true})
+	hub.Publish("CA2", Event{Type: TypeTranscript, Text: "other call"})
+
+	event := <-events
+	if event.CallSid != "CA1" || event.Text != "hello" || event.Timestamp.IsZero() {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+
+	hub.Close("CA1")
+	if _, open := <-events; open {
+		t.Error("Expected channel to close when the call ends")
+	}
+	if len(hub.ActiveCalls()) != 0 {
+		t.Errorf("Expected no active calls, got %v", hub.ActiveCalls())
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+	hub.Open("CA1")
+	_, unsubscribe, _ := hub.Subscribe("CA1")
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish("CA1", Event{Type: TypeTranscript})
+	}
+
+	// Unsubscribing after the call ends is a no-op
+	hub.Close("CA1")
+	unsubscribe()
+}
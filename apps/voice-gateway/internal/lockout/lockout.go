@@ -0,0 +1,80 @@
+// Package lockout counts failed identity checks per caller, across calls and
+// gateway instances, so hanging up and calling back does not earn a caller
+// more guesses
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Counter records failures against a key (a firm's contact or caller number)
+// for a window starting at the first failure
+type Counter interface {
+	// Failures returns the failures recorded against key in its window
+	Failures(ctx context.Context, key string) (int, error)
+
+	// Fail records a failure against key and returns the count in its window
+	Fail(ctx context.Context, key string) (int, error)
+
+	// Reset clears key's failures
+	Reset(ctx context.Context, key string) error
+}
+
+// MemoryCounter keeps failures in process memory, shared by the calls on one
+// instance only, so it suits single-instance deployments and development
+type MemoryCounter struct {
+	window time.Duration
+
+	mu       sync.Mutex
+	failures map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	count   int
+	expires time.Time
+}
+
+// NewMemoryCounter creates an in-memory counter whose failures expire after window
+func NewMemoryCounter(window time.Duration) *MemoryCounter {
+	return &MemoryCounter{window: window, failures: make(map[string]memoryEntry)}
+}
+
+// Failures returns the failures recorded against key in its window
+func (m *MemoryCounter) Failures(ctx context.Context, key string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entryLocked(key).count, nil
+}
+
+// Fail records a failure against key and returns the count in its window
+func (m *MemoryCounter) Fail(ctx context.Context, key string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := m.entryLocked(key)
+	if entry.count == 0 {
+		entry.expires = time.Now().Add(m.window)
+	}
+	entry.count++
+	m.failures[key] = entry
+	return entry.count, nil
+}
+
+// Reset clears key's failures
+func (m *MemoryCounter) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.failures, key)
+	return nil
+}
+
+// entryLocked returns key's entry, dropping it once its window has passed; m.mu must be held
+func (m *MemoryCounter) entryLocked(key string) memoryEntry {
+	entry, ok := m.failures[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(m.failures, key)
+		return memoryEntry{}
+	}
+	return entry
+}
@@ -0,0 +1,47 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCounter(t *testing.T) {
+	ctx := context.Background()
+	counter := NewMemoryCounter(time.Hour)
+
+	for want := 1; want <= 3; want++ {
+		if got, _ := counter.Fail(ctx, "acme:contact:c-1"); got != want {
+			t.Errorf("Expected failure %d, got %d", want, got)
+		}
+	}
+	if got, _ := counter.Failures(ctx, "acme:contact:c-1"); got != 3 {
+		t.Errorf("Expected 3 failures, got %d", got)
+	}
+	if got, _ := counter.Failures(ctx, "acme:contact:c-2"); got != 0 {
+		t.Errorf("Expected other keys to have no failures, got %d", got)
+	}
+
+	if err := counter.Reset(ctx, "acme:contact:c-1"); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if got, _ := counter.Failures(ctx, "acme:contact:c-1"); got != 0 {
+		t.Errorf("Expected no failures after a reset, got %d", got)
+	}
+}
+
+func TestMemoryCounter_WindowExpires(t *testing.T) {
+	ctx := context.Background()
+	counter := NewMemoryCounter(20 * time.Millisecond)
+
+	counter.Fail(ctx, "acme:number:+15551234567")
+	counter.Fail(ctx, "acme:number:+15551234567")
+	time.Sleep(40 * time.Millisecond)
+
+	if got, _ := counter.Failures(ctx, "acme:number:+15551234567"); got != 0 {
+		t.Errorf("Expected failures to expire with the window, got %d", got)
+	}
+	if got, _ := counter.Fail(ctx, "acme:number:+15551234567"); got != 1 {
+		t.Errorf("Expected a new window to start at 1, got %d", got)
+	}
+}
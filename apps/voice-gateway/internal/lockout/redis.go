@@ -0,0 +1,75 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+)
+
+// redisKeyPrefix namespaces lockout keys in a shared Redis
+const redisKeyPrefix = "voice-gateway:lockout:"
+
+// failScript counts a failure, starting the window on the first
+// KEYS: counter
+// ARGV: window ms
+const failScript = `
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`
+
+// RedisCounter keeps failures in Redis, shared by every gateway instance;
+// each key is a counter that expires at the end of its window
+type RedisCounter struct {
+	client *cluster.RedisClient
+	window time.Duration
+}
+
+// NewRedisCounter creates a counter on the given client whose failures expire after window
+func NewRedisCounter(client *cluster.RedisClient, window time.Duration) *RedisCounter {
+	return &RedisCounter{client: client, window: window}
+}
+
+// Failures returns the failures recorded against key in its window
+func (r *RedisCounter) Failures(ctx context.Context, key string) (int, error) {
+	reply, err := r.client.Do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load failures: %w", err)
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid failure count for %s: %w", key, err)
+	}
+	return count, nil
+}
+
+// Fail records a failure against key and returns the count in its window
+func (r *RedisCounter) Fail(ctx context.Context, key string) (int, error) {
+	reply, err := r.client.Do(ctx, "EVAL", failScript, "1", redisKeyPrefix+key,
+		strconv.FormatInt(r.window.Milliseconds(), 10))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record failure: %w", err)
+	}
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected failure count reply %v", reply)
+	}
+	return int(count), nil
+}
+
+// Reset clears key's failures
+func (r *RedisCounter) Reset(ctx context.Context, key string) error {
+	if _, err := r.client.Do(ctx, "DEL", redisKeyPrefix+key); err != nil {
+		return fmt.Errorf("failed to reset failures: %w", err)
+	}
+	return nil
+}
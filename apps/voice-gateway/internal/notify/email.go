@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// EmailSender sends plain-text notification emails over SMTP
+type EmailSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewEmailSender creates an SMTP sender
+// addr is host:port; username may be empty for unauthenticated relays
+func NewEmailSender(addr, from, username, password string) *EmailSender {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &EmailSender{addr: addr, from: from, auth: auth}
+}
+
+// Send delivers a plain-text email to the recipients
+func (e *EmailSender) Send(to []string, subject, body string) error {
+	if len(to) == 0 {
+		return nil
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", sanitizeHeader(subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	if err := smtp.SendMail(e.addr, e.auth, e.from, to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// sanitizeHeader strips line breaks so user-controlled values can't inject headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
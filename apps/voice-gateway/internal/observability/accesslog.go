@@ -0,0 +1,160 @@
+package observability
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Access log results
+const (
+	AccessUpgraded  = "upgraded"  // WebSocket established and used as expected
+	AccessRejected  = "rejected"  // Never upgraded (bad handshake, unauthorized, ...)
+	AccessMalformed = "malformed" // Upgraded, but the client broke the protocol (e.g. no start event)
+)
+
+type accessRecordKey struct{}
+
+// AccessRecord collects what handlers learn about a connection attempt that
+// the request alone does not show. Methods are safe on a nil record, so
+// handlers need not check whether access logging is on
+type AccessRecord struct {
+	mu           sync.Mutex
+	accountSid   string
+	callSid      string
+	reason       string
+	reasonDetail string
+}
+
+// AccessRecordFrom returns the access record for the request, or nil
+func AccessRecordFrom(ctx context.Context) *AccessRecord {
+	record, _ := ctx.Value(accessRecordKey{}).(*AccessRecord)
+	return record
+}
+
+// SetCall records the Twilio account and call the connection carried
+func (a *AccessRecord) SetCall(accountSid, callSid string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	a.accountSid = accountSid
+	a.callSid = callSid
+	a.mu.Unlock()
+}
+
+// Reject records why the connection was refused or broke the protocol;
+// reason is a short fixed code, detail the specifics (e.g. an error message)
+func (a *AccessRecord) Reject(reason, detail string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	if a.reason == "" {
+		a.reason = reason
+		a.reasonDetail = detail
+	}
+	a.mu.Unlock()
+}
+
+// AccessLog writes one structured log line (log_type "access") for each
+// WebSocket connection attempt: requests asking for an upgrade, and any
+// request to a path under one of wsPaths, so plain requests that never
+// attempt the handshake are recorded too. Lines are written when the
+// connection ends, once the Twilio start event has named the account
+func AccessLog(next http.Handler, logger zerolog.Logger, wsPaths ...string) http.Handler {
+	logger = logger.With().Str("log_type", "access").Logger()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint := websocketEndpoint(r, wsPaths)
+		if endpoint == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		record := &AccessRecord{}
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), accessRecordKey{}, record)))
+
+		record.mu.Lock()
+		defer record.mu.Unlock()
+
+		status := recorder.status
+		if recorder.hijacked {
+			status = http.StatusSwitchingProtocols
+		} else if status == 0 {
+			status = http.StatusOK
+		}
+
+		result := AccessUpgraded
+		reason := record.reason
+		switch {
+		case !recorder.hijacked:
+			result = AccessRejected
+			if reason == "" {
+				reason = strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+			}
+		case reason != "":
+			result = AccessMalformed
+		}
+
+		event := logger.Info()
+		if result != AccessUpgraded {
+			event = logger.Warn()
+		}
+		event.
+			Str("endpoint", endpoint).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Str("remote_ip", remoteIP(r)).
+			Str("forwarded_for", r.Header.Get("X-Forwarded-For")).
+			Str("user_agent", r.UserAgent()).
+			Bool("upgrade_requested", isWebSocketUpgrade(r)).
+			Bool("twilio_signature", r.Header.Get("X-Twilio-Signature") != "").
+			Str("account_sid", record.accountSid).
+			Str("call_sid", record.callSid).
+			Str("result", result).
+			Int("status", status).
+			Str("reason", reason).
+			Str("reason_detail", record.reasonDetail).
+			Dur("duration", time.Since(start)).
+			Msg("WebSocket access")
+
+		RecordWebSocketAccess(endpoint, result, reason)
+	})
+}
+
+// websocketEndpoint returns the wsPaths entry r falls under, "other" for an
+// upgrade elsewhere, or "" when r is not a WebSocket attempt
+func websocketEndpoint(r *http.Request, wsPaths []string) string {
+	for _, path := range wsPaths {
+		if strings.HasPrefix(r.URL.Path, path) {
+			return path
+		}
+	}
+	if isWebSocketUpgrade(r) {
+		return "other"
+	}
+	return ""
+}
+
+// isWebSocketUpgrade reports whether the request asks for a WebSocket upgrade
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// remoteIP returns the peer address without its port
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
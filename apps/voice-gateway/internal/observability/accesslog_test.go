@@ -0,0 +1,193 @@
+package observability
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+// syncBuffer is a log sink safe to read while the server writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries waits briefly for n log lines and decodes them
+func (b *syncBuffer) entries(t *testing.T, n int) []map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		b.mu.Lock()
+		lines := strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+		b.mu.Unlock()
+		if lines[0] != "" && len(lines) >= n || time.Now().After(deadline) {
+			var entries []map[string]interface{}
+			for _, line := range lines {
+				if line == "" {
+					continue
+				}
+				var entry map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("Invalid log line %q: %v", line, err)
+				}
+				entries = append(entries, entry)
+			}
+			return entries
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func newAccessLogServer(sink *syncBuffer) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/streams/twilio", func(w http.ResponseWriter, r *http.Request) {
+		access := AccessRecordFrom(r.Context())
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			access.Reject("handshake_failed", err.Error())
+			return
+		}
+		defer conn.Close()
+		_, message, err := conn.ReadMessage()
+		if err != nil || string(message) != "start" {
+			access.Reject("no_start_event", "")
+			return
+		}
+		access.SetCall("AC1", "CA1")
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+	return httptest.NewServer(AccessLog(mux, zerolog.New(sink), "/streams/"))
+}
+
+func TestAccessLog_Upgraded(t *testing.T) {
+	sink := &syncBuffer{}
+	server := newAccessLogServer(sink)
+	defer server.Close()
+
+	header := http.Header{"User-Agent": {"TwilioProxy/1.1"}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/streams/twilio", header)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	_ = conn.WriteMessage(websocket.TextMessage, []byte("start"))
+	defer conn.Close()
+
+	entries := sink.entries(t, 1)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 access log line, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["log_type"] != "access" || entry["result"] != AccessUpgraded {
+		t.Errorf("Expected upgraded access entry, got %v", entry)
+	}
+	if entry["account_sid"] != "AC1" || entry["call_sid"] != "CA1" {
+		t.Errorf("Expected account and call SIDs, got %v %v", entry["account_sid"], entry["call_sid"])
+	}
+	if entry["user_agent"] != "TwilioProxy/1.1" || entry["remote_ip"] != "127.0.0.1" {
+		t.Errorf("Expected user agent and remote IP, got %v %v", entry["user_agent"], entry["remote_ip"])
+	}
+	if entry["status"] != float64(http.StatusSwitchingProtocols) {
+		t.Errorf("Expected status 101, got %v", entry["status"])
+	}
+}
+
+func TestAccessLog_Rejected(t *testing.T) {
+	sink := &syncBuffer{}
+	server := newAccessLogServer(sink)
+	defer server.Close()
+
+	// A plain GET never attempts the handshake
+	resp, err := http.Get(server.URL + "/streams/twilio")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+
+	entries := sink.entries(t, 1)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 access log line, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["result"] != AccessRejected || entry["reason"] != "handshake_failed" {
+		t.Errorf("Expected rejected handshake, got %v", entry)
+	}
+	if entry["upgrade_requested"] != false || entry["reason_detail"] == "" {
+		t.Errorf("Expected upgrade_requested false and a detail, got %v", entry)
+	}
+}
+
+func TestAccessLog_Malformed(t *testing.T) {
+	sink := &syncBuffer{}
+	server := newAccessLogServer(sink)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/streams/twilio", nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	_ = conn.WriteMessage(websocket.TextMessage, []byte("hello"))
+	defer conn.Close()
+
+	entries := sink.entries(t, 1)
+	if len(entries) != 1 || entries[0]["result"] != AccessMalformed || entries[0]["reason"] != "no_start_event" {
+		t.Errorf("Expected malformed entry, got %v", entries)
+	}
+}
+
+func TestAccessLog_SkipsOtherRequests(t *testing.T) {
+	sink := &syncBuffer{}
+	server := newAccessLogServer(sink)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if sink.buf.Len() != 0 {
+		t.Errorf("Expected no access log for /health, got %s", sink.buf.String())
+	}
+}
+
+func TestAccessLog_ExposesDeadlines(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	server := httptest.NewServer(AccessLog(handler, zerolog.Nop(), "/streams/"))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/streams/live")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the write deadline to reach the connection, got %d", resp.StatusCode)
+	}
+}
+
+func TestAccessRecord_NilSafe(t *testing.T) {
+	var record *AccessRecord
+	record.SetCall("AC1", "CA1")
+	record.Reject("x", "y")
+}
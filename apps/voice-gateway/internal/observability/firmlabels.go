@@ -0,0 +1,81 @@
+package observability
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// FirmLabelOther is the firm_id label of firms past the cardinality guard
+	FirmLabelOther = "other"
+
+	// FirmLabelUnattributed is the firm_id label of calls without a firm
+	FirmLabelUnattributed = "unattributed"
+)
+
+// firmLabelGuard caps how many firms get their own firm_id series. Allowlisted
+// firms always do; after them, the first limit firms seen since startup do,
+// and every other firm is reported as FirmLabelOther
+type firmLabelGuard struct {
+	mu       sync.Mutex
+	allow    map[string]bool
+	limit    int
+	admitted map[string]bool
+	warned   bool
+}
+
+var firmLabels = &firmLabelGuard{limit: 50, admitted: make(map[string]bool)}
+
+// ConfigureFirmLabels sets the firms always given their own firm_id series
+// and how many others may be, in arrival order. Call it at startup
+func ConfigureFirmLabels(allowlist []string, limit int) {
+	allow := make(map[string]bool, len(allowlist))
+	for _, id := range allowlist {
+		if id != "" {
+			allow[id] = true
+		}
+	}
+	firmLabels.mu.Lock()
+	defer firmLabels.mu.Unlock()
+	firmLabels.allow = allow
+	firmLabels.limit = limit
+	firmLabels.admitted = make(map[string]bool)
+	firmLabels.warned = false
+}
+
+// FirmLabel returns the firm_id label value for a firm under the guard
+func FirmLabel(firmID string) string {
+	return firmLabels.label(firmID)
+}
+
+func (g *firmLabelGuard) label(firmID string) string {
+	if firmID == "" {
+		return FirmLabelUnattributed
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.allow[firmID] || g.admitted[firmID] {
+		return firmID
+	}
+	if len(g.admitted) < g.limit {
+		g.admitted[firmID] = true
+		return firmID
+	}
+	if !g.warned {
+		g.warned = true
+		logger := GetLogger()
+		logger.Warn().Int("limit", g.limit).Str("firm_id", firmID).
+			Msg("Firm metric label limit reached; further firms are reported as \"other\"")
+	}
+	return FirmLabelOther
+}
+
+// observeWithTrace observes v, attaching traceID as an exemplar when there is one
+func observeWithTrace(o prometheus.Observer, v float64, traceID string) {
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && traceID != "" {
+		eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": traceID})
+		return
+	}
+	o.Observe(v)
+}
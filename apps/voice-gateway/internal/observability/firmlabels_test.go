@@ -0,0 +1,95 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestFirmLabel_CardinalityGuard(t *testing.T) {
+	ConfigureFirmLabels([]string{"acme"}, 2)
+	defer ConfigureFirmLabels(nil, 50)
+
+	if got := FirmLabel(""); got != FirmLabelUnattributed {
+		t.Errorf("Expected calls without a firm labelled %q, got %q", FirmLabelUnattributed, got)
+	}
+	for _, id := range []string{"globex", "initech", "acme"} {
+		if got := FirmLabel(id); got != id {
+			t.Errorf("Expected %s to get its own label, got %q", id, got)
+		}
+	}
+	if got := FirmLabel("hooli"); got != FirmLabelOther {
+		t.Errorf("Expected firms past the limit labelled %q, got %q", FirmLabelOther, got)
+	}
+	if got := FirmLabel("globex"); got != "globex" {
+		t.Errorf("Expected an admitted firm to keep its label, got %q", got)
+	}
+
+	ConfigureFirmLabels([]string{"acme"}, 0)
+	if got := FirmLabel("globex"); got != FirmLabelOther {
+		t.Errorf("Expected only allowlisted firms labelled with a zero limit, got %q", got)
+	}
+	if got := FirmLabel("acme"); got != "acme" {
+		t.Errorf("Expected an allowlisted firm labelled with a zero limit, got %q", got)
+	}
+}
+
+func TestFirmLabel_FirmCounters(t *testing.T) {
+	ConfigureFirmLabels([]string{"acme"}, 0)
+	defer ConfigureFirmLabels(nil, 50)
+	callOutcomes.Reset()
+	budgetDivertedCalls.Reset()
+	usageSTTSeconds.Reset()
+
+	RecordCallOutcome("hooli", "answered_by_ai")
+	RecordBudgetDiverted("hooli")
+	RecordUsage("hooli", 12, 0, 0, 0)
+	RecordCallOutcome("acme", "answered_by_ai")
+
+	counter := func(c prometheus.Counter) float64 {
+		var m dto.Metric
+		if err := c.Write(&m); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		return m.GetCounter().GetValue()
+	}
+	if got := counter(callOutcomes.WithLabelValues(FirmLabelOther, "answered_by_ai")); got != 1 {
+		t.Errorf("Expected the unlisted firm's outcome under %q, got %v", FirmLabelOther, got)
+	}
+	if got := counter(callOutcomes.WithLabelValues("acme", "answered_by_ai")); got != 1 {
+		t.Errorf("Expected the allowlisted firm's outcome under its own label, got %v", got)
+	}
+	if got := counter(budgetDivertedCalls.WithLabelValues(FirmLabelOther)); got != 1 {
+		t.Errorf("Expected the unlisted firm's diversion under %q, got %v", FirmLabelOther, got)
+	}
+	if got := counter(usageSTTSeconds.WithLabelValues(FirmLabelOther)); got != 12 {
+		t.Errorf("Expected the unlisted firm's usage under %q, got %v", FirmLabelOther, got)
+	}
+}
+
+func TestObserveWithTrace_AttachesExemplar(t *testing.T) {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "test_latency_seconds",
+		Buckets: []float64{0.5, 1},
+	}, []string{"firm_id"})
+
+	observeWithTrace(h.WithLabelValues("acme"), 0.3, "trace-123")
+	observeWithTrace(h.WithLabelValues("acme"), 0.8, "")
+
+	var m dto.Metric
+	if err := h.WithLabelValues("acme").(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := m.GetHistogram().GetSampleCount(); got != 2 {
+		t.Fatalf("Expected 2 samples, got %d", got)
+	}
+	buckets := m.GetHistogram().GetBucket()
+	exemplar := buckets[0].GetExemplar()
+	if exemplar == nil || exemplar.GetLabel()[0].GetValue() != "trace-123" || exemplar.GetValue() != 0.3 {
+		t.Errorf("Expected the trace exemplar on the first bucket, got %+v", exemplar)
+	}
+	if buckets[1].GetExemplar() != nil {
+		t.Errorf("Expected no exemplar without a trace ID, got %+v", buckets[1].GetExemplar())
+	}
+}
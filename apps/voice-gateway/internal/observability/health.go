@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/buildinfo"
 )
 
 // HealthStatus represents the health status of the service
@@ -29,7 +31,7 @@ func HealthCheckHandler() http.HandlerFunc {
 		status := HealthStatus{
 			Status:    "healthy",
 			Service:   "voice-gateway",
-			Version:   "1.0.0",
+			Version:   buildinfo.Version,
 			Timestamp: time.Now().UTC().Format(time.RFC3339),
 		}
 
@@ -39,106 +41,5 @@ func HealthCheckHandler() http.HandlerFunc {
 	}
 }
 
-// ReadinessHandler handles readiness check requests
-// It accepts health check functions for each dependency to avoid import cycles
+// HealthCheckFunc probes a dependency; the health registry runs these in the background
 type HealthCheckFunc func(ctx context.Context) (bool, error)
-
-func ReadinessHandler(
-	deepgramCheck HealthCheckFunc,
-	cartesiaCheck HealthCheckFunc,
-	orchestratorCheck HealthCheckFunc,
-) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		dependencies := make(map[string]DependencyStatus)
-		allHealthy := true
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		// Check Deepgram STT
-		if deepgramCheck != nil {
-			start := time.Now()
-			healthy, err := deepgramCheck(ctx)
-			latency := time.Since(start).Milliseconds()
-			
-			status := "healthy"
-			message := ""
-			if err != nil || !healthy {
-				status = "unhealthy"
-				allHealthy = false
-				if err != nil {
-					message = err.Error()
-				}
-			}
-			
-			dependencies["deepgram"] = DependencyStatus{
-				Status:    status,
-				Message:   message,
-				LatencyMs: latency,
-			}
-		}
-
-		// Check Cartesia TTS
-		if cartesiaCheck != nil {
-			start := time.Now()
-			healthy, err := cartesiaCheck(ctx)
-			latency := time.Since(start).Milliseconds()
-			
-			status := "healthy"
-			message := ""
-			if err != nil || !healthy {
-				status = "unhealthy"
-				allHealthy = false
-				if err != nil {
-					message = err.Error()
-				}
-			}
-			
-			dependencies["cartesia"] = DependencyStatus{
-				Status:    status,
-				Message:   message,
-				LatencyMs: latency,
-			}
-		}
-
-		// Check Orchestrator
-		if orchestratorCheck != nil {
-			start := time.Now()
-			healthy, err := orchestratorCheck(ctx)
-			latency := time.Since(start).Milliseconds()
-			
-			status := "healthy"
-			message := ""
-			if err != nil || !healthy {
-				status = "unhealthy"
-				allHealthy = false
-				if err != nil {
-					message = err.Error()
-				}
-			}
-			
-			dependencies["orchestrator"] = DependencyStatus{
-				Status:    status,
-				Message:   message,
-				LatencyMs: latency,
-			}
-		}
-
-		status := HealthStatus{
-			Status:      "ready",
-			Service:     "voice-gateway",
-			Version:     "1.0.0",
-			Timestamp:   time.Now().UTC().Format(time.RFC3339),
-			Dependencies: dependencies,
-		}
-
-		if !allHealthy {
-			status.Status = "not_ready"
-			w.WriteHeader(http.StatusServiceUnavailable)
-		} else {
-			w.WriteHeader(http.StatusOK)
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(status)
-	}
-}
@@ -1,9 +1,11 @@
 package observability
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/lexiqai/voice-gateway/internal/buildinfo"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -20,11 +22,11 @@ var (
 		Help: "Total number of calls processed",
 	})
 
-	callDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	callDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "voice_gateway_call_duration_seconds",
-		Help:    "Duration of phone calls in seconds",
+		Help:    "Duration of phone calls in seconds, by firm",
 		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600},
-	})
+	}, []string{"firm_id"})
 
 	// STT metrics
 	sttRequests = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -32,23 +34,100 @@ var (
 		Help: "Total number of STT requests",
 	}, []string{"status"})
 
-	sttLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+	sttLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "voice_gateway_stt_latency_seconds",
-		Help:    "STT processing latency in seconds",
+		Help:    "STT processing latency in seconds, by firm",
 		Buckets: []float64{0.1, 0.25, 0.5, 1.0, 2.0, 5.0},
+	}, []string{"firm_id"})
+
+	// Streaming STT timing and stability, for endpointing tuning
+	sttWordLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_stt_word_latency_seconds",
+		Help:    "Time from sending a word's audio until an interim result first contains it, by model and language",
+		Buckets: []float64{0.1, 0.2, 0.3, 0.5, 0.75, 1, 1.5, 2, 3},
+	}, []string{"model", "language"})
+
+	sttFinalizationDelay = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_stt_finalization_delay_seconds",
+		Help:    "Time from sending an utterance's last word until its final transcript, by model, language and trigger (endpoint, finalize)",
+		Buckets: []float64{0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5},
+	}, []string{"model", "language", "trigger"})
+
+	sttFinals = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_finals_total",
+		Help: "Final transcripts that followed an interim, by model, language and whether the final changed its words",
+	}, []string{"model", "language", "result"}) // unchanged, corrected
+
+	sttFinalWords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_final_words_total",
+		Help: "Words in final transcripts that followed an interim, by model and language",
+	}, []string{"model", "language"})
+
+	sttCorrectedWords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_corrected_words_total",
+		Help: "Words inserted, deleted or substituted between the last interim and the final, by model and language",
+	}, []string{"model", "language"})
+
+	sttModelFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_model_fallbacks_total",
+		Help: "Calls that switched from their primary Deepgram model to the fallback, by models and reason",
+	}, []string{"from", "to", "reason"})
+
+	sttKeepAlives = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_keepalives_total",
+		Help: "KeepAlive messages sent on idle Deepgram streams, by result",
+	}, []string{"result"})
+
+	sttRotations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_rotations_total",
+		Help: "Deepgram connections replaced on long calls, by result (completed, failed, abandoned)",
+	}, []string{"result"})
+
+	translations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_translations_total",
+		Help: "Caller speech and assistant replies translated between the caller's and the firm's language, by direction and result",
+	}, []string{"direction", "result"})
+
+	// Caller audio levels around automatic gain, for tuning AGC_* settings
+	inboundLevel = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_inbound_level_rms",
+		Help:    "RMS level of caller speech frames where automatic gain is enabled, by stage (before, after gain)",
+		Buckets: []float64{125, 250, 500, 1000, 2000, 4000, 8000},
+	}, []string{"stage"})
+
+	echoFramesSuppressed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_echo_frames_suppressed_total",
+		Help: "Caller audio frames silenced as echo of the agent's own speech",
+	})
+
+	selfEchoDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_self_echo_transcripts_dropped_total",
+		Help: "Final transcripts dropped as the agent's own speech heard back",
 	})
 
+	responsesTruncated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_responses_truncated_total",
+		Help: "Orchestrator responses cut short at MAX_RESPONSE_WORDS or MAX_RESPONSE_SECONDS",
+	})
+
+	// Talk-over: caller and agent audio overlapping, for judging barge-in
+	talkOverSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_talk_over_seconds",
+		Help:    "Length of stretches where the caller spoke while agent audio played, by who started talking second (caller, agent)",
+		Buckets: []float64{0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5},
+	}, []string{"started_by"})
+
 	// TTS metrics
 	ttsRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "voice_gateway_tts_requests_total",
 		Help: "Total number of TTS requests",
 	}, []string{"status"})
 
-	ttsLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+	ttsLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "voice_gateway_tts_latency_seconds",
-		Help:    "TTS processing latency in seconds",
+		Help:    "TTS processing latency in seconds, by firm",
 		Buckets: []float64{0.1, 0.25, 0.5, 1.0, 2.0, 5.0},
-	})
+	}, []string{"firm_id"})
 
 	// Orchestrator metrics
 	orchestratorRequests = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -56,11 +135,11 @@ var (
 		Help: "Total number of Orchestrator requests",
 	}, []string{"status"})
 
-	orchestratorLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+	orchestratorLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "voice_gateway_orchestrator_latency_seconds",
-		Help:    "Orchestrator processing latency in seconds",
+		Help:    "Orchestrator processing latency in seconds, by firm",
 		Buckets: []float64{0.1, 0.25, 0.5, 1.0, 2.0, 5.0, 10.0},
-	})
+	}, []string{"firm_id"})
 
 	// Error metrics
 	errorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -79,6 +158,273 @@ var (
 		Help: "Total circuit breaker failures",
 	}, []string{"service"})
 
+	// Billable usage per firm
+	usageSTTSeconds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_usage_stt_seconds_total",
+		Help: "STT audio seconds billed, by firm",
+	}, []string{"firm_id"})
+
+	usageTTSCharacters = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_usage_tts_characters_total",
+		Help: "Characters synthesized by TTS, by firm",
+	}, []string{"firm_id"})
+
+	usageOrchestratorTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_usage_orchestrator_tokens_total",
+		Help: "LLM tokens reported by the orchestrator, by firm",
+	}, []string{"firm_id"})
+
+	usageTelephonyMinutes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_usage_telephony_minutes_total",
+		Help: "Call minutes (each started minute counted), by firm",
+	}, []string{"firm_id"})
+
+	// Playback marks
+	playbackMarkLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_playback_mark_latency_seconds",
+		Help:    "Time from an utterance's audio being sent until Twilio reports it played, by kind (prompt, response)",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 15, 30},
+	}, []string{"kind"})
+
+	// Budget guards
+	budgetDivertedCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_budget_diverted_calls_total",
+		Help: "Calls kept from the AI because their firm reached its monthly spend cap",
+	}, []string{"firm_id"})
+
+	budgetAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_budget_alerts_total",
+		Help: "Budget alerts raised, by firm and percent of the monthly cap",
+	}, []string{"firm_id", "threshold"})
+
+	// WebSocket access
+	websocketAccess = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_websocket_access_total",
+		Help: "WebSocket connection attempts, by endpoint, result (upgraded, rejected, malformed) and reason",
+	}, []string{"endpoint", "result", "reason"})
+
+	// Recovered panics
+	panics = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_panics_total",
+		Help: "Panics recovered in per-call goroutines, by component and goroutine",
+	}, []string{"component", "goroutine"})
+
+	retries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_retries_total",
+		Help: "Failed attempts that were retried after a backoff",
+	}, []string{"service"})
+
+	// Endpointing metrics
+	endpointingMode = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "voice_gateway_endpointing_mode",
+		Help: "Active endpointing mode (1 for the active mode label)",
+	}, []string{"mode"})
+
+	vadFinalizations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_vad_finalizations_total",
+		Help: "Total utterances force-finalized by local VAD",
+	}, []string{"status"})
+
+	// STT billing metrics (silence suppression)
+	callAudioSeconds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_call_audio_seconds_total",
+		Help: "Total seconds of inbound caller audio received from Twilio",
+	})
+
+	sttBilledSeconds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_stt_billed_seconds_total",
+		Help: "Total seconds of audio forwarded (and billed) to the STT provider",
+	})
+
+	sttBilledRatio = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "voice_gateway_stt_billed_ratio",
+		Help:    "Per-call ratio of STT seconds billed to call audio seconds",
+		Buckets: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0},
+	})
+
+	// Screening metrics
+	screeningResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_screening_results_total",
+		Help: "Caller screening outcomes",
+	}, []string{"outcome"}) // allow, block, challenge_passed, challenge_failed
+
+	callOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_call_outcomes_total",
+		Help: "Finished calls by firm and outcome (answered_by_ai, transferred, voicemail, abandoned_in_greeting, failed_technical, rejected)",
+	}, []string{"firm_id", "outcome"})
+
+	firstResponseLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_first_response_latency_seconds",
+		Help:    "Time from the end of a caller's speech until the first audio of the reply is sent, by firm",
+		Buckets: []float64{0.25, 0.5, 0.75, 1, 1.25, 1.5, 2, 3, 5, 10},
+	}, []string{"firm_id"})
+
+	callAbandonTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_call_abandon_seconds",
+		Help:    "Time from call start until the caller hung up unanswered, by stage (greeting, conversation)",
+		Buckets: []float64{1, 2, 3, 5, 8, 13, 20, 30, 60, 120, 300},
+	}, []string{"stage"})
+
+	unattributedCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_unattributed_calls_total",
+		Help: "Calls whose stream carried no firm_id, by how MISSING_FIRM_POLICY handled them",
+	}, []string{"action"}) // rejected, defaulted, allowed
+
+	// Feature flag rollout: calls evaluated per flag, on or off
+	featureFlagCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_feature_flag_calls_total",
+		Help: "Calls by feature flag and whether the flag was on for them",
+	}, []string{"flag", "state"})
+
+	// Faults injected by chaos mode (development and staging only)
+	chaosFaults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_chaos_faults_total",
+		Help: "Faults injected by chaos mode, by fault",
+	}, []string{"fault"})
+
+	// Conversation metrics: how conversational calls are, for calls where the
+	// caller said something to the assistant
+	callTurns = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_call_turns",
+		Help:    "Assistant turns completed per call, by firm",
+		Buckets: []float64{1, 2, 3, 5, 8, 13, 20, 30, 50},
+	}, []string{"firm_id"})
+
+	callInterruptions = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_call_interruptions",
+		Help:    "Times per call the caller talked over the assistant and cut its speech off, by firm",
+		Buckets: []float64{0, 1, 2, 3, 5, 8, 13},
+	}, []string{"firm_id"})
+
+	utteranceWords = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_utterance_words",
+		Help:    "Words per caller utterance sent to the Orchestrator, by firm",
+		Buckets: []float64{1, 2, 3, 5, 8, 13, 20, 30, 50, 80},
+	}, []string{"firm_id"})
+
+	turnTokens = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_turn_orchestrator_tokens",
+		Help:    "LLM tokens the Orchestrator reported per turn, by firm",
+		Buckets: prometheus.ExponentialBuckets(100, 2, 10), // 100 to 51200
+	}, []string{"firm_id"})
+
+	turnTTSCharacters = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_turn_tts_characters",
+		Help:    "Characters of reply text queued for speech per turn, by firm",
+		Buckets: []float64{10, 25, 50, 100, 150, 250, 400, 600, 1000},
+	}, []string{"firm_id"})
+
+	// Twilio media stream connection metrics
+	twilioStreamCloses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_twilio_stream_closes_total",
+		Help: "Twilio media streams ended, by cause (stop, twilio_close, network_error, protocol_error, gateway)",
+	}, []string{"cause"})
+
+	twilioReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_twilio_stream_reconnects_total",
+		Help: "Dropped Twilio media streams waited out for a reconnect, by result (resumed, expired)",
+	}, []string{"result"})
+
+	// Tool metrics
+	toolExecutions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_tool_executions_total",
+		Help: "Telephony tool calls executed by the gateway",
+	}, []string{"tool", "status"})
+
+	// SMS metrics
+	smsMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_sms_total",
+		Help: "Follow-up SMS send attempts",
+	}, []string{"status"}) // sent, rate_limited, error
+
+	// Clarification metrics
+	clarifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_clarifications_total",
+		Help: "Low-confidence final transcripts handled by the gateway",
+	}, []string{"outcome"}) // prompted, passed_through
+
+	// Escalation metrics
+	escalations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_escalations_total",
+		Help: "Calls escalated by sentiment and keyword detection",
+	}, []string{"trigger"}) // escalation_request, profanity, frustration
+
+	// Subsystem health (1 healthy, 0 otherwise)
+	subsystemHealth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "voice_gateway_subsystem_healthy",
+		Help: "Whether each internal subsystem is healthy",
+	}, []string{"subsystem"})
+
+	// The running release, for joining release labels onto other series
+	buildInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "voice_gateway_build_info",
+		Help: "Always 1, labelled with the running release",
+	}, []string{"version", "commit", "go_version"})
+
+	// Cluster metrics
+	overflowCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_overflow_calls_total",
+		Help: "Calls that arrived while their firm was at its concurrent call limit",
+	})
+
+	// Orchestrator stream watchdog
+	orchestratorStalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_orchestrator_stalls_total",
+		Help: "Orchestrator response streams that stopped sending without closing",
+	}, []string{"outcome"}) // retried, fallback
+
+	orchestratorChunksDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_orchestrator_chunks_dropped_total",
+		Help: "Orchestrator text chunks dropped before synthesis",
+	}, []string{"reason"}) // superseded, duplicate
+
+	orchestratorTurnsSuperseded = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "voice_gateway_orchestrator_turns_superseded_total",
+		Help: "Orchestrator turns cancelled mid-response because the caller spoke again",
+	})
+
+	orchestratorHedges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_orchestrator_hedges_total",
+		Help: "Hedged Orchestrator requests for slow first responses",
+	}, []string{"outcome"}) // sent, won, budget_exhausted
+
+	toolProgress = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_tool_progress_total",
+		Help: "Orchestrator tool runs that reached or cut short the progress phrase delay",
+	}, []string{"outcome"}) // spoken, skipped, suppressed
+
+	toolConfirmations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_tool_confirmations_total",
+		Help: "Caller answers to gateway-managed tool confirmations",
+	}, []string{"status", "method"}) // confirmed, rejected, unclear, no_response; speech, dtmf
+
+	callerVerifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_caller_verifications_total",
+		Help: "Caller identity checks run by the verify_caller tool",
+	}, []string{"method", "status"}) // pin, sms_code; verified, failed, locked, expired, no_response
+
+	orchestratorCapabilities = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "voice_gateway_orchestrator_capability",
+		Help: "Whether the Orchestrator supports each optional API feature (1 supported, 0 not)",
+	}, []string{"capability"})
+
+	// Compliance metrics
+	recordingConsent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_recording_consent_total",
+		Help: "Recording consent requests by caller response",
+	}, []string{"status"}) // granted, declined, no_response
+
+	dataDeletions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_data_deletions_total",
+		Help: "Stored call data deleted under retention policies and erasure requests",
+	}, []string{"kind", "reason"}) // recordings, voicemail; expired, erasure
+
+	// Call limit metrics
+	limitHangups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_limit_hangups_total",
+		Help: "Calls ended by the gateway for exceeding a limit",
+	}, []string{"reason"}) // inactivity, max_duration
+
 	// Audio metrics
 	audioBytesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "voice_gateway_audio_bytes_total",
@@ -86,24 +432,47 @@ var (
 	}, []string{"direction"}) // direction: "in" or "out"
 )
 
-// Metrics tracks metrics for a single call
+// Metrics tracks metrics for a single call. Its latency histograms are
+// labelled with the call's firm (see FirmLabel) and carry its trace ID as an
+// exemplar
 type Metrics struct {
-	callID         string
-	startTime      time.Time
-	sttStartTime   time.Time
-	ttsStartTime   time.Time
+	callID                string
+	traceID               string
+	firmLabel             string
+	startTime             time.Time
+	sttStartTime          time.Time
+	ttsStartTime          time.Time
 	orchestratorStartTime time.Time
-	mu             sync.Mutex
+	callAudioBytes        int64
+	sttAudioBytes         int64
+	turns                 int
+	utterances            int
+	interruptions         int
+	mu                    sync.Mutex
 }
 
-// NewCallMetrics creates a new metrics tracker for a call
-func NewCallMetrics(callID string) *Metrics {
+// pcmuBytesPerSecond is the byte rate of 8kHz mono μ-law audio
+const pcmuBytesPerSecond = 8000.0
+
+// NewCallMetrics creates a new metrics tracker for a call; traceID (the
+// call's correlation ID) links its latency samples to its logs and traces
+func NewCallMetrics(callID, traceID string) *Metrics {
 	return &Metrics{
 		callID:    callID,
+		traceID:   traceID,
+		firmLabel: FirmLabelUnattributed,
 		startTime: time.Now(),
 	}
 }
 
+// SetFirm attributes the call's metrics to its firm once the firm is known
+func (m *Metrics) SetFirm(firmID string) {
+	label := FirmLabel(firmID)
+	m.mu.Lock()
+	m.firmLabel = label
+	m.mu.Unlock()
+}
+
 // RecordCallStart records the start of a call
 func (m *Metrics) RecordCallStart() {
 	activeCalls.Inc()
@@ -114,7 +483,44 @@ func (m *Metrics) RecordCallStart() {
 func (m *Metrics) RecordCallEnd() {
 	activeCalls.Dec()
 	duration := time.Since(m.startTime).Seconds()
-	callDuration.Observe(duration)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	observeWithTrace(callDuration.WithLabelValues(m.firmLabel), duration, m.traceID)
+	if m.callAudioBytes > 0 {
+		sttBilledRatio.Observe(float64(m.sttAudioBytes) / float64(m.callAudioBytes))
+	}
+	if m.utterances > 0 {
+		callTurns.WithLabelValues(m.firmLabel).Observe(float64(m.turns))
+		callInterruptions.WithLabelValues(m.firmLabel).Observe(float64(m.interruptions))
+	}
+}
+
+// RecordUtterance records a caller utterance sent to the Orchestrator
+func (m *Metrics) RecordUtterance(words int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.utterances++
+	utteranceWords.WithLabelValues(m.firmLabel).Observe(float64(words))
+}
+
+// RecordTurn records a completed assistant turn, with the tokens the
+// Orchestrator reported (0 when it reported none) and the characters queued for speech
+func (m *Metrics) RecordTurn(tokens, characters int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.turns++
+	if tokens > 0 {
+		turnTokens.WithLabelValues(m.firmLabel).Observe(float64(tokens))
+	}
+	turnTTSCharacters.WithLabelValues(m.firmLabel).Observe(float64(characters))
+}
+
+// RecordInterruption records the caller cutting the assistant's speech off
+func (m *Metrics) RecordInterruption() {
+	m.mu.Lock()
+	m.interruptions++
+	m.mu.Unlock()
 }
 
 // RecordSTTStart records the start of STT processing
@@ -131,7 +537,7 @@ func (m *Metrics) RecordSTTEnd(success bool) {
 
 	if !m.sttStartTime.IsZero() {
 		latency := time.Since(m.sttStartTime).Seconds()
-		sttLatency.Observe(latency)
+		observeWithTrace(sttLatency.WithLabelValues(m.firmLabel), latency, m.traceID)
 	}
 
 	status := "success"
@@ -155,7 +561,7 @@ func (m *Metrics) RecordTTSEnd(success bool) {
 
 	if !m.ttsStartTime.IsZero() {
 		latency := time.Since(m.ttsStartTime).Seconds()
-		ttsLatency.Observe(latency)
+		observeWithTrace(ttsLatency.WithLabelValues(m.firmLabel), latency, m.traceID)
 	}
 
 	status := "success"
@@ -179,7 +585,7 @@ func (m *Metrics) RecordOrchestratorEnd(success bool) {
 
 	if !m.orchestratorStartTime.IsZero() {
 		latency := time.Since(m.orchestratorStartTime).Seconds()
-		orchestratorLatency.Observe(latency)
+		observeWithTrace(orchestratorLatency.WithLabelValues(m.firmLabel), latency, m.traceID)
 	}
 
 	status := "success"
@@ -209,3 +615,303 @@ func IncrementCircuitBreakerFailures(service string) {
 	circuitBreakerFailures.WithLabelValues(service).Inc()
 }
 
+// SetEndpointingMode marks the given endpointing mode as active
+func SetEndpointingMode(mode string) {
+	for _, m := range []string{"deepgram", "vad", "hybrid"} {
+		value := 0.0
+		if m == mode {
+			value = 1.0
+		}
+		endpointingMode.WithLabelValues(m).Set(value)
+	}
+}
+
+// RecordVADFinalize records a VAD-driven utterance finalization
+func (m *Metrics) RecordVADFinalize(success bool) {
+	status := "success"
+	if !success {
+		status = "error"
+	}
+	vadFinalizations.WithLabelValues(status).Inc()
+}
+
+// RecordSTTAudio records inbound PCMU bytes received and the bytes actually
+// forwarded to the STT provider (which may include replayed pre-roll)
+func (m *Metrics) RecordSTTAudio(receivedBytes, forwardedBytes int) {
+	m.mu.Lock()
+	m.callAudioBytes += int64(receivedBytes)
+	m.sttAudioBytes += int64(forwardedBytes)
+	m.mu.Unlock()
+
+	callAudioSeconds.Add(float64(receivedBytes) / pcmuBytesPerSecond)
+	sttBilledSeconds.Add(float64(forwardedBytes) / pcmuBytesPerSecond)
+}
+
+// STTUsage returns the STT seconds billed and the call audio seconds so far
+func (m *Metrics) STTUsage() (billedSeconds, callSeconds float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return float64(m.sttAudioBytes) / pcmuBytesPerSecond, float64(m.callAudioBytes) / pcmuBytesPerSecond
+}
+
+// RecordScreening records a caller screening outcome
+func RecordScreening(outcome string) {
+	screeningResults.WithLabelValues(outcome).Inc()
+}
+
+// RecordToolExecution records a gateway-executed tool call
+func RecordToolExecution(tool string, success bool) {
+	status := "success"
+	if !success {
+		status = "error"
+	}
+	toolExecutions.WithLabelValues(tool, status).Inc()
+}
+
+// RecordSMS records an SMS send attempt
+func RecordSMS(status string) {
+	smsMessages.WithLabelValues(status).Inc()
+}
+
+// RecordLimitHangup records a call ended for exceeding a limit
+func RecordLimitHangup(reason string) {
+	limitHangups.WithLabelValues(reason).Inc()
+}
+
+// RecordClarification records how a low-confidence transcript was handled
+func RecordClarification(outcome string) {
+	clarifications.WithLabelValues(outcome).Inc()
+}
+
+// RecordEscalation records a call escalated by sentiment detection
+func RecordEscalation(trigger string) {
+	escalations.WithLabelValues(trigger).Inc()
+}
+
+// RecordConsent records the caller's answer to the recording consent prompt
+func RecordConsent(status string) {
+	recordingConsent.WithLabelValues(status).Inc()
+}
+
+// RecordOverflow records a call over its firm's concurrent call limit
+func RecordOverflow() {
+	overflowCalls.Inc()
+}
+
+// RecordOrchestratorStall records a stalled orchestrator stream and how it was handled
+func RecordOrchestratorStall(outcome string) {
+	orchestratorStalls.WithLabelValues(outcome).Inc()
+}
+
+// RecordOrchestratorHedge records a hedged Orchestrator request decision or win
+func RecordOrchestratorHedge(outcome string) {
+	orchestratorHedges.WithLabelValues(outcome).Inc()
+}
+
+// RecordRetry records a failed attempt that will be retried
+func RecordRetry(service string) {
+	retries.WithLabelValues(service).Inc()
+}
+
+// RecordUsage adds a finished call's billable usage to its firm's counters
+func RecordUsage(firmID string, sttSeconds float64, ttsCharacters, orchestratorTokens int64, telephonyMinutes float64) {
+	label := FirmLabel(firmID)
+	usageSTTSeconds.WithLabelValues(label).Add(sttSeconds)
+	usageTTSCharacters.WithLabelValues(label).Add(float64(ttsCharacters))
+	usageOrchestratorTokens.WithLabelValues(label).Add(float64(orchestratorTokens))
+	usageTelephonyMinutes.WithLabelValues(label).Add(telephonyMinutes)
+}
+
+// RecordBudgetDiverted records a call diverted because its firm is over budget
+func RecordBudgetDiverted(firmID string) {
+	budgetDivertedCalls.WithLabelValues(FirmLabel(firmID)).Inc()
+}
+
+// RecordBudgetAlert records a budget alert at percent of the firm's cap
+func RecordBudgetAlert(firmID string, percent int) {
+	budgetAlerts.WithLabelValues(FirmLabel(firmID), strconv.Itoa(percent)).Inc()
+}
+
+// RecordPlaybackMark records how long Twilio took to play an utterance after it was sent
+func RecordPlaybackMark(kind string, latency time.Duration) {
+	playbackMarkLatency.WithLabelValues(kind).Observe(latency.Seconds())
+}
+
+// RecordPanic records a panic recovered in a per-call goroutine
+func RecordPanic(component, goroutine string) {
+	panics.WithLabelValues(component, goroutine).Inc()
+}
+
+// RecordWebSocketAccess records the outcome of a WebSocket connection attempt
+func RecordWebSocketAccess(endpoint, result, reason string) {
+	websocketAccess.WithLabelValues(endpoint, result, reason).Inc()
+}
+
+// RecordTwilioStreamClose records why a Twilio media stream ended
+func RecordTwilioStreamClose(cause string) {
+	twilioStreamCloses.WithLabelValues(cause).Inc()
+}
+
+// RecordTwilioReconnect records whether a dropped Twilio stream reconnected within its grace
+func RecordTwilioReconnect(result string) {
+	twilioReconnects.WithLabelValues(result).Inc()
+}
+
+// RecordUnattributedCall records a call that arrived without a firm_id
+func RecordUnattributedCall(action string) {
+	unattributedCalls.WithLabelValues(action).Inc()
+}
+
+// RecordFeatureFlag counts a call's evaluation of one feature flag
+func RecordFeatureFlag(flag string, on bool) {
+	state := "off"
+	if on {
+		state = "on"
+	}
+	featureFlagCalls.WithLabelValues(flag, state).Inc()
+}
+
+// RecordChaosFault counts a fault injected by chaos mode
+func RecordChaosFault(fault string) {
+	chaosFaults.WithLabelValues(fault).Inc()
+}
+
+// SetOrchestratorCapability records whether the Orchestrator supports a feature
+func SetOrchestratorCapability(capability string, supported bool) {
+	value := 0.0
+	if supported {
+		value = 1.0
+	}
+	orchestratorCapabilities.WithLabelValues(capability).Set(value)
+}
+
+// RecordToolProgress records whether a slow tool run was announced to the caller
+func RecordToolProgress(outcome string) {
+	toolProgress.WithLabelValues(outcome).Inc()
+}
+
+// RecordToolConfirmation records how a caller answered a tool confirmation
+func RecordToolConfirmation(status, method string) {
+	toolConfirmations.WithLabelValues(status, method).Inc()
+}
+
+// RecordCallerVerification records the result of a caller identity check
+func RecordCallerVerification(method, status string) {
+	callerVerifications.WithLabelValues(method, status).Inc()
+}
+
+// RecordDataDeletion records a stored object deleted for retention or erasure
+func RecordDataDeletion(kind, reason string) {
+	dataDeletions.WithLabelValues(kind, reason).Inc()
+}
+
+// RecordCallOutcome records a finished call's outcome for the firm's funnel
+func RecordCallOutcome(firmID, outcome string) {
+	callOutcomes.WithLabelValues(FirmLabel(firmID), outcome).Inc()
+}
+
+// RecordCallAbandoned records how long a caller stayed before hanging up unanswered
+func RecordCallAbandoned(stage string, seconds float64) {
+	callAbandonTime.WithLabelValues(stage).Observe(seconds)
+}
+
+// RecordFirstResponseLatency records the time from the end of caller speech to the reply's first audio
+func (m *Metrics) RecordFirstResponseLatency(latency time.Duration) {
+	m.mu.Lock()
+	firmLabel := m.firmLabel
+	m.mu.Unlock()
+	observeWithTrace(firstResponseLatency.WithLabelValues(firmLabel), latency.Seconds(), m.traceID)
+}
+
+// RecordSTTWordLatency records how long a word took to appear in an interim result
+func RecordSTTWordLatency(model, language string, latency time.Duration) {
+	sttWordLatency.WithLabelValues(model, language).Observe(latency.Seconds())
+}
+
+// RecordSTTFinalizationDelay records how long an utterance's final transcript took after its last word
+func RecordSTTFinalizationDelay(model, language, trigger string, delay time.Duration) {
+	sttFinalizationDelay.WithLabelValues(model, language, trigger).Observe(delay.Seconds())
+}
+
+// RecordSTTCorrection records how a final transcript differed from the interim before it
+func RecordSTTCorrection(model, language string, words, corrected int) {
+	result := "unchanged"
+	if corrected > 0 {
+		result = "corrected"
+	}
+	sttFinals.WithLabelValues(model, language, result).Inc()
+	sttFinalWords.WithLabelValues(model, language).Add(float64(words))
+	sttCorrectedWords.WithLabelValues(model, language).Add(float64(corrected))
+}
+
+// RecordSTTModelFallback records a call switching to its fallback STT model
+func RecordSTTModelFallback(from, to, reason string) {
+	sttModelFallbacks.WithLabelValues(from, to, reason).Inc()
+}
+
+// RecordSTTKeepAlive records a KeepAlive sent on an idle STT stream
+func RecordSTTKeepAlive(ok bool) {
+	result := "sent"
+	if !ok {
+		result = "failed"
+	}
+	sttKeepAlives.WithLabelValues(result).Inc()
+}
+
+// RecordSTTRotation records the outcome of replacing a call's STT connection
+func RecordSTTRotation(result string) {
+	sttRotations.WithLabelValues(result).Inc()
+}
+
+// RecordTranslation records translating a caller utterance ("caller") or an
+// assistant reply ("agent"); a failed one is used untranslated
+func RecordTranslation(direction string, ok bool) {
+	result := "translated"
+	if !ok {
+		result = "failed"
+	}
+	translations.WithLabelValues(direction, result).Inc()
+}
+
+// RecordInboundLevel records a caller speech frame's level before and after
+// automatic gain
+func RecordInboundLevel(before, after float64) {
+	inboundLevel.WithLabelValues("before").Observe(before)
+	inboundLevel.WithLabelValues("after").Observe(after)
+}
+
+// RecordEchoSuppressed records a caller frame silenced as echo
+func RecordEchoSuppressed() {
+	echoFramesSuppressed.Inc()
+}
+
+// RecordSelfEchoDropped records a final transcript dropped as the agent's own speech
+func RecordSelfEchoDropped() {
+	selfEchoDropped.Inc()
+}
+
+// RecordResponseTruncated records a response cut short at the maximum length
+func RecordResponseTruncated() {
+	responsesTruncated.Inc()
+}
+
+// RecordTalkOver records a stretch of caller and agent audio overlapping,
+// by who started talking second
+func RecordTalkOver(startedBy string, seconds float64) {
+	talkOverSeconds.WithLabelValues(startedBy).Observe(seconds)
+}
+
+// RecordOrchestratorChunkDropped records orchestrator text dropped before synthesis
+func RecordOrchestratorChunkDropped(reason string) {
+	orchestratorChunksDropped.WithLabelValues(reason).Inc()
+}
+
+// RecordOrchestratorTurnSuperseded records a turn cancelled by a newer caller utterance
+func RecordOrchestratorTurnSuperseded() {
+	orchestratorTurnsSuperseded.Inc()
+}
+
+// RecordBuildInfo publishes the running release on the build_info gauge
+func RecordBuildInfo(info buildinfo.Info) {
+	buildInfo.WithLabelValues(info.Version, info.Commit, info.GoVersion).Set(1)
+}
@@ -0,0 +1,61 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func histogramOf(t *testing.T, h *prometheus.HistogramVec, firmLabel string) *dto.Histogram {
+	t.Helper()
+	var m dto.Metric
+	if err := h.WithLabelValues(firmLabel).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return m.GetHistogram()
+}
+
+func TestMetrics_ConversationMetrics(t *testing.T) {
+	ConfigureFirmLabels([]string{"conversational-firm", "silent-firm"}, 0)
+	defer ConfigureFirmLabels(nil, 50)
+	for _, h := range []*prometheus.HistogramVec{callTurns, callInterruptions, utteranceWords, turnTokens, turnTTSCharacters} {
+		h.Reset()
+	}
+
+	m := NewCallMetrics("call-1", "trace-1")
+	m.SetFirm("conversational-firm")
+	m.RecordCallStart()
+	m.RecordUtterance(4)
+	m.RecordTurn(350, 120)
+	m.RecordUtterance(7)
+	m.RecordInterruption()
+	m.RecordTurn(0, 40)
+	m.RecordCallEnd()
+
+	turns := histogramOf(t, callTurns, "conversational-firm")
+	if turns.GetSampleCount() != 1 || turns.GetSampleSum() != 2 {
+		t.Errorf("Expected one call with 2 turns, got %d calls summing %v", turns.GetSampleCount(), turns.GetSampleSum())
+	}
+	if got := histogramOf(t, callInterruptions, "conversational-firm").GetSampleSum(); got != 1 {
+		t.Errorf("Expected 1 interruption, got %v", got)
+	}
+	if words := histogramOf(t, utteranceWords, "conversational-firm"); words.GetSampleCount() != 2 || words.GetSampleSum() != 11 {
+		t.Errorf("Expected 2 utterances of 11 words, got %d summing %v", words.GetSampleCount(), words.GetSampleSum())
+	}
+	if tokens := histogramOf(t, turnTokens, "conversational-firm"); tokens.GetSampleCount() != 1 || tokens.GetSampleSum() != 350 {
+		t.Errorf("Expected only the turn with reported tokens observed, got %d summing %v", tokens.GetSampleCount(), tokens.GetSampleSum())
+	}
+	if chars := histogramOf(t, turnTTSCharacters, "conversational-firm"); chars.GetSampleCount() != 2 || chars.GetSampleSum() != 160 {
+		t.Errorf("Expected 2 turns of 160 characters, got %d summing %v", chars.GetSampleCount(), chars.GetSampleSum())
+	}
+
+	// Calls where the caller never spoke to the assistant don't skew turns per call
+	silent := NewCallMetrics("call-2", "trace-2")
+	silent.SetFirm("silent-firm")
+	silent.RecordCallStart()
+	silent.RecordCallEnd()
+	if got := histogramOf(t, callTurns, "silent-firm").GetSampleCount(); got != 0 {
+		t.Errorf("Expected no turns observed for a call without utterances, got %d", got)
+	}
+}
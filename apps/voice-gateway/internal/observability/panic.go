@@ -0,0 +1,29 @@
+package observability
+
+import (
+	"runtime/debug"
+)
+
+// RecoverPanic keeps a panic in a per-call goroutine from taking down the
+// process. Defer it directly at the top of the goroutine (recover only works
+// there): it logs the panic with its stack, counts it in
+// voice_gateway_panics_total and calls onPanic, if non-nil, with the value
+func RecoverPanic(component, goroutine string, onPanic func(recovered interface{})) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	logger := GetLogger()
+	logger.Error().
+		Str("component", component).
+		Str("goroutine", goroutine).
+		Interface("panic", recovered).
+		Str("stack", string(debug.Stack())).
+		Msg("Recovered panic")
+	RecordPanic(component, goroutine)
+
+	if onPanic != nil {
+		onPanic(recovered)
+	}
+}
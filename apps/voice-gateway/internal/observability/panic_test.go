@@ -0,0 +1,57 @@
+package observability
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	var recovered interface{}
+	func() {
+		defer RecoverPanic("test", "worker", func(r interface{}) { recovered = r })
+		panic("boom")
+	}()
+
+	if recovered != "boom" {
+		t.Errorf("Expected onPanic to receive boom, got %v", recovered)
+	}
+	if got := panicCount(t, "test", "worker"); got != 1 {
+		t.Errorf("Expected 1 recorded panic, got %v", got)
+	}
+}
+
+func TestRecoverPanic_NoPanic(t *testing.T) {
+	called := false
+	func() {
+		defer RecoverPanic("test", "quiet", func(interface{}) { called = true })
+	}()
+
+	if called {
+		t.Error("Expected onPanic not to be called without a panic")
+	}
+}
+
+// panicCount reads voice_gateway_panics_total for one component and goroutine
+func panicCount(t *testing.T, component, goroutine string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "voice_gateway_panics_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["component"] == component && labels["goroutine"] == goroutine {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,126 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// WebSocket close labels that aren't close codes
+const (
+	CloseAbnormal = "abnormal" // The peer went away without a close frame
+	CloseServer   = "server"   // The gateway ended the session
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_http_requests_total",
+		Help: "HTTP requests by route pattern, method and status class (1xx for WebSocket upgrades)",
+	}, []string{"route", "method", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_http_request_duration_seconds",
+		Help:    "HTTP request duration by route pattern and method, excluding WebSocket sessions",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"route", "method"})
+
+	websocketSessionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "voice_gateway_websocket_session_seconds",
+		Help:    "WebSocket session length by route pattern",
+		Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 3600},
+	}, []string{"route"})
+
+	websocketCloses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "voice_gateway_websocket_closes_total",
+		Help: "Ended WebSocket sessions by route pattern and close code (abnormal without a close frame, server when the gateway ended it)",
+	}, []string{"route", "code"})
+)
+
+type redRecordKey struct{}
+
+// redRecord carries what a WebSocket handler learns about how its session ended
+type redRecord struct {
+	mu        sync.Mutex
+	closeCode string
+}
+
+// RecordWebSocketClose notes how the request's WebSocket session ended,
+// from the error that ended reading it: the peer's close code, or
+// CloseAbnormal. A nil error means the gateway ended the session. Only
+// the first call for a request counts
+func RecordWebSocketClose(ctx context.Context, err error) {
+	record, _ := ctx.Value(redRecordKey{}).(*redRecord)
+	if record == nil {
+		return
+	}
+	code := CloseServer
+	var closeErr *websocket.CloseError
+	switch {
+	case errors.As(err, &closeErr):
+		code = strconv.Itoa(closeErr.Code)
+	case err != nil:
+		code = CloseAbnormal
+	}
+	record.mu.Lock()
+	if record.closeCode == "" {
+		record.closeCode = code
+	}
+	record.mu.Unlock()
+}
+
+// RED records rate, errors and duration for each request, labelled with the
+// route pattern route returns for it ("" for none), so labels stay bounded
+// whatever paths clients ask for. Upgraded WebSocket connections are
+// measured by session length and close code instead of request duration
+func RED(next http.Handler, route func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pattern := route(r)
+		if pattern == "" {
+			pattern = "unmatched"
+		}
+		method := requestMethod(r.Method)
+
+		start := time.Now()
+		record := &redRecord{}
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), redRecordKey{}, record)))
+		elapsed := time.Since(start).Seconds()
+
+		if recorder.hijacked {
+			httpRequests.WithLabelValues(pattern, method, "1xx").Inc()
+			websocketSessionDuration.WithLabelValues(pattern).Observe(elapsed)
+			record.mu.Lock()
+			code := record.closeCode
+			record.mu.Unlock()
+			if code == "" {
+				code = CloseServer
+			}
+			websocketCloses.WithLabelValues(pattern, code).Inc()
+			return
+		}
+
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		httpRequests.WithLabelValues(pattern, method, strconv.Itoa(status/100)+"xx").Inc()
+		httpRequestDuration.WithLabelValues(pattern, method).Observe(elapsed)
+	})
+}
+
+// requestMethod bounds the method label to the standard methods
+func requestMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "other"
+}
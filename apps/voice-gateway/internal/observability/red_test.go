@@ -0,0 +1,127 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func counterValue(t *testing.T, c *prometheus.CounterVec, labels ...string) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := c.WithLabelValues(labels...).Write(&m); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestRED_RecordsRoutesAndWebSocketCloses(t *testing.T) {
+	httpRequests.Reset()
+	websocketCloses.Reset()
+
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /red/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/red/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if r.URL.Query().Get("end") == "server" {
+			RecordWebSocketClose(r.Context(), nil)
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				RecordWebSocketClose(r.Context(), err)
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(RED(mux, func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/red/items/1", "/red/items/2", "/red/items/missing", "/red/nowhere"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+	if got := counterValue(t, httpRequests, "GET /red/items/{id}", "GET", "2xx"); got != 2 {
+		t.Errorf("Expected 2 successful requests on the route pattern, got %v", got)
+	}
+	if got := counterValue(t, httpRequests, "GET /red/items/{id}", "GET", "4xx"); got != 1 {
+		t.Errorf("Expected 1 failed request on the route pattern, got %v", got)
+	}
+	if got := counterValue(t, httpRequests, "unmatched", "GET", "4xx"); got < 1 {
+		t.Errorf("Expected unknown paths under one label, got %v", got)
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/red/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "bye"))
+	conn.Close()
+
+	conn, _, err = websocket.DefaultDialer.Dial(wsURL+"?end=server", nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	conn.ReadMessage()
+	conn.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if counterValue(t, websocketCloses, "/red/ws", "1001") == 1 && counterValue(t, websocketCloses, "/red/ws", CloseServer) == 1 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := counterValue(t, websocketCloses, "/red/ws", "1001"); got != 1 {
+		t.Errorf("Expected the client's close code recorded, got %v", got)
+	}
+	if got := counterValue(t, websocketCloses, "/red/ws", CloseServer); got != 1 {
+		t.Errorf("Expected a gateway-ended session recorded, got %v", got)
+	}
+	if got := counterValue(t, httpRequests, "/red/ws", "GET", "1xx"); got != 2 {
+		t.Errorf("Expected 2 upgraded requests, got %v", got)
+	}
+}
+
+func TestRED_ExposesDeadlines(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	server := httptest.NewServer(RED(handler, func(*http.Request) string { return "/calls/{callSid}/live" }))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/calls/CA1/live")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the write deadline to reach the connection, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,108 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// RegisterBulkhead exports a provider bulkhead's usage, read at scrape time
+// Call it once per bulkhead; a nil (unlimited) bulkhead is not exported
+func RegisterBulkhead(b *resilience.Bulkhead) {
+	if b == nil {
+		return
+	}
+	labels := prometheus.Labels{"provider": b.Name()}
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_bulkhead_capacity",
+		Help:        "Maximum concurrent requests allowed to a provider",
+		ConstLabels: labels,
+	}, func() float64 { return float64(b.Stats().Capacity) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_bulkhead_in_use",
+		Help:        "Requests currently holding a provider bulkhead slot",
+		ConstLabels: labels,
+	}, func() float64 { return float64(b.Stats().InUse) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_bulkhead_queued",
+		Help:        "Requests waiting for a provider bulkhead slot",
+		ConstLabels: labels,
+	}, func() float64 { return float64(b.Stats().Queued) })
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_bulkhead_rejections_total",
+		Help:        "Requests rejected by a provider bulkhead",
+		ConstLabels: prometheus.Labels{"provider": b.Name(), "reason": "queue_full"},
+	}, func() float64 { return float64(b.Stats().RejectedFull) })
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_bulkhead_rejections_total",
+		Help:        "Requests rejected by a provider bulkhead",
+		ConstLabels: prometheus.Labels{"provider": b.Name(), "reason": "queue_timeout"},
+	}, func() float64 { return float64(b.Stats().RejectedTimeout) })
+}
+
+// RegisterAdaptiveLimiter exports the call admission limiter's state, read at scrape time
+func RegisterAdaptiveLimiter(l *resilience.AdaptiveLimiter) {
+	if l == nil {
+		return
+	}
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "voice_gateway_admission_limit",
+		Help: "Current adaptive limit on concurrent calls",
+	}, func() float64 { return float64(l.Stats().Limit) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "voice_gateway_admission_in_flight",
+		Help: "Calls currently admitted by the adaptive limiter",
+	}, func() float64 { return float64(l.Stats().InFlight) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "voice_gateway_admission_queued",
+		Help: "New calls waiting for adaptive limiter capacity",
+	}, func() float64 { return float64(l.Stats().Queued) })
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_admission_shed_total",
+		Help:        "New calls shed to overflow by the adaptive limiter",
+		ConstLabels: prometheus.Labels{"reason": "limit"},
+	}, func() float64 { return float64(l.Stats().RejectedLimit) })
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_admission_shed_total",
+		Help:        "New calls shed to overflow by the adaptive limiter",
+		ConstLabels: prometheus.Labels{"reason": "queue_timeout"},
+	}, func() float64 { return float64(l.Stats().RejectedTimeout) })
+}
+
+var (
+	breakerFailureRateDesc = prometheus.NewDesc(
+		"voice_gateway_circuit_breaker_failure_rate",
+		"Percent of a circuit breaker's requests in its sliding window that failed",
+		[]string{"service"}, nil)
+	breakerTransitionDesc = prometheus.NewDesc(
+		"voice_gateway_circuit_breaker_last_transition_timestamp_seconds",
+		"Unix time of a circuit breaker's last state change",
+		[]string{"service"}, nil)
+)
+
+// breakerCollector reads every circuit breaker in a registry at scrape time
+type breakerCollector struct {
+	registry *resilience.BreakerRegistry
+}
+
+func (c breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- breakerFailureRateDesc
+	ch <- breakerTransitionDesc
+}
+
+func (c breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, b := range c.registry.Snapshots() {
+		ch <- prometheus.MustNewConstMetric(breakerFailureRateDesc, prometheus.GaugeValue, b.FailureRate, b.Name)
+		ch <- prometheus.MustNewConstMetric(breakerTransitionDesc, prometheus.GaugeValue,
+			float64(b.LastTransition.UnixNano())/1e9, b.Name)
+	}
+}
+
+// RegisterBreakers exports the failure rate and last transition of every
+// circuit breaker in r, alongside voice_gateway_circuit_breaker_state
+func RegisterBreakers(r *resilience.BreakerRegistry) {
+	prometheus.MustRegister(breakerCollector{registry: r})
+}
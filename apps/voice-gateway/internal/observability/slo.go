@@ -0,0 +1,48 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/lexiqai/voice-gateway/internal/slo"
+)
+
+// RegisterSLO exports a latency objective's events, burn rates and alert
+// state, read at scrape time. Call it once per tracker; nil is not exported
+func RegisterSLO(t *slo.Tracker) {
+	if t == nil {
+		return
+	}
+	name := t.Objective().Name
+
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_slo_events_total",
+		Help:        "Events measured against a latency SLO, by whether they met the threshold",
+		ConstLabels: prometheus.Labels{"slo": name, "result": "good"},
+	}, func() float64 { return float64(t.Stats().Good) })
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "voice_gateway_slo_events_total",
+		Help:        "Events measured against a latency SLO, by whether they met the threshold",
+		ConstLabels: prometheus.Labels{"slo": name, "result": "bad"},
+	}, func() float64 { return float64(t.Stats().Bad) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_slo_burn_rate",
+		Help:        "Rate a latency SLO's error budget is being spent (1 spends exactly the budget), by window",
+		ConstLabels: prometheus.Labels{"slo": name, "window": "short"},
+	}, func() float64 { return t.Stats().ShortBurnRate })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_slo_burn_rate",
+		Help:        "Rate a latency SLO's error budget is being spent (1 spends exactly the budget), by window",
+		ConstLabels: prometheus.Labels{"slo": name, "window": "long"},
+	}, func() float64 { return t.Stats().LongBurnRate })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "voice_gateway_slo_alerting",
+		Help:        "Whether a latency SLO's burn-rate alert is firing (1) or not (0)",
+		ConstLabels: prometheus.Labels{"slo": name},
+	}, func() float64 {
+		if t.Stats().Alerting {
+			return 1
+		}
+		return 0
+	})
+}
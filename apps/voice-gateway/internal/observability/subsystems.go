@@ -0,0 +1,331 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Subsystem states
+const (
+	StateHealthy   = "healthy"
+	StateUnhealthy = "unhealthy"
+	StateStale     = "stale"   // A worker stopped heartbeating
+	StatePending   = "pending" // A probe has not completed yet
+)
+
+const (
+	defaultFailureThreshold  = 3
+	defaultRecoveryThreshold = 2
+
+	// probeTimeout bounds each background dependency probe
+	probeTimeout = 5 * time.Second
+)
+
+// SubsystemConfig describes how a subsystem affects liveness and readiness
+type SubsystemConfig struct {
+	// Critical subsystems take the instance out of rotation (not ready) while unhealthy
+	Critical bool
+
+	// StaleAfter fails liveness when the subsystem has not heartbeated for this
+	// long, i.e. its worker goroutine is stuck; 0 means it never heartbeats
+	StaleAfter time.Duration
+
+	// FailureThreshold consecutive failures mark the subsystem unhealthy (default 3)
+	FailureThreshold int
+
+	// RecoveryThreshold consecutive successes mark it healthy again (default 2)
+	RecoveryThreshold int
+}
+
+// Subsystem tracks the health of one internal component
+// Methods are safe on a nil *Subsystem, so components can report unconditionally
+type Subsystem struct {
+	name string
+	cfg  SubsystemConfig
+
+	mu            sync.Mutex
+	state         string
+	since         time.Time
+	failures      int // Consecutive failures
+	successes     int // Consecutive successes
+	lastError     string
+	lastHeartbeat time.Time
+}
+
+// SubsystemStatus is the per-subsystem detail in health responses
+type SubsystemStatus struct {
+	Status              string `json:"status"`
+	Critical            bool   `json:"critical"`
+	Since               string `json:"since"`
+	ConsecutiveFailures int    `json:"consecutive_failures,omitempty"`
+	LastError           string `json:"last_error,omitempty"`
+	LastHeartbeat       string `json:"last_heartbeat,omitempty"`
+}
+
+// HealthReport is the body of /health/live and /health/ready
+type HealthReport struct {
+	Status     string                     `json:"status"` // ok or fail
+	Service    string                     `json:"service"`
+	Version    string                     `json:"version"`
+	Timestamp  string                     `json:"timestamp"`
+	Subsystems map[string]SubsystemStatus `json:"subsystems"`
+}
+
+// Success records a successful operation; a run of them recovers an unhealthy subsystem
+func (s *Subsystem) Success() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = 0
+	s.successes++
+	if s.state == StatePending || (s.state == StateUnhealthy && s.successes >= s.cfg.RecoveryThreshold) {
+		s.transition(StateHealthy)
+	}
+}
+
+// Failure records a failed operation; a run of them marks the subsystem unhealthy
+// Isolated failures do not flip the state, so a flaky dependency does not flap readiness
+func (s *Subsystem) Failure(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.successes = 0
+	s.failures++
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	if s.state == StatePending || (s.state == StateHealthy && s.failures >= s.cfg.FailureThreshold) {
+		s.transition(StateUnhealthy)
+	}
+}
+
+// Heartbeat records that the subsystem's worker is still running
+func (s *Subsystem) Heartbeat() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.lastHeartbeat = time.Now()
+	s.mu.Unlock()
+}
+
+// transition changes state; callers hold s.mu
+func (s *Subsystem) transition(state string) {
+	s.state = state
+	s.since = time.Now()
+	healthy := 0.0
+	if state == StateHealthy {
+		healthy = 1
+	}
+	subsystemHealth.WithLabelValues(s.name).Set(healthy)
+}
+
+// status returns the subsystem's current detail, accounting for missed heartbeats
+func (s *Subsystem) status(now time.Time) SubsystemStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := SubsystemStatus{
+		Status:              s.state,
+		Critical:            s.cfg.Critical,
+		Since:               s.since.UTC().Format(time.RFC3339),
+		ConsecutiveFailures: s.failures,
+		LastError:           s.lastError,
+	}
+	if s.cfg.StaleAfter > 0 {
+		status.LastHeartbeat = s.lastHeartbeat.UTC().Format(time.RFC3339)
+		if now.Sub(s.lastHeartbeat) > s.cfg.StaleAfter {
+			status.Status = StateStale
+		}
+	}
+	return status
+}
+
+// HealthRegistry aggregates subsystem health into liveness and readiness
+//
+// Liveness fails only when a worker stops heartbeating: restarting the process
+// is the fix. Readiness fails when liveness does or any critical subsystem is
+// unhealthy: the instance should stop receiving new calls but keep running
+type HealthRegistry struct {
+	service string
+	version string
+
+	mu         sync.RWMutex
+	subsystems map[string]*Subsystem
+	probes     []probe
+}
+
+type probe struct {
+	subsystem *Subsystem
+	check     HealthCheckFunc
+	interval  time.Duration
+}
+
+// NewHealthRegistry creates an empty registry
+func NewHealthRegistry(service, version string) *HealthRegistry {
+	return &HealthRegistry{
+		service:    service,
+		version:    version,
+		subsystems: make(map[string]*Subsystem),
+	}
+}
+
+// Register adds a subsystem that reports its own outcomes and heartbeats
+// It starts healthy; registering the same name again returns the existing subsystem
+func (h *HealthRegistry) Register(name string, cfg SubsystemConfig) *Subsystem {
+	return h.register(name, cfg, StateHealthy)
+}
+
+// AddCheck adds a subsystem probed in the background every interval by Run
+// It is pending (not ready, if critical) until the first probe completes
+func (h *HealthRegistry) AddCheck(name string, check HealthCheckFunc, interval time.Duration, cfg SubsystemConfig) *Subsystem {
+	s := h.register(name, cfg, StatePending)
+	h.mu.Lock()
+	h.probes = append(h.probes, probe{subsystem: s, check: check, interval: interval})
+	h.mu.Unlock()
+	return s
+}
+
+func (h *HealthRegistry) register(name string, cfg SubsystemConfig, initial string) *Subsystem {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.RecoveryThreshold <= 0 {
+		cfg.RecoveryThreshold = defaultRecoveryThreshold
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, ok := h.subsystems[name]; ok {
+		return s
+	}
+	s := &Subsystem{name: name, cfg: cfg, lastHeartbeat: time.Now()}
+	s.mu.Lock()
+	s.transition(initial)
+	s.mu.Unlock()
+	h.subsystems[name] = s
+	return s
+}
+
+// Run probes the checks added with AddCheck until ctx is cancelled
+func (h *HealthRegistry) Run(ctx context.Context) {
+	h.mu.RLock()
+	probes := append([]probe(nil), h.probes...)
+	h.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, p := range probes {
+		wg.Add(1)
+		go func(p probe) {
+			defer wg.Done()
+			ticker := time.NewTicker(p.interval)
+			defer ticker.Stop()
+			for {
+				runProbe(ctx, p)
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+}
+
+// runProbe runs one check and records its outcome
+func runProbe(ctx context.Context, p probe) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	p.subsystem.Heartbeat()
+	healthy, err := p.check(ctx)
+	if ctx.Err() == context.Canceled {
+		return
+	}
+	if err != nil || !healthy {
+		p.subsystem.Failure(err)
+		return
+	}
+	p.subsystem.Success()
+}
+
+// Live returns whether every worker is heartbeating, with per-subsystem detail
+func (h *HealthRegistry) Live() (bool, map[string]SubsystemStatus) {
+	statuses := h.statuses()
+	for _, status := range statuses {
+		if status.Status == StateStale {
+			return false, statuses
+		}
+	}
+	return true, statuses
+}
+
+// Ready returns whether the instance should receive new calls
+func (h *HealthRegistry) Ready() (bool, map[string]SubsystemStatus) {
+	statuses := h.statuses()
+	for _, status := range statuses {
+		if status.Status == StateStale || (status.Critical && status.Status != StateHealthy) {
+			return false, statuses
+		}
+	}
+	return true, statuses
+}
+
+func (h *HealthRegistry) statuses() map[string]SubsystemStatus {
+	h.mu.RLock()
+	subsystems := make([]*Subsystem, 0, len(h.subsystems))
+	for _, s := range h.subsystems {
+		subsystems = append(subsystems, s)
+	}
+	h.mu.RUnlock()
+
+	now := time.Now()
+	statuses := make(map[string]SubsystemStatus, len(subsystems))
+	for _, s := range subsystems {
+		statuses[s.name] = s.status(now)
+	}
+	return statuses
+}
+
+// LiveHandler serves /health/live: 503 only when the process should be restarted
+func (h *HealthRegistry) LiveHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, statuses := h.Live()
+		h.writeReport(w, ok, statuses)
+	}
+}
+
+// ReadyHandler serves /health/ready: 503 when new calls should go elsewhere
+// Probe results are cached, so the endpoint is cheap and never calls dependencies
+func (h *HealthRegistry) ReadyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ok, statuses := h.Ready()
+		h.writeReport(w, ok, statuses)
+	}
+}
+
+func (h *HealthRegistry) writeReport(w http.ResponseWriter, ok bool, statuses map[string]SubsystemStatus) {
+	report := HealthReport{
+		Status:     "ok",
+		Service:    h.service,
+		Version:    h.version,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Subsystems: statuses,
+	}
+	code := http.StatusOK
+	if !ok {
+		report.Status = "fail"
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,124 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSubsystem_FlappingProtection(t *testing.T) {
+	h := NewHealthRegistry("voice-gateway", "test")
+	s := h.Register("test_flapping", SubsystemConfig{Critical: true, FailureThreshold: 3, RecoveryThreshold: 2})
+
+	// Isolated failures do not take the instance out of rotation
+	s.Failure(errors.New("timeout"))
+	s.Failure(errors.New("timeout"))
+	s.Success()
+	s.Failure(errors.New("timeout"))
+	if ready, _ := h.Ready(); !ready {
+		t.Error("Expected ready after isolated failures")
+	}
+
+	s.Failure(errors.New("timeout"))
+	s.Failure(errors.New("timeout"))
+	ready, statuses := h.Ready()
+	if ready {
+		t.Error("Expected not ready after consecutive failures")
+	}
+	if got := statuses["test_flapping"]; got.Status != StateUnhealthy || got.LastError != "timeout" || got.ConsecutiveFailures != 3 {
+		t.Errorf("Unexpected status: %+v", got)
+	}
+
+	// One success is not enough to recover
+	s.Success()
+	if ready, _ := h.Ready(); ready {
+		t.Error("Expected still not ready after a single success")
+	}
+	s.Success()
+	if ready, _ := h.Ready(); !ready {
+		t.Error("Expected ready after recovery")
+	}
+}
+
+func TestHealthRegistry_LiveVsReady(t *testing.T) {
+	h := NewHealthRegistry("voice-gateway", "test")
+	worker := h.Register("test_worker", SubsystemConfig{StaleAfter: 50 * time.Millisecond})
+	dependency := h.Register("test_dependency", SubsystemConfig{Critical: true, FailureThreshold: 1})
+
+	// A failing dependency stops new calls but does not warrant a restart
+	dependency.Failure(errors.New("connection refused"))
+	if live, _ := h.Live(); !live {
+		t.Error("Expected live while only a dependency is failing")
+	}
+	if ready, _ := h.Ready(); ready {
+		t.Error("Expected not ready while a critical dependency is failing")
+	}
+
+	// A worker that stops heartbeating fails liveness
+	worker.Heartbeat()
+	time.Sleep(100 * time.Millisecond)
+	live, statuses := h.Live()
+	if live {
+		t.Error("Expected not live once a worker stops heartbeating")
+	}
+	if statuses["test_worker"].Status != StateStale {
+		t.Errorf("Expected stale worker, got %q", statuses["test_worker"].Status)
+	}
+}
+
+func TestHealthRegistry_Probes(t *testing.T) {
+	h := NewHealthRegistry("voice-gateway", "test")
+	h.AddCheck("test_probe", func(ctx context.Context) (bool, error) { return true, nil }, time.Hour, SubsystemConfig{Critical: true})
+
+	// Not ready until the first probe completes
+	if ready, _ := h.Ready(); ready {
+		t.Error("Expected not ready before the first probe")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		h.Run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if ready, _ := h.Ready(); ready {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected ready after the first probe")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestHealthRegistry_Handlers(t *testing.T) {
+	h := NewHealthRegistry("voice-gateway", "test")
+	h.Register("test_handler", SubsystemConfig{Critical: true, FailureThreshold: 1}).Failure(errors.New("down"))
+
+	rec := httptest.NewRecorder()
+	h.ReadyHandler()(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 from readiness, got %d", rec.Code)
+	}
+	var report HealthReport
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if report.Status != "fail" || report.Subsystems["test_handler"].Status != StateUnhealthy {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	rec = httptest.NewRecorder()
+	h.LiveHandler()(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 from liveness, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,314 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator/proto"
+)
+
+// Capability names an optional part of the Orchestrator API the gateway uses;
+// calls degrade rather than fail when the Orchestrator lacks one
+type Capability string
+
+const (
+	// CapabilityToolResults is the SubmitToolResults RPC for gateway-executed tools
+	CapabilityToolResults Capability = "tool_results"
+
+	// CapabilityCallMetadata is TextRequest.metadata (caller ID, CRM match)
+	CapabilityCallMetadata Capability = "call_metadata"
+
+	// CapabilityTokenCounts is TextResponse.total_tokens, used for usage metering
+	CapabilityTokenCounts Capability = "token_counts"
+)
+
+// capabilitySymbols maps each capability to the RPC ("Method") or field
+// ("Message.field") that provides it
+var capabilitySymbols = map[Capability]string{
+	CapabilityToolResults:  "SubmitToolResults",
+	CapabilityCallMetadata: "TextRequest.metadata",
+	CapabilityTokenCounts:  "TextResponse.total_tokens",
+}
+
+// handshakeTimeout bounds the capability handshake on connect
+const handshakeTimeout = 5 * time.Second
+
+// requiredMethod is the RPC the gateway cannot work without
+const requiredMethod = "ProcessText"
+
+// capabilityTTL is how long a handshake result is reused, so every call does
+// not repeat it but an Orchestrator deploy is picked up
+const capabilityTTL = 5 * time.Minute
+
+// reflectionMethods are the reflection RPCs tried in order; grpcio servers
+// only serve v1alpha, which has the same messages as v1
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// errNoReflection means the server has no reflection service
+var errNoReflection = errors.New("orchestrator does not serve gRPC reflection")
+
+// ErrUnsupported is returned for features the Orchestrator does not support
+var ErrUnsupported = errors.New("not supported by the orchestrator")
+
+// Capabilities is what a connected Orchestrator supports
+type Capabilities struct {
+	Version    string // Reported by HealthCheck, empty if unknown
+	Negotiated bool   // Read through reflection rather than assumed from the compiled proto
+
+	supported map[Capability]bool
+	expires   time.Time
+}
+
+// Supports reports whether the Orchestrator supports a capability
+func (c *Capabilities) Supports(capability Capability) bool {
+	return c.supported[capability]
+}
+
+// String lists the supported capabilities, for logs
+func (c *Capabilities) String() string {
+	var names []string
+	for capability, ok := range c.supported {
+		if ok {
+			names = append(names, string(capability))
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// compiledCapabilities assumes the Orchestrator serves the proto the gateway
+// was built with
+func compiledCapabilities() *Capabilities {
+	supported := make(map[Capability]bool, len(capabilitySymbols))
+	for capability := range capabilitySymbols {
+		supported[capability] = true
+	}
+	return &Capabilities{supported: supported}
+}
+
+// Every call session has its own client, so handshake results are shared by
+// Orchestrator address
+var (
+	capabilitiesMu sync.Mutex
+	capabilitiesBy = map[string]*Capabilities{}
+)
+
+// Capabilities returns what the Orchestrator supports
+func (c *OrchestratorClient) Capabilities() *Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.capabilities == nil {
+		return compiledCapabilities()
+	}
+	return c.capabilities
+}
+
+// Supports reports whether the Orchestrator supports a capability
+func (c *OrchestratorClient) Supports(capability Capability) bool {
+	return c.Capabilities().Supports(capability)
+}
+
+// negotiate runs the capability handshake, or reuses a recent result for the
+// same address. It fails only when the Orchestrator lacks ProcessText
+func (c *OrchestratorClient) negotiate(ctx context.Context) error {
+	address := c.config.OrchestratorURL
+
+	capabilitiesMu.Lock()
+	caps, ok := capabilitiesBy[address]
+	capabilitiesMu.Unlock()
+	if !ok || time.Now().After(caps.expires) {
+		var err error
+		if caps, err = c.handshake(ctx); err != nil {
+			return err
+		}
+		capabilitiesMu.Lock()
+		capabilitiesBy[address] = caps
+		capabilitiesMu.Unlock()
+	}
+
+	c.mu.Lock()
+	c.capabilities = caps
+	c.mu.Unlock()
+	return nil
+}
+
+// handshake reads the Orchestrator's API through reflection and its version
+// through HealthCheck
+func (c *OrchestratorClient) handshake(ctx context.Context) (*Capabilities, error) {
+	c.mu.RLock()
+	conn, client := c.conn, c.client
+	c.mu.RUnlock()
+
+	caps := compiledCapabilities()
+	if c.config.OrchestratorReflection {
+		symbols, err := reflectSymbols(ctx, conn, proto.CognitiveOrchestrator_ServiceDesc.ServiceName)
+		switch {
+		case err == nil:
+			if !symbols[requiredMethod] {
+				return nil, fmt.Errorf("orchestrator at %s does not implement %s", c.config.OrchestratorURL, requiredMethod)
+			}
+			for capability, symbol := range capabilitySymbols {
+				caps.supported[capability] = symbols[symbol]
+			}
+			caps.Negotiated = true
+		case errors.Is(err, errNoReflection):
+			log.Printf("Orchestrator at %s does not serve gRPC reflection, assuming the compiled API", c.config.OrchestratorURL)
+		default:
+			// A transient failure should not cost the call; the next handshake retries
+			log.Printf("Warning: Orchestrator capability handshake failed, assuming the compiled API: %v", err)
+		}
+	}
+
+	if health, err := client.HealthCheck(ctx, &proto.HealthRequest{}); err == nil {
+		caps.Version = health.Version
+	}
+
+	caps.expires = time.Now().Add(capabilityTTL)
+	if !caps.Negotiated {
+		// Retry sooner, in case reflection was only briefly unreachable
+		caps.expires = time.Now().Add(capabilityTTL / 5)
+	}
+	for capability := range capabilitySymbols {
+		observability.SetOrchestratorCapability(string(capability), caps.Supports(capability))
+	}
+	log.Printf("Orchestrator at %s: version=%q negotiated=%v capabilities=[%s]",
+		c.config.OrchestratorURL, caps.Version, caps.Negotiated, caps)
+	return caps, nil
+}
+
+// disable marks a capability unsupported after the Orchestrator rejected it
+// as unimplemented, for this client and later ones
+func (c *OrchestratorClient) disable(capability Capability) {
+	c.mu.Lock()
+	caps := c.capabilities
+	if caps == nil {
+		caps = compiledCapabilities()
+	}
+	updated := &Capabilities{
+		Version:    caps.Version,
+		Negotiated: caps.Negotiated,
+		supported:  make(map[Capability]bool, len(caps.supported)),
+		expires:    caps.expires,
+	}
+	for k, v := range caps.supported {
+		updated.supported[k] = v
+	}
+	updated.supported[capability] = false
+	c.capabilities = updated
+	c.mu.Unlock()
+
+	capabilitiesMu.Lock()
+	capabilitiesBy[c.config.OrchestratorURL] = updated
+	capabilitiesMu.Unlock()
+
+	observability.SetOrchestratorCapability(string(capability), false)
+	log.Printf("Warning: Orchestrator at %s does not implement %s, disabling it", c.config.OrchestratorURL, capability)
+}
+
+// isUnimplemented reports whether err is the server rejecting an unknown RPC
+func isUnimplemented(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// reflectSymbols returns the methods ("Method") of service and the fields
+// ("Message.field") of messages in its package, as served by reflection
+func reflectSymbols(ctx context.Context, conn grpc.ClientConnInterface, service string) (map[string]bool, error) {
+	for _, method := range reflectionMethods {
+		files, err := fileContainingSymbol(ctx, conn, method, service)
+		if isUnimplemented(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return symbolsOf(files, service), nil
+	}
+	return nil, errNoReflection
+}
+
+// fileContainingSymbol asks a reflection service for the files defining symbol
+func fileContainingSymbol(ctx context.Context, conn grpc.ClientConnInterface, method, symbol string) ([]*descriptorpb.FileDescriptorProto, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, method)
+	if err != nil {
+		return nil, err
+	}
+	req := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	resp := &reflectionpb.ServerReflectionResponse{}
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+
+	if e := resp.GetErrorResponse(); e != nil {
+		if codes.Code(e.ErrorCode) == codes.NotFound {
+			// Reflection works but the service is not registered
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reflection error: %s", e.ErrorMessage)
+	}
+	var files []*descriptorpb.FileDescriptorProto
+	for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := protobuf.Unmarshal(raw, file); err != nil {
+			return nil, fmt.Errorf("parse reflected descriptor: %w", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// symbolsOf collects the symbols of service ("package.Service") from files
+func symbolsOf(files []*descriptorpb.FileDescriptorProto, service string) map[string]bool {
+	pkg, name := "", service
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		pkg, name = service[:i], service[i+1:]
+	}
+
+	symbols := map[string]bool{}
+	for _, file := range files {
+		if file.GetPackage() != pkg {
+			continue
+		}
+		for _, svc := range file.GetService() {
+			if svc.GetName() != name {
+				continue
+			}
+			for _, method := range svc.GetMethod() {
+				symbols[method.GetName()] = true
+			}
+		}
+		for _, message := range file.GetMessageType() {
+			for _, field := range message.GetField() {
+				symbols[message.GetName()+"."+field.GetName()] = true
+			}
+		}
+	}
+	return symbols
+}
@@ -0,0 +1,192 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator/proto"
+)
+
+// fakeOrchestrator answers HealthCheck with a version
+type fakeOrchestrator struct {
+	proto.UnimplementedCognitiveOrchestratorServer
+}
+
+func (fakeOrchestrator) HealthCheck(context.Context, *proto.HealthRequest) (*proto.HealthResponse, error) {
+	return &proto.HealthResponse{Healthy: true, Version: "0.1.0"}, nil
+}
+
+// orchestratorFile describes a cognitive_orch.proto with the given methods and
+// TextRequest fields
+func orchestratorFile(methods []string, textRequestFields ...string) *descriptorpb.FileDescriptorProto {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    protobuf.String("cognitive_orch.proto"),
+		Package: protobuf.String("cognitive_orch"),
+		Service: []*descriptorpb.ServiceDescriptorProto{{Name: protobuf.String("CognitiveOrchestrator")}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: protobuf.String("TextRequest")},
+			{Name: protobuf.String("TextResponse"), Field: []*descriptorpb.FieldDescriptorProto{{Name: protobuf.String("total_tokens")}}},
+		},
+	}
+	for _, method := range methods {
+		file.Service[0].Method = append(file.Service[0].Method, &descriptorpb.MethodDescriptorProto{Name: protobuf.String(method)})
+	}
+	for _, field := range textRequestFields {
+		file.MessageType[0].Field = append(file.MessageType[0].Field, &descriptorpb.FieldDescriptorProto{Name: protobuf.String(field)})
+	}
+	return file
+}
+
+// reflectionService serves v1alpha reflection only, as grpcio servers do,
+// answering every request with file
+func reflectionService(t *testing.T, file *descriptorpb.FileDescriptorProto) *grpc.ServiceDesc {
+	raw, err := protobuf.Marshal(file)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	return &grpc.ServiceDesc{
+		ServiceName: "grpc.reflection.v1alpha.ServerReflection",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "ServerReflectionInfo",
+			ServerStreams: true,
+			ClientStreams: true,
+			Handler: func(_ interface{}, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&reflectionpb.ServerReflectionRequest{}); err != nil {
+					return err
+				}
+				return stream.SendMsg(&reflectionpb.ServerReflectionResponse{
+					MessageResponse: &reflectionpb.ServerReflectionResponse_FileDescriptorResponse{
+						FileDescriptorResponse: &reflectionpb.FileDescriptorResponse{FileDescriptorProto: [][]byte{raw}},
+					},
+				})
+			},
+		}},
+	}
+}
+
+// startOrchestrator serves a fake Orchestrator, with reflection when
+// reflection is set, and returns a config pointing at it
+func startOrchestrator(t *testing.T, reflection *grpc.ServiceDesc) *config.Config {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	server := grpc.NewServer()
+	proto.RegisterCognitiveOrchestratorServer(server, fakeOrchestrator{})
+	if reflection != nil {
+		server.RegisterService(reflection, struct{}{})
+	}
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	return &config.Config{
+		OrchestratorURL:        lis.Addr().String(),
+		OrchestratorTimeout:    5,
+		OrchestratorReflection: true,
+	}
+}
+
+func TestNewOrchestratorClient_NegotiatesCapabilities(t *testing.T) {
+	cfg := startOrchestrator(t, reflectionService(t, orchestratorFile([]string{"ProcessText", "HealthCheck"}, "text", "firm_id")))
+
+	client, err := NewOrchestratorClient(cfg)
+	if err != nil {
+		t.Fatalf("NewOrchestratorClient failed: %v", err)
+	}
+	defer client.Close()
+
+	caps := client.Capabilities()
+	if !caps.Negotiated || caps.Version != "0.1.0" {
+		t.Errorf("Expected negotiated capabilities from version 0.1.0, got negotiated=%v version=%q", caps.Negotiated, caps.Version)
+	}
+	if client.Supports(CapabilityToolResults) || client.Supports(CapabilityCallMetadata) {
+		t.Error("Expected tool results and call metadata to be unsupported")
+	}
+	if !client.Supports(CapabilityTokenCounts) {
+		t.Error("Expected token counts to be supported")
+	}
+
+	if _, err := client.OpenToolResultStream("conv-1"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported opening a tool result stream, got %v", err)
+	}
+}
+
+func TestNewOrchestratorClient_WithoutReflection(t *testing.T) {
+	cfg := startOrchestrator(t, nil)
+
+	client, err := NewOrchestratorClient(cfg)
+	if err != nil {
+		t.Fatalf("NewOrchestratorClient failed: %v", err)
+	}
+	defer client.Close()
+
+	caps := client.Capabilities()
+	if caps.Negotiated {
+		t.Error("Expected capabilities to be assumed without reflection")
+	}
+	for capability := range capabilitySymbols {
+		if !caps.Supports(capability) {
+			t.Errorf("Expected the compiled API to support %s", capability)
+		}
+	}
+
+	// The server rejects the RPC it does not implement; later calls skip it
+	stream, err := client.OpenToolResultStream("conv-1")
+	if err != nil {
+		t.Fatalf("OpenToolResultStream failed: %v", err)
+	}
+	_ = stream.Send("send_sms", &ToolResult{CallID: "call-1", Success: true})
+	if _, err := stream.Close(); err == nil {
+		t.Error("Expected closing the stream to fail")
+	}
+	if client.Supports(CapabilityToolResults) {
+		t.Error("Expected tool results to be disabled after Unimplemented")
+	}
+
+	// Clients created later reuse the result
+	later, err := NewOrchestratorClient(cfg)
+	if err != nil {
+		t.Fatalf("NewOrchestratorClient failed: %v", err)
+	}
+	defer later.Close()
+	if later.Supports(CapabilityToolResults) {
+		t.Error("Expected later clients to share the disabled capability")
+	}
+}
+
+func TestNewOrchestratorClient_RequiresProcessText(t *testing.T) {
+	cfg := startOrchestrator(t, reflectionService(t, orchestratorFile([]string{"HealthCheck"})))
+
+	if _, err := NewOrchestratorClient(cfg); err == nil {
+		t.Error("Expected error for an Orchestrator without ProcessText")
+	}
+}
+
+func TestSymbolsOf(t *testing.T) {
+	other := &descriptorpb.FileDescriptorProto{
+		Package: protobuf.String("google.protobuf"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: protobuf.String("TextRequest"), Field: []*descriptorpb.FieldDescriptorProto{{Name: protobuf.String("metadata")}}},
+		},
+	}
+	symbols := symbolsOf([]*descriptorpb.FileDescriptorProto{orchestratorFile([]string{"ProcessText"}, "text"), other}, "cognitive_orch.CognitiveOrchestrator")
+
+	for _, symbol := range []string{"ProcessText", "TextRequest.text", "TextResponse.total_tokens"} {
+		if !symbols[symbol] {
+			t.Errorf("Expected symbol %s", symbol)
+		}
+	}
+	if symbols["TextRequest.metadata"] {
+		t.Error("Expected fields of other packages to be ignored")
+	}
+}
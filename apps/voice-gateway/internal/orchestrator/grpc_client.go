@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/lexiqai/voice-gateway/internal/chaos"
 	"github.com/lexiqai/voice-gateway/internal/config"
 	"github.com/lexiqai/voice-gateway/internal/orchestrator/proto"
 	"github.com/lexiqai/voice-gateway/internal/observability"
@@ -26,6 +26,14 @@ type OrchestratorClient struct {
 	mu            sync.RWMutex
 	isConnected   bool
 	circuitBreaker *resilience.CircuitBreaker
+	retryable     resilience.IsRetryableError
+
+	// What the Orchestrator supports, from the capability handshake
+	capabilities *Capabilities
+
+	// Optional second connection raced against slow first responses
+	hedge      *OrchestratorClient
+	hedgeDelay time.Duration
 }
 
 // NewOrchestratorClient creates a new Orchestrator gRPC client
@@ -33,13 +41,30 @@ func NewOrchestratorClient(cfg *config.Config) (*OrchestratorClient, error) {
 	client := &OrchestratorClient{
 		config:      cfg,
 		isConnected: false,
+		circuitBreaker: resilience.Breakers.Get("orchestrator"), // Shared by every call
 	}
 
+	// Codes were validated when the config loaded
+	retryCodes, _ := resilience.ParseGRPCCodes(cfg.RetryGRPCCodes)
+	client.retryable = resilience.RetryableErrors(retryCodes)
+
 	// Connect to Orchestrator
 	if err := client.connect(); err != nil {
 		return nil, fmt.Errorf("failed to connect to orchestrator: %w", err)
 	}
 
+	// Learn which optional RPCs and fields the Orchestrator supports
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	if err := client.negotiate(ctx); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	if cfg.OrchestratorHedgeDelayMs > 0 {
+		client.enableHedging(cfg)
+	}
+
 	return client, nil
 }
 
@@ -71,6 +96,12 @@ func (c *OrchestratorClient) connect() error {
 		PermitWithoutStream: true,
 	}))
 
+	// Injected latency for resilience testing; a no-op unless chaos mode is on
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(chaos.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(chaos.StreamClientInterceptor()),
+	)
+
 	// Connection timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.config.OrchestratorTimeout)*time.Second)
 	defer cancel()
@@ -90,14 +121,43 @@ func (c *OrchestratorClient) connect() error {
 }
 
 // ProcessTextStream sends text to the Orchestrator and streams responses back
+// metadata carries call context (caller ID, CRM match) and may be nil
+// With hedging enabled, a request with no response after the hedge delay is
+// also sent over the hedge connection and the first to answer is used
 func (c *OrchestratorClient) ProcessTextStream(
 	ctx context.Context,
 	conversationID string,
 	text string,
 	userID string,
 	firmID string,
+	metadata map[string]string,
+) (<-chan *OrchestratorResponse, error) {
+	open := func(client *OrchestratorClient) streamFunc {
+		return func(ctx context.Context) (<-chan *OrchestratorResponse, error) {
+			return client.processTextStream(ctx, conversationID, text, userID, firmID, metadata)
+		}
+	}
+	if c.hedge == nil {
+		return open(c)(ctx)
+	}
+	return hedgeStream(ctx, open(c), open(c.hedge), c.hedgeDelay, sharedHedgeBudget(c.config))
+}
+
+// processTextStream runs one ProcessText call on this client's connection
+func (c *OrchestratorClient) processTextStream(
+	ctx context.Context,
+	conversationID string,
+	text string,
+	userID string,
+	firmID string,
+	metadata map[string]string,
 ) (<-chan *OrchestratorResponse, error) {
 
+	// Older Orchestrators do not know call metadata
+	if !c.Supports(CapabilityCallMetadata) {
+		metadata = nil
+	}
+
 	// Create request
 	req := &proto.TextRequest{
 		ConversationId: conversationID,
@@ -106,12 +166,18 @@ func (c *OrchestratorClient) ProcessTextStream(
 		FirmId:         firmID,
 		IncludeRag:     true,
 		ToolsEnabled:   true,
+		Metadata:       metadata,
 		// Model can be left empty to use default
 	}
 
+	// Hold a bulkhead slot for the life of the stream
+	release, err := sharedBulkhead(c.config).Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Use circuit breaker to protect the call
 	var stream proto.CognitiveOrchestrator_ProcessTextClient
-	var err error
 
 	err = c.circuitBreaker.Call(func() error {
 		// Retry logic with exponential backoff
@@ -121,9 +187,15 @@ func (c *OrchestratorClient) ProcessTextStream(
 			MaxBackoff:       5 * time.Second,
 			BackoffMultiplier: 2.0,
 			Jitter:           true,
+			OnAttempt: func(attempt int, err error, delay time.Duration) {
+				if delay > 0 {
+					observability.RecordRetry("orchestrator")
+				}
+			},
 		}
 
-		err = resilience.Retry(func() error {
+		// Stop retrying once the turn is abandoned (stall watchdog, losing hedge)
+		err = resilience.RetryWithContext(ctx, func() error {
 			// Check connection and reconnect if needed
 			c.mu.RLock()
 			connected := c.isConnected
@@ -147,7 +219,7 @@ func (c *OrchestratorClient) ProcessTextStream(
 			var callErr error
 			stream, callErr = client.ProcessText(ctx, req)
 			return callErr
-		}, retryConfig, resilience.IsRetryableNetworkError)
+		}, retryConfig, c.retryable)
 
 		return err
 	})
@@ -159,6 +231,7 @@ func (c *OrchestratorClient) ProcessTextStream(
 	}
 
 	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to call ProcessText: %w", err)
 	}
 
@@ -168,6 +241,8 @@ func (c *OrchestratorClient) ProcessTextStream(
 	// Start goroutine to receive streaming responses
 	go func() {
 		defer close(responseChan)
+		defer release()
+		defer observability.RecoverPanic("orchestrator", "process_text_stream", nil)
 
 		for {
 			select {
@@ -179,7 +254,7 @@ func (c *OrchestratorClient) ProcessTextStream(
 				resp, err := stream.Recv()
 				if err != nil {
 					// Check if error is retryable
-					if isRetryableError(err) {
+					if c.retryable(err) {
 						log.Printf("Retryable error receiving from ProcessText stream: %v", err)
 						// Could implement reconnection logic here if needed
 					} else {
@@ -261,6 +336,10 @@ func (c *OrchestratorClient) HealthCheck(ctx context.Context) (bool, error) {
 
 // Close closes the gRPC connection
 func (c *OrchestratorClient) Close() error {
+	if c.hedge != nil {
+		_ = c.hedge.Close()
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -281,35 +360,3 @@ func (c *OrchestratorClient) IsConnected() bool {
 	defer c.mu.RUnlock()
 	return c.isConnected
 }
-
-// isRetryableError checks if an error is retryable
-func isRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-	
-	// Check for gRPC status codes that are retryable
-	errStr := strings.ToLower(err.Error())
-	
-	// Connection errors
-	if strings.Contains(errStr, "connection refused") || 
-	   strings.Contains(errStr, "connection reset") ||
-	   strings.Contains(errStr, "connection closed") ||
-	   strings.Contains(errStr, "transport is closing") ||
-	   strings.Contains(errStr, "unavailable") {
-		return true
-	}
-	
-	// Timeout errors
-	if strings.Contains(errStr, "deadline exceeded") || 
-	   strings.Contains(errStr, "context deadline exceeded") {
-		return true
-	}
-	
-	// Resource exhaustion (may be temporary)
-	if strings.Contains(errStr, "resource exhausted") {
-		return true
-	}
-	
-	return false
-}
@@ -0,0 +1,125 @@
+package orchestrator
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// streamFunc opens one ProcessText response stream
+type streamFunc func(ctx context.Context) (<-chan *OrchestratorResponse, error)
+
+// enableHedging opens the hedge connection; without ORCHESTRATOR_HEDGE_URL it
+// is a second connection to ORCHESTRATOR_URL, which a load balancer may route
+// to a different backend
+func (c *OrchestratorClient) enableHedging(cfg *config.Config) {
+	hedgeCfg := *cfg
+	hedgeCfg.OrchestratorHedgeDelayMs = 0
+	if cfg.OrchestratorHedgeURL != "" {
+		hedgeCfg.OrchestratorURL = cfg.OrchestratorHedgeURL
+	}
+
+	hedge, err := NewOrchestratorClient(&hedgeCfg)
+	if err != nil {
+		log.Printf("Warning: Failed to connect hedge Orchestrator at %s, hedging disabled: %v", hedgeCfg.OrchestratorURL, err)
+		return
+	}
+	c.hedge = hedge
+	c.hedgeDelay = time.Duration(cfg.OrchestratorHedgeDelayMs) * time.Millisecond
+}
+
+// hedgeStream opens primary and, if it has not responded within delay and the
+// budget allows, secondary too; the first stream to respond is forwarded and
+// the other is cancelled
+func hedgeStream(ctx context.Context, primary, secondary streamFunc, delay time.Duration, budget *resilience.RetryBudget) (<-chan *OrchestratorResponse, error) {
+	budget.Deposit()
+
+	primaryCtx, cancelPrimary := context.WithCancel(ctx)
+	primaryChan, err := primary(primaryCtx)
+	if err != nil {
+		cancelPrimary()
+		return nil, err
+	}
+
+	responseChan := make(chan *OrchestratorResponse, 100)
+	go func() {
+		defer close(responseChan)
+		defer cancelPrimary()
+		defer observability.RecoverPanic("orchestrator", "hedge", nil)
+		cancelSecondary := context.CancelFunc(func() {})
+		defer func() { cancelSecondary() }()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		hedgeTimer := timer.C
+
+		// Wait for the first response from either stream
+		var secondaryChan <-chan *OrchestratorResponse
+		var winner <-chan *OrchestratorResponse
+		var first *OrchestratorResponse
+		for winner == nil {
+			select {
+			case resp, ok := <-primaryChan:
+				if !ok {
+					if secondaryChan == nil {
+						return
+					}
+					primaryChan = nil
+					continue
+				}
+				winner, first = primaryChan, resp
+				cancelSecondary()
+
+			case resp, ok := <-secondaryChan:
+				if !ok {
+					if primaryChan == nil {
+						return
+					}
+					secondaryChan = nil
+					continue
+				}
+				winner, first = secondaryChan, resp
+				cancelPrimary()
+				observability.RecordOrchestratorHedge("won")
+
+			case <-hedgeTimer:
+				hedgeTimer = nil
+				if !budget.TryWithdraw() {
+					observability.RecordOrchestratorHedge("budget_exhausted")
+					continue
+				}
+				secondaryCtx, cancel := context.WithCancel(ctx)
+				stream, err := secondary(secondaryCtx)
+				if err != nil {
+					cancel()
+					log.Printf("Hedged ProcessText request failed: %v", err)
+					continue
+				}
+				secondaryChan, cancelSecondary = stream, cancel
+				observability.RecordOrchestratorHedge("sent")
+
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		// Forward the winning stream
+		for resp := first; ; {
+			select {
+			case responseChan <- resp:
+			case <-ctx.Done():
+				return
+			}
+			var ok bool
+			if resp, ok = <-winner; !ok {
+				return
+			}
+		}
+	}()
+
+	return responseChan, nil
+}
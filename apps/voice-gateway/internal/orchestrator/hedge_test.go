@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// delayedStream answers with text after delay, or never if delay is negative,
+// and reports cancellation on cancelled
+func delayedStream(text string, delay time.Duration, cancelled chan<- struct{}) streamFunc {
+	return func(ctx context.Context) (<-chan *OrchestratorResponse, error) {
+		ch := make(chan *OrchestratorResponse, 2)
+		go func() {
+			defer close(ch)
+			var answer <-chan time.Time
+			if delay >= 0 {
+				answer = time.After(delay)
+			}
+			select {
+			case <-answer:
+				ch <- &OrchestratorResponse{TextChunk: text}
+				ch <- &OrchestratorResponse{IsDone: true}
+			case <-ctx.Done():
+				if cancelled != nil {
+					close(cancelled)
+				}
+			}
+		}()
+		return ch, nil
+	}
+}
+
+func collect(t *testing.T, ch <-chan *OrchestratorResponse) []string {
+	t.Helper()
+	var chunks []string
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case resp, ok := <-ch:
+			if !ok {
+				return chunks
+			}
+			if resp.TextChunk != "" {
+				chunks = append(chunks, resp.TextChunk)
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for the stream to close")
+		}
+	}
+}
+
+func TestHedgeStream_HedgeWinsSlowPrimary(t *testing.T) {
+	primaryCancelled := make(chan struct{})
+	ch, err := hedgeStream(context.Background(),
+		delayedStream("primary", -1, primaryCancelled),
+		delayedStream("hedge", 0, nil),
+		20*time.Millisecond, resilience.NewRetryBudget(0.1, 1))
+	if err != nil {
+		t.Fatalf("hedgeStream failed: %v", err)
+	}
+
+	if chunks := collect(t, ch); len(chunks) != 1 || chunks[0] != "hedge" {
+		t.Errorf("Expected the hedge's response, got %v", chunks)
+	}
+	select {
+	case <-primaryCancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the slow primary to be cancelled")
+	}
+}
+
+func TestHedgeStream_FastPrimaryNotHedged(t *testing.T) {
+	hedged := false
+	secondary := func(ctx context.Context) (<-chan *OrchestratorResponse, error) {
+		hedged = true
+		return nil, errors.New("unexpected hedge")
+	}
+	ch, _ := hedgeStream(context.Background(), delayedStream("primary", 0, nil), secondary, 500*time.Millisecond, resilience.NewRetryBudget(0.1, 1))
+
+	if chunks := collect(t, ch); len(chunks) != 1 || chunks[0] != "primary" {
+		t.Errorf("Expected the primary's response, got %v", chunks)
+	}
+	if hedged {
+		t.Error("Expected no hedge for a fast primary")
+	}
+}
+
+func TestHedgeStream_BudgetExhausted(t *testing.T) {
+	budget := resilience.NewRetryBudget(0, 0)
+	budget.TryWithdraw() // Spend the single starting token
+
+	hedged := false
+	secondary := func(ctx context.Context) (<-chan *OrchestratorResponse, error) {
+		hedged = true
+		return delayedStream("hedge", 0, nil)(ctx)
+	}
+	ch, _ := hedgeStream(context.Background(), delayedStream("primary", 50*time.Millisecond, nil), secondary, 10*time.Millisecond, budget)
+
+	if chunks := collect(t, ch); len(chunks) != 1 || chunks[0] != "primary" {
+		t.Errorf("Expected to wait for the primary, got %v", chunks)
+	}
+	if hedged {
+		t.Error("Expected no hedge once the budget is exhausted")
+	}
+}
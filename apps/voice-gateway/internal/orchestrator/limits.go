@@ -0,0 +1,38 @@
+package orchestrator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// Every call session has its own client, so the hedge budget and the stream
+// bulkhead are process-wide
+var (
+	hedgeBudgetOnce sync.Once
+	hedgeBudget     *resilience.RetryBudget
+
+	bulkheadOnce sync.Once
+	bulkhead     *resilience.Bulkhead
+)
+
+// sharedHedgeBudget returns the budget all clients draw hedged requests from
+func sharedHedgeBudget(cfg *config.Config) *resilience.RetryBudget {
+	hedgeBudgetOnce.Do(func() {
+		hedgeBudget = resilience.NewRetryBudget(cfg.OrchestratorHedgeBudgetRatio, cfg.OrchestratorHedgeMinPerSecond)
+	})
+	return hedgeBudget
+}
+
+// sharedBulkhead returns the bulkhead bounding open ProcessText streams
+func sharedBulkhead(cfg *config.Config) *resilience.Bulkhead {
+	bulkheadOnce.Do(func() {
+		bulkhead = resilience.NewBulkhead("orchestrator", cfg.OrchestratorMaxConcurrentStreams, cfg.OrchestratorBulkheadQueue,
+			time.Duration(cfg.BulkheadQueueTimeoutMs)*time.Millisecond)
+		observability.RegisterBulkhead(bulkhead)
+	})
+	return bulkhead
+}
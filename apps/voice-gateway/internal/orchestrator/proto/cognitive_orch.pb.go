@@ -24,13 +24,14 @@ const (
 // Request to process text input
 type TextRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"` // Optional: existing conversation ID
-	Text           string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                           // User's transcribed text
-	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                         // User identifier
-	FirmId         string                 `protobuf:"bytes,4,opt,name=firm_id,json=firmId,proto3" json:"firm_id,omitempty"`                         // Firm/tenant identifier
-	IncludeRag     bool                   `protobuf:"varint,5,opt,name=include_rag,json=includeRag,proto3" json:"include_rag,omitempty"`            // Enable RAG context retrieval
-	ToolsEnabled   bool                   `protobuf:"varint,6,opt,name=tools_enabled,json=toolsEnabled,proto3" json:"tools_enabled,omitempty"`      // Enable tool/function calling
-	Model          string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`                                         // Optional: model override (e.g., "azure/gpt-4o")
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`                                         // Optional: existing conversation ID
+	Text           string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                                                                   // User's transcribed text
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                                 // User identifier
+	FirmId         string                 `protobuf:"bytes,4,opt,name=firm_id,json=firmId,proto3" json:"firm_id,omitempty"`                                                                 // Firm/tenant identifier
+	IncludeRag     bool                   `protobuf:"varint,5,opt,name=include_rag,json=includeRag,proto3" json:"include_rag,omitempty"`                                                    // Enable RAG context retrieval
+	ToolsEnabled   bool                   `protobuf:"varint,6,opt,name=tools_enabled,json=toolsEnabled,proto3" json:"tools_enabled,omitempty"`                                              // Enable tool/function calling
+	Model          string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`                                                                                 // Optional: model override (e.g., "azure/gpt-4o")
+	Metadata       map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Optional: call context (caller ID, CRM match), sent on the first turn
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -114,6 +115,13 @@ func (x *TextRequest) GetModel() string {
 	return ""
 }
 
+func (x *TextRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 // Streaming response chunks
 type TextResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -383,6 +391,112 @@ func (x *ToolResult) GetErrorMessage() string {
 	return ""
 }
 
+// Result of a gateway-executed tool, tied to its conversation
+type ToolResultSubmission struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ToolName       string                 `protobuf:"bytes,2,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`
+	Result         *ToolResult            `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ToolResultSubmission) Reset() {
+	*x = ToolResultSubmission{}
+	mi := &file_cognitive_orch_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolResultSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolResultSubmission) ProtoMessage() {}
+
+func (x *ToolResultSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_cognitive_orch_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolResultSubmission.ProtoReflect.Descriptor instead.
+func (*ToolResultSubmission) Descriptor() ([]byte, []int) {
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ToolResultSubmission) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ToolResultSubmission) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+func (x *ToolResultSubmission) GetResult() *ToolResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Acknowledgement once the gateway closes its result stream
+type ToolResultAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Received      int32                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"` // Number of results accepted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolResultAck) Reset() {
+	*x = ToolResultAck{}
+	mi := &file_cognitive_orch_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolResultAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolResultAck) ProtoMessage() {}
+
+func (x *ToolResultAck) ProtoReflect() protoreflect.Message {
+	mi := &file_cognitive_orch_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolResultAck.ProtoReflect.Descriptor instead.
+func (*ToolResultAck) Descriptor() ([]byte, []int) {
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ToolResultAck) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
 // Error information
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -395,7 +509,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_cognitive_orch_proto_msgTypes[4]
+	mi := &file_cognitive_orch_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -407,7 +521,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[4]
+	mi := &file_cognitive_orch_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +534,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{4}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Error) GetCode() string {
@@ -454,7 +568,7 @@ type StateRequest struct {
 
 func (x *StateRequest) Reset() {
 	*x = StateRequest{}
-	mi := &file_cognitive_orch_proto_msgTypes[5]
+	mi := &file_cognitive_orch_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -466,7 +580,7 @@ func (x *StateRequest) String() string {
 func (*StateRequest) ProtoMessage() {}
 
 func (x *StateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[5]
+	mi := &file_cognitive_orch_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -479,7 +593,7 @@ func (x *StateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateRequest.ProtoReflect.Descriptor instead.
 func (*StateRequest) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{5}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *StateRequest) GetConversationId() string {
@@ -505,7 +619,7 @@ type StateResponse struct {
 
 func (x *StateResponse) Reset() {
 	*x = StateResponse{}
-	mi := &file_cognitive_orch_proto_msgTypes[6]
+	mi := &file_cognitive_orch_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -517,7 +631,7 @@ func (x *StateResponse) String() string {
 func (*StateResponse) ProtoMessage() {}
 
 func (x *StateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[6]
+	mi := &file_cognitive_orch_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,7 +644,7 @@ func (x *StateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateResponse.ProtoReflect.Descriptor instead.
 func (*StateResponse) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{6}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *StateResponse) GetConversationId() string {
@@ -596,7 +710,7 @@ type Message struct {
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_cognitive_orch_proto_msgTypes[7]
+	mi := &file_cognitive_orch_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +722,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[7]
+	mi := &file_cognitive_orch_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +735,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{7}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Message) GetRole() string {
@@ -669,7 +783,7 @@ type ClearRequest struct {
 
 func (x *ClearRequest) Reset() {
 	*x = ClearRequest{}
-	mi := &file_cognitive_orch_proto_msgTypes[8]
+	mi := &file_cognitive_orch_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -681,7 +795,7 @@ func (x *ClearRequest) String() string {
 func (*ClearRequest) ProtoMessage() {}
 
 func (x *ClearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[8]
+	mi := &file_cognitive_orch_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,7 +808,7 @@ func (x *ClearRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearRequest.ProtoReflect.Descriptor instead.
 func (*ClearRequest) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{8}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ClearRequest) GetConversationId() string {
@@ -715,7 +829,7 @@ type ClearResponse struct {
 
 func (x *ClearResponse) Reset() {
 	*x = ClearResponse{}
-	mi := &file_cognitive_orch_proto_msgTypes[9]
+	mi := &file_cognitive_orch_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -727,7 +841,7 @@ func (x *ClearResponse) String() string {
 func (*ClearResponse) ProtoMessage() {}
 
 func (x *ClearResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[9]
+	mi := &file_cognitive_orch_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,7 +854,7 @@ func (x *ClearResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearResponse.ProtoReflect.Descriptor instead.
 func (*ClearResponse) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{9}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ClearResponse) GetSuccess() bool {
@@ -766,7 +880,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_cognitive_orch_proto_msgTypes[10]
+	mi := &file_cognitive_orch_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -778,7 +892,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[10]
+	mi := &file_cognitive_orch_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,7 +905,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{10}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{12}
 }
 
 // Health check response
@@ -806,7 +920,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_cognitive_orch_proto_msgTypes[11]
+	mi := &file_cognitive_orch_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -818,7 +932,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cognitive_orch_proto_msgTypes[11]
+	mi := &file_cognitive_orch_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -831,7 +945,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_cognitive_orch_proto_rawDescGZIP(), []int{11}
+	return file_cognitive_orch_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *HealthResponse) GetHealthy() bool {
@@ -859,7 +973,7 @@ var File_cognitive_orch_proto protoreflect.FileDescriptor
 
 const file_cognitive_orch_proto_rawDesc = "" +
 	"\n" +
-	"\x14cognitive_orch.proto\x12\x0ecognitive_orch\"\xd8\x01\n" +
+	"\x14cognitive_orch.proto\x12\x0ecognitive_orch\"\xdc\x02\n" +
 	"\vTextRequest\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x12\n" +
 	"\x04text\x18\x02 \x01(\tR\x04text\x12\x17\n" +
@@ -868,7 +982,11 @@ const file_cognitive_orch_proto_rawDesc = "" +
 	"\vinclude_rag\x18\x05 \x01(\bR\n" +
 	"includeRag\x12#\n" +
 	"\rtools_enabled\x18\x06 \x01(\bR\ftoolsEnabled\x12\x14\n" +
-	"\x05model\x18\a \x01(\tR\x05model\"\xc6\x02\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12E\n" +
+	"\bmetadata\x18\b \x03(\v2).cognitive_orch.TextRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x02\n" +
 	"\fTextResponse\x12\x1f\n" +
 	"\n" +
 	"text_chunk\x18\x01 \x01(\tH\x00R\ttextChunk\x127\n" +
@@ -890,7 +1008,13 @@ const file_cognitive_orch_proto_rawDesc = "" +
 	"\vresult_json\x18\x02 \x01(\tR\n" +
 	"resultJson\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"X\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\x90\x01\n" +
+	"\x14ToolResultSubmission\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1b\n" +
+	"\ttool_name\x18\x02 \x01(\tR\btoolName\x122\n" +
+	"\x06result\x18\x03 \x01(\v2\x1a.cognitive_orch.ToolResultR\x06result\"+\n" +
+	"\rToolResultAck\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x05R\breceived\"X\n" +
 	"\x05Error\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
@@ -923,12 +1047,13 @@ const file_cognitive_orch_proto_rawDesc = "" +
 	"\x0eHealthResponse\x12\x18\n" +
 	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\tR\aversion2\xd8\x02\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion2\xb4\x03\n" +
 	"\x15CognitiveOrchestrator\x12J\n" +
 	"\vProcessText\x12\x1b.cognitive_orch.TextRequest\x1a\x1c.cognitive_orch.TextResponse0\x01\x12S\n" +
 	"\x14GetConversationState\x12\x1c.cognitive_orch.StateRequest\x1a\x1d.cognitive_orch.StateResponse\x12P\n" +
 	"\x11ClearConversation\x12\x1c.cognitive_orch.ClearRequest\x1a\x1d.cognitive_orch.ClearResponse\x12L\n" +
-	"\vHealthCheck\x12\x1d.cognitive_orch.HealthRequest\x1a\x1e.cognitive_orch.HealthResponseB>Z<github.com/lexiqai/voice-gateway/internal/orchestrator/protob\x06proto3"
+	"\vHealthCheck\x12\x1d.cognitive_orch.HealthRequest\x1a\x1e.cognitive_orch.HealthResponse\x12Z\n" +
+	"\x11SubmitToolResults\x12$.cognitive_orch.ToolResultSubmission\x1a\x1d.cognitive_orch.ToolResultAck(\x01B>Z<github.com/lexiqai/voice-gateway/internal/orchestrator/protob\x06proto3"
 
 var (
 	file_cognitive_orch_proto_rawDescOnce sync.Once
@@ -942,39 +1067,46 @@ func file_cognitive_orch_proto_rawDescGZIP() []byte {
 	return file_cognitive_orch_proto_rawDescData
 }
 
-var file_cognitive_orch_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_cognitive_orch_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_cognitive_orch_proto_goTypes = []any{
-	(*TextRequest)(nil),    // 0: cognitive_orch.TextRequest
-	(*TextResponse)(nil),   // 1: cognitive_orch.TextResponse
-	(*ToolCall)(nil),       // 2: cognitive_orch.ToolCall
-	(*ToolResult)(nil),     // 3: cognitive_orch.ToolResult
-	(*Error)(nil),          // 4: cognitive_orch.Error
-	(*StateRequest)(nil),   // 5: cognitive_orch.StateRequest
-	(*StateResponse)(nil),  // 6: cognitive_orch.StateResponse
-	(*Message)(nil),        // 7: cognitive_orch.Message
-	(*ClearRequest)(nil),   // 8: cognitive_orch.ClearRequest
-	(*ClearResponse)(nil),  // 9: cognitive_orch.ClearResponse
-	(*HealthRequest)(nil),  // 10: cognitive_orch.HealthRequest
-	(*HealthResponse)(nil), // 11: cognitive_orch.HealthResponse
+	(*TextRequest)(nil),          // 0: cognitive_orch.TextRequest
+	(*TextResponse)(nil),         // 1: cognitive_orch.TextResponse
+	(*ToolCall)(nil),             // 2: cognitive_orch.ToolCall
+	(*ToolResult)(nil),           // 3: cognitive_orch.ToolResult
+	(*ToolResultSubmission)(nil), // 4: cognitive_orch.ToolResultSubmission
+	(*ToolResultAck)(nil),        // 5: cognitive_orch.ToolResultAck
+	(*Error)(nil),                // 6: cognitive_orch.Error
+	(*StateRequest)(nil),         // 7: cognitive_orch.StateRequest
+	(*StateResponse)(nil),        // 8: cognitive_orch.StateResponse
+	(*Message)(nil),              // 9: cognitive_orch.Message
+	(*ClearRequest)(nil),         // 10: cognitive_orch.ClearRequest
+	(*ClearResponse)(nil),        // 11: cognitive_orch.ClearResponse
+	(*HealthRequest)(nil),        // 12: cognitive_orch.HealthRequest
+	(*HealthResponse)(nil),       // 13: cognitive_orch.HealthResponse
+	nil,                          // 14: cognitive_orch.TextRequest.MetadataEntry
 }
 var file_cognitive_orch_proto_depIdxs = []int32{
-	2,  // 0: cognitive_orch.TextResponse.tool_call:type_name -> cognitive_orch.ToolCall
-	3,  // 1: cognitive_orch.TextResponse.tool_result:type_name -> cognitive_orch.ToolResult
-	4,  // 2: cognitive_orch.TextResponse.error:type_name -> cognitive_orch.Error
-	7,  // 3: cognitive_orch.StateResponse.messages:type_name -> cognitive_orch.Message
-	0,  // 4: cognitive_orch.CognitiveOrchestrator.ProcessText:input_type -> cognitive_orch.TextRequest
-	5,  // 5: cognitive_orch.CognitiveOrchestrator.GetConversationState:input_type -> cognitive_orch.StateRequest
-	8,  // 6: cognitive_orch.CognitiveOrchestrator.ClearConversation:input_type -> cognitive_orch.ClearRequest
-	10, // 7: cognitive_orch.CognitiveOrchestrator.HealthCheck:input_type -> cognitive_orch.HealthRequest
-	1,  // 8: cognitive_orch.CognitiveOrchestrator.ProcessText:output_type -> cognitive_orch.TextResponse
-	6,  // 9: cognitive_orch.CognitiveOrchestrator.GetConversationState:output_type -> cognitive_orch.StateResponse
-	9,  // 10: cognitive_orch.CognitiveOrchestrator.ClearConversation:output_type -> cognitive_orch.ClearResponse
-	11, // 11: cognitive_orch.CognitiveOrchestrator.HealthCheck:output_type -> cognitive_orch.HealthResponse
-	8,  // [8:12] is the sub-list for method output_type
-	4,  // [4:8] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	14, // 0: cognitive_orch.TextRequest.metadata:type_name -> cognitive_orch.TextRequest.MetadataEntry
+	2,  // 1: cognitive_orch.TextResponse.tool_call:type_name -> cognitive_orch.ToolCall
+	3,  // 2: cognitive_orch.TextResponse.tool_result:type_name -> cognitive_orch.ToolResult
+	6,  // 3: cognitive_orch.TextResponse.error:type_name -> cognitive_orch.Error
+	3,  // 4: cognitive_orch.ToolResultSubmission.result:type_name -> cognitive_orch.ToolResult
+	9,  // 5: cognitive_orch.StateResponse.messages:type_name -> cognitive_orch.Message
+	0,  // 6: cognitive_orch.CognitiveOrchestrator.ProcessText:input_type -> cognitive_orch.TextRequest
+	7,  // 7: cognitive_orch.CognitiveOrchestrator.GetConversationState:input_type -> cognitive_orch.StateRequest
+	10, // 8: cognitive_orch.CognitiveOrchestrator.ClearConversation:input_type -> cognitive_orch.ClearRequest
+	12, // 9: cognitive_orch.CognitiveOrchestrator.HealthCheck:input_type -> cognitive_orch.HealthRequest
+	4,  // 10: cognitive_orch.CognitiveOrchestrator.SubmitToolResults:input_type -> cognitive_orch.ToolResultSubmission
+	1,  // 11: cognitive_orch.CognitiveOrchestrator.ProcessText:output_type -> cognitive_orch.TextResponse
+	8,  // 12: cognitive_orch.CognitiveOrchestrator.GetConversationState:output_type -> cognitive_orch.StateResponse
+	11, // 13: cognitive_orch.CognitiveOrchestrator.ClearConversation:output_type -> cognitive_orch.ClearResponse
+	13, // 14: cognitive_orch.CognitiveOrchestrator.HealthCheck:output_type -> cognitive_orch.HealthResponse
+	5,  // 15: cognitive_orch.CognitiveOrchestrator.SubmitToolResults:output_type -> cognitive_orch.ToolResultAck
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_cognitive_orch_proto_init() }
@@ -994,7 +1126,7 @@ func file_cognitive_orch_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cognitive_orch_proto_rawDesc), len(file_cognitive_orch_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
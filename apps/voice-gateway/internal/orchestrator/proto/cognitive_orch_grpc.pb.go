@@ -23,6 +23,7 @@ const (
 	CognitiveOrchestrator_GetConversationState_FullMethodName = "/cognitive_orch.CognitiveOrchestrator/GetConversationState"
 	CognitiveOrchestrator_ClearConversation_FullMethodName    = "/cognitive_orch.CognitiveOrchestrator/ClearConversation"
 	CognitiveOrchestrator_HealthCheck_FullMethodName          = "/cognitive_orch.CognitiveOrchestrator/HealthCheck"
+	CognitiveOrchestrator_SubmitToolResults_FullMethodName    = "/cognitive_orch.CognitiveOrchestrator/SubmitToolResults"
 )
 
 // CognitiveOrchestratorClient is the client API for CognitiveOrchestrator service.
@@ -39,6 +40,8 @@ type CognitiveOrchestratorClient interface {
 	ClearConversation(ctx context.Context, in *ClearRequest, opts ...grpc.CallOption) (*ClearResponse, error)
 	// Health check
 	HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Results of telephony tools (transfer_call, send_sms, ...) executed by the voice gateway
+	SubmitToolResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ToolResultSubmission, ToolResultAck], error)
 }
 
 type cognitiveOrchestratorClient struct {
@@ -98,6 +101,19 @@ func (c *cognitiveOrchestratorClient) HealthCheck(ctx context.Context, in *Healt
 	return out, nil
 }
 
+func (c *cognitiveOrchestratorClient) SubmitToolResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ToolResultSubmission, ToolResultAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CognitiveOrchestrator_ServiceDesc.Streams[1], CognitiveOrchestrator_SubmitToolResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ToolResultSubmission, ToolResultAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CognitiveOrchestrator_SubmitToolResultsClient = grpc.ClientStreamingClient[ToolResultSubmission, ToolResultAck]
+
 // CognitiveOrchestratorServer is the server API for CognitiveOrchestrator service.
 // All implementations must embed UnimplementedCognitiveOrchestratorServer
 // for forward compatibility.
@@ -112,6 +128,8 @@ type CognitiveOrchestratorServer interface {
 	ClearConversation(context.Context, *ClearRequest) (*ClearResponse, error)
 	// Health check
 	HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Results of telephony tools (transfer_call, send_sms, ...) executed by the voice gateway
+	SubmitToolResults(grpc.ClientStreamingServer[ToolResultSubmission, ToolResultAck]) error
 	mustEmbedUnimplementedCognitiveOrchestratorServer()
 }
 
@@ -134,6 +152,9 @@ func (UnimplementedCognitiveOrchestratorServer) ClearConversation(context.Contex
 func (UnimplementedCognitiveOrchestratorServer) HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedCognitiveOrchestratorServer) SubmitToolResults(grpc.ClientStreamingServer[ToolResultSubmission, ToolResultAck]) error {
+	return status.Error(codes.Unimplemented, "method SubmitToolResults not implemented")
+}
 func (UnimplementedCognitiveOrchestratorServer) mustEmbedUnimplementedCognitiveOrchestratorServer() {}
 func (UnimplementedCognitiveOrchestratorServer) testEmbeddedByValue()                               {}
 
@@ -220,6 +241,13 @@ func _CognitiveOrchestrator_HealthCheck_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CognitiveOrchestrator_SubmitToolResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CognitiveOrchestratorServer).SubmitToolResults(&grpc.GenericServerStream[ToolResultSubmission, ToolResultAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CognitiveOrchestrator_SubmitToolResultsServer = grpc.ClientStreamingServer[ToolResultSubmission, ToolResultAck]
+
 // CognitiveOrchestrator_ServiceDesc is the grpc.ServiceDesc for CognitiveOrchestrator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -246,6 +274,11 @@ var CognitiveOrchestrator_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CognitiveOrchestrator_ProcessText_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubmitToolResults",
+			Handler:       _CognitiveOrchestrator_SubmitToolResults_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "cognitive_orch.proto",
 }
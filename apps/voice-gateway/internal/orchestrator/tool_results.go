@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lexiqai/voice-gateway/internal/orchestrator/proto"
+)
+
+// ToolResultStream sends results of gateway-executed tools back to the Orchestrator
+// One stream is held open per call; safe for concurrent use
+type ToolResultStream struct {
+	mu             sync.Mutex
+	client         *OrchestratorClient
+	conversationID string
+	stream         proto.CognitiveOrchestrator_SubmitToolResultsClient
+	cancel         context.CancelFunc
+}
+
+// OpenToolResultStream opens a SubmitToolResults stream for a conversation
+func (c *OrchestratorClient) OpenToolResultStream(conversationID string) (*ToolResultStream, error) {
+	c.mu.RLock()
+	client := c.client
+	c.mu.RUnlock()
+	if client == nil {
+		return nil, fmt.Errorf("orchestrator client is not connected")
+	}
+	if !c.Supports(CapabilityToolResults) {
+		return nil, fmt.Errorf("tool results: %w", ErrUnsupported)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.SubmitToolResults(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open tool result stream: %w", err)
+	}
+
+	return &ToolResultStream{
+		client:         c,
+		conversationID: conversationID,
+		stream:         stream,
+		cancel:         cancel,
+	}, nil
+}
+
+// Send submits one tool result
+func (t *ToolResultStream) Send(toolName string, result *ToolResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stream.Send(&proto.ToolResultSubmission{
+		ConversationId: t.conversationID,
+		ToolName:       toolName,
+		Result: &proto.ToolResult{
+			CallId:       result.CallID,
+			ResultJson:   result.ResultJSON,
+			Success:      result.Success,
+			ErrorMessage: result.ErrorMessage,
+		},
+	})
+}
+
+// Close ends the stream and returns how many results the Orchestrator accepted
+func (t *ToolResultStream) Close() (int32, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.cancel()
+
+	ack, err := t.stream.CloseAndRecv()
+	if isUnimplemented(err) {
+		// Later calls stop opening result streams
+		t.client.disable(CapabilityToolResults)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to close tool result stream: %w", err)
+	}
+	return ack.Received, nil
+}
@@ -0,0 +1,109 @@
+package playback
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/recording"
+)
+
+// SampleRate is the rate of Twilio media streams
+const SampleRate = 8000
+
+// ErrNoTranscoder is returned for non-WAV audio when ffmpeg is not available
+var ErrNoTranscoder = errors.New("playing this format requires ffmpeg (set FFMPEG_PATH)")
+
+// DecodeWAV converts a WAV file to 8kHz mono PCMU
+// Supports 8/16-bit PCM and μ-law at any sample rate; stereo is mixed down
+func DecodeWAV(data []byte) ([]byte, error) {
+	info, payload, err := recording.DecodeWAV(data)
+	if err != nil {
+		return nil, err
+	}
+	if info.Channels < 1 || info.SampleRate <= 0 {
+		return nil, fmt.Errorf("invalid WAV: %d channels at %dHz", info.Channels, info.SampleRate)
+	}
+
+	// Already in Twilio's format
+	if info.Format == recording.WAVFormatMulaw && info.SampleRate == SampleRate && info.Channels == 1 {
+		return payload, nil
+	}
+
+	var samples []int16
+	switch {
+	case info.Format == recording.WAVFormatMulaw:
+		samples = audio.DecodePCMU(payload)
+	case info.Format == recording.WAVFormatPCM && info.BitsPerSample == 16:
+		samples = make([]int16, len(payload)/2)
+		for i := range samples {
+			samples[i] = int16(binary.LittleEndian.Uint16(payload[i*2:]))
+		}
+	case info.Format == recording.WAVFormatPCM && info.BitsPerSample == 8:
+		samples = make([]int16, len(payload))
+		for i, b := range payload {
+			samples[i] = (int16(b) - 128) << 8 // 8-bit WAV is unsigned
+		}
+	default:
+		return nil, fmt.Errorf("unsupported WAV format %d with %d bits per sample", info.Format, info.BitsPerSample)
+	}
+
+	samples = mixDown(samples, info.Channels)
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("WAV contains no audio")
+	}
+	return audio.EncodeSamplesToPCMU(samples, info.SampleRate, SampleRate), nil
+}
+
+// mixDown averages interleaved channels into mono
+func mixDown(samples []int16, channels int) []int16 {
+	if channels == 1 {
+		return samples
+	}
+	mono := make([]int16, len(samples)/channels)
+	for i := range mono {
+		var sum int
+		for c := 0; c < channels; c++ {
+			sum += int(samples[i*channels+c])
+		}
+		mono[i] = int16(sum / channels)
+	}
+	return mono
+}
+
+// Transcode converts any format ffmpeg understands (MP3, OGG, ...) to 8kHz mono PCMU
+func Transcode(ctx context.Context, ffmpegPath string, data []byte) ([]byte, error) {
+	if ffmpegPath == "" {
+		return nil, ErrNoTranscoder
+	}
+	path, err := exec.LookPath(ffmpegPath)
+	if err != nil {
+		return nil, ErrNoTranscoder
+	}
+
+	cmd := exec.CommandContext(ctx, path,
+		"-hide_banner", "-loglevel", "error",
+		"-i", "pipe:0",
+		"-f", "mulaw", "-ar", fmt.Sprint(SampleRate), "-ac", "1",
+		"pipe:1")
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("ffmpeg produced no audio")
+	}
+	return stdout.Bytes(), nil
+}
+
+// isWAV returns whether data starts with a RIFF/WAVE header
+func isWAV(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE"
+}
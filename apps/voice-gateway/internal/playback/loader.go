@@ -0,0 +1,200 @@
+package playback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+// MaxFileBytes caps audio files fetched for playback (~10 minutes of 8kHz PCM16)
+const MaxFileBytes = 10 << 20
+
+// cacheSize is how many decoded clips are kept; greetings, disclaimers and
+// hold music are played over and over
+const cacheSize = 32
+
+// Clip is decoded audio ready for a Twilio media stream (PCMU, 8kHz, mono)
+type Clip struct {
+	PCMU []byte
+}
+
+// Duration returns the clip length
+func (c *Clip) Duration() time.Duration {
+	return time.Duration(len(c.PCMU)) * time.Second / SampleRate
+}
+
+// ErrURLNotAllowed is returned for audio URLs the loader will not fetch
+var ErrURLNotAllowed = errors.New("audio URL not allowed")
+
+// Loader fetches audio files by reference and decodes them to PCMU
+// A reference is an https URL on an allowed host, or a storage key
+// (optionally "storage://key") resolved against the configured store, which
+// is local disk or S3
+type Loader struct {
+	store        storage.Store
+	client       *http.Client
+	ffmpegPath   string
+	allowedHosts map[string]bool
+
+	mu    sync.Mutex
+	cache map[string]*Clip
+	order []string // Insertion order, for eviction
+}
+
+// NewLoader creates a loader; ffmpegPath enables MP3 and other non-WAV
+// formats, and URLs are fetched only from allowedHosts
+func NewLoader(store storage.Store, ffmpegPath string, allowedHosts []string) *Loader {
+	l := &Loader{
+		store:        store,
+		ffmpegPath:   ffmpegPath,
+		allowedHosts: make(map[string]bool, len(allowedHosts)),
+		cache:        make(map[string]*Clip),
+	}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			l.allowedHosts[host] = true
+		}
+	}
+
+	// Addresses are checked as they are dialed, so a name resolving to an
+	// internal address (or rebinding to one) is refused too
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: refusePrivate}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	l.client = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many redirects")
+			}
+			return l.checkURL(req.URL)
+		},
+	}
+	return l
+}
+
+// checkURL refuses audio URLs other than https on an allowed host
+func (l *Loader) checkURL(u *url.URL) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: %s is not https", ErrURLNotAllowed, u.Redacted())
+	}
+	if !l.allowedHosts[strings.ToLower(u.Hostname())] {
+		return fmt.Errorf("%w: %s is not in PLAYBACK_ALLOWED_HOSTS", ErrURLNotAllowed, u.Hostname())
+	}
+	return nil
+}
+
+// refusePrivate stops a dial to a loopback, private, link-local (cloud
+// metadata) or otherwise non-public address
+func refusePrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s is not a public address", ErrURLNotAllowed, host)
+	}
+	return nil
+}
+
+// publicIP reports whether ip is a globally routable unicast address
+func publicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is carrier-grade NAT space (RFC 6598), internal to providers
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Load returns the decoded clip for ref, from cache when possible
+func (l *Loader) Load(ctx context.Context, ref string) (*Clip, error) {
+	l.mu.Lock()
+	clip, ok := l.cache[ref]
+	l.mu.Unlock()
+	if ok {
+		return clip, nil
+	}
+
+	data, err := l.fetch(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var pcmu []byte
+	if isWAV(data) {
+		pcmu, err = DecodeWAV(data)
+	} else {
+		pcmu, err = Transcode(ctx, l.ffmpegPath, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", ref, err)
+	}
+
+	clip = &Clip{PCMU: pcmu}
+	l.mu.Lock()
+	if _, ok := l.cache[ref]; !ok {
+		if len(l.order) >= cacheSize {
+			delete(l.cache, l.order[0])
+			l.order = l.order[1:]
+		}
+		l.cache[ref] = clip
+		l.order = append(l.order, ref)
+	}
+	l.mu.Unlock()
+	return clip, nil
+}
+
+// fetch reads the raw file for ref
+func (l *Loader) fetch(ctx context.Context, ref string) ([]byte, error) {
+	var body io.ReadCloser
+	switch {
+	case strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://"):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audio URL: %w", err)
+		}
+		if err := l.checkURL(req.URL); err != nil {
+			return nil, err
+		}
+		resp, err := l.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch audio: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("audio fetch returned status %d", resp.StatusCode)
+		}
+		body = resp.Body
+	default:
+		if l.store == nil {
+			return nil, fmt.Errorf("no storage configured for %s", ref)
+		}
+		r, err := l.store.Get(ctx, strings.TrimPrefix(ref, "storage://"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", ref, err)
+		}
+		body = r
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+	if len(data) > MaxFileBytes {
+		return nil, fmt.Errorf("audio file exceeds %d bytes", MaxFileBytes)
+	}
+	return data, nil
+}
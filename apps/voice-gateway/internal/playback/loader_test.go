@@ -0,0 +1,149 @@
+package playback
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/recording"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+)
+
+func TestDecodeWAV_ResamplesAndMixesDown(t *testing.T) {
+	// 100ms of 16kHz stereo
+	samples := make([]int16, 1600*2)
+	for i := range samples {
+		samples[i] = 1000
+	}
+
+	pcmu, err := DecodeWAV(recording.EncodeWAV(samples, 16000, 2))
+	if err != nil {
+		t.Fatalf("DecodeWAV() failed: %v", err)
+	}
+	if len(pcmu) != 800 {
+		t.Errorf("Expected 800 PCMU bytes (100ms at 8kHz), got %d", len(pcmu))
+	}
+}
+
+func TestDecodeWAV_8BitPCM(t *testing.T) {
+	wav := recording.EncodeWAV(nil, 8000, 1)
+	binary.LittleEndian.PutUint16(wav[32:34], 1) // block align
+	binary.LittleEndian.PutUint16(wav[34:36], 8) // bits per sample
+	binary.LittleEndian.PutUint32(wav[40:44], 160)
+	wav = append(wav, bytes.Repeat([]byte{128}, 160)...)
+
+	pcmu, err := DecodeWAV(wav)
+	if err != nil {
+		t.Fatalf("DecodeWAV() failed: %v", err)
+	}
+	if len(pcmu) != 160 {
+		t.Errorf("Expected 160 PCMU bytes, got %d", len(pcmu))
+	}
+}
+
+func TestLoader_StorageAndCache(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() failed: %v", err)
+	}
+	wav := recording.EncodeWAV(make([]int16, 8000), 8000, 1)
+	if _, err := store.Put(context.Background(), "audio/disclaimer.wav", bytes.NewReader(wav), "audio/wav"); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	loader := NewLoader(store, "", nil)
+	clip, err := loader.Load(context.Background(), "storage://audio/disclaimer.wav")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if clip.Duration() != time.Second {
+		t.Errorf("Expected 1s clip, got %v", clip.Duration())
+	}
+
+	// Served from cache even after the file is gone
+	store.Delete(context.Background(), "audio/disclaimer.wav")
+	if _, err := loader.Load(context.Background(), "storage://audio/disclaimer.wav"); err != nil {
+		t.Errorf("Expected cached clip, got %v", err)
+	}
+}
+
+func TestLoader_HTTP(t *testing.T) {
+	var fetches int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		if r.URL.Path == "/hold.mp3" {
+			w.Write([]byte("ID3\x03not really mp3"))
+			return
+		}
+		w.Write(recording.EncodeWAV(make([]int16, 800), 8000, 1))
+	}))
+	defer server.Close()
+
+	// The test server is on loopback, which the real dialer refuses
+	host, _, _ := net.SplitHostPort(server.Listener.Addr().String())
+	loader := NewLoader(nil, "", []string{host})
+	loader.client = server.Client()
+	for i := 0; i < 2; i++ {
+		if _, err := loader.Load(context.Background(), server.URL+"/greeting.wav"); err != nil {
+			t.Fatalf("Load() failed: %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("Expected one fetch with caching, got %d", fetches)
+	}
+
+	if _, err := loader.Load(context.Background(), server.URL+"/hold.mp3"); !errors.Is(err, ErrNoTranscoder) {
+		t.Errorf("Expected ErrNoTranscoder for MP3 without ffmpeg, got %v", err)
+	}
+}
+
+func TestLoader_RefusesURLs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(recording.EncodeWAV(make([]int16, 800), 8000, 1))
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	loader := NewLoader(nil, "", []string{host, "localhost", "audio.example.com", "169.254.169.254"})
+	tests := map[string]string{
+		"plain http":        "http://audio.example.com/greeting.wav",
+		"host not allowed":  "https://other.example.com/greeting.wav",
+		"metadata service":  "https://169.254.169.254/latest/meta-data",
+		"loopback address":  server.URL + "/greeting.wav",
+		"loopback hostname": "https://localhost:" + port + "/greeting.wav",
+	}
+	for name, ref := range tests {
+		if _, err := loader.Load(context.Background(), ref); !errors.Is(err, ErrURLNotAllowed) {
+			t.Errorf("%s: expected ErrURLNotAllowed, got %v", name, err)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.0.0.5":        false,
+		"172.16.3.4":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	}
+	for ip, want := range tests {
+		if got := publicIP(net.ParseIP(ip)); got != want {
+			t.Errorf("publicIP(%s) = %v, want %v", ip, got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package preflight verifies the gateway's dependencies at startup with real
+// calls, so a bad API key or an unreachable orchestrator shows up at boot
+// rather than on the first live call. The gateway either exits on a failure
+// (fail_fast) or starts degraded: health pages are served, but calls are
+// refused until the failing checks pass
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Modes, as set by STARTUP_PREFLIGHT
+const (
+	ModeOff      = "off"       // Skip the preflight
+	ModeFailFast = "fail_fast" // Exit when a check fails
+	ModeDegraded = "degraded"  // Start, refusing calls until every check passes
+)
+
+// Check verifies one dependency; Run returns nil when it is usable
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Failure is a check that did not pass
+type Failure struct {
+	Check string
+	Err   error
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("%s: %v", f.Check, f.Err)
+}
+
+// Run runs the checks concurrently, each bounded by timeout, and returns the
+// failures in check order
+func Run(ctx context.Context, checks []Check, timeout time.Duration) []Failure {
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			errs[i] = check.Run(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	var failures []Failure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, Failure{Check: checks[i].Name, Err: err})
+		}
+	}
+	return failures
+}
+
+// Gate holds new calls back while the preflight is failing. A nil Gate is
+// always open
+type Gate struct {
+	open atomic.Bool
+}
+
+// Open reports whether calls may be taken
+func (g *Gate) Open() bool {
+	return g == nil || g.open.Load()
+}
+
+// Hold returns a closed gate and re-runs the checks every interval until they
+// all pass, then opens it. report sees the failures of each retry, and nil
+// once the gate opens. Retrying stops with ctx
+func Hold(ctx context.Context, checks []Check, timeout, interval time.Duration, report func([]Failure)) *Gate {
+	g := &Gate{}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			failures := Run(ctx, checks, timeout)
+			if ctx.Err() != nil {
+				return
+			}
+			if len(failures) == 0 {
+				g.open.Store(true)
+				report(nil)
+				return
+			}
+			report(failures)
+		}
+	}()
+	return g
+}
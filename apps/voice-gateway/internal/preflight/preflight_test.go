@@ -0,0 +1,74 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun_CollectsFailuresInOrder(t *testing.T) {
+	checks := []Check{
+		{Name: "deepgram", Run: func(ctx context.Context) error { return errors.New("invalid credentials") }},
+		{Name: "cartesia", Run: func(ctx context.Context) error { return nil }},
+		{Name: "orchestrator", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}
+	start := time.Now()
+	failures := Run(context.Background(), checks, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected a hung check bounded by the timeout, took %v", elapsed)
+	}
+	if len(failures) != 2 || failures[0].Check != "deepgram" || failures[1].Check != "orchestrator" {
+		t.Fatalf("Expected deepgram and orchestrator to fail, got %v", failures)
+	}
+	if !errors.Is(failures[1].Err, context.DeadlineExceeded) {
+		t.Errorf("Expected the hung check to time out, got %v", failures[1].Err)
+	}
+}
+
+func TestHold_OpensOnceChecksPass(t *testing.T) {
+	var attempts atomic.Int32
+	checks := []Check{{Name: "orchestrator", Run: func(ctx context.Context) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reports := make(chan []Failure, 10)
+	gate := Hold(ctx, checks, time.Second, 10*time.Millisecond, func(f []Failure) { reports <- f })
+	if gate.Open() {
+		t.Fatal("Expected the gate closed while the preflight is failing")
+	}
+
+	for {
+		select {
+		case failures := <-reports:
+			if failures != nil {
+				continue
+			}
+			if !gate.Open() {
+				t.Error("Expected the gate open once the checks passed")
+			}
+			if got := attempts.Load(); got != 3 {
+				t.Errorf("Expected 3 attempts, got %d", got)
+			}
+			return
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected the gate to open")
+		}
+	}
+}
+
+func TestGate_NilIsOpen(t *testing.T) {
+	var gate *Gate
+	if !gate.Open() {
+		t.Error("Expected a nil gate to be open")
+	}
+}
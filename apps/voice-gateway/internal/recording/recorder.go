@@ -0,0 +1,69 @@
+package recording
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+)
+
+// SampleRate is the sample rate of Twilio media streams
+const SampleRate = 8000
+
+// Recorder accumulates caller audio (PCMU from Twilio) for a single call
+type Recorder struct {
+	mu        sync.Mutex
+	samples   []int16
+	maxSample int
+	startedAt time.Time
+	stopped   bool
+}
+
+// NewRecorder creates a recorder that keeps at most maxDuration of audio
+// A zero maxDuration means unlimited
+func NewRecorder(maxDuration time.Duration) *Recorder {
+	return &Recorder{
+		samples:   make([]int16, 0, SampleRate*10),
+		maxSample: int(maxDuration.Seconds() * SampleRate),
+		startedAt: time.Now(),
+	}
+}
+
+// WritePCMU appends a μ-law chunk; returns false once the recorder is full or stopped
+func (r *Recorder) WritePCMU(chunk []byte) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return false
+	}
+
+	samples := audio.DecodePCMU(chunk)
+	if r.maxSample > 0 && len(r.samples)+len(samples) > r.maxSample {
+		samples = samples[:r.maxSample-len(r.samples)]
+		r.stopped = true
+	}
+	r.samples = append(r.samples, samples...)
+	return !r.stopped
+}
+
+// Stop stops accepting audio
+func (r *Recorder) Stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+}
+
+// Duration returns the length of the recorded audio
+func (r *Recorder) Duration() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Duration(len(r.samples)) * time.Second / SampleRate
+}
+
+// WAV returns the recording as a mono 16-bit WAV file
+func (r *Recorder) WAV() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return EncodeWAV(r.samples, SampleRate, 1)
+}
@@ -0,0 +1,83 @@
+package recording
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestEncodeWAV_Header(t *testing.T) {
+	samples := []int16{0, 1000, -1000, 32767}
+	wav := EncodeWAV(samples, 8000, 1)
+
+	if len(wav) != 44+len(samples)*2 {
+		t.Fatalf("Expected %d bytes, got %d", 44+len(samples)*2, len(wav))
+	}
+	if string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		t.Error("Expected RIFF/WAVE header")
+	}
+	if channels := binary.LittleEndian.Uint16(wav[22:24]); channels != 1 {
+		t.Errorf("Expected 1 channel, got %d", channels)
+	}
+	if rate := binary.LittleEndian.Uint32(wav[24:28]); rate != 8000 {
+		t.Errorf("Expected sample rate 8000, got %d", rate)
+	}
+	if dataSize := binary.LittleEndian.Uint32(wav[40:44]); dataSize != uint32(len(samples)*2) {
+		t.Errorf("Expected data size %d, got %d", len(samples)*2, dataSize)
+	}
+	if sample := int16(binary.LittleEndian.Uint16(wav[46:48])); sample != 1000 {
+		t.Errorf("Expected second sample 1000, got %d", sample)
+	}
+}
+
+func TestRecorder_MaxDuration(t *testing.T) {
+	recorder := NewRecorder(100 * time.Millisecond) // 800 samples
+
+	chunk := make([]byte, 160) // 20ms
+	accepted := 0
+	for i := 0; i < 10; i++ {
+		if !recorder.WritePCMU(chunk) {
+			break
+		}
+		accepted++
+	}
+
+	if accepted != 5 {
+		t.Errorf("Expected 5 full chunks before the limit, got %d", accepted)
+	}
+	if recorder.Duration() != 100*time.Millisecond {
+		t.Errorf("Expected duration 100ms, got %v", recorder.Duration())
+	}
+}
+
+func TestRecorder_Stop(t *testing.T) {
+	recorder := NewRecorder(0)
+	recorder.WritePCMU(make([]byte, 160))
+	recorder.Stop()
+
+	if recorder.WritePCMU(make([]byte, 160)) {
+		t.Error("Expected writes to be rejected after Stop")
+	}
+	if len(recorder.WAV()) != 44+160*2 {
+		t.Errorf("Expected WAV with 160 samples, got %d bytes", len(recorder.WAV()))
+	}
+}
+
+func TestDecodeWAV_RoundTrip(t *testing.T) {
+	samples := []int16{0, 1000, -1000, 32767}
+	info, data, err := DecodeWAV(EncodeWAV(samples, 16000, 1))
+	if err != nil {
+		t.Fatalf("DecodeWAV() failed: %v", err)
+	}
+
+	if info.Format != WAVFormatPCM || info.SampleRate != 16000 || info.Channels != 1 || info.BitsPerSample != 16 {
+		t.Errorf("Unexpected format: %+v", info)
+	}
+	if len(data) != len(samples)*2 {
+		t.Errorf("Expected %d data bytes, got %d", len(samples)*2, len(data))
+	}
+
+	if _, _, err := DecodeWAV([]byte("not a wav file")); err == nil {
+		t.Error("Expected error for non-WAV input")
+	}
+}
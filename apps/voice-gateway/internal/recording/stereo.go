@@ -0,0 +1,136 @@
+package recording
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+)
+
+// Stereo recording channels
+const (
+	ChannelCaller = 0
+	ChannelAgent  = 1
+)
+
+// StereoRecorder records a full call as two time-aligned tracks: caller
+// audio on channel 0 and agent (TTS) audio on channel 1
+// Positions are offsets from the start of the media stream, so talk-over and
+// response delay can be measured directly from the file
+type StereoRecorder struct {
+	mu        sync.Mutex
+	caller    []int16
+	agent     []int16
+	maxSample int
+	stopped   bool
+}
+
+// NewStereoRecorder creates a recorder that keeps at most maxDuration of audio
+// A zero maxDuration means unlimited
+func NewStereoRecorder(maxDuration time.Duration) *StereoRecorder {
+	return &StereoRecorder{
+		caller:    make([]int16, 0, SampleRate*10),
+		agent:     make([]int16, 0, SampleRate*10),
+		maxSample: int(maxDuration.Seconds() * SampleRate),
+	}
+}
+
+// WriteCaller places a μ-law chunk on the caller track at the given stream
+// offset (Twilio's media timestamp); gaps are filled with silence
+func (r *StereoRecorder) WriteCaller(at time.Duration, chunk []byte) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return false
+	}
+	r.caller = r.place(r.caller, sampleOffset(at), audio.DecodePCMU(chunk))
+	return !r.stopped
+}
+
+// WriteAgent appends a μ-law chunk to the agent track
+// at is the stream offset when the chunk was sent; Twilio plays outbound
+// audio back to back, so the chunk starts at whichever is later: at, or the
+// end of audio already queued
+func (r *StereoRecorder) WriteAgent(at time.Duration, chunk []byte) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return false
+	}
+	offset := sampleOffset(at)
+	if len(r.agent) > offset {
+		offset = len(r.agent)
+	}
+	r.agent = r.place(r.agent, offset, audio.DecodePCMU(chunk))
+	return !r.stopped
+}
+
+// place writes samples into track at offset, padding with silence and
+// truncating at the recorder's limit
+func (r *StereoRecorder) place(track []int16, offset int, samples []int16) []int16 {
+	if r.maxSample > 0 {
+		if offset >= r.maxSample {
+			r.stopped = true
+			return track
+		}
+		if offset+len(samples) > r.maxSample {
+			samples = samples[:r.maxSample-offset]
+			r.stopped = true
+		}
+	}
+
+	end := offset + len(samples)
+	if end > len(track) {
+		track = append(track, make([]int16, end-len(track))...)
+	}
+	copy(track[offset:end], samples)
+	return track
+}
+
+// Stop stops accepting audio
+func (r *StereoRecorder) Stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+}
+
+// Duration returns the length of the longer track
+func (r *StereoRecorder) Duration() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Duration(r.length()) * time.Second / SampleRate
+}
+
+// WAV returns the recording as a two-channel 16-bit WAV file
+func (r *StereoRecorder) WAV() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := r.length()
+	interleaved := make([]int16, n*2)
+	for i := 0; i < n; i++ {
+		if i < len(r.caller) {
+			interleaved[i*2+ChannelCaller] = r.caller[i]
+		}
+		if i < len(r.agent) {
+			interleaved[i*2+ChannelAgent] = r.agent[i]
+		}
+	}
+	return EncodeWAV(interleaved, SampleRate, 2)
+}
+
+func (r *StereoRecorder) length() int {
+	if len(r.agent) > len(r.caller) {
+		return len(r.agent)
+	}
+	return len(r.caller)
+}
+
+func sampleOffset(at time.Duration) int {
+	if at < 0 {
+		return 0
+	}
+	return int(at * SampleRate / time.Second)
+}
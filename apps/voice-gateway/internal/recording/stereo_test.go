@@ -0,0 +1,77 @@
+package recording
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// pcmuChunk returns 20ms of loud μ-law audio (0x00 decodes to the largest negative sample)
+func pcmuChunk() []byte {
+	return make([]byte, 160)
+}
+
+// stereoSample returns the sample at index i of the given channel
+func stereoSample(wav []byte, i, channel int) int16 {
+	return int16(binary.LittleEndian.Uint16(wav[44+(i*2+channel)*2:]))
+}
+
+func TestStereoRecorder_AlignsTracks(t *testing.T) {
+	recorder := NewStereoRecorder(0)
+
+	// Caller speaks at 0ms and 100ms (gap in between), agent replies at 200ms
+	recorder.WriteCaller(0, pcmuChunk())
+	recorder.WriteCaller(100*time.Millisecond, pcmuChunk())
+	recorder.WriteAgent(200*time.Millisecond, pcmuChunk())
+
+	wav := recorder.WAV()
+	if channels := binary.LittleEndian.Uint16(wav[22:24]); channels != 2 {
+		t.Fatalf("Expected 2 channels, got %d", channels)
+	}
+	if recorder.Duration() != 220*time.Millisecond {
+		t.Errorf("Expected duration 220ms, got %v", recorder.Duration())
+	}
+
+	if stereoSample(wav, 0, ChannelCaller) == 0 {
+		t.Error("Expected caller audio at 0ms")
+	}
+	if stereoSample(wav, 400, ChannelCaller) != 0 {
+		t.Error("Expected silence on caller track at 50ms")
+	}
+	if stereoSample(wav, 800, ChannelCaller) == 0 {
+		t.Error("Expected caller audio at 100ms")
+	}
+	if stereoSample(wav, 800, ChannelAgent) != 0 {
+		t.Error("Expected silence on agent track at 100ms")
+	}
+	if stereoSample(wav, 1600, ChannelAgent) == 0 {
+		t.Error("Expected agent audio at 200ms")
+	}
+}
+
+func TestStereoRecorder_AgentQueuesBackToBack(t *testing.T) {
+	recorder := NewStereoRecorder(0)
+
+	// TTS chunks sent in a burst play one after another
+	for i := 0; i < 5; i++ {
+		recorder.WriteAgent(time.Second, pcmuChunk())
+	}
+
+	if recorder.Duration() != 1100*time.Millisecond {
+		t.Errorf("Expected duration 1.1s, got %v", recorder.Duration())
+	}
+}
+
+func TestStereoRecorder_MaxDuration(t *testing.T) {
+	recorder := NewStereoRecorder(100 * time.Millisecond)
+
+	if !recorder.WriteCaller(60*time.Millisecond, pcmuChunk()) {
+		t.Error("Expected write within the limit to be accepted")
+	}
+	if recorder.WriteCaller(90*time.Millisecond, pcmuChunk()) {
+		t.Error("Expected write crossing the limit to stop the recorder")
+	}
+	if recorder.Duration() != 100*time.Millisecond {
+		t.Errorf("Expected duration 100ms, got %v", recorder.Duration())
+	}
+}
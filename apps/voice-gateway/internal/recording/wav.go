@@ -0,0 +1,91 @@
+package recording
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// WAV audio format codes
+const (
+	WAVFormatPCM   = 1
+	WAVFormatMulaw = 7
+)
+
+// WAVInfo describes the audio in a decoded WAV file
+type WAVInfo struct {
+	Format        int
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+}
+
+// EncodeWAV wraps interleaved 16-bit PCM samples in a RIFF/WAVE container
+func EncodeWAV(samples []int16, sampleRate, channels int) []byte {
+	const headerSize = 44
+	dataSize := len(samples) * 2
+	buf := make([]byte, headerSize+dataSize)
+
+	// RIFF header
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(headerSize-8+dataSize))
+	copy(buf[8:12], "WAVE")
+
+	// fmt chunk (PCM)
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], 1) // PCM
+	binary.LittleEndian.PutUint16(buf[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(buf[28:32], uint32(sampleRate*channels*2)) // byte rate
+	binary.LittleEndian.PutUint16(buf[32:34], uint16(channels*2))            // block align
+	binary.LittleEndian.PutUint16(buf[34:36], 16)                            // bits per sample
+
+	// data chunk
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(dataSize))
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint16(buf[headerSize+i*2:], uint16(sample))
+	}
+
+	return buf
+}
+
+// DecodeWAV parses a RIFF/WAVE file, returning its format and raw data chunk
+func DecodeWAV(data []byte) (WAVInfo, []byte, error) {
+	var info WAVInfo
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return info, nil, fmt.Errorf("not a RIFF/WAVE file")
+	}
+
+	var haveFmt bool
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		if size < 0 || body+size > len(data) {
+			size = len(data) - body // Tolerate truncated/streamed files
+		}
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return info, nil, fmt.Errorf("fmt chunk too short")
+			}
+			info.Format = int(binary.LittleEndian.Uint16(data[body : body+2]))
+			info.Channels = int(binary.LittleEndian.Uint16(data[body+2 : body+4]))
+			info.SampleRate = int(binary.LittleEndian.Uint32(data[body+4 : body+8]))
+			info.BitsPerSample = int(binary.LittleEndian.Uint16(data[body+14 : body+16]))
+			haveFmt = true
+		case "data":
+			if !haveFmt {
+				return info, nil, fmt.Errorf("data chunk before fmt chunk")
+			}
+			return info, data[body : body+size], nil
+		}
+
+		// Chunks are word-aligned
+		offset = body + size + size%2
+	}
+
+	return info, nil, fmt.Errorf("no data chunk")
+}
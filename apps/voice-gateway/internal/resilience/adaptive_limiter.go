@@ -0,0 +1,250 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLimitExceeded is returned when an AdaptiveLimiter sheds a request
+var ErrLimitExceeded = errors.New("adaptive concurrency limit exceeded")
+
+const (
+	// Smoothing for the short-term (recent) and long-term (baseline) latency averages
+	shortLatencyWeight = 0.2
+	longLatencyWeight  = 0.01
+
+	// The limit grows only while at least this share of it is in use
+	increaseUtilization = 0.8
+)
+
+// AdaptiveLimiterConfig tunes an AdaptiveLimiter
+type AdaptiveLimiterConfig struct {
+	InitialLimit int
+	MinLimit     int
+	MaxLimit     int
+
+	// Tolerance is the short- over long-term latency ratio treated as saturation (default 1.5)
+	Tolerance float64
+
+	// Backoff multiplies the limit on saturation (default 0.9)
+	Backoff float64
+
+	// AdjustInterval is the minimum time between limit changes (default 1s)
+	AdjustInterval time.Duration
+
+	// Requests over the limit wait in a queue of MaxQueue for up to QueueTimeout
+	// before being shed; 0 sheds immediately
+	MaxQueue     int
+	QueueTimeout time.Duration
+}
+
+// AdaptiveLimiter admits work up to a concurrency limit that follows
+// downstream latency (AIMD): the limit shrinks multiplicatively while any
+// latency signal runs well above its baseline, and grows by one while
+// latency is normal and the limit is nearly used up
+//
+// A nil *AdaptiveLimiter admits everything.
+type AdaptiveLimiter struct {
+	cfg AdaptiveLimiterConfig
+	now func() time.Time
+
+	mu              sync.Mutex
+	limit           float64
+	inFlight        int
+	queued          int
+	signals         map[string]*latencySignal
+	lastAdjust      time.Time
+	changed         chan struct{} // Closed and replaced when capacity may have freed up
+	rejectedLimit   int64
+	rejectedTimeout int64
+}
+
+// latencySignal tracks one downstream's recent latency against its baseline, in seconds
+type latencySignal struct {
+	short float64
+	long  float64
+}
+
+// AdaptiveLimiterStats is a point-in-time view of an AdaptiveLimiter
+type AdaptiveLimiterStats struct {
+	Limit           int
+	InFlight        int
+	Queued          int
+	RejectedLimit   int64 // Shed immediately (no queue room)
+	RejectedTimeout int64 // Shed after waiting QueueTimeout
+}
+
+// NewAdaptiveLimiter creates a limiter starting at cfg.InitialLimit
+func NewAdaptiveLimiter(cfg AdaptiveLimiterConfig) *AdaptiveLimiter {
+	if cfg.MinLimit <= 0 {
+		cfg.MinLimit = 1
+	}
+	if cfg.MaxLimit < cfg.MinLimit {
+		cfg.MaxLimit = cfg.MinLimit
+	}
+	if cfg.InitialLimit < cfg.MinLimit {
+		cfg.InitialLimit = cfg.MinLimit
+	}
+	if cfg.InitialLimit > cfg.MaxLimit {
+		cfg.InitialLimit = cfg.MaxLimit
+	}
+	if cfg.Tolerance <= 1 {
+		cfg.Tolerance = 1.5
+	}
+	if cfg.Backoff <= 0 || cfg.Backoff >= 1 {
+		cfg.Backoff = 0.9
+	}
+	if cfg.AdjustInterval <= 0 {
+		cfg.AdjustInterval = time.Second
+	}
+	return &AdaptiveLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		limit:   float64(cfg.InitialLimit),
+		signals: make(map[string]*latencySignal),
+		changed: make(chan struct{}),
+	}
+}
+
+// Acquire admits a request, waiting in the queue if the limit is reached; the
+// returned release must be called when the work finishes and is idempotent
+func (l *AdaptiveLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.admit() {
+		l.mu.Unlock()
+		return l.releaser(), nil
+	}
+	if l.cfg.QueueTimeout <= 0 || l.queued >= l.cfg.MaxQueue {
+		l.rejectedLimit++
+		l.mu.Unlock()
+		return nil, ErrLimitExceeded
+	}
+	l.queued++
+
+	timer := time.NewTimer(l.cfg.QueueTimeout)
+	defer timer.Stop()
+	for {
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			l.mu.Lock()
+			l.queued--
+			l.rejectedTimeout++
+			l.mu.Unlock()
+			return nil, ErrLimitExceeded
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.queued--
+			l.mu.Unlock()
+			return nil, ctx.Err()
+		}
+
+		l.mu.Lock()
+		if l.admit() {
+			l.queued--
+			l.mu.Unlock()
+			return l.releaser(), nil
+		}
+	}
+}
+
+// Observe records a latency sample for a downstream signal (e.g. "orchestrator", "tts")
+func (l *AdaptiveLimiter) Observe(signal string, latency time.Duration) {
+	if l == nil {
+		return
+	}
+	sample := latency.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.signals[signal]
+	if !ok {
+		l.signals[signal] = &latencySignal{short: sample, long: sample}
+		return
+	}
+	s.short += (sample - s.short) * shortLatencyWeight
+	s.long += (sample - s.long) * longLatencyWeight
+	l.adjust()
+}
+
+// Stats returns the limiter's current state
+func (l *AdaptiveLimiter) Stats() AdaptiveLimiterStats {
+	if l == nil {
+		return AdaptiveLimiterStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return AdaptiveLimiterStats{
+		Limit:           int(l.limit),
+		InFlight:        l.inFlight,
+		Queued:          l.queued,
+		RejectedLimit:   l.rejectedLimit,
+		RejectedTimeout: l.rejectedTimeout,
+	}
+}
+
+// admit takes a slot if one is free; callers hold l.mu
+func (l *AdaptiveLimiter) admit() bool {
+	if l.inFlight >= int(l.limit) {
+		return false
+	}
+	l.inFlight++
+	return true
+}
+
+// adjust moves the limit at most once per AdjustInterval; callers hold l.mu
+func (l *AdaptiveLimiter) adjust() {
+	now := l.now()
+	if now.Sub(l.lastAdjust) < l.cfg.AdjustInterval {
+		return
+	}
+
+	saturated := false
+	for _, s := range l.signals {
+		if s.short > s.long*l.cfg.Tolerance {
+			saturated = true
+			break
+		}
+	}
+
+	switch {
+	case saturated:
+		l.limit *= l.cfg.Backoff
+		if l.limit < float64(l.cfg.MinLimit) {
+			l.limit = float64(l.cfg.MinLimit)
+		}
+	case float64(l.inFlight) >= l.limit*increaseUtilization && l.limit < float64(l.cfg.MaxLimit):
+		l.limit = float64(int(l.limit) + 1)
+		l.broadcast()
+	default:
+		return
+	}
+	l.lastAdjust = now
+}
+
+// broadcast wakes queued requests; callers hold l.mu
+func (l *AdaptiveLimiter) broadcast() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+func (l *AdaptiveLimiter) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.inFlight--
+			l.broadcast()
+			l.mu.Unlock()
+		})
+	}
+}
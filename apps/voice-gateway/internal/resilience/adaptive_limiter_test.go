@@ -0,0 +1,110 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiter_ShedsOverLimit(t *testing.T) {
+	l := NewAdaptiveLimiter(AdaptiveLimiterConfig{InitialLimit: 2, MinLimit: 1, MaxLimit: 10})
+	ctx := context.Background()
+
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Expected admission under the limit, got %v", err)
+	}
+	if _, err := l.Acquire(ctx); err != nil {
+		t.Fatalf("Expected admission at the limit, got %v", err)
+	}
+	if _, err := l.Acquire(ctx); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded over the limit, got %v", err)
+	}
+
+	release()
+	release() // Releasing twice must not free a second slot
+	if _, err := l.Acquire(ctx); err != nil {
+		t.Errorf("Expected a released slot to admit, got %v", err)
+	}
+	if _, err := l.Acquire(ctx); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected the limit to hold after a double release, got %v", err)
+	}
+	if stats := l.Stats(); stats.InFlight != 2 || stats.RejectedLimit != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestAdaptiveLimiter_FollowsLatency(t *testing.T) {
+	l := NewAdaptiveLimiter(AdaptiveLimiterConfig{InitialLimit: 10, MinLimit: 2, MaxLimit: 12, Tolerance: 1.5, Backoff: 0.5})
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	observe := func(latency time.Duration) {
+		now = now.Add(2 * time.Second)
+		l.Observe("orchestrator", latency)
+	}
+
+	// Establish a baseline, then saturate
+	for i := 0; i < 20; i++ {
+		observe(200 * time.Millisecond)
+	}
+	if got := l.Stats().Limit; got != 10 {
+		t.Fatalf("Expected an idle limiter to hold its limit, got %d", got)
+	}
+	for i := 0; i < 10; i++ {
+		observe(2 * time.Second)
+	}
+	if got := l.Stats().Limit; got != 2 {
+		t.Errorf("Expected the limit to back off to the minimum, got %d", got)
+	}
+
+	// Latency recovers and the limit is in use: grow additively
+	for i := 0; i < 40; i++ {
+		observe(100 * time.Millisecond)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := l.Acquire(context.Background()); err != nil {
+			t.Fatalf("Acquire failed: %v", err)
+		}
+	}
+	observe(100 * time.Millisecond)
+	if got := l.Stats().Limit; got != 3 {
+		t.Errorf("Expected the limit to grow by one, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiter_QueuesUntilRelease(t *testing.T) {
+	l := NewAdaptiveLimiter(AdaptiveLimiterConfig{InitialLimit: 1, MinLimit: 1, MaxLimit: 1, MaxQueue: 1, QueueTimeout: time.Second})
+	release, _ := l.Acquire(context.Background())
+
+	admitted := make(chan error, 1)
+	go func() {
+		_, err := l.Acquire(context.Background())
+		admitted <- err
+	}()
+	for l.Stats().Queued != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	release()
+	if err := <-admitted; err != nil {
+		t.Errorf("Expected the queued request to be admitted, got %v", err)
+	}
+
+	// With the queue's only slot expired, a further request times out
+	l.cfg.QueueTimeout = 20 * time.Millisecond
+	if _, err := l.Acquire(context.Background()); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded after the queue timeout, got %v", err)
+	}
+	if stats := l.Stats(); stats.RejectedTimeout != 1 {
+		t.Errorf("Expected one queue timeout, got %+v", stats)
+	}
+}
+
+func TestAdaptiveLimiter_NilAdmitsEverything(t *testing.T) {
+	var l *AdaptiveLimiter
+	if _, err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("Expected a nil limiter to admit, got %v", err)
+	}
+	l.Observe("tts", time.Second)
+}
@@ -0,0 +1,119 @@
+package resilience
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// windowBuckets is the resolution of a circuit breaker's sliding window
+const windowBuckets = 10
+
+// slidingWindow counts requests and failures over the last size, in buckets
+// of size/windowBuckets
+type slidingWindow struct {
+	size    time.Duration
+	buckets [windowBuckets]windowBucket
+}
+
+type windowBucket struct {
+	start    time.Time
+	requests int64
+	failures int64
+}
+
+// add counts one request at now
+func (w *slidingWindow) add(now time.Time, failed bool) {
+	width := w.size / windowBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+	b := &w.buckets[(start.UnixNano()/int64(width))%windowBuckets]
+	if !b.start.Equal(start) {
+		*b = windowBucket{start: start}
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+}
+
+// counts sums the buckets still inside the window at now
+func (w *slidingWindow) counts(now time.Time) (requests, failures int64) {
+	for _, b := range w.buckets {
+		if b.requests > 0 && now.Sub(b.start) < w.size {
+			requests += b.requests
+			failures += b.failures
+		}
+	}
+	return
+}
+
+func (w *slidingWindow) reset() {
+	w.buckets = [windowBuckets]windowBucket{}
+}
+
+// BreakerRegistry holds one circuit breaker per downstream service, shared by
+// every call so that a failing provider trips once for the whole process
+type BreakerRegistry struct {
+	mu       sync.Mutex
+	defaults CircuitBreakerConfig
+	breakers map[string]*CircuitBreaker
+}
+
+// Breakers is the process-wide registry used by the provider clients
+var Breakers = NewBreakerRegistry(DefaultCircuitBreakerConfig())
+
+// NewBreakerRegistry creates a registry whose breakers are tuned by defaults
+func NewBreakerRegistry(defaults CircuitBreakerConfig) *BreakerRegistry {
+	return &BreakerRegistry{
+		defaults: defaults,
+		breakers: make(map[string]*CircuitBreaker),
+	}
+}
+
+// Configure sets the config for breakers created afterwards; call it at startup
+func (r *BreakerRegistry) Configure(cfg CircuitBreakerConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaults = cfg
+}
+
+// Get returns the breaker for name, creating it on first use
+func (r *BreakerRegistry) Get(name string) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cb, ok := r.breakers[name]
+	if !ok {
+		cb = NewCircuitBreakerWithConfig(name, r.defaults)
+		r.breakers[name] = cb
+	}
+	return cb
+}
+
+// Snapshots returns every breaker's state, ordered by name
+func (r *BreakerRegistry) Snapshots() []CircuitBreakerSnapshot {
+	r.mu.Lock()
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, cb := range r.breakers {
+		breakers = append(breakers, cb)
+	}
+	r.mu.Unlock()
+
+	snapshots := make([]CircuitBreakerSnapshot, 0, len(breakers))
+	for _, cb := range breakers {
+		snapshots = append(snapshots, cb.Snapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots
+}
+
+// KeyedName names a breaker for one credential of a service, e.g.
+// "deepgram:1a2b3c4d", without exposing the key
+func KeyedName(service, apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return service + ":" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,77 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// budgetWindow is how much unused allowance a RetryBudget can bank
+const budgetWindow = 10 * time.Second
+
+// RetryBudget caps extra attempts (retries, hedges) to a fraction of regular
+// traffic, so a struggling dependency is not buried under duplicate requests
+//
+// Every regular request deposits Ratio of a token and every extra attempt
+// withdraws a whole one. MinPerSecond tokens also accrue over time, so a
+// quiet instance can still retry occasionally.
+type RetryBudget struct {
+	ratio        float64
+	minPerSecond float64
+	maxBalance   float64
+
+	mu      sync.Mutex
+	balance float64
+	last    time.Time
+	now     func() time.Time
+}
+
+// NewRetryBudget creates a budget allowing ratio extra attempts per request
+// plus minPerSecond regardless of traffic; it starts full
+func NewRetryBudget(ratio, minPerSecond float64) *RetryBudget {
+	maxBalance := minPerSecond * budgetWindow.Seconds()
+	if maxBalance < 1 {
+		maxBalance = 1
+	}
+	return &RetryBudget{
+		ratio:        ratio,
+		minPerSecond: minPerSecond,
+		maxBalance:   maxBalance,
+		balance:      maxBalance,
+		last:         time.Now(),
+		now:          time.Now,
+	}
+}
+
+// Deposit records a regular request
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.balance += b.ratio
+	if b.balance > b.maxBalance {
+		b.balance = b.maxBalance
+	}
+}
+
+// TryWithdraw spends a token for an extra attempt; false means the budget is
+// exhausted and the attempt should be skipped
+func (b *RetryBudget) TryWithdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.balance < 1 {
+		return false
+	}
+	b.balance--
+	return true
+}
+
+// refill accrues the time-based allowance; callers hold b.mu
+func (b *RetryBudget) refill() {
+	now := b.now()
+	b.balance += now.Sub(b.last).Seconds() * b.minPerSecond
+	if b.balance > b.maxBalance {
+		b.balance = b.maxBalance
+	}
+	b.last = now
+}
@@ -0,0 +1,64 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryBudget_LimitsExtraAttempts(t *testing.T) {
+	b := NewRetryBudget(0.5, 0)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	// The initial allowance is a single token when there is no time-based floor
+	if !b.TryWithdraw() {
+		t.Fatal("Expected the initial token to be available")
+	}
+	if b.TryWithdraw() {
+		t.Error("Expected the budget to be exhausted")
+	}
+
+	// Two regular requests at ratio 0.5 earn one extra attempt
+	b.Deposit()
+	if b.TryWithdraw() {
+		t.Error("Expected half a token to be insufficient")
+	}
+	b.Deposit()
+	b.Deposit()
+	if !b.TryWithdraw() {
+		t.Error("Expected deposits to fund an extra attempt")
+	}
+}
+
+func TestRetryBudget_MinPerSecond(t *testing.T) {
+	b := NewRetryBudget(0, 2)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	// Drain the banked allowance (ten seconds' worth)
+	withdrawn := 0
+	for b.TryWithdraw() {
+		withdrawn++
+	}
+	if withdrawn != 20 {
+		t.Errorf("Expected 20 banked tokens, got %d", withdrawn)
+	}
+
+	now = now.Add(time.Second)
+	if !b.TryWithdraw() || !b.TryWithdraw() {
+		t.Error("Expected two tokens to accrue per second")
+	}
+	if b.TryWithdraw() {
+		t.Error("Expected no more than two tokens after one second")
+	}
+
+	// Idle time never banks more than the window
+	now = now.Add(time.Hour)
+	withdrawn = 0
+	for b.TryWithdraw() {
+		withdrawn++
+	}
+	if withdrawn != 20 {
+		t.Errorf("Expected the balance capped at 20, got %d", withdrawn)
+	}
+}
@@ -0,0 +1,124 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBulkheadFull is returned when a bulkhead has no free slot for a request
+var ErrBulkheadFull = errors.New("bulkhead full")
+
+// Bulkhead bounds concurrent requests to one provider across all calls, so a
+// misbehaving session cannot exhaust capacity shared with the others
+//
+// Requests beyond MaxConcurrent wait in a queue of at most MaxQueue for up to
+// QueueTimeout; anything more is rejected immediately. A nil *Bulkhead admits
+// everything, so limits can be disabled in configuration.
+type Bulkhead struct {
+	name         string
+	slots        chan struct{}
+	maxQueue     int32
+	queueTimeout time.Duration
+
+	queued          atomic.Int32
+	rejectedFull    atomic.Int64
+	rejectedTimeout atomic.Int64
+}
+
+// BulkheadStats is a point-in-time view of a bulkhead
+type BulkheadStats struct {
+	Capacity        int
+	InUse           int
+	Queued          int
+	RejectedFull    int64 // Rejected because the queue was full
+	RejectedTimeout int64 // Rejected after waiting QueueTimeout in the queue
+}
+
+// NewBulkhead creates a bulkhead; maxConcurrent <= 0 returns nil (unlimited)
+func NewBulkhead(name string, maxConcurrent, maxQueue int, queueTimeout time.Duration) *Bulkhead {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	if maxQueue < 0 {
+		maxQueue = 0
+	}
+	return &Bulkhead{
+		name:         name,
+		slots:        make(chan struct{}, maxConcurrent),
+		maxQueue:     int32(maxQueue),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Name returns the provider the bulkhead protects
+func (b *Bulkhead) Name() string {
+	return b.name
+}
+
+// Acquire takes a slot, queueing if none is free; the returned release must be
+// called when the request finishes and is safe to call more than once
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), err error) {
+	if b == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return b.releaser(), nil
+	default:
+	}
+
+	if b.queued.Add(1) > b.maxQueue {
+		b.queued.Add(-1)
+		b.rejectedFull.Add(1)
+		return nil, fmt.Errorf("%s: %w (queue full)", b.name, ErrBulkheadFull)
+	}
+	defer b.queued.Add(-1)
+
+	timer := time.NewTimer(b.queueTimeout)
+	defer timer.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return b.releaser(), nil
+	case <-timer.C:
+		b.rejectedTimeout.Add(1)
+		return nil, fmt.Errorf("%s: %w (queued %v)", b.name, ErrBulkheadFull, b.queueTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Execute runs fn while holding a slot
+func (b *Bulkhead) Execute(ctx context.Context, fn func() error) error {
+	release, err := b.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+// Stats returns the bulkhead's current usage
+func (b *Bulkhead) Stats() BulkheadStats {
+	if b == nil {
+		return BulkheadStats{}
+	}
+	return BulkheadStats{
+		Capacity:        cap(b.slots),
+		InUse:           len(b.slots),
+		Queued:          int(b.queued.Load()),
+		RejectedFull:    b.rejectedFull.Load(),
+		RejectedTimeout: b.rejectedTimeout.Load(),
+	}
+}
+
+func (b *Bulkhead) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-b.slots })
+	}
+}
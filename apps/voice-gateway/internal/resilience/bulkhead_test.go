@@ -0,0 +1,73 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkhead_BoundsConcurrencyAndQueue(t *testing.T) {
+	b := NewBulkhead("test", 1, 1, time.Second)
+	ctx := context.Background()
+
+	release, err := b.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Expected a free slot, got %v", err)
+	}
+
+	// The second request queues until the first releases
+	acquired := make(chan error, 1)
+	go func() {
+		r, err := b.Acquire(ctx)
+		if err == nil {
+			defer r()
+		}
+		acquired <- err
+	}()
+	for b.Stats().Queued != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The queue holds one, so a third is rejected immediately
+	if _, err := b.Acquire(ctx); !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("Expected ErrBulkheadFull with a full queue, got %v", err)
+	}
+
+	release()
+	release() // Releasing twice must not free a second slot
+	if err := <-acquired; err != nil {
+		t.Errorf("Expected the queued request to get the slot, got %v", err)
+	}
+
+	stats := b.Stats()
+	if stats.Capacity != 1 || stats.RejectedFull != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestBulkhead_QueueTimeout(t *testing.T) {
+	b := NewBulkhead("test", 1, 5, 20*time.Millisecond)
+	release, _ := b.Acquire(context.Background())
+	defer release()
+
+	err := b.Execute(context.Background(), func() error { return nil })
+	if !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("Expected ErrBulkheadFull after the queue timeout, got %v", err)
+	}
+	if stats := b.Stats(); stats.RejectedTimeout != 1 || stats.Queued != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestBulkhead_DisabledWhenUnlimited(t *testing.T) {
+	b := NewBulkhead("test", 0, 0, 0)
+	if b != nil {
+		t.Fatal("Expected nil bulkhead for an unlimited provider")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := b.Acquire(context.Background()); err != nil {
+			t.Errorf("Expected a nil bulkhead to admit everything, got %v", err)
+		}
+	}
+}
@@ -15,6 +15,43 @@ const (
 	StateHalfOpen                   // Testing if service has recovered
 )
 
+// String returns the state's name as exposed on /admin/breakers
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	}
+	return "unknown"
+}
+
+// CircuitBreakerConfig tunes when a circuit breaker trips and recovers
+type CircuitBreakerConfig struct {
+	MaxFailures  int           // Consecutive failures that open the circuit (0 disables)
+	ResetTimeout time.Duration // Time to wait before attempting half-open
+	HalfOpenMax  int           // Trial requests in half-open; as many successes close the circuit (default 3)
+
+	// The circuit also opens when FailureRateThreshold percent of the requests
+	// in the sliding Window failed, once at least MinRequests were made (0 disables)
+	FailureRateThreshold float64
+	MinRequests          int
+	Window               time.Duration // Default 1 minute
+}
+
+// DefaultCircuitBreakerConfig trips after 5 consecutive failures and retries after 30s
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		MaxFailures:  5,
+		ResetTimeout: 30 * time.Second,
+		HalfOpenMax:  3,
+		MinRequests:  10,
+		Window:       time.Minute,
+	}
+}
+
 // CircuitBreaker implements the circuit breaker pattern
 type CircuitBreaker struct {
 	name          string
@@ -30,19 +67,49 @@ type CircuitBreaker struct {
 	successCount  int
 	requestCount  int64
 	failureCountTotal int64
+
+	failureRateThreshold float64
+	minRequests          int
+	window               slidingWindow
+	lastTransition       time.Time
+	now                  func() time.Time
 }
 
 // NewCircuitBreaker creates a new circuit breaker
 func NewCircuitBreaker(name string, maxFailures int, resetTimeout time.Duration) *CircuitBreaker {
+	cfg := DefaultCircuitBreakerConfig()
+	cfg.MaxFailures = maxFailures
+	cfg.ResetTimeout = resetTimeout
+	return NewCircuitBreakerWithConfig(name, cfg)
+}
+
+// NewCircuitBreakerWithConfig creates a circuit breaker tuned by cfg
+func NewCircuitBreakerWithConfig(name string, cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.HalfOpenMax <= 0 {
+		cfg.HalfOpenMax = 3
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
 	return &CircuitBreaker{
-		name:         name,
-		maxFailures:  maxFailures,
-		resetTimeout: resetTimeout,
-		halfOpenMax:  3, // Allow 3 requests in half-open state
-		state:        StateClosed,
+		name:                 name,
+		maxFailures:          cfg.MaxFailures,
+		resetTimeout:         cfg.ResetTimeout,
+		halfOpenMax:          cfg.HalfOpenMax,
+		failureRateThreshold: cfg.FailureRateThreshold,
+		minRequests:          cfg.MinRequests,
+		window:               slidingWindow{size: cfg.Window},
+		state:                StateClosed,
+		lastTransition:       time.Now(),
+		now:                  time.Now,
 	}
 }
 
+// Name returns the breaker's name
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
+}
+
 // Call executes a function with circuit breaker protection
 func (cb *CircuitBreaker) Call(fn func() error) error {
 	// Check if we should allow the request
@@ -64,7 +131,7 @@ func (cb *CircuitBreaker) allowRequest() bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	now := time.Now()
+	now := cb.now()
 
 	switch cb.state {
 	case StateClosed:
@@ -74,9 +141,7 @@ func (cb *CircuitBreaker) allowRequest() bool {
 	case StateOpen:
 		// Circuit is open - check if we should transition to half-open
 		if now.Sub(cb.lastFailTime) >= cb.resetTimeout {
-			cb.state = StateHalfOpen
-			cb.halfOpenCount = 0
-			cb.successCount = 0
+			cb.transition(StateHalfOpen, now)
 			return true // Allow one request to test
 		}
 		return false
@@ -98,25 +163,12 @@ func (cb *CircuitBreaker) RecordResult(success bool) {
 	defer cb.mu.Unlock()
 
 	// Check if we should transition from Open to HalfOpen
-	now := time.Now()
+	now := cb.now()
 	if cb.state == StateOpen && now.Sub(cb.lastFailTime) >= cb.resetTimeout {
-		cb.state = StateHalfOpen
-		cb.halfOpenCount = 0
-		cb.successCount = 0
+		cb.transition(StateHalfOpen, now)
 	}
 
-	cb.requestCount++
-
-	// If in HalfOpen state, increment halfOpenCount
-	if cb.state == StateHalfOpen {
-		cb.halfOpenCount++
-	}
-
-	if success {
-		cb.recordSuccess()
-	} else {
-		cb.recordFailure()
-	}
+	cb.record(success, now)
 }
 
 // recordResult records the result of a request (internal)
@@ -124,7 +176,13 @@ func (cb *CircuitBreaker) recordResult(success bool) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
+	cb.record(success, cb.now())
+}
+
+// record counts a result and applies it; callers hold cb.mu
+func (cb *CircuitBreaker) record(success bool, now time.Time) {
 	cb.requestCount++
+	cb.window.add(now, !success)
 
 	// If in HalfOpen state, increment halfOpenCount
 	if cb.state == StateHalfOpen {
@@ -149,10 +207,7 @@ func (cb *CircuitBreaker) recordSuccess() {
 		cb.successCount++
 		// If we have enough successes, close the circuit
 		if cb.successCount >= cb.halfOpenMax {
-			cb.state = StateClosed
-			cb.failureCount = 0
-			cb.halfOpenCount = 0
-			cb.successCount = 0
+			cb.transition(StateClosed, cb.now())
 		}
 	}
 }
@@ -160,21 +215,44 @@ func (cb *CircuitBreaker) recordSuccess() {
 // recordFailure records a failed request
 func (cb *CircuitBreaker) recordFailure() {
 	cb.failureCountTotal++
-	cb.lastFailTime = time.Now()
+	cb.lastFailTime = cb.now()
 
 	switch cb.state {
 	case StateClosed:
 		cb.failureCount++
-		// If we exceed max failures, open the circuit
-		if cb.failureCount >= cb.maxFailures {
-			cb.state = StateOpen
+		// Open on too many consecutive failures or too high a recent failure rate
+		if (cb.maxFailures > 0 && cb.failureCount >= cb.maxFailures) || cb.failureRateExceeded(cb.lastFailTime) {
+			cb.transition(StateOpen, cb.lastFailTime)
 		}
 
 	case StateHalfOpen:
 		// Any failure in half-open immediately opens the circuit
-		cb.state = StateOpen
-		cb.halfOpenCount = 0
-		cb.successCount = 0
+		cb.transition(StateOpen, cb.lastFailTime)
+	}
+}
+
+// failureRateExceeded reports whether the sliding window trips the circuit; callers hold cb.mu
+func (cb *CircuitBreaker) failureRateExceeded(now time.Time) bool {
+	if cb.failureRateThreshold <= 0 {
+		return false
+	}
+	requests, failures := cb.window.counts(now)
+	if requests == 0 || requests < int64(cb.minRequests) {
+		return false
+	}
+	return float64(failures)/float64(requests)*100.0 >= cb.failureRateThreshold
+}
+
+// transition moves to state and starts its counts afresh; callers hold cb.mu
+func (cb *CircuitBreaker) transition(state CircuitState, now time.Time) {
+	cb.state = state
+	cb.lastTransition = now
+	cb.failureCount = 0
+	cb.halfOpenCount = 0
+	cb.successCount = 0
+	if state == StateClosed {
+		// Failures from before the outage must not re-trip a recovered circuit
+		cb.window.reset()
 	}
 }
 
@@ -201,15 +279,54 @@ func (cb *CircuitBreaker) GetStats() (state CircuitState, requestCount, failureC
 	return
 }
 
+// CircuitBreakerSnapshot is a point-in-time view of a circuit breaker
+type CircuitBreakerSnapshot struct {
+	Name                string    `json:"name"`
+	State               string    `json:"state"`
+	LastTransition      time.Time `json:"last_transition"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	WindowRequests      int64     `json:"window_requests"`
+	WindowFailures      int64     `json:"window_failures"`
+	FailureRate         float64   `json:"failure_rate"` // Percent of the window's requests that failed
+	TotalRequests       int64     `json:"total_requests"`
+	TotalFailures       int64     `json:"total_failures"`
+}
+
+// Snapshot returns the breaker's current state and sliding-window counts
+func (cb *CircuitBreaker) Snapshot() CircuitBreakerSnapshot {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	requests, failures := cb.window.counts(cb.now())
+	snapshot := CircuitBreakerSnapshot{
+		Name:                cb.name,
+		State:               cb.state.String(),
+		LastTransition:      cb.lastTransition,
+		ConsecutiveFailures: cb.failureCount,
+		WindowRequests:      requests,
+		WindowFailures:      failures,
+		TotalRequests:       cb.requestCount,
+		TotalFailures:       cb.failureCountTotal,
+	}
+	if requests > 0 {
+		snapshot.FailureRate = float64(failures) / float64(requests) * 100.0
+	}
+	return snapshot
+}
+
 // Reset manually resets the circuit breaker to closed state
 func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
+	if cb.state != StateClosed {
+		cb.lastTransition = cb.now()
+	}
 	cb.state = StateClosed
 	cb.failureCount = 0
 	cb.halfOpenCount = 0
 	cb.successCount = 0
+	cb.window.reset()
 	cb.requestCount = 0
 	cb.failureCountTotal = 0
 	cb.lastFailTime = time.Time{}
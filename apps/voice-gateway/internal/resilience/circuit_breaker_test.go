@@ -195,3 +195,77 @@ func TestCircuitBreaker_Reset(t *testing.T) {
 	}
 }
 
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig("test", CircuitBreakerConfig{
+		ResetTimeout:         time.Second,
+		FailureRateThreshold: 50,
+		MinRequests:          4,
+		Window:               10 * time.Second,
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	// Alternating results never reach a consecutive-failure count
+	cb.RecordResult(true)
+	cb.RecordResult(false)
+	cb.RecordResult(true)
+	if cb.GetState() != StateClosed {
+		t.Fatal("Expected state to be Closed below MinRequests")
+	}
+	cb.RecordResult(false)
+	if cb.GetState() != StateOpen {
+		t.Errorf("Expected state to be Open at a 50%% failure rate, got %s", cb.GetState())
+	}
+}
+
+func TestCircuitBreaker_WindowForgetsOldFailures(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig("test", CircuitBreakerConfig{
+		ResetTimeout:         time.Second,
+		FailureRateThreshold: 50,
+		MinRequests:          2,
+		Window:               10 * time.Second,
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	cb.RecordResult(false)
+	now = now.Add(15 * time.Second)
+	cb.RecordResult(true)
+	cb.RecordResult(true)
+	cb.RecordResult(false)
+	if cb.GetState() != StateClosed {
+		t.Errorf("Expected failures outside the window to be ignored, got %s", cb.GetState())
+	}
+	if snap := cb.Snapshot(); snap.WindowRequests != 3 || snap.WindowFailures != 1 {
+		t.Errorf("Expected 1 failure in 3 window requests, got %+v", snap)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenMaxConfigurable(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig("test", CircuitBreakerConfig{MaxFailures: 1, ResetTimeout: time.Second, HalfOpenMax: 1})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	cb.RecordResult(false)
+	opened := cb.Snapshot().LastTransition
+	now = now.Add(2 * time.Second)
+	cb.RecordResult(true)
+	if cb.GetState() != StateClosed {
+		t.Errorf("Expected one half-open success to close the circuit, got %s", cb.GetState())
+	}
+	if !cb.Snapshot().LastTransition.After(opened) {
+		t.Error("Expected the last transition time to advance")
+	}
+}
+
+func TestBreakerRegistry_SharesBreakersByName(t *testing.T) {
+	r := NewBreakerRegistry(DefaultCircuitBreakerConfig())
+	if r.Get("deepgram") != r.Get("deepgram") {
+		t.Error("Expected the same breaker for the same name")
+	}
+	r.Get("cartesia")
+	if snaps := r.Snapshots(); len(snaps) != 2 || snaps[0].Name != "cartesia" {
+		t.Errorf("Expected snapshots ordered by name, got %+v", snaps)
+	}
+}
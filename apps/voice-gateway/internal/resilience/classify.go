@@ -0,0 +1,114 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRetryableCodes are the gRPC codes retried unless configured otherwise
+var DefaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+	codes.Aborted,
+}
+
+// retryableErrnos are connection failures worth another attempt
+var retryableErrnos = []error{
+	syscall.ECONNREFUSED,
+	syscall.ECONNRESET,
+	syscall.ECONNABORTED,
+	syscall.ENETUNREACH,
+	syscall.EHOSTUNREACH,
+	syscall.EPIPE,
+}
+
+// ParseGRPCCodes converts code names such as "Unavailable" or "RESOURCE_EXHAUSTED"
+func ParseGRPCCodes(names []string) ([]codes.Code, error) {
+	byName := make(map[string]codes.Code)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		byName[normalizeCodeName(c.String())] = c
+	}
+
+	parsed := make([]codes.Code, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		c, ok := byName[normalizeCodeName(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown gRPC code %q", name)
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed, nil
+}
+
+func normalizeCodeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// RetryableErrors returns a classifier that retries gRPC errors with one of
+// retryableCodes, transient network errors and RetryableError-wrapped errors
+func RetryableErrors(retryableCodes []codes.Code) IsRetryableError {
+	retryable := make(map[codes.Code]bool, len(retryableCodes))
+	for _, c := range retryableCodes {
+		retryable[c] = true
+	}
+
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		if IsRetryable(err) {
+			return true
+		}
+
+		// Our own cancellation is final; an expired deadline may succeed on retry
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return true
+		}
+
+		// gRPC errors carry a status code, also when wrapped
+		var grpcErr interface{ GRPCStatus() *status.Status }
+		if errors.As(err, &grpcErr) {
+			return retryable[grpcErr.GRPCStatus().Code()]
+		}
+
+		return isTransientNetworkError(err)
+	}
+}
+
+// isTransientNetworkError recognizes timeouts and dropped or refused connections
+func isTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	for _, errno := range retryableErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+}
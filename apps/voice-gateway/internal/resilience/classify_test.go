@@ -0,0 +1,75 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryableErrors_GRPCCodes(t *testing.T) {
+	isRetryable := RetryableErrors([]codes.Code{codes.Unavailable})
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"unavailable", status.Error(codes.Unavailable, "backend down"), true},
+		{"wrapped unavailable", fmt.Errorf("failed to call ProcessText: %w", status.Error(codes.Unavailable, "")), true},
+		{"code not configured", status.Error(codes.ResourceExhausted, "quota"), false},
+		// A status decides on its code alone, whatever the message says
+		{"invalid argument mentioning timeout", status.Error(codes.InvalidArgument, "timeout must be positive"), false},
+		{"context canceled", context.Canceled, false},
+		{"context deadline", fmt.Errorf("dial: %w", context.DeadlineExceeded), true},
+		{"explicitly retryable", NewRetryableError(errors.New("try again")), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryableErrors_NetworkErrors(t *testing.T) {
+	isRetryable := RetryableErrors(DefaultRetryableCodes)
+
+	refused := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNREFUSED}
+	if !isRetryable(fmt.Errorf("send: %w", refused)) {
+		t.Error("Expected a wrapped ECONNREFUSED to be retryable")
+	}
+	if !isRetryable(&net.DNSError{Err: "lookup failed", IsTimeout: true}) {
+		t.Error("Expected a DNS timeout to be retryable")
+	}
+	if isRetryable(&net.DNSError{Err: "no such host", IsNotFound: true}) {
+		t.Error("Expected an unknown host not to be retryable")
+	}
+}
+
+func TestParseGRPCCodes(t *testing.T) {
+	parsed, err := ParseGRPCCodes([]string{"Unavailable", "RESOURCE_EXHAUSTED", " deadlineexceeded "})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded}
+	if len(parsed) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, parsed)
+	}
+	for i := range want {
+		if parsed[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, parsed[i])
+		}
+	}
+
+	if _, err := ParseGRPCCodes([]string{"NotACode"}); err == nil {
+		t.Error("Expected error for an unknown code")
+	}
+}
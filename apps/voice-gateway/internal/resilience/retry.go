@@ -1,8 +1,11 @@
 package resilience
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math"
+	"math/rand"
 	"time"
 )
 
@@ -13,6 +16,7 @@ type RetryConfig struct {
 	MaxBackoff       time.Duration // Maximum backoff duration
 	BackoffMultiplier float64       // Multiplier for exponential backoff
 	Jitter           bool          // Whether to add jitter to backoff
+	OnAttempt        AttemptFunc   // Optional; called after every attempt, e.g. for metrics
 }
 
 // DefaultRetryConfig returns a default retry configuration
@@ -32,8 +36,19 @@ type RetryableFunc func() error
 // IsRetryableError checks if an error is retryable
 type IsRetryableError func(error) bool
 
+// AttemptFunc observes one attempt: its number (from 1), its error (nil on
+// success) and the delay before the next attempt (0 when none follows)
+type AttemptFunc func(attempt int, err error, delay time.Duration)
+
 // Retry executes a function with retry logic
 func Retry(fn RetryableFunc, config *RetryConfig, isRetryable IsRetryableError) error {
+	return RetryWithContext(context.Background(), fn, config, isRetryable)
+}
+
+// RetryWithContext executes a function with retry logic, giving up as soon as
+// ctx is cancelled; the returned error then wraps both ctx.Err() and the last
+// attempt's error
+func RetryWithContext(ctx context.Context, fn RetryableFunc, config *RetryConfig, isRetryable IsRetryableError) error {
 	if config == nil {
 		config = DefaultRetryConfig()
 	}
@@ -42,47 +57,69 @@ func Retry(fn RetryableFunc, config *RetryConfig, isRetryable IsRetryableError)
 	backoff := config.InitialBackoff
 
 	for attempt := 0; attempt < config.MaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			if lastErr == nil {
+				return err
+			}
+			return fmt.Errorf("%w (last error: %w)", err, lastErr)
+		}
+
 		err := fn()
 		if err == nil {
+			config.observe(attempt, nil, 0)
 			return nil // Success
 		}
 
 		lastErr = err
 
-		// Check if error is retryable
-		if isRetryable != nil && !isRetryable(err) {
-			return err // Non-retryable error
+		// Check if error is retryable; don't sleep after the last attempt
+		if (isRetryable != nil && !isRetryable(err)) || attempt == config.MaxAttempts-1 {
+			config.observe(attempt, err, 0)
+			return err
 		}
 
-		// Don't sleep after the last attempt
-		if attempt < config.MaxAttempts-1 {
-			// Calculate backoff with exponential growth
-			sleepDuration := backoff
-
-			// Add jitter if enabled (up to 25% of backoff)
-			if config.Jitter {
-				jitter := time.Duration(float64(sleepDuration) * 0.25 * (1.0 - 0.5)) // 0-25% jitter
-				sleepDuration += jitter
-			}
+		delay := config.delay(backoff)
+		config.observe(attempt, err, delay)
 
-			// Cap at max backoff
-			if sleepDuration > config.MaxBackoff {
-				sleepDuration = config.MaxBackoff
-			}
-
-			time.Sleep(sleepDuration)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), lastErr)
+		}
 
-			// Increase backoff for next attempt
-			backoff = time.Duration(float64(backoff) * config.BackoffMultiplier)
-			if backoff > config.MaxBackoff {
-				backoff = config.MaxBackoff
-			}
+		// Increase backoff for next attempt
+		backoff = time.Duration(float64(backoff) * config.BackoffMultiplier)
+		if backoff > config.MaxBackoff {
+			backoff = config.MaxBackoff
 		}
 	}
 
 	return lastErr
 }
 
+// delay returns the wait after an attempt, adding up to 25% random jitter
+// when enabled so that clients failing together do not retry in lockstep
+func (c *RetryConfig) delay(backoff time.Duration) time.Duration {
+	sleepDuration := backoff
+	if c.Jitter {
+		sleepDuration += time.Duration(rand.Float64() * 0.25 * float64(backoff))
+	}
+
+	// Cap at max backoff
+	if sleepDuration > c.MaxBackoff {
+		sleepDuration = c.MaxBackoff
+	}
+	return sleepDuration
+}
+
+func (c *RetryConfig) observe(attempt int, err error, delay time.Duration) {
+	if c.OnAttempt != nil {
+		c.OnAttempt(attempt+1, err, delay)
+	}
+}
+
 // RetryWithExponentialBackoff is a convenience function for retry with exponential backoff
 func RetryWithExponentialBackoff(fn RetryableFunc, maxAttempts int, initialBackoff time.Duration) error {
 	config := &RetryConfig{
@@ -105,62 +142,13 @@ func CalculateBackoff(attempt int, initialBackoff time.Duration, maxBackoff time
 	return backoff
 }
 
-// IsRetryableNetworkError checks if an error is a retryable network error
+// IsRetryableNetworkError checks if an error is a retryable network error,
+// retrying gRPC errors with one of DefaultRetryableCodes
 func IsRetryableNetworkError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := err.Error()
-	
-	// Connection errors
-	if containsAny(errStr, []string{
-		"connection refused",
-		"connection reset",
-		"connection closed",
-		"transport is closing",
-		"unavailable",
-		"network is unreachable",
-		"no route to host",
-	}) {
-		return true
-	}
-
-	// Timeout errors
-	if containsAny(errStr, []string{
-		"deadline exceeded",
-		"context deadline exceeded",
-		"timeout",
-		"i/o timeout",
-	}) {
-		return true
-	}
-
-	// Resource exhaustion (may be temporary)
-	if containsAny(errStr, []string{
-		"resource exhausted",
-		"too many connections",
-		"rate limit",
-	}) {
-		return true
-	}
-
-	return false
+	return defaultRetryable(err)
 }
 
-// containsAny checks if a string contains any of the substrings
-func containsAny(s string, substrings []string) bool {
-	for _, substr := range substrings {
-		if len(s) >= len(substr) {
-			for i := 0; i <= len(s)-len(substr); i++ {
-				if s[i:i+len(substr)] == substr {
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
+var defaultRetryable = RetryableErrors(DefaultRetryableCodes)
 
 // RetryableError wraps an error to indicate it's retryable
 type RetryableError struct {
@@ -1,9 +1,17 @@
 package resilience
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
 	"testing"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestRetry_Success(t *testing.T) {
@@ -122,13 +130,14 @@ func TestIsRetryableNetworkError(t *testing.T) {
 		err      error
 		expected bool
 	}{
-		{"connection refused", errors.New("connection refused"), true},
-		{"connection reset", errors.New("connection reset"), true},
-		{"unavailable", errors.New("unavailable"), true},
-		{"deadline exceeded", errors.New("deadline exceeded"), true},
-		{"timeout", errors.New("timeout"), true},
-		{"resource exhausted", errors.New("resource exhausted"), true},
-		{"rate limit", errors.New("rate limit"), true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, true},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"unavailable", status.Error(codes.Unavailable, "backend down"), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"resource exhausted", status.Error(codes.ResourceExhausted, "quota"), true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		// Messages alone are not classified, whatever they say
+		{"text only", errors.New("connection refused: timeout"), false},
 		{"other error", errors.New("other error"), false},
 		{"nil error", nil, false},
 	}
@@ -204,3 +213,100 @@ func TestNewRetryableError(t *testing.T) {
 	}
 }
 
+func TestRetryWithContext_CancelledDuringBackoff(t *testing.T) {
+	config := &RetryConfig{
+		MaxAttempts:       5,
+		InitialBackoff:    time.Hour,
+		MaxBackoff:        time.Hour,
+		BackoffMultiplier: 2.0,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	attemptErr := errors.New("unavailable")
+	start := time.Now()
+	err := RetryWithContext(ctx, func() error {
+		attempts++
+		return attemptErr
+	}, config, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, attemptErr) {
+		t.Errorf("Expected the deadline and last attempt error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt before cancellation, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected cancellation to interrupt the backoff, took %v", elapsed)
+	}
+}
+
+func TestRetryWithContext_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := RetryWithContext(ctx, func() error {
+		attempts++
+		return nil
+	}, DefaultRetryConfig(), nil)
+
+	if !errors.Is(err, context.Canceled) || attempts != 0 {
+		t.Errorf("Expected no attempts and context.Canceled, got %d attempts and %v", attempts, err)
+	}
+}
+
+func TestRetry_OnAttempt(t *testing.T) {
+	type attempt struct {
+		n     int
+		err   bool
+		delay time.Duration
+	}
+	var seen []attempt
+	config := &RetryConfig{
+		MaxAttempts:       3,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        10 * time.Millisecond,
+		BackoffMultiplier: 2.0,
+		OnAttempt: func(n int, err error, delay time.Duration) {
+			seen = append(seen, attempt{n, err != nil, delay})
+		},
+	}
+
+	calls := 0
+	_ = Retry(func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	}, config, nil)
+
+	want := []attempt{{1, true, time.Millisecond}, {2, true, 2 * time.Millisecond}, {3, false, 0}}
+	if len(seen) != len(want) {
+		t.Fatalf("Expected %d callbacks, got %+v", len(want), seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("Attempt %d: expected %+v, got %+v", i+1, want[i], seen[i])
+		}
+	}
+}
+
+func TestRetryConfig_Jitter(t *testing.T) {
+	config := &RetryConfig{MaxBackoff: time.Hour, Jitter: true}
+	backoff := 100 * time.Millisecond
+
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < 50; i++ {
+		delay := config.delay(backoff)
+		if delay < backoff || delay >= backoff+backoff/4 {
+			t.Fatalf("Expected delay within 25%% above %v, got %v", backoff, delay)
+		}
+		distinct[delay] = true
+	}
+	if len(distinct) < 2 {
+		t.Error("Expected jitter to vary between attempts")
+	}
+}
@@ -0,0 +1,198 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+	"github.com/rs/zerolog"
+)
+
+// storedKinds are the kinds of call data the gateway keeps in storage, each
+// under "<kind>/<firm>/<call sid>.<ext>"
+var storedKinds = []string{firm.DataRecordings, firm.DataVoicemail}
+
+// ErrInvalidID is returned for a firm or call ID that can't name stored data
+var ErrInvalidID = errors.New("invalid firm or call ID")
+
+// erasurePublishTimeout bounds delivery of the data.erased event
+const erasurePublishTimeout = 10 * time.Second
+
+// Erasure reports what a right-to-erasure request removed
+type Erasure struct {
+	FirmID    string    `json:"firm_id"`
+	CallSid   string    `json:"call_sid"`
+	Objects   []string  `json:"objects"`   // Storage keys deleted
+	Callbacks []string  `json:"callbacks"` // Callback IDs canceled and cleared
+	ErasedAt  time.Time `json:"erased_at"`
+}
+
+// Engine enforces firms' retention policies on stored call data and erases a
+// call's data on request
+type Engine struct {
+	store     storage.Store
+	firms     *firm.Registry
+	callbacks *callback.Scheduler
+	publisher events.Publisher
+	logger    zerolog.Logger
+	now       func() time.Time
+}
+
+// NewEngine creates an engine; callbacks may be nil when outbound calling is
+// not configured. Run starts the janitor
+func NewEngine(store storage.Store, firms *firm.Registry, callbacks *callback.Scheduler, publisher events.Publisher, logger zerolog.Logger) *Engine {
+	return &Engine{
+		store:     store,
+		firms:     firms,
+		callbacks: callbacks,
+		publisher: publisher,
+		logger:    logger.With().Str("component", "retention").Logger(),
+		now:       time.Now,
+	}
+}
+
+// Run sweeps expired data every interval until ctx is cancelled
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if deleted, err := e.Sweep(ctx); err != nil {
+			e.logger.Warn().Err(err).Int("deleted", deleted).Msg("Retention sweep left expired call data behind")
+		} else if deleted > 0 {
+			e.logger.Info().Int("deleted", deleted).Msg("Retention sweep deleted expired call data")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sweep deletes the stored objects older than their firm's retention period
+// and returns how many it deleted. An object that fails to delete doesn't stop
+// the sweep; the failures are returned together once it finishes
+func (e *Engine) Sweep(ctx context.Context) (int, error) {
+	now := e.now()
+	periods := make(map[string]firm.RetentionSettings)
+	deleted := 0
+	var errs []error
+	for _, kind := range storedKinds {
+		objects, err := e.store.List(ctx, kind+"/")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list %s: %w", kind, err))
+			continue
+		}
+		for _, obj := range objects {
+			firmID, _, ok := parseKey(obj.Key)
+			if !ok {
+				continue
+			}
+			policy, seen := periods[firmID]
+			if !seen {
+				policy = e.firms.Get(firmID).Retention
+				periods[firmID] = policy
+			}
+			period := policy.Period(kind)
+			if period == 0 || now.Sub(obj.LastModified) < period {
+				continue
+			}
+			if err := e.store.Delete(ctx, obj.Key); err != nil {
+				errs = append(errs, fmt.Errorf("delete %s: %w", obj.Key, err))
+				continue
+			}
+			deleted++
+			observability.RecordDataDeletion(kind, "expired")
+			e.logger.Debug().Str("key", obj.Key).Time("stored_at", obj.LastModified).Msg("Deleted expired call data")
+		}
+	}
+	if len(errs) > 0 {
+		return deleted, fmt.Errorf("%d retention sweep errors: %w", len(errs), errors.Join(errs...))
+	}
+	return deleted, nil
+}
+
+// Erase deletes everything the gateway holds for a call (recordings,
+// voicemail, callbacks) and publishes data.erased so downstream sinks delete
+// their copies of its records and transcripts
+func (e *Engine) Erase(ctx context.Context, firmID, callSid string) (*Erasure, error) {
+	if !validSegment(firmID) || !validSegment(callSid) {
+		return nil, ErrInvalidID
+	}
+	erasure := &Erasure{FirmID: firmID, CallSid: callSid, Objects: []string{}, Callbacks: []string{}}
+
+	for _, kind := range storedKinds {
+		objects, err := e.store.List(ctx, kind+"/"+firmID+"/"+callSid)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			if _, sid, ok := parseKey(obj.Key); !ok || sid != callSid {
+				continue // A longer call SID sharing the prefix
+			}
+			if err := e.store.Delete(ctx, obj.Key); err != nil {
+				return nil, err
+			}
+			erasure.Objects = append(erasure.Objects, obj.Key)
+			observability.RecordDataDeletion(kind, "erasure")
+		}
+	}
+
+	if e.callbacks != nil {
+		ids, err := e.callbacks.Erase(ctx, firmID, callSid)
+		erasure.Callbacks = append(erasure.Callbacks, ids...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase callbacks: %w", err)
+		}
+	}
+	erasure.ErasedAt = e.now().UTC()
+
+	e.logger.Info().
+		Str("firm_id", firmID).
+		Str("call_sid", callSid).
+		Int("objects", len(erasure.Objects)).
+		Int("callbacks", len(erasure.Callbacks)).
+		Msg("Call data erased")
+
+	if e.publisher != nil {
+		pubCtx, cancel := context.WithTimeout(context.Background(), erasurePublishTimeout)
+		defer cancel()
+		if err := e.publisher.Publish(pubCtx, events.NewEvent(events.TypeDataErased, callSid, firmID, erasure)); err != nil {
+			return erasure, fmt.Errorf("data erased, but failed to notify downstream sinks: %w", err)
+		}
+	}
+	return erasure, nil
+}
+
+// Open returns a call's stored audio of kind (firm.DataRecordings or
+// firm.DataVoicemail); storage.ErrNotFound when there is none
+func (e *Engine) Open(ctx context.Context, kind, firmID, callSid string) (io.ReadCloser, error) {
+	if !validSegment(firmID) || !validSegment(callSid) {
+		return nil, ErrInvalidID
+	}
+	return e.store.Get(ctx, kind+"/"+firmID+"/"+callSid+".wav")
+}
+
+// parseKey splits "<kind>/<firm>/<call sid>.<ext>"
+func parseKey(key string) (firmID, callSid string, ok bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 {
+		return "", "", false
+	}
+	name := parts[2]
+	return parts[1], strings.TrimSuffix(name, path.Ext(name)), true
+}
+
+// validSegment rejects IDs that would reach outside a firm's or call's keys
+func validSegment(id string) bool {
+	return id != "" && !strings.ContainsAny(id, "/\\") && !strings.Contains(id, "..")
+}
@@ -0,0 +1,181 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+	"github.com/rs/zerolog"
+)
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []*events.Event
+}
+
+func (l *eventLog) Publish(ctx context.Context, event *events.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	return nil
+}
+
+// newTestEngine returns an engine over a local store, where acme keeps
+// recordings 30 days and voicemail 7, and globex has no policy
+func newTestEngine(t *testing.T) (*Engine, string, *eventLog) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "firms.json")
+	firmsJSON := `{"firms": {"acme": {"retention": {"recording_days": 30, "voicemail_days": 7}}}}`
+	if err := os.WriteFile(path, []byte(firmsJSON), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	base := filepath.Join(dir, "store")
+	store, err := storage.NewLocalStore(base)
+	if err != nil {
+		t.Fatalf("NewLocalStore failed: %v", err)
+	}
+	log := &eventLog{}
+	e := NewEngine(store, firms, nil, log, zerolog.Nop())
+	now := time.Date(2024, 3, 12, 14, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	return e, base, log
+}
+
+// put stores an object and backdates it to storedAt
+func put(t *testing.T, e *Engine, base, key string, storedAt time.Time) {
+	t.Helper()
+	if _, err := e.store.Put(context.Background(), key, strings.NewReader("audio"), "audio/wav"); err != nil {
+		t.Fatalf("Put %s failed: %v", key, err)
+	}
+	if err := os.Chtimes(filepath.Join(base, filepath.FromSlash(key)), storedAt, storedAt); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+}
+
+func exists(e *Engine, key string) bool {
+	r, err := e.store.Get(context.Background(), key)
+	if err != nil {
+		return false
+	}
+	r.Close()
+	return true
+}
+
+func TestEngine_SweepDeletesExpiredData(t *testing.T) {
+	e, base, _ := newTestEngine(t)
+	now := e.now()
+	days := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+
+	put(t, e, base, "recordings/acme/CA1.wav", days(31))
+	put(t, e, base, "recordings/acme/CA2.wav", days(29))
+	put(t, e, base, "voicemail/acme/CA3.wav", days(8))
+	put(t, e, base, "voicemail/acme/CA4.wav", days(6))
+	put(t, e, base, "recordings/globex/CA5.wav", days(400))
+
+	deleted, err := e.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected 2 deletions, got %d", deleted)
+	}
+	for key, want := range map[string]bool{
+		"recordings/acme/CA1.wav":   false,
+		"recordings/acme/CA2.wav":   true,
+		"voicemail/acme/CA3.wav":    false,
+		"voicemail/acme/CA4.wav":    true,
+		"recordings/globex/CA5.wav": true, // No policy: kept indefinitely
+	} {
+		if got := exists(e, key); got != want {
+			t.Errorf("%s: expected exists=%v, got %v", key, want, got)
+		}
+	}
+}
+
+// failingStore fails deletes of one key
+type failingStore struct {
+	storage.Store
+	key string
+}
+
+func (s failingStore) Delete(ctx context.Context, key string) error {
+	if key == s.key {
+		return errors.New("access denied")
+	}
+	return s.Store.Delete(ctx, key)
+}
+
+func TestEngine_SweepContinuesPastDeleteErrors(t *testing.T) {
+	e, base, _ := newTestEngine(t)
+	now := e.now()
+	put(t, e, base, "recordings/acme/CA1.wav", now.AddDate(0, 0, -31))
+	put(t, e, base, "recordings/acme/CA2.wav", now.AddDate(0, 0, -31))
+	put(t, e, base, "voicemail/acme/CA3.wav", now.AddDate(0, 0, -8))
+	e.store = failingStore{Store: e.store, key: "recordings/acme/CA1.wav"}
+
+	deleted, err := e.Sweep(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "recordings/acme/CA1.wav") {
+		t.Errorf("Expected the failed delete reported, got %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected the other 2 expired objects deleted, got %d", deleted)
+	}
+	if !exists(e, "recordings/acme/CA1.wav") || exists(e, "voicemail/acme/CA3.wav") {
+		t.Error("Expected only the failed object left behind")
+	}
+}
+
+func TestEngine_EraseCall(t *testing.T) {
+	e, base, log := newTestEngine(t)
+	now := e.now()
+	put(t, e, base, "recordings/acme/CA1.wav", now)
+	put(t, e, base, "voicemail/acme/CA1.wav", now)
+	put(t, e, base, "recordings/acme/CA10.wav", now)
+	put(t, e, base, "recordings/globex/CA1.wav", now)
+
+	erasure, err := e.Erase(context.Background(), "acme", "CA1")
+	if err != nil {
+		t.Fatalf("Erase failed: %v", err)
+	}
+	if len(erasure.Objects) != 2 {
+		t.Errorf("Expected 2 objects erased, got %v", erasure.Objects)
+	}
+	if exists(e, "recordings/acme/CA1.wav") || exists(e, "voicemail/acme/CA1.wav") {
+		t.Error("Expected the call's recording and voicemail to be deleted")
+	}
+	if !exists(e, "recordings/acme/CA10.wav") || !exists(e, "recordings/globex/CA1.wav") {
+		t.Error("Expected other calls' data to be kept")
+	}
+
+	if len(log.events) != 1 || log.events[0].Type != events.TypeDataErased || log.events[0].CallSid != "CA1" {
+		t.Fatalf("Expected one data.erased event for CA1, got %+v", log.events)
+	}
+
+	// Repeating the request erases nothing more but notifies again
+	erasure, err = e.Erase(context.Background(), "acme", "CA1")
+	if err != nil || len(erasure.Objects) != 0 || len(log.events) != 2 {
+		t.Errorf("Expected an empty, re-notified erasure, got %+v, %v", erasure, err)
+	}
+}
+
+func TestEngine_EraseRejectsInvalidIDs(t *testing.T) {
+	e, _, _ := newTestEngine(t)
+	for _, ids := range [][2]string{{"", "CA1"}, {"acme", ""}, {"acme", "../CA1"}, {"ac/me", "CA1"}} {
+		if _, err := e.Erase(context.Background(), ids[0], ids[1]); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Erase(%q, %q): expected ErrInvalidID, got %v", ids[0], ids[1], err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package routing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+// Decision is the outcome of evaluating a firm's routing policy for a call
+type Decision struct {
+	Action     string `json:"action"`                // ai, voicemail, or transfer
+	Reason     string `json:"reason"`                // Why the action was chosen
+	TransferTo string `json:"transfer_to,omitempty"` // Number for the transfer action
+	Override   bool   `json:"override,omitempty"`    // True when an admin override applied
+}
+
+// Override forces a routing action for a firm until it expires
+type Override struct {
+	FirmID     string    `json:"firm_id"`
+	Action     string    `json:"action"`
+	TransferTo string    `json:"transfer_to,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"` // Zero means until removed
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Engine evaluates routing policies and holds live admin overrides
+type Engine struct {
+	mu        sync.RWMutex
+	overrides map[string]*Override
+}
+
+// NewEngine creates a routing engine with no overrides
+func NewEngine() *Engine {
+	return &Engine{
+		overrides: make(map[string]*Override),
+	}
+}
+
+// Decide evaluates the policy for a call to firmID at time now
+// Precedence: admin override, voicemail "always", holiday, business hours
+func (e *Engine) Decide(firmID string, settings *firm.Settings, now time.Time) Decision {
+	if o := e.activeOverride(firmID, now); o != nil {
+		reason := "override"
+		if o.Reason != "" {
+			reason = "override: " + o.Reason
+		}
+		return Decision{Action: o.Action, Reason: reason, TransferTo: o.TransferTo, Override: true}
+	}
+
+	if settings.Voicemail.Mode == firm.VoicemailAlways {
+		return Decision{Action: firm.ActionVoicemail, Reason: "voicemail_always"}
+	}
+
+	if holiday, ok := settings.HolidayAt(now); ok {
+		action := settings.Routing.HolidayAction
+		if action == "" {
+			action = closedAction(settings)
+		}
+		reason := "holiday"
+		if holiday.Name != "" {
+			reason = "holiday: " + holiday.Name
+		}
+		return decision(action, reason, settings)
+	}
+
+	if settings.IsOpen(now) {
+		action := settings.Routing.OpenAction
+		if action == "" {
+			action = firm.ActionAI
+		}
+		return decision(action, "business_hours", settings)
+	}
+
+	return decision(closedAction(settings), "after_hours", settings)
+}
+
+// Overflow reroutes a call that would reach the AI while it cannot take the
+// call (the firm is at its concurrent call limit, or the gateway is shedding
+// load); reason records which. Other decisions are returned unchanged
+func Overflow(d Decision, settings *firm.Settings, reason string) Decision {
+	if d.Action != firm.ActionAI {
+		return d
+	}
+	action := settings.Routing.OverflowAction
+	if action == "" {
+		action = firm.ActionVoicemail
+	}
+	overflow := decision(action, reason, settings)
+	overflow.Override = d.Override
+	return overflow
+}
+
+// OverBudget reroutes a call that would reach the AI after the firm has
+// reached its monthly spend cap. Other decisions are returned unchanged
+func OverBudget(d Decision, settings *firm.Settings) Decision {
+	if d.Action != firm.ActionAI {
+		return d
+	}
+	action := settings.Budget.Action
+	if action == "" {
+		action = firm.ActionVoicemail
+	}
+	diverted := decision(action, "budget_exceeded", settings)
+	diverted.Override = d.Override
+	return diverted
+}
+
+// closedAction resolves the action outside business hours
+func closedAction(settings *firm.Settings) string {
+	if settings.Routing.ClosedAction != "" {
+		return settings.Routing.ClosedAction
+	}
+	if settings.Voicemail.Mode == firm.VoicemailAfterHours {
+		return firm.ActionVoicemail
+	}
+	return firm.ActionAI
+}
+
+// decision builds a Decision, attaching the transfer number when needed
+func decision(action, reason string, settings *firm.Settings) Decision {
+	d := Decision{Action: action, Reason: reason}
+	if action == firm.ActionTransfer {
+		d.TransferTo = settings.Routing.TransferNumber
+	}
+	return d
+}
+
+// SetOverride installs or replaces a firm's override
+func (e *Engine) SetOverride(o Override) error {
+	if o.FirmID == "" {
+		return fmt.Errorf("firm_id is required")
+	}
+	if !firm.ValidAction(o.Action) {
+		return fmt.Errorf("invalid action %q", o.Action)
+	}
+	if o.Action == firm.ActionTransfer && o.TransferTo == "" {
+		return fmt.Errorf("transfer_to is required for the transfer action")
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = time.Now().UTC()
+	}
+
+	e.mu.Lock()
+	e.overrides[o.FirmID] = &o
+	e.mu.Unlock()
+	return nil
+}
+
+// ClearOverride removes a firm's override; returns false if none existed
+func (e *Engine) ClearOverride(firmID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.overrides[firmID]
+	delete(e.overrides, firmID)
+	return ok
+}
+
+// Overrides returns the active overrides sorted by firm ID
+func (e *Engine) Overrides() []Override {
+	now := time.Now()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	result := make([]Override, 0, len(e.overrides))
+	for _, o := range e.overrides {
+		if o.ExpiresAt.IsZero() || now.Before(o.ExpiresAt) {
+			result = append(result, *o)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].FirmID < result[j].FirmID })
+	return result
+}
+
+// activeOverride returns the firm's unexpired override, pruning expired ones
+func (e *Engine) activeOverride(firmID string, now time.Time) *Override {
+	e.mu.RLock()
+	o, ok := e.overrides[firmID]
+	e.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	if !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt) {
+		e.mu.Lock()
+		if e.overrides[firmID] == o {
+			delete(e.overrides, firmID)
+		}
+		e.mu.Unlock()
+		return nil
+	}
+	return o
+}
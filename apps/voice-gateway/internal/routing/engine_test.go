@@ -0,0 +1,167 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+func testSettings() *firm.Settings {
+	settings := firm.DefaultSettings()
+	settings.Timezone = "UTC"
+	settings.BusinessHours = firm.BusinessHours{
+		"monday": {{Open: "09:00", Close: "17:00"}},
+	}
+	return settings
+}
+
+var (
+	mondayOpen   = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	mondayClosed = time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+)
+
+func TestEngine_BusinessHours(t *testing.T) {
+	engine := NewEngine()
+	settings := testSettings()
+
+	d := engine.Decide("firm-1", settings, mondayOpen)
+	if d.Action != firm.ActionAI || d.Reason != "business_hours" {
+		t.Errorf("Expected ai/business_hours, got %+v", d)
+	}
+
+	// Without a closed action or after-hours voicemail, closed calls still go to the AI
+	d = engine.Decide("firm-1", settings, mondayClosed)
+	if d.Action != firm.ActionAI || d.Reason != "after_hours" {
+		t.Errorf("Expected ai/after_hours, got %+v", d)
+	}
+}
+
+func TestEngine_AfterHoursVoicemail(t *testing.T) {
+	engine := NewEngine()
+	settings := testSettings()
+	settings.Voicemail.Mode = firm.VoicemailAfterHours
+
+	d := engine.Decide("firm-1", settings, mondayClosed)
+	if d.Action != firm.ActionVoicemail {
+		t.Errorf("Expected voicemail after hours, got %+v", d)
+	}
+}
+
+func TestEngine_HolidayTransfer(t *testing.T) {
+	engine := NewEngine()
+	settings := testSettings()
+	settings.Holidays = []firm.Holiday{{Date: "2024-01-01", Name: "New Year"}}
+	settings.Routing.HolidayAction = firm.ActionTransfer
+	settings.Routing.TransferNumber = "+15551234567"
+
+	d := engine.Decide("firm-1", settings, mondayOpen)
+	if d.Action != firm.ActionTransfer || d.TransferTo != "+15551234567" {
+		t.Errorf("Expected transfer on holiday, got %+v", d)
+	}
+	if d.Reason != "holiday: New Year" {
+		t.Errorf("Expected holiday reason, got '%s'", d.Reason)
+	}
+}
+
+func TestEngine_Override(t *testing.T) {
+	engine := NewEngine()
+	settings := testSettings()
+
+	err := engine.SetOverride(Override{
+		FirmID:    "firm-1",
+		Action:    firm.ActionVoicemail,
+		Reason:    "office closed for storm",
+		ExpiresAt: mondayOpen.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("SetOverride() failed: %v", err)
+	}
+
+	d := engine.Decide("firm-1", settings, mondayOpen)
+	if !d.Override || d.Action != firm.ActionVoicemail {
+		t.Errorf("Expected override to apply, got %+v", d)
+	}
+
+	// Other firms are unaffected
+	if d := engine.Decide("firm-2", settings, mondayOpen); d.Override {
+		t.Error("Expected override to apply only to firm-1")
+	}
+
+	// Expired override no longer applies
+	d = engine.Decide("firm-1", settings, mondayOpen.Add(2*time.Hour))
+	if d.Override {
+		t.Errorf("Expected expired override to be ignored, got %+v", d)
+	}
+}
+
+func TestEngine_OverrideValidation(t *testing.T) {
+	engine := NewEngine()
+
+	if err := engine.SetOverride(Override{FirmID: "firm-1", Action: "fax"}); err == nil {
+		t.Error("Expected error for invalid action")
+	}
+	if err := engine.SetOverride(Override{FirmID: "firm-1", Action: firm.ActionTransfer}); err == nil {
+		t.Error("Expected error for transfer without number")
+	}
+	if err := engine.SetOverride(Override{Action: firm.ActionAI}); err == nil {
+		t.Error("Expected error for missing firm_id")
+	}
+}
+
+func TestEngine_ClearOverride(t *testing.T) {
+	engine := NewEngine()
+	engine.SetOverride(Override{FirmID: "firm-1", Action: firm.ActionVoicemail})
+
+	if len(engine.Overrides()) != 1 {
+		t.Fatalf("Expected 1 override, got %d", len(engine.Overrides()))
+	}
+	if !engine.ClearOverride("firm-1") {
+		t.Error("Expected ClearOverride to report removal")
+	}
+	if engine.ClearOverride("firm-1") {
+		t.Error("Expected second ClearOverride to report nothing removed")
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	settings := firm.DefaultSettings()
+
+	d := Overflow(Decision{Action: firm.ActionAI, Reason: "business_hours"}, settings, "concurrency_limit")
+	if d.Action != firm.ActionVoicemail || d.Reason != "concurrency_limit" {
+		t.Errorf("Expected voicemail overflow, got %+v", d)
+	}
+
+	settings.Routing.OverflowAction = firm.ActionTransfer
+	settings.Routing.TransferNumber = "+15550100"
+	d = Overflow(Decision{Action: firm.ActionAI}, settings, "concurrency_limit")
+	if d.Action != firm.ActionTransfer || d.TransferTo != "+15550100" {
+		t.Errorf("Expected transfer overflow, got %+v", d)
+	}
+
+	d = Overflow(Decision{Action: firm.ActionVoicemail, Reason: "after_hours"}, settings, "concurrency_limit")
+	if d.Reason != "after_hours" {
+		t.Errorf("Expected non-AI decision unchanged, got %+v", d)
+	}
+}
+
+func TestOverBudget(t *testing.T) {
+	settings := firm.DefaultSettings()
+
+	d := OverBudget(Decision{Action: firm.ActionAI, Reason: "business_hours"}, settings)
+	if d.Action != firm.ActionVoicemail || d.Reason != "budget_exceeded" {
+		t.Errorf("Expected voicemail for an exhausted budget, got %+v", d)
+	}
+
+	settings.Budget.Action = firm.ActionTransfer
+	settings.Routing.TransferNumber = "+15550100"
+	d = OverBudget(Decision{Action: firm.ActionAI}, settings)
+	if d.Action != firm.ActionTransfer || d.TransferTo != "+15550100" {
+		t.Errorf("Expected transfer for an exhausted budget, got %+v", d)
+	}
+
+	d = OverBudget(Decision{Action: firm.ActionVoicemail, Reason: "after_hours"}, settings)
+	if d.Reason != "after_hours" {
+		t.Errorf("Expected non-AI decision unchanged, got %+v", d)
+	}
+}
@@ -0,0 +1,72 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Reputation is a caller's spam reputation
+type Reputation struct {
+	Score float64 `json:"score"` // 0 (clean) to 1 (certain spam)
+}
+
+// ReputationChecker looks up a caller's reputation
+type ReputationChecker interface {
+	Check(ctx context.Context, number string) (Reputation, error)
+}
+
+// HTTPReputation queries a reputation API with GET <url>?phone_number=<number>
+// The API must return JSON containing a "score" between 0 and 1
+type HTTPReputation struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTPReputation creates a reputation checker for the given endpoint
+func NewHTTPReputation(endpoint, apiKey string, timeout time.Duration) *HTTPReputation {
+	return &HTTPReputation{
+		url:        endpoint,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Check implements ReputationChecker
+func (h *HTTPReputation) Check(ctx context.Context, number string) (Reputation, error) {
+	u, err := url.Parse(h.url)
+	if err != nil {
+		return Reputation{}, fmt.Errorf("invalid reputation URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("phone_number", number)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Reputation{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return Reputation{}, fmt.Errorf("reputation lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Reputation{}, fmt.Errorf("reputation lookup returned status %d", resp.StatusCode)
+	}
+
+	var rep Reputation
+	if err := json.NewDecoder(resp.Body).Decode(&rep); err != nil {
+		return Reputation{}, fmt.Errorf("failed to decode reputation: %w", err)
+	}
+	return rep, nil
+}
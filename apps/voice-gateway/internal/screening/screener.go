@@ -0,0 +1,171 @@
+package screening
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Verdict is the outcome of screening a caller
+type Verdict string
+
+const (
+	// Allow engages the full pipeline immediately
+	Allow Verdict = "allow"
+
+	// Challenge requires the caller to pass a voice/keypress challenge first
+	Challenge Verdict = "challenge"
+
+	// Block rejects the call without engaging the pipeline
+	Block Verdict = "block"
+)
+
+// Challenge modes (per firm)
+const (
+	ChallengeOff        = "off"        // Never challenge
+	ChallengeSuspicious = "suspicious" // Challenge anonymous or low-reputation callers
+	ChallengeAlways     = "always"     // Challenge every caller
+)
+
+// Result describes a screening decision
+type Result struct {
+	Verdict Verdict `json:"verdict"`
+	Reason  string  `json:"reason,omitempty"`
+	Score   float64 `json:"score,omitempty"` // Reputation spam score, when looked up
+}
+
+// Policy is the per-firm screening configuration applied to a call
+type Policy struct {
+	Blocklist     []string
+	ChallengeMode string
+}
+
+// Config holds the process-wide screening configuration
+type Config struct {
+	// BlockScore is the reputation score at or above which calls are blocked
+	BlockScore float64
+
+	// ChallengeScore is the reputation score at or above which suspicious
+	// callers are challenged
+	ChallengeScore float64
+}
+
+// Screener decides whether a caller is allowed, challenged, or blocked
+type Screener struct {
+	cfg        Config
+	blocklist  map[string]struct{}
+	reputation ReputationChecker
+}
+
+// NewScreener creates a screener; blocklist and reputation may be empty/nil
+func NewScreener(cfg Config, blocklist []string, reputation ReputationChecker) *Screener {
+	s := &Screener{
+		cfg:        cfg,
+		blocklist:  make(map[string]struct{}, len(blocklist)),
+		reputation: reputation,
+	}
+	for _, number := range blocklist {
+		if n := NormalizeNumber(number); n != "" {
+			s.blocklist[n] = struct{}{}
+		}
+	}
+	return s
+}
+
+// Screen evaluates the caller number against the blocklists, the reputation
+// service, and the firm's challenge mode
+// Reputation lookup failures fail open so a provider outage never drops calls
+func (s *Screener) Screen(ctx context.Context, number string, policy Policy) (Result, error) {
+	normalized := NormalizeNumber(number)
+
+	if normalized != "" {
+		if _, ok := s.blocklist[normalized]; ok {
+			return Result{Verdict: Block, Reason: "blocklist"}, nil
+		}
+		for _, blocked := range policy.Blocklist {
+			if NormalizeNumber(blocked) == normalized {
+				return Result{Verdict: Block, Reason: "firm_blocklist"}, nil
+			}
+		}
+	}
+
+	if policy.ChallengeMode == ChallengeAlways {
+		return Result{Verdict: Challenge, Reason: "always"}, nil
+	}
+
+	suspicious := policy.ChallengeMode == ChallengeSuspicious
+	if normalized == "" {
+		if suspicious {
+			return Result{Verdict: Challenge, Reason: "anonymous"}, nil
+		}
+		return Result{Verdict: Allow}, nil
+	}
+
+	if s.reputation == nil {
+		return Result{Verdict: Allow}, nil
+	}
+
+	rep, err := s.reputation.Check(ctx, normalized)
+	if err != nil {
+		return Result{Verdict: Allow, Reason: "reputation_unavailable"}, err
+	}
+
+	switch {
+	case rep.Score >= s.cfg.BlockScore:
+		return Result{Verdict: Block, Reason: "reputation", Score: rep.Score}, nil
+	case suspicious && rep.Score >= s.cfg.ChallengeScore:
+		return Result{Verdict: Challenge, Reason: "reputation", Score: rep.Score}, nil
+	default:
+		return Result{Verdict: Allow, Score: rep.Score}, nil
+	}
+}
+
+// NormalizeNumber strips formatting from a phone number, keeping a leading +
+// Returns "" for anonymous/withheld numbers
+func NormalizeNumber(number string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(number) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		}
+	}
+	n := b.String()
+	if n == "" || n == "+" {
+		return ""
+	}
+	return n
+}
+
+// LoadBlocklist reads one number per line; blank lines and # comments are ignored
+func LoadBlocklist(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blocklist: %w", err)
+	}
+	defer f.Close()
+
+	var numbers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line != "" {
+			numbers = append(numbers, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read blocklist: %w", err)
+	}
+	return numbers, nil
+}
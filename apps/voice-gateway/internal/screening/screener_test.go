@@ -0,0 +1,138 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeReputation struct {
+	score float64
+	err   error
+}
+
+func (f *fakeReputation) Check(ctx context.Context, number string) (Reputation, error) {
+	return Reputation{Score: f.score}, f.err
+}
+
+var testConfig = Config{BlockScore: 0.9, ChallengeScore: 0.5}
+
+func TestScreener_Blocklist(t *testing.T) {
+	s := NewScreener(testConfig, []string{"+1 (555) 123-4567"}, nil)
+
+	result, _ := s.Screen(context.Background(), "+15551234567", Policy{})
+	if result.Verdict != Block || result.Reason != "blocklist" {
+		t.Errorf("Expected global blocklist block, got %+v", result)
+	}
+
+	result, _ = s.Screen(context.Background(), "+15550000000", Policy{Blocklist: []string{"+15550000000"}})
+	if result.Verdict != Block || result.Reason != "firm_blocklist" {
+		t.Errorf("Expected firm blocklist block, got %+v", result)
+	}
+}
+
+func TestScreener_Reputation(t *testing.T) {
+	tests := []struct {
+		score   float64
+		mode    string
+		verdict Verdict
+	}{
+		{0.95, ChallengeOff, Block},
+		{0.6, ChallengeSuspicious, Challenge},
+		{0.6, ChallengeOff, Allow},
+		{0.1, ChallengeSuspicious, Allow},
+	}
+
+	for _, tt := range tests {
+		s := NewScreener(testConfig, nil, &fakeReputation{score: tt.score})
+		result, err := s.Screen(context.Background(), "+15551234567", Policy{ChallengeMode: tt.mode})
+		if err != nil {
+			t.Fatalf("Screen() failed: %v", err)
+		}
+		if result.Verdict != tt.verdict {
+			t.Errorf("score=%.2f mode=%s: expected %s, got %s", tt.score, tt.mode, tt.verdict, result.Verdict)
+		}
+	}
+}
+
+func TestScreener_ReputationFailsOpen(t *testing.T) {
+	s := NewScreener(testConfig, nil, &fakeReputation{err: errors.New("timeout")})
+
+	result, err := s.Screen(context.Background(), "+15551234567", Policy{ChallengeMode: ChallengeSuspicious})
+	if err == nil {
+		t.Error("Expected lookup error to be returned")
+	}
+	if result.Verdict != Allow {
+		t.Errorf("Expected allow on lookup failure, got %s", result.Verdict)
+	}
+}
+
+func TestScreener_Anonymous(t *testing.T) {
+	s := NewScreener(testConfig, nil, nil)
+
+	result, _ := s.Screen(context.Background(), "anonymous", Policy{ChallengeMode: ChallengeSuspicious})
+	if result.Verdict != Challenge || result.Reason != "anonymous" {
+		t.Errorf("Expected anonymous caller to be challenged, got %+v", result)
+	}
+
+	result, _ = s.Screen(context.Background(), "", Policy{ChallengeMode: ChallengeOff})
+	if result.Verdict != Allow {
+		t.Errorf("Expected allow with challenge off, got %s", result.Verdict)
+	}
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := map[string]string{
+		"+1 (555) 123-4567": "+15551234567",
+		"555.123.4567":      "5551234567",
+		"anonymous":         "",
+		"+":                 "",
+	}
+	for input, want := range tests {
+		if got := NormalizeNumber(input); got != want {
+			t.Errorf("NormalizeNumber(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestLoadBlocklist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	content := "# known robocallers\n+15551234567\n\n+15557654321  # reported 2024-01\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	numbers, err := LoadBlocklist(path)
+	if err != nil {
+		t.Fatalf("LoadBlocklist() failed: %v", err)
+	}
+	if len(numbers) != 2 || numbers[1] != "+15557654321" {
+		t.Errorf("Unexpected blocklist: %v", numbers)
+	}
+}
+
+func TestHTTPReputation_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("phone_number") != "+15551234567" {
+			t.Errorf("Unexpected phone_number: %s", r.URL.Query().Get("phone_number"))
+		}
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("Expected API key header")
+		}
+		w.Write([]byte(`{"score":0.72,"carrier":"voip"}`))
+	}))
+	defer server.Close()
+
+	rep, err := NewHTTPReputation(server.URL, "key", time.Second).Check(context.Background(), "+15551234567")
+	if err != nil {
+		t.Fatalf("Check() failed: %v", err)
+	}
+	if rep.Score != 0.72 {
+		t.Errorf("Expected score 0.72, got %f", rep.Score)
+	}
+}
@@ -0,0 +1,145 @@
+package sentiment
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Signals detected in a transcript
+const (
+	SignalEscalation  = "escalation_request" // Caller asks for a human
+	SignalProfanity   = "profanity"
+	SignalFrustration = "frustration"
+)
+
+// Result is the analysis of a single caller turn
+type Result struct {
+	// Score ranges from -1 (negative) to 1 (positive); 0 is neutral or unknown
+	Score float64
+
+	// Signals lists the escalation signals present, without duplicates
+	Signals []string
+}
+
+// Has returns whether the result contains signal
+func (r Result) Has(signal string) bool {
+	for _, s := range r.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
+
+var defaultEscalationPhrases = []string{
+	"real person", "human being", "speak to a human", "talk to a human", "speak to someone",
+	"talk to someone", "speak to a person", "talk to a person", "representative",
+	"speak to an operator", "talk to an operator", "get me an operator",
+	"lawyer now", "attorney now", "my lawyer", "my attorney", "speak to a lawyer", "talk to a lawyer",
+	"speak to an attorney", "talk to an attorney", "speak to a manager", "talk to a manager",
+	"speak to the manager", "talk to the manager", "get me a manager", "speak to a supervisor",
+	"talk to a supervisor", "speak to your supervisor", "talk to your supervisor", "get me a supervisor",
+}
+
+var profanity = []string{
+	"fuck", "fucking", "fucked", "shit", "bullshit", "damn", "damnit", "goddamn", "asshole",
+	"bitch", "bastard", "crap", "piss", "pissed",
+}
+
+var frustrationPhrases = []string{
+	"this is ridiculous", "ridiculous", "you're not listening", "you are not listening",
+	"not listening", "i already told you", "i already said", "i just said", "how many times",
+	"useless", "waste of time", "wasting my time", "this is stupid", "stupid", "unbelievable",
+	"frustrated", "frustrating", "annoyed", "fed up", "sick of", "what is wrong with you",
+}
+
+// Small sentiment lexicon tuned for short phone turns
+var lexicon = map[string]float64{
+	"thank": 1, "thanks": 1, "great": 1, "good": 0.5, "perfect": 1, "wonderful": 1, "appreciate": 1,
+	"helpful": 1, "awesome": 1, "excellent": 1, "happy": 1, "glad": 1, "nice": 0.5, "yes": 0.25,
+	"bad": -1, "terrible": -1, "awful": -1, "horrible": -1, "angry": -1, "upset": -1, "hate": -1,
+	"worst": -1, "useless": -1, "ridiculous": -1, "stupid": -1, "frustrated": -1, "frustrating": -1,
+	"annoyed": -1, "wrong": -0.5, "problem": -0.5, "never": -0.5, "no": -0.25, "unacceptable": -1,
+}
+
+// Analyzer scores caller turns and spots requests for a human, profanity, and frustration
+type Analyzer struct {
+	escalation []string
+}
+
+// NewAnalyzer creates an analyzer; extraPhrases are treated as escalation requests
+func NewAnalyzer(extraPhrases []string) *Analyzer {
+	phrases := append([]string(nil), defaultEscalationPhrases...)
+	for _, p := range extraPhrases {
+		if p = normalize(p); p != "" {
+			phrases = append(phrases, p)
+		}
+	}
+	return &Analyzer{escalation: phrases}
+}
+
+// Analyze scores a single transcript
+func (a *Analyzer) Analyze(text string) Result {
+	norm := normalize(text)
+	if norm == "" {
+		return Result{}
+	}
+	padded := " " + norm + " "
+
+	var result Result
+	if containsAny(padded, a.escalation) {
+		result.Signals = append(result.Signals, SignalEscalation)
+	}
+	if containsAny(padded, profanity) {
+		result.Signals = append(result.Signals, SignalProfanity)
+	}
+	if containsAny(padded, frustrationPhrases) {
+		result.Signals = append(result.Signals, SignalFrustration)
+	}
+
+	var sum, weight float64
+	for _, word := range strings.Fields(norm) {
+		if v, ok := lexicon[word]; ok {
+			sum += v
+			weight += abs(v)
+		}
+	}
+	if weight > 0 {
+		result.Score = sum / weight
+	}
+	if result.Has(SignalProfanity) || result.Has(SignalFrustration) {
+		result.Score = min(result.Score, -0.5)
+	}
+	return result
+}
+
+// normalize lowercases text and replaces punctuation with spaces (apostrophes are kept)
+func normalize(text string) string {
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'':
+			return unicode.ToLower(r)
+		case r == '’':
+			return '\''
+		}
+		return ' '
+	}, text)
+	return strings.Join(strings.Fields(mapped), " ")
+}
+
+// containsAny reports whether padded (" text ") contains any phrase on word boundaries
+func containsAny(padded string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(padded, " "+p+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestAnalyzer_Signals(t *testing.T) {
+	analyzer := NewAnalyzer([]string{"Senior Partner"})
+
+	tests := []struct {
+		text   string
+		signal string
+		want   bool
+	}{
+		{"I want to talk to a human, please.", SignalEscalation, true},
+		{"Get me my lawyer now!", SignalEscalation, true},
+		{"Can I speak with the senior partner?", SignalEscalation, true},
+		{"I'd like to schedule a consultation", SignalEscalation, false},
+		{"I want to speak to a manager", SignalEscalation, true},
+		{"My manager fired me last week", SignalEscalation, false}, // Bare mentions aren't requests
+		{"I work as a machine operator", SignalEscalation, false},
+		{"This is bullshit", SignalProfanity, true},
+		{"I need help with my classic car", SignalProfanity, false}, // No partial-word matches
+		{"I already told you my name", SignalFrustration, true},
+		{"Thanks, that works", SignalFrustration, false},
+	}
+
+	for _, tt := range tests {
+		if got := analyzer.Analyze(tt.text).Has(tt.signal); got != tt.want {
+			t.Errorf("Analyze(%q).Has(%s): expected %v, got %v", tt.text, tt.signal, tt.want, got)
+		}
+	}
+}
+
+func TestAnalyzer_Score(t *testing.T) {
+	analyzer := NewAnalyzer(nil)
+
+	if score := analyzer.Analyze("Great, thank you so much").Score; score <= 0 {
+		t.Errorf("Expected positive score, got %v", score)
+	}
+	if score := analyzer.Analyze("This is terrible and I'm upset").Score; score >= 0 {
+		t.Errorf("Expected negative score, got %v", score)
+	}
+	if score := analyzer.Analyze("My name is Jane").Score; score != 0 {
+		t.Errorf("Expected neutral score, got %v", score)
+	}
+}
+
+func TestTracker_Escalation(t *testing.T) {
+	analyzer := NewAnalyzer(nil)
+
+	tracker := NewTracker(2)
+	if trigger := tracker.Observe(analyzer.Analyze("This is terrible")); trigger != "" {
+		t.Errorf("Expected no trigger after one negative turn, got %q", trigger)
+	}
+	if trigger := tracker.Observe(analyzer.Analyze("You're not listening")); trigger != SignalFrustration {
+		t.Errorf("Expected frustration trigger, got %q", trigger)
+	}
+	if trigger := tracker.Observe(analyzer.Analyze("Get me a real person")); trigger != "" {
+		t.Errorf("Expected escalation to trigger once per call, got %q", trigger)
+	}
+	if signals := tracker.Signals(); len(signals) != 2 {
+		t.Errorf("Expected escalation and frustration signals, got %v", signals)
+	}
+
+	tracker = NewTracker(0)
+	if trigger := tracker.Observe(analyzer.Analyze("Let me speak to a representative")); trigger != SignalEscalation {
+		t.Errorf("Expected immediate escalation request trigger, got %q", trigger)
+	}
+}
@@ -0,0 +1,77 @@
+package sentiment
+
+// Tracker follows sentiment across a call and decides when to escalate
+// Escalation triggers at most once per call
+type Tracker struct {
+	frustrationTurns int
+
+	turns         int
+	scoreSum      float64
+	negativeTurns int // Consecutive negative turns
+	signals       map[string]bool
+	escalated     bool
+}
+
+// NewTracker creates a tracker that escalates after frustrationTurns
+// consecutive negative turns (0 disables frustration-based escalation)
+func NewTracker(frustrationTurns int) *Tracker {
+	return &Tracker{
+		frustrationTurns: frustrationTurns,
+		signals:          make(map[string]bool),
+	}
+}
+
+// Observe records a caller turn and returns the escalation trigger, or "" if
+// the call should not (or has already been) escalated
+// A request for a human or profanity escalates immediately; frustration
+// escalates once the caller has been negative for frustrationTurns turns
+func (t *Tracker) Observe(r Result) string {
+	t.turns++
+	t.scoreSum += r.Score
+	for _, s := range r.Signals {
+		t.signals[s] = true
+	}
+
+	if r.Score < 0 || r.Has(SignalFrustration) {
+		t.negativeTurns++
+	} else {
+		t.negativeTurns = 0
+	}
+
+	if t.escalated {
+		return ""
+	}
+
+	var trigger string
+	switch {
+	case r.Has(SignalEscalation):
+		trigger = SignalEscalation
+	case r.Has(SignalProfanity):
+		trigger = SignalProfanity
+	case t.frustrationTurns > 0 && t.negativeTurns >= t.frustrationTurns:
+		trigger = SignalFrustration
+	}
+	if trigger != "" {
+		t.escalated = true
+	}
+	return trigger
+}
+
+// Average returns the mean sentiment score over the call
+func (t *Tracker) Average() float64 {
+	if t.turns == 0 {
+		return 0
+	}
+	return t.scoreSum / float64(t.turns)
+}
+
+// Signals returns every signal seen during the call, in a stable order
+func (t *Tracker) Signals() []string {
+	var signals []string
+	for _, s := range []string{SignalEscalation, SignalProfanity, SignalFrustration} {
+		if t.signals[s] {
+			signals = append(signals, s)
+		}
+	}
+	return signals
+}
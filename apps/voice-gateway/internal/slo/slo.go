@@ -0,0 +1,249 @@
+package slo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucketsPerShortWindow is how finely the short window is divided; the long
+// window is kept in buckets of the same width
+const bucketsPerShortWindow = 5
+
+// Alert states
+const (
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// Objective is a latency target: Goal of events (e.g. 0.95 for p95) must
+// complete within Threshold
+type Objective struct {
+	Name      string
+	Threshold time.Duration
+	Goal      float64
+}
+
+// Config tunes burn-rate alerting for a Tracker
+type Config struct {
+	// ShortWindow and LongWindow are the burn-rate windows (default 5m and 1h);
+	// an alert fires only while both burn fast, so it neither flaps on a brief
+	// spike nor lingers long after recovery
+	ShortWindow time.Duration
+	LongWindow  time.Duration
+
+	// AlertBurnRate is the burn rate at which the alert fires (default 14.4,
+	// which spends 2% of a 30-day error budget in an hour)
+	AlertBurnRate float64
+
+	// MinEvents is the fewest events in the short window that may fire an alert (default 20)
+	MinEvents int64
+
+	// Notify receives alerts as they fire and resolve; it must not block
+	Notify func(Alert)
+}
+
+// Alert reports an objective whose error budget is burning too fast, or has recovered
+type Alert struct {
+	SLO           string    `json:"slo"`
+	State         string    `json:"state"` // firing or resolved
+	ThresholdMs   int64     `json:"threshold_ms"`
+	Goal          float64   `json:"goal"`
+	ShortBurnRate float64   `json:"short_burn_rate"`
+	LongBurnRate  float64   `json:"long_burn_rate"`
+	AlertBurnRate float64   `json:"alert_burn_rate"`
+	At            time.Time `json:"at"`
+}
+
+// Stats is a point-in-time view of a Tracker
+type Stats struct {
+	Good          int64 // Events within the threshold since start
+	Bad           int64 // Events over the threshold since start
+	ShortBurnRate float64
+	LongBurnRate  float64
+	Alerting      bool
+}
+
+// bucket counts the events of one slice of the long window
+type bucket struct {
+	start     time.Time
+	good, bad int64
+}
+
+// Tracker measures events against an Objective and computes how fast its
+// error budget (1 - Goal) is being spent: a burn rate of 1 spends exactly the
+// budget, higher rates spend it early
+//
+// A nil *Tracker ignores observations.
+type Tracker struct {
+	objective Objective
+	cfg       Config
+	width     time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   []bucket // Ring covering the long window
+	good, bad int64
+	alerting  bool
+}
+
+// NewTracker creates a tracker for objective
+func NewTracker(objective Objective, cfg Config) *Tracker {
+	if cfg.ShortWindow <= 0 {
+		cfg.ShortWindow = 5 * time.Minute
+	}
+	if cfg.LongWindow < cfg.ShortWindow {
+		cfg.LongWindow = 12 * cfg.ShortWindow
+	}
+	if cfg.AlertBurnRate <= 0 {
+		cfg.AlertBurnRate = 14.4
+	}
+	if cfg.MinEvents <= 0 {
+		cfg.MinEvents = 20
+	}
+	width := cfg.ShortWindow / bucketsPerShortWindow
+	return &Tracker{
+		objective: objective,
+		cfg:       cfg,
+		width:     width,
+		now:       time.Now,
+		buckets:   make([]bucket, int(cfg.LongWindow/width)),
+	}
+}
+
+// Objective returns the tracked objective
+func (t *Tracker) Objective() Objective {
+	return t.objective
+}
+
+// Observe records one event's latency and re-evaluates the alert
+func (t *Tracker) Observe(latency time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	b := t.current()
+	if latency <= t.objective.Threshold {
+		b.good++
+		t.good++
+	} else {
+		b.bad++
+		t.bad++
+	}
+	alert := t.evaluate()
+	t.mu.Unlock()
+
+	t.notify(alert)
+}
+
+// Evaluate re-checks the alert without a new event, so an alert resolves
+// once traffic stops
+func (t *Tracker) Evaluate() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	alert := t.evaluate()
+	t.mu.Unlock()
+
+	t.notify(alert)
+}
+
+// Run evaluates the alert every bucket until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	if t == nil {
+		return
+	}
+	ticker := time.NewTicker(t.width)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.Evaluate()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stats returns the tracker's counts and burn rates
+func (t *Tracker) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Stats{
+		Good:          t.good,
+		Bad:           t.bad,
+		ShortBurnRate: t.burnRate(t.cfg.ShortWindow),
+		LongBurnRate:  t.burnRate(t.cfg.LongWindow),
+		Alerting:      t.alerting,
+	}
+}
+
+// current returns the bucket for now, recycling a stale one. Callers hold mu
+func (t *Tracker) current() *bucket {
+	now := t.now()
+	start := now.Truncate(t.width)
+	b := &t.buckets[int(start.UnixNano()/int64(t.width))%len(t.buckets)]
+	if !b.start.Equal(start) {
+		*b = bucket{start: start}
+	}
+	return b
+}
+
+// window sums the events of the buckets within d of now. Callers hold mu
+func (t *Tracker) window(d time.Duration) (good, bad int64) {
+	since := t.now().Truncate(t.width).Add(-d + t.width)
+	for _, b := range t.buckets {
+		if !b.start.Before(since) {
+			good += b.good
+			bad += b.bad
+		}
+	}
+	return good, bad
+}
+
+// burnRate is the share of bad events in the window over the error budget. Callers hold mu
+func (t *Tracker) burnRate(d time.Duration) float64 {
+	good, bad := t.window(d)
+	budget := 1 - t.objective.Goal
+	if good+bad == 0 || budget <= 0 {
+		return 0
+	}
+	return float64(bad) / float64(good+bad) / budget
+}
+
+// evaluate fires the alert while both windows burn at AlertBurnRate, and
+// resolves it once the short window recovers; returns the alert to deliver,
+// if the state changed. Callers hold mu
+func (t *Tracker) evaluate() *Alert {
+	short, long := t.burnRate(t.cfg.ShortWindow), t.burnRate(t.cfg.LongWindow)
+	good, bad := t.window(t.cfg.ShortWindow)
+
+	var state string
+	switch {
+	case !t.alerting && short >= t.cfg.AlertBurnRate && long >= t.cfg.AlertBurnRate && good+bad >= t.cfg.MinEvents:
+		t.alerting = true
+		state = StateFiring
+	case t.alerting && short < t.cfg.AlertBurnRate:
+		t.alerting = false
+		state = StateResolved
+	default:
+		return nil
+	}
+	return &Alert{
+		SLO:           t.objective.Name,
+		State:         state,
+		ThresholdMs:   t.objective.Threshold.Milliseconds(),
+		Goal:          t.objective.Goal,
+		ShortBurnRate: short,
+		LongBurnRate:  long,
+		AlertBurnRate: t.cfg.AlertBurnRate,
+		At:            t.now().UTC(),
+	}
+}
+
+func (t *Tracker) notify(alert *Alert) {
+	if alert != nil && t.cfg.Notify != nil {
+		t.cfg.Notify(*alert)
+	}
+}
@@ -0,0 +1,112 @@
+package slo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// newTestTracker returns a tracker on a fake clock for a p90 < 1s objective,
+// collecting its alerts
+func newTestTracker(cfg Config) (*Tracker, *time.Time, *[]Alert) {
+	var alerts []Alert
+	cfg.Notify = func(a Alert) { alerts = append(alerts, a) }
+	t := NewTracker(Objective{Name: "first_response", Threshold: time.Second, Goal: 0.9}, cfg)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	t.now = func() time.Time { return now }
+	return t, &now, &alerts
+}
+
+func observe(t *Tracker, good, bad int) {
+	for i := 0; i < good; i++ {
+		t.Observe(500 * time.Millisecond)
+	}
+	for i := 0; i < bad; i++ {
+		t.Observe(2 * time.Second)
+	}
+}
+
+func TestTracker_BurnRate(t *testing.T) {
+	tracker, now, _ := newTestTracker(Config{ShortWindow: 5 * time.Minute, LongWindow: time.Hour})
+
+	observe(tracker, 18, 2) // 10% bad spends the 10% budget exactly
+	stats := tracker.Stats()
+	if stats.Good != 18 || stats.Bad != 2 {
+		t.Errorf("Expected 18 good and 2 bad events, got %+v", stats)
+	}
+	if math.Abs(stats.ShortBurnRate-1) > 1e-9 || math.Abs(stats.LongBurnRate-1) > 1e-9 {
+		t.Errorf("Expected burn rate 1 in both windows, got %+v", stats)
+	}
+
+	// Ten minutes on, the short window has forgotten the events
+	*now = now.Add(10 * time.Minute)
+	observe(tracker, 10, 0)
+	stats = tracker.Stats()
+	if stats.ShortBurnRate != 0 {
+		t.Errorf("Expected short burn rate 0, got %v", stats.ShortBurnRate)
+	}
+	if math.Abs(stats.LongBurnRate-2.0/30/0.1) > 1e-9 {
+		t.Errorf("Expected long burn rate %v, got %v", 2.0/30/0.1, stats.LongBurnRate)
+	}
+
+	// And after the long window, so has the long one
+	*now = now.Add(time.Hour)
+	if stats := tracker.Stats(); stats.LongBurnRate != 0 {
+		t.Errorf("Expected long burn rate 0 after the window, got %v", stats.LongBurnRate)
+	}
+}
+
+func TestTracker_AlertFiresAndResolves(t *testing.T) {
+	tracker, now, alerts := newTestTracker(Config{ShortWindow: 5 * time.Minute, LongWindow: time.Hour, AlertBurnRate: 5, MinEvents: 10})
+
+	observe(tracker, 2, 3) // Burning fast, but too few events to judge
+	if len(*alerts) != 0 {
+		t.Fatalf("Expected no alert below MinEvents, got %+v", *alerts)
+	}
+
+	observe(tracker, 0, 5)
+	if len(*alerts) != 1 || (*alerts)[0].State != StateFiring {
+		t.Fatalf("Expected a firing alert, got %+v", *alerts)
+	}
+	if a := (*alerts)[0]; a.SLO != "first_response" || a.ThresholdMs != 1000 || a.Goal != 0.9 {
+		t.Errorf("Expected the objective in the alert, got %+v", a)
+	}
+	if !tracker.Stats().Alerting {
+		t.Error("Expected the tracker to be alerting")
+	}
+
+	observe(tracker, 0, 5)
+	if len(*alerts) != 1 {
+		t.Errorf("Expected one alert while firing, got %d", len(*alerts))
+	}
+
+	// Traffic stops; the alert resolves once the short window has passed
+	*now = now.Add(6 * time.Minute)
+	tracker.Evaluate()
+	if len(*alerts) != 2 || (*alerts)[1].State != StateResolved {
+		t.Fatalf("Expected a resolved alert, got %+v", *alerts)
+	}
+}
+
+func TestTracker_NeedsBothWindows(t *testing.T) {
+	tracker, now, alerts := newTestTracker(Config{ShortWindow: 5 * time.Minute, LongWindow: time.Hour, AlertBurnRate: 5, MinEvents: 10})
+
+	// A long healthy stretch keeps a short spike from firing
+	for i := 0; i < 11; i++ {
+		observe(tracker, 100, 0)
+		*now = now.Add(5 * time.Minute)
+	}
+	observe(tracker, 0, 20)
+	if stats := tracker.Stats(); stats.ShortBurnRate < 5 || stats.LongBurnRate >= 5 {
+		t.Fatalf("Expected only the short window burning, got %+v", stats)
+	}
+	if len(*alerts) != 0 {
+		t.Errorf("Expected no alert for a short spike, got %+v", *alerts)
+	}
+}
+
+func TestTracker_Nil(t *testing.T) {
+	var tracker *Tracker
+	tracker.Observe(time.Second)
+	tracker.Evaluate()
+}
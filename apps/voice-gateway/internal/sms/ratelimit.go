@@ -0,0 +1,50 @@
+package sms
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter enforces a sliding-window limit per key (firm)
+type RateLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	sent   map[string][]time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a limiter counting events within window
+func NewRateLimiter(window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		window: window,
+		sent:   make(map[string][]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Allow records an event for key if fewer than limit occurred within the
+// window; a limit of zero or less disables limiting
+func (r *RateLimiter) Allow(key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	cutoff := now.Add(-r.window)
+	recent := r.sent[key][:0]
+	for _, t := range r.sent[key] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= limit {
+		r.sent[key] = recent
+		return false
+	}
+	r.sent[key] = append(recent, now)
+	return true
+}
@@ -0,0 +1,140 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// ErrRateLimited is returned when a firm has exhausted its SMS allowance
+var ErrRateLimited = errors.New("sms rate limit exceeded")
+
+// maxBodyLength caps a message at 10 concatenated SMS segments
+const maxBodyLength = 1600
+
+// Transport delivers a rendered message (implemented by the Twilio client)
+type Transport interface {
+	SendSMS(ctx context.Context, from, to, body string) (string, error)
+}
+
+// Request describes a message to send on behalf of a firm
+// Either Body or Template must be set; Data fills template fields
+type Request struct {
+	FirmID   string            `json:"firm_id"`
+	To       string            `json:"to"`
+	From     string            `json:"from,omitempty"` // Overrides the firm's configured number
+	Template string            `json:"template,omitempty"`
+	Data     map[string]string `json:"data,omitempty"`
+	Body     string            `json:"body,omitempty"`
+}
+
+// Result describes a sent message
+type Result struct {
+	SID      string    `json:"message_sid"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Template string    `json:"template,omitempty"`
+	Body     string    `json:"body"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// Sender renders and sends firm SMS messages, enforcing per-firm rate limits
+type Sender struct {
+	transport Transport
+	limiter   *RateLimiter
+}
+
+// NewSender creates a sender using transport for delivery
+func NewSender(transport Transport) *Sender {
+	return &Sender{
+		transport: transport,
+		limiter:   NewRateLimiter(time.Hour),
+	}
+}
+
+// Send renders req with the firm's templates and sends it
+func (s *Sender) Send(ctx context.Context, settings *firm.Settings, req Request) (*Result, error) {
+	if req.To == "" {
+		return nil, fmt.Errorf("recipient number is required")
+	}
+
+	from := req.From
+	if from == "" {
+		from = settings.SMS.FromNumber
+	}
+	if from == "" {
+		return nil, fmt.Errorf("no sending number configured for firm")
+	}
+
+	body, err := Render(settings, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.limiter.Allow(req.FirmID, settings.SMS.MaxPerHour) {
+		observability.RecordSMS("rate_limited")
+		return nil, ErrRateLimited
+	}
+
+	sid, err := s.transport.SendSMS(ctx, from, req.To, body)
+	if err != nil {
+		observability.RecordSMS("error")
+		return nil, fmt.Errorf("failed to send sms: %w", err)
+	}
+	observability.RecordSMS("sent")
+
+	return &Result{
+		SID:      sid,
+		From:     from,
+		To:       req.To,
+		Template: req.Template,
+		Body:     body,
+		SentAt:   time.Now().UTC(),
+	}, nil
+}
+
+// Render produces the message body for req
+// Templates use text/template syntax with the request data as fields, plus
+// "sender" set to the firm's SMS sender name
+func Render(settings *firm.Settings, req Request) (string, error) {
+	var body string
+	switch {
+	case req.Template != "":
+		source, ok := settings.SMS.Templates[req.Template]
+		if !ok {
+			return "", fmt.Errorf("unknown sms template %q", req.Template)
+		}
+		tmpl, err := template.New(req.Template).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return "", fmt.Errorf("invalid sms template %q: %w", req.Template, err)
+		}
+
+		data := map[string]string{"sender": settings.SMS.SenderName}
+		for k, v := range req.Data {
+			data[k] = v
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to render sms template %q: %w", req.Template, err)
+		}
+		body = buf.String()
+	case req.Body != "":
+		body = req.Body
+	default:
+		return "", fmt.Errorf("body or template is required")
+	}
+
+	body = strings.TrimSpace(body)
+	if len(body) > maxBodyLength {
+		return "", fmt.Errorf("sms body exceeds %d characters", maxBodyLength)
+	}
+	return body, nil
+}
@@ -0,0 +1,109 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+type fakeTransport struct {
+	sent []string
+}
+
+func (f *fakeTransport) SendSMS(ctx context.Context, from, to, body string) (string, error) {
+	f.sent = append(f.sent, body)
+	return "SM123", nil
+}
+
+func testSettings() *firm.Settings {
+	settings := firm.DefaultSettings()
+	settings.SMS.FromNumber = "+15550001111"
+	settings.SMS.SenderName = "Smith & Lee"
+	settings.SMS.MaxPerHour = 2
+	return settings
+}
+
+func TestRender_Template(t *testing.T) {
+	settings := testSettings()
+
+	body, err := Render(settings, Request{
+		Template: "appointment_confirmation",
+		Data:     map[string]string{"when": "Tuesday at 3pm"},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	want := "Smith & Lee: your appointment is confirmed for Tuesday at 3pm. Reply STOP to opt out."
+	if body != want {
+		t.Errorf("Expected %q, got %q", want, body)
+	}
+}
+
+func TestRender_MissingField(t *testing.T) {
+	_, err := Render(testSettings(), Request{Template: "appointment_confirmation"})
+	if err == nil {
+		t.Error("Expected error for missing template field")
+	}
+
+	_, err = Render(testSettings(), Request{Template: "nope"})
+	if err == nil {
+		t.Error("Expected error for unknown template")
+	}
+}
+
+func TestSender_RateLimit(t *testing.T) {
+	transport := &fakeTransport{}
+	sender := NewSender(transport)
+	settings := testSettings()
+
+	req := Request{FirmID: "firm-1", To: "+15551234567", Body: "Hello"}
+	for i := 0; i < 2; i++ {
+		if _, err := sender.Send(context.Background(), settings, req); err != nil {
+			t.Fatalf("Send() %d failed: %v", i, err)
+		}
+	}
+
+	if _, err := sender.Send(context.Background(), settings, req); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+
+	// Other firms have their own allowance
+	req.FirmID = "firm-2"
+	if _, err := sender.Send(context.Background(), settings, req); err != nil {
+		t.Errorf("Expected firm-2 to be allowed, got %v", err)
+	}
+	if len(transport.sent) != 3 {
+		t.Errorf("Expected 3 messages sent, got %d", len(transport.sent))
+	}
+}
+
+func TestRateLimiter_Window(t *testing.T) {
+	limiter := NewRateLimiter(time.Hour)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow("firm-1", 1) {
+		t.Fatal("Expected first event to be allowed")
+	}
+	if limiter.Allow("firm-1", 1) {
+		t.Error("Expected second event within the window to be denied")
+	}
+
+	now = now.Add(61 * time.Minute)
+	if !limiter.Allow("firm-1", 1) {
+		t.Error("Expected event after the window to be allowed")
+	}
+}
+
+func TestSender_RequiresFromNumber(t *testing.T) {
+	settings := testSettings()
+	settings.SMS.FromNumber = ""
+
+	_, err := NewSender(&fakeTransport{}).Send(context.Background(), settings, Request{To: "+15551234567", Body: "Hi"})
+	if err == nil {
+		t.Error("Expected error without a sending number")
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrRegionUnavailable is returned for an object whose firm requires a
+// region the gateway has no store in
+var ErrRegionUnavailable = errors.New("no storage in the required region")
+
+// RegionalStore keeps each firm's objects in the store for the firm's
+// storage region, named in the object key ("recordings/<firm>/<call>.wav").
+// Objects never fall back to another region's store
+type RegionalStore struct {
+	stores map[string]Store
+	region func(firmID string) string
+}
+
+// NewRegionalStore routes objects between stores by region; region returns
+// a firm's storage region, and is called with "" for unattributed objects
+func NewRegionalStore(stores map[string]Store, region func(firmID string) string) *RegionalStore {
+	return &RegionalStore{stores: stores, region: region}
+}
+
+// Put stores the object in its firm's region
+func (r *RegionalStore) Put(ctx context.Context, key string, data io.Reader, contentType string) (string, error) {
+	store, err := r.storeFor(key)
+	if err != nil {
+		return "", err
+	}
+	return store.Put(ctx, key, data, contentType)
+}
+
+// Get reads the object from its firm's region, or from whichever region
+// holds it when the firm has since moved
+func (r *RegionalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	home, err := r.storeFor(key)
+	if err == nil {
+		body, err := home.Get(ctx, key)
+		if !errors.Is(err, ErrNotFound) {
+			return body, err
+		}
+	}
+	for _, region := range r.regions() {
+		if store := r.stores[region]; store != home {
+			body, err := store.Get(ctx, key)
+			if !errors.Is(err, ErrNotFound) {
+				return body, err
+			}
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Delete removes the object from every region, so objects stored before a
+// firm moved region can still be deleted
+func (r *RegionalStore) Delete(ctx context.Context, key string) error {
+	var errs []error
+	for _, region := range r.regions() {
+		if err := r.stores[region].Delete(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("region %s: %w", region, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// List lists matching objects across every region, sorted by key, each
+// with the region it is stored in
+func (r *RegionalStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	for region, store := range r.stores {
+		found, err := store.List(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+		for _, object := range found {
+			object.Region = region
+			objects = append(objects, object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// regions returns the regions with a store, sorted
+func (r *RegionalStore) regions() []string {
+	regions := make([]string, 0, len(r.stores))
+	for region := range r.stores {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+func (r *RegionalStore) storeFor(key string) (Store, error) {
+	region := r.region(FirmFromKey(key))
+	store, ok := r.stores[region]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for %s", ErrRegionUnavailable, region, key)
+	}
+	return store, nil
+}
+
+// FirmFromKey returns the firm an object key belongs to ("<kind>/<firm>/..."),
+// or "" when the key names none
+func FirmFromKey(key string) string {
+	parts := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	if len(parts) < 3 || parts[1] == "unattributed" {
+		return ""
+	}
+	return parts[1]
+}
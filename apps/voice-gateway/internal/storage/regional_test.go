@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRegionalStore_RoutesByFirmRegion(t *testing.T) {
+	us, _ := NewLocalStore(t.TempDir())
+	eu, _ := NewLocalStore(t.TempDir())
+	regions := map[string]string{"acme-eu": "eu", "acme-apac": "apac"}
+	store := NewRegionalStore(map[string]Store{"us": us, "eu": eu}, func(firmID string) string {
+		if region, ok := regions[firmID]; ok {
+			return region
+		}
+		return "us"
+	})
+	ctx := context.Background()
+
+	for _, key := range []string{"recordings/acme-eu/CA1.wav", "recordings/acme/CA2.wav", "recordings/unattributed/CA3.wav"} {
+		if _, err := store.Put(ctx, key, bytes.NewReader([]byte("audio")), "audio/wav"); err != nil {
+			t.Fatalf("Put(%s) failed: %v", key, err)
+		}
+	}
+	if _, err := eu.Get(ctx, "recordings/acme-eu/CA1.wav"); err != nil {
+		t.Errorf("Expected the EU firm's recording in the EU store, got %v", err)
+	}
+	if _, err := us.Get(ctx, "recordings/acme-eu/CA1.wav"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the EU firm's recording kept out of the US store, got %v", err)
+	}
+	if _, err := us.Get(ctx, "recordings/acme/CA2.wav"); err != nil {
+		t.Errorf("Expected other firms' recordings in the default store, got %v", err)
+	}
+
+	_, err := store.Put(ctx, "recordings/acme-apac/CA4.wav", bytes.NewReader([]byte("audio")), "audio/wav")
+	if !errors.Is(err, ErrRegionUnavailable) {
+		t.Errorf("Expected ErrRegionUnavailable for a region without a store, got %v", err)
+	}
+
+	objects, err := store.List(ctx, "recordings/")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(objects) != 3 || objects[0].Key != "recordings/acme-eu/CA1.wav" {
+		t.Errorf("Expected objects from every region sorted by key, got %+v", objects)
+	}
+	if objects[0].Region != "eu" || objects[1].Region != "us" {
+		t.Errorf("Expected each object's region, got %+v", objects)
+	}
+}
+
+func TestRegionalStore_FindsObjectsAfterFirmMovesRegion(t *testing.T) {
+	us, _ := NewLocalStore(t.TempDir())
+	eu, _ := NewLocalStore(t.TempDir())
+	region := "us"
+	store := NewRegionalStore(map[string]Store{"us": us, "eu": eu}, func(string) string { return region })
+	ctx := context.Background()
+
+	if _, err := store.Put(ctx, "recordings/acme/CA1.wav", bytes.NewReader([]byte("audio")), "audio/wav"); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	region = "eu"
+
+	body, err := store.Get(ctx, "recordings/acme/CA1.wav")
+	if err != nil {
+		t.Fatalf("Expected the recording from the firm's old region, got %v", err)
+	}
+	body.Close()
+	if err := store.Delete(ctx, "recordings/acme/CA1.wav"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := us.Get(ctx, "recordings/acme/CA1.wav"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the recording deleted from its old region, got %v", err)
+	}
+	if _, err := store.Get(ctx, "recordings/acme/CA1.wav"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound once deleted, got %v", err)
+	}
+}
+
+func TestFirmFromKey(t *testing.T) {
+	tests := map[string]string{
+		"recordings/acme/CA1.wav":        "acme",
+		"/voicemail/acme/CA1.wav":        "acme",
+		"voicemail/unattributed/CA1.wav": "",
+		"greeting.wav":                   "",
+		"recordings/CA1.wav":             "",
+	}
+	for key, want := range tests {
+		if got := FirmFromKey(key); got != want {
+			t.Errorf("FirmFromKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
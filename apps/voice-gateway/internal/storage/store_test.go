@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	location, err := store.Put(ctx, "voicemail/firm-1/CA123.wav", bytes.NewReader([]byte("audio")), "audio/wav")
+	if err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	if location == "" {
+		t.Error("Expected non-empty location")
+	}
+
+	reader, err := store.Get(ctx, "voicemail/firm-1/CA123.wav")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "audio" {
+		t.Errorf("Expected 'audio', got '%s'", string(data))
+	}
+
+	if err := store.Delete(ctx, "voicemail/firm-1/CA123.wav"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := store.Get(ctx, "voicemail/firm-1/CA123.wav"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+
+	// Deleting again is not an error
+	if err := store.Delete(ctx, "voicemail/firm-1/CA123.wav"); err != nil {
+		t.Errorf("Expected no error deleting missing object, got %v", err)
+	}
+}
+
+func TestLocalStore_RejectsTraversal(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() failed: %v", err)
+	}
+
+	_, err = store.Put(context.Background(), "../escape.wav", bytes.NewReader(nil), "audio/wav")
+	if err == nil {
+		t.Error("Expected error for key escaping the base directory")
+	}
+}
+
+func TestLocalStore_List(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() failed: %v", err)
+	}
+	ctx := context.Background()
+	for _, key := range []string{"recordings/acme/CA1.wav", "recordings/acme/CA2.wav", "recordings/globex/CA3.wav", "voicemail/acme/CA4.wav"} {
+		if _, err := store.Put(ctx, key, bytes.NewReader([]byte("audio")), "audio/wav"); err != nil {
+			t.Fatalf("Put() failed: %v", err)
+		}
+	}
+
+	objects, err := store.List(ctx, "recordings/acme/")
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "recordings/acme/CA1.wav" || objects[1].Key != "recordings/acme/CA2.wav" {
+		t.Fatalf("Expected acme's two recordings, got %+v", objects)
+	}
+	if objects[0].Size != 5 || objects[0].LastModified.IsZero() {
+		t.Errorf("Expected size and modification time, got %+v", objects[0])
+	}
+
+	if all, _ := store.List(ctx, ""); len(all) != 4 {
+		t.Errorf("Expected every object for an empty prefix, got %+v", all)
+	}
+	if none, err := store.List(ctx, "transcripts/"); err != nil || len(none) != 0 {
+		t.Errorf("Expected no objects under a missing prefix, got %+v, %v", none, err)
+	}
+}
@@ -0,0 +1,57 @@
+package stt
+
+import (
+	"encoding/json"
+	"testing"
+
+	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket"
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/contract"
+)
+
+// deepgramMessages are the message types the SDK routes to our callback,
+// with the type each decodes into
+var deepgramMessages = map[string]interface{}{
+	"Results":       msginterfaces.MessageResponse{},
+	"Metadata":      msginterfaces.MetadataResponse{},
+	"SpeechStarted": msginterfaces.SpeechStartedResponse{},
+	"UtteranceEnd":  msginterfaces.UtteranceEndResponse{},
+	"Error":         msginterfaces.ErrorResponse{},
+}
+
+// TestDeepgramContract replays recorded Deepgram sessions through the SDK's
+// router into handleDeepgramMessage
+func TestDeepgramContract(t *testing.T) {
+	for _, fixture := range contract.Load(t, "testdata/deepgram") {
+		t.Run(fixture.Name, func(t *testing.T) {
+			d := NewDeepgramClient(&config.Config{DeepgramLanguage: "en", DeepgramModel: "nova-2"})
+			defer d.cancel()
+			sess := newSession()
+			router := websocketv1api.NewCallbackRouter(d.newCallback(newStream(sess, 0)))
+
+			for _, message := range fixture.Messages {
+				var mt msginterfaces.MessageType
+				if err := json.Unmarshal(message, &mt); err != nil {
+					t.Fatalf("Failed to decode message type: %v", err)
+				}
+				v, ok := deepgramMessages[mt.Type]
+				if !ok {
+					t.Errorf("Unhandled Deepgram message type %q", mt.Type)
+					continue
+				}
+				contract.RequireModelled(t, message, v)
+				if err := router.Message(message); err != nil {
+					t.Errorf("Router rejected %s: %v", mt.Type, err)
+				}
+			}
+
+			results := []*TranscriptionResult{}
+			for len(sess.results) > 0 {
+				results = append(results, <-sess.results)
+			}
+			contract.Golden(t, fixture.Golden, results)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	websocketv1api "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket"
@@ -12,6 +14,7 @@ import (
 	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
 	listenClient "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen"
 
+	"github.com/lexiqai/voice-gateway/internal/chaos"
 	"github.com/lexiqai/voice-gateway/internal/config"
 	"github.com/lexiqai/voice-gateway/internal/observability"
 	"github.com/lexiqai/voice-gateway/internal/resilience"
@@ -25,7 +28,7 @@ type messageCallbackHandler struct {
 	errorHandler                           func(*msginterfaces.ErrorResponse) error
 }
 
-// Message overrides the default handler to send transcriptions to our channel
+// Message overrides the default handler to send transcriptions to the session
 func (m *messageCallbackHandler) Message(message *msginterfaces.MessageResponse) error {
 	m.handler(message)
 	return nil
@@ -40,121 +43,242 @@ func (m *messageCallbackHandler) Error(errorResponse *msginterfaces.ErrorRespons
 	return m.DefaultCallbackHandler.Error(errorResponse)
 }
 
+// Stream connections from every call share one bulkhead
+var (
+	bulkheadOnce sync.Once
+	bulkhead     *resilience.Bulkhead
+)
+
+// sharedBulkhead returns the bulkhead bounding in-progress Deepgram connections
+func sharedBulkhead(cfg *config.Config) *resilience.Bulkhead {
+	bulkheadOnce.Do(func() {
+		bulkhead = resilience.NewBulkhead("deepgram", cfg.DeepgramMaxConcurrentConnects, cfg.DeepgramBulkheadQueue,
+			time.Duration(cfg.BulkheadQueueTimeoutMs)*time.Millisecond)
+		observability.RegisterBulkhead(bulkhead)
+	})
+	return bulkhead
+}
+
 // DeepgramClient implements STTClient using Deepgram's streaming API
 type DeepgramClient struct {
 	config         *config.Config
-	client       *listenClient.WSCallback
-	transcript   chan *TranscriptionResult
+	conn         *stream  // Live connection; nil until started
+	session      *session // Current session; nil while stopped
 	mu           sync.RWMutex
 	isActive     bool
 	ctx          context.Context
 	cancel       context.CancelFunc
 	circuitBreaker *resilience.CircuitBreaker
+	lastSent       sendClock    // Audio or control messages, for keepAlive
+	sent           atomic.Int64 // Audio sent in the session, across connections
+	rotation       rotation
+	model          string // Model in use; switches to fallbackModel once the primary fails
+	fallbackModel  string // Model to fall back to, or empty for none
+	fellBack       bool
 }
 
 // NewDeepgramClient creates a new Deepgram streaming client
 func NewDeepgramClient(cfg *config.Config) *DeepgramClient {
 	ctx, cancel := context.WithCancel(context.Background())
 	
-	// Create circuit breaker
-	circuitBreaker := resilience.NewCircuitBreaker(
-		"deepgram",
-		cfg.CircuitBreakerMaxFailures,
-		time.Duration(cfg.CircuitBreakerResetTimeout)*time.Second,
-	)
+	// Deepgram's circuit breaker is shared by every call
+	circuitBreaker := resilience.Breakers.Get("deepgram")
 	
 	return &DeepgramClient{
 		config:         cfg,
-		transcript:     make(chan *TranscriptionResult, 100),
 		ctx:            ctx,
 		cancel:         cancel,
 		isActive:       false,
 		circuitBreaker: circuitBreaker,
+		model:          cfg.DeepgramModel,
+		fallbackModel:  cfg.DeepgramFallbackModel,
+	}
+}
+
+// NewDeepgramClientWithKey creates a client billed to a firm's own Deepgram
+// account, with a circuit breaker of its own so the firm's key failing does
+// not affect other firms
+func NewDeepgramClientWithKey(cfg *config.Config, apiKey string) *DeepgramClient {
+	keyCfg := *cfg
+	keyCfg.DeepgramAPIKey = apiKey
+	client := NewDeepgramClient(&keyCfg)
+	client.circuitBreaker = resilience.Breakers.Get(resilience.KeyedName("deepgram", apiKey))
+	return client
+}
+
+// Start begins a new Deepgram streaming transcription session, returning the
+// channel its results arrive on
+func (d *DeepgramClient) Start() (<-chan *TranscriptionResult, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.session != nil {
+		return nil, fmt.Errorf("deepgram client is already active")
+	}
+
+	sess := newSession()
+	d.sent.Store(0)
+	if err := d.open(sess); err != nil {
+		return nil, err
 	}
+	d.session = sess
+	return sess.results, nil
 }
 
-// Start begins a new Deepgram streaming transcription session
-func (d *DeepgramClient) Start() error {
+// restart reopens the current session's connection after it failed. A
+// session stopped in the meantime stays stopped
+func (d *DeepgramClient) restart() error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.isActive {
-		return fmt.Errorf("deepgram client is already active")
+	if d.session == nil || d.isActive {
+		return nil
+	}
+	return d.open(d.session)
+}
+
+// open connects a stream for sess and makes it the live connection. Callers
+// hold mu
+func (d *DeepgramClient) open(sess *session) error {
+	mode := EndpointingMode(d.config.EndpointingMode)
+	tOptions, cOptions := d.streamOptions()
+
+	// A new connection's results continue the session's stream time
+	st := newStream(sess, d.sessionTime())
+	callback := d.newCallback(st)
+
+	// Bound concurrent connection attempts across calls, so one session's
+	// reconnect loop cannot starve the others
+	release, err := sharedBulkhead(d.config).Acquire(d.ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Create Deepgram WebSocket client using callback (v3 API)
+	client, err := listenClient.NewWSUsingCallback(
+		d.ctx,
+		d.config.DeepgramAPIKey,
+		cOptions,
+		tOptions,
+		callback,
+	)
+
+	if err != nil && d.fallBack(fallbackReason(err.Error(), reasonConnectError)) {
+		tOptions.Model = d.model
+		client, err = listenClient.NewWSUsingCallback(
+			d.ctx,
+			d.config.DeepgramAPIKey,
+			cOptions,
+			tOptions,
+			callback,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create Deepgram client: %w", err)
+	}
+
+	// The connection being replaced, if any, failed or was stopped
+	d.abandonRotation()
+	if d.conn != nil {
+		go d.conn.close()
 	}
+	st.client = client
+	d.conn = st
+	d.isActive = true
+	d.lastSent.sent(time.Now())
+	d.rotation.schedule(d.config, time.Now())
+	d.startKeepAlive(st)
+	
+	// Record success in circuit breaker
+	d.circuitBreaker.RecordResult(true)
+	observability.UpdateCircuitBreakerState(d.circuitBreaker.Name(), int(d.circuitBreaker.GetState()))
+
+	// Start the connection (WebSocket client starts automatically on creation)
+	// No explicit Start() call needed for WSCallback
 
+	log.Printf("Deepgram streaming client started (model: %s, language: %s, endpointing: %s)", d.model, d.config.DeepgramLanguage, mode)
+	return nil
+}
+
+// streamOptions returns the options a connection is opened with. Callers hold mu
+func (d *DeepgramClient) streamOptions() (*interfaces.LiveTranscriptionOptions, *interfaces.ClientOptions) {
 	// Create Deepgram transcription options (v3 API)
 	tOptions := &interfaces.LiveTranscriptionOptions{
-		Model:          d.config.DeepgramModel,
+		Model:          d.model,
 		Language:       d.config.DeepgramLanguage,
-		Punctuate:      true,
 		InterimResults: true,
-		UtteranceEndMs: "1000",  // End utterance after 1 second of silence (string in v3)
 		VadEvents:      true,    // Enable voice activity detection events
 		Encoding:       "mulaw", // G.711 PCMU (μ-law)
 		Channels:       1,       // Mono
 		SampleRate:     8000,    // 8kHz (Twilio standard)
 	}
+	formattingOptions(d.config).deepgram(tOptions)
+	if EndpointingMode(d.config.EndpointingMode).UsesUtteranceEnd() {
+		// End utterance after N ms of silence (string in v3)
+		tOptions.UtteranceEndMs = strconv.Itoa(d.config.DeepgramUtteranceEndMs)
+	}
 
+	// KeepAlive messages are sent by keepAlive, only while the stream is idle
+	cOptions := &interfaces.ClientOptions{
+		Host: d.config.DeepgramAPIHost, // A regional host keeps audio in the firm's region
+	}
+	return tOptions, cOptions
+}
+
+// newCallback routes a connection's messages to the session. Only errors on
+// the live connection reconnect; a failing standby ends its rotation
+func (d *DeepgramClient) newCallback(st *stream) *messageCallbackHandler {
 	// Create callback struct that implements LiveMessageCallback interface
 	// We embed the default handler and only override Message and Error methods
-	callback := &messageCallbackHandler{
+	return &messageCallbackHandler{
 		DefaultCallbackHandler: websocketv1api.NewDefaultCallbackHandler(),
-		handler:                d.handleDeepgramMessage,
+		handler: func(msg *msginterfaces.MessageResponse) {
+			d.handleDeepgramMessage(st, msg)
+		},
 		errorHandler: func(errorResponse *msginterfaces.ErrorResponse) error {
 			log.Printf("Deepgram error: %+v", errorResponse)
 			
 			// Record failure in circuit breaker
 			d.circuitBreaker.RecordResult(false)
-			observability.UpdateCircuitBreakerState("deepgram", int(d.circuitBreaker.GetState()))
-			observability.IncrementCircuitBreakerFailures("deepgram")
+			observability.UpdateCircuitBreakerState(d.circuitBreaker.Name(), int(d.circuitBreaker.GetState()))
+			observability.IncrementCircuitBreakerFailures(d.circuitBreaker.Name())
 			
 			// Try to reconnect if not cancelled
 			select {
 			case <-d.ctx.Done():
 				return nil
 			default:
-				// Connection lost, mark as inactive
 				d.mu.Lock()
+				switch st {
+				case d.conn:
+				case d.rotation.standby:
+					d.abandonRotation()
+					d.mu.Unlock()
+					return nil
+				default:
+					// A replaced connection failing while it drains changes nothing
+					d.mu.Unlock()
+					return nil
+				}
+
+				// Connection lost, mark as inactive and reconnect on the
+				// fallback model, if one is configured
 				d.isActive = false
+				d.fallBack(fallbackReason(fmt.Sprintf("%s %s %s", errorResponse.ErrCode, errorResponse.ErrMsg, errorResponse.Description), reasonStreamError))
 				d.mu.Unlock()
 				
 				// Attempt reconnection in background
-				go d.attemptReconnect()
+				go d.reconnectSafely()
 			}
 			return nil
 		},
 	}
-
-	// Create Deepgram WebSocket client using callback (v3 API)
-	// Using nil for cOptions to use defaults
-	client, err := listenClient.NewWSUsingCallback(
-		d.ctx,
-		d.config.DeepgramAPIKey,
-		nil, // ClientOptions - nil uses defaults
-		tOptions,
-		callback,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to create Deepgram client: %w", err)
-	}
-
-	d.client = client
-	d.isActive = true
-	
-	// Record success in circuit breaker
-	d.circuitBreaker.RecordResult(true)
-	observability.UpdateCircuitBreakerState("deepgram", int(d.circuitBreaker.GetState()))
-
-	// Start the connection (WebSocket client starts automatically on creation)
-	// No explicit Start() call needed for WSCallback
-
-	log.Printf("Deepgram streaming client started (model: %s, language: %s)", d.config.DeepgramModel, d.config.DeepgramLanguage)
-	return nil
 }
 
 // handleDeepgramMessage processes messages from Deepgram
-func (d *DeepgramClient) handleDeepgramMessage(msg *msginterfaces.MessageResponse) {
+func (d *DeepgramClient) handleDeepgramMessage(st *stream, msg *msginterfaces.MessageResponse) {
 	if msg == nil {
 		return
 	}
@@ -185,6 +309,13 @@ func (d *DeepgramClient) handleDeepgramMessage(msg *msginterfaces.MessageRespons
 			return
 		}
 
+		// Time the result against the audio it covers
+		language := d.config.DeepgramLanguage
+		if len(alt.Languages) > 0 {
+			language = alt.Languages[0]
+		}
+		st.timing.observe(msg, &alt, d.activeModel(), language, time.Now())
+
 		// Determine if this is a final result
 		isFinal := msg.IsFinal
 
@@ -212,17 +343,30 @@ func (d *DeepgramClient) handleDeepgramMessage(msg *msginterfaces.MessageRespons
 			StartTime:  startTime,
 			Duration:   duration,
 		}
-
-		// Send to transcript channel (non-blocking)
-		select {
-		case d.transcript <- result:
-			if isFinal {
-				log.Printf("Deepgram final transcription: %s (confidence: %.2f)", alt.Transcript, confidence)
-			} else {
-				log.Printf("Deepgram interim transcription: %s", alt.Transcript)
+		if len(alt.Languages) > 0 {
+			result.Language = alt.Languages[0]
+		}
+		for _, w := range alt.Words {
+			text := w.PunctuatedWord
+			if text == "" {
+				text = w.Word
 			}
-		default:
-			log.Printf("Warning: transcript channel full, dropping transcription")
+			result.Words = append(result.Words, Word{Text: text, Start: w.Start, End: w.End, Confidence: w.Confidence})
+		}
+
+		// Move the result into session time, keeping only this connection's
+		// words while a rotation overlaps two
+		if result = d.toSession(st, result); result == nil {
+			return
+		}
+
+		// Send to the session's results channel (non-blocking)
+		if st.session == nil || !st.session.deliver(result) {
+			log.Printf("Warning: transcript channel full or closed, dropping transcription")
+		} else if isFinal {
+			log.Printf("Deepgram final transcription: %s (confidence: %.2f)", result.Text, confidence)
+		} else {
+			log.Printf("Deepgram interim transcription: %s", result.Text)
 		}
 
 	default:
@@ -232,36 +376,73 @@ func (d *DeepgramClient) handleDeepgramMessage(msg *msginterfaces.MessageRespons
 
 // SendAudio sends an audio chunk to Deepgram
 func (d *DeepgramClient) SendAudio(audioData []byte) error {
+	d.mu.RLock()
+	active := d.isActive
+	st := d.conn
+	standby := d.rotation.standby
+	d.mu.RUnlock()
+
+	// An inactive session is not a Deepgram failure and must not trip the
+	// breaker shared with other calls
+	if !active || st == nil {
+		return fmt.Errorf("deepgram client is not active")
+	}
+
 	// Use circuit breaker to protect the call
 	err := d.circuitBreaker.Call(func() error {
-		d.mu.RLock()
-		active := d.isActive
-		client := d.client
-		d.mu.RUnlock()
-
-		if !active || client == nil {
-			return fmt.Errorf("deepgram client is not active")
+		if chaos.DisconnectDeepgram() {
+			d.dropConnection()
+			go d.reconnectSafely()
+			return fmt.Errorf("failed to send audio to Deepgram: %w", chaos.ErrInjected)
 		}
 
 		// Send audio data to Deepgram
 		// WSCallback uses Write method for sending audio (returns bytes written and error)
-		_, err := client.Write(audioData)
+		_, err := st.client.Write(audioData)
 		if err != nil {
 			// Attempt reconnection in background on error
-			go d.attemptReconnect()
+			go d.reconnectSafely()
 			return fmt.Errorf("failed to send audio to Deepgram: %w", err)
 		}
+		now := time.Now()
+		st.timing.sent(len(audioData), now)
+		d.lastSent.sent(now)
 
 		return nil
 	})
 	
 	// Update circuit breaker metrics
-	observability.UpdateCircuitBreakerState("deepgram", int(d.circuitBreaker.GetState()))
+	observability.UpdateCircuitBreakerState(d.circuitBreaker.Name(), int(d.circuitBreaker.GetState()))
 	if err != nil {
-		observability.IncrementCircuitBreakerFailures("deepgram")
+		observability.IncrementCircuitBreakerFailures(d.circuitBreaker.Name())
+		return err
 	}
-	
-	return err
+
+	// During a rotation the standby hears the same audio
+	if standby != nil {
+		d.feedStandby(standby, audioData)
+	}
+	d.sent.Add(int64(len(audioData)))
+	d.advanceRotation(st)
+	return nil
+}
+
+// dropConnection closes the live connection as if Deepgram had hung up, for
+// chaos testing
+func (d *DeepgramClient) dropConnection() {
+	d.mu.Lock()
+	st := d.conn
+	d.isActive = false
+	d.mu.Unlock()
+	if st != nil {
+		go st.close()
+	}
+}
+
+// reconnectSafely runs attemptReconnect as its own goroutine, recovering a panic
+func (d *DeepgramClient) reconnectSafely() {
+	defer observability.RecoverPanic("stt", "reconnect", nil)
+	d.attemptReconnect()
 }
 
 // attemptReconnect attempts to reconnect to Deepgram
@@ -289,9 +470,7 @@ func (d *DeepgramClient) attemptReconnect() {
 		MaxBackoff:  30 * time.Second,
 	}
 
-	err := resilience.Reconnect(d.ctx, func() error {
-		return d.Start()
-	}, reconnectConfig)
+	err := resilience.Reconnect(d.ctx, d.restart, reconnectConfig)
 
 	if err != nil {
 		log.Printf("Failed to reconnect Deepgram client: %v", err)
@@ -300,25 +479,50 @@ func (d *DeepgramClient) attemptReconnect() {
 	}
 }
 
-// GetTranscription returns a channel that receives transcription results
-func (d *DeepgramClient) GetTranscription() <-chan *TranscriptionResult {
-	return d.transcript
+// Finalize asks Deepgram to flush any buffered audio as a final transcription
+func (d *DeepgramClient) Finalize() error {
+	d.mu.RLock()
+	active := d.isActive
+	st := d.conn
+	standby := d.rotation.standby
+	overlapping := d.rotation.fed
+	d.mu.RUnlock()
+
+	if !active || st == nil {
+		return fmt.Errorf("deepgram client is not active")
+	}
+
+	if err := st.client.Finalize(); err != nil {
+		return fmt.Errorf("failed to finalize Deepgram utterance: %w", err)
+	}
+	if standby != nil && overlapping {
+		_ = standby.client.Finalize()
+	}
+	d.lastSent.sent(time.Now())
+	return nil
 }
 
-// Stop stops the Deepgram streaming session
+// Stop stops the Deepgram streaming session. Its results channel is closed
+// once the connection has flushed its last results
 func (d *DeepgramClient) Stop() error {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	if !d.isActive {
+	sess := d.session
+	if sess == nil {
+		d.mu.Unlock()
 		return nil // Already stopped
 	}
-
-	// Send finish message to Deepgram
-	// WSCallback Finish() doesn't return an error
-	d.client.Finish()
-
+	st := d.conn
+	d.abandonRotation()
 	d.isActive = false
+	d.session = nil
+	d.mu.Unlock()
+
+	// Send CloseStream so Deepgram flushes, then close the socket. A
+	// connection lost mid-session may already be closed
+	if st != nil {
+		st.close()
+	}
+	sess.end()
 	log.Printf("Deepgram streaming client stopped")
 	return nil
 }
@@ -327,17 +531,7 @@ func (d *DeepgramClient) Stop() error {
 func (d *DeepgramClient) Close() error {
 	d.cancel() // Cancel context to stop any reconnection attempts
 
-	if err := d.Stop(); err != nil {
-		return err
-	}
-
-	// Close transcript channel after a short delay to allow any pending reads
-	go func() {
-		time.Sleep(100 * time.Millisecond)
-		close(d.transcript)
-	}()
-
-	return nil
+	return d.Stop()
 }
 
 // IsActive returns whether the client is currently active
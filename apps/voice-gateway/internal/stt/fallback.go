@@ -0,0 +1,48 @@
+package stt
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Reasons a session falls back to its secondary model, for the fallback metric
+const (
+	reasonRateLimited  = "rate_limited"  // Deepgram throttled the primary model
+	reasonConnectError = "connect_error" // The primary model's stream failed to open
+	reasonStreamError  = "stream_error"  // The primary model's stream failed mid-call
+)
+
+// fallbackReason labels a Deepgram failure, telling rate limiting apart from
+// other failures of the same kind
+func fallbackReason(message, otherwise string) string {
+	lower := strings.ToLower(message)
+	for _, marker := range []string{"429", "rate limit", "too many requests"} {
+		if strings.Contains(lower, marker) {
+			return reasonRateLimited
+		}
+	}
+	return otherwise
+}
+
+// fallBack switches the session to its fallback model for the rest of the
+// call, reporting whether it did. Callers hold mu
+func (d *DeepgramClient) fallBack(reason string) bool {
+	if d.fellBack || d.fallbackModel == "" {
+		return false
+	}
+	from := d.model
+	d.model = d.fallbackModel
+	d.fellBack = true
+	observability.RecordSTTModelFallback(from, d.model, reason)
+	log.Printf("Deepgram model %s failed (%s), falling back to %s", from, reason, d.model)
+	return true
+}
+
+// activeModel returns the model the session is transcribing with
+func (d *DeepgramClient) activeModel() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.model
+}
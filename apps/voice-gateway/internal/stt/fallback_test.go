@@ -0,0 +1,50 @@
+package stt
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestFallbackReason(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"websocket: bad handshake (HTTP 429)", reasonRateLimited},
+		{"Too Many Requests", reasonRateLimited},
+		{"NET-0001 rate limit exceeded", reasonRateLimited},
+		{"dial tcp: connection refused", reasonConnectError},
+	}
+	for _, tt := range tests {
+		if got := fallbackReason(tt.message, reasonConnectError); got != tt.want {
+			t.Errorf("fallbackReason(%q): expected %s, got %s", tt.message, tt.want, got)
+		}
+	}
+}
+
+func TestDeepgramClient_FallBack(t *testing.T) {
+	client := NewDeepgramClient(&config.Config{DeepgramModel: "nova-2", DeepgramFallbackModel: "base"})
+	defer client.Close()
+
+	if !client.fallBack(reasonStreamError) {
+		t.Fatal("Expected the client to fall back")
+	}
+	if got := client.activeModel(); got != "base" {
+		t.Errorf("Expected model base, got %q", got)
+	}
+
+	// The fallback sticks for the rest of the call
+	if client.fallBack(reasonStreamError) {
+		t.Error("Expected no second fallback")
+	}
+	if got := client.activeModel(); got != "base" {
+		t.Errorf("Expected model base, got %q", got)
+	}
+
+	noFallback := NewDeepgramClient(&config.Config{DeepgramModel: "nova-2"})
+	defer noFallback.Close()
+	if noFallback.fallBack(reasonRateLimited) || noFallback.activeModel() != "nova-2" {
+		t.Error("Expected no fallback without a fallback model")
+	}
+}
@@ -0,0 +1,35 @@
+package stt
+
+import (
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// formatting is how transcripts are written, independent of the provider
+type formatting struct {
+	punctuate       bool // Punctuation and capitalization
+	smartFormat     bool // Punctuation, and numbers, dates and amounts as digits
+	numerals        bool // Numbers as digits
+	profanityFilter bool
+	fillerWords     bool // Keep "uh" and "um"
+}
+
+func formattingOptions(cfg *config.Config) formatting {
+	return formatting{
+		punctuate:       cfg.STTPunctuate,
+		smartFormat:     cfg.STTSmartFormat,
+		numerals:        cfg.STTNumerals,
+		profanityFilter: cfg.STTProfanityFilter,
+		fillerWords:     cfg.STTFillerWords,
+	}
+}
+
+// deepgram sets Deepgram's equivalents on a stream's options
+func (f formatting) deepgram(opts *interfaces.LiveTranscriptionOptions) {
+	opts.Punctuate = f.punctuate
+	opts.SmartFormat = f.smartFormat
+	opts.Numerals = f.numerals
+	opts.ProfanityFilter = f.profanityFilter
+	opts.FillerWords = f.fillerWords
+}
@@ -0,0 +1,24 @@
+package stt
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestStreamOptions_Formatting(t *testing.T) {
+	d := NewDeepgramClient(&config.Config{DeepgramModel: "nova-2", STTPunctuate: true, STTProfanityFilter: true})
+	defer d.Close()
+
+	tOptions, _ := d.streamOptions()
+	if !tOptions.Punctuate || !tOptions.ProfanityFilter || tOptions.SmartFormat || tOptions.Numerals || tOptions.FillerWords {
+		t.Errorf("Expected the configured formatting on the stream, got %+v", tOptions)
+	}
+
+	d = NewDeepgramClient(&config.Config{DeepgramModel: "nova-2", STTSmartFormat: true, STTNumerals: true, STTFillerWords: true})
+	defer d.Close()
+	tOptions, _ = d.streamOptions()
+	if tOptions.Punctuate || !tOptions.SmartFormat || !tOptions.Numerals || !tOptions.FillerWords {
+		t.Errorf("Expected punctuation off and smart formatting on, got %+v", tOptions)
+	}
+}
@@ -0,0 +1,64 @@
+package stt
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// sendClock remembers when anything last went to Deepgram
+type sendClock struct {
+	last atomic.Int64 // Unix nanoseconds
+}
+
+func (c *sendClock) sent(now time.Time) {
+	c.last.Store(now.UnixNano())
+}
+
+// idle reports whether nothing has been sent for at least interval
+func (c *sendClock) idle(interval time.Duration, now time.Time) bool {
+	return now.Sub(time.Unix(0, c.last.Load())) >= interval
+}
+
+// startKeepAlive keeps st open while it is the live connection, unless
+// keepalives are disabled
+func (d *DeepgramClient) startKeepAlive(st *stream) {
+	if d.config.DeepgramKeepAliveMs > 0 {
+		go d.keepAlive(st, time.Duration(d.config.DeepgramKeepAliveMs)*time.Millisecond)
+	}
+}
+
+// keepAlive sends a KeepAlive whenever st has been idle for interval, so
+// Deepgram keeps the stream open while audio is withheld. It stops once st
+// is no longer the live connection
+func (d *DeepgramClient) keepAlive(st *stream, interval time.Duration) {
+	defer observability.RecoverPanic("stt", "keepalive", nil)
+
+	ticker := time.NewTicker(interval / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case now := <-ticker.C:
+			d.mu.RLock()
+			current := d.isActive && d.conn == st
+			d.mu.RUnlock()
+			if !current {
+				return
+			}
+			if !d.lastSent.idle(interval, now) {
+				continue
+			}
+			if err := st.client.KeepAlive(); err != nil {
+				log.Printf("Failed to send Deepgram KeepAlive: %v", err)
+				observability.RecordSTTKeepAlive(false)
+				continue
+			}
+			d.lastSent.sent(now)
+			observability.RecordSTTKeepAlive(true)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package stt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSendClock_Idle(t *testing.T) {
+	var clock sendClock
+	start := time.Now()
+	clock.sent(start)
+
+	if clock.idle(4*time.Second, start.Add(3*time.Second)) {
+		t.Error("Expected a stream that sent 3s ago not to need a KeepAlive")
+	}
+	if !clock.idle(4*time.Second, start.Add(4*time.Second)) {
+		t.Error("Expected a stream silent for the interval to need a KeepAlive")
+	}
+
+	// Audio resets the clock
+	clock.sent(start.Add(4 * time.Second))
+	if clock.idle(4*time.Second, start.Add(5*time.Second)) {
+		t.Error("Expected sent audio to put the KeepAlive off")
+	}
+}
@@ -0,0 +1,149 @@
+package stt
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// A partial word is stable once this many interims agree on it, or once one
+// reports it with stableConfidence; stable words are only revised by a more
+// confident hypothesis, so dashboards and speculative turns don't flicker
+const (
+	stableAfter      = 2
+	stableConfidence = 0.9
+)
+
+// Partial is the caller's speech so far in the current segment, merged from
+// interim results
+type Partial struct {
+	Text       string  // Best hypothesis for every word heard so far
+	Stable     string  // Leading words no longer expected to change
+	Confidence float64 // Mean word confidence of Text
+	Revisions  int     // Earlier words interims have corrected
+	Words      []Word  // Text's words; untimed when the interims had no word timing
+}
+
+// partialWord is the merged hypothesis for one word of the segment
+type partialWord struct {
+	Word
+	seen int // Consecutive interims that agreed on the text
+}
+
+// stable reports whether later interims should only revise the word with a
+// more confident hypothesis
+func (w *partialWord) stable() bool {
+	return w.seen >= stableAfter || w.Confidence >= stableConfidence
+}
+
+// PartialTranscript merges a segment's interim results into a stable partial
+// transcript. Deepgram re-sends the whole segment with each interim and
+// may change earlier words, so each interim is aligned with the words so
+// far by timing and merged word by word, weighing agreement and confidence.
+// The zero value is ready to use
+type PartialTranscript struct {
+	mu        sync.Mutex
+	words     []partialWord
+	revisions int
+}
+
+// Update merges an interim result and returns the partial transcript
+func (p *PartialTranscript) Update(result *TranscriptionResult) Partial {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	words := result.Words
+	if len(words) == 0 && result.Text != "" {
+		// No word timing: the interim replaces the partial outright
+		p.words = p.words[:0]
+		for _, text := range strings.Fields(result.Text) {
+			p.words = append(p.words, partialWord{Word: Word{Text: text, Confidence: result.Confidence}, seen: 1})
+		}
+		return p.snapshot()
+	}
+
+	merged := make([]partialWord, 0, len(words))
+	j := 0
+	for _, w := range words {
+		// Skip earlier words that ended before this one
+		for j < len(p.words) && !overlaps(p.words[j].Word, w) && p.words[j].End <= w.Start {
+			j++
+		}
+		if j == len(p.words) || !overlaps(p.words[j].Word, w) {
+			merged = append(merged, partialWord{Word: w, seen: 1})
+			continue
+		}
+
+		prev := p.words[j]
+		j++
+		switch {
+		case sameWord(prev.Text, w.Text):
+			// Agreement: weight the confidence by how many interims agree
+			n := float64(prev.seen)
+			w.Confidence = (prev.Confidence*n + w.Confidence) / (n + 1)
+			merged = append(merged, partialWord{Word: w, seen: prev.seen + 1})
+		case prev.stable() && prev.Confidence > w.Confidence:
+			// A less confident guess doesn't overturn a stable word
+			merged = append(merged, prev)
+		default:
+			p.revisions++
+			merged = append(merged, partialWord{Word: w, seen: 1})
+		}
+	}
+	p.words = merged
+	return p.snapshot()
+}
+
+// Snapshot returns the partial transcript without changing it
+func (p *PartialTranscript) Snapshot() Partial {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.snapshot()
+}
+
+// Reset starts a new segment, once a final result has covered the last one
+func (p *PartialTranscript) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.words = nil
+	p.revisions = 0
+}
+
+// snapshot builds the partial transcript. Callers hold mu
+func (p *PartialTranscript) snapshot() Partial {
+	partial := Partial{Revisions: p.revisions}
+	if len(p.words) == 0 {
+		return partial
+	}
+
+	texts := make([]string, len(p.words))
+	partial.Words = make([]Word, len(p.words))
+	stable := 0
+	confidence := 0.0
+	for i := range p.words {
+		texts[i] = p.words[i].Text
+		partial.Words[i] = p.words[i].Word
+		confidence += p.words[i].Confidence
+		if stable == i && p.words[i].stable() {
+			stable++
+		}
+	}
+	partial.Text = strings.Join(texts, " ")
+	partial.Stable = strings.Join(texts[:stable], " ")
+	partial.Confidence = confidence / float64(len(p.words))
+	return partial
+}
+
+// overlaps reports whether two hypotheses cover the same stretch of audio
+func overlaps(a, b Word) bool {
+	return a.Start < b.End && b.Start < a.End
+}
+
+// sameWord compares words ignoring case and punctuation, which Deepgram
+// revises as the sentence takes shape
+func sameWord(a, b string) bool {
+	trim := func(s string) string {
+		return strings.ToLower(strings.TrimFunc(s, unicode.IsPunct))
+	}
+	return trim(a) == trim(b)
+}
@@ -0,0 +1,69 @@
+package stt
+
+import "testing"
+
+// interim builds an interim result from words 0.5s apart
+func interim(words []string, confidences ...float64) *TranscriptionResult {
+	result := &TranscriptionResult{}
+	for i, text := range words {
+		result.Words = append(result.Words, Word{Text: text, Start: float64(i) * 0.5, End: float64(i)*0.5 + 0.4, Confidence: confidences[i]})
+	}
+	return result
+}
+
+func TestPartialTranscript_StablePrefix(t *testing.T) {
+	var p PartialTranscript
+
+	partial := p.Update(interim([]string{"i", "need"}, 0.8, 0.6))
+	if partial.Text != "i need" || partial.Stable != "" {
+		t.Errorf("Expected nothing stable after one interim, got %+v", partial)
+	}
+
+	partial = p.Update(interim([]string{"I", "need", "a"}, 0.8, 0.7, 0.5))
+	if partial.Text != "I need a" {
+		t.Errorf("Expected text %q, got %q", "I need a", partial.Text)
+	}
+	if partial.Stable != "I need" {
+		t.Errorf("Expected the words two interims agree on to be stable, got %q", partial.Stable)
+	}
+	if partial.Revisions != 0 {
+		t.Errorf("Expected case changes not to count as revisions, got %d", partial.Revisions)
+	}
+	if len(partial.Words) != 3 || partial.Words[2].Text != "a" || partial.Words[2].Start != 1.0 || partial.Words[2].End != 1.4 {
+		t.Errorf("Expected the partial's words with their timing, got %+v", partial.Words)
+	}
+}
+
+func TestPartialTranscript_Corrections(t *testing.T) {
+	var p PartialTranscript
+	p.Update(interim([]string{"i", "need", "a", "liar"}, 0.9, 0.9, 0.8, 0.4))
+
+	// Deepgram corrects an unstable word: the correction wins
+	partial := p.Update(interim([]string{"i", "need", "a", "lawyer"}, 0.9, 0.9, 0.8, 0.7))
+	if partial.Text != "i need a lawyer" || partial.Revisions != 1 {
+		t.Errorf("Expected the corrected word, got %+v", partial)
+	}
+
+	// Once stable, a less confident guess doesn't overturn it
+	p.Update(interim([]string{"i", "need", "a", "lawyer"}, 0.9, 0.9, 0.8, 0.7))
+	partial = p.Update(interim([]string{"i", "need", "a", "layer"}, 0.9, 0.9, 0.8, 0.5))
+	if partial.Text != "i need a lawyer" {
+		t.Errorf("Expected the stable word to be kept, got %q", partial.Text)
+	}
+	if partial.Stable != "i need a lawyer" {
+		t.Errorf("Expected the whole partial to be stable, got %q", partial.Stable)
+	}
+
+	p.Reset()
+	if partial := p.Snapshot(); partial.Text != "" || partial.Revisions != 0 {
+		t.Errorf("Expected an empty partial after Reset, got %+v", partial)
+	}
+}
+
+func TestPartialTranscript_WithoutWords(t *testing.T) {
+	var p PartialTranscript
+	partial := p.Update(&TranscriptionResult{Text: "hello there", Confidence: 0.95})
+	if partial.Text != "hello there" || partial.Stable != "hello there" {
+		t.Errorf("Expected a confident interim without words to be used whole, got %+v", partial)
+	}
+}
@@ -0,0 +1,279 @@
+package stt
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	interfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/interfaces"
+	listenClient "github.com/deepgram/deepgram-go-sdk/v3/pkg/client/listen"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+const (
+	// rotationDrain is how long a replaced connection is given to return the
+	// finals of its last audio before it is closed
+	rotationDrain = 2 * time.Second
+
+	// rotationRetry is how long to wait before trying again after a
+	// replacement connection could not be opened
+	rotationRetry = time.Minute
+)
+
+// stream is one Deepgram connection of a session. Its results are in its own
+// stream time, which starts offset seconds into the session's audio
+type stream struct {
+	client    *listenClient.WSCallback
+	session   *session // Session the connection's results go to
+	timing    resultTiming
+	closeOnce sync.Once
+
+	// Guarded by the client's mu
+	offset float64 // Session time of the connection's first audio, in seconds
+	from   float64 // Session time range of the words the connection reports,
+	until  float64 // narrowed while a rotation overlaps two connections
+}
+
+func newStream(sess *session, offset float64) *stream {
+	return &stream{session: sess, offset: offset, until: math.Inf(1)}
+}
+
+// close ends the connection once. Stop sends Deepgram's CloseStream and
+// closes the socket (the SDK's Finish does nothing)
+func (st *stream) close() {
+	defer observability.RecoverPanic("stt", "close_stream", nil)
+	st.closeOnce.Do(func() {
+		if st.client != nil {
+			st.client.Stop()
+		}
+	})
+}
+
+// rotation replaces a long-lived connection before it reaches Deepgram's
+// session limits. A standby connection is opened, sent the same audio for an
+// overlap, and takes over once the overlap ends. Words are split between the
+// two at the overlap's midpoint, so each comes from the connection that heard
+// all of it. Guarded by the client's mu
+type rotation struct {
+	dueAt      time.Time // When the live connection should be replaced; zero never
+	dialing    bool
+	standby    *stream
+	fed        bool    // Whether the standby has been sent audio
+	overlapEnd float64 // Session time the overlap ends at
+}
+
+// schedule sets when a connection opened now is due for replacement
+func (r *rotation) schedule(cfg *config.Config, now time.Time) {
+	r.dueAt = time.Time{}
+	if cfg.DeepgramSessionMaxMinutes > 0 {
+		r.dueAt = now.Add(time.Duration(cfg.DeepgramSessionMaxMinutes) * time.Minute)
+	}
+}
+
+// sessionTime is the audio sent in the session so far, across connections,
+// in seconds
+func (d *DeepgramClient) sessionTime() float64 {
+	return float64(d.sent.Load()) / mulawBytesPerSecond
+}
+
+// advanceRotation starts a rotation that is due, or completes one whose
+// overlap has ended. It runs after each chunk sent on st, the live connection
+func (d *DeepgramClient) advanceRotation(st *stream) {
+	d.mu.RLock()
+	r := d.rotation
+	d.mu.RUnlock()
+
+	switch {
+	case r.standby != nil && r.fed && d.sessionTime() >= r.overlapEnd:
+		d.cutOver(st, r.standby)
+	case r.standby == nil && !r.dialing && !r.dueAt.IsZero() && time.Now().After(r.dueAt):
+		d.mu.Lock()
+		start := !d.rotation.dialing && d.rotation.standby == nil
+		d.rotation.dialing = true
+		d.mu.Unlock()
+		if start {
+			go d.dialStandby()
+		}
+	}
+}
+
+// dialStandby opens the connection that will replace the live one
+func (d *DeepgramClient) dialStandby() {
+	defer observability.RecoverPanic("stt", "rotation_dial", nil)
+
+	d.mu.RLock()
+	sess := d.session
+	tOptions, cOptions := d.streamOptions()
+	d.mu.RUnlock()
+
+	st := newStream(sess, 0)
+	client, err := d.connect(st, tOptions, cOptions)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rotation.dialing = false
+	if err != nil {
+		observability.RecordSTTRotation("failed")
+		log.Printf("Failed to open a replacement Deepgram connection, retrying in %v: %v", rotationRetry, err)
+		d.rotation.dueAt = time.Now().Add(rotationRetry)
+		return
+	}
+	st.client = client
+	if !d.isActive || d.session != sess || d.ctx.Err() != nil {
+		go st.close()
+		return
+	}
+	d.rotation.standby = st
+	d.rotation.fed = false
+}
+
+// connect opens a connection for st and waits for the socket, so audio is
+// not held up when the connection takes over
+func (d *DeepgramClient) connect(st *stream, tOptions *interfaces.LiveTranscriptionOptions, cOptions *interfaces.ClientOptions) (*listenClient.WSCallback, error) {
+	release, err := sharedBulkhead(d.config).Acquire(d.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client, err := listenClient.NewWSUsingCallback(d.ctx, d.config.DeepgramAPIKey, cOptions, tOptions, d.newCallback(st))
+	if err != nil {
+		return nil, err
+	}
+	if !client.Connect() {
+		client.Stop()
+		return nil, fmt.Errorf("failed to connect to Deepgram")
+	}
+	return client, nil
+}
+
+// feedStandby sends a chunk to the standby connection; the first chunk
+// starts the overlap and splits the words between the two connections
+func (d *DeepgramClient) feedStandby(standby *stream, audioData []byte) {
+	d.mu.Lock()
+	if d.rotation.standby != standby || d.conn == nil {
+		d.mu.Unlock()
+		return
+	}
+	if !d.rotation.fed {
+		d.beginOverlap(standby)
+	}
+	d.mu.Unlock()
+
+	if _, err := standby.client.Write(audioData); err != nil {
+		log.Printf("Replacement Deepgram connection failed, keeping the current one: %v", err)
+		d.mu.Lock()
+		if d.rotation.standby == standby {
+			d.abandonRotation()
+		}
+		d.mu.Unlock()
+		return
+	}
+	standby.timing.sent(len(audioData), time.Now())
+}
+
+// beginOverlap starts the standby's stream time at the session's, and hands
+// it the words starting after the overlap's midpoint. Callers hold mu
+func (d *DeepgramClient) beginOverlap(standby *stream) {
+	now := d.sessionTime()
+	overlap := time.Duration(d.config.DeepgramRotationOverlapMs) * time.Millisecond
+	standby.offset = now
+	standby.from = now + overlap.Seconds()/2
+	d.conn.until = standby.from
+	d.rotation.overlapEnd = now + overlap.Seconds()
+	d.rotation.fed = true
+}
+
+// cutOver makes the standby the live connection, then drains the old one
+func (d *DeepgramClient) cutOver(old, standby *stream) {
+	d.mu.Lock()
+	if d.conn != old || d.rotation.standby != standby {
+		d.mu.Unlock()
+		return
+	}
+	d.conn = standby
+	d.rotation = rotation{}
+	d.rotation.schedule(d.config, time.Now())
+	d.mu.Unlock()
+
+	observability.RecordSTTRotation("completed")
+	log.Printf("Deepgram connection rotated at %.1fs of audio", standby.offset)
+	d.startKeepAlive(standby)
+	go d.drain(old)
+}
+
+// drain asks a replaced connection for the finals of its last audio, then
+// closes it
+func (d *DeepgramClient) drain(old *stream) {
+	defer observability.RecoverPanic("stt", "rotation_drain", nil)
+	if err := old.client.Finalize(); err != nil {
+		log.Printf("Failed to finalize the replaced Deepgram connection: %v", err)
+	}
+	select {
+	case <-time.After(rotationDrain):
+	case <-d.ctx.Done():
+	}
+	old.close()
+}
+
+// abandonRotation drops the standby connection, if any, leaving the live
+// connection with all words. Callers hold mu
+func (d *DeepgramClient) abandonRotation() {
+	if standby := d.rotation.standby; standby != nil {
+		observability.RecordSTTRotation("abandoned")
+		go standby.close()
+		if d.conn != nil {
+			d.conn.until = math.Inf(1)
+		}
+		d.rotation.dueAt = time.Now().Add(rotationRetry)
+	}
+	d.rotation.standby = nil
+	d.rotation.fed = false
+}
+
+// toSession moves a result from st's stream time into session time, keeping
+// only the words st is responsible for. It returns nil when none are left
+func (d *DeepgramClient) toSession(st *stream, result *TranscriptionResult) *TranscriptionResult {
+	d.mu.RLock()
+	offset, from, until := st.offset, st.from, st.until
+	d.mu.RUnlock()
+
+	result.StartTime += offset
+	for i := range result.Words {
+		result.Words[i].Start += offset
+		result.Words[i].End += offset
+	}
+	if len(result.Words) == 0 {
+		if result.StartTime < from || result.StartTime >= until {
+			return nil
+		}
+		return result
+	}
+
+	kept := make([]Word, 0, len(result.Words))
+	for _, w := range result.Words {
+		if w.Start >= from && w.Start < until {
+			kept = append(kept, w)
+		}
+	}
+	switch len(kept) {
+	case len(result.Words):
+		return result
+	case 0:
+		return nil
+	}
+	texts := make([]string, len(kept))
+	for i, w := range kept {
+		texts[i] = w.Text
+	}
+	result.Text = strings.Join(texts, " ")
+	result.Words = kept
+	result.StartTime = kept[0].Start
+	result.Duration = kept[len(kept)-1].End - kept[0].Start
+	return result
+}
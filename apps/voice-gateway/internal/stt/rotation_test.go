@@ -0,0 +1,78 @@
+package stt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// finalResult builds a Deepgram final from words in the connection's stream time
+func finalResult(words ...msginterfaces.Word) *msginterfaces.MessageResponse {
+	texts := make([]string, len(words))
+	for i, w := range words {
+		texts[i] = w.PunctuatedWord
+	}
+	msg := &msginterfaces.MessageResponse{Type: "Results", IsFinal: true, Start: words[0].Start}
+	msg.Channel.Alternatives = []msginterfaces.Alternative{{Transcript: strings.Join(texts, " "), Confidence: 0.98, Words: words}}
+	return msg
+}
+
+func word(text string, start, end float64) msginterfaces.Word {
+	return msginterfaces.Word{Word: strings.ToLower(strings.Trim(text, ",.")), PunctuatedWord: text, Start: start, End: end, Confidence: 0.98}
+}
+
+func TestRotation_StitchesWordsAcrossConnections(t *testing.T) {
+	d := NewDeepgramClient(&config.Config{DeepgramLanguage: "en", DeepgramModel: "nova-2", DeepgramRotationOverlapMs: 2000})
+	defer d.cancel()
+	sess := newSession()
+	old := newStream(sess, 0)
+	d.conn = old
+	d.sent.Store(9 * mulawBytesPerSecond)
+
+	// The standby joins 9s into the session; words from 10s on are its
+	standby := newStream(sess, 0)
+	d.rotation.standby = standby
+	d.beginOverlap(standby)
+
+	// The old connection heard until 11s, cutting the last word short; the
+	// standby, from 9s, came in mid-word
+	d.handleDeepgramMessage(old, finalResult(word("Please", 9.2, 9.6), word("call", 9.7, 10.1), word("me", 10.2, 10.4), word("ba-", 10.9, 11.0)))
+	d.handleDeepgramMessage(standby, finalResult(word("all", 0.5, 1.1), word("me", 1.2, 1.4), word("back", 1.5, 1.9), word("tomorrow.", 2.0, 2.6)))
+
+	var texts []string
+	var starts []float64
+	for len(sess.results) > 0 {
+		result := <-sess.results
+		texts = append(texts, result.Text)
+		starts = append(starts, result.StartTime)
+	}
+	if got := strings.Join(texts, " | "); got != "Please call | me back tomorrow." {
+		t.Errorf("Expected each word once, from the connection that heard all of it, got %q", got)
+	}
+	if len(starts) == 2 && (starts[0] != 9.2 || starts[1] != 10.2) {
+		t.Errorf("Expected results in session time, got %v", starts)
+	}
+
+	// A standby result entirely before the split is dropped
+	d.handleDeepgramMessage(standby, finalResult(word("all", 0.5, 0.9)))
+	if len(sess.results) != 0 {
+		t.Error("Expected the old connection's words not to be repeated")
+	}
+}
+
+func TestRotation_Schedule(t *testing.T) {
+	now := time.Now()
+	var r rotation
+	r.schedule(&config.Config{DeepgramSessionMaxMinutes: 60}, now)
+	if !r.dueAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected the connection due for replacement after an hour, got %v", r.dueAt.Sub(now))
+	}
+	r.schedule(&config.Config{}, now)
+	if !r.dueAt.IsZero() {
+		t.Error("Expected no rotation with DEEPGRAM_SESSION_MAX_MINUTES=0")
+	}
+}
@@ -0,0 +1,44 @@
+package stt
+
+import "sync"
+
+// session is one Start-to-Stop transcription session, which may span several
+// connections through reconnects and rotations. Its results channel belongs
+// to the session, so a restarted client never hands a caller the previous
+// session's results
+type session struct {
+	results chan *TranscriptionResult
+	mu      sync.RWMutex
+	closed  bool
+}
+
+func newSession() *session {
+	return &session{results: make(chan *TranscriptionResult, 100)}
+}
+
+// deliver sends result without blocking, reporting false when it is dropped
+// because the reader fell behind or the session has ended. The read lock is
+// held across the send, so end cannot close the channel underneath it
+func (s *session) deliver(result *TranscriptionResult) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+	select {
+	case s.results <- result:
+		return true
+	default:
+		return false
+	}
+}
+
+// end closes the results channel once; nothing is delivered after it
+func (s *session) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.results)
+	}
+}
@@ -0,0 +1,98 @@
+package stt
+
+import (
+	"sync"
+	"testing"
+
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// startedClient returns a client mid-session on a connection with no socket
+func startedClient() (*DeepgramClient, *session, *stream) {
+	d := NewDeepgramClient(&config.Config{DeepgramLanguage: "en", DeepgramModel: "nova-2"})
+	sess := newSession()
+	st := newStream(sess, 0)
+	d.session = sess
+	d.conn = st
+	d.isActive = true
+	return d, sess, st
+}
+
+func interimResult(text string) *msginterfaces.MessageResponse {
+	msg := &msginterfaces.MessageResponse{Type: "Results"}
+	msg.Channel.Alternatives = []msginterfaces.Alternative{{Transcript: text}}
+	return msg
+}
+
+func TestSession_StopClosesResults(t *testing.T) {
+	d, sess, st := startedClient()
+	defer d.Close()
+
+	d.handleDeepgramMessage(st, interimResult("hello"))
+	if err := d.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	// Results delivered before Stop are still read, then the channel closes
+	if result, ok := <-sess.results; !ok || result.Text != "hello" {
+		t.Errorf("Expected the result sent before Stop, got %v (open %v)", result, ok)
+	}
+	if _, ok := <-sess.results; ok {
+		t.Fatal("Expected the results channel closed by Stop")
+	}
+
+	// A straggler from the stopped connection is dropped, not sent on the
+	// closed channel
+	d.handleDeepgramMessage(st, interimResult("late"))
+
+	// Stopping again, or closing, is harmless
+	if err := d.Stop(); err != nil {
+		t.Errorf("Expected a second Stop to succeed, got %v", err)
+	}
+}
+
+func TestSession_StopRacesDelivery(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		d, sess, st := startedClient()
+
+		var senders sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			senders.Add(1)
+			go func() {
+				defer senders.Done()
+				for k := 0; k < 50; k++ {
+					d.handleDeepgramMessage(st, interimResult("racing"))
+				}
+			}()
+		}
+		drained := make(chan struct{})
+		go func() {
+			for range sess.results {
+			}
+			close(drained)
+		}()
+
+		if err := d.Stop(); err != nil {
+			t.Fatalf("Stop failed: %v", err)
+		}
+		<-drained
+		senders.Wait()
+		d.Close()
+	}
+}
+
+func TestSession_RestartAfterStop(t *testing.T) {
+	d, _, _ := startedClient()
+	defer d.Close()
+
+	if err := d.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	// A reconnect still in flight must not revive a stopped session
+	if err := d.restart(); err != nil || d.IsActive() {
+		t.Errorf("Expected a stopped session to stay stopped, got active %v (%v)", d.IsActive(), err)
+	}
+}
@@ -0,0 +1,155 @@
+package stt
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	msginterfaces "github.com/deepgram/deepgram-go-sdk/v3/pkg/api/listen/v1/websocket/interfaces"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// mulawBytesPerSecond is the rate of the 8kHz μ-law stream sent to Deepgram
+const mulawBytesPerSecond = 8000
+
+// audioClockSpan is how much recently sent audio the clock remembers
+const audioClockSpan = 30 * time.Second
+
+// Finalization triggers, for the finalization delay histogram
+const (
+	triggerFinalize = "finalize" // Local VAD forced the final
+	triggerEndpoint = "endpoint" // Deepgram's own endpointing produced it
+)
+
+// audioMark is one chunk sent to Deepgram
+type audioMark struct {
+	end float64   // Stream time at the end of the chunk, in seconds
+	at  time.Time // When the chunk was sent
+}
+
+// resultTiming measures how quickly and how stably Deepgram's results arrive,
+// to guide UtteranceEndMs and endpointing tuning. Result timestamps are in
+// stream time, the seconds of audio sent on the connection, so the audio
+// sent is remembered to turn them back into wall-clock time
+type resultTiming struct {
+	mu           sync.Mutex
+	bytes        int64       // Audio sent on the current connection
+	marks        []audioMark // Recent chunks, oldest first
+	forgotten    float64     // Stream time sent before the oldest remembered chunk
+	timedUpTo    float64     // Stream time of the newest word already timed
+	interimWords []string    // Words of the current segment's latest interim
+}
+
+// reset starts a new connection, whose stream time starts at zero
+func (t *resultTiming) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytes = 0
+	t.marks = nil
+	t.forgotten = 0
+	t.timedUpTo = 0
+	t.interimWords = nil
+}
+
+// sent records a chunk of audio written to the connection
+func (t *resultTiming) sent(n int, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bytes += int64(n)
+	t.marks = append(t.marks, audioMark{end: float64(t.bytes) / mulawBytesPerSecond, at: at})
+
+	// Forget chunks too old for any pending result
+	drop := 0
+	for drop < len(t.marks) && at.Sub(t.marks[drop].at) > audioClockSpan {
+		drop++
+	}
+	if drop > 0 {
+		t.forgotten = t.marks[drop-1].end
+		t.marks = t.marks[drop:]
+	}
+}
+
+// sentAt returns when the audio at a stream time was sent. Callers hold mu
+func (t *resultTiming) sentAt(streamTime float64) (time.Time, bool) {
+	i := sort.Search(len(t.marks), func(i int) bool { return t.marks[i].end >= streamTime })
+	if i == len(t.marks) || streamTime < t.forgotten {
+		return time.Time{}, false
+	}
+	return t.marks[i].at, true
+}
+
+// observe times a result against the audio of its last word: interims give
+// word latency as new words appear, finals the finalization delay. Finals
+// are also compared with the segment's last interim to count corrections
+func (t *resultTiming) observe(msg *msginterfaces.MessageResponse, alt *msginterfaces.Alternative, model, language string, now time.Time) {
+	if len(alt.Words) == 0 {
+		return
+	}
+	lastWordEnd := alt.Words[len(alt.Words)-1].End
+
+	t.mu.Lock()
+	sentAt, known := t.sentAt(lastWordEnd)
+	newWord := lastWordEnd > t.timedUpTo
+	if newWord {
+		t.timedUpTo = lastWordEnd
+	}
+	interim := t.interimWords
+	if msg.IsFinal {
+		t.interimWords = nil
+	} else {
+		t.interimWords = resultWords(alt.Words)
+	}
+	t.mu.Unlock()
+
+	if !msg.IsFinal {
+		if known && newWord {
+			observability.RecordSTTWordLatency(model, language, now.Sub(sentAt))
+		}
+		return
+	}
+
+	if known {
+		trigger := triggerEndpoint
+		if msg.FromFinalize {
+			trigger = triggerFinalize
+		}
+		observability.RecordSTTFinalizationDelay(model, language, trigger, now.Sub(sentAt))
+	}
+	if interim != nil {
+		final := resultWords(alt.Words)
+		observability.RecordSTTCorrection(model, language, len(final), wordDistance(interim, final))
+	}
+}
+
+// resultWords returns a result's words, normalized for comparison
+func resultWords(words []msginterfaces.Word) []string {
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = strings.ToLower(w.Word)
+	}
+	return normalized
+}
+
+// wordDistance is the word-level edit distance between two transcripts: the
+// words inserted, deleted or substituted to turn a into b
+func wordDistance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,52 @@
+package stt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultTiming_SentAt(t *testing.T) {
+	var timing resultTiming
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ { // 2 seconds of 20ms frames
+		timing.sent(160, start.Add(time.Duration(i)*20*time.Millisecond))
+	}
+
+	at, ok := timing.sentAt(1.0)
+	if !ok || !at.Equal(start.Add(980*time.Millisecond)) {
+		t.Errorf("Expected the frame ending at 1s to be sent at 980ms, got %v (%v)", at.Sub(start), ok)
+	}
+	if _, ok := timing.sentAt(2.5); ok {
+		t.Error("Expected audio not yet sent to be unknown")
+	}
+
+	// Frames older than the clock's span are forgotten
+	timing.sent(160, start.Add(audioClockSpan+time.Second))
+	if _, ok := timing.sentAt(0.5); ok {
+		t.Error("Expected forgotten audio to be unknown")
+	}
+
+	timing.reset()
+	if _, ok := timing.sentAt(0.01); ok {
+		t.Error("Expected a new connection to start with no audio")
+	}
+}
+
+func TestWordDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"i need a lawyer", "i need a lawyer", 0},
+		{"i need a liar", "i need a lawyer", 1},
+		{"i need", "i need a lawyer", 2},
+		{"", "hello", 1},
+		{"call me at five", "call me", 2},
+	}
+	for _, tt := range tests {
+		if got := wordDistance(strings.Fields(tt.a), strings.Fields(tt.b)); got != tt.want {
+			t.Errorf("wordDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
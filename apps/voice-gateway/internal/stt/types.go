@@ -16,24 +16,69 @@ type TranscriptionResult struct {
 	
 	// Duration is the duration of the utterance in seconds
 	Duration float64
+
+	// Language is the language a multilingual model heard, when it reports one
+	Language string
+
+	// Words are the result's words with their timing and confidence, when
+	// the provider reports them
+	Words []Word
+}
+
+// Word is one recognized word of a transcription result, as punctuated and
+// cased by the provider. Times are in seconds of audio since the session
+// started, across reconnects, so they line up with the call's own audio
+type Word struct {
+	Text       string
+	Start      float64
+	End        float64
+	Confidence float64
 }
 
 // STTClient is the interface for speech-to-text clients
 type STTClient interface {
-	// Start begins a new transcription session
-	Start() error
+	// Start begins a new transcription session. Its results arrive on the
+	// returned channel, which is closed once the session is stopped; each
+	// session gets a channel of its own
+	Start() (<-chan *TranscriptionResult, error)
 	
 	// SendAudio sends an audio chunk to the STT service
 	SendAudio(audioData []byte) error
 	
-	// GetTranscription returns the next transcription result
-	// Returns nil if no transcription is available yet
-	GetTranscription() <-chan *TranscriptionResult
+	// Finalize forces the provider to flush buffered audio as a final result
+	// Used when local VAD detects the end of an utterance
+	Finalize() error
 	
-	// Stop stops the transcription session
+	// Stop stops the transcription session, closing its results channel
+	// after the provider's last results are delivered
 	Stop() error
 	
 	// Close closes the client and cleans up resources
 	Close() error
 }
 
+// EndpointingMode selects which component decides that the caller finished speaking
+type EndpointingMode string
+
+const (
+	EndpointingDeepgram EndpointingMode = "deepgram" // Deepgram UtteranceEndMs only
+	EndpointingVAD      EndpointingMode = "vad"      // Local VAD finalizes, silence is not forwarded
+	EndpointingHybrid   EndpointingMode = "hybrid"   // Both signals active, whichever fires first wins
+)
+
+// UsesLocalVAD returns whether local end-of-speech should force finalization
+func (m EndpointingMode) UsesLocalVAD() bool {
+	return m == EndpointingVAD || m == EndpointingHybrid
+}
+
+// UsesUtteranceEnd returns whether the provider's utterance-end timer is enabled
+func (m EndpointingMode) UsesUtteranceEnd() bool {
+	return m != EndpointingVAD
+}
+
+// ForwardsSilence returns whether silence frames are sent to the provider
+// The provider's utterance-end timer needs silence to measure, so only
+// pure VAD mode withholds it
+func (m EndpointingMode) ForwardsSilence() bool {
+	return m != EndpointingVAD
+}
@@ -0,0 +1,57 @@
+package stt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// deepgramDefaultHost serves Deepgram's API when DEEPGRAM_API_HOST is unset
+const deepgramDefaultHost = "api.deepgram.com"
+
+// VerifyDeepgramKey checks that apiKey is accepted by the Deepgram API at
+// host ("" for Deepgram's own), listing the key's projects, which costs
+// nothing
+func VerifyDeepgramKey(ctx context.Context, host, apiKey string) error {
+	if host == "" {
+		host = deepgramDefaultHost
+	}
+	base := host
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	base = strings.Replace(strings.Replace(base, "wss://", "https://", 1), "ws://", "http://", 1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+"/v1/projects", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Deepgram: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("deepgram rejected the API key (status %d)", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("deepgram API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// VerifyVosk checks that the Vosk server at url accepts a connection
+func VerifyVosk(ctx context.Context, url string) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach Vosk: %w", err)
+	}
+	return conn.Close()
+}
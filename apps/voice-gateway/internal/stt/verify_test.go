@@ -0,0 +1,30 @@
+package stt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyDeepgramKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Token good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"projects":[]}`))
+	}))
+	defer server.Close()
+
+	if err := VerifyDeepgramKey(context.Background(), server.URL, "good-key"); err != nil {
+		t.Errorf("Expected the key to verify, got %v", err)
+	}
+	if err := VerifyDeepgramKey(context.Background(), server.URL, "bad-key"); err == nil {
+		t.Error("Expected a rejected key to fail")
+	}
+}
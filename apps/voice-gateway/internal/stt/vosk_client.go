@@ -0,0 +1,337 @@
+package stt
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+// Providers accepted by STT_PROVIDER and firm settings
+const (
+	ProviderDeepgram = "deepgram"
+	ProviderVosk     = "vosk" // Self-hosted Vosk server; audio never leaves the firm's network
+)
+
+const (
+	// voskDialTimeout bounds connecting to the Vosk server
+	voskDialTimeout = 5 * time.Second
+
+	// voskCloseTimeout is how long Stop waits for the final result after
+	// telling the server the audio has ended
+	voskCloseTimeout = 2 * time.Second
+)
+
+// NewClient creates the STT client for cfg's provider
+func NewClient(cfg *config.Config) STTClient {
+	if cfg.STTProvider == ProviderVosk {
+		return NewVoskClient(cfg)
+	}
+	return NewDeepgramClient(cfg)
+}
+
+// voskConfig is the first message on a Vosk connection
+type voskConfig struct {
+	Config struct {
+		SampleRate int `json:"sample_rate"`
+		Words      int `json:"words"`
+	} `json:"config"`
+}
+
+// voskMessage is a Vosk result: partial while the utterance goes on, then
+// text with its words once Vosk's own endpointing closes it
+type voskMessage struct {
+	Partial string `json:"partial"`
+	Text    string `json:"text"`
+	Result  []struct {
+		Word  string  `json:"word"`
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Conf  float64 `json:"conf"`
+	} `json:"result"`
+}
+
+// VoskClient implements STTClient against a self-hosted Vosk WebSocket
+// server, for firms that cannot send audio to a cloud provider. Twilio's
+// 20ms μ-law frames are decoded to 16-bit PCM and sent in VOSK_CHUNK_MS
+// chunks: larger chunks cost Vosk less per second of audio, smaller ones
+// return words sooner. Vosk punctuates nothing and decides itself when an
+// utterance ends, so Finalize only flushes the audio held for the next chunk
+type VoskClient struct {
+	config     *config.Config
+	chunkBytes int // PCM bytes sent per message
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	session  *session
+	isActive bool
+	readDone chan struct{} // Closed when the live connection's reader exits
+	pending  []byte        // PCM held for the next chunk
+	sent     int64         // μ-law bytes sent in the session, across connections
+
+	writeMu sync.Mutex // A connection takes one writer at a time
+}
+
+// NewVoskClient creates a client for the Vosk server at VOSK_URL
+func NewVoskClient(cfg *config.Config) *VoskClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &VoskClient{
+		config:     cfg,
+		chunkBytes: cfg.VoskChunkMs * mulawBytesPerSecond / 1000 * 2,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start begins a transcription session, returning the channel its results
+// arrive on
+func (v *VoskClient) Start() (<-chan *TranscriptionResult, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.session != nil {
+		return nil, fmt.Errorf("vosk client is already active")
+	}
+	sess := newSession()
+	v.sent = 0
+	if err := v.open(sess); err != nil {
+		return nil, err
+	}
+	v.session = sess
+	return sess.results, nil
+}
+
+// open connects to Vosk and starts reading results for sess. Callers hold mu
+func (v *VoskClient) open(sess *session) error {
+	ctx, cancel := context.WithTimeout(v.ctx, voskDialTimeout)
+	defer cancel()
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, v.config.VoskURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Vosk: %w", err)
+	}
+
+	var cfg voskConfig
+	cfg.Config.SampleRate = mulawBytesPerSecond
+	cfg.Config.Words = 1
+	if err := conn.WriteJSON(cfg); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to configure Vosk: %w", err)
+	}
+
+	// Vosk times words from the start of each connection
+	offset := float64(v.sent) / mulawBytesPerSecond
+	done := make(chan struct{})
+	v.conn = conn
+	v.readDone = done
+	v.pending = v.pending[:0]
+	v.isActive = true
+	go v.read(conn, sess, offset, done)
+
+	log.Printf("Vosk streaming client started (%s, %dms chunks)", v.config.VoskURL, v.config.VoskChunkMs)
+	return nil
+}
+
+// read delivers a connection's results to sess until the connection closes
+func (v *VoskClient) read(conn *websocket.Conn, sess *session, offset float64, done chan struct{}) {
+	defer close(done)
+	defer observability.RecoverPanic("stt", "vosk_read", nil)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			v.connectionLost(conn, err)
+			return
+		}
+		var msg voskMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			log.Printf("Vosk: failed to decode message: %v", err)
+			continue
+		}
+		if result := msg.result(offset); result != nil && !sess.deliver(result) {
+			log.Printf("Warning: transcript channel full or closed, dropping transcription")
+		}
+	}
+}
+
+// result converts a Vosk message into session time, or nil when it holds no speech
+func (m *voskMessage) result(offset float64) *TranscriptionResult {
+	if m.Text == "" {
+		if m.Partial == "" {
+			return nil
+		}
+		return &TranscriptionResult{Text: m.Partial}
+	}
+
+	result := &TranscriptionResult{Text: m.Text, IsFinal: true}
+	confidence := 0.0
+	for _, w := range m.Result {
+		result.Words = append(result.Words, Word{Text: w.Word, Start: w.Start + offset, End: w.End + offset, Confidence: w.Conf})
+		confidence += w.Conf
+	}
+	if n := len(result.Words); n > 0 {
+		result.Confidence = confidence / float64(n)
+		result.StartTime = result.Words[0].Start
+		result.Duration = result.Words[n-1].End - result.StartTime
+	}
+	return result
+}
+
+// connectionLost reconnects when the live connection fails mid-session
+func (v *VoskClient) connectionLost(conn *websocket.Conn, err error) {
+	v.mu.Lock()
+	live := v.conn == conn && v.isActive
+	if live {
+		v.isActive = false
+	}
+	v.mu.Unlock()
+	if !live {
+		return // Stopped, or already replaced
+	}
+	log.Printf("Vosk connection lost, reconnecting: %v", err)
+	conn.Close()
+	go v.reconnect()
+}
+
+// reconnect reopens the session's connection with the gateway's reconnect backoff
+func (v *VoskClient) reconnect() {
+	defer observability.RecoverPanic("stt", "vosk_reconnect", nil)
+	err := resilience.Reconnect(v.ctx, v.restart, &resilience.ReconnectConfig{
+		MaxAttempts: v.config.ReconnectMaxAttempts,
+		Backoff:     time.Duration(v.config.ReconnectBackoff) * time.Millisecond,
+		Multiplier:  2.0,
+		MaxBackoff:  30 * time.Second,
+	})
+	if err != nil {
+		log.Printf("Failed to reconnect Vosk client: %v", err)
+	}
+}
+
+// restart reopens the current session's connection. A session stopped in the
+// meantime stays stopped
+func (v *VoskClient) restart() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.session == nil || v.isActive {
+		return nil
+	}
+	return v.open(v.session)
+}
+
+// SendAudio decodes a μ-law chunk and sends it once a full chunk is held
+func (v *VoskClient) SendAudio(audioData []byte) error {
+	v.mu.Lock()
+	if !v.isActive {
+		v.mu.Unlock()
+		return fmt.Errorf("vosk client is not active")
+	}
+	for _, sample := range audio.DecodePCMU(audioData) {
+		v.pending = binary.LittleEndian.AppendUint16(v.pending, uint16(sample))
+	}
+	v.sent += int64(len(audioData))
+	var chunk []byte
+	if len(v.pending) >= v.chunkBytes {
+		chunk = v.pending
+		v.pending = make([]byte, 0, v.chunkBytes+len(audioData)*2)
+	}
+	conn := v.conn
+	v.mu.Unlock()
+
+	if chunk == nil {
+		return nil
+	}
+	return v.write(conn, websocket.BinaryMessage, chunk)
+}
+
+// Finalize sends the audio held for the next chunk, so the end of an
+// utterance is not delayed by chunking
+func (v *VoskClient) Finalize() error {
+	v.mu.Lock()
+	if !v.isActive {
+		v.mu.Unlock()
+		return fmt.Errorf("vosk client is not active")
+	}
+	chunk := v.pending
+	v.pending = nil
+	conn := v.conn
+	v.mu.Unlock()
+
+	if len(chunk) == 0 {
+		return nil
+	}
+	return v.write(conn, websocket.BinaryMessage, chunk)
+}
+
+// write sends one message on conn, reconnecting when it fails
+func (v *VoskClient) write(conn *websocket.Conn, messageType int, data []byte) error {
+	v.writeMu.Lock()
+	err := conn.WriteMessage(messageType, data)
+	v.writeMu.Unlock()
+	if err != nil {
+		v.connectionLost(conn, err)
+		return fmt.Errorf("failed to send audio to Vosk: %w", err)
+	}
+	return nil
+}
+
+// Stop ends the session: the held audio and an end-of-stream are sent, and
+// the results channel is closed once Vosk returns its final result
+func (v *VoskClient) Stop() error {
+	v.mu.Lock()
+	sess := v.session
+	if sess == nil {
+		v.mu.Unlock()
+		return nil // Already stopped
+	}
+	active := v.isActive
+	conn, done, chunk := v.conn, v.readDone, v.pending
+	v.session = nil
+	v.isActive = false
+	v.pending = nil
+	v.mu.Unlock()
+
+	if active {
+		v.writeMu.Lock()
+		if len(chunk) > 0 {
+			_ = conn.WriteMessage(websocket.BinaryMessage, chunk)
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"eof" : 1}`))
+		v.writeMu.Unlock()
+
+		// Vosk answers end-of-stream with the final result, then closes
+		select {
+		case <-done:
+		case <-time.After(voskCloseTimeout):
+		}
+	}
+	if conn != nil {
+		conn.Close()
+	}
+	sess.end()
+	log.Printf("Vosk streaming client stopped")
+	return nil
+}
+
+// Close stops the session and any reconnection
+func (v *VoskClient) Close() error {
+	v.cancel()
+	return v.Stop()
+}
+
+// IsActive returns whether the client is currently connected
+func (v *VoskClient) IsActive() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.isActive
+}
@@ -0,0 +1,124 @@
+package stt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// fakeVosk serves the Vosk WebSocket protocol: it records the configuration
+// and the size of each audio message, answers each with a partial, and the
+// end of stream with a final result before closing
+func fakeVosk(t *testing.T, chunks chan<- int) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var cfg voskConfig
+		if err := conn.ReadJSON(&cfg); err != nil || cfg.Config.SampleRate != 8000 || cfg.Config.Words != 1 {
+			t.Errorf("Expected an 8kHz configuration with words, got %+v (%v)", cfg, err)
+			return
+		}
+		for {
+			kind, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if kind == websocket.TextMessage && strings.Contains(string(data), "eof") {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"result":[{"conf":1.0,"start":0.1,"end":0.4,"word":"hello"},{"conf":0.5,"start":0.5,"end":0.9,"word":"there"}],"text":"hello there"}`))
+				return
+			}
+			chunks <- len(data)
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"partial":"hello"}`))
+		}
+	}))
+}
+
+func TestVoskClient_StreamsChunksAndResults(t *testing.T) {
+	chunks := make(chan int, 16)
+	server := fakeVosk(t, chunks)
+	defer server.Close()
+
+	v := NewVoskClient(&config.Config{VoskURL: "ws" + strings.TrimPrefix(server.URL, "http"), VoskChunkMs: 100})
+	defer v.Close()
+	results, err := v.Start()
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// Five 20ms frames fill one 100ms chunk of 16-bit PCM
+	frame := make([]byte, 160)
+	for i := 0; i < 6; i++ {
+		if err := v.SendAudio(frame); err != nil {
+			t.Fatalf("SendAudio failed: %v", err)
+		}
+	}
+	select {
+	case n := <-chunks:
+		if n != 1600 {
+			t.Errorf("Expected a 1600-byte chunk, got %d", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a chunk")
+	}
+	if result := <-results; result.IsFinal || result.Text != "hello" {
+		t.Errorf("Expected the interim \"hello\", got %+v", result)
+	}
+
+	// Stop sends the held frame before the end of stream
+	if err := v.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if n := <-chunks; n != 320 {
+		t.Errorf("Expected the held 320 bytes on Stop, got %d", n)
+	}
+
+	var final *TranscriptionResult
+	for result := range results {
+		if result.IsFinal {
+			final = result
+		}
+	}
+	if final == nil || final.Text != "hello there" || len(final.Words) != 2 {
+		t.Fatalf("Expected the final with its words before the channel closed, got %+v", final)
+	}
+	if final.Confidence != 0.75 || final.StartTime != 0.1 || final.Words[1].End != 0.9 {
+		t.Errorf("Unexpected timing or confidence: %+v", final)
+	}
+}
+
+func TestVoskMessage_ResultInSessionTime(t *testing.T) {
+	var msg voskMessage
+	if err := json.Unmarshal([]byte(`{"result":[{"conf":0.9,"start":1.0,"end":1.5,"word":"yes"}],"text":"yes"}`), &msg); err != nil {
+		t.Fatal(err)
+	}
+	result := msg.result(60)
+	if !result.IsFinal || result.StartTime != 61 || result.Words[0].End != 61.5 {
+		t.Errorf("Expected the word shifted by the connection's offset, got %+v", result)
+	}
+
+	if (&voskMessage{}).result(0) != nil {
+		t.Error("Expected no result for an empty partial")
+	}
+}
+
+func TestNewClient_ChoosesProvider(t *testing.T) {
+	if _, ok := NewClient(&config.Config{STTProvider: ProviderVosk, VoskChunkMs: 100}).(*VoskClient); !ok {
+		t.Error("Expected a Vosk client for the vosk provider")
+	}
+	if _, ok := NewClient(&config.Config{STTProvider: ProviderDeepgram, DeepgramModel: "nova-2"}).(*DeepgramClient); !ok {
+		t.Error("Expected a Deepgram client for the deepgram provider")
+	}
+}
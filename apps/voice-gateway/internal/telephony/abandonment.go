@@ -0,0 +1,193 @@
+package telephony
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// abandonmentPublishTimeout bounds delivery of the call.abandoned event
+const abandonmentPublishTimeout = 10 * time.Second
+
+// maxTranscriptTurns caps the turns kept for the call.abandoned event; the
+// oldest are dropped first
+const maxTranscriptTurns = 100
+
+// Abandonment stages, for the time-to-abandon histogram
+const (
+	abandonedInGreeting     = "greeting"     // Before the AI answered
+	abandonedInConversation = "conversation" // With a caller turn unanswered or the agent cut off
+)
+
+// TranscriptTurn is one speaker's consecutive final speech
+type TranscriptTurn struct {
+	Speaker string `json:"speaker"` // caller or agent
+	Text    string `json:"text"`
+	Spoken  string `json:"spoken,omitempty"` // As said on a translated call, where Text is in the firm's language
+}
+
+// callTranscript collects the final speech of a call
+type callTranscript struct {
+	mu    sync.Mutex
+	turns []TranscriptTurn
+}
+
+// add appends text, joining it to the previous turn when the speaker is the
+// same. spoken is what was said when text is a translation, or empty
+func (t *callTranscript) add(speaker, text, spoken string) {
+	text = strings.TrimSpace(text)
+	spoken = strings.TrimSpace(spoken)
+	if speaker == "" || text == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n := len(t.turns); n > 0 && t.turns[n-1].Speaker == speaker {
+		last := &t.turns[n-1]
+		if spoken != "" || last.Spoken != "" {
+			last.Spoken = orText(last.Spoken, last.Text) + " " + orText(spoken, text)
+		}
+		last.Text += " " + text
+		return
+	}
+	if len(t.turns) == maxTranscriptTurns {
+		t.turns = t.turns[1:]
+	}
+	t.turns = append(t.turns, TranscriptTurn{Speaker: speaker, Text: text, Spoken: spoken})
+}
+
+// orText returns spoken, or text when nothing was translated
+func orText(spoken, text string) string {
+	if spoken == "" {
+		return text
+	}
+	return spoken
+}
+
+// snapshot returns a copy of the turns so far
+func (t *callTranscript) snapshot() []TranscriptTurn {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TranscriptTurn(nil), t.turns...)
+}
+
+// callerHangup is the call's state when the caller hung up
+type callerHangup struct {
+	at            time.Time
+	agentSpeaking bool
+}
+
+// AbandonedCall is the payload of the call.abandoned event
+type AbandonedCall struct {
+	CallSid          string           `json:"call_sid"`
+	FirmID           string           `json:"firm_id"`
+	ConversationID   string           `json:"conversation_id,omitempty"`
+	CallerNumber     string           `json:"caller_number,omitempty"`
+	Transcript       []TranscriptTurn `json:"transcript"`
+	LastAITurn       string           `json:"last_ai_turn,omitempty"`
+	AgentSpeaking    bool             `json:"agent_speaking"` // The caller hung up while the agent was talking
+	AITurns          int              `json:"ai_turns"`
+	SecondsToAbandon float64          `json:"seconds_to_abandon"`
+	AbandonedAt      string           `json:"abandoned_at"`
+}
+
+// agentAudible reports whether the caller can still hear, or is about to
+// hear, the agent
+func (s *CallSession) agentAudible() bool {
+	return len(s.audioOut) > 0 || (s.ttsClient != nil && s.ttsClient.IsActive()) || s.playbackActive()
+}
+
+// noteCallerHangup records how the call stood when the media stream ended,
+// unless the gateway ended it
+func (s *CallSession) noteCallerHangup() {
+	if s.endedByGateway.Load() {
+		return
+	}
+	hangup := &callerHangup{at: time.Now(), agentSpeaking: s.agentAudible()}
+	s.mu.Lock()
+	s.hungUp = hangup
+	s.mu.Unlock()
+}
+
+// abandonment returns the call.abandoned payload when the caller hung up
+// mid-conversation: after the AI answered at least once, but with their own
+// turn unanswered or over the agent. Callers who hang up after the agent has
+// finished speaking ended the call normally
+func (s *CallSession) abandonment(record *cdr.Record) *AbandonedCall {
+	s.mu.RLock()
+	hangup := s.hungUp
+	s.mu.RUnlock()
+	if hangup == nil || record.Outcome != cdr.OutcomeAnsweredByAI || record.Disposition != "completed" {
+		return nil
+	}
+
+	turns := s.transcript.snapshot()
+	callerWaiting := len(turns) > 0 && turns[len(turns)-1].Speaker == live.SpeakerCaller
+	if !callerWaiting && !hangup.agentSpeaking {
+		return nil
+	}
+
+	abandoned := &AbandonedCall{
+		CallSid:          record.CallSid,
+		FirmID:           record.FirmID,
+		ConversationID:   record.ConversationID,
+		CallerNumber:     record.CallerNumber,
+		Transcript:       turns,
+		AgentSpeaking:    hangup.agentSpeaking,
+		AITurns:          record.AITurns,
+		SecondsToAbandon: hangup.at.Sub(record.StartedAt).Seconds(),
+		AbandonedAt:      hangup.at.UTC().Format(time.RFC3339),
+	}
+	for i := len(turns) - 1; i >= 0; i-- {
+		if turns[i].Speaker == live.SpeakerAgent {
+			abandoned.LastAITurn = turns[i].Text
+			break
+		}
+	}
+	return abandoned
+}
+
+// reportAbandonment records time-to-abandon for calls the caller left
+// unanswered, and publishes call.abandoned for mid-conversation hangups
+func (s *CallSession) reportAbandonment(record *cdr.Record) {
+	if record.Outcome == cdr.OutcomeAbandoned {
+		observability.RecordCallAbandoned(abandonedInGreeting, record.DurationSecs)
+		return
+	}
+	abandoned := s.abandonment(record)
+	if abandoned == nil {
+		return
+	}
+
+	observability.RecordCallAbandoned(abandonedInConversation, abandoned.SecondsToAbandon)
+	s.logger.Info().
+		Bool("agent_speaking", abandoned.AgentSpeaking).
+		Int("ai_turns", abandoned.AITurns).
+		Float64("seconds_to_abandon", abandoned.SecondsToAbandon).
+		Msg("Caller abandoned call mid-conversation")
+	s.publishAbandonment(abandoned)
+}
+
+// publishAbandonment sends the call.abandoned event to the global sink and the firm's webhook
+func (s *CallSession) publishAbandonment(abandoned *AbandonedCall) {
+	if s.services == nil || s.services.Events == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), abandonmentPublishTimeout)
+	defer cancel()
+
+	publisher := s.services.Events
+	if settings := s.firmSettings(); settings != nil && settings.Abandonment.WebhookURL != "" {
+		publisher = events.MultiPublisher{publisher, s.services.webhookPublisher(settings.Abandonment.WebhookURL, s.config.EventsWebhookSecret)}
+	}
+	event := events.NewEvent(events.TypeCallAbandoned, abandoned.CallSid, abandoned.FirmID, abandoned)
+	if err := publisher.Publish(ctx, event); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to publish call abandonment event")
+	}
+}
@@ -0,0 +1,135 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/live"
+)
+
+// newAbandonmentTestSession returns a session that has heard the given
+// turns, alternating caller and agent, and publishes to the returned channel
+func newAbandonmentTestSession(turns ...string) (*CallSession, channelPublisher) {
+	published := make(channelPublisher, 2)
+	s := newSupervisorTestSession()
+	s.services = &Services{Events: published}
+	for i, text := range turns {
+		speaker := live.SpeakerCaller
+		if i%2 == 1 {
+			speaker = live.SpeakerAgent
+		}
+		s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: speaker, Text: text, Final: true})
+	}
+	return s, published
+}
+
+func answeredRecord() *cdr.Record {
+	return &cdr.Record{
+		CallSid:     "CA1",
+		FirmID:      "acme",
+		StartedAt:   time.Now().Add(-30 * time.Second),
+		Disposition: "completed",
+		Outcome:     cdr.OutcomeAnsweredByAI,
+		AITurns:     1,
+	}
+}
+
+func TestCallTranscript_JoinsSpeakerTurns(t *testing.T) {
+	var transcript callTranscript
+	transcript.add(live.SpeakerCaller, "Hi,", "")
+	transcript.add(live.SpeakerCaller, "I need a lawyer.", "")
+	transcript.add(live.SpeakerAgent, "I can help with that.", "")
+	transcript.add(live.SpeakerAgent, " ", "")
+
+	turns := transcript.snapshot()
+	if len(turns) != 2 || turns[0].Text != "Hi, I need a lawyer." || turns[1].Speaker != live.SpeakerAgent {
+		t.Errorf("Expected a caller and an agent turn, got %+v", turns)
+	}
+
+	for i := 0; i < maxTranscriptTurns; i++ {
+		transcript.add(live.SpeakerCaller, "caller", "")
+		transcript.add(live.SpeakerAgent, "agent", "")
+	}
+	if turns := transcript.snapshot(); len(turns) != maxTranscriptTurns {
+		t.Errorf("Expected %d turns kept, got %d", maxTranscriptTurns, len(turns))
+	}
+}
+
+func TestReportAbandonment_CallerTurnUnanswered(t *testing.T) {
+	s, published := newAbandonmentTestSession("Hi, I was in a car accident.", "I'm sorry to hear that. When did it happen?", "Last Tuesday, on the")
+	s.noteCallerHangup()
+
+	s.reportAbandonment(answeredRecord())
+
+	select {
+	case event := <-published:
+		abandoned, ok := event.Data.(*AbandonedCall)
+		if event.Type != events.TypeCallAbandoned || !ok {
+			t.Fatalf("Expected a call.abandoned event, got %+v", event)
+		}
+		if len(abandoned.Transcript) != 3 || abandoned.LastAITurn != "I'm sorry to hear that. When did it happen?" {
+			t.Errorf("Expected the transcript and last AI turn, got %+v", abandoned)
+		}
+		if abandoned.SecondsToAbandon < 29 {
+			t.Errorf("Expected about 30 seconds to abandon, got %.1f", abandoned.SecondsToAbandon)
+		}
+	default:
+		t.Fatal("Expected a call.abandoned event")
+	}
+}
+
+func TestReportAbandonment_CallerCutsOffAgent(t *testing.T) {
+	s, published := newAbandonmentTestSession("Do you handle divorces?", "Yes, we do. Let me tell you about")
+	s.audioOut <- []byte{0xff}
+	s.noteCallerHangup()
+
+	s.reportAbandonment(answeredRecord())
+
+	select {
+	case event := <-published:
+		if abandoned := event.Data.(*AbandonedCall); !abandoned.AgentSpeaking {
+			t.Errorf("Expected the agent to be speaking at hangup, got %+v", abandoned)
+		}
+	default:
+		t.Fatal("Expected a call.abandoned event")
+	}
+}
+
+func TestReportAbandonment_NotAbandoned(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(s *CallSession, r *cdr.Record)
+	}{
+		{"agent answered last", func(s *CallSession, r *cdr.Record) {
+			s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerAgent, Text: "Goodbye!", Final: true})
+			s.noteCallerHangup()
+		}},
+		{"gateway ended the call", func(s *CallSession, r *cdr.Record) {
+			s.endedByGateway.Store(true)
+			s.noteCallerHangup()
+		}},
+		{"transferred", func(s *CallSession, r *cdr.Record) {
+			s.noteCallerHangup()
+			r.Disposition, r.Outcome = "transferred", cdr.OutcomeTransferred
+		}},
+		{"hung up in greeting", func(s *CallSession, r *cdr.Record) {
+			s.noteCallerHangup()
+			r.Outcome, r.AITurns = cdr.OutcomeAbandoned, 0
+		}},
+	}
+	for _, tt := range tests {
+		s, published := newAbandonmentTestSession("Can I speak to someone?")
+		record := answeredRecord()
+		tt.setup(s, record)
+
+		s.reportAbandonment(record)
+
+		select {
+		case event := <-published:
+			t.Errorf("%s: expected no event, got %+v", tt.name, event)
+		default:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package telephony
+
+import (
+	"context"
+	"time"
+)
+
+// Latency signals the admission limiter watches
+const (
+	signalOrchestrator = "orchestrator" // Turn sent to first streamed response
+	signalTTS          = "tts"          // Synthesis request to audio response
+)
+
+// admitCall claims a slot from the adaptive admission limiter for a call about
+// to reach the AI; false means the backends are saturated and the call should
+// overflow. New calls may wait briefly for capacity before being shed
+func (s *CallSession) admitCall() bool {
+	if s.services == nil || s.services.Admission == nil {
+		return true
+	}
+
+	release, err := s.services.Admission.Acquire(context.Background())
+	if err != nil {
+		stats := s.services.Admission.Stats()
+		s.logger.Warn().
+			Err(err).
+			Int("limit", stats.Limit).
+			Int("in_flight", stats.InFlight).
+			Msg("Backends saturated, shedding call")
+		return false
+	}
+	s.mu.Lock()
+	s.admissionRelease = release
+	s.mu.Unlock()
+	return true
+}
+
+// releaseAdmission returns the call's admission slot, if it holds one
+func (s *CallSession) releaseAdmission() {
+	s.mu.Lock()
+	release := s.admissionRelease
+	s.admissionRelease = nil
+	s.mu.Unlock()
+	if release != nil {
+		release()
+	}
+}
+
+// observeLatency feeds a downstream latency sample to the admission limiter
+func (s *CallSession) observeLatency(signal string, latency time.Duration) {
+	if s.services == nil {
+		return
+	}
+	s.services.Admission.Observe(signal, latency)
+}
@@ -0,0 +1,44 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/preflight"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+)
+
+func TestAdmitCall_ShedsWhenSaturated(t *testing.T) {
+	limiter := resilience.NewAdaptiveLimiter(resilience.AdaptiveLimiterConfig{InitialLimit: 1, MinLimit: 1, MaxLimit: 1})
+	first, second := newSupervisorTestSession(), newSupervisorTestSession()
+	first.services = &Services{Admission: limiter}
+	second.services = &Services{Admission: limiter}
+
+	if !first.admitCall() {
+		t.Fatal("Expected the first call to be admitted")
+	}
+	if second.admitCall() {
+		t.Error("Expected the second call to be shed at the limit")
+	}
+
+	// Ending the admitted call frees its slot, once
+	first.releaseAdmission()
+	first.releaseAdmission()
+	if !second.admitCall() {
+		t.Error("Expected the freed slot to admit the next call")
+	}
+	if stats := limiter.Stats(); stats.InFlight != 1 {
+		t.Errorf("Expected 1 call in flight, got %d", stats.InFlight)
+	}
+}
+
+func TestHandleTwilioWS_RefusesCallsWhilePreflightFails(t *testing.T) {
+	handler := HandleTwilioWS(&config.Config{}, &Services{Preflight: &preflight.Gate{}})
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/streams/twilio", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while the preflight is failing, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,71 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// MISSING_FIRM_POLICY values, for calls whose stream carries no firm_id
+const (
+	missingFirmReject  = "reject"       // Apologize and hang up
+	missingFirmDefault = "default_firm" // Attribute the call to STREAM_DEFAULT_FIRM_ID
+	missingFirmAllow   = "allow"        // Proceed unattributed under the default settings
+)
+
+// dispositionUnattributed marks a call rejected for carrying no firm_id
+const dispositionUnattributed = "unattributed"
+
+// attributeCall applies MISSING_FIRM_POLICY to a call whose stream named no
+// firm, returning the firm to use; false means the call is being rejected
+func (s *CallSession) attributeCall() (string, bool) {
+	switch s.config.MissingFirmAction() {
+	case missingFirmDefault:
+		firmID := s.config.StreamDefaultFirmID
+		observability.RecordUnattributedCall("defaulted")
+		s.logger.Warn().Str("firm_id", firmID).Msg("No firm_id stream parameter, using STREAM_DEFAULT_FIRM_ID")
+		s.mu.Lock()
+		s.firmID = firmID
+		s.mu.Unlock()
+		s.cdr.Update(func(r *cdr.Record) { r.FirmID = firmID })
+		return firmID, true
+
+	case missingFirmReject:
+		observability.RecordUnattributedCall("rejected")
+		s.logger.Error().
+			Str("call_sid", s.GetCallSid()).
+			Msg("No firm_id stream parameter, rejecting call; set it in the TwiML <Stream>")
+		s.access.Reject("missing_firm_id", "")
+		s.mu.Lock()
+		s.rejecting = true
+		s.mu.Unlock()
+		s.goSafe("reject_unattributed", func() {
+			s.rejectWithMessage(dispositionUnattributed, s.localize(i18n.MissingFirm, s.config.MissingFirmMessage))
+		})
+		return "", false
+	}
+
+	observability.RecordUnattributedCall("allowed")
+	s.logger.Warn().Msg("No firm_id stream parameter, continuing unattributed")
+	return "", true
+}
+
+// rejectWithMessage tells the caller why the call cannot proceed, then hangs up
+func (s *CallSession) rejectWithMessage(disposition, message string) {
+	s.cdr.Update(func(r *cdr.Record) { r.Disposition = disposition })
+	if message != "" {
+		if err := s.speak(message); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to play rejection message")
+		} else {
+			s.waitForPlayback(hangupDrainTimeout)
+		}
+	}
+	s.hangup(disposition)
+}
+
+// isRejecting reports whether the call is playing its rejection message
+func (s *CallSession) isRejecting() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rejecting
+}
@@ -0,0 +1,132 @@
+package telephony
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+)
+
+const (
+	// budgetMessageTimeout bounds how long the over-budget message may play
+	budgetMessageTimeout = 10 * time.Second
+
+	// budgetAlertPublishTimeout bounds delivering a budget.alert event
+	budgetAlertPublishTimeout = 5 * time.Second
+
+	// usageStoreTimeout bounds reading or adding to a firm's monthly usage
+	usageStoreTimeout = 2 * time.Second
+)
+
+// BudgetAlert is the payload of the budget.alert event
+type BudgetAlert struct {
+	FirmID           string  `json:"firm_id"`
+	Month            string  `json:"month"` // "2006-01", UTC
+	ThresholdPercent int     `json:"threshold_percent"`
+	SpendUSD         float64 `json:"spend_usd"`
+	MonthlyCapUSD    float64 `json:"monthly_cap_usd"`
+}
+
+// usageRates prices usage at the configured rates
+func (s *CallSession) usageRates() usage.Rates {
+	return usage.Rates{
+		STTPerMinute:       s.config.UsageRateSTTPerMinute,
+		TTSPer1KCharacters: s.config.UsageRateTTSPer1KCharacters,
+		TokensPer1K:        s.config.UsageRateTokensPer1K,
+		TelephonyPerMinute: s.config.UsageRateTelephonyPerMinute,
+	}
+}
+
+// overBudget returns whether the firm has reached its monthly spend cap; the
+// call proceeds when the month's usage cannot be read
+func (s *CallSession) overBudget(firmID string, settings *firm.Settings) bool {
+	monthlyCap := settings.Budget.MonthlyCapUSD
+	if monthlyCap <= 0 || s.services == nil || s.services.Usage == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), usageStoreTimeout)
+	defer cancel()
+	month, err := s.services.Usage.Month(ctx, firmID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to read the firm's monthly usage, skipping the budget check")
+		return false
+	}
+	spend := s.usageRates().Cost(month)
+	if spend < monthlyCap {
+		return false
+	}
+
+	s.logger.Warn().
+		Float64("spend_usd", spend).
+		Float64("monthly_cap_usd", monthlyCap).
+		Msg("Firm over monthly budget, diverting call")
+	observability.RecordBudgetDiverted(firmID)
+	return true
+}
+
+// playBudgetMessage tells an over-budget caller what happens next and waits
+// for it to finish, so a transfer does not cut it off
+func (s *CallSession) playBudgetMessage(settings *firm.Settings) {
+	if settings.Budget.Message == "" {
+		return
+	}
+	if err := s.speak(s.localize(i18n.BudgetMessage, settings.Budget.Message)); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to play budget message")
+		return
+	}
+	s.waitForPlayback(budgetMessageTimeout)
+}
+
+// checkBudgetAlerts raises a budget.alert for each threshold a finished
+// call's usage carried the firm past; month includes the call
+func (s *CallSession) checkBudgetAlerts(firmID string, call, month cdr.Usage) {
+	if s.services == nil || s.services.Firms == nil {
+		return
+	}
+	settings := s.services.Firms.Get(firmID)
+	rates := s.usageRates()
+	after := rates.Cost(month)
+	before := after - rates.Cost(call)
+
+	for _, threshold := range usage.CrossedThresholds(before, after, settings.Budget.MonthlyCapUSD) {
+		alert := &BudgetAlert{
+			FirmID:           firmID,
+			Month:            time.Now().UTC().Format("2006-01"),
+			ThresholdPercent: int(math.Round(threshold * 100)),
+			SpendUSD:         after,
+			MonthlyCapUSD:    settings.Budget.MonthlyCapUSD,
+		}
+		s.logger.Warn().
+			Int("threshold_percent", alert.ThresholdPercent).
+			Float64("spend_usd", alert.SpendUSD).
+			Float64("monthly_cap_usd", alert.MonthlyCapUSD).
+			Msg("Firm budget threshold reached")
+		observability.RecordBudgetAlert(firmID, alert.ThresholdPercent)
+		webhookURL := settings.Budget.AlertWebhookURL
+		s.goSafe("budget_alert", func() { s.publishBudgetAlert(webhookURL, alert) })
+	}
+}
+
+// publishBudgetAlert sends the budget.alert event to the global sink and the firm's webhook
+func (s *CallSession) publishBudgetAlert(webhookURL string, alert *BudgetAlert) {
+	if s.services == nil || s.services.Events == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), budgetAlertPublishTimeout)
+	defer cancel()
+
+	publisher := s.services.Events
+	if webhookURL != "" {
+		publisher = events.MultiPublisher{publisher, s.services.webhookPublisher(webhookURL, s.config.EventsWebhookSecret)}
+	}
+	event := events.NewEvent(events.TypeBudgetAlert, s.GetCallSid(), alert.FirmID, alert)
+	if err := publisher.Publish(ctx, event); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to publish budget alert")
+	}
+}
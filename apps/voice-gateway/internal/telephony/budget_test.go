@@ -0,0 +1,78 @@
+package telephony
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+)
+
+// channelPublisher hands published events to the test
+type channelPublisher chan *events.Event
+
+func (c channelPublisher) Publish(ctx context.Context, event *events.Event) error {
+	c <- event
+	return nil
+}
+
+func TestCallSession_BudgetGuard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "firms.json")
+	if err := os.WriteFile(path, []byte(`{"firms": {"firm-1": {"budget": {"monthly_cap_usd": 1}}}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write firm config: %v", err)
+	}
+	firms, err := firm.LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() failed: %v", err)
+	}
+
+	published := make(channelPublisher, 4)
+	s := newSupervisorTestSession()
+	s.config = &config.Config{UsageRateTelephonyPerMinute: 0.1}
+	s.services = &Services{Firms: firms, Usage: usage.NewLedger(), Events: published}
+	settings := firms.Get("firm-1")
+
+	finishCall := func(minutes float64) {
+		record := cdr.Record{FirmID: "firm-1", DurationSecs: minutes * 60}
+		s.meterUsage(&record)
+	}
+
+	finishCall(7) // $0.70
+	if s.overBudget("firm-1", settings) {
+		t.Error("Expected firm under its cap")
+	}
+	select {
+	case event := <-published:
+		t.Fatalf("Expected no alert below 80%%, got %+v", event)
+	default:
+	}
+
+	finishCall(4) // $1.10: past 80% and 100% at once
+	thresholds := make(map[int]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-published:
+			if alert, ok := event.Data.(*BudgetAlert); ok && event.Type == events.TypeBudgetAlert {
+				thresholds[alert.ThresholdPercent] = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected two budget alerts")
+		}
+	}
+	if !thresholds[80] || !thresholds[100] {
+		t.Errorf("Expected budget alerts at 80%% and 100%%, got %v", thresholds)
+	}
+
+	if !s.overBudget("firm-1", settings) {
+		t.Error("Expected firm over its cap")
+	}
+	if s.overBudget("firm-2", firms.Get("firm-2")) {
+		t.Error("Expected a firm without a cap never to be over budget")
+	}
+}
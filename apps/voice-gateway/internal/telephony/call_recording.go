@@ -0,0 +1,156 @@
+package telephony
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/recording"
+)
+
+// recordingUploadTimeout bounds storing the call recording at call end
+const recordingUploadTimeout = 30 * time.Second
+
+// callRecording holds the stereo recorder and the media stream clock used to
+// place agent audio, which Twilio does not timestamp
+type callRecording struct {
+	recorder *recording.StereoRecorder
+
+	// Latest inbound media timestamp and when it arrived
+	lastMediaTS time.Duration
+	lastMediaAt time.Time
+
+	// held recordings await the caller's consent and are never stored
+	held bool
+}
+
+// startCallRecording begins a stereo recording of the call, if enabled
+func (s *CallSession) startCallRecording() {
+	if !s.config.CallRecordingEnabled {
+		return
+	}
+	maxDuration := time.Duration(s.config.CallRecordingMaxMinutes) * time.Minute
+
+	s.recordingMu.Lock()
+	s.recording = &callRecording{
+		recorder:    recording.NewStereoRecorder(maxDuration),
+		lastMediaAt: time.Now(),
+	}
+	s.recordingMu.Unlock()
+}
+
+// recordCallerAudio places inbound audio at its Twilio media timestamp
+// (milliseconds since the stream started)
+func (s *CallSession) recordCallerAudio(timestamp []byte, chunk []byte) {
+	s.recordingMu.Lock()
+	defer s.recordingMu.Unlock()
+	if s.recording == nil {
+		return
+	}
+
+	at := s.recording.lastMediaTS
+	if ms, err := strconv.ParseInt(string(timestamp), 10, 64); err == nil {
+		at = time.Duration(ms) * time.Millisecond
+	}
+	s.recording.lastMediaTS = at
+	s.recording.lastMediaAt = time.Now()
+	s.recording.recorder.WriteCaller(at, chunk)
+}
+
+// recordAgentAudio adds outbound audio at the current stream position
+func (s *CallSession) recordAgentAudio(chunk []byte) {
+	s.recordingMu.Lock()
+	defer s.recordingMu.Unlock()
+	if s.recording == nil {
+		return
+	}
+
+	at := s.recording.lastMediaTS + time.Since(s.recording.lastMediaAt)
+	s.recording.recorder.WriteAgent(at, chunk)
+}
+
+// holdCallRecording keeps recording but withholds the result until
+// releaseCallRecording, so nothing is stored without the caller's consent
+func (s *CallSession) holdCallRecording() {
+	s.recordingMu.Lock()
+	defer s.recordingMu.Unlock()
+	if s.recording != nil {
+		s.recording.held = true
+	}
+}
+
+// releaseCallRecording resolves a held recording: kept when the caller
+// consented, otherwise discarded and recording stops
+func (s *CallSession) releaseCallRecording(keep bool) {
+	s.recordingMu.Lock()
+	defer s.recordingMu.Unlock()
+	if s.recording == nil {
+		return
+	}
+	if keep {
+		s.recording.held = false
+		return
+	}
+	s.recording.recorder.Stop()
+	s.recording = nil
+	s.logger.Info().Msg("Call recording discarded without consent")
+}
+
+// storeCallRecording uploads the recording and returns its location
+// Returns "" when the call was not recorded, consent was never given, or the upload failed
+func (s *CallSession) storeCallRecording(firmID, callSid string) string {
+	s.recordingMu.Lock()
+	rec := s.recording
+	held := rec != nil && rec.held
+	s.recordingMu.Unlock()
+	if rec == nil || held || s.services == nil || s.services.Storage == nil || callSid == "" {
+		return ""
+	}
+	rec.recorder.Stop()
+
+	if firmID == "" {
+		firmID = "unattributed"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recordingUploadTimeout)
+	defer cancel()
+
+	key := fmt.Sprintf("recordings/%s/%s.wav", firmID, callSid)
+	location, err := s.services.Storage.Put(ctx, key, bytes.NewReader(rec.recorder.WAV()), "audio/wav")
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to store call recording")
+		s.services.RecordingHealth.Failure(err)
+		if s.metrics != nil {
+			s.metrics.RecordError("recording_store_error", "storage")
+		}
+		return ""
+	}
+
+	s.services.RecordingHealth.Success()
+	s.logger.Info().
+		Str("recording_url", location).
+		Float64("duration_seconds", rec.recorder.Duration().Seconds()).
+		Msg("Call recording stored")
+	return location
+}
+
+// retention returns when the firm's policy requires a recording of
+// recordingKind (firm.DataRecordings or firm.DataVoicemail) made at from, and
+// its transcripts, to be deleted; nil when the firm keeps them
+func retention(settings *firm.Settings, recordingKind string, from time.Time) *cdr.Retention {
+	if settings == nil {
+		return nil
+	}
+	r := cdr.Retention{
+		RecordingUntil:  settings.Retention.Until(recordingKind, from),
+		TranscriptUntil: settings.Retention.Until(firm.DataTranscripts, from),
+	}
+	if r.RecordingUntil == nil && r.TranscriptUntil == nil {
+		return nil
+	}
+	return &r
+}
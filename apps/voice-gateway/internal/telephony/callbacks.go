@@ -0,0 +1,92 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+// callbackTimeLayouts are accepted for schedule_callback times without a
+// UTC offset; they are read in the called party's local time
+var callbackTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"}
+
+// scheduleCallbackTool queues a call back to the caller (or another number)
+// at the time they asked for
+func scheduleCallbackTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Time   string `json:"time"`   // RFC 3339, or local "YYYY-MM-DDTHH:MM"
+		Number string `json:"number"` // Defaults to the caller's number
+		Notes  string `json:"notes"`  // What the callback is about
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if s.services.Callbacks == nil {
+		return nil, fmt.Errorf("callbacks not configured")
+	}
+
+	s.mu.RLock()
+	firmID := s.firmID
+	callSid := s.callSid
+	from := s.calledNumber
+	number := p.Number
+	if number == "" {
+		number = s.callerNumber
+	}
+	s.mu.RUnlock()
+	if number == "" {
+		return nil, fmt.Errorf("no number given and the caller's number is unknown")
+	}
+
+	settings := s.services.Firms.Get(firmID)
+	loc, known := settings.CalleeLocation(number)
+	if !known {
+		return nil, firm.ErrUnknownTimezone
+	}
+	at, err := parseCallbackTime(p.Time, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.services.Callbacks.Schedule(ctx, callback.Callback{
+		FirmID:  firmID,
+		Number:  number,
+		From:    from,
+		At:      at,
+		Notes:   p.Notes,
+		CallSid: callSid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Callbacks = append(r.Callbacks, scheduled.ID)
+	})
+	return map[string]string{
+		"status":      "scheduled",
+		"callback_id": scheduled.ID,
+		"at":          scheduled.At.In(loc).Format(time.RFC3339),
+	}, nil
+}
+
+// parseCallbackTime reads an RFC 3339 time, or a local time in loc
+func parseCallbackTime(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time is required")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range callbackTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: expected RFC 3339 or YYYY-MM-DDTHH:MM", value)
+}
@@ -0,0 +1,94 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/campaign"
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/rs/zerolog"
+)
+
+// idleDialer never places calls; callbacks in these tests are only scheduled
+type idleDialer struct{}
+
+func (idleDialer) Start(req campaign.Request) (*campaign.Campaign, error) {
+	return nil, context.Canceled
+}
+
+func (idleDialer) Wait(ctx context.Context, id string) (*campaign.Campaign, error) {
+	return nil, context.Canceled
+}
+
+func TestParseCallbackTime(t *testing.T) {
+	chicago, _ := time.LoadLocation("America/Chicago")
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2030-03-12T15:00:00-05:00", time.Date(2030, 3, 12, 20, 0, 0, 0, time.UTC)},
+		{"2030-03-12T15:00", time.Date(2030, 3, 12, 15, 0, 0, 0, chicago)},
+		{"2030-03-12 15:30", time.Date(2030, 3, 12, 15, 30, 0, 0, chicago)},
+	}
+	for _, tt := range tests {
+		got, err := parseCallbackTime(tt.value, chicago)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseCallbackTime(%q) = %v (%v), want %v", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := parseCallbackTime("3pm tomorrow", chicago); err == nil {
+		t.Error("Expected error for free-form times")
+	}
+}
+
+func TestScheduleCallbackTool(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"timezone": "America/Chicago", "outbound": {"default_timezone": "America/Chicago"}}}}`)
+	s.callSid = "CA123"
+	s.callerNumber = "+15551234567"
+	s.calledNumber = "+15550001111"
+
+	if _, err := scheduleCallbackTool(context.Background(), s, json.RawMessage(`{"time": "2030-03-12T15:00"}`)); err == nil ||
+		!strings.Contains(err.Error(), "not configured") {
+		t.Errorf("Expected an error without a scheduler, got %v", err)
+	}
+
+	store := callback.NewMemoryStore()
+	s.services.Callbacks = callback.NewScheduler(store, idleDialer{}, s.services.Firms, nil, callback.Config{MaxAttempts: 1}, zerolog.Nop())
+
+	// Free-form times are refused so the assistant asks for a specific one
+	if _, err := scheduleCallbackTool(context.Background(), s, json.RawMessage(`{"time": "3pm"}`)); err == nil {
+		t.Error("Expected error for a free-form time")
+	}
+
+	// "Call me back tomorrow at 3pm", in the caller's (here the firm's) time zone
+	chicago, _ := time.LoadLocation("America/Chicago")
+	tomorrow := time.Now().In(chicago).AddDate(0, 0, 1)
+	at := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 15, 0, 0, 0, chicago)
+	result, err := scheduleCallbackTool(context.Background(), s,
+		json.RawMessage(`{"time": "`+at.Format("2006-01-02T15:04")+`", "notes": "Discuss the settlement offer"}`))
+	if err != nil {
+		t.Fatalf("schedule_callback failed: %v", err)
+	}
+	out := result.(map[string]string)
+	if out["status"] != "scheduled" || out["at"] != at.Format(time.RFC3339) {
+		t.Errorf("Unexpected result: %v", out)
+	}
+
+	cb, err := store.Get(context.Background(), out["callback_id"])
+	if err != nil {
+		t.Fatalf("Expected the callback stored: %v", err)
+	}
+	if cb.Number != "+15551234567" || cb.From != "+15550001111" || cb.CallSid != "CA123" || cb.Notes != "Discuss the settlement offer" {
+		t.Errorf("Unexpected callback: %+v", cb)
+	}
+
+	var record cdr.Record
+	s.cdr.Update(func(r *cdr.Record) { record = *r })
+	if len(record.Callbacks) != 1 || record.Callbacks[0] != cb.ID {
+		t.Errorf("Expected the callback on the CDR, got %v", record.Callbacks)
+	}
+}
@@ -0,0 +1,56 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// clarifier decides when a final transcript is too uncertain to send to the orchestrator
+type clarifier struct {
+	minConfidence  float64
+	maxConsecutive int
+	prompt         string
+
+	consecutive int // Repeat requests since the last transcript passed through
+}
+
+// newClarifier creates a clarifier from the firm's settings
+func newClarifier(settings firm.ClarificationSettings) *clarifier {
+	return &clarifier{
+		minConfidence:  settings.MinConfidence,
+		maxConsecutive: settings.MaxConsecutive,
+		prompt:         settings.Prompt,
+	}
+}
+
+// shouldClarify returns whether the caller should be asked to repeat a
+// transcript with the given confidence. A confidence of 0 means the STT
+// provider did not report one, and is passed through. After maxConsecutive
+// repeat requests the transcript is passed on so the caller is never stuck
+func (c *clarifier) shouldClarify(confidence float64) bool {
+	if c.minConfidence <= 0 || c.prompt == "" || confidence <= 0 || confidence >= c.minConfidence {
+		c.consecutive = 0
+		return false
+	}
+	if c.maxConsecutive > 0 && c.consecutive >= c.maxConsecutive {
+		observability.RecordClarification("passed_through")
+		c.consecutive = 0
+		return false
+	}
+	c.consecutive++
+	return true
+}
+
+// requestClarification asks the caller to repeat a low-confidence transcript
+func (s *CallSession) requestClarification(text string, confidence float64, prompt string) {
+	s.logger.Info().
+		Float64("confidence", confidence).
+		Str("transcript", text).
+		Msg("Low-confidence transcript, asking caller to repeat")
+	observability.RecordClarification("prompted")
+
+	if err := s.speak(s.localize(i18n.Clarification, prompt)); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to play clarification prompt")
+	}
+}
@@ -0,0 +1,40 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+func TestClarifier_Threshold(t *testing.T) {
+	c := newClarifier(firm.ClarificationSettings{MinConfidence: 0.5, Prompt: "Pardon?", MaxConsecutive: 2})
+
+	if c.shouldClarify(0.9) {
+		t.Error("Expected confident transcript to pass through")
+	}
+	if c.shouldClarify(0) {
+		t.Error("Expected transcript without confidence to pass through")
+	}
+	if !c.shouldClarify(0.3) {
+		t.Error("Expected low-confidence transcript to trigger clarification")
+	}
+}
+
+func TestClarifier_MaxConsecutive(t *testing.T) {
+	c := newClarifier(firm.ClarificationSettings{MinConfidence: 0.5, Prompt: "Pardon?", MaxConsecutive: 2})
+
+	got := []bool{c.shouldClarify(0.2), c.shouldClarify(0.2), c.shouldClarify(0.2), c.shouldClarify(0.2)}
+	want := []bool{true, true, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected attempt %d to return %v, got %v", i+1, want[i], got[i])
+		}
+	}
+}
+
+func TestClarifier_Disabled(t *testing.T) {
+	c := newClarifier(firm.ClarificationSettings{Prompt: "Pardon?"})
+	if c.shouldClarify(0.1) {
+		t.Error("Expected clarification to be disabled without a threshold")
+	}
+}
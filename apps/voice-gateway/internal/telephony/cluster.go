@@ -0,0 +1,81 @@
+package telephony
+
+import (
+	"context"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// clusterTimeout bounds registry calls at call start and end
+const clusterTimeout = 2 * time.Second
+
+// registerCall lists the call in the cluster registry and claims a slot under
+// the firm's concurrency limit; calls over the limit are marked for overflow
+// Registry failures admit the call rather than turn callers away
+func (s *CallSession) registerCall(settings *firm.Settings) {
+	if s.services == nil || s.services.Cluster == nil {
+		return
+	}
+	call := s.clusterCall()
+	if call.CallSid == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+	admitted, err := s.services.Cluster.Register(ctx, call, settings.Routing.MaxConcurrentCalls)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Call registry unavailable, admitting call")
+		return
+	}
+	if admitted {
+		return
+	}
+
+	s.logger.Warn().
+		Int("max_concurrent_calls", settings.Routing.MaxConcurrentCalls).
+		Msg("Firm at concurrent call limit")
+	observability.RecordOverflow()
+	s.mu.Lock()
+	s.overCapacity = true
+	s.mu.Unlock()
+}
+
+// unregisterCall releases the call's registry entry and firm slot
+func (s *CallSession) unregisterCall() {
+	if s.services == nil || s.services.Cluster == nil {
+		return
+	}
+	call := s.clusterCall()
+	if call.CallSid == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+	if err := s.services.Cluster.Unregister(ctx, call); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to unregister call")
+	}
+}
+
+// isOverCapacity returns whether the firm was at its limit when the call arrived
+func (s *CallSession) isOverCapacity() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.overCapacity
+}
+
+// clusterCall describes the call for the registry
+func (s *CallSession) clusterCall() cluster.Call {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cluster.Call{
+		CallSid:      s.callSid,
+		FirmID:       s.firmID,
+		CallerNumber: s.callerNumber,
+		StartedAt:    s.cdr.Snapshot().StartedAt,
+	}
+}
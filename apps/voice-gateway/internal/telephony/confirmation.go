@@ -0,0 +1,210 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+const (
+	// confirmationPlaybackTimeout bounds waiting for a confirmation prompt to play
+	confirmationPlaybackTimeout = 60 * time.Second
+
+	// confirmationMaxTimeout caps the answer timeout the orchestrator may ask for
+	confirmationMaxTimeout = 30 * time.Second
+
+	// confirmationMaxWords is the longest reply taken as a yes or no; longer
+	// ones ("yes, but move it to Thursday") are unclear and asked again
+	confirmationMaxWords = 6
+)
+
+// Confirmation outcomes returned to the orchestrator
+const (
+	ConfirmationConfirmed  = "confirmed"
+	ConfirmationRejected   = "rejected"
+	ConfirmationUnclear    = "unclear"     // The caller answered, but not yes or no
+	ConfirmationNoResponse = "no_response" // Silence until the timeout
+)
+
+// confirmationAnswer is the caller's reply to a confirmation prompt
+type confirmationAnswer struct {
+	status     string
+	method     string // speech or dtmf
+	transcript string // What the caller said, for speech replies
+}
+
+// confirmationState tracks a pending confirmation question
+type confirmationState struct {
+	answers chan confirmationAnswer
+}
+
+// confirmTool reads details back to the caller and collects yes/no by voice
+// or keypad (1 yes, 2 no), so simple confirmations skip an LLM round trip
+func confirmTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Details        string `json:"details"`
+		Prompt         string `json:"prompt"` // Replaces the firm's prompt entirely
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	return s.confirm(ctx, p.Details, p.Prompt, p.TimeoutSeconds)
+}
+
+// confirm asks the caller to confirm details, in the firm's prompt unless
+// prompt replaces it, and returns the confirm tool's result
+func (s *CallSession) confirm(ctx context.Context, details, prompt string, timeoutSeconds int) (map[string]interface{}, error) {
+	c := firm.DefaultSettings().Confirmation
+	if settings := s.firmSettings(); settings != nil {
+		c = settings.Confirmation
+	}
+	if prompt == "" {
+		if details == "" {
+			return nil, fmt.Errorf("details or prompt is required")
+		}
+		prompt = strings.ReplaceAll(s.localize(i18n.ConfirmationPrompt, c.Prompt), "{details}", details)
+	}
+	repeat := s.localize(i18n.ConfirmationRepeat, c.RepeatPrompt)
+	if repeat == "" {
+		repeat = prompt
+	}
+	timeout := confirmationTimeout(c, timeoutSeconds)
+
+	state := &confirmationState{answers: make(chan confirmationAnswer, 1)}
+	s.mu.Lock()
+	s.confirmation = state
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.confirmation = nil
+		s.mu.Unlock()
+	}()
+
+	// Silence and unclear replies are asked again; yes or no ends the question
+	var answer confirmationAnswer
+	attempt := 1
+	for ; ; attempt++ {
+		text := prompt
+		if attempt > 1 {
+			text = repeat
+		}
+		var err error
+		if answer, err = s.awaitConfirmation(ctx, state, text, timeout); err != nil {
+			return nil, err
+		}
+		if answer.status == ConfirmationConfirmed || answer.status == ConfirmationRejected || attempt >= c.MaxAttempts {
+			break
+		}
+	}
+
+	observability.RecordToolConfirmation(answer.status, answer.method)
+	s.logger.Info().
+		Str("status", answer.status).
+		Str("method", answer.method).
+		Int("attempts", attempt).
+		Msg("Tool confirmation resolved")
+
+	result := map[string]interface{}{
+		"confirmed": answer.status == ConfirmationConfirmed,
+		"status":    answer.status,
+		"attempts":  attempt,
+	}
+	if answer.method != "" {
+		result["method"] = answer.method
+	}
+	if answer.transcript != "" {
+		result["transcript"] = answer.transcript
+	}
+	return result, nil
+}
+
+// confirmationTimeout returns how long the caller has to answer: the
+// orchestrator's timeout_seconds up to confirmationMaxTimeout, else the firm's
+func confirmationTimeout(c firm.ConfirmationSettings, timeoutSeconds int) time.Duration {
+	if timeoutSeconds > 0 {
+		return min(time.Duration(timeoutSeconds)*time.Second, confirmationMaxTimeout)
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// awaitConfirmation speaks prompt and waits for an answer; the timeout starts
+// once the prompt has played, though the caller may answer over it
+func (s *CallSession) awaitConfirmation(ctx context.Context, state *confirmationState, prompt string, timeout time.Duration) (confirmationAnswer, error) {
+	if err := s.speak(prompt); err != nil {
+		return confirmationAnswer{}, fmt.Errorf("failed to play confirmation prompt: %w", err)
+	}
+
+	waitDone := make(chan struct{})
+	s.goSafe("confirmation_wait", func() {
+		s.waitForPlayback(confirmationPlaybackTimeout)
+		close(waitDone)
+	})
+	select {
+	case answer := <-state.answers:
+		return answer, nil
+	case <-waitDone:
+	case <-ctx.Done():
+		return confirmationAnswer{}, ctx.Err()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case answer := <-state.answers:
+		return answer, nil
+	case <-timer.C:
+		return confirmationAnswer{status: ConfirmationNoResponse}, nil
+	case <-ctx.Done():
+		return confirmationAnswer{}, ctx.Err()
+	}
+}
+
+// pendingConfirmation returns the open confirmation question, or nil when none is asked
+func (s *CallSession) pendingConfirmation() *confirmationState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.confirmation
+}
+
+// answerSpeech resolves the confirmation from a final transcript; only a
+// short, plain yes or no counts, and long or mixed replies ("yes, no, wait")
+// are unclear so the caller is asked again
+func (c *confirmationState) answerSpeech(text string) {
+	no, yes := isNegative(text), hasAffirmative(text)
+	if len(strings.Fields(padWords(text))) > confirmationMaxWords {
+		no, yes = false, false
+	}
+	switch {
+	case no && !yes:
+		c.answer(confirmationAnswer{status: ConfirmationRejected, method: "speech", transcript: text})
+	case yes && !no:
+		c.answer(confirmationAnswer{status: ConfirmationConfirmed, method: "speech", transcript: text})
+	default:
+		c.answer(confirmationAnswer{status: ConfirmationUnclear, method: "speech", transcript: text})
+	}
+}
+
+// answerDigit resolves the confirmation from a keypress; other keys are ignored
+func (c *confirmationState) answerDigit(digit string) {
+	switch digit {
+	case "1":
+		c.answer(confirmationAnswer{status: ConfirmationConfirmed, method: "dtmf"})
+	case "2":
+		c.answer(confirmationAnswer{status: ConfirmationRejected, method: "dtmf"})
+	}
+}
+
+// answer delivers the first answer; later ones are dropped
+func (c *confirmationState) answer(a confirmationAnswer) {
+	select {
+	case c.answers <- a:
+	default:
+	}
+}
@@ -0,0 +1,143 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+// runConfirm runs the confirm tool, feeding answer to each question it asks
+func runConfirm(t *testing.T, s *CallSession, params string, answer func(attempt int, c *confirmationState)) (map[string]interface{}, []string) {
+	t.Helper()
+	return runConfirmingTool(t, s, confirmTool, params, answer)
+}
+
+// runConfirmingTool runs a tool that ends in a confirmation question,
+// feeding answer to each question it asks
+func runConfirmingTool(t *testing.T, s *CallSession, tool toolHandler, params string, answer func(attempt int, c *confirmationState)) (map[string]interface{}, []string) {
+	t.Helper()
+	voice := &phraseTTS{}
+	s.ttsClient = voice
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := tool(context.Background(), s, json.RawMessage(params))
+		done <- outcome{result, err}
+	}()
+
+	asked := 0
+	for {
+		select {
+		case o := <-done:
+			if o.err != nil {
+				t.Fatalf("Tool failed: %v", o.err)
+			}
+			return o.result.(map[string]interface{}), voice.spoken()
+		case <-time.After(5 * time.Millisecond):
+		}
+		if c := s.pendingConfirmation(); c != nil && len(voice.spoken()) > asked {
+			asked++
+			if answer != nil {
+				answer(asked, c)
+			}
+		}
+	}
+}
+
+func TestConfirmTool_KeypadYes(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+
+	result, spoken := runConfirm(t, s, `{"details": "a consultation on Tuesday at 2 PM"}`, func(_ int, c *confirmationState) {
+		c.answerDigit("1")
+	})
+
+	if result["confirmed"] != true || result["method"] != "dtmf" {
+		t.Errorf("Expected a keypad confirmation, got %v", result)
+	}
+	if len(spoken) != 1 || !strings.Contains(spoken[0], "To confirm: a consultation on Tuesday at 2 PM.") {
+		t.Errorf("Expected the details read back, got %v", spoken)
+	}
+	if s.pendingConfirmation() != nil {
+		t.Error("Expected the question to be closed")
+	}
+}
+
+func TestConfirmTool_AsksAgainAfterUnclearReply(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+
+	result, spoken := runConfirm(t, s, `{"details": "Tuesday at 2 PM"}`, func(attempt int, c *confirmationState) {
+		if attempt == 1 {
+			c.answerSpeech("what time was that")
+			return
+		}
+		c.answerSpeech("No, make it Wednesday")
+	})
+
+	if result["status"] != ConfirmationRejected || result["attempts"] != 2 || result["transcript"] != "No, make it Wednesday" {
+		t.Errorf("Expected a spoken rejection on the second attempt, got %v", result)
+	}
+	if len(spoken) != 2 || !strings.HasPrefix(spoken[1], "Sorry, is that correct?") {
+		t.Errorf("Expected the repeat prompt, got %v", spoken)
+	}
+}
+
+func TestConfirmTool_NoResponse(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"confirmation": {"max_attempts": 1}}}}`)
+
+	result, _ := runConfirm(t, s, `{"prompt": "Shall I book it?", "timeout_seconds": 1}`, nil)
+
+	if result["confirmed"] != false || result["status"] != ConfirmationNoResponse {
+		t.Errorf("Expected no response, got %v", result)
+	}
+}
+
+func TestConfirmTool_RequiresDetails(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+	if _, err := confirmTool(context.Background(), s, json.RawMessage(`{}`)); err == nil {
+		t.Error("Expected error without details or prompt")
+	}
+}
+
+func TestConfirmationState_AnswerSpeech(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Yes", ConfirmationConfirmed},
+		{"Yeah, that's right", ConfirmationConfirmed},
+		{"No, make it Wednesday", ConfirmationRejected},
+		{"Nope", ConfirmationRejected},
+		{"Yes. No, wait", ConfirmationUnclear},
+		{"I'm not sure", ConfirmationUnclear},
+		{"Yes but could we move it to Thursday afternoon instead", ConfirmationUnclear},
+		{"What time was that", ConfirmationUnclear},
+	}
+	for _, tt := range tests {
+		c := &confirmationState{answers: make(chan confirmationAnswer, 1)}
+		c.answerSpeech(tt.text)
+		if got := <-c.answers; got.status != tt.want {
+			t.Errorf("answerSpeech(%q) = %s, want %s", tt.text, got.status, tt.want)
+		}
+	}
+}
+
+func TestConfirmationTimeout(t *testing.T) {
+	c := firm.DefaultSettings().Confirmation
+	if got := confirmationTimeout(c, 0); got != time.Duration(c.TimeoutSeconds)*time.Second {
+		t.Errorf("Expected the firm's timeout, got %v", got)
+	}
+	if got := confirmationTimeout(c, 12); got != 12*time.Second {
+		t.Errorf("Expected the requested timeout, got %v", got)
+	}
+	if got := confirmationTimeout(c, 3600); got != confirmationMaxTimeout {
+		t.Errorf("Expected the timeout capped at %v, got %v", confirmationMaxTimeout, got)
+	}
+}
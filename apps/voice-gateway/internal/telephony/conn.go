@@ -0,0 +1,30 @@
+package telephony
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// Conn is the connection a call session speaks Twilio's media stream
+// protocol over: the Twilio WebSocket itself, or another transport that
+// translates to it. *websocket.Conn implements it
+type Conn interface {
+	NextReader() (messageType int, r io.Reader, err error)
+	WriteJSON(v interface{}) error
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	Close() error
+}
+
+// ServeConn runs a call session on conn until the call ends, for transports
+// other than Twilio's WebSocket. The conversation starts with conn's start
+// event and ends with its stop event or a read error; the session closes conn
+func ServeConn(ctx context.Context, conn Conn, cfg *config.Config, services *Services) {
+	session := NewCallSession(conn, cfg, services)
+	defer func() { _ = session.twilioConn().Close() }()
+	session.serve(ctx)
+}
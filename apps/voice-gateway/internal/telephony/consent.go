@@ -0,0 +1,186 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+const (
+	// complianceAnnounceTimeout bounds waiting for the disclaimer to finish playing
+	complianceAnnounceTimeout = 60 * time.Second
+
+	// complianceDeclineTimeout bounds the goodbye before a declined call is ended
+	complianceDeclineTimeout = 15 * time.Second
+)
+
+// Recording consent outcomes
+const (
+	ConsentGranted    = "granted"
+	ConsentDeclined   = "declined"
+	ConsentNoResponse = "no_response"
+)
+
+// consentAnswer is the caller's reply to the consent prompt
+type consentAnswer struct {
+	status string
+	method string // speech or dtmf
+}
+
+// consentState tracks a pending recording consent question
+type consentState struct {
+	answers chan consentAnswer
+}
+
+// startCompliance plays the firm's disclaimer and collects recording consent,
+// then runs onDone; onDone is not run for callers whose decline ends the call
+// Calls with nothing to announce go straight to onDone
+func (s *CallSession) startCompliance(settings *firm.Settings, onDone func()) {
+	c := settings.Compliance
+	if c.Disclaimer == "" && c.DisclaimerAudio == "" && !c.RequireConsent {
+		onDone()
+		return
+	}
+
+	// Nothing recorded so far is kept unless the caller agrees
+	if c.RequireConsent {
+		s.holdCallRecording()
+	}
+
+	s.goSafe("consent", func() {
+		record := &cdr.Consent{}
+		if c.Disclaimer != "" || c.DisclaimerAudio != "" {
+			s.playGreeting(i18n.Disclaimer, c.DisclaimerAudio, c.Disclaimer)
+			s.waitForPlayback(complianceAnnounceTimeout)
+			record.DisclaimerPlayed = true
+		}
+		if !c.RequireConsent {
+			s.cdr.Update(func(r *cdr.Record) { r.Consent = record })
+			onDone()
+			return
+		}
+
+		answer, ok := s.askConsent(c)
+		if !ok {
+			// Caller hung up; the held recording is never stored
+			return
+		}
+		now := time.Now().UTC()
+		record.Status = answer.status
+		record.Method = answer.method
+		if answer.method != "" {
+			record.AnsweredAt = &now
+		}
+		s.cdr.Update(func(r *cdr.Record) { r.Consent = record })
+		observability.RecordConsent(answer.status)
+		s.logger.Info().
+			Str("status", answer.status).
+			Str("method", answer.method).
+			Msg("Recording consent resolved")
+
+		granted := answer.status == ConsentGranted
+		s.releaseCallRecording(granted)
+		if granted {
+			onDone()
+			return
+		}
+
+		if c.DeclineMessage != "" {
+			if err := s.speak(s.localize(i18n.ConsentDeclined, c.DeclineMessage)); err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to play consent decline message")
+			}
+		}
+		if c.DeclineAction == firm.DeclineEndCall {
+			s.waitForPlayback(complianceDeclineTimeout)
+			s.cdr.Update(func(r *cdr.Record) { r.Disposition = "consent_declined" })
+			s.hangup("consent_declined")
+			return
+		}
+		s.waitForPlayback(complianceAnnounceTimeout)
+		onDone()
+	})
+}
+
+// askConsent prompts the caller and waits for a spoken or keypad answer
+// Returns false if the call ended before the question was resolved
+func (s *CallSession) askConsent(c firm.ComplianceSettings) (consentAnswer, bool) {
+	state := &consentState{answers: make(chan consentAnswer, 1)}
+	s.mu.Lock()
+	s.consent = state
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.consent = nil
+		s.mu.Unlock()
+	}()
+
+	if err := s.speak(s.localize(i18n.ConsentPrompt, c.ConsentPrompt)); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to play consent prompt")
+	}
+
+	// The caller may answer mid-prompt; the timeout starts once it has played
+	waitDone := make(chan struct{})
+	s.goSafe("consent_wait", func() {
+		s.waitForPlayback(complianceAnnounceTimeout)
+		close(waitDone)
+	})
+	select {
+	case answer := <-state.answers:
+		return answer, true
+	case <-s.done:
+		return consentAnswer{}, false
+	case <-waitDone:
+	}
+
+	timeout := time.NewTimer(time.Duration(c.ConsentTimeoutSeconds) * time.Second)
+	defer timeout.Stop()
+	select {
+	case answer := <-state.answers:
+		return answer, true
+	case <-timeout.C:
+		return consentAnswer{status: ConsentNoResponse}, true
+	case <-s.done:
+		return consentAnswer{}, false
+	}
+}
+
+// pendingConsent returns the open consent question, or nil when none is asked
+func (s *CallSession) pendingConsent() *consentState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.consent
+}
+
+// answerConsentSpeech resolves the consent question from a final transcript
+// Replies that are neither yes nor no are ignored until the timeout
+func (s *CallSession) answerConsentSpeech(state *consentState, text string) {
+	switch {
+	case isNegative(text):
+		state.answer(consentAnswer{status: ConsentDeclined, method: "speech"})
+	case isAffirmative(text):
+		state.answer(consentAnswer{status: ConsentGranted, method: "speech"})
+	default:
+		s.logger.Debug().Str("text", text).Msg("Unclear consent reply, still waiting")
+	}
+}
+
+// answerConsentDigit resolves the consent question from a keypress
+func (s *CallSession) answerConsentDigit(state *consentState, digit string) {
+	switch digit {
+	case "1":
+		state.answer(consentAnswer{status: ConsentGranted, method: "dtmf"})
+	case "2":
+		state.answer(consentAnswer{status: ConsentDeclined, method: "dtmf"})
+	}
+}
+
+// answer delivers the first answer; later ones are dropped
+func (c *consentState) answer(a consentAnswer) {
+	select {
+	case c.answers <- a:
+	default:
+	}
+}
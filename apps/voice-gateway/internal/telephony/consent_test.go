@@ -0,0 +1,107 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/recording"
+)
+
+// runConsent starts the consent flow and answers it once the question is open
+func runConsent(t *testing.T, s *CallSession, settings *firm.Settings, answer func(c *consentState)) bool {
+	t.Helper()
+	done := make(chan struct{})
+	s.startCompliance(settings, func() { close(done) })
+
+	deadline := time.Now().Add(time.Second)
+	for s.pendingConsent() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected consent question to open")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	answer(s.pendingConsent())
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(3 * time.Second):
+		return false
+	}
+}
+
+func newConsentTestSession() *CallSession {
+	s := newSupervisorTestSession()
+	s.recording = &callRecording{recorder: recording.NewStereoRecorder(time.Minute)}
+	return s
+}
+
+func TestCompliance_ConsentGranted(t *testing.T) {
+	s := newConsentTestSession()
+	settings := firm.DefaultSettings()
+	settings.Compliance.RequireConsent = true
+
+	if !runConsent(t, s, settings, func(c *consentState) { s.answerConsentSpeech(c, "Yes, that's fine") }) {
+		t.Fatal("Expected the call to continue after consent")
+	}
+
+	consent := s.cdr.Snapshot().Consent
+	if consent == nil || consent.Status != ConsentGranted || consent.Method != "speech" {
+		t.Errorf("Expected granted by speech, got %+v", consent)
+	}
+	if s.recording == nil || s.recording.held {
+		t.Error("Expected recording to be kept and released")
+	}
+}
+
+func TestCompliance_ConsentDeclinedByKeypad(t *testing.T) {
+	s := newConsentTestSession()
+	settings := firm.DefaultSettings()
+	settings.Compliance.RequireConsent = true
+
+	if !runConsent(t, s, settings, func(c *consentState) { s.answerConsentDigit(c, "2") }) {
+		t.Fatal("Expected the call to continue unrecorded")
+	}
+
+	consent := s.cdr.Snapshot().Consent
+	if consent == nil || consent.Status != ConsentDeclined || consent.Method != "dtmf" {
+		t.Errorf("Expected declined by dtmf, got %+v", consent)
+	}
+	if s.recording != nil {
+		t.Error("Expected recording to be discarded")
+	}
+}
+
+func TestCompliance_NoResponse(t *testing.T) {
+	s := newConsentTestSession()
+	settings := firm.DefaultSettings()
+	settings.Compliance.RequireConsent = true
+	settings.Compliance.ConsentTimeoutSeconds = 1
+
+	// Unclear replies do not resolve the question
+	if !runConsent(t, s, settings, func(c *consentState) { s.answerConsentSpeech(c, "what was that?") }) {
+		t.Fatal("Expected the call to continue after the timeout")
+	}
+
+	consent := s.cdr.Snapshot().Consent
+	if consent == nil || consent.Status != ConsentNoResponse || consent.AnsweredAt != nil {
+		t.Errorf("Expected no_response, got %+v", consent)
+	}
+	if s.recording != nil {
+		t.Error("Expected recording to be discarded")
+	}
+}
+
+func TestCompliance_Disabled(t *testing.T) {
+	s := newConsentTestSession()
+	called := false
+	s.startCompliance(firm.DefaultSettings(), func() { called = true })
+
+	if !called {
+		t.Error("Expected routing to run immediately without compliance settings")
+	}
+	if s.cdr.Snapshot().Consent != nil {
+		t.Error("Expected no consent record")
+	}
+}
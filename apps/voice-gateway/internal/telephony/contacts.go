@@ -0,0 +1,115 @@
+package telephony
+
+import (
+	"context"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/contacts"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+// contactLookupTimeout bounds the CRM lookup, and how long the first
+// orchestrator request waits for it
+const contactLookupTimeout = 3 * time.Second
+
+// lookupCaller matches the caller against the firm's CRM so the first
+// orchestrator request can carry their context
+func (s *CallSession) lookupCaller(firmID string, settings *firm.Settings) {
+	defer close(s.contactReady)
+
+	s.mu.RLock()
+	number := s.callerNumber
+	s.mu.RUnlock()
+	if number == "" {
+		return
+	}
+
+	provider, err := contacts.NewProvider(contacts.ProviderConfig{
+		Provider: settings.Contacts.Provider,
+		URL:      settings.Contacts.URL,
+		APIToken: settings.Contacts.APIToken,
+	})
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Invalid contact provider configuration")
+		return
+	}
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contactLookupTimeout)
+	defer cancel()
+
+	contact, err := provider.Lookup(ctx, firmID, number)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("provider", settings.Contacts.Provider).Msg("Caller lookup failed")
+		return
+	}
+	if contact == nil {
+		s.logger.Debug().Str("provider", settings.Contacts.Provider).Msg("Caller not found in CRM")
+		return
+	}
+
+	s.logger.Info().
+		Str("contact_id", contact.ID).
+		Str("provider", contact.Source).
+		Int("open_matters", len(contact.Matters)).
+		Msg("Caller matched in CRM")
+
+	s.mu.Lock()
+	s.contact = contact
+	s.mu.Unlock()
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.ContactID = contact.ID
+		r.ContactSource = contact.Source
+	})
+}
+
+// firstTurnMetadata returns call context for the first orchestrator request
+// and nil afterwards; waits briefly for an in-flight CRM lookup
+func (s *CallSession) firstTurnMetadata() map[string]string {
+	s.mu.Lock()
+	if s.metadataSent {
+		s.mu.Unlock()
+		return nil
+	}
+	s.metadataSent = true
+	s.mu.Unlock()
+
+	select {
+	case <-s.contactReady:
+	case <-time.After(contactLookupTimeout):
+		s.logger.Warn().Msg("Caller lookup still pending, sending first turn without CRM context")
+	case <-s.done:
+		return nil
+	}
+	callerLanguage, firmLanguage, translated := s.translationLanguages()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metadata := map[string]string{}
+	if s.contact != nil {
+		metadata = s.contact.Metadata()
+	}
+	if s.callerNumber != "" {
+		metadata["caller_number"] = s.callerNumber
+	}
+	if s.language != "" {
+		metadata["language"] = s.language
+	}
+	if translated {
+		// The assistant works in the firm's language; the gateway translates
+		metadata["language"] = firmLanguage
+		metadata["caller_language"] = callerLanguage
+	}
+	if s.campaign != "" {
+		metadata["campaign"] = s.campaign
+	}
+	if s.script != "" {
+		metadata["campaign_script"] = s.script
+	}
+	return metadata
+}
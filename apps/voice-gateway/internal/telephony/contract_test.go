@@ -0,0 +1,53 @@
+package telephony
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/contract"
+)
+
+// twilioEvents are the events the read loop handles
+var twilioEvents = map[string]bool{"connected": true, "start": true, "media": true, "mark": true, "dtmf": true, "stop": true}
+
+// twilioIgnored are fields of Twilio's messages the gateway does not read; a
+// bidirectional stream's mediaFormat is always 8kHz μ-law
+var twilioIgnored = []string{"protocol", "version", "sequenceNumber", "start.mediaFormat"}
+
+// TestTwilioContract replays recorded media streams through the decoder and
+// the media fast path
+func TestTwilioContract(t *testing.T) {
+	for _, fixture := range contract.Load(t, "testdata/twilio") {
+		t.Run(fixture.Name, func(t *testing.T) {
+			parsed := []TwilioMessage{}
+			for _, message := range fixture.Messages {
+				contract.RequireModelled(t, message, TwilioMessage{}, twilioIgnored...)
+				var msg TwilioMessage
+				if err := json.Unmarshal(message, &msg); err != nil {
+					t.Fatalf("Failed to decode %s: %v", message, err)
+				}
+				if !twilioEvents[msg.Event] {
+					t.Errorf("Unhandled Twilio event %q", msg.Event)
+				}
+				if msg.Start != nil {
+					if err := msg.Start.CustomParameters.Check(nil); err != nil || len(msg.Start.CustomParameters.Unknown) > 0 {
+						t.Errorf("Expected valid stream parameters, got %v (unknown %v)", err, msg.Start.CustomParameters.Unknown)
+					}
+				}
+
+				// Media events take the fast path in production, which must
+				// read them as the decoder does
+				if msg.Event == "media" {
+					media, ok := parseMediaEvent(message)
+					if !ok {
+						t.Errorf("Expected the fast path to read %s", message)
+					} else if trackName(media.track) != msg.Media.Track || string(media.timestamp) != msg.Media.Timestamp || string(media.payload) != msg.Media.Payload {
+						t.Errorf("Fast path read %s as track %q, timestamp %q", message, media.track, media.timestamp)
+					}
+				}
+				parsed = append(parsed, msg)
+			}
+			contract.Golden(t, fixture.Golden, parsed)
+		})
+	}
+}
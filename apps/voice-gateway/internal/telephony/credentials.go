@@ -0,0 +1,44 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// useFirmCredentials swaps in STT and TTS clients billed to the firm's own
+// provider accounts before either is used. A credential that cannot be read
+// falls back to the shared key so the call still goes through. Calls on Vosk
+// or Piper have no provider account to bill
+func (s *CallSession) useFirmCredentials(settings *firm.Settings) {
+	if cfg := s.sttConfig(); cfg.STTProvider != stt.ProviderVosk {
+		if key, ok := s.firmKey("deepgram", settings.Providers.Deepgram); ok {
+			if s.sttClient != nil {
+				_ = s.sttClient.Close()
+			}
+			s.sttClient = stt.NewDeepgramClientWithKey(cfg, key)
+		}
+	}
+	if cfg := s.ttsConfig(); cfg.TTSProvider != tts.ProviderPiper {
+		if key, ok := s.firmKey("cartesia", settings.Providers.Cartesia); ok {
+			if s.ttsClient != nil {
+				_ = s.ttsClient.Close()
+			}
+			s.ttsClient = tts.NewCartesiaClientWithKey(cfg, key)
+		}
+	}
+}
+
+// firmKey resolves one provider credential, logging (never the key) on failure
+func (s *CallSession) firmKey(provider string, credential firm.Credential) (string, bool) {
+	if !credential.IsSet() {
+		return "", false
+	}
+	key, err := credential.Resolve()
+	if err != nil {
+		s.logger.Error().Err(err).Str("provider", provider).Msg("Firm credential unavailable, using the shared key")
+		return "", false
+	}
+	s.logger.Info().Str("provider", provider).Msg("Using the firm's own provider account")
+	return key, true
+}
@@ -0,0 +1,48 @@
+package telephony
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+func hasBreaker(name string) bool {
+	for _, b := range resilience.Breakers.Snapshots() {
+		if b.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUseFirmCredentials(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "cartesia.key")
+	if err := os.WriteFile(keyFile, []byte("firm-cartesia-key\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	cfg := &config.Config{DeepgramAPIKey: "shared", CartesiaAPIKey: "shared"}
+	s := newSupervisorTestSession()
+	s.config = cfg
+	shared := stt.NewDeepgramClient(cfg)
+	s.sttClient = shared
+	s.ttsClient = tts.NewCartesiaClient(cfg)
+
+	settings := firm.DefaultSettings()
+	settings.Providers.Deepgram = firm.Credential{APIKeyEnv: "TEST_FIRM_DEEPGRAM_KEY_UNSET"}
+	settings.Providers.Cartesia = firm.Credential{APIKeyFile: keyFile}
+	s.useFirmCredentials(settings)
+
+	if s.sttClient != shared {
+		t.Error("Expected an unreadable credential to keep the shared Deepgram client")
+	}
+	if !hasBreaker(resilience.KeyedName("cartesia", "firm-cartesia-key")) {
+		t.Error("Expected a circuit breaker for the firm's Cartesia key")
+	}
+}
@@ -0,0 +1,91 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+)
+
+// disclosureQuietPoll is how often a due AI disclosure checks for a pause to be spoken in
+const disclosureQuietPoll = 250 * time.Millisecond
+
+// useFirmWatermark mixes the firm's AI-disclosure tone into everything the
+// agent says, when the firm discloses by tone
+func (s *CallSession) useFirmWatermark(settings *firm.Settings) {
+	d := settings.Compliance.AIDisclosure
+	if d.Mode != firm.DisclosureTone {
+		return
+	}
+	watermark := audio.NewWatermark(audio.WatermarkConfig{
+		SampleRate:  8000,
+		Interval:    time.Duration(d.IntervalSeconds) * time.Second,
+		FrequencyHz: d.ToneHz,
+		Duration:    time.Duration(d.ToneMs) * time.Millisecond,
+		Level:       d.ToneLevel,
+	})
+	s.mu.Lock()
+	s.watermark = watermark
+	s.mu.Unlock()
+}
+
+// startAIDisclosure repeats the firm's AI disclosure for the rest of an AI
+// conversation, when the firm discloses by announcement
+func (s *CallSession) startAIDisclosure(settings *firm.Settings) {
+	d := settings.Compliance.AIDisclosure
+	if d.Mode != firm.DisclosureAnnouncement {
+		return
+	}
+	interval := time.Duration(d.IntervalSeconds) * time.Second
+	s.goSafe("ai_disclosure", func() { s.announceAIDisclosure(d, interval) })
+}
+
+// announceAIDisclosure speaks the disclosure every interval, each time
+// waiting for a pause so it never talks over the caller or cuts the agent off
+func (s *CallSession) announceAIDisclosure(d firm.AIDisclosureSettings, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	poll := time.NewTicker(min(disclosureQuietPoll, interval))
+	defer poll.Stop()
+
+	due := false
+	for {
+		select {
+		case <-timer.C:
+			due = true
+		case <-poll.C:
+			if !due || !s.lineQuiet() {
+				continue
+			}
+			due = false
+			// An operator speaking for the firm is not the AI
+			if !s.aiPaused() {
+				s.playGreeting(i18n.AIDisclosure, d.AnnouncementAudio, d.Announcement)
+				s.cdr.Update(func(r *cdr.Record) { r.AIDisclosures++ })
+				s.logger.Debug().Msg("Repeated the AI disclosure")
+			}
+			timer.Reset(interval)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// lineQuiet reports whether nobody is speaking or about to: the caller is
+// silent, no response is streaming from the orchestrator, and no agent audio
+// is being synthesized, queued, or played
+func (s *CallSession) lineQuiet() bool {
+	s.mu.RLock()
+	talking := s.isTalking
+	s.mu.RUnlock()
+
+	s.turnMu.Lock()
+	responding := s.activeTurn != nil
+	s.turnMu.Unlock()
+
+	ttsActive := s.ttsClient != nil && s.ttsClient.IsActive()
+	return !talking && !responding && !ttsActive && len(s.audioOut) == 0 &&
+		!s.playbackActive() && s.marks.pendingCount() == 0 && s.pendingConfirmation() == nil
+}
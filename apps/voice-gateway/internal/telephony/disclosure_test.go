@@ -0,0 +1,60 @@
+package telephony
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+func TestCallSession_AIDisclosureWaitsForQuiet(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+	voice := &phraseTTS{}
+	s.ttsClient = voice
+	s.isTalking = true
+
+	d := firm.AIDisclosureSettings{Mode: firm.DisclosureAnnouncement, Announcement: "You're speaking with an AI assistant."}
+	go s.announceAIDisclosure(d, 10*time.Millisecond)
+	defer close(s.done)
+
+	time.Sleep(50 * time.Millisecond)
+	if spoken := voice.spoken(); len(spoken) != 0 {
+		t.Fatalf("Expected no disclosure while the caller is talking, got %q", spoken)
+	}
+
+	s.mu.Lock()
+	s.isTalking = false
+	s.mu.Unlock()
+	deadline := time.Now().Add(time.Second)
+	for len(voice.spoken()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if spoken := voice.spoken(); len(spoken) == 0 || spoken[0] != d.Announcement {
+		t.Errorf("Expected the disclosure once the line was quiet, got %q", spoken)
+	}
+}
+
+func TestCallSession_WatermarkMixedIntoAgentAudio(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"compliance": {"ai_disclosure": {"mode": "tone", "interval_seconds": 30}}}}}`)
+	s.isActive = true
+	s.audioOutBuffer = audio.NewRingBuffer(1024)
+	s.writer = newTwilioWriter(&fakeTwilioConn{}, nil)
+	s.outbound = s.newOutboundPipeline()
+	s.useFirmWatermark(s.firmSettings())
+
+	var sent []byte
+	if err := s.outbound.InsertBefore(stageSend, "tap", audio.StageFunc(func(p *audio.Packet) bool {
+		sent = append(sent, p.Data...)
+		return true
+	})); err != nil {
+		t.Fatalf("InsertBefore() failed: %v", err)
+	}
+
+	silence := bytes.Repeat([]byte{0xFF}, 160)
+	s.sendOutgoingChunk(append([]byte(nil), silence...))
+	if bytes.Equal(sent, silence) {
+		t.Error("Expected the watermark tone in the agent's first audio")
+	}
+}
@@ -0,0 +1,198 @@
+package telephony
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+)
+
+const (
+	// vadFrameSize is the VAD analysis window: 20ms at 8kHz, matching Twilio's media frames
+	vadFrameSize = 160
+
+	// vadFrameMs is the duration of one VAD frame in milliseconds
+	vadFrameMs = 20
+)
+
+// Reasons adaptive endpointing chose the silence it waits for
+const (
+	endpointBase     = "base"     // VAD_SILENCE_FRAMES
+	endpointShort    = "short"    // The caller has been answering in a few words
+	endpointTrailing = "trailing" // The caller paused on a conjunction or filler
+)
+
+const (
+	// shortAnswerWords is the most words a final may have and count as a
+	// short answer
+	shortAnswerWords = 3
+
+	// shortAnswerRun is how many short answers in a row shorten the endpoint
+	shortAnswerRun = 2
+)
+
+// trailingWords leave a phrase unfinished: a caller pausing on one is
+// usually thinking, not done
+var trailingWords = map[string]bool{
+	"and": true, "but": true, "or": true, "so": true, "because": true, "cause": true,
+	"if": true, "then": true, "that": true, "which": true, "when": true, "with": true,
+	"to": true, "of": true, "the": true, "a": true, "an": true, "my": true,
+	"um": true, "uh": true, "er": true, "erm": true, "hmm": true, "like": true,
+}
+
+// endpointTuner adapts how long local VAD waits in silence before
+// finalizing an utterance, from what the caller has been saying. A nil
+// tuner leaves VAD_SILENCE_FRAMES in place
+type endpointTuner struct {
+	base, short, long int // Silence frames
+
+	mu       sync.Mutex
+	shortRun int  // Consecutive short finals
+	trailing bool // The latest transcript ends on a trailing word
+}
+
+// newEndpointTuner returns a tuner for cfg, or nil when adaptive endpointing
+// is off or local VAD does not finalize utterances
+func newEndpointTuner(cfg *config.Config) *endpointTuner {
+	if !cfg.AdaptiveEndpointingEnabled || !stt.EndpointingMode(cfg.EndpointingMode).UsesLocalVAD() {
+		return nil
+	}
+	return &endpointTuner{
+		base:  cfg.VADSilenceFrames,
+		short: max(cfg.EndpointingMinSilenceMs/vadFrameMs, 1),
+		long:  max(cfg.EndpointingMaxSilenceMs/vadFrameMs, 1),
+	}
+}
+
+// endsTrailing reports whether text stops on a trailing word
+func endsTrailing(text string) bool {
+	words := matchWords(text)
+	return len(words) > 0 && trailingWords[words[len(words)-1]]
+}
+
+// heardPartial notes the caller's speech so far in the current segment
+func (t *endpointTuner) heardPartial(text string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.trailing = endsTrailing(text)
+	t.mu.Unlock()
+}
+
+// heardFinal notes a final transcript of the caller's speech
+func (t *endpointTuner) heardFinal(text string) {
+	if t == nil || text == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trailing = endsTrailing(text)
+	if len(matchWords(text)) <= shortAnswerWords {
+		t.shortRun++
+	} else {
+		t.shortRun = 0
+	}
+}
+
+// silence returns the silence frames that end the caller's speech now, and why
+func (t *endpointTuner) silence() (frames int, reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case t.trailing:
+		return t.long, endpointTrailing
+	case t.shortRun >= shortAnswerRun:
+		return t.short, endpointShort
+	default:
+		return t.base, endpointBase
+	}
+}
+
+// detectSpeech runs the local VAD over a decoded inbound chunk and updates the
+// session's talking state. Returns whether the caller is speaking and whether
+// an utterance ended within this chunk.
+func (s *CallSession) detectSpeech(samples []int16) (speaking bool, speechEnded bool) {
+	var endpoint string
+	if s.endpoints != nil {
+		var frames int
+		frames, endpoint = s.endpoints.silence()
+		s.vadDetector.SetSilenceFrames(frames)
+	}
+
+	for start := 0; start < len(samples); start += vadFrameSize {
+		end := min(start+vadFrameSize, len(samples))
+		isSpeaking, started, ended := s.vadDetector.ProcessFrame(samples[start:end])
+
+		if started {
+			s.mu.Lock()
+			s.isTalking = true
+			s.talkingSince = time.Now()
+			s.mu.Unlock()
+
+			if s.metrics != nil {
+				s.metrics.RecordSTTStart()
+			}
+			s.logger.Debug().Msg("Local VAD: speech started")
+		}
+
+		if ended {
+			s.mu.Lock()
+			s.isTalking = false
+			s.speechEndedAt = time.Now()
+			s.mu.Unlock()
+
+			speechEnded = true
+			event := s.logger.Debug()
+			if s.endpoints != nil {
+				event = event.Int("silence_ms", s.vadDetector.SilenceFrames()*vadFrameMs).Str("endpoint", endpoint)
+			}
+			event.Msg("Local VAD: speech ended")
+		}
+
+		speaking = speaking || isSpeaking
+	}
+
+	return speaking, speechEnded
+}
+
+// finalizeUtterance forces Deepgram to emit a final transcription when the
+// endpointing mode lets local VAD decide end-of-speech
+func (s *CallSession) finalizeUtterance() {
+	if !s.endpointingMode.UsesLocalVAD() {
+		return
+	}
+
+	err := s.sttClient.Finalize()
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to force-finalize utterance")
+	} else {
+		s.logger.Debug().
+			Str("endpointing_mode", string(s.endpointingMode)).
+			Msg("Utterance finalized by local VAD")
+	}
+
+	if s.metrics != nil {
+		s.metrics.RecordVADFinalize(err == nil)
+	}
+}
+
+// framesForSTT returns the frames that should be sent to the STT provider for
+// an inbound chunk, recording forwarded vs suppressed audio for billing metrics
+func (s *CallSession) framesForSTT(pcmuChunk []byte, speaking bool) [][]byte {
+	frames := [][]byte{pcmuChunk}
+	if s.suppressor != nil {
+		frames = s.suppressor.Process(pcmuChunk, speaking)
+	}
+
+	if s.metrics != nil {
+		forwarded := 0
+		for _, frame := range frames {
+			forwarded += len(frame)
+		}
+		s.metrics.RecordSTTAudio(len(pcmuChunk), forwarded)
+	}
+
+	return frames
+}
@@ -0,0 +1,83 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestNewEndpointTuner(t *testing.T) {
+	cfg := &config.Config{
+		AdaptiveEndpointingEnabled: true,
+		EndpointingMode:            "hybrid",
+		VADSilenceFrames:           15,
+		EndpointingMinSilenceMs:    120,
+		EndpointingMaxSilenceMs:    1000,
+	}
+	tuner := newEndpointTuner(cfg)
+	if tuner == nil || tuner.base != 15 || tuner.short != 6 || tuner.long != 50 {
+		t.Fatalf("Unexpected tuner %+v", tuner)
+	}
+
+	cfg.EndpointingMode = "deepgram"
+	if newEndpointTuner(cfg) != nil {
+		t.Error("Expected no tuner when Deepgram alone endpoints")
+	}
+	cfg.EndpointingMode, cfg.AdaptiveEndpointingEnabled = "vad", false
+	if newEndpointTuner(cfg) != nil {
+		t.Error("Expected no tuner when disabled")
+	}
+}
+
+func TestEndpointTuner_Silence(t *testing.T) {
+	tuner := &endpointTuner{base: 15, short: 6, long: 50}
+	check := func(wantFrames int, wantReason string) {
+		t.Helper()
+		if frames, reason := tuner.silence(); frames != wantFrames || reason != wantReason {
+			t.Errorf("silence() = %d (%s), want %d (%s)", frames, reason, wantFrames, wantReason)
+		}
+	}
+
+	check(15, endpointBase)
+
+	// Short answers in a row shorten the wait
+	tuner.heardFinal("Yes.")
+	check(15, endpointBase)
+	tuner.heardFinal("That's right.")
+	check(6, endpointShort)
+
+	// A pause on a conjunction or filler lengthens it
+	tuner.heardPartial("I was driving home and")
+	check(50, endpointTrailing)
+	tuner.heardPartial("I was driving home and, um...")
+	check(50, endpointTrailing)
+	tuner.heardPartial("I was driving home and the other car ran the light")
+	check(6, endpointShort)
+
+	// A longer answer ends the run of short ones
+	tuner.heardFinal("I was driving home and the other car ran the light.")
+	check(15, endpointBase)
+}
+
+func TestCallSession_DetectSpeechAdaptsSilence(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.vadDetector = audio.NewVADDetector(&audio.VADConfig{EnergyThreshold: 500, SilenceFrames: 15, FrameSize: vadFrameSize})
+	s.endpoints = &endpointTuner{base: 15, short: 6, long: 50}
+	s.endpoints.heardFinal("Yes.")
+	s.endpoints.heardFinal("No.")
+
+	speech := make([]int16, vadFrameSize)
+	for i := range speech {
+		speech[i] = 5000
+	}
+	s.detectSpeech(speech)
+
+	// Six frames of silence end a short answer
+	if _, ended := s.detectSpeech(make([]int16, 6*vadFrameSize)); !ended {
+		t.Error("Expected speech to end after the short-answer silence")
+	}
+	if got := s.vadDetector.SilenceFrames(); got != 6 {
+		t.Errorf("Expected 6 silence frames, got %d", got)
+	}
+}
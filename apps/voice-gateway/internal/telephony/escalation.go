@@ -0,0 +1,225 @@
+package telephony
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/sentiment"
+)
+
+// escalationPublishTimeout bounds delivery of the call.escalated event
+const escalationPublishTimeout = 10 * time.Second
+
+// transferFailedMessage is spoken when an accepted escalation transfer fails
+const transferFailedMessage = "I'm sorry, I wasn't able to connect you. Let me keep helping you in the meantime."
+
+// escalationMonitor analyzes caller turns and runs the firm's escalation
+// actions; used only from the transcription goroutine
+type escalationMonitor struct {
+	settings    firm.EscalationSettings
+	transferTo  string // Empty for the firm's hunt group
+	canTransfer bool
+	analyzer    *sentiment.Analyzer
+	tracker     *sentiment.Tracker
+
+	offerPending bool // A transfer was offered and the caller's answer is awaited
+}
+
+// EscalationAlert is the payload of the call.escalated event
+type EscalationAlert struct {
+	CallSid      string   `json:"call_sid"`
+	FirmID       string   `json:"firm_id"`
+	CallerNumber string   `json:"caller_number,omitempty"`
+	Trigger      string   `json:"trigger"`
+	Signals      []string `json:"signals,omitempty"`
+	Sentiment    float64  `json:"sentiment"`
+	Transcript   string   `json:"transcript"` // The turn that triggered escalation
+	EscalatedAt  string   `json:"escalated_at"`
+}
+
+// newEscalationMonitor returns nil when the firm has no escalation actions
+func newEscalationMonitor(settings *firm.Settings) *escalationMonitor {
+	if len(settings.Escalation.Actions) == 0 {
+		return nil
+	}
+	return &escalationMonitor{
+		settings:    settings.Escalation,
+		transferTo:  settings.EscalationTransferNumber(),
+		canTransfer: settings.EscalationTransferNumber() != "" || len(settings.Transfer.Targets) > 0,
+		analyzer:    sentiment.NewAnalyzer(settings.Escalation.Keywords),
+		tracker:     sentiment.NewTracker(settings.Escalation.FrustrationTurns),
+	}
+}
+
+func (m *escalationMonitor) has(action string) bool {
+	for _, a := range m.settings.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// handleEscalation analyzes a final caller transcript and runs escalation
+// actions; returns true when the gateway handled the turn itself and it
+// should not reach the orchestrator
+func (s *CallSession) handleEscalation(m *escalationMonitor, text string) bool {
+	if m == nil {
+		return false
+	}
+
+	// Answer to a transfer offer
+	if m.offerPending {
+		m.offerPending = false
+		if isAffirmative(text) {
+			s.acceptEscalationTransfer(m.transferTo)
+			return true
+		}
+	}
+
+	result := m.analyzer.Analyze(text)
+	trigger := m.tracker.Observe(result)
+	average := m.tracker.Average()
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Sentiment = average
+		if r.Escalation != nil {
+			r.Escalation.Signals = m.tracker.Signals()
+		}
+	})
+	if trigger == "" {
+		return false
+	}
+
+	s.logger.Warn().
+		Str("trigger", trigger).
+		Strs("signals", result.Signals).
+		Float64("sentiment", average).
+		Msg("Call escalated")
+	observability.RecordEscalation(trigger)
+	s.publishLive(live.Event{Type: live.TypeEscalation, Speaker: live.SpeakerCaller, Text: text, Reason: trigger})
+
+	if m.has(firm.EscalationTag) {
+		s.cdr.Update(func(r *cdr.Record) {
+			r.Escalation = &cdr.Escalation{
+				Trigger:     trigger,
+				Signals:     m.tracker.Signals(),
+				Sentiment:   average,
+				EscalatedAt: time.Now().UTC(),
+			}
+		})
+	}
+
+	if m.has(firm.EscalationWebhook) {
+		s.mu.RLock()
+		alert := &EscalationAlert{
+			CallSid:      s.callSid,
+			FirmID:       s.firmID,
+			CallerNumber: s.callerNumber,
+			Trigger:      trigger,
+			Signals:      m.tracker.Signals(),
+			Sentiment:    average,
+			Transcript:   text,
+			EscalatedAt:  time.Now().UTC().Format(time.RFC3339),
+		}
+		s.mu.RUnlock()
+		webhookURL := m.settings.WebhookURL
+		s.goSafe("escalation_alert", func() { s.publishEscalation(webhookURL, alert) })
+	}
+
+	if m.has(firm.EscalationOfferTransfer) && m.canTransfer && !s.aiPaused() {
+		if err := s.speak(s.localize(i18n.EscalationOffer, m.settings.OfferPrompt)); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to offer transfer")
+			return false
+		}
+		m.offerPending = true
+		s.cdr.Update(func(r *cdr.Record) {
+			if r.Escalation != nil {
+				r.Escalation.TransferOffered = true
+			}
+		})
+		return true
+	}
+	return false
+}
+
+// acceptEscalationTransfer transfers a caller who accepted the offer
+func (s *CallSession) acceptEscalationTransfer(number string) {
+	s.logger.Info().Str("transfer_to", number).Msg("Caller accepted escalation transfer")
+	s.cdr.Update(func(r *cdr.Record) {
+		if r.Escalation != nil {
+			r.Escalation.TransferAccepted = true
+		}
+	})
+
+	s.goSafe("escalation_transfer", func() {
+		if err := s.transferCall(number, ""); err != nil {
+			s.logger.Error().Err(err).Msg("Escalation transfer failed")
+			if err := s.speak(s.localize(i18n.TransferFailed, transferFailedMessage)); err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to play transfer failure message")
+			}
+		}
+	})
+}
+
+// publishEscalation sends the call.escalated event to the global sink and the firm's webhook
+func (s *CallSession) publishEscalation(webhookURL string, alert *EscalationAlert) {
+	if s.services == nil || s.services.Events == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), escalationPublishTimeout)
+	defer cancel()
+
+	publisher := s.services.Events
+	if webhookURL != "" {
+		publisher = events.MultiPublisher{publisher, s.services.webhookPublisher(webhookURL, s.config.EventsWebhookSecret)}
+	}
+	event := events.NewEvent(events.TypeCallEscalated, alert.CallSid, alert.FirmID, alert)
+	if err := publisher.Publish(ctx, event); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to publish escalation event")
+	}
+}
+
+// isAffirmative returns whether a short reply accepts an offer
+func isAffirmative(text string) bool {
+	return !isNegative(text) && hasAffirmative(text)
+}
+
+// hasAffirmative returns whether a reply contains a yes, even alongside a no
+func hasAffirmative(text string) bool {
+	padded := padWords(text)
+	for _, yes := range []string{" yes ", " yeah ", " yep ", " sure ", " please ", " okay ", " ok ", " go ahead ", " connect me ", " absolutely ", " definitely ",
+		" correct ", " that's right ", " confirm ",
+		" sí ", " si ", " claro ", " acepto ", " de acuerdo ", " por favor ", " correcto "} {
+		if strings.Contains(padded, yes) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNegative returns whether a short reply turns an offer down
+func isNegative(text string) bool {
+	padded := padWords(text)
+	for _, no := range []string{" no ", " nope ", " not ", " don't ", " dont ", " nah ", " decline "} {
+		if strings.Contains(padded, no) {
+			return true
+		}
+	}
+	return false
+}
+
+// padWords lowercases text to space-separated words with a space at each end,
+// so phrases can be matched on word boundaries
+func padWords(text string) string {
+	return " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(unicode.IsLetter(r) || r == '\'')
+	}), " ") + " "
+}
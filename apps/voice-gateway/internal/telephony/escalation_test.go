@@ -0,0 +1,60 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/sentiment"
+)
+
+func TestIsAffirmative(t *testing.T) {
+	tests := map[string]bool{
+		"Yes please":             true,
+		"Yeah, go ahead.":        true,
+		"Okay":                   true,
+		"No, I'm fine":           false,
+		"Please don't":           false,
+		"What are your hours?":   false,
+		"Not right now, thanks.": false,
+		"Sí, por favor":          true,
+		"Claro que sí":           true,
+		"No, gracias":            false,
+	}
+	for text, want := range tests {
+		if got := isAffirmative(text); got != want {
+			t.Errorf("isAffirmative(%q): expected %v, got %v", text, want, got)
+		}
+	}
+}
+
+func TestHandleEscalation_Tag(t *testing.T) {
+	s := newSupervisorTestSession()
+	settings := firm.DefaultSettings()
+	monitor := newEscalationMonitor(settings)
+
+	if s.handleEscalation(monitor, "Hi, I'd like to book a consultation") {
+		t.Error("Expected a calm turn to reach the orchestrator")
+	}
+	if s.cdr.Snapshot().Escalation != nil {
+		t.Error("Expected no escalation for a calm turn")
+	}
+
+	if s.handleEscalation(monitor, "Just let me talk to a real person") {
+		t.Error("Expected tag-only escalation to still reach the orchestrator")
+	}
+	escalation := s.cdr.Snapshot().Escalation
+	if escalation == nil || escalation.Trigger != sentiment.SignalEscalation {
+		t.Errorf("Expected CDR tagged with escalation_request, got %+v", escalation)
+	}
+}
+
+func TestNewEscalationMonitor_Disabled(t *testing.T) {
+	settings := firm.DefaultSettings()
+	settings.Escalation.Actions = nil
+	if newEscalationMonitor(settings) != nil {
+		t.Error("Expected no monitor without escalation actions")
+	}
+	if (&CallSession{}).handleEscalation(nil, "talk to a human") {
+		t.Error("Expected nil monitor to pass turns through")
+	}
+}
@@ -0,0 +1,35 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/flags"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// evaluateFeatures decides the call's feature flags once it is attributed,
+// so a flag changing mid-call never switches behaviour under the caller
+func (s *CallSession) evaluateFeatures(firmID, callSid string) {
+	var set *flags.Set
+	if s.services != nil {
+		set = s.services.Flags
+	}
+	features := set.ForCall(firmID, callSid)
+	for name, on := range features {
+		observability.RecordFeatureFlag(name, on)
+	}
+
+	s.mu.Lock()
+	s.features = features
+	s.mu.Unlock()
+	if on := features.On(); len(on) > 0 {
+		s.logger.Info().Strs("features", on).Msg("Feature flags on for call")
+		s.cdr.Update(func(r *cdr.Record) { r.Features = on })
+	}
+}
+
+// featureEnabled reports whether a rollout-gated behaviour is on for the call
+func (s *CallSession) featureEnabled(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.features.Enabled(name)
+}
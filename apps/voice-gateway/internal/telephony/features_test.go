@@ -0,0 +1,38 @@
+package telephony
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/flags"
+)
+
+func TestCallSession_EvaluatesFeaturesOnce(t *testing.T) {
+	set := flags.New(map[string]flags.Rule{"barge_in_v2": {Enabled: true, Firms: []string{"firm_a"}, Percent: new(float64)}})
+	s := newSupervisorTestSession()
+	s.services = &Services{Flags: set}
+
+	s.evaluateFeatures("firm_a", "CA1")
+	if !s.featureEnabled("barge_in_v2") {
+		t.Fatal("Expected barge_in_v2 on for a targeted firm")
+	}
+	if got := s.cdr.Finish(time.Now()); len(got.Features) != 1 || got.Features[0] != "barge_in_v2" {
+		t.Errorf("Expected the CDR to list the flag, got %v", got.Features)
+	}
+
+	// Turning the flag off later leaves the running call as it started
+	path := filepath.Join(t.TempDir(), "flags.json")
+	os.WriteFile(path, []byte(`{"barge_in_v2": {"enabled": false}}`), 0o600)
+	if err := set.Refresh(context.Background(), flags.FileSource(path)); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if !s.featureEnabled("barge_in_v2") {
+		t.Error("Expected the call to keep its evaluation")
+	}
+	if set.ForCall("firm_a", "CA2").Enabled("barge_in_v2") {
+		t.Error("Expected new calls to see the flag off")
+	}
+}
@@ -0,0 +1,38 @@
+package telephony
+
+import (
+	"time"
+)
+
+// firstResponseSpeechWindow is how recent local VAD's end of speech must be
+// to time a turn from it; otherwise the turn is timed from its final transcript
+const firstResponseSpeechWindow = 3 * time.Second
+
+// awaitFirstResponse starts timing the reply to a caller turn sent to the
+// orchestrator, from when the caller stopped speaking
+func (s *CallSession) awaitFirstResponse() {
+	now := time.Now()
+	s.mu.RLock()
+	since := s.speechEndedAt
+	s.mu.RUnlock()
+	if since.IsZero() || now.Sub(since) > firstResponseSpeechWindow {
+		since = now
+	}
+	s.responseDue.Store(since.UnixNano())
+}
+
+// noteFirstResponse stops timing at the first audio sent after a caller
+// turn, and feeds the latency to the first-response SLO
+func (s *CallSession) noteFirstResponse() {
+	since := s.responseDue.Swap(0)
+	if since == 0 {
+		return
+	}
+	latency := time.Since(time.Unix(0, since))
+	if s.metrics != nil {
+		s.metrics.RecordFirstResponseLatency(latency)
+	}
+	if s.services != nil {
+		s.services.ResponseSLO.Observe(latency)
+	}
+}
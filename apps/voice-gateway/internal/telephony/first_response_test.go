@@ -0,0 +1,33 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/slo"
+)
+
+func TestFirstResponse_TimedFromSpeechEnd(t *testing.T) {
+	tracker := slo.NewTracker(slo.Objective{Name: "first_response", Threshold: 500 * time.Millisecond, Goal: 0.95}, slo.Config{})
+	s := newSupervisorTestSession()
+	s.services = &Services{ResponseSLO: tracker}
+
+	// The caller stopped speaking well before the transcript was final
+	s.speechEndedAt = time.Now().Add(-800 * time.Millisecond)
+	s.awaitFirstResponse()
+	s.noteFirstResponse()
+	s.noteFirstResponse() // Later audio of the same reply is not timed
+
+	if stats := tracker.Stats(); stats.Good != 0 || stats.Bad != 1 {
+		t.Errorf("Expected one slow reply, got %+v", stats)
+	}
+
+	// A stale end of speech is ignored in favour of the transcript
+	s.speechEndedAt = time.Now().Add(-10 * time.Second)
+	s.awaitFirstResponse()
+	s.noteFirstResponse()
+
+	if stats := tracker.Stats(); stats.Good != 1 || stats.Bad != 1 {
+		t.Errorf("Expected a fast reply timed from the transcript, got %+v", stats)
+	}
+}
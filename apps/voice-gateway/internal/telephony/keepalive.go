@@ -0,0 +1,63 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// readDeadlineStep is how often reading a message pushes the read deadline
+// back; media arrives every 20ms, far more often than it needs moving
+const readDeadlineStep = time.Second
+
+// watchConn arms dead-connection detection on a Twilio connection: reads
+// fail once nothing, not even a pong, has arrived for TWILIO_READ_TIMEOUT_MS,
+// so a half-open connection ends the stream within seconds
+func (s *CallSession) watchConn(conn *websocket.Conn) {
+	timeout := time.Duration(s.config.TwilioReadTimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timeout))
+	})
+	s.readDeadlineAt = time.Now()
+}
+
+// noteRead pushes the read deadline back after a message from Twilio. Only
+// the reader goroutine calls it
+func (s *CallSession) noteRead() {
+	timeout := time.Duration(s.config.TwilioReadTimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(s.readDeadlineAt) < readDeadlineStep {
+		return
+	}
+	s.readDeadlineAt = now
+	_ = s.conn.SetReadDeadline(now.Add(timeout))
+}
+
+// keepAlive pings Twilio every TWILIO_PING_INTERVAL_MS until the call ends,
+// so a quiet but healthy connection answers with pongs that keep it readable
+func (s *CallSession) keepAlive() {
+	interval := time.Duration(s.config.TwilioPingIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Control frames may be written alongside the Twilio writer
+			if err := s.twilioConn().WriteControl(websocket.PingMessage, nil, time.Now().Add(s.writeTimeout())); err != nil && !s.reconnecting.Load() {
+				s.logger.Debug().Err(err).Msg("Failed to ping Twilio")
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// startKeepAliveSession runs a session with fast pings on a new stream and
+// returns it with the Twilio side of the connection
+func startKeepAliveSession(t *testing.T) (*CallSession, *websocket.Conn) {
+	t.Helper()
+	serverConn := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := newUpgrader(&config.Config{}, nil).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		serverConn <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	stream, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { stream.Close() })
+
+	s := newSupervisorTestSession()
+	conn := <-serverConn
+	s.conn = conn
+	s.isActive = true
+	s.config = &config.Config{TwilioPingIntervalMs: 50, TwilioReadTimeoutMs: 200}
+	s.watchConn(conn)
+	go s.keepAlive()
+	go s.processIncomingMessages()
+	return s, stream
+}
+
+func TestCallSession_KeepAliveDetectsDeadConnection(t *testing.T) {
+	// A peer that stops reading never answers pings, like a half-open connection
+	s, _ := startKeepAliveSession(t)
+	select {
+	case <-s.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the silent connection to be detected as dead")
+	}
+	s.mu.RLock()
+	readErr := s.readErr
+	s.mu.RUnlock()
+	if cause := closeCause(readErr); cause != closeNetwork {
+		t.Errorf("Expected a dead connection classed as %q, got %q (%v)", closeNetwork, cause, readErr)
+	}
+}
+
+func TestCallSession_KeepAliveKeepsQuietConnection(t *testing.T) {
+	// The peer answers pings (on read) but sends nothing else
+	s, stream := startKeepAliveSession(t)
+	go func() {
+		for {
+			if _, _, err := stream.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	select {
+	case <-s.done:
+		t.Fatal("Expected a connection answering pings to stay open")
+	case <-time.After(600 * time.Millisecond):
+	}
+}
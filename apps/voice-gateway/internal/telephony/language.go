@@ -0,0 +1,129 @@
+package telephony
+
+import (
+	"sync"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// callLanguage is the language gateway speech uses: what STT heard the caller
+// speak, else what the stream asked for, else DEEPGRAM_LANGUAGE
+func (s *CallSession) callLanguage() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.detectedLanguage != "" {
+		return s.detectedLanguage
+	}
+	if s.language != "" {
+		return s.language
+	}
+	if s.config != nil {
+		return s.config.DeepgramLanguage
+	}
+	return ""
+}
+
+// firmSettings returns the call's firm settings, or nil without a registry
+func (s *CallSession) firmSettings() *firm.Settings {
+	if s.services == nil || s.services.Firms == nil {
+		return nil
+	}
+	return s.services.Firms.Get(s.GetFirmID())
+}
+
+// builtinEnglish maps each message to the English the gateway ships with,
+// which the catalog's translations say; they do not match customized English
+var builtinEnglish = sync.OnceValue(func() map[i18n.Key]string {
+	d := firm.DefaultSettings()
+	return map[i18n.Key]string{
+		i18n.StallFallback:          config.DefaultValue("ORCHESTRATOR_STALL_FALLBACK"),
+		i18n.Clarification:          d.Clarification.Prompt,
+		i18n.ChallengePrompt:        d.Screening.ChallengePrompt,
+		i18n.ConsentPrompt:          d.Compliance.ConsentPrompt,
+		i18n.ConsentDeclined:        d.Compliance.DeclineMessage,
+		i18n.EscalationOffer:        d.Escalation.OfferPrompt,
+		i18n.TransferFailed:         transferFailedMessage,
+		i18n.InactivityPrompt:       config.DefaultValue("INACTIVITY_PROMPT"),
+		i18n.InactivityGoodbye:      config.DefaultValue("INACTIVITY_GOODBYE"),
+		i18n.MaxDurationGoodbye:     config.DefaultValue("MAX_DURATION_GOODBYE"),
+		i18n.BudgetMessage:          d.Budget.Message,
+		i18n.MissingFirm:            config.DefaultValue("MISSING_FIRM_MESSAGE"),
+		i18n.VoicemailGreeting:      d.Voicemail.Greeting,
+		i18n.ToolProgress:           d.ToolProgress.Phrase,
+		i18n.ConfirmationPrompt:     d.Confirmation.Prompt,
+		i18n.ConfirmationRepeat:     d.Confirmation.RepeatPrompt,
+		i18n.AIDisclosure:           d.Compliance.AIDisclosure.Announcement,
+		i18n.ResponseContinue:       config.DefaultValue("MAX_RESPONSE_CONTINUE_PROMPT"),
+		i18n.VerificationCodePrompt: d.Verification.CodePrompt,
+		i18n.VerificationPINPrompt:  d.Verification.PINPrompt,
+		i18n.VerificationRetry:      d.Verification.RetryPrompt,
+	}
+})
+
+// localize returns a message in the call's language: the firm's translation,
+// then the gateway catalog when english is the built-in text it translates,
+// then english (the configured text). A firm's or operator's own English is
+// never swapped for the catalog's translation of different words. An empty
+// english means the message is disabled, in any language
+func (s *CallSession) localize(key i18n.Key, english string) string {
+	language := s.callLanguage()
+	if english == "" || i18n.IsEnglish(language) {
+		return english
+	}
+	if settings := s.firmSettings(); settings != nil {
+		for tag, messages := range settings.Translations {
+			if i18n.Base(tag) == i18n.Base(language) && messages[key] != "" {
+				return messages[key]
+			}
+		}
+	}
+	if s.services != nil && english == builtinEnglish()[key] {
+		if text, ok := s.services.Messages.Lookup(language, key); ok {
+			return text
+		}
+	}
+	s.logger.Debug().Str("language", language).Str("message", string(key)).Msg("No translation, speaking English")
+	return english
+}
+
+// applyVoice switches TTS to the voice for the call's language: the firm's,
+// then TTS_VOICES, then the provider's default voice
+func (s *CallSession) applyVoice() {
+	setter, ok := s.ttsClient.(tts.VoiceSetter)
+	if !ok || s.config == nil {
+		return
+	}
+	language := s.callLanguage()
+	voice, found := "", false
+	if settings := s.firmSettings(); settings != nil {
+		voice, found = i18n.NewVoices(settings.Voices).Voice(language)
+	}
+	if !found && s.services != nil {
+		voice, found = s.services.Voices.Voice(language)
+	}
+	if !found {
+		setter.SetVoice(tts.DefaultVoice(s.ttsConfig()), "")
+		return
+	}
+	setter.SetVoice(voice, i18n.Base(language))
+	s.logger.Info().Str("language", language).Str("voice", voice).Msg("Using the voice for the call's language")
+}
+
+// noteDetectedLanguage records the language STT heard, switching gateway
+// speech and the TTS voice when it changes
+func (s *CallSession) noteDetectedLanguage(language string) {
+	if i18n.Base(language) == "" {
+		return
+	}
+	s.mu.Lock()
+	changed := i18n.Base(language) != i18n.Base(s.detectedLanguage)
+	s.detectedLanguage = language
+	s.mu.Unlock()
+	if changed {
+		s.logger.Info().Str("language", language).Msg("Caller language detected")
+		s.applyVoice()
+	}
+}
@@ -0,0 +1,168 @@
+package telephony
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// voiceTTS records the voice the session selects
+type voiceTTS struct {
+	voice, language string
+}
+
+func (v *voiceTTS) Enqueue(string) (<-chan *tts.AudioChunk, error) { return nil, nil }
+func (v *voiceTTS) Flush(context.Context) error                    { return nil }
+func (v *voiceTTS) CancelAll()                                     {}
+func (v *voiceTTS) Close() error                                   { return nil }
+func (v *voiceTTS) IsActive() bool                                 { return false }
+func (v *voiceTTS) SetVoice(voice, language string)                { v.voice, v.language = voice, language }
+
+func newLanguageTestSession(t *testing.T, firms string) *CallSession {
+	t.Helper()
+	registry := firm.NewRegistry()
+	if firms != "" {
+		path := filepath.Join(t.TempDir(), "firms.json")
+		if err := os.WriteFile(path, []byte(firms), 0o600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		var err error
+		if registry, err = firm.LoadRegistry(path); err != nil {
+			t.Fatalf("LoadRegistry failed: %v", err)
+		}
+	}
+
+	voices, _ := i18n.ParseVoices([]string{"es:voice-es"})
+	s := newSupervisorTestSession()
+	s.config = &config.Config{DeepgramLanguage: "en", CartesiaVoiceID: "voice-en"}
+	s.services = &Services{Firms: registry, Messages: i18n.Builtin(), Voices: voices}
+	s.firmID = "acme"
+	return s
+}
+
+func TestCallSession_Localize(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"translations": {"es": {"disclaimer": "Aviso legal de la firma."}}}}}`)
+	english := "Sorry, I didn't quite catch that. Could you repeat that?"
+
+	if got := s.localize(i18n.Clarification, english); got != english {
+		t.Errorf("Expected English on an English call, got %q", got)
+	}
+
+	s.language = "es-MX"
+	want, _ := i18n.Builtin().Lookup("es", i18n.Clarification)
+	if got := s.localize(i18n.Clarification, english); got != want {
+		t.Errorf("Expected the catalog's Spanish prompt, got %q", got)
+	}
+	if got := s.localize(i18n.Disclaimer, "Legal disclaimer."); got != "Aviso legal de la firma." {
+		t.Errorf("Expected the firm's Spanish disclaimer, got %q", got)
+	}
+	if got := s.localize(i18n.Clarification, ""); got != "" {
+		t.Errorf("Expected a disabled message to stay disabled, got %q", got)
+	}
+
+	s.language = "de"
+	if got := s.localize(i18n.Clarification, english); got != english {
+		t.Errorf("Expected English without a German translation, got %q", got)
+	}
+}
+
+func TestCallSession_LocalizeKeepsCustomEnglish(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"translations": {"es": {"budget_message": "La firma le devolverá la llamada hoy."}}}}}`)
+	s.language = "es"
+
+	custom := "Please hold while I find someone from the Smith & Jones intake team."
+	if got := s.localize(i18n.Clarification, custom); got != custom {
+		t.Errorf("Expected the firm's own English over the catalog's translation of the default, got %q", got)
+	}
+	if got := s.localize(i18n.BudgetMessage, "We'll call you back today."); got != "La firma le devolverá la llamada hoy." {
+		t.Errorf("Expected the firm's translation of its own English, got %q", got)
+	}
+	want, _ := i18n.Builtin().Lookup("es", i18n.InactivityPrompt)
+	if got := s.localize(i18n.InactivityPrompt, "Are you still there?"); got != want {
+		t.Errorf("Expected the catalog for the built-in English, got %q", got)
+	}
+}
+
+func TestBuiltinEnglish_CoversTheCatalog(t *testing.T) {
+	catalog := i18n.Builtin()
+	for _, key := range []i18n.Key{
+		i18n.StallFallback, i18n.Clarification, i18n.ChallengePrompt, i18n.Disclaimer, i18n.ConsentPrompt,
+		i18n.ConsentDeclined, i18n.EscalationOffer, i18n.TransferFailed, i18n.InactivityPrompt,
+		i18n.InactivityGoodbye, i18n.MaxDurationGoodbye, i18n.BudgetMessage, i18n.MissingFirm,
+		i18n.VoicemailGreeting, i18n.ToolProgress, i18n.ConfirmationPrompt, i18n.ConfirmationRepeat,
+		i18n.AIDisclosure, i18n.ResponseContinue, i18n.VerificationCodePrompt, i18n.VerificationPINPrompt,
+		i18n.VerificationRetry,
+	} {
+		if _, translated := catalog.Lookup("es", key); translated && builtinEnglish()[key] == "" {
+			t.Errorf("No built-in English for %s, so its catalog translations are never used", key)
+		}
+	}
+}
+
+func TestCallSession_ApplyVoice(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"voices": {"fr": "firm-voice-fr"}}}}`)
+	voice := &voiceTTS{}
+	s.ttsClient = voice
+
+	s.applyVoice()
+	if voice.voice != "voice-en" || voice.language != "" {
+		t.Errorf("Expected the default voice, got %q (%q)", voice.voice, voice.language)
+	}
+
+	s.noteDetectedLanguage("es")
+	if voice.voice != "voice-es" || voice.language != "es" {
+		t.Errorf("Expected the Spanish voice, got %q (%q)", voice.voice, voice.language)
+	}
+	if s.callLanguage() != "es" {
+		t.Errorf("Expected the detected language to win, got %q", s.callLanguage())
+	}
+
+	s.noteDetectedLanguage("fr-FR")
+	if voice.voice != "firm-voice-fr" || voice.language != "fr" {
+		t.Errorf("Expected the firm's French voice, got %q (%q)", voice.voice, voice.language)
+	}
+}
+
+func TestCallSession_SynthesizeUsesFirmPronunciations(t *testing.T) {
+	s := newLanguageTestSession(t, `{
+		"defaults": {"pronunciations": {"voir dire": {"say": "vwahr deer"}}},
+		"firms": {"acme": {"pronunciations": {"Nguyen": {"ipa": "wɪn"}}}}
+	}`)
+	phrases := &phraseTTS{}
+	s.ttsClient = phrases
+	s.useFirmLexicon(s.firmSettings())
+
+	if _, err := s.synthesize("Ms. Nguyen handles voir dire."); err != nil {
+		t.Fatalf("synthesize failed: %v", err)
+	}
+	expected := `Ms. <phoneme alphabet="ipa" ph="wɪn">Nguyen</phoneme> handles vwahr deer.`
+	if len(phrases.texts) != 1 || phrases.texts[0] != expected {
+		t.Errorf("Expected the firm's and the default pronunciations, got %q", phrases.texts)
+	}
+}
+
+func TestCallSession_SynthesizeVerbalizesEnglish(t *testing.T) {
+	s := newLanguageTestSession(t, `{}`)
+	phrases := &phraseTTS{}
+	s.ttsClient = phrases
+	s.verbalizer, _ = tts.NewVerbalizer(tts.LocaleUS)
+
+	if _, err := s.synthesize("The fee is $1,500."); err != nil {
+		t.Fatalf("synthesize failed: %v", err)
+	}
+	s.noteDetectedLanguage("es")
+	if _, err := s.synthesize("La tarifa es $1,500."); err != nil {
+		t.Fatalf("synthesize failed: %v", err)
+	}
+
+	expected := []string{"The fee is one thousand five hundred dollars.", "La tarifa es $1,500."}
+	if len(phrases.texts) != 2 || phrases.texts[0] != expected[0] || phrases.texts[1] != expected[1] {
+		t.Errorf("Expected English numbers spelled out and Spanish left alone, got %q", phrases.texts)
+	}
+}
@@ -0,0 +1,133 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// limitAction is what the call-limit monitor should do next
+type limitAction int
+
+const (
+	limitNone limitAction = iota
+	limitPrompt
+	limitHangupInactive
+	limitHangupMaxDuration
+)
+
+// callLimits bounds call length and idle time; zero durations disable a limit
+type callLimits struct {
+	maxDuration time.Duration
+	idleTimeout time.Duration
+	grace       time.Duration // Time to respond to the "are you still there?" prompt
+}
+
+// check decides the next action from the call's activity timestamps
+// lastActivity covers caller speech and agent output; promptedAt is zero
+// unless the caller has been prompted and has not spoken since
+func (l callLimits) check(now, startedAt, lastActivity, promptedAt time.Time) limitAction {
+	if l.maxDuration > 0 && now.Sub(startedAt) >= l.maxDuration {
+		return limitHangupMaxDuration
+	}
+	if l.idleTimeout <= 0 {
+		return limitNone
+	}
+	if !promptedAt.IsZero() {
+		if now.Sub(promptedAt) >= l.grace {
+			return limitHangupInactive
+		}
+		return limitNone
+	}
+	if now.Sub(lastActivity) >= l.idleTimeout {
+		return limitPrompt
+	}
+	return limitNone
+}
+
+// touchCallerActivity records caller speech, cancelling any pending inactivity prompt
+func (s *CallSession) touchCallerActivity() {
+	s.activityMu.Lock()
+	s.lastActivity = time.Now()
+	s.promptedAt = time.Time{}
+	s.activityMu.Unlock()
+}
+
+// touchAgentActivity records audio played to the caller
+func (s *CallSession) touchAgentActivity() {
+	s.activityMu.Lock()
+	s.lastActivity = time.Now()
+	s.activityMu.Unlock()
+}
+
+// monitorCallLimits enforces the maximum call length and hangs up idle calls
+func (s *CallSession) monitorCallLimits() {
+	limits := callLimits{
+		maxDuration: time.Duration(s.config.MaxCallDurationSeconds) * time.Second,
+		idleTimeout: time.Duration(s.config.InactivityTimeoutSeconds) * time.Second,
+		grace:       time.Duration(s.config.InactivityGraceSeconds) * time.Second,
+	}
+	if limits.maxDuration <= 0 && limits.idleTimeout <= 0 {
+		return
+	}
+
+	startedAt := time.Now()
+	s.touchAgentActivity()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.activityMu.Lock()
+			lastActivity, promptedAt := s.lastActivity, s.promptedAt
+			s.activityMu.Unlock()
+
+			// Voicemail has its own length cap and is silent by design
+			action := limits.check(now, startedAt, lastActivity, promptedAt)
+			if s.inVoicemail() != nil && action != limitHangupMaxDuration {
+				continue
+			}
+
+			switch action {
+			case limitPrompt:
+				s.logger.Info().Dur("idle", now.Sub(lastActivity)).Msg("Call inactive, prompting caller")
+				s.activityMu.Lock()
+				s.promptedAt = now
+				s.activityMu.Unlock()
+				if err := s.speak(s.localize(i18n.InactivityPrompt, s.config.InactivityPrompt)); err != nil {
+					s.logger.Warn().Err(err).Msg("Failed to play inactivity prompt")
+				}
+
+			case limitHangupInactive:
+				s.endCallWithGoodbye("inactivity", s.localize(i18n.InactivityGoodbye, s.config.InactivityGoodbye))
+				return
+
+			case limitHangupMaxDuration:
+				s.endCallWithGoodbye("max_duration", s.localize(i18n.MaxDurationGoodbye, s.config.MaxDurationGoodbye))
+				return
+			}
+
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// endCallWithGoodbye plays a closing message, then hangs up
+func (s *CallSession) endCallWithGoodbye(reason, goodbye string) {
+	s.logger.Info().Str("reason", reason).Msg("Call limit reached, hanging up")
+	observability.RecordLimitHangup(reason)
+	s.cdr.Update(func(r *cdr.Record) { r.Disposition = reason })
+
+	if goodbye != "" {
+		if err := s.speak(goodbye); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to play goodbye")
+		}
+		s.waitForPlayback(hangupDrainTimeout)
+	}
+	s.hangup(reason)
+}
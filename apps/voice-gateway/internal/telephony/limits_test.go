@@ -0,0 +1,44 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCallLimits_Check(t *testing.T) {
+	limits := callLimits{
+		maxDuration: 10 * time.Minute,
+		idleTimeout: 30 * time.Second,
+		grace:       10 * time.Second,
+	}
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		lastActivity time.Time
+		promptedAt   time.Time
+		want         limitAction
+	}{
+		{"active call", start.Add(time.Minute), start.Add(50 * time.Second), time.Time{}, limitNone},
+		{"idle past timeout", start.Add(time.Minute), start.Add(25 * time.Second), time.Time{}, limitPrompt},
+		{"prompted within grace", start.Add(time.Minute), start.Add(25 * time.Second), start.Add(55 * time.Second), limitNone},
+		{"prompted past grace", start.Add(time.Minute), start.Add(25 * time.Second), start.Add(45 * time.Second), limitHangupInactive},
+		{"max duration", start.Add(10 * time.Minute), start.Add(10 * time.Minute), time.Time{}, limitHangupMaxDuration},
+	}
+
+	for _, tt := range tests {
+		if got := limits.check(tt.now, start, tt.lastActivity, tt.promptedAt); got != tt.want {
+			t.Errorf("%s: expected action %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCallLimits_Disabled(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(24 * time.Hour)
+
+	if got := (callLimits{}).check(now, start, start, time.Time{}); got != limitNone {
+		t.Errorf("Expected no action with limits disabled, got %d", got)
+	}
+}
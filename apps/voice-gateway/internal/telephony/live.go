@@ -0,0 +1,51 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+)
+
+// openLiveFeed makes the call visible to supervisors
+func (s *CallSession) openLiveFeed(callSid string) {
+	if s.services == nil || s.services.Live == nil || callSid == "" {
+		return
+	}
+	s.services.Live.Open(callSid)
+	s.publishLive(live.Event{Type: live.TypeCallStarted})
+}
+
+// closeLiveFeed announces the end of the call and disconnects supervisors
+func (s *CallSession) closeLiveFeed(disposition string) {
+	callSid := s.GetCallSid()
+	if s.services == nil || s.services.Live == nil || callSid == "" {
+		return
+	}
+	s.publishLive(live.Event{Type: live.TypeCallEnded, Reason: disposition})
+	s.services.Live.Close(callSid)
+}
+
+// publishLive sends an event to supervisors watching the call, and keeps
+// final speech for the call transcript
+func (s *CallSession) publishLive(event live.Event) {
+	if event.Type == live.TypeTranscript && event.Final {
+		s.transcript.add(event.Speaker, event.Text, event.Spoken)
+	}
+	if s.services == nil || s.services.Live == nil {
+		return
+	}
+	s.services.Live.Publish(s.GetCallSid(), event)
+}
+
+// liveWords converts caller speech's words for supervisors. Untimed words add
+// nothing to the text, and a code being collected for verify_caller is never
+// shown, word by word or otherwise
+func (s *CallSession) liveWords(words []stt.Word) []live.Word {
+	if len(words) == 0 || words[len(words)-1].End == 0 || s.pendingVerification() != nil {
+		return nil
+	}
+	converted := make([]live.Word, len(words))
+	for i, w := range words {
+		converted[i] = live.Word{Text: w.Text, Start: w.Start, End: w.End, Confidence: w.Confidence}
+	}
+	return converted
+}
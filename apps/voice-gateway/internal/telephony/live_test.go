@@ -0,0 +1,26 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/stt"
+)
+
+func TestLiveWords(t *testing.T) {
+	s := newSupervisorTestSession()
+	words := []stt.Word{{Text: "My", Start: 61.2, End: 61.4, Confidence: 0.99}, {Text: "name", Start: 61.4, End: 61.7, Confidence: 0.97}}
+
+	got := s.liveWords(words)
+	if len(got) != 2 || got[1].Text != "name" || got[1].Start != 61.4 || got[1].End != 61.7 || got[1].Confidence != 0.97 {
+		t.Errorf("Expected the words with their timing, got %+v", got)
+	}
+	if got := s.liveWords([]stt.Word{{Text: "hello"}}); got != nil {
+		t.Errorf("Expected untimed words left out, got %+v", got)
+	}
+
+	// The words of a code being collected are redacted along with the text
+	s.verification = &verificationState{}
+	if got := s.liveWords(words); got != nil {
+		t.Errorf("Expected no words while verifying the caller, got %+v", got)
+	}
+}
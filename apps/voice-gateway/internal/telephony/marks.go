@@ -0,0 +1,111 @@
+package telephony
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Utterance kinds named in marks
+const (
+	markPrompt   = "prompt"   // Gateway-generated speech (greetings, prompts)
+	markResponse = "response" // Orchestrator responses
+)
+
+// playbackMarks tracks the marks sent after each utterance until Twilio echoes
+// them back, which it does once the audio ahead of the mark has played
+type playbackMarks struct {
+	mu      sync.Mutex
+	next    int
+	pending map[string]time.Time // Mark name to when the utterance finished queueing
+}
+
+// add registers a new mark for an utterance of kind and returns its name
+func (m *playbackMarks) add(kind string, now time.Time) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending == nil {
+		m.pending = make(map[string]time.Time)
+	}
+	m.next++
+	name := fmt.Sprintf("%s-%d", kind, m.next)
+	m.pending[name] = now
+	return name
+}
+
+// ack removes an acknowledged mark, returning when it was added
+func (m *playbackMarks) ack(name string) (time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sentAt, ok := m.pending[name]
+	delete(m.pending, name)
+	return sentAt, ok
+}
+
+// pendingCount returns how many utterances the caller has yet to hear
+func (m *playbackMarks) pendingCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.pending)
+}
+
+// markUtterance follows an utterance's audio with a mark; call it once every
+// chunk has been queued on audioOut
+func (s *CallSession) markUtterance(kind string) {
+	name := s.marks.add(kind, time.Now())
+	select {
+	case s.markOut <- name:
+	default:
+		// Without its mark the utterance would hold off waitForPlayback until timeout
+		s.marks.ack(name)
+		s.logger.Warn().Str("mark", name).Msg("Mark queue full, dropping mark")
+	}
+}
+
+// drainAudioOut sends the audio already queued on audioOut
+func (s *CallSession) drainAudioOut() {
+	for {
+		select {
+		case audioChunk := <-s.audioOut:
+			s.sendOutgoingChunk(audioChunk)
+		default:
+			return
+		}
+	}
+}
+
+// sendMark queues a mark message behind the audio already sent
+func (s *CallSession) sendMark(name string) {
+	s.mu.RLock()
+	streamSid := s.streamSid
+	s.mu.RUnlock()
+
+	markMsg := map[string]interface{}{
+		"event":     "mark",
+		"streamSid": streamSid,
+		"mark": map[string]interface{}{
+			"name": name,
+		},
+	}
+	if err := s.writer.enqueue(outboundMark, markMsg); err != nil {
+		s.marks.ack(name)
+		s.logger.Warn().Err(err).Str("mark", name).Msg("Failed to queue mark")
+	}
+}
+
+// handleMark processes Twilio's echo of a mark: the caller has now heard
+// (or Twilio has cleared) the audio sent before it
+func (s *CallSession) handleMark(name string) {
+	sentAt, ok := s.marks.ack(name)
+	if !ok {
+		return
+	}
+	kind, _, _ := strings.Cut(name, "-")
+	observability.RecordPlaybackMark(kind, time.Since(sentAt))
+
+	// Idle time counts from when the caller finished hearing the agent
+	s.touchAgentActivity()
+}
@@ -0,0 +1,56 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+)
+
+func TestCallSession_MarkFollowsUtterance(t *testing.T) {
+	conn := &fakeTwilioConn{}
+	s := newSupervisorTestSession()
+	s.isActive = true
+	s.streamSid = "MZ1"
+	s.markOut = make(chan string, 1)
+	s.audioOutBuffer = audio.NewRingBuffer(1024)
+	s.writer = newTwilioWriter(conn, nil)
+	s.outbound = s.newOutboundPipeline()
+
+	s.audioOut <- []byte{0x7f, 0x7f}
+	s.audioOut <- []byte{0xff, 0xff}
+	s.markUtterance(markPrompt)
+	if s.marks.pendingCount() != 1 {
+		t.Fatalf("Expected 1 pending mark, got %d", s.marks.pendingCount())
+	}
+
+	// As processOutgoingAudio does on receiving the mark
+	name := <-s.markOut
+	s.drainAudioOut()
+	s.sendMark(name)
+
+	go s.writer.run(s.done)
+	defer close(s.done)
+	deadline := time.Now().Add(time.Second)
+	for len(conn.messages()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	written := conn.messages()
+	if len(written) != 3 {
+		t.Fatalf("Expected 2 media messages and a mark, got %d messages", len(written))
+	}
+	for i, want := range []string{"media", "media", "mark"} {
+		if got := written[i].(map[string]interface{})["event"]; got != want {
+			t.Errorf("Expected message %d to be %s, got %v", i, want, got)
+		}
+	}
+
+	s.handleMark("prompt-unknown")
+	if s.marks.pendingCount() != 1 {
+		t.Error("Expected an unknown mark to be ignored")
+	}
+	s.handleMark(name)
+	if s.marks.pendingCount() != 0 {
+		t.Errorf("Expected no pending marks after the ack, got %d", s.marks.pendingCount())
+	}
+}
@@ -0,0 +1,133 @@
+package telephony
+
+import (
+	"bytes"
+	"io"
+)
+
+// maxReadBuffer caps the read buffer kept between messages; media events are
+// well under 1KB, so only an unusually large message grows past it
+const maxReadBuffer = 64 * 1024
+
+var (
+	mediaEventMarker = []byte(`"event":"media"`)
+	mediaObjectKey   = []byte(`"media":{`)
+	payloadField     = []byte(`"payload":"`)
+	trackField       = []byte(`"track":"`)
+	timestampField   = []byte(`"timestamp":"`)
+)
+
+// mediaFields are a media event's fields, pointing into the message they were read from
+type mediaFields struct {
+	track     []byte
+	timestamp []byte
+	payload   []byte
+}
+
+// parseMediaEvent reads a media event without unmarshalling it. Media events
+// arrive 50 times a second per call, so this skips the allocations of
+// json.Unmarshal. ok is false for other events and for anything it cannot
+// read cheaply (e.g. whitespace or escaped strings), which take the full path
+func parseMediaEvent(message []byte) (mediaFields, bool) {
+	if !bytes.Contains(message, mediaEventMarker) {
+		return mediaFields{}, false
+	}
+	start := bytes.Index(message, mediaObjectKey)
+	if start < 0 {
+		return mediaFields{}, false
+	}
+	object := message[start+len(mediaObjectKey):]
+	if end := bytes.IndexByte(object, '}'); end >= 0 {
+		object = object[:end]
+	} else {
+		return mediaFields{}, false
+	}
+
+	var media mediaFields
+	var ok bool
+	if media.payload, ok = jsonStringField(object, payloadField); !ok || len(media.payload) == 0 {
+		return mediaFields{}, false
+	}
+	if media.track, ok = jsonStringField(object, trackField); !ok && media.track != nil {
+		return mediaFields{}, false
+	}
+	if media.timestamp, ok = jsonStringField(object, timestampField); !ok && media.timestamp != nil {
+		return mediaFields{}, false
+	}
+	return media, true
+}
+
+// jsonStringField finds field (`"key":"`) in a flat JSON object and returns
+// its string value. A missing key returns nil, false; a value with escapes
+// returns non-nil, false
+func jsonStringField(object, field []byte) ([]byte, bool) {
+	i := 0
+	for {
+		idx := bytes.Index(object[i:], field)
+		if idx < 0 {
+			return nil, false
+		}
+		// Make sure this is a key, not the tail of a longer one
+		at := i + idx
+		if at == 0 || object[at-1] == '{' || object[at-1] == ',' {
+			value := object[at+len(field):]
+			end := bytes.IndexByte(value, '"')
+			if end < 0 {
+				return []byte{}, false
+			}
+			if bytes.IndexByte(value[:end], '\\') >= 0 {
+				return []byte{}, false
+			}
+			return value[:end], true
+		}
+		i = at + 1
+	}
+}
+
+// trackName returns the track as a string, without allocating for Twilio's own track names
+func trackName(track []byte) string {
+	switch string(track) {
+	case TrackInbound:
+		return TrackInbound
+	case TrackOutbound:
+		return TrackOutbound
+	}
+	return string(track)
+}
+
+// readMessage reads the next WebSocket message into the session's read
+// buffer; the message is only valid until the next call
+func (s *CallSession) readMessage() ([]byte, error) {
+	if len(s.pending) > 0 {
+		message := s.pending[0]
+		s.pending = s.pending[1:]
+		return message, nil
+	}
+
+	_, r, err := s.conn.NextReader()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := s.readBuf[:0]
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cap(buf) <= maxReadBuffer {
+		s.readBuf = buf
+	} else {
+		s.readBuf = nil
+	}
+	return buf, nil
+}
@@ -0,0 +1,66 @@
+package telephony
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const twilioMediaMessage = `{"event":"media","sequenceNumber":"4","media":{"track":"inbound","chunk":"2","timestamp":"40","payload":"//7+/n5+fv7+/v5+fn7+"},"streamSid":"MZ18ad3ab5a668481ce02b83e7395059f0"}`
+
+func TestParseMediaEvent(t *testing.T) {
+	media, ok := parseMediaEvent([]byte(twilioMediaMessage))
+	if !ok {
+		t.Fatal("Expected a Twilio media event to take the fast path")
+	}
+	if string(media.track) != "inbound" || string(media.timestamp) != "40" || string(media.payload) != "//7+/n5+fv7+/v5+fn7+" {
+		t.Errorf("Unexpected fields: track=%q timestamp=%q payload=%q", media.track, media.timestamp, media.payload)
+	}
+
+	// The full decoder agrees
+	var msg TwilioMessage
+	if err := json.Unmarshal([]byte(twilioMediaMessage), &msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if msg.Media.Payload != string(media.payload) {
+		t.Errorf("Expected payload %q, got %q", msg.Media.Payload, media.payload)
+	}
+
+	for name, message := range map[string]string{
+		"other event":     `{"event":"mark","streamSid":"MZ1","mark":{"name":"prompt-1"}}`,
+		"escaped payload": `{"event":"media","media":{"track":"inbound","payload":"\/\/7+"}}`,
+		"spaced JSON":     `{"event": "media", "media": {"payload": "//7+"}}`,
+		"no payload":      `{"event":"media","media":{"track":"inbound","chunk":"1"}}`,
+	} {
+		if _, ok := parseMediaEvent([]byte(message)); ok {
+			t.Errorf("%s: expected the full JSON path", name)
+		}
+	}
+}
+
+func TestParseMediaEvent_KeyBoundary(t *testing.T) {
+	media, ok := parseMediaEvent([]byte(`{"event":"media","media":{"xpayload":"AAAA","payload":"//7+"}}`))
+	if !ok || string(media.payload) != "//7+" {
+		t.Errorf("Expected the payload key rather than a key ending in payload, got %q", media.payload)
+	}
+}
+
+func BenchmarkMediaEvent_Unmarshal(b *testing.B) {
+	message := []byte(twilioMediaMessage)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var msg TwilioMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMediaEvent_FastPath(b *testing.B) {
+	message := []byte(twilioMediaMessage)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, ok := parseMediaEvent(message); !ok {
+			b.Fatal("fast path rejected a media event")
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package telephony
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+)
+
+// streamOpener starts one orchestrator response stream for the current turn
+type streamOpener func(ctx context.Context) (<-chan *orchestrator.OrchestratorResponse, error)
+
+// streamOutcome is how an orchestrator response stream ended
+type streamOutcome int
+
+const (
+	streamDone       streamOutcome = iota // The orchestrator sent is_done
+	streamClosed                          // The stream ended early, or the call did
+	streamStalled                         // No message arrived within the stall timeout
+	streamSuperseded                      // The caller spoke again, starting a newer turn
+)
+
+// runOrchestratorTurn streams one turn's responses under a watchdog
+// A stream that goes quiet without closing is cancelled and retried once;
+// if that stalls too, or the first attempt already spoke, the caller hears
+// the fallback phrase instead of silence
+func (s *CallSession) runOrchestratorTurn(open streamOpener, conversationID string, turn *orchestratorTurn) {
+	defer s.finishTurn(turn)
+	stallTimeout := time.Duration(s.config.OrchestratorStallTimeoutSeconds) * time.Second
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithCancel(turn.ctx)
+		start := time.Now()
+		responseChan, err := open(ctx)
+		if err != nil {
+			cancel()
+			if turn.ctx.Err() != nil {
+				return // Superseded before the stream opened
+			}
+			s.logger.Error().Err(err).Msg("Error sending transcription to Orchestrator")
+			s.technicalFailures.Add(1)
+			if s.metrics != nil {
+				s.metrics.RecordOrchestratorEnd(false)
+				s.metrics.RecordError("orchestrator_send_error", "orchestrator")
+			}
+			return
+		}
+
+		outcome, spoke := s.consumeOrchestratorStream(responseChan, start, stallTimeout, conversationID, turn)
+		cancel()
+		if outcome == streamSuperseded {
+			s.logger.Info().Uint64("turn", turn.id).Msg("Orchestrator turn superseded, stream cancelled")
+			return
+		}
+		if outcome != streamStalled {
+			return
+		}
+
+		if attempt == 1 && !spoke {
+			s.logger.Warn().
+				Dur("stall_timeout", stallTimeout).
+				Msg("Orchestrator stream stalled, retrying")
+			observability.RecordOrchestratorStall("retried")
+			continue
+		}
+
+		s.logger.Error().
+			Int("attempt", attempt).
+			Bool("partial_response", spoke).
+			Msg("Orchestrator stream stalled, playing fallback")
+		observability.RecordOrchestratorStall("fallback")
+		s.technicalFailures.Add(1)
+		if s.metrics != nil {
+			s.metrics.RecordOrchestratorEnd(false)
+			s.metrics.RecordError("orchestrator_stalled", "orchestrator")
+		}
+		if s.config.OrchestratorStallFallback != "" && !s.aiPaused() {
+			if err := s.speak(s.localize(i18n.StallFallback, s.config.OrchestratorStallFallback)); err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to play stall fallback")
+			}
+		}
+		s.publishLive(live.Event{Type: live.TypeTurnCompleted, Speaker: live.SpeakerAgent, Reason: "stalled"})
+		return
+	}
+}
+
+// consumeOrchestratorStream handles responses until the stream finishes or the
+// watchdog fires; spoke reports whether any text was queued for TTS
+// The time from start to the first response feeds the admission limiter
+// The watchdog is held off while telephony tools run, since the orchestrator
+// legitimately waits on their results (e.g. collecting keypad digits)
+// Tools the orchestrator runs itself get a progress phrase if they run long
+func (s *CallSession) consumeOrchestratorStream(responseChan <-chan *orchestrator.OrchestratorResponse, start time.Time, stallTimeout time.Duration, conversationID string, turn *orchestratorTurn) (outcome streamOutcome, spoke bool) {
+	var stalled <-chan time.Time
+	var watchdog *time.Timer
+	if stallTimeout > 0 {
+		watchdog = time.NewTimer(stallTimeout)
+		defer watchdog.Stop()
+		stalled = watchdog.C
+	}
+	progress := s.newToolProgress()
+	defer progress.close()
+
+	first := true
+	for {
+		select {
+		case response, ok := <-responseChan:
+			if !ok {
+				return streamClosed, spoke
+			}
+			if first {
+				first = false
+				s.observeLatency(signalOrchestrator, time.Since(start))
+			}
+			if watchdog != nil {
+				if !watchdog.Stop() {
+					<-watchdog.C
+				}
+				watchdog.Reset(stallTimeout)
+			}
+			if response.TextChunk != "" {
+				spoke = true
+			}
+			progress.observe(response, spoke)
+			if s.handleOrchestratorResponse(response, conversationID, turn) {
+				return streamDone, spoke
+			}
+
+		case <-stalled:
+			if s.toolsInFlight.Load() > 0 {
+				watchdog.Reset(stallTimeout)
+				continue
+			}
+			return streamStalled, spoke
+
+		case <-progress.due():
+			s.speakToolProgress(progress)
+
+		case <-turn.ctx.Done():
+			return streamSuperseded, spoke
+
+		case <-s.done:
+			return streamClosed, spoke
+		}
+	}
+}
+
+// handleOrchestratorResponse routes one streamed response; returns true once
+// the orchestrator marks the turn done
+func (s *CallSession) handleOrchestratorResponse(response *orchestrator.OrchestratorResponse, conversationID string, turn *orchestratorTurn) bool {
+	if response.Error != nil {
+		s.logger.Error().
+			Str("code", response.Error.Code).
+			Str("message", response.Error.Message).
+			Msg("Orchestrator error")
+		if s.metrics != nil {
+			s.metrics.RecordError("orchestrator_error", "orchestrator")
+		}
+		return false
+	}
+
+	// Queue text chunks for TTS, unless the caller has spoken again since
+	if response.TextChunk != "" && s.superseded(turn.id) {
+		observability.RecordOrchestratorChunkDropped(chunkSuperseded)
+		s.logger.Debug().
+			Str("chunk", response.TextChunk).
+			Msg("Dropping Orchestrator response to a superseded turn")
+	} else if response.TextChunk != "" {
+		turn.chunks++
+		turn.characters += utf8.RuneCountInString(response.TextChunk)
+		select {
+		case s.orchestratorResponseQueue <- responseChunk{turn: turn.id, seq: turn.chunks, text: response.TextChunk}:
+			s.logger.Debug().
+				Str("chunk", response.TextChunk).
+				Msg("Queued Orchestrator response for TTS")
+		default:
+			s.logger.Warn().
+				Str("chunk", response.TextChunk).
+				Msg("Orchestrator response queue full, dropping")
+		}
+	}
+
+	// Execute telephony tools; log the rest for observability
+	if response.ToolCall != nil {
+		s.logger.Info().
+			Str("tool_name", response.ToolCall.ToolName).
+			Str("call_id", response.ToolCall.CallID).
+			Msg("Orchestrator tool call")
+		s.publishLive(live.Event{Type: live.TypeToolCall, ToolName: response.ToolCall.ToolName})
+		if isTelephonyTool(response.ToolCall.ToolName) {
+			s.toolsInFlight.Add(1)
+			call := response.ToolCall
+			s.goSafe("tool_call", func() {
+				defer s.toolsInFlight.Add(-1)
+				s.executeTool(call)
+			})
+		}
+	}
+	if response.ToolResult != nil {
+		s.logger.Info().
+			Str("call_id", response.ToolResult.CallID).
+			Bool("success", response.ToolResult.Success).
+			Msg("Orchestrator tool result")
+	}
+
+	if response.IsDone {
+		s.meterTokens(response.TotalTokens)
+		s.cdr.Update(func(r *cdr.Record) { r.AITurns++ })
+		s.logger.Info().
+			Str("conversation_id", conversationID).
+			Msg("Orchestrator response stream completed")
+		if s.metrics != nil {
+			s.metrics.RecordOrchestratorEnd(true)
+			s.metrics.RecordTurn(int(response.TotalTokens), turn.characters)
+		}
+		s.publishLive(live.Event{Type: live.TypeTurnCompleted, Speaker: live.SpeakerAgent})
+		return true
+	}
+	return false
+}
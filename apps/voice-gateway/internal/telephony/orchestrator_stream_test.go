@@ -0,0 +1,112 @@
+package telephony
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+)
+
+func newStreamTestSession() *CallSession {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{OrchestratorStallTimeoutSeconds: 1}
+	s.orchestratorResponseQueue = make(chan responseChunk, 8)
+	return s
+}
+
+// scriptedOpener returns streams that send the responses the script gives for
+// each attempt, then either close or go quiet until cancelled
+func scriptedOpener(attempts *int, script func(attempt int) ([]*orchestrator.OrchestratorResponse, bool)) streamOpener {
+	return func(ctx context.Context) (<-chan *orchestrator.OrchestratorResponse, error) {
+		*attempts++
+		responses, stall := script(*attempts)
+		ch := make(chan *orchestrator.OrchestratorResponse, len(responses))
+		for _, r := range responses {
+			ch <- r
+		}
+		go func() {
+			defer close(ch)
+			if stall {
+				<-ctx.Done()
+			}
+		}()
+		return ch, nil
+	}
+}
+
+func TestRunOrchestratorTurn_RetriesStalledStream(t *testing.T) {
+	s := newStreamTestSession()
+	attempts := 0
+	open := scriptedOpener(&attempts, func(attempt int) ([]*orchestrator.OrchestratorResponse, bool) {
+		if attempt == 1 {
+			return nil, true
+		}
+		return []*orchestrator.OrchestratorResponse{{TextChunk: "Hello"}, {IsDone: true}}, false
+	})
+
+	s.runOrchestratorTurn(open, "conv-1", s.startTurn())
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	select {
+	case chunk := <-s.orchestratorResponseQueue:
+		if chunk.text != "Hello" {
+			t.Errorf("Expected retried response, got %q", chunk)
+		}
+	default:
+		t.Error("Expected the retried stream's response to be queued")
+	}
+}
+
+func TestRunOrchestratorTurn_NoRetryAfterPartialResponse(t *testing.T) {
+	s := newStreamTestSession()
+	attempts := 0
+	open := scriptedOpener(&attempts, func(int) ([]*orchestrator.OrchestratorResponse, bool) {
+		return []*orchestrator.OrchestratorResponse{{TextChunk: "Let me check"}}, true
+	})
+
+	start := time.Now()
+	s.runOrchestratorTurn(open, "conv-1", s.startTurn())
+
+	// Retrying would repeat what the caller already heard
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 1900*time.Millisecond {
+		t.Errorf("Expected the fallback after one stall timeout, took %v", elapsed)
+	}
+}
+
+func TestRunOrchestratorTurn_ClosedStreamNotRetried(t *testing.T) {
+	s := newStreamTestSession()
+	attempts := 0
+	open := scriptedOpener(&attempts, func(int) ([]*orchestrator.OrchestratorResponse, bool) {
+		return []*orchestrator.OrchestratorResponse{{TextChunk: "Hi"}, {IsDone: true}}, false
+	})
+
+	s.runOrchestratorTurn(open, "conv-1", s.startTurn())
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestConsumeOrchestratorStream_ToolsHoldOffWatchdog(t *testing.T) {
+	s := newStreamTestSession()
+	ch := make(chan *orchestrator.OrchestratorResponse)
+
+	s.toolsInFlight.Add(1)
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		s.toolsInFlight.Add(-1)
+		ch <- &orchestrator.OrchestratorResponse{IsDone: true}
+	}()
+
+	outcome, _ := s.consumeOrchestratorStream(ch, time.Now(), 50*time.Millisecond, "conv-1", s.startTurn())
+	if outcome != streamDone {
+		t.Errorf("Expected the stream to finish while a tool ran, got %v", outcome)
+	}
+}
@@ -0,0 +1,35 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+)
+
+// rejectedDispositions are calls refused before the AI took them
+var rejectedDispositions = map[string]bool{
+	"blocked":                    true,
+	"challenge_failed":           true,
+	"consent_declined":           true,
+	dispositionInvalidParameters: true,
+	dispositionUnattributed:      true,
+}
+
+// classifyOutcome places a finished call in the reporting funnel. failures
+// counts failures that cost the caller an answer (STT down, orchestrator
+// errors or stalls); they only fail calls the AI never answered
+func classifyOutcome(r *cdr.Record, failures int) string {
+	switch {
+	case r.Disposition == dispositionError || r.Error != "":
+		return cdr.OutcomeFailed
+	case r.Disposition == "transferred":
+		return cdr.OutcomeTransferred
+	case r.Disposition == "voicemail":
+		return cdr.OutcomeVoicemail
+	case rejectedDispositions[r.Disposition]:
+		return cdr.OutcomeRejected
+	case r.AITurns > 0:
+		return cdr.OutcomeAnsweredByAI
+	case failures > 0:
+		return cdr.OutcomeFailed
+	}
+	return cdr.OutcomeAbandoned
+}
@@ -0,0 +1,48 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+)
+
+func TestClassifyOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   cdr.Record
+		failures int
+		want     string
+	}{
+		{"answered", cdr.Record{Disposition: "completed", AITurns: 3}, 0, cdr.OutcomeAnsweredByAI},
+		{"answered despite a later stall", cdr.Record{Disposition: "completed", AITurns: 2}, 1, cdr.OutcomeAnsweredByAI},
+		{"limit after turns", cdr.Record{Disposition: "max_duration", AITurns: 9}, 0, cdr.OutcomeAnsweredByAI},
+		{"transferred", cdr.Record{Disposition: "transferred", AITurns: 2}, 0, cdr.OutcomeTransferred},
+		{"voicemail", cdr.Record{Disposition: "voicemail"}, 0, cdr.OutcomeVoicemail},
+		{"hung up in greeting", cdr.Record{Disposition: "completed"}, 0, cdr.OutcomeAbandoned},
+		{"silent caller", cdr.Record{Disposition: "inactivity"}, 0, cdr.OutcomeAbandoned},
+		{"orchestrator down", cdr.Record{Disposition: "completed"}, 2, cdr.OutcomeFailed},
+		{"panic", cdr.Record{Disposition: dispositionError, AITurns: 4, Error: "panic in tts"}, 0, cdr.OutcomeFailed},
+		{"blocked", cdr.Record{Disposition: "blocked"}, 0, cdr.OutcomeRejected},
+		{"unattributed", cdr.Record{Disposition: dispositionUnattributed}, 0, cdr.OutcomeRejected},
+		{"consent declined", cdr.Record{Disposition: "consent_declined"}, 0, cdr.OutcomeRejected},
+	}
+	for _, tt := range tests {
+		if got := classifyOutcome(&tt.record, tt.failures); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestHandleOrchestratorResponse_CountsAITurns(t *testing.T) {
+	s := newStreamTestSession()
+	turn := s.startTurn()
+
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{TextChunk: "Hello"}, "conv-1", turn)
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{IsDone: true}, "conv-1", turn)
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{IsDone: true}, "conv-1", turn)
+
+	if turns := s.cdr.Snapshot().AITurns; turns != 2 {
+		t.Errorf("Expected 2 AI turns, got %d", turns)
+	}
+}
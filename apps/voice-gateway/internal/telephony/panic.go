@@ -0,0 +1,35 @@
+package telephony
+
+import (
+	"fmt"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// dispositionError marks a call ended by a failure in the gateway
+const dispositionError = "error"
+
+// goSafe runs fn in a new goroutine; a panic there ends this call rather than the process
+func (s *CallSession) goSafe(name string, fn func()) {
+	go func() {
+		defer observability.RecoverPanic("telephony", name, func(recovered interface{}) {
+			s.endAfterPanic(name, recovered)
+		})
+		fn()
+	}()
+}
+
+// endAfterPanic records the failure in the CDR and hangs up. The stream
+// closing ends the session's other goroutines, and the CDR is emitted as
+// for any other call
+func (s *CallSession) endAfterPanic(goroutine string, recovered interface{}) {
+	s.logger.Error().Str("goroutine", goroutine).Msg("Ending call after panic")
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Disposition = dispositionError
+		if r.Error == "" {
+			r.Error = fmt.Sprintf("panic in %s: %v", goroutine, recovered)
+		}
+	})
+	s.hangup("panic")
+}
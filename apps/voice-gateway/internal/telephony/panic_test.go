@@ -0,0 +1,57 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestCallSession_PanicEndsOnlyTheCall(t *testing.T) {
+	serverConn := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := newUpgrader(&config.Config{}, nil).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		serverConn <- conn
+	}))
+	defer server.Close()
+
+	twilio, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer twilio.Close()
+
+	s := newSupervisorTestSession()
+	s.conn = <-serverConn
+	s.isActive = true
+
+	s.goSafe("incoming_audio", func() {
+		var frames map[string][]byte
+		frames["caller"] = nil
+	})
+
+	// The stream closes once the panic is recovered
+	_ = twilio.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := twilio.ReadMessage(); err == nil || strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("Expected the stream to close, got %v", err)
+	}
+
+	if s.IsActive() {
+		t.Error("Expected session to be inactive")
+	}
+	record := s.cdr.Snapshot()
+	if record.Disposition != dispositionError {
+		t.Errorf("Expected disposition %q, got %q", dispositionError, record.Disposition)
+	}
+	if !strings.Contains(record.Error, "panic in incoming_audio") {
+		t.Errorf("Expected panic recorded in CDR error, got %q", record.Error)
+	}
+}
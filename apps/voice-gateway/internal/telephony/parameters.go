@@ -0,0 +1,126 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// dispositionInvalidParameters marks a call rejected for missing or malformed stream parameters
+const dispositionInvalidParameters = "invalid_parameters"
+
+// sttConfig returns the config speech recognition runs with, in the call's
+// language when the stream asked for one, on the firm's models, with its
+// formatting and in its region
+func (s *CallSession) sttConfig() *config.Config {
+	s.mu.RLock()
+	provider, language, model, fallback, host, formatting := s.sttProvider, s.language, s.sttModel, s.sttFallbackModel, s.sttHost, s.sttFormatting
+	s.mu.RUnlock()
+	if provider == "" {
+		provider = s.config.STTProvider
+	}
+	if language == "" {
+		language = s.config.DeepgramLanguage
+	}
+	if model == "" {
+		model = s.config.DeepgramModel
+	}
+	if fallback == "" {
+		fallback = s.config.DeepgramFallbackModel
+	}
+	if fallback == model {
+		fallback = ""
+	}
+	if host == "" {
+		host = s.config.DeepgramAPIHost
+	}
+	if provider == s.config.STTProvider && language == s.config.DeepgramLanguage && model == s.config.DeepgramModel && fallback == s.config.DeepgramFallbackModel && host == s.config.DeepgramAPIHost && formatting == nil {
+		return s.config
+	}
+	cfg := *s.config
+	cfg.STTProvider = provider
+	cfg.DeepgramLanguage = language
+	cfg.DeepgramModel = model
+	cfg.DeepgramFallbackModel = fallback
+	cfg.DeepgramAPIHost = host
+	if formatting != nil {
+		cfg.STTPunctuate = formatting.Punctuate
+		cfg.STTSmartFormat = formatting.SmartFormat
+		cfg.STTNumerals = formatting.Numerals
+		cfg.STTProfanityFilter = formatting.ProfanityFilter
+		cfg.STTFillerWords = formatting.FillerWords
+	}
+	return &cfg
+}
+
+// useLanguage switches the shared-account STT client to the stream's
+// language; useFirmCredentials, run after it, keeps the language too
+func (s *CallSession) useLanguage(language string) {
+	if language == "" || language == s.config.DeepgramLanguage {
+		return
+	}
+	if s.sttClient != nil {
+		_ = s.sttClient.Close()
+	}
+	s.sttClient = stt.NewClient(s.sttConfig())
+	s.logger.Info().Str("language", language).Msg("Using the stream's recognition language")
+}
+
+// useFirmSTT switches the shared-account STT client to the firm's provider,
+// Deepgram models and transcript formatting; useFirmCredentials, run after
+// it, keeps them too
+func (s *CallSession) useFirmSTT(settings *firm.Settings) {
+	if settings.STT.Provider == "" && settings.STT.Model == "" && settings.STT.FallbackModel == "" && settings.STT.Formatting == nil {
+		return
+	}
+	provider := settings.STT.Provider
+	if provider == stt.ProviderVosk && s.config.VoskURL == "" {
+		s.logger.Warn().Msg("Firm asks for Vosk speech recognition but VOSK_URL is not set, using the gateway's provider")
+		provider = ""
+	}
+	s.mu.Lock()
+	s.sttProvider = provider
+	s.sttModel = settings.STT.Model
+	s.sttFallbackModel = settings.STT.FallbackModel
+	s.sttFormatting = settings.STT.Formatting
+	s.mu.Unlock()
+
+	cfg := s.sttConfig()
+	if cfg == s.config {
+		return
+	}
+	if s.sttClient != nil {
+		_ = s.sttClient.Close()
+	}
+	s.sttClient = stt.NewClient(cfg)
+	s.logger.Info().Str("provider", cfg.STTProvider).Str("model", cfg.DeepgramModel).Str("fallback_model", cfg.DeepgramFallbackModel).
+		Bool("punctuate", cfg.STTPunctuate).Bool("smart_format", cfg.STTSmartFormat).
+		Msg("Using the firm's speech recognition settings")
+}
+
+// useFirmTTS switches speech synthesis to the firm's provider;
+// useFirmCredentials, run after it, keeps it too
+func (s *CallSession) useFirmTTS(settings *firm.Settings) {
+	provider := settings.TTS.Provider
+	if provider == "" {
+		return
+	}
+	if provider == tts.ProviderPiper && s.config.PiperURL == "" {
+		s.logger.Warn().Msg("Firm asks for Piper speech synthesis but PIPER_URL is not set, using the gateway's provider")
+		return
+	}
+	s.mu.Lock()
+	s.ttsProvider = provider
+	s.mu.Unlock()
+
+	cfg := s.ttsConfig()
+	if cfg == s.config {
+		return
+	}
+	if s.ttsClient != nil {
+		_ = s.ttsClient.Close()
+	}
+	s.ttsClient = tts.NewClient(cfg)
+	s.logger.Info().Str("provider", provider).Msg("Using the firm's speech synthesis provider")
+}
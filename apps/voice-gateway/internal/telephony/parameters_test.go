@@ -0,0 +1,192 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// runStartEvent feeds a start event with the given custom parameters to a
+// session and returns its CDR once the session has ended the call
+func runStartEvent(t *testing.T, cfg *config.Config, customParameters string) cdr.Record {
+	t.Helper()
+	serverConn := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := newUpgrader(&config.Config{}, nil).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		serverConn <- conn
+	}))
+	defer server.Close()
+
+	stream, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer stream.Close()
+
+	s := newSupervisorTestSession()
+	s.conn = <-serverConn
+	s.isActive = true
+	s.config = cfg
+	go s.processIncomingMessages()
+
+	start := `{"event":"start","callSid":"CA1","streamSid":"MZ1","start":{"accountSid":"AC1","callSid":"CA1","customParameters":` + customParameters + `}}`
+	if err := stream.WriteMessage(websocket.TextMessage, []byte(start)); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+
+	select {
+	case <-s.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the session to end")
+	}
+	return s.cdr.Snapshot()
+}
+
+func TestCallSession_RejectsInvalidParameters(t *testing.T) {
+	cfg := &config.Config{StreamRequiredParams: []string{"user_id"}, MissingFirmPolicy: missingFirmAllow}
+	record := runStartEvent(t, cfg, `{"firm_id":"{{FirmId}}","user_id":"u-1"}`)
+	if record.Disposition != dispositionInvalidParameters {
+		t.Errorf("Expected disposition %q, got %q", dispositionInvalidParameters, record.Disposition)
+	}
+	if record.UserID != "u-1" {
+		t.Errorf("Expected user_id recorded, got %q", record.UserID)
+	}
+}
+
+func TestCallSession_RejectsMissingFirmID(t *testing.T) {
+	cfg := &config.Config{MissingFirmPolicy: missingFirmReject}
+	record := runStartEvent(t, cfg, `{"user_id":"u-1","frim_id":"acme"}`)
+	if record.Disposition != dispositionUnattributed {
+		t.Errorf("Expected disposition %q, got %q", dispositionUnattributed, record.Disposition)
+	}
+}
+
+func TestCallSession_AttributeCallDefaultFirm(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{MissingFirmPolicy: missingFirmDefault, StreamDefaultFirmID: "house"}
+
+	firmID, ok := s.attributeCall()
+	if !ok || firmID != "house" {
+		t.Errorf("Expected the default firm, got %q (ok %v)", firmID, ok)
+	}
+	if record := s.cdr.Snapshot(); record.FirmID != "house" {
+		t.Errorf("Expected CDR firm_id house, got %q", record.FirmID)
+	}
+
+	// STREAM_DEFAULT_FIRM_ID without a policy still attributes the call
+	s.config.MissingFirmPolicy = ""
+	if firmID, ok := s.attributeCall(); !ok || firmID != "house" {
+		t.Errorf("Expected STREAM_DEFAULT_FIRM_ID as the fallback, got %q (ok %v)", firmID, ok)
+	}
+
+	s.config.MissingFirmPolicy = missingFirmAllow
+	if firmID, ok := s.attributeCall(); !ok || firmID != "" {
+		t.Errorf("Expected the call to proceed unattributed, got %q (ok %v)", firmID, ok)
+	}
+}
+
+func TestCallSession_STTConfigUsesStreamLanguage(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{DeepgramLanguage: "en"}
+	if s.sttConfig() != s.config {
+		t.Error("Expected the shared config without a stream language")
+	}
+
+	s.language = "es"
+	if got := s.sttConfig().DeepgramLanguage; got != "es" {
+		t.Errorf("Expected language es, got %q", got)
+	}
+	if s.config.DeepgramLanguage != "en" {
+		t.Error("Expected the shared config to be left unchanged")
+	}
+}
+
+func TestCallSession_STTConfigUsesFirmModel(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{DeepgramLanguage: "en", DeepgramModel: "nova-2", DeepgramFallbackModel: "base"}
+
+	s.sttModel = "base"
+	cfg := s.sttConfig()
+	if cfg.DeepgramModel != "base" {
+		t.Errorf("Expected model base, got %q", cfg.DeepgramModel)
+	}
+	if cfg.DeepgramFallbackModel != "" {
+		t.Errorf("Expected no fallback to the same model, got %q", cfg.DeepgramFallbackModel)
+	}
+
+	s.sttFallbackModel = "enhanced"
+	if got := s.sttConfig().DeepgramFallbackModel; got != "enhanced" {
+		t.Errorf("Expected the firm's fallback model, got %q", got)
+	}
+	if s.config.DeepgramModel != "nova-2" {
+		t.Error("Expected the shared config to be left unchanged")
+	}
+}
+
+func TestCallSession_STTConfigUsesFirmFormatting(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{DeepgramLanguage: "en", DeepgramModel: "nova-2", STTPunctuate: true}
+
+	s.sttFormatting = &firm.STTFormatting{SmartFormat: true, FillerWords: true}
+	cfg := s.sttConfig()
+	if cfg.STTPunctuate || !cfg.STTSmartFormat || !cfg.STTFillerWords {
+		t.Errorf("Expected the firm's formatting to replace the gateway's, got %+v", cfg)
+	}
+	if !s.config.STTPunctuate || s.config.STTSmartFormat {
+		t.Error("Expected the shared config to be left unchanged")
+	}
+}
+
+func TestCallSession_UseFirmSTTProvider(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{STTProvider: "deepgram", DeepgramLanguage: "en", DeepgramModel: "nova-2", VoskChunkMs: 100}
+	settings := firm.DefaultSettings()
+	settings.STT.Provider = "vosk"
+
+	// Without a Vosk server the gateway's provider is kept
+	s.useFirmSTT(settings)
+	if _, ok := s.sttClient.(*stt.VoskClient); ok || s.sttConfig() != s.config {
+		t.Error("Expected the gateway's provider without VOSK_URL")
+	}
+
+	s.config.VoskURL = "ws://vosk:2700"
+	s.useFirmSTT(settings)
+	if _, ok := s.sttClient.(*stt.VoskClient); !ok {
+		t.Errorf("Expected a Vosk client, got %T", s.sttClient)
+	}
+}
+
+func TestCallSession_UseFirmTTSProvider(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{TTSProvider: "cartesia", CartesiaVoiceID: "sonic-english", PiperVoice: "en_US-lessac-medium"}
+	settings := firm.DefaultSettings()
+	settings.TTS.Provider = "piper"
+
+	// Without a Piper server the gateway's provider is kept
+	s.useFirmTTS(settings)
+	if _, ok := s.ttsClient.(*tts.PiperClient); ok || s.ttsConfig() != s.config {
+		t.Error("Expected the gateway's provider without PIPER_URL")
+	}
+
+	s.config.PiperURL = "http://piper:5000"
+	s.useFirmTTS(settings)
+	if _, ok := s.ttsClient.(*tts.PiperClient); !ok {
+		t.Errorf("Expected a Piper client, got %T", s.ttsClient)
+	}
+	if voice := tts.DefaultVoice(s.ttsConfig()); voice != "en_US-lessac-medium" {
+		t.Errorf("Expected Piper's default voice, got %q", voice)
+	}
+}
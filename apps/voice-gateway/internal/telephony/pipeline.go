@@ -0,0 +1,263 @@
+package telephony
+
+import (
+	"log"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Inbound pipeline stages, in order: caller audio from Twilio to STT
+const (
+	stageMeter     = "meter"
+	stageVoicemail = "voicemail"
+	stageDecode    = "decode"
+	stageEcho      = "echo"
+	stageGain      = "gain"
+	stageVAD       = "vad"
+	stageScreening = "screening"
+	stageBargeIn   = "barge_in"
+	stageSuppress  = "suppress"
+	stageSTT       = "stt"
+	stageEndpoint  = "endpoint"
+)
+
+// Outbound pipeline stages, in order: PCMU agent audio (TTS, prompts and
+// playback, already converted by its source) to Twilio
+const (
+	stagePace       = "pace"
+	stageWatermark  = "watermark"
+	stageRecord     = "record"
+	stageSupervisor = "supervisor"
+	stageEchoRef    = "echo_reference"
+	stageSend       = "send"
+)
+
+type pipelineStage struct {
+	name  string
+	stage audio.StageFunc
+}
+
+// newPipeline builds a pipeline from stages with fixed, distinct names
+func newPipeline(stages ...pipelineStage) *audio.Pipeline {
+	p := audio.NewPipeline()
+	for _, st := range stages {
+		if err := p.Append(st.name, st.stage); err != nil {
+			panic(err)
+		}
+	}
+	return p
+}
+
+// newInboundPipeline builds the stages caller audio passes through. Features
+// such as redaction tones slot in with InsertBefore/After
+func (s *CallSession) newInboundPipeline() *audio.Pipeline {
+	return newPipeline(
+		pipelineStage{stageMeter, s.meterInbound},
+		pipelineStage{stageVoicemail, s.tapVoicemail},
+		pipelineStage{stageDecode, decodeInbound},
+		pipelineStage{stageEcho, s.gateEcho},
+		pipelineStage{stageGain, s.applyGain},
+		pipelineStage{stageVAD, s.runVAD},
+		pipelineStage{stageScreening, s.holdForScreening},
+		pipelineStage{stageBargeIn, s.bargeIn},
+		pipelineStage{stageSuppress, s.suppressSilence},
+		pipelineStage{stageSTT, s.feedSTT},
+		pipelineStage{stageEndpoint, s.endpoint},
+	)
+}
+
+// newOutboundPipeline builds the stages agent audio passes through on its way to the caller
+func (s *CallSession) newOutboundPipeline() *audio.Pipeline {
+	return newPipeline(
+		pipelineStage{stagePace, s.pace},
+		pipelineStage{stageWatermark, s.mixWatermark},
+		pipelineStage{stageRecord, s.recordOutbound},
+		pipelineStage{stageSupervisor, s.tapOutbound},
+		pipelineStage{stageEchoRef, s.noteAgentAudio},
+		pipelineStage{stageSend, s.sendToTwilio},
+	)
+}
+
+// meterInbound records inbound audio bytes
+func (s *CallSession) meterInbound(p *audio.Packet) bool {
+	if s.metrics != nil {
+		s.metrics.RecordAudioBytes("in", int64(len(p.Data)))
+	}
+	return true
+}
+
+// tapVoicemail copies caller audio into the voicemail recording
+func (s *CallSession) tapVoicemail(p *audio.Packet) bool {
+	if vm := s.inVoicemail(); vm != nil {
+		vm.recordAudio(p.Data)
+	}
+	return true
+}
+
+// decodeInbound decodes PCMU into the packet's reused sample buffer
+func decodeInbound(p *audio.Packet) bool {
+	p.Samples = audio.DecodePCMUInto(p.Samples, p.Data)
+	return true
+}
+
+// gateEcho silences caller audio that is the agent's own speech coming back,
+// so VAD, barge-in and STT never hear it
+func (s *CallSession) gateEcho(p *audio.Packet) bool {
+	if s.echo == nil || !s.echo.IsEcho(p.Samples, time.Now()) {
+		return true
+	}
+	clear(p.Samples)
+	for i := range p.Data {
+		p.Data[i] = 0xFF // PCMU silence
+	}
+	observability.RecordEchoSuppressed()
+	return true
+}
+
+// applyGain boosts a persistently quiet caller before VAD and STT hear them
+func (s *CallSession) applyGain(p *audio.Packet) bool {
+	if s.agc == nil {
+		return true
+	}
+	if before, after := s.agc.Process(p.Samples, p.Data); before >= audio.AGCNoiseFloorRMS {
+		observability.RecordInboundLevel(before, after)
+	}
+	return true
+}
+
+// runVAD runs local VAD, which updates isTalking and drives VAD endpointing
+func (s *CallSession) runVAD(p *audio.Packet) bool {
+	p.Speaking, p.SpeechEnded = s.detectSpeech(p.Samples)
+	s.trackTalkOver(len(p.Samples), p.Speaking)
+	if p.Speaking {
+		s.touchCallerActivity()
+	}
+	return true
+}
+
+// holdForScreening stops audio short of STT until the caller passes screening
+func (s *CallSession) holdForScreening(p *audio.Packet) bool {
+	if ch := s.pendingChallenge(); ch != nil {
+		s.feedChallenge(ch, p.Speaking)
+		return false
+	}
+	return true
+}
+
+// bargeIn stops active TTS once the caller has talked over it for the firm's barge-in time
+func (s *CallSession) bargeIn(p *audio.Packet) bool {
+	s.mu.Lock()
+	if s.isTalking && time.Since(s.talkingSince) >= s.bargeInDelay() {
+		if s.ttsClient != nil && s.ttsClient.IsActive() {
+			s.logger.Info().Msg("User speaking detected, stopping TTS")
+			s.ttsClient.CancelAll()
+			if s.metrics != nil {
+				s.metrics.RecordInterruption()
+			}
+		}
+	}
+	s.mu.Unlock()
+	return true
+}
+
+// suppressSilence withholds long silences from STT when suppression is active,
+// releasing pre-roll with the audio that ends them
+func (s *CallSession) suppressSilence(p *audio.Packet) bool {
+	p.Forward(s.framesForSTT(p.Data, p.Speaking || p.SpeechEnded))
+	return true
+}
+
+// feedSTT sends the packet's audio to Deepgram
+func (s *CallSession) feedSTT(p *audio.Packet) bool {
+	for _, frame := range p.Frames() {
+		if err := s.sttClient.SendAudio(frame); err != nil {
+			s.logger.Error().Err(err).Msg("Error sending audio to Deepgram")
+			if s.metrics != nil {
+				s.metrics.RecordError("stt_send_error", "deepgram")
+			}
+			// Continue processing - don't break the call flow
+			// The STT client should handle reconnection internally
+		}
+	}
+	return true
+}
+
+// endpoint flushes the utterance on local end-of-speech instead of waiting for UtteranceEndMs
+func (s *CallSession) endpoint(p *audio.Packet) bool {
+	if p.SpeechEnded {
+		s.finalizeUtterance()
+	}
+	return true
+}
+
+// pace passes outbound audio through the ring buffer for smooth playback
+func (s *CallSession) pace(p *audio.Packet) bool {
+	written := s.audioOutBuffer.Write(p.Data)
+	if written < len(p.Data) {
+		log.Printf("Warning: audioOut buffer overflow, dropped %d bytes", len(p.Data)-written)
+	}
+
+	bufferData := make([]byte, len(p.Data))
+	read := s.audioOutBuffer.Read(bufferData)
+	if read == 0 {
+		return false
+	}
+	p.Data = bufferData[:read]
+	s.touchAgentActivity()
+	return true
+}
+
+// mixWatermark adds the AI-disclosure tone to agent audio, before it is
+// recorded so the recording matches what the caller heard
+func (s *CallSession) mixWatermark(p *audio.Packet) bool {
+	s.mu.RLock()
+	watermark := s.watermark
+	s.mu.RUnlock()
+	if watermark != nil {
+		watermark.Mix(p.Data)
+	}
+	return true
+}
+
+// recordOutbound copies agent audio into the call recording
+func (s *CallSession) recordOutbound(p *audio.Packet) bool {
+	s.recordAgentAudio(p.Data)
+	return true
+}
+
+// tapOutbound copies agent audio to a listening supervisor
+func (s *CallSession) tapOutbound(p *audio.Packet) bool {
+	s.tapSupervisor(TrackOutbound, p.Data)
+	return true
+}
+
+// noteAgentAudio tells echo detection and talk-over analytics what the
+// caller is about to hear
+func (s *CallSession) noteAgentAudio(p *audio.Packet) bool {
+	now := time.Now()
+	if s.echo != nil {
+		s.echo.Played(p.Data, now)
+	}
+	s.spoken.playing(now)
+	s.talkOver.played(len(p.Data), now)
+	return true
+}
+
+// sendToTwilio queues agent audio for Twilio; it is already PCMU
+func (s *CallSession) sendToTwilio(p *audio.Packet) bool {
+	if err := s.SendAudioToTwilio(p.Data); err != nil {
+		s.logger.Error().Err(err).Msg("Error sending audio to Twilio")
+		if s.metrics != nil {
+			s.metrics.RecordError("twilio_send_error", "telephony")
+		}
+		// Continue processing - don't break the call flow
+		return true
+	}
+	s.noteFirstResponse()
+	s.logger.Debug().
+		Int("bytes", len(p.Data)).
+		Msg("Queued TTS audio for Twilio")
+	return true
+}
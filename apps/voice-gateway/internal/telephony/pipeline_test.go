@@ -0,0 +1,61 @@
+package telephony
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+)
+
+func TestCallSession_InboundPipelineStages(t *testing.T) {
+	s := newSupervisorTestSession()
+	want := []string{
+		stageMeter, stageVoicemail, stageDecode, stageEcho, stageGain, stageVAD, stageScreening,
+		stageBargeIn, stageSuppress, stageSTT, stageEndpoint,
+	}
+	if got := s.newInboundPipeline().Stages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected inbound stages %v, got %v", want, got)
+	}
+}
+
+func TestCallSession_OutboundPipelineTap(t *testing.T) {
+	conn := &fakeTwilioConn{}
+	s := newSupervisorTestSession()
+	s.isActive = true
+	s.audioOutBuffer = audio.NewRingBuffer(1024)
+	s.writer = newTwilioWriter(conn, nil)
+	s.outbound = s.newOutboundPipeline()
+
+	// A tap added after pacing sees exactly what is sent
+	var tapped []byte
+	err := s.outbound.InsertBefore(stageSend, "tap", audio.StageFunc(func(p *audio.Packet) bool {
+		tapped = append(tapped, p.Data...)
+		return true
+	}))
+	if err != nil {
+		t.Fatalf("InsertBefore() failed: %v", err)
+	}
+
+	s.sendOutgoingChunk([]byte{0x7f, 0xff})
+	if !reflect.DeepEqual(tapped, []byte{0x7f, 0xff}) {
+		t.Errorf("Expected tap to see outbound audio, got %v", tapped)
+	}
+	if len(s.writer.stream) != 1 {
+		t.Errorf("Expected 1 queued media message, got %d", len(s.writer.stream))
+	}
+}
+
+func TestCallSession_OutboundTrackSkipsSTT(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.audioIn = make(chan *audio.Frame, 2)
+
+	// With both tracks streamed, only the caller's reaches the inbound pipeline
+	s.handleMediaPayload(TrackOutbound, []byte("20"), []byte("f39/"))
+	if len(s.audioIn) != 0 {
+		t.Error("Expected the agent's outbound track to be dropped")
+	}
+	s.handleMediaPayload(TrackInbound, []byte("20"), []byte("f39/"))
+	if len(s.audioIn) != 1 {
+		t.Error("Expected the caller's inbound track to be queued")
+	}
+}
@@ -0,0 +1,164 @@
+package telephony
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+)
+
+const (
+	// playbackFrameSize is the PCMU bytes sent per paced frame (20ms)
+	playbackFrameSize = 160
+
+	// playbackFrameInterval is the real-time length of one frame
+	playbackFrameInterval = 20 * time.Millisecond
+
+	// playbackLeadFrames are sent up front so Twilio's jitter buffer never runs dry
+	playbackLeadFrames = 10
+
+	// maxLoopDuration caps looping playback such as hold music
+	maxLoopDuration = 10 * time.Minute
+
+	// playbackLoadTimeout bounds fetching and decoding a greeting
+	playbackLoadTimeout = 5 * time.Second
+)
+
+// playbackState is the clip currently being streamed to the caller
+type playbackState struct {
+	cancel context.CancelFunc
+	loop   bool
+	done   chan struct{}
+}
+
+// playAudio loads a pre-recorded file (URL or storage key) and streams it to
+// the caller in real time, replacing anything already playing
+// Looping playback (hold music) stops when the agent next speaks
+func (s *CallSession) playAudio(ctx context.Context, ref string, loop bool) (time.Duration, error) {
+	if s.services == nil || s.services.Audio == nil {
+		return 0, fmt.Errorf("audio playback not configured")
+	}
+	clip, err := s.services.Audio.Load(ctx, ref)
+	if err != nil {
+		return 0, err
+	}
+
+	playCtx, cancel := context.WithCancel(context.Background())
+	state := &playbackState{cancel: cancel, loop: loop, done: make(chan struct{})}
+
+	s.playbackMu.Lock()
+	previous := s.playback
+	s.playback = state
+	s.playbackMu.Unlock()
+	if previous != nil {
+		previous.cancel()
+		<-previous.done
+	}
+
+	s.logger.Info().
+		Str("audio", ref).
+		Dur("duration", clip.Duration()).
+		Bool("loop", loop).
+		Msg("Playing audio file")
+
+	s.goSafe("playback", func() {
+		defer func() {
+			s.playbackMu.Lock()
+			if s.playback == state {
+				s.playback = nil
+			}
+			s.playbackMu.Unlock()
+			close(state.done)
+		}()
+		s.streamPCMU(playCtx, clip.PCMU, loop)
+	})
+
+	return clip.Duration(), nil
+}
+
+// streamPCMU paces audio into audioOut at real time, optionally looping
+func (s *CallSession) streamPCMU(ctx context.Context, pcmu []byte, loop bool) {
+	if len(pcmu) == 0 {
+		return
+	}
+	deadline := time.Now().Add(maxLoopDuration)
+
+	ticker := time.NewTicker(playbackFrameInterval)
+	defer ticker.Stop()
+
+	frames := 0
+	for {
+		for start := 0; start < len(pcmu); start += playbackFrameSize {
+			if frames >= playbackLeadFrames {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				case <-s.done:
+					return
+				}
+			}
+			frames++
+
+			end := min(start+playbackFrameSize, len(pcmu))
+			select {
+			case s.audioOut <- pcmu[start:end]:
+			case <-ctx.Done():
+				return
+			case <-s.done:
+				return
+			}
+		}
+		if !loop || time.Now().After(deadline) {
+			return
+		}
+	}
+}
+
+// stopAudio stops any file playback; with loopOnly, one-shot clips such as
+// disclaimers are left to finish
+func (s *CallSession) stopAudio(loopOnly bool) {
+	s.playbackMu.Lock()
+	state := s.playback
+	if state == nil || (loopOnly && !state.loop) {
+		s.playbackMu.Unlock()
+		return
+	}
+	s.playback = nil
+	s.playbackMu.Unlock()
+
+	state.cancel()
+	s.logger.Debug().Msg("Stopped audio playback")
+}
+
+// playGreeting plays a pre-recorded greeting, falling back to speaking text
+// when there is no recording or it cannot be played
+func (s *CallSession) playGreeting(key i18n.Key, audioRef, text string) {
+	// Recordings are in English; a translation for the caller wins over them
+	if translated := s.localize(key, text); translated != text {
+		audioRef, text = "", translated
+	}
+	if audioRef != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), playbackLoadTimeout)
+		defer cancel()
+		_, err := s.playAudio(ctx, audioRef, false)
+		if err == nil {
+			return
+		}
+		s.logger.Warn().Err(err).Str("audio", audioRef).Msg("Failed to play greeting audio, using TTS")
+	}
+	if text == "" {
+		return
+	}
+	if err := s.speak(text); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to speak greeting")
+	}
+}
+
+// playbackActive returns whether a file is still being streamed
+func (s *CallSession) playbackActive() bool {
+	s.playbackMu.Lock()
+	defer s.playbackMu.Unlock()
+	return s.playback != nil
+}
@@ -0,0 +1,25 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// useFirmLexicon compiles the firm's pronunciations, applied to everything
+// the call says. A lexicon that fails to compile is skipped, not fatal
+func (s *CallSession) useFirmLexicon(settings *firm.Settings) {
+	if len(settings.Pronunciations) == 0 {
+		return
+	}
+	entries := make([]tts.LexiconEntry, 0, len(settings.Pronunciations))
+	for term, pronunciation := range settings.Pronunciations {
+		entries = append(entries, tts.LexiconEntry{Term: term, Say: pronunciation.Say, IPA: pronunciation.IPA})
+	}
+	lexicon, err := tts.NewLexicon(entries)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Firm pronunciations unusable, speaking terms as written")
+		return
+	}
+	s.lexicon = lexicon
+	s.logger.Debug().Int("terms", len(entries)).Msg("Using the firm's pronunciations")
+}
@@ -0,0 +1,95 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// Kinds of intake field read_back reads
+const (
+	ReadBackText  = "text"  // Read as written (dates, addresses, reasons for calling)
+	ReadBackName  = "name"  // Said, then spelled letter by letter
+	ReadBackPhone = "phone" // Read digit by digit in groups
+	ReadBackEmail = "email" // Spelled, with common providers said as words
+	ReadBackSpell = "spell" // Spelled character by character (case numbers, policy IDs)
+)
+
+// readBackPause separates fields, so each is heard on its own
+const readBackPause = `<break time="600ms"/>`
+
+// readBackField is one collected value to read back
+type readBackField struct {
+	Label string `json:"label"` // What the value is, e.g. "your phone number"
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// say returns how the field is read aloud
+func (f readBackField) say() (string, error) {
+	value := strings.TrimSpace(f.Value)
+	if value == "" {
+		return "", fmt.Errorf("field %q has no value", f.Label)
+	}
+
+	var said string
+	switch f.Type {
+	case ReadBackText, "":
+		said = value
+	case ReadBackName:
+		said = value + ", spelled " + tts.SpellOut(value)
+	case ReadBackPhone:
+		said = tts.SayPhoneDigits(value)
+	case ReadBackEmail:
+		said = tts.SayEmail(value)
+	case ReadBackSpell:
+		said = tts.SpellOut(value)
+	default:
+		return "", fmt.Errorf("field %q has unknown type %q", f.Label, f.Type)
+	}
+	if f.Label == "" {
+		return said, nil
+	}
+	return f.Label + ": " + said, nil
+}
+
+// readBackTool reads collected intake fields back to the caller slowly and
+// clearly (names spelled, phone digits grouped, email addresses verbalized)
+// and collects their confirmation as the confirm tool does, so a routine
+// receptionist check never depends on how the LLM phrases it
+func readBackTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Fields         []readBackField `json:"fields"`
+		Prompt         string          `json:"prompt"` // Replaces the firm's prompt; {details} is the read-back
+		TimeoutSeconds int             `json:"timeout_seconds"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if len(p.Fields) == 0 {
+		return nil, fmt.Errorf("fields is required")
+	}
+
+	said := make([]string, len(p.Fields))
+	for i, field := range p.Fields {
+		var err error
+		if said[i], err = field.say(); err != nil {
+			return nil, err
+		}
+	}
+	details := strings.Join(said, ". "+readBackPause+" ")
+
+	prompt := p.Prompt
+	if prompt != "" {
+		prompt = strings.ReplaceAll(prompt, "{details}", details)
+	}
+	result, err := s.confirm(ctx, details, prompt, p.TimeoutSeconds)
+	if err != nil {
+		return nil, err
+	}
+	result["fields"] = len(p.Fields)
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadBackTool_ReadsFieldsAndConfirms(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+
+	params := `{"fields": [
+		{"label": "Your name", "type": "name", "value": "Ann Lee"},
+		{"label": "your number", "type": "phone", "value": "555-123-4567"},
+		{"label": "your email", "type": "email", "value": "ann@gmail.com"},
+		{"label": "the reason for your call", "value": "a lease dispute"}
+	]}`
+	result, spoken := runConfirmingTool(t, s, readBackTool, params, func(_ int, c *confirmationState) {
+		c.answerSpeech("Yes, that's all correct")
+	})
+
+	if result["confirmed"] != true || result["fields"] != 4 {
+		t.Errorf("Expected the read-back confirmed, got %v", result)
+	}
+	if len(spoken) != 1 {
+		t.Fatalf("Expected one read-back, got %v", spoken)
+	}
+	for _, want := range []string{
+		"To confirm: Your name: Ann Lee, spelled A, N, N",
+		"your number: five five five, one two three, four five six seven. " + readBackPause,
+		"your email: A, N, N, at, gmail, dot, com",
+		"the reason for your call: a lease dispute.",
+	} {
+		if !strings.Contains(spoken[0], want) {
+			t.Errorf("Expected %q in the read-back, got %q", want, spoken[0])
+		}
+	}
+}
+
+func TestReadBackTool_CustomPrompt(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+
+	params := `{"fields": [{"type": "spell", "value": "CV-7"}], "prompt": "Your case number is {details}. Right?"}`
+	result, spoken := runConfirmingTool(t, s, readBackTool, params, func(_ int, c *confirmationState) {
+		c.answerDigit("2")
+	})
+
+	if result["status"] != ConfirmationRejected {
+		t.Errorf("Expected a keypad rejection, got %v", result)
+	}
+	if len(spoken) == 0 || spoken[0] != "Your case number is C, V, dash, seven. Right?" {
+		t.Errorf("Unexpected prompt %v", spoken)
+	}
+}
+
+func TestReadBackTool_InvalidFields(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+
+	for _, params := range []string{
+		`{}`,
+		`{"fields": [{"label": "name", "type": "name"}]}`,
+		`{"fields": [{"label": "fax", "type": "fax", "value": "555"}]}`,
+	} {
+		if _, err := readBackTool(context.Background(), s, json.RawMessage(params)); err == nil {
+			t.Errorf("Expected error for %s", params)
+		}
+	}
+}
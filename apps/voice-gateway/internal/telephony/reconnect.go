@@ -0,0 +1,186 @@
+package telephony
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Twilio stream close causes, for metrics and logs
+const (
+	closeStop     = "stop"           // Twilio sent a stop event: the call ended normally
+	closeTwilio   = "twilio_close"   // Twilio closed the WebSocket without a stop event
+	closeNetwork  = "network_error"  // The connection dropped without a close frame
+	closeProtocol = "protocol_error" // The peer broke the WebSocket protocol, or closed with an error code
+	closeGateway  = "gateway"        // The gateway ended the call
+)
+
+// twilioHandshakeTimeout bounds waiting for the start event that tells
+// whether a new connection resumes a stream in its reconnect grace
+const twilioHandshakeTimeout = 10 * time.Second
+
+// StreamRegistry holds the sessions whose Twilio connection dropped, by
+// stream SID, while they wait out their reconnect grace
+type StreamRegistry struct {
+	mu      sync.Mutex
+	waiting map[string]*CallSession
+}
+
+// NewStreamRegistry creates an empty registry
+func NewStreamRegistry() *StreamRegistry {
+	return &StreamRegistry{waiting: make(map[string]*CallSession)}
+}
+
+func (r *StreamRegistry) park(streamSid string, s *CallSession) {
+	r.mu.Lock()
+	r.waiting[streamSid] = s
+	r.mu.Unlock()
+}
+
+func (r *StreamRegistry) unpark(streamSid string, s *CallSession) {
+	r.mu.Lock()
+	if r.waiting[streamSid] == s {
+		delete(r.waiting, streamSid)
+	}
+	r.mu.Unlock()
+}
+
+// claim hands conn to the session waiting on the stream, returning it, or nil
+// when no session is waiting
+func (r *StreamRegistry) claim(streamSid string, conn *websocket.Conn) *CallSession {
+	if r == nil || streamSid == "" {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.waiting[streamSid]
+	if !ok {
+		return nil
+	}
+	delete(r.waiting, streamSid)
+	s.reconnect <- conn
+	return s
+}
+
+// closeCause classifies the error that ended reading the Twilio stream
+func closeCause(err error) string {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		switch closeErr.Code {
+		case websocket.CloseNormalClosure, websocket.CloseGoingAway:
+			return closeTwilio
+		case websocket.CloseAbnormalClosure:
+			return closeNetwork
+		}
+		return closeProtocol
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return closeNetwork
+	}
+	return closeProtocol
+}
+
+// resumeStream reads a new connection's handshake. When its start event
+// names a stream waiting out its reconnect grace, the connection is handed
+// to that session, which is returned; otherwise the messages read are
+// returned for a new session to process first
+func resumeStream(conn *websocket.Conn, streams *StreamRegistry) (*CallSession, [][]byte) {
+	_ = conn.SetReadDeadline(time.Now().Add(twilioHandshakeTimeout))
+
+	var pending [][]byte
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return nil, pending
+		}
+		pending = append(pending, message)
+
+		var msg TwilioMessage
+		if json.Unmarshal(message, &msg) == nil && msg.Event == "connected" {
+			continue
+		}
+
+		// The session reading the connection sets its own deadline
+		_ = conn.SetReadDeadline(time.Time{})
+		if msg.Event == "start" {
+			if s := streams.claim(msg.StreamSid, conn); s != nil {
+				return s, nil
+			}
+		}
+		return nil, pending
+	}
+}
+
+// awaitReconnect keeps the session alive for the reconnect grace after its
+// Twilio connection dropped, in case Twilio reconnects the same stream. It
+// reports whether the stream resumed on a new connection; only network
+// errors on a started stream are waited out, since Twilio closing the socket
+// or breaking the protocol means it won't be back
+func (s *CallSession) awaitReconnect(cause string) bool {
+	grace := time.Duration(s.config.TwilioReconnectGraceMs) * time.Millisecond
+	s.mu.RLock()
+	streamSid := s.streamSid
+	s.mu.RUnlock()
+	if cause != closeNetwork || grace <= 0 || streamSid == "" || s.services == nil || s.services.Streams == nil {
+		return false
+	}
+
+	s.logger.Warn().Dur("grace", grace).Msg("Twilio connection lost, waiting for the stream to reconnect")
+	s.reconnecting.Store(true)
+	defer s.reconnecting.Store(false)
+	s.services.Streams.park(streamSid, s)
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case conn := <-s.reconnect:
+		s.resumeOn(conn)
+		return true
+	case <-timer.C:
+	}
+
+	// A reconnect may have claimed the session as the grace ran out
+	s.services.Streams.unpark(streamSid, s)
+	select {
+	case conn := <-s.reconnect:
+		s.resumeOn(conn)
+		return true
+	default:
+	}
+	s.logger.Warn().Msg("Twilio stream did not reconnect within the grace period")
+	observability.RecordTwilioReconnect("expired")
+	return false
+}
+
+// resumeOn moves the session onto a new Twilio connection for its stream
+func (s *CallSession) resumeOn(conn *websocket.Conn) {
+	s.mu.Lock()
+	old := s.conn
+	s.conn = conn
+	s.mu.Unlock()
+	s.writer.setConn(conn)
+	tuneConn(conn, s.config)
+	s.watchConn(conn)
+	_ = old.Close()
+
+	// The gateway may have ended the call while it waited
+	if s.endedByGateway.Load() {
+		_ = conn.Close()
+	}
+	s.logger.Info().Msg("Twilio stream resumed on a new connection")
+	observability.RecordTwilioReconnect("resumed")
+}
+
+// twilioConn returns the session's current Twilio connection
+func (s *CallSession) twilioConn() Conn {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conn
+}
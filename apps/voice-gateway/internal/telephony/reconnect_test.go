@@ -0,0 +1,136 @@
+package telephony
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestCloseCause(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&websocket.CloseError{Code: websocket.CloseNormalClosure}, closeTwilio},
+		{&websocket.CloseError{Code: websocket.CloseGoingAway}, closeTwilio},
+		{&websocket.CloseError{Code: websocket.CloseAbnormalClosure}, closeNetwork},
+		{&websocket.CloseError{Code: websocket.CloseProtocolError}, closeProtocol},
+		{&websocket.CloseError{Code: websocket.CloseMessageTooBig}, closeProtocol},
+		{io.ErrUnexpectedEOF, closeNetwork},
+		{websocket.ErrReadLimit, closeProtocol},
+		{errors.New("websocket: bad opcode 7"), closeProtocol},
+	}
+	for _, tt := range tests {
+		if got := closeCause(tt.err); got != tt.want {
+			t.Errorf("closeCause(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// newReconnectTestServer starts a media stream endpoint: the first connection
+// belongs to a session on stream MZ1, later ones may resume it
+func newReconnectTestServer(t *testing.T, graceMs int) (*CallSession, string, chan *CallSession) {
+	t.Helper()
+	streams := NewStreamRegistry()
+	first := make(chan *websocket.Conn, 1)
+	resumed := make(chan *CallSession, 1)
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := newUpgrader(&config.Config{}, nil).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		connections++
+		if connections == 1 {
+			first <- conn
+			return
+		}
+		s, _ := resumeStream(conn, streams)
+		resumed <- s
+	}))
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	stream, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { stream.Close() })
+
+	s := newSupervisorTestSession()
+	s.conn = <-first
+	s.writer = newTwilioWriter(s.conn, nil)
+	s.reconnect = make(chan *websocket.Conn, 1)
+	s.isActive = true
+	s.streamSid = "MZ1"
+	s.config = &config.Config{TwilioReconnectGraceMs: graceMs}
+	s.services = &Services{Streams: streams}
+	go s.processIncomingMessages()
+
+	// Drop the connection without a close frame, as a network failure would
+	stream.UnderlyingConn().Close()
+	return s, url, resumed
+}
+
+func TestCallSession_ResumesReconnectedStream(t *testing.T) {
+	s, url, resumed := newReconnectTestServer(t, 2000)
+
+	// Wait for the session to notice the drop
+	deadline := time.Now().Add(2 * time.Second)
+	for !s.reconnecting.Load() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stream, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer stream.Close()
+	stream.WriteMessage(websocket.TextMessage, []byte(`{"event":"connected","protocol":"Call","version":"1.0.0"}`))
+	stream.WriteMessage(websocket.TextMessage, []byte(`{"event":"start","streamSid":"MZ1","callSid":"CA1"}`))
+
+	if got := <-resumed; got != s {
+		t.Fatalf("Expected the reconnect to resume the waiting session, got %v", got)
+	}
+	select {
+	case <-s.done:
+		t.Fatal("Expected the session to carry on after resuming")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Twilio closing the resumed stream ends the call without a grace
+	stream.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	select {
+	case <-s.done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the session to end when Twilio closed the stream")
+	}
+}
+
+func TestCallSession_ReconnectGraceExpires(t *testing.T) {
+	s, url, resumed := newReconnectTestServer(t, 50)
+	select {
+	case <-s.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the session to end once the grace ran out")
+	}
+
+	// A late reconnect starts afresh
+	stream, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer stream.Close()
+	stream.WriteMessage(websocket.TextMessage, []byte(`{"event":"start","streamSid":"MZ1","callSid":"CA1"}`))
+	if got := <-resumed; got != nil {
+		t.Error("Expected no session to resume after its grace")
+	}
+}
@@ -0,0 +1,75 @@
+package telephony
+
+import (
+	"fmt"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/stt"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// dispositionRegionUnavailable marks a call rejected because its firm
+// requires a data region the gateway has no endpoint in
+const dispositionRegionUnavailable = "region_unavailable"
+
+// useFirmRegions points STT and TTS at the endpoints in the firm's required
+// regions, before either is used. It fails, and the call must be rejected,
+// when any required region (storage included) has no endpoint, so nothing
+// about the call is processed outside it
+func (s *CallSession) useFirmRegions(settings *firm.Settings) error {
+	regions := settings.Regions
+	host, ok := s.config.DeepgramHostIn(regions.STT)
+	if !ok {
+		return fmt.Errorf("no speech recognition endpoint in region %q", regions.STT)
+	}
+	ttsURL, ok := s.config.CartesiaURLIn(regions.TTS)
+	if !ok {
+		return fmt.Errorf("no speech synthesis endpoint in region %q", regions.TTS)
+	}
+	if _, ok := s.config.StorageIn(regions.Storage); !ok {
+		return fmt.Errorf("no storage in region %q", regions.Storage)
+	}
+
+	s.mu.Lock()
+	s.sttHost = host
+	s.ttsURL = ttsURL
+	s.mu.Unlock()
+
+	if host != s.config.DeepgramAPIHost {
+		if s.sttClient != nil {
+			_ = s.sttClient.Close()
+		}
+		s.sttClient = stt.NewClient(s.sttConfig())
+		s.logger.Info().Str("region", regions.STT).Str("host", host).Msg("Using the firm's speech recognition region")
+	}
+	if ttsURL != s.config.CartesiaAPIURL {
+		if s.ttsClient != nil {
+			_ = s.ttsClient.Close()
+		}
+		s.ttsClient = tts.NewClient(s.ttsConfig())
+		s.logger.Info().Str("region", regions.TTS).Str("url", ttsURL).Msg("Using the firm's speech synthesis region")
+	}
+	return nil
+}
+
+// ttsConfig returns the config speech synthesis runs with, on the firm's
+// provider and regional endpoint
+func (s *CallSession) ttsConfig() *config.Config {
+	s.mu.RLock()
+	provider, url := s.ttsProvider, s.ttsURL
+	s.mu.RUnlock()
+	if provider == "" {
+		provider = s.config.TTSProvider
+	}
+	if url == "" {
+		url = s.config.CartesiaAPIURL
+	}
+	if provider == s.config.TTSProvider && url == s.config.CartesiaAPIURL {
+		return s.config
+	}
+	cfg := *s.config
+	cfg.TTSProvider = provider
+	cfg.CartesiaAPIURL = url
+	return &cfg
+}
@@ -0,0 +1,49 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestCallSession_UseFirmRegions(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {
+		"acme": {"regions": {"stt": "eu", "tts": "eu", "storage": "eu"}},
+		"globex": {"regions": {"stt": "apac"}},
+		"initech": {"regions": {"storage": "us"}}
+	}}`)
+	s.config = &config.Config{
+		DeepgramLanguage:    "en",
+		CartesiaAPIURL:      "https://api.cartesia.ai/v1/tts",
+		DataRegion:          "us",
+		DeepgramRegionHosts: []string{"eu=api.eu.deepgram.com"},
+		CartesiaRegionURLs:  []string{"eu=https://tts.eu.example.com/v1/tts"},
+		StorageRegions:      []string{"eu=recordings-eu@eu-west-1"},
+	}
+
+	if err := s.useFirmRegions(s.services.Firms.Get("acme")); err != nil {
+		t.Fatalf("Expected the EU firm to be served, got %v", err)
+	}
+	if cfg := s.sttConfig(); cfg.DeepgramAPIHost != "api.eu.deepgram.com" {
+		t.Errorf("Expected the EU Deepgram host, got %q", cfg.DeepgramAPIHost)
+	}
+	if cfg := s.ttsConfig(); cfg.CartesiaAPIURL != "https://tts.eu.example.com/v1/tts" {
+		t.Errorf("Expected the EU Cartesia URL, got %q", cfg.CartesiaAPIURL)
+	}
+	if s.sttClient == nil || s.ttsClient == nil {
+		t.Error("Expected regional STT and TTS clients")
+	}
+
+	if err := s.useFirmRegions(s.services.Firms.Get("globex")); err == nil {
+		t.Error("Expected a call needing an unserved region to be refused")
+	}
+
+	s = newLanguageTestSession(t, `{"firms": {"initech": {"regions": {"storage": "us"}}}}`)
+	s.config = &config.Config{DeepgramLanguage: "en", DataRegion: "us"}
+	if err := s.useFirmRegions(s.services.Firms.Get("initech")); err != nil {
+		t.Fatalf("Expected the gateway's own region to be served, got %v", err)
+	}
+	if cfg := s.sttConfig(); cfg != s.config {
+		t.Error("Expected the gateway's own endpoints in its own region")
+	}
+}
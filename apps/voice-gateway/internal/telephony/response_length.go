@@ -0,0 +1,98 @@
+package telephony
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// spokenWordsPerSecond is the agent's pace at its natural speed (150 words a
+// minute), for turning MAX_RESPONSE_SECONDS into words
+const spokenWordsPerSecond = 2.5
+
+// sentenceEnd matches the end of a sentence and the space after it
+var sentenceEnd = regexp.MustCompile(`[.!?…]+["')\]]*(\s+|$)`)
+
+// responseWordLimit returns the most words the agent speaks of one response,
+// or 0 for no limit
+func (s *CallSession) responseWordLimit() int {
+	if s.config == nil {
+		return 0
+	}
+	limit := s.config.MaxResponseWords
+	if s.config.MaxResponseSeconds > 0 {
+		s.mu.RLock()
+		speed := s.speaking.Speed
+		s.mu.RUnlock()
+		if speed <= 0 {
+			speed = 1
+		}
+		byTime := int(float64(s.config.MaxResponseSeconds) * spokenWordsPerSecond * speed)
+		if limit == 0 || byTime < limit {
+			limit = byTime
+		}
+	}
+	return limit
+}
+
+// truncateResponse returns the whole sentences of text that fit in limit
+// words once spoken words of the response have been said, and their word
+// count. A first sentence too long to fit on its own is cut at the limit,
+// so the agent says something before asking to go on
+func truncateResponse(text string, spoken, limit int) (string, int) {
+	var kept strings.Builder
+	words := 0
+	rest := text
+	for rest != "" {
+		end := len(rest)
+		if loc := sentenceEnd.FindStringIndex(rest); loc != nil {
+			end = loc[1]
+		}
+		n := len(strings.Fields(rest[:end]))
+		if spoken+words+n > limit {
+			break
+		}
+		kept.WriteString(rest[:end])
+		words += n
+		rest = rest[end:]
+	}
+
+	if words == 0 && spoken == 0 {
+		fields := strings.Fields(text)
+		return strings.Join(fields[:min(limit, len(fields))], " "), min(limit, len(fields))
+	}
+	return strings.TrimSpace(kept.String()), words
+}
+
+// limit returns the part of text from the buffer's turn to speak, and whether
+// the response was cut short with it. Once a turn is cut, the rest of its
+// text is not spoken
+func (t *turnText) limit(text string, limit int) (string, bool) {
+	if t.cut {
+		return "", false
+	}
+	if limit <= 0 {
+		return text, false
+	}
+	if t.words+len(strings.Fields(text)) <= limit {
+		t.words += len(strings.Fields(text))
+		return text, false
+	}
+	kept, words := truncateResponse(text, t.words, limit)
+	t.words += words
+	t.cut = true
+	return kept, true
+}
+
+// continuePrompt ends a response cut short, asking the caller whether to go on
+func (s *CallSession) continuePrompt(text string) string {
+	observability.RecordResponseTruncated()
+	s.logger.Info().Int("max_words", s.responseWordLimit()).Msg("Response too long, cutting it short")
+	prompt := s.localize(i18n.ResponseContinue, s.config.MaxResponseContinuePrompt)
+	if text == "" {
+		return prompt
+	}
+	return text + " " + prompt
+}
@@ -0,0 +1,78 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+)
+
+func TestTruncateResponse(t *testing.T) {
+	text := "You have three options. First, we can file a motion. Second, we can settle."
+	tests := []struct {
+		spoken, limit int
+		want          string
+		words         int
+	}{
+		{0, 10, "You have three options. First, we can file a motion.", 10},
+		{0, 12, "You have three options. First, we can file a motion.", 10},
+		{6, 10, "You have three options.", 4},
+		{8, 10, "", 0},
+		{0, 2, "You have", 2}, // The first sentence alone is too long
+	}
+	for _, tt := range tests {
+		got, words := truncateResponse(text, tt.spoken, tt.limit)
+		if got != tt.want || words != tt.words {
+			t.Errorf("truncateResponse(%d, %d) = %q (%d words), want %q (%d words)", tt.spoken, tt.limit, got, words, tt.want, tt.words)
+		}
+	}
+}
+
+func TestTurnText_Limit(t *testing.T) {
+	var buffer turnText
+	buffer.add(responseChunk{turn: 1, seq: 1, text: "x"})
+
+	if got, cut := buffer.limit("Your consultation is booked.", 8); got != "Your consultation is booked." || cut {
+		t.Errorf("Expected the first sentence whole, got %q (cut %v)", got, cut)
+	}
+	if got, cut := buffer.limit("It is on Tuesday. Please bring your documents.", 8); got != "It is on Tuesday." || !cut {
+		t.Errorf("Expected the response cut after the second sentence, got %q (cut %v)", got, cut)
+	}
+	if got, cut := buffer.limit("Parking is free.", 8); got != "" || cut {
+		t.Errorf("Expected nothing more of a cut turn spoken, got %q (cut %v)", got, cut)
+	}
+
+	// A new turn starts over
+	buffer.add(responseChunk{turn: 2, seq: 1, text: "x"})
+	if got, _ := buffer.limit("Parking is free.", 8); got != "Parking is free." {
+		t.Errorf("Expected a new turn spoken, got %q", got)
+	}
+	if got, cut := buffer.limit("Anything at all.", 0); got != "Anything at all." || cut {
+		t.Error("Expected no limit at 0")
+	}
+}
+
+func TestCallSession_ResponseWordLimit(t *testing.T) {
+	s := newSupervisorTestSession()
+	if got := s.responseWordLimit(); got != 0 {
+		t.Errorf("Expected no limit without config, got %d", got)
+	}
+
+	s.config = &config.Config{MaxResponseSeconds: 40}
+	if got := s.responseWordLimit(); got != 100 {
+		t.Errorf("Expected 40s to allow 100 words, got %d", got)
+	}
+	s.speaking = firm.SpeakingSettings{Speed: 1.5}
+	if got := s.responseWordLimit(); got != 150 {
+		t.Errorf("Expected a faster voice to fit 150 words, got %d", got)
+	}
+	s.config.MaxResponseWords = 60
+	if got := s.responseWordLimit(); got != 60 {
+		t.Errorf("Expected the lower word limit, got %d", got)
+	}
+
+	s.config.MaxResponseContinuePrompt = "…shall I continue?"
+	if got := s.continuePrompt("It is on Tuesday."); got != "It is on Tuesday. …shall I continue?" {
+		t.Errorf("Unexpected prompt %q", got)
+	}
+}
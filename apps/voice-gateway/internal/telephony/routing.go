@@ -0,0 +1,206 @@
+package telephony
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+)
+
+// transferTimeout bounds the Twilio API call that redirects a call
+const transferTimeout = 10 * time.Second
+
+// routeCall evaluates the firm's routing policy at call start and applies it
+func (s *CallSession) routeCall(firmID string, settings *firm.Settings) {
+	s.mu.RLock()
+	fallback := s.transferFallback
+	s.mu.RUnlock()
+
+	decision := s.services.Router.Decide(firmID, settings, time.Now())
+	overBudget := false
+	if fallback != "" {
+		decision = s.transferFallbackDecision(firmID, settings, fallback)
+	} else if s.isOverCapacity() {
+		decision = routing.Overflow(decision, settings, "concurrency_limit")
+	} else if decision.Action == firm.ActionAI && s.overBudget(firmID, settings) {
+		decision = routing.OverBudget(decision, settings)
+		overBudget = true
+	} else if decision.Action == firm.ActionAI && !s.admitCall() {
+		decision = routing.Overflow(decision, settings, "load_shed")
+	}
+
+	s.logger.Info().
+		Str("action", decision.Action).
+		Str("reason", decision.Reason).
+		Bool("override", decision.Override).
+		Msg("Routing decision")
+
+	if overBudget {
+		s.playBudgetMessage(settings)
+	}
+
+	switch decision.Action {
+	case firm.ActionVoicemail:
+		s.startVoicemail(settings)
+
+	case firm.ActionTransfer:
+		if err := s.transferCall(decision.TransferTo, ""); err != nil && overBudget {
+			// The AI is what the budget holds back, so take a message instead
+			s.logger.Error().Err(err).Str("transfer_to", decision.TransferTo).Msg("Transfer failed, taking voicemail")
+			decision = routing.Decision{
+				Action:   firm.ActionVoicemail,
+				Reason:   "transfer_failed: " + decision.Reason,
+				Override: decision.Override,
+			}
+			s.startVoicemail(settings)
+		} else if err != nil {
+			// Keep the caller on the line with the AI rather than dropping them
+			s.logger.Error().Err(err).Str("transfer_to", decision.TransferTo).Msg("Transfer failed, continuing with AI")
+			decision = routing.Decision{
+				Action:   firm.ActionAI,
+				Reason:   "transfer_failed: " + decision.Reason,
+				Override: decision.Override,
+			}
+		}
+	}
+
+	if decision.Action == firm.ActionAI {
+		if fallback != "" {
+			if err := s.speak(s.localize(i18n.TransferFailed, transferFailedMessage)); err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to play transfer failure message")
+			}
+		}
+		s.startAIDisclosure(settings)
+	}
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Routing = cdr.Routing{
+			Action:     decision.Action,
+			Reason:     decision.Reason,
+			TransferTo: decision.TransferTo,
+			Override:   decision.Override,
+		}
+	})
+}
+
+// transferCall redirects the call via the Twilio REST API; Twilio ends the
+// media stream once the new TwiML takes over. An empty number, or the firm's
+// transfer_number, rings the firm's hunt group when it has one. When the firm
+// wants warm transfers, whoever answers first hears a summary of the call;
+// summary is the assistant's account of it, if it gave one
+func (s *CallSession) transferCall(number, summary string) error {
+	if s.services.Twilio == nil {
+		return fmt.Errorf("twilio API not configured")
+	}
+
+	settings := s.firmSettings()
+	var group []firm.TransferTarget
+	if settings != nil {
+		if number == "" {
+			number = settings.Routing.TransferNumber
+		}
+		if number == settings.Routing.TransferNumber {
+			group = settings.Transfer.Targets
+		}
+	}
+	if number == "" && len(group) == 0 {
+		return fmt.Errorf("no transfer number given and none configured for the firm")
+	}
+
+	s.mu.RLock()
+	callSid := s.callSid
+	s.mu.RUnlock()
+
+	whisper := ""
+	if settings != nil && settings.Transfer.Whisper && s.services.Transfers != nil {
+		whisper = whisperText(settings.Transfer.WhisperIntro, s.transferSummary(summary))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	var err error
+	switch {
+	case len(group) > 0:
+		err = s.services.Twilio.RedirectCall(ctx, callSid, s.huntTwiML(settings, group, whisper))
+	case whisper != "":
+		err = s.services.Twilio.WarmTransferCall(ctx, callSid, number, s.services.Transfers.WhisperURL(whisper))
+	default:
+		err = s.services.Twilio.TransferCall(ctx, callSid, number)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger := s.logger.Info().Bool("whisper", whisper != "")
+	if len(group) > 0 {
+		logger = logger.Int("targets", len(group)).Str("ring", settings.Transfer.Ring)
+	} else {
+		logger = logger.Str("transfer_to", number)
+	}
+	logger.Msg("Call transferred")
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Disposition = "transferred"
+		r.TransferWhisper = whisper
+	})
+	return nil
+}
+
+// huntTwiML returns TwiML ringing the firm's hunt group; a caller nobody
+// answers is reconnected with this call's stream parameters and the firm's fallback
+func (s *CallSession) huntTwiML(settings *firm.Settings, group []firm.TransferTarget, whisper string) string {
+	plan := transfer.Plan{
+		Simultaneous: settings.Transfer.Ring == firm.RingSimultaneous,
+		Whisper:      whisper,
+		Fallback:     settings.Transfer.Fallback,
+		Stream:       s.streamParameters(),
+	}
+	for _, target := range group {
+		plan.Targets = append(plan.Targets, transfer.Target{Number: target.Number, Timeout: target.Timeout()})
+	}
+	if s.services.Transfers != nil {
+		return s.services.Transfers.Hunt(plan)
+	}
+
+	// Without the transfer webhooks there is no next step: ring everyone at once
+	s.logger.Warn().Msg("Transfer webhooks not configured, ringing the hunt group at once without a fallback")
+	d := twilio.Dial{}
+	for _, target := range plan.Targets {
+		d.Numbers = append(d.Numbers, target.Number)
+		d.Timeout = max(d.Timeout, target.Timeout)
+	}
+	return d.TwiML()
+}
+
+// streamParameters returns the parameters the call's stream started with,
+// for reconnecting the caller to a new stream
+func (s *CallSession) streamParameters() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return map[string]string{
+		twilio.ParamFirmID:   s.firmID,
+		twilio.ParamUserID:   s.userID,
+		twilio.ParamCallID:   s.callID,
+		twilio.ParamLanguage: s.language,
+		twilio.ParamCampaign: s.campaign,
+		twilio.ParamFrom:     s.callerNumber,
+		twilio.ParamTo:       s.calledNumber,
+	}
+}
+
+// transferFallbackDecision routes a caller reconnected after nobody answered
+// a hunt group; they never go back to the transfer, and get voicemail
+// rather than the assistant when it is not available to them
+func (s *CallSession) transferFallbackDecision(firmID string, settings *firm.Settings, fallback string) routing.Decision {
+	decision := routing.Decision{Action: fallback, Reason: "transfer_unanswered"}
+	if fallback == firm.ActionAI && (s.isOverCapacity() || s.overBudget(firmID, settings) || !s.admitCall()) {
+		decision.Action = firm.ActionVoicemail
+	}
+	return decision
+}
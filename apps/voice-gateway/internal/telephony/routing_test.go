@@ -0,0 +1,79 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+const huntGroupFirms = `{"firms": {"acme": {
+	"routing": {"transfer_number": "+15550000009"},
+	"transfer": {"ring": "simultaneous", "fallback": "voicemail", "targets": [
+		{"number": "+15550000001", "name": "Attorney cell", "timeout_seconds": 15},
+		{"number": "+15550000002", "name": "Front desk"}
+	]}
+}}}`
+
+func TestCallSession_TransferCallRingsHuntGroup(t *testing.T) {
+	var gotTwiml string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotTwiml = r.PostForm.Get("Twiml")
+		w.Write([]byte(`{"sid":"CA1"}`))
+	}))
+	defer server.Close()
+
+	s := newLanguageTestSession(t, huntGroupFirms)
+	s.callSid = "CA1"
+	s.callerNumber = "+15551234567"
+	s.services.Twilio = twilio.NewClient("AC1", "token", server.URL)
+	s.services.Transfers = transfer.NewWebhooks("https://gateway.example.com", "token", zerolog.Nop())
+
+	// The firm's transfer_number stands for the hunt group
+	for _, number := range []string{"", "+15550000009"} {
+		if err := s.transferCall(number, ""); err != nil {
+			t.Fatalf("transferCall(%q) failed: %v", number, err)
+		}
+		for _, want := range []string{
+			`<Dial timeout="20" action="https://gateway.example.com/twilio/transfer/next?`,
+			"<Number>+15550000001</Number><Number>+15550000002</Number>",
+			"fallback=voicemail",
+			"s.from=%2B15551234567",
+		} {
+			if !strings.Contains(gotTwiml, want) {
+				t.Errorf("transferCall(%q): expected TwiML containing %s, got %s", number, want, gotTwiml)
+			}
+		}
+	}
+
+	// Any other number is dialed directly
+	if err := s.transferCall("+15557654321", ""); err != nil {
+		t.Fatalf("transferCall() failed: %v", err)
+	}
+	if gotTwiml != twilio.DialTwiML("+15557654321") {
+		t.Errorf("Expected a direct transfer, got %s", gotTwiml)
+	}
+}
+
+func TestCallSession_TransferFallbackDecision(t *testing.T) {
+	s := newLanguageTestSession(t, huntGroupFirms)
+	settings := s.firmSettings()
+
+	if got := s.transferFallbackDecision("acme", settings, firm.ActionAI); got.Action != firm.ActionAI || got.Reason != "transfer_unanswered" {
+		t.Errorf("Expected the assistant to resume, got %+v", got)
+	}
+	if got := s.transferFallbackDecision("acme", settings, firm.ActionVoicemail); got.Action != firm.ActionVoicemail {
+		t.Errorf("Expected voicemail, got %+v", got)
+	}
+
+	s.overCapacity = true
+	if got := s.transferFallbackDecision("acme", settings, firm.ActionAI); got.Action != firm.ActionVoicemail {
+		t.Errorf("Expected voicemail when the firm is over capacity, got %+v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package telephony
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/screening"
+)
+
+const (
+	// screeningTimeout bounds the reputation lookup at call start
+	screeningTimeout = 3 * time.Second
+
+	// challengeMinSpeechChunks is how much caller speech (20ms chunks) passes
+	// the voice challenge; robocalls rarely answer a prompt with live speech
+	challengeMinSpeechChunks = 20
+)
+
+// challengeState tracks a pending voice/keypress challenge
+type challengeState struct {
+	mu           sync.Mutex
+	resolved     bool
+	speechChunks int
+	timer        *time.Timer
+	onPass       func()
+}
+
+// resolve marks the challenge decided; returns false if it already was
+func (c *challengeState) resolve() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resolved {
+		return false
+	}
+	c.resolved = true
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	return true
+}
+
+// screenCaller runs spam screening for the caller before any pipeline
+// resources are spent
+func (s *CallSession) screenCaller(settings *firm.Settings) screening.Result {
+	if s.services.Screener == nil {
+		return screening.Result{Verdict: screening.Allow}
+	}
+
+	s.mu.RLock()
+	number := s.callerNumber
+	s.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), screeningTimeout)
+	defer cancel()
+
+	result, err := s.services.Screener.Screen(ctx, number, screening.Policy{
+		Blocklist:     settings.Screening.Blocklist,
+		ChallengeMode: settings.Screening.ChallengeMode,
+	})
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Caller reputation lookup failed, allowing call")
+	}
+
+	s.logger.Info().
+		Str("verdict", string(result.Verdict)).
+		Str("reason", result.Reason).
+		Float64("score", result.Score).
+		Msg("Caller screened")
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.Screening = &cdr.Screening{
+			Verdict: string(result.Verdict),
+			Reason:  result.Reason,
+			Score:   result.Score,
+		}
+	})
+
+	switch result.Verdict {
+	case screening.Block:
+		observability.RecordScreening("block")
+	case screening.Allow:
+		observability.RecordScreening("allow")
+	}
+	return result
+}
+
+// rejectCall ends a screened-out call without engaging the pipeline
+func (s *CallSession) rejectCall(disposition string) {
+	s.cdr.Update(func(r *cdr.Record) { r.Disposition = disposition })
+	s.endCall(disposition)
+}
+
+// startChallenge prompts the caller to speak or press a key; onPass runs once
+// the caller responds, otherwise the call is ended when the timeout expires
+func (s *CallSession) startChallenge(settings *firm.Settings, onPass func()) {
+	ch := &challengeState{onPass: onPass}
+
+	s.mu.Lock()
+	s.challenge = ch
+	s.mu.Unlock()
+
+	if err := s.speak(s.localize(i18n.ChallengePrompt, settings.Screening.ChallengePrompt)); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to play screening challenge")
+	}
+
+	timeout := time.Duration(settings.Screening.ChallengeTimeoutSeconds) * time.Second
+	ch.mu.Lock()
+	ch.timer = time.AfterFunc(timeout, func() {
+		if !ch.resolve() {
+			return
+		}
+		observability.RecordScreening("challenge_failed")
+		s.cdr.Update(func(r *cdr.Record) { r.Screening.Challenge = "failed" })
+		s.logger.Info().Msg("Caller did not answer screening challenge")
+		s.rejectCall("challenge_failed")
+	})
+	ch.mu.Unlock()
+}
+
+// pendingChallenge returns the active challenge, or nil once passed or never started
+func (s *CallSession) pendingChallenge() *challengeState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.challenge
+}
+
+// passChallenge admits the caller and starts the pipeline
+func (s *CallSession) passChallenge(ch *challengeState, how string) {
+	if !ch.resolve() {
+		return
+	}
+	observability.RecordScreening("challenge_passed")
+	s.cdr.Update(func(r *cdr.Record) { r.Screening.Challenge = "passed" })
+	s.logger.Info().Str("response", how).Msg("Caller passed screening challenge")
+
+	s.goSafe("screening_pass", func() {
+		ch.onPass()
+
+		// Audio keeps bypassing STT until the pipeline is up
+		s.mu.Lock()
+		s.challenge = nil
+		s.mu.Unlock()
+	})
+}
+
+// feedChallenge counts caller speech toward the challenge
+func (s *CallSession) feedChallenge(ch *challengeState, speaking bool) {
+	if !speaking {
+		return
+	}
+	ch.mu.Lock()
+	ch.speechChunks++
+	passed := ch.speechChunks >= challengeMinSpeechChunks
+	ch.mu.Unlock()
+
+	if passed {
+		s.passChallenge(ch, "speech")
+	}
+}
@@ -0,0 +1,149 @@
+package telephony
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// selfEchoMinWords is the shortest final checked against the agent's speech;
+// short answers ("yes", "that's right") echo the agent's words too often to
+// be dropped on a match
+const selfEchoMinWords = 3
+
+// selfEchoTail is how long after the agent's audio stops a final can still
+// be its echo. Later finals are the caller, who may well repeat the agent's
+// words back (reading back an address or a case number)
+const selfEchoTail = 2 * time.Second
+
+// ssmlTag matches SSML markup added to text for synthesis
+var ssmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// spokenText is text sent to TTS, as words
+type spokenText struct {
+	words []string
+	at    time.Time
+}
+
+// spokenHistory is the text the agent spoke recently, for recognizing its own
+// speech when STT transcribes it back off the caller's line
+type spokenHistory struct {
+	mu      sync.Mutex
+	entries []spokenText
+	played  time.Time // When agent audio was last sent to the caller
+}
+
+// matchWords lowercases text to words of letters and digits, without markup
+func matchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(ssmlTag.ReplaceAllString(text, " ")), func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'')
+	})
+}
+
+// add records text sent to TTS at now, forgetting text older than window
+func (h *spokenHistory) add(text string, now time.Time, window time.Duration) {
+	words := matchWords(text)
+	if len(words) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.expire(now, window)
+	h.entries = append(h.entries, spokenText{words: words, at: now})
+}
+
+// playing records that agent audio was sent to the caller at now
+func (h *spokenHistory) playing(now time.Time) {
+	h.mu.Lock()
+	h.played = now
+	h.mu.Unlock()
+}
+
+// audible reports whether agent audio was sent to the caller within
+// selfEchoTail of now
+func (h *spokenHistory) audible(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return !h.played.IsZero() && now.Sub(h.played) <= selfEchoTail
+}
+
+// expire drops text spoken longer than window ago. Callers hold mu
+func (h *spokenHistory) expire(now time.Time, window time.Duration) {
+	keep := 0
+	for keep < len(h.entries) && now.Sub(h.entries[keep].at) > window {
+		keep++
+	}
+	h.entries = h.entries[keep:]
+}
+
+// similarity returns how closely text matches some stretch of the agent's
+// speech within window, from 0 to 1: one minus the fewest word edits turning
+// text into consecutive spoken words, over text's length. Responses are
+// synthesized a sentence at a time, so the spoken text is matched as one run
+func (h *spokenHistory) similarity(text string, now time.Time, window time.Duration) float64 {
+	words := matchWords(text)
+	if len(words) < selfEchoMinWords {
+		return 0
+	}
+	h.mu.Lock()
+	h.expire(now, window)
+	var spoken []string
+	for _, entry := range h.entries {
+		spoken = append(spoken, entry.words...)
+	}
+	h.mu.Unlock()
+	if len(spoken) == 0 {
+		return 0
+	}
+
+	// Edit distance from words to any substring of spoken: starting and
+	// ending anywhere in spoken is free
+	prev, row := make([]int, len(spoken)+1), make([]int, len(spoken)+1)
+	for i := 1; i <= len(words); i++ {
+		row[0] = i
+		for j := 1; j <= len(spoken); j++ {
+			cost := 1
+			if words[i-1] == spoken[j-1] {
+				cost = 0
+			}
+			row[j] = min(prev[j-1]+cost, prev[j]+1, row[j-1]+1)
+		}
+		prev, row = row, prev
+	}
+	best := len(words)
+	for _, d := range prev {
+		best = min(best, d)
+	}
+	return 1 - float64(best)/float64(len(words))
+}
+
+// noteSpoken remembers text sent to TTS for self-echo filtering
+func (s *CallSession) noteSpoken(text string) {
+	if s.config != nil && s.config.SelfEchoSimilarity > 0 {
+		s.spoken.add(text, time.Now(), time.Duration(s.config.SelfEchoWindowMs)*time.Millisecond)
+	}
+}
+
+// isSelfEcho returns whether a final transcript is the agent's own recent
+// speech picked up on the caller's line, which must not be answered. Only
+// finals heard while the agent is speaking, or just after, are checked
+func (s *CallSession) isSelfEcho(text string) bool {
+	if s.config == nil || s.config.SelfEchoSimilarity <= 0 {
+		return false
+	}
+	now := time.Now()
+	if !s.spoken.audible(now) {
+		return false
+	}
+	score := s.spoken.similarity(text, now, time.Duration(s.config.SelfEchoWindowMs)*time.Millisecond)
+	if score < s.config.SelfEchoSimilarity {
+		return false
+	}
+	s.logger.Info().Str("text", text).Float64("similarity", score).Msg("Dropping transcript of the agent's own speech")
+	observability.RecordSelfEchoDropped()
+	return true
+}
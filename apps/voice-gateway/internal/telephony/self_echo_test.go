@@ -0,0 +1,66 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+func TestSpokenHistory_Similarity(t *testing.T) {
+	var h spokenHistory
+	now := time.Now()
+	window := 15 * time.Second
+	h.add("Thanks for calling Smith & Jones. How can I help you today?", now, window)
+	h.add(`Could you spell your last name?<break time="500ms"/> Take your time.`, now, window)
+
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{"how can I help you today", 1},
+		{"how can I help you to day", 5.0 / 7},          // One word misheard as two
+		{"spell your last name take your", 1},           // Across sentences and markup
+		{"thanks for calling smith and jones", 5.0 / 6}, // "&" is not spoken as a word
+		{"I need help with a will", 2.0 / 6},
+		{"yes thanks", 0}, // Too short to judge
+	}
+	for _, tt := range tests {
+		if got := h.similarity(tt.text, now, window); got < tt.want-0.01 || got > tt.want+0.01 {
+			t.Errorf("similarity(%q) = %.2f, want %.2f", tt.text, got, tt.want)
+		}
+	}
+
+	if got := h.similarity("how can I help you today", now.Add(20*time.Second), window); got != 0 {
+		t.Errorf("Expected speech outside the window forgotten, got %.2f", got)
+	}
+}
+
+func TestCallSession_IsSelfEcho(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.config = &config.Config{SelfEchoSimilarity: 0.8, SelfEchoWindowMs: 15000}
+	s.noteSpoken("I can schedule a consultation for Tuesday at ten.")
+	if s.isSelfEcho("schedule a consultation for Tuesday at 10") {
+		t.Error("Expected no filtering before the agent's audio played")
+	}
+
+	s.spoken.playing(time.Now())
+	if !s.isSelfEcho("schedule a consultation for Tuesday at 10") {
+		t.Error("Expected the agent's own sentence to be recognized")
+	}
+	if s.isSelfEcho("Tuesday doesn't work for me") {
+		t.Error("Expected the caller's answer to pass")
+	}
+
+	// The caller reading the agent's words back after it finished speaking
+	s.spoken.playing(time.Now().Add(-selfEchoTail - time.Second))
+	if s.isSelfEcho("schedule a consultation for Tuesday at 10") {
+		t.Error("Expected a read-back after the agent stopped speaking to pass")
+	}
+
+	s.spoken.playing(time.Now())
+	s.config.SelfEchoSimilarity = 0
+	if s.isSelfEcho("schedule a consultation for Tuesday at ten") {
+		t.Error("Expected no filtering when disabled")
+	}
+}
@@ -0,0 +1,135 @@
+package telephony
+
+import (
+	"github.com/lexiqai/voice-gateway/internal/callback"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+	"github.com/lexiqai/voice-gateway/internal/encryption"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/flags"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/lockout"
+	"github.com/lexiqai/voice-gateway/internal/notify"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/playback"
+	"github.com/lexiqai/voice-gateway/internal/preflight"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/screening"
+	"github.com/lexiqai/voice-gateway/internal/slo"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+	"github.com/lexiqai/voice-gateway/internal/storage"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+	"github.com/lexiqai/voice-gateway/internal/translate"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+)
+
+// Services bundles the process-wide dependencies shared by every call session
+type Services struct {
+	// Firms resolves per-firm settings
+	Firms *firm.Registry
+
+	// Router decides at call start whether a call goes to the AI, voicemail, or a transfer
+	Router *routing.Engine
+
+	// Screener rejects or challenges suspected spam before the pipeline starts
+	Screener *screening.Screener
+
+	// Storage persists recordings and voicemail
+	Storage storage.Store
+
+	// Audio loads pre-recorded files (greetings, disclaimers, hold music) for playback
+	Audio *playback.Loader
+
+	// Events delivers gateway events (voicemail, call lifecycle) to external sinks
+	Events events.Publisher
+
+	// Encryption seals payloads sent to firms' own webhooks, as Storage and
+	// Events already seal theirs; nil when encryption at rest is off
+	Encryption *encryption.Encryptor
+
+	// Email sends notification emails; nil when SMTP is not configured
+	Email *notify.EmailSender
+
+	// Twilio controls live calls (transfer, hangup); nil when not configured
+	Twilio *twilio.Client
+
+	// Transfers serves the TwiML Twilio fetches for warm and hunt group
+	// transfers; nil (Twilio or VOICE_GATEWAY_URL not configured) makes every
+	// transfer cold, and hunt groups ring at once without a fallback
+	Transfers *transfer.Webhooks
+
+	// SMS sends templated follow-up texts; nil when Twilio is not configured
+	SMS *sms.Sender
+
+	// Live streams transcripts and turn events to supervisor dashboards
+	Live *live.Hub
+
+	// Calls indexes active sessions so operators can supervise them
+	Calls *CallRegistry
+
+	// Streams holds sessions waiting for their dropped Twilio stream to
+	// reconnect; nil ends calls as soon as the connection drops
+	Streams *StreamRegistry
+
+	// Cluster shares active calls with other instances and enforces per-firm
+	// concurrency limits; in-memory when running a single instance
+	Cluster cluster.Registry
+
+	// Callbacks queues the calls the assistant promises (schedule_callback);
+	// nil when outbound calling is not configured
+	Callbacks *callback.Scheduler
+
+	// Flags decides which rollout-gated behaviours each call gets; nil turns
+	// every flag off
+	Flags *flags.Set
+
+	// Preflight refuses media streams while the startup preflight is failing
+	// in degraded mode; nil takes every call
+	Preflight *preflight.Gate
+
+	// Admission sheds new AI calls to overflow when orchestrator and TTS latency
+	// show the backends are saturated; nil admits every call
+	Admission *resilience.AdaptiveLimiter
+
+	// ResponseSLO tracks time from the end of caller speech to the reply's
+	// first audio against its objective; nil only records the latency histogram
+	ResponseSLO *slo.Tracker
+
+	// Messages translates gateway speech for non-English calls, and Voices
+	// picks the TTS voice for the call's language; nil keeps English and the default voice
+	Messages *i18n.Catalog
+	Voices   i18n.Voices
+
+	// Translator relays calls between the caller's language and the firm's,
+	// for firms that turn translation on; nil leaves every call untranslated
+	Translator translate.Translator
+
+	// Origins decides which browsers may open the media stream; nil allows
+	// same-origin browsers only (Twilio sends no Origin and is always accepted)
+	Origins *cors.Policy
+
+	// VerifyFailures counts wrong verify_caller answers per contact or caller
+	// number across calls and instances; nil refuses caller verification
+	VerifyFailures lockout.Counter
+
+	// Usage totals each firm's billable usage; nil skips the per-firm totals and budget caps
+	Usage *usage.Ledger
+
+	// Health tracking for call pipeline startup and recording uploads; nil skips reporting
+	SessionHealth   *observability.Subsystem
+	RecordingHealth *observability.Subsystem
+}
+
+// webhookPublisher returns a publisher for a firm's own webhook URL, sealing
+// payloads like the gateway's event sink when encryption is on
+func (svc *Services) webhookPublisher(url, secret string) events.Publisher {
+	var publisher events.Publisher = events.NewWebhookPublisher(url, secret)
+	if svc.Encryption != nil {
+		publisher = encryption.NewPublisher(publisher, svc.Encryption)
+	}
+	return publisher
+}
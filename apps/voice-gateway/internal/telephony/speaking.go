@@ -0,0 +1,40 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// useFirmSpeakingStyle sets the firm's TTS speaking rate and keeps its pause
+// and barge-in tuning for the turn manager
+func (s *CallSession) useFirmSpeakingStyle(settings *firm.Settings) {
+	s.mu.Lock()
+	s.speaking = settings.Speaking
+	s.mu.Unlock()
+
+	if setter, ok := s.ttsClient.(tts.SpeedSetter); ok && settings.Speaking.Speed > 0 {
+		setter.SetSpeed(settings.Speaking.Speed)
+	}
+	s.logger.Debug().
+		Float64("speed", settings.Speaking.Speed).
+		Int("question_pause_ms", settings.Speaking.QuestionPauseMs).
+		Int("barge_in_ms", settings.Speaking.BargeInMs).
+		Msg("Using the firm's speaking style")
+}
+
+// pauseAfterQuestions gives the caller the firm's pause to answer each
+// question in a response before the agent goes on
+func (s *CallSession) pauseAfterQuestions(text string) string {
+	s.mu.RLock()
+	pause := time.Duration(s.speaking.QuestionPauseMs) * time.Millisecond
+	s.mu.RUnlock()
+	return tts.PauseAfterQuestions(text, pause)
+}
+
+// bargeInDelay is how long the caller must talk before the agent stops
+// speaking; the caller holds s.mu
+func (s *CallSession) bargeInDelay() time.Duration {
+	return time.Duration(s.speaking.BargeInMs) * time.Millisecond
+}
@@ -0,0 +1,53 @@
+package telephony
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// speakingTTS is always mid-synthesis, and records the speed and cancellations it gets
+type speakingTTS struct {
+	speed     float64
+	cancelled int
+}
+
+func (v *speakingTTS) Enqueue(string) (<-chan *tts.AudioChunk, error) { return nil, nil }
+func (v *speakingTTS) Flush(context.Context) error                    { return nil }
+func (v *speakingTTS) CancelAll()                                     { v.cancelled++ }
+func (v *speakingTTS) Close() error                                   { return nil }
+func (v *speakingTTS) IsActive() bool                                 { return true }
+func (v *speakingTTS) SetSpeed(speed float64)                         { v.speed = speed }
+
+func TestCallSession_SpeakingStyle(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"speaking": {"speed": 0.85, "question_pause_ms": 400, "barge_in_ms": 250}}}}`)
+	voice := &speakingTTS{}
+	s.ttsClient = voice
+	s.useFirmSpeakingStyle(s.firmSettings())
+
+	if voice.speed != 0.85 {
+		t.Errorf("Expected the firm's speed 0.85, got %v", voice.speed)
+	}
+	expected := `Is Tuesday good?<break time="400ms"/> Or Wednesday?<break time="400ms"/>`
+	if got := s.pauseAfterQuestions("Is Tuesday good? Or Wednesday?"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// The caller has only just started talking over the agent
+	var p audio.Packet
+	s.isTalking = true
+	s.talkingSince = time.Now()
+	s.bargeIn(&p)
+	if voice.cancelled != 0 {
+		t.Error("Expected the agent to keep talking before the barge-in time")
+	}
+
+	s.talkingSince = time.Now().Add(-300 * time.Millisecond)
+	s.bargeIn(&p)
+	if voice.cancelled != 1 {
+		t.Errorf("Expected the agent to stop once the caller talked past the barge-in time, got %d cancels", voice.cancelled)
+	}
+}
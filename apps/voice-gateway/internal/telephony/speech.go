@@ -0,0 +1,87 @@
+package telephony
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/live"
+)
+
+// hangupDrainTimeout bounds how long a hangup waits for queued audio
+const hangupDrainTimeout = 10 * time.Second
+
+// speak synthesizes gateway-generated text (greetings, prompts) and queues
+// the audio for playback to the caller
+func (s *CallSession) speak(text string) error {
+	if s.ttsClient == nil {
+		return fmt.Errorf("TTS client not available")
+	}
+	s.stopAudio(true)
+
+	audioChan, err := s.synthesize(text)
+	if err != nil {
+		return fmt.Errorf("failed to synthesize prompt: %w", err)
+	}
+	s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerAgent, Text: text, Final: true})
+
+	s.goSafe("speak", func() {
+		for audioChunk := range audioChan {
+			select {
+			case s.audioOut <- audioChunk.Data:
+			case <-s.done:
+				return
+			}
+		}
+		s.markUtterance(markPrompt)
+	})
+	return nil
+}
+
+// endCall ends the call from the gateway side by closing the media stream
+// With <Connect><Stream>, Twilio moves on to the next TwiML verb, which ends the call
+func (s *CallSession) endCall(reason string) {
+	s.logger.Info().Str("reason", reason).Msg("Ending call from gateway")
+	s.endedByGateway.Store(true)
+
+	s.mu.Lock()
+	s.isActive = false
+	conn := s.conn
+	s.mu.Unlock()
+
+	if err := conn.Close(); err != nil {
+		s.logger.Debug().Err(err).Msg("Error closing Twilio WebSocket")
+	}
+}
+
+// hangup ends the call, via the Twilio API when configured so the caller is
+// disconnected even if the TwiML continues past the stream
+func (s *CallSession) hangup(reason string) {
+	// Twilio's stop event may arrive before the stream is closed
+	s.endedByGateway.Store(true)
+	if s.services != nil && s.services.Twilio != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+		defer cancel()
+		if err := s.services.Twilio.HangupCall(ctx, s.GetCallSid()); err != nil {
+			s.logger.Warn().Err(err).Msg("Twilio hangup failed, closing stream instead")
+		}
+	}
+	s.endCall(reason)
+}
+
+// waitForPlayback blocks until queued outbound audio has been sent, TTS is
+// idle and Twilio reports the caller has heard it, or until timeout
+func (s *CallSession) waitForPlayback(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		ttsActive := s.ttsClient != nil && s.ttsClient.IsActive()
+		if len(s.audioOut) == 0 && !ttsActive && !s.playbackActive() && s.marks.pendingCount() == 0 {
+			return
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-s.done:
+			return
+		}
+	}
+}
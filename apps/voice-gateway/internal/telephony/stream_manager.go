@@ -9,28 +9,31 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/chaos"
 	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/contacts"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/flags"
+	"github.com/lexiqai/voice-gateway/internal/live"
 	"github.com/lexiqai/voice-gateway/internal/observability"
 	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+	"github.com/lexiqai/voice-gateway/internal/screening"
 	"github.com/lexiqai/voice-gateway/internal/stt"
 	"github.com/lexiqai/voice-gateway/internal/tts"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
 	"github.com/rs/zerolog"
 )
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		// In production, validate origin against Twilio's IP ranges
-		// For now, allow all origins (development only)
-		return true
-	},
-	ReadBufferSize:  4096,
-	WriteBufferSize: 4096,
-}
+// cdrPublishTimeout bounds delivery of the call detail record at call end
+const cdrPublishTimeout = 10 * time.Second
 
 // TwilioMessage represents a message from Twilio Media Streams
 type TwilioMessage struct {
@@ -42,23 +45,25 @@ type TwilioMessage struct {
 	Media      *TwilioMedia `json:"media,omitempty"`
 	Start      *TwilioStart `json:"start,omitempty"`
 	Stop       *TwilioStop  `json:"stop,omitempty"`
+	DTMF       *TwilioDTMF  `json:"dtmf,omitempty"`
+	Mark       *TwilioMark  `json:"mark,omitempty"`
 }
 
 // TwilioMedia represents the media payload in a media event
 type TwilioMedia struct {
 	Track     string `json:"track"`
-	Chunk     string `json:"chunk"` // Base64 encoded audio
+	Chunk     string `json:"chunk"` // Chunk sequence number (older senders put base64 audio here)
 	Timestamp string `json:"timestamp"`
 	Payload   string `json:"payload"` // Alternative field name for chunk
 }
 
 // TwilioStart represents the start event payload
 type TwilioStart struct {
-	AccountSid       string                 `json:"accountSid"`
-	CallSid          string                 `json:"callSid"`
-	Tracks           []string               `json:"tracks"`
-	StreamSid        string                 `json:"streamSid"`
-	CustomParameters map[string]interface{} `json:"customParameters,omitempty"`
+	AccountSid       string                  `json:"accountSid"`
+	CallSid          string                  `json:"callSid"`
+	Tracks           []string                `json:"tracks"`
+	StreamSid        string                  `json:"streamSid"`
+	CustomParameters twilio.StreamParameters `json:"customParameters,omitempty"`
 }
 
 // TwilioStop represents the stop event payload
@@ -68,10 +73,36 @@ type TwilioStop struct {
 	StreamSid  string `json:"streamSid"`
 }
 
+// TwilioMark names a mark message: sent after outbound audio, and echoed back
+// by Twilio once that audio has played (or been cleared)
+type TwilioMark struct {
+	Name string `json:"name"`
+}
+
+// TwilioDTMF represents a keypress reported in a dtmf event
+type TwilioDTMF struct {
+	Track string `json:"track"`
+	Digit string `json:"digit"`
+}
+
 // CallSession holds the state of a single phone call
 type CallSession struct {
-	// Connection
-	conn *websocket.Conn
+	// Connection; every write goes through writer, and readBuf is reused for each message read
+	conn    Conn
+	writer  *twilioWriter
+	readBuf []byte
+	pending [][]byte // Handshake messages read before the session started, processed first
+
+	// readDeadlineAt is when reading last pushed the read deadline back
+	readDeadlineAt time.Time
+
+	// Reconnect grace: a new connection for the same stream arrives on
+	// reconnect while reconnecting is set
+	reconnect    chan *websocket.Conn
+	reconnecting atomic.Bool
+
+	// Access log record for the connection (nil when access logging is off)
+	access *observability.AccessRecord
 
 	// Session identifiers
 	callSid    string
@@ -81,24 +112,183 @@ type CallSession struct {
 	// State management
 	mu             sync.RWMutex
 	isActive       bool
+	readErr        error // What ended reading from Twilio; nil if the gateway ended the call first
 	isTalking      bool
+	talkingSince   time.Time // When local VAD heard the caller start talking
 	conversationID string
 
 	// Firm and user identification (from Twilio custom parameters)
-	firmID string
-	userID string
-	callID string // Internal call ID from database
+	firmID       string
+	userID       string
+	callID       string // Internal call ID from database
+	callerNumber string // Caller's phone number (E.164), if provided
+	calledNumber string // Firm number that was dialed (E.164), if provided
+	language     string // Speech recognition language, when the stream overrides it
+	campaign     string // Campaign label, if provided
+	script       string // Outbound campaign script context, if provided
+
+	// transferFallback is set when the caller was reconnected after nobody
+	// answered a hunt group transfer: "ai" or "voicemail"
+	transferFallback string
+	rejecting        bool // Playing a goodbye before hanging up; caller audio is dropped
+
+	// Language STT heard the caller speak, with a multilingual model
+	detectedLanguage string
+
+	// Caller speech so far in the current segment, merged from interim
+	// results, for live dashboards and speculative orchestrator turns
+	partial stt.PartialTranscript
+
+	// Speech recognition provider, Deepgram models and transcript formatting
+	// the firm chose, when it overrides the gateway's
+	sttProvider      string
+	sttModel         string
+	sttFallbackModel string
+	sttFormatting    *firm.STTFormatting
+
+	// Speech synthesis provider the firm chose; empty uses TTS_PROVIDER
+	ttsProvider string
+
+	// Provider endpoints in the firm's required regions; empty uses the gateway's
+	sttHost string
+	ttsURL  string
+
+	// Spells out numbers in English speech before synthesis (nil when disabled)
+	verbalizer *tts.Verbalizer
+
+	// Firm pronunciations applied to text before synthesis (nil for none)
+	lexicon *tts.Lexicon
+
+	// Firm speaking style: pauses after questions, and how long the caller
+	// must talk over the agent to interrupt it (guarded by mu)
+	speaking firm.SpeakingSettings
+
+	// AI-disclosure tone mixed into agent audio (nil unless the firm discloses by tone; guarded by mu)
+	watermark *audio.Watermark
+
+	// CRM match for the caller (nil when unknown); contactReady closes once
+	// the lookup finishes, and metadataSent marks the context as delivered
+	contact      *contacts.Contact
+	contactReady chan struct{}
+	metadataSent bool
+
+	// Telephony tool execution: results stream back to the orchestrator,
+	// and dtmfDigits receives keypresses for collect_dtmf
+	toolMu      sync.Mutex
+	toolResults *orchestrator.ToolResultStream
+	dtmfDigits  chan string
+
+	// Activity tracking for the inactivity limit
+	activityMu   sync.Mutex
+	lastActivity time.Time
+	promptedAt   time.Time // Set while an "are you still there?" prompt awaits a reply
+
+	// Screening challenge (non-nil while the caller has yet to pass it)
+	challenge *challengeState
+
+	// Recording consent question (non-nil while awaiting the caller's answer)
+	consent *consentState
+
+	// Confirm tool question (non-nil while awaiting the caller's yes or no)
+	confirmation *confirmationState
+
+	// Caller identity check (non-nil while awaiting the caller's PIN or code);
+	// verifiedBy is how the caller proved who they are. Wrong answers are
+	// counted per contact in Services.VerifyFailures, not per call
+	verification *verificationState
+	verifiedBy   string
+
+	// Latest caller turn sent to the orchestrator; text from earlier turns is
+	// dropped, and a turn still streaming when the next starts is cancelled
+	turn       atomic.Uint64
+	turnMu     sync.Mutex
+	activeTurn *orchestratorTurn
+
+	// Telephony tools still executing; holds off the orchestrator stall watchdog
+	toolsInFlight atomic.Int32
+
+	// Failures that cost the caller an answer, for classifying the call's outcome
+	technicalFailures atomic.Int32
+
+	// Final speech so far, and how the call stood if the caller hung up, for call.abandoned
+	transcript     callTranscript
+	hungUp         *callerHangup
+	endedByGateway atomic.Bool
+
+	// First-response timing: when local VAD last heard the caller stop, and
+	// the start (unix nanoseconds) of a turn awaiting its reply's first audio
+	speechEndedAt time.Time
+	responseDue   atomic.Int64
+
+	// overCapacity is set when the firm was at its concurrent call limit at call start
+	overCapacity bool
+
+	// Feature flags evaluated for the call when it started (guarded by mu)
+	features flags.Features
+
+	// admissionRelease returns the call's adaptive admission slot (nil when none is held)
+	admissionRelease func()
+
+	// Supervisor leg (nil unless an operator is on the call); guidance and
+	// caller speech heard during a takeover wait for the next orchestrator turn
+	supervisorMu       sync.Mutex
+	supervisor         *SupervisorLeg
+	supervisorGuidance []string
+	takeoverTranscript []string
+
+	// Pre-recorded file playback (nil when nothing is playing)
+	playbackMu sync.Mutex
+	playback   *playbackState
+
+	// Stereo call recording (nil unless CALL_RECORDING_ENABLED)
+	recordingMu sync.Mutex
+	recording   *callRecording
+
+	// Voicemail state (nil unless the call is being sent to voicemail)
+	voicemail *voicemailState
+
+	// Call detail record, emitted when the call ends
+	cdr *cdr.Builder
 
 	// Audio channels
-	audioIn  chan []byte // Audio from Twilio (decoded PCMU)
-	audioOut chan []byte // Audio to Twilio (for TTS playback)
+	audioIn  chan *audio.Frame // Audio from Twilio (decoded PCMU); the receiver releases each frame
+	audioOut chan []byte       // Audio to Twilio (for TTS playback)
+	markOut  chan string       // Marks to send once the audio queued before them is sent
+
+	// Marks awaiting Twilio's acknowledgment that the caller heard the audio
+	marks playbackMarks
+
+	// Audio processing stages; each packet is reused by the one goroutine running its pipeline
+	inbound        *audio.Pipeline // Caller audio on its way to STT
+	outbound       *audio.Pipeline // Agent audio on its way to Twilio
+	inboundPacket  audio.Packet
+	outboundPacket audio.Packet
 
 	// Audio buffers
 	audioInBuffer  *audio.RingBuffer // Ring buffer for incoming audio
 	audioOutBuffer *audio.RingBuffer // Ring buffer for outgoing audio
 
 	// Voice Activity Detection
-	vadDetector *audio.VADDetector
+	vadDetector     *audio.VADDetector
+	endpointingMode stt.EndpointingMode
+
+	// Adapts the VAD's end-of-speech silence per turn (nil when disabled)
+	endpoints *endpointTuner
+
+	// Silence suppression (nil when all audio is forwarded to STT)
+	suppressor *audio.SilenceSuppressor
+
+	// Automatic gain for quiet callers (nil when disabled; used only by the inbound pipeline)
+	agc *audio.AGC
+
+	// Echo detection on caller audio (nil when disabled)
+	echo *audio.EchoDetector
+
+	// Text recently sent to TTS, to drop transcripts of the agent's own speech
+	spoken spokenHistory
+
+	// Overlap between caller speech and agent audio, for the CDR
+	talkOver talkOverTracker
 
 	// STT client for speech-to-text transcription
 	sttClient stt.STTClient
@@ -113,11 +303,14 @@ type CallSession struct {
 	transcriptionQueue chan string
 
 	// Orchestrator response channel for text ready for TTS
-	orchestratorResponseQueue chan string
+	orchestratorResponseQueue chan responseChunk
 
 	// Configuration
 	config *config.Config
 
+	// Shared services (firm settings, storage, events)
+	services *Services
+
 	// Observability
 	correlationID string
 	metrics       *observability.Metrics
@@ -129,9 +322,9 @@ type CallSession struct {
 }
 
 // NewCallSession creates a new call session
-func NewCallSession(conn *websocket.Conn, cfg *config.Config) *CallSession {
-	// Create Deepgram STT client
-	sttClient := stt.NewDeepgramClient(cfg)
+func NewCallSession(conn Conn, cfg *config.Config, services *Services) *CallSession {
+	// Create the STT client for the gateway's provider
+	sttClient := stt.NewClient(cfg)
 
 	// Create Orchestrator client
 	orchClient, err := orchestrator.NewOrchestratorClient(cfg)
@@ -141,21 +334,57 @@ func NewCallSession(conn *websocket.Conn, cfg *config.Config) *CallSession {
 		orchClient = nil
 	}
 
-	// Create Cartesia TTS client
-	ttsClient := tts.NewCartesiaClient(cfg)
+	// Create the TTS client for the gateway's provider
+	ttsClient := tts.NewClient(cfg)
+
+	// Spell out numbers before synthesis; Load already checked the locale
+	verbalizer, err := tts.NewVerbalizer(cfg.TTSVerbalizeLocale)
+	if err != nil {
+		log.Printf("Warning: %v, speaking numbers as written", err)
+	}
 
 	// Create VAD detector
 	vadConfig := &audio.VADConfig{
 		EnergyThreshold: cfg.VADEnergyThreshold,
 		SilenceFrames:   cfg.VADSilenceFrames,
-		FrameSize:       160, // 20ms at 8kHz
+		FrameSize:       vadFrameSize,
 	}
 	vadDetector := audio.NewVADDetector(vadConfig)
 
+	// Create silence suppressor
+	var suppressor *audio.SilenceSuppressor
+	if cfg.SilenceSuppressionActive() {
+		suppressor = audio.NewSilenceSuppressor(&audio.SuppressorConfig{
+			HangoverFrames: cfg.SilenceHangoverMs / vadFrameMs,
+			PreRollFrames:  cfg.SilencePreRollMs / vadFrameMs,
+		})
+	}
+
+	// Create automatic gain control
+	var agc *audio.AGC
+	if cfg.AGCEnabled {
+		agc = audio.NewAGC(&audio.AGCConfig{
+			TargetRMS:    cfg.AGCTargetRMS,
+			QuietRMS:     cfg.AGCQuietRMS,
+			MaxGain:      cfg.AGCMaxGain,
+			WindowFrames: max(cfg.AGCWindowMs/vadFrameMs, 1),
+		})
+	}
+
+	// Create echo detector
+	var echo *audio.EchoDetector
+	if cfg.EchoSuppressionEnabled {
+		echo = audio.NewEchoDetector(&audio.EchoConfig{
+			MaxDelayFrames: cfg.EchoMaxDelayMs / vadFrameMs,
+			WindowFrames:   audio.DefaultEchoConfig().WindowFrames,
+			Threshold:      cfg.EchoCorrelation,
+		})
+	}
+
 	// Generate correlation ID for this call
 	correlationID := observability.NewCorrelationID()
 	callID := generateConversationID()
-	
+
 	// Create logger with correlation ID
 	logger := observability.WithCorrelationID(correlationID).
 		With().
@@ -163,68 +392,136 @@ func NewCallSession(conn *websocket.Conn, cfg *config.Config) *CallSession {
 		Logger()
 
 	// Create metrics tracker
-	metrics := observability.NewCallMetrics(callID)
+	metrics := observability.NewCallMetrics(callID, correlationID)
 	metrics.RecordCallStart()
 
-	return &CallSession{
-		conn:              conn,
-		audioIn:           make(chan []byte, 100), // Buffered channel for audio chunks
-		audioOut:          make(chan []byte, 100), // Buffered channel for TTS audio
-		audioInBuffer:     audio.NewRingBuffer(cfg.AudioBufferSize),
-		audioOutBuffer:    audio.NewRingBuffer(cfg.AudioBufferSize),
-		vadDetector:       vadDetector,
-		sttClient:         sttClient,
-		orchestratorClient: orchClient,
-		ttsClient:          ttsClient,
-		transcriptionQueue: make(chan string, 50), // Buffered channel for complete transcriptions
-		orchestratorResponseQueue: make(chan string, 50), // Buffered channel for Orchestrator responses
-		config:            cfg,
-		correlationID:     correlationID,
-		metrics:           metrics,
-		logger:            logger,
-		done:              make(chan struct{}),
-		errChan:           make(chan error, 1),
-		isActive:          true,
-		conversationID:    callID,
+	session := &CallSession{
+		conn:                      conn,
+		audioIn:                   make(chan *audio.Frame, 100), // Buffered channel for audio chunks
+		audioOut:                  make(chan []byte, 100),       // Buffered channel for TTS audio
+		markOut:                   make(chan string, 16),
+		audioInBuffer:             audio.NewRingBuffer(cfg.AudioBufferSize),
+		audioOutBuffer:            audio.NewRingBuffer(cfg.AudioBufferSize),
+		vadDetector:               vadDetector,
+		endpointingMode:           stt.EndpointingMode(cfg.EndpointingMode),
+		endpoints:                 newEndpointTuner(cfg),
+		verbalizer:                verbalizer,
+		suppressor:                suppressor,
+		agc:                       agc,
+		echo:                      echo,
+		sttClient:                 sttClient,
+		orchestratorClient:        orchClient,
+		ttsClient:                 ttsClient,
+		transcriptionQueue:        make(chan string, 50),        // Buffered channel for complete transcriptions
+		orchestratorResponseQueue: make(chan responseChunk, 50), // Buffered channel for Orchestrator responses
+		config:                    cfg,
+		services:                  services,
+		correlationID:             correlationID,
+		metrics:                   metrics,
+		logger:                    logger,
+		done:                      make(chan struct{}),
+		errChan:                   make(chan error, 1),
+		isActive:                  true,
+		conversationID:            callID,
+		cdr:                       cdr.NewBuilder(callID, time.Now()),
+		contactReady:              make(chan struct{}),
+		dtmfDigits:                make(chan string, 32),
+		reconnect:                 make(chan *websocket.Conn, 1),
 	}
+	session.writer = newTwilioWriter(conn, session.outboundError)
+	session.writer.timeout = session.writeTimeout()
+	session.inbound = session.newInboundPipeline()
+	session.outbound = session.newOutboundPipeline()
+	return session
 }
 
 // HandleTwilioWS is the main entry point for Twilio WebSocket connections
-func HandleTwilioWS(cfg *config.Config) http.HandlerFunc {
+func HandleTwilioWS(cfg *config.Config, services *Services) http.HandlerFunc {
+	upgrader := newUpgrader(cfg, services.Origins)
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Upgrade HTTP connection to WebSocket
+		access := observability.AccessRecordFrom(r.Context())
+		if !services.Preflight.Open() {
+			// Twilio moves on to the next TwiML verb when the stream fails
+			access.Reject("preflight_failed", "dependencies failed the startup preflight")
+			http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+			return
+		}
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
+			access.Reject("handshake_failed", err.Error())
 			log.Printf("Failed to upgrade connection to WebSocket: %v", err)
 			http.Error(w, "Failed to upgrade to WebSocket", http.StatusBadRequest)
 			return
 		}
-		defer conn.Close()
+
+		// A stream reconnecting within its grace carries on in its session
+		var pending [][]byte
+		if cfg.TwilioReconnectGraceMs > 0 && services.Streams != nil {
+			var resumed *CallSession
+			if resumed, pending = resumeStream(conn, services.Streams); resumed != nil {
+				access.SetCall(resumed.accountSid, resumed.GetCallSid())
+				<-resumed.done
+				return
+			}
+		}
 
 		// Create new call session
-		session := NewCallSession(conn, cfg)
+		session := NewCallSession(conn, cfg, services)
+		session.access = access
+		session.pending = pending
+		defer func() { _ = session.twilioConn().Close() }()
+		tuneConn(conn, cfg)
+		session.watchConn(conn)
 		log.Printf("New Twilio WebSocket connection established")
 
-		// Start processing goroutines
-		go session.processIncomingMessages()
-		go session.processIncomingAudio()
-		go session.processOutgoingAudio()
-		go session.processOrchestratorRequests()
-		go session.processOrchestratorResponses()
+		session.serve(r.Context())
+	}
+}
 
-		// Wait for session to complete or error
-		select {
-		case <-session.done:
-			log.Printf("Call session ended: %s", session.callSid)
-		case err := <-session.errChan:
-			log.Printf("Call session error: %v", err)
-		}
+// serve runs the session until the call ends, then records how it ended
+func (s *CallSession) serve(ctx context.Context) {
+	// Start processing goroutines
+	s.goSafe("twilio_keepalive", s.keepAlive)
+	s.goSafe("incoming_messages", s.processIncomingMessages)
+	s.goSafe("incoming_audio", s.processIncomingAudio)
+	s.goSafe("outgoing_audio", s.processOutgoingAudio)
+	s.goSafe("twilio_writer", func() { s.writer.run(s.done) })
+	s.goSafe("orchestrator_requests", s.processOrchestratorRequests)
+	s.goSafe("orchestrator_responses", s.processOrchestratorResponses)
+
+	// Wait for session to complete or error
+	select {
+	case <-s.done:
+		log.Printf("Call session ended: %s", s.callSid)
+	case err := <-s.errChan:
+		log.Printf("Call session error: %v", err)
 	}
+	s.mu.RLock()
+	observability.RecordWebSocketClose(ctx, s.readErr)
+	s.mu.RUnlock()
+
+	// A client that never starts a stream is not Twilio, or Twilio misbehaving
+	if s.GetCallSid() == "" {
+		s.access.Reject("no_start_event", "connection closed before a start event")
+	}
+
+	s.recordCallEnd()
 }
 
 // processIncomingMessages handles all incoming WebSocket messages from Twilio
 func (s *CallSession) processIncomingMessages() {
 	defer func() {
+		s.noteCallerHangup()
+
+		// A caller who hung up mid-challenge needs no verdict
+		if ch := s.pendingChallenge(); ch != nil {
+			ch.resolve()
+		}
+
+		// Deliver any voicemail before tearing down STT
+		s.finishVoicemail()
+
 		// Cleanup STT client when session ends
 		if s.sttClient != nil {
 			if err := s.sttClient.Close(); err != nil {
@@ -232,6 +529,7 @@ func (s *CallSession) processIncomingMessages() {
 			}
 		}
 		// Cleanup Orchestrator client when session ends
+		s.closeToolResults()
 		if s.orchestratorClient != nil {
 			if err := s.orchestratorClient.Close(); err != nil {
 				log.Printf("Error closing Orchestrator client: %v", err)
@@ -251,16 +549,33 @@ func (s *CallSession) processIncomingMessages() {
 		}
 
 		// Read message from WebSocket
-		_, message, err := s.conn.ReadMessage()
+		message, err := s.readMessage()
 		if err != nil {
+			cause := closeCause(err)
+			if s.endedByGateway.Load() {
+				cause = closeGateway
+			} else if s.awaitReconnect(cause) {
+				continue
+			}
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				s.logger.Warn().Err(err).Msg("WebSocket read error")
+				s.logger.Warn().Err(err).Str("cause", cause).Msg("WebSocket read error")
 			}
+			observability.RecordTwilioStreamClose(cause)
 			s.mu.Lock()
 			s.isActive = false
+			s.readErr = err
 			s.mu.Unlock()
 			return
 		}
+		s.noteRead()
+
+		// Media events skip the JSON decoder; a call being rejected drops them
+		if media, ok := parseMediaEvent(message); ok {
+			if !s.isRejecting() {
+				s.handleMediaPayload(trackName(media.track), media.timestamp, media.payload)
+			}
+			continue
+		}
 
 		// Parse Twilio message
 		var twilioMsg TwilioMessage
@@ -283,66 +598,160 @@ func (s *CallSession) processIncomingMessages() {
 			s.logger.Info().
 				Str("call_sid", twilioMsg.CallSid).
 				Str("stream_sid", twilioMsg.StreamSid).
+				Str("endpointing_mode", string(s.endpointingMode)).
 				Msg("Call started")
+			var params twilio.StreamParameters
+			if twilioMsg.Start != nil {
+				params = twilioMsg.Start.CustomParameters
+			}
+			paramErr := params.Check(s.config.StreamRequiredParams)
+
 			s.mu.Lock()
 			s.callSid = twilioMsg.CallSid
 			s.streamSid = twilioMsg.StreamSid
 			if twilioMsg.Start != nil {
 				s.accountSid = twilioMsg.Start.AccountSid
-
-				// Extract custom parameters
-				if params := twilioMsg.Start.CustomParameters; params != nil {
-					if firmID, ok := params["firm_id"].(string); ok {
-						s.firmID = firmID
-					}
-					if userID, ok := params["user_id"].(string); ok {
-						s.userID = userID
-					}
-					if callID, ok := params["call_id"].(string); ok {
-						s.callID = callID
-					}
-				}
+				s.access.SetCall(s.accountSid, s.callSid)
 			}
+			s.firmID = params.FirmID
+			s.userID = params.UserID
+			s.callID = params.CallID
+			s.callerNumber = params.From
+			s.calledNumber = params.To
+			s.language = params.Language
+			s.campaign = params.Campaign
+			s.script = params.Script
+			s.transferFallback = params.TransferFallback
 
 			// Validate we have required IDs (while holding lock)
+			callSid := s.callSid
 			firmID := s.firmID
 			userID := s.userID
 			callID := s.callID
+			s.cdr.Update(func(r *cdr.Record) {
+				r.CallSid = s.callSid
+				r.StreamSid = s.streamSid
+				r.AccountSid = s.accountSid
+				r.FirmID = firmID
+				r.UserID = userID
+				r.CallID = callID
+				r.CallerNumber = s.callerNumber
+				r.Language = s.language
+				r.Campaign = s.campaign
+			})
 			s.mu.Unlock()
 
-			if firmID == "" || userID == "" {
-				log.Printf("Warning: Missing firm_id or user_id for call %s", twilioMsg.CallSid)
-				// Could close connection or use default firm
-				// For now, we'll log a warning and continue
+			if len(params.Unknown) > 0 {
+				s.logger.Warn().Strs("parameters", params.Unknown).Msg("Ignoring unknown stream parameters")
+			}
+			if paramErr != nil {
+				// Misconfigured TwiML: fail loudly rather than run an anonymous call
+				s.logger.Error().Err(paramErr).
+					Str("call_sid", twilioMsg.CallSid).
+					Msg("Rejecting call; check the <Parameter> values in the TwiML <Stream>")
+				s.access.Reject("invalid_parameters", paramErr.Error())
+				if s.metrics != nil {
+					s.metrics.RecordError("invalid_stream_parameters", "telephony")
+				}
+				s.rejectCall(dispositionInvalidParameters)
+				continue
+			}
+			if firmID == "" {
+				var attributed bool
+				if firmID, attributed = s.attributeCall(); !attributed {
+					continue
+				}
 			}
 
 			log.Printf("Call context: firm_id=%s, user_id=%s, call_id=%s", firmID, userID, callID)
-			
-			// Initialize Deepgram streaming connection
-			if err := s.sttClient.Start(); err != nil {
-				log.Printf("Error starting Deepgram client: %v", err)
-				// Continue anyway - we can retry later
-			} else {
-				log.Printf("Deepgram streaming connection initialized for call %s", twilioMsg.CallSid)
-				
-				// Start goroutine to process transcriptions
-				go s.processTranscriptions()
+			if s.metrics != nil {
+				s.metrics.SetFirm(firmID)
+			}
+			s.evaluateFeatures(firmID, callSid)
+
+			s.startCallRecording()
+			s.openLiveFeed(callSid)
+			if s.services.Calls != nil && callSid != "" {
+				s.services.Calls.add(callSid, s)
+			}
+
+			// Screen the caller before spending STT/orchestrator resources
+			settings := s.services.Firms.Get(firmID)
+			if err := s.useFirmRegions(settings); err != nil {
+				s.logger.Error().Err(err).Msg("Rejecting call; it can't be served within the firm's required region")
+				if s.metrics != nil {
+					s.metrics.RecordError("region_unavailable", "telephony")
+				}
+				s.rejectCall(dispositionRegionUnavailable)
+				continue
+			}
+			s.useLanguage(params.Language)
+			s.useFirmSTT(settings)
+			s.useFirmTTS(settings)
+			s.useFirmCredentials(settings)
+			s.applyVoice()
+			s.useFirmLexicon(settings)
+			s.useFirmSpeakingStyle(settings)
+			s.useFirmWatermark(settings)
+			s.registerCall(settings)
+			switch s.screenCaller(settings).Verdict {
+			case screening.Block:
+				s.rejectCall("blocked")
+			case screening.Challenge:
+				s.startChallenge(settings, func() { s.startPipeline(firmID, settings) })
+			default:
+				s.startPipeline(firmID, settings)
 			}
 
 		case "media":
 			// Handle audio media event
-			if twilioMsg.Media != nil {
+			if twilioMsg.Media != nil && !s.isRejecting() {
 				s.handleMediaEvent(twilioMsg.Media)
 			}
 
+		case "mark":
+			if twilioMsg.Mark != nil {
+				s.handleMark(twilioMsg.Mark.Name)
+			}
+
+		case "dtmf":
+			if twilioMsg.DTMF == nil {
+				continue
+			}
+			if ch := s.pendingChallenge(); ch != nil {
+				s.passChallenge(ch, "dtmf")
+				continue
+			}
+			if c := s.pendingConsent(); c != nil {
+				s.answerConsentDigit(c, twilioMsg.DTMF.Digit)
+				continue
+			}
+			if c := s.pendingConfirmation(); c != nil {
+				c.answerDigit(twilioMsg.DTMF.Digit)
+				continue
+			}
+			if v := s.pendingVerification(); v != nil {
+				v.answerDigit(twilioMsg.DTMF.Digit)
+				continue
+			}
+			select {
+			case s.dtmfDigits <- twilioMsg.DTMF.Digit:
+			default:
+				s.logger.Warn().Str("digit", twilioMsg.DTMF.Digit).Msg("DTMF buffer full, dropping digit")
+			}
+
 		case "stop":
 			s.logger.Info().
 				Str("call_sid", twilioMsg.CallSid).
 				Msg("Call stopped")
+			observability.RecordTwilioStreamClose(closeStop)
 			s.mu.Lock()
 			s.isActive = false
 			s.mu.Unlock()
-			
+
+			// Deliver any voicemail while STT can still return the last words
+			s.finishVoicemail()
+
 			// Stop Deepgram streaming connection
 			if err := s.sttClient.Stop(); err != nil {
 				log.Printf("Error stopping Deepgram client: %v", err)
@@ -357,32 +766,84 @@ func (s *CallSession) processIncomingMessages() {
 	}
 }
 
+// startPipeline connects STT, looks up the caller, and applies the firm's routing policy
+func (s *CallSession) startPipeline(firmID string, settings *firm.Settings) {
+	// Recognize returning clients while STT spins up
+	s.goSafe("caller_lookup", func() { s.lookupCaller(firmID, settings) })
+
+	// Initialize Deepgram streaming connection
+	transcripts, err := s.sttClient.Start()
+	if err != nil {
+		log.Printf("Error starting Deepgram client: %v", err)
+		s.services.SessionHealth.Failure(err)
+		s.technicalFailures.Add(1)
+		// Continue anyway - we can retry later
+	} else {
+		log.Printf("Deepgram streaming connection initialized for call %s", s.GetCallSid())
+		s.services.SessionHealth.Success()
+
+		// Start goroutine to process transcriptions
+		s.goSafe("transcriptions", func() { s.processTranscriptions(settings, transcripts) })
+	}
+
+	// Announce recording and AI use, then decide between AI conversation,
+	// voicemail, and transfer
+	s.startCompliance(settings, func() { s.routeCall(firmID, settings) })
+
+	// Enforce max call length and hang up idle calls
+	s.goSafe("call_limits", s.monitorCallLimits)
+}
+
 // handleMediaEvent processes a media event from Twilio
 func (s *CallSession) handleMediaEvent(media *TwilioMedia) {
-	// Extract base64 encoded audio chunk
+	// Extract base64 encoded audio; Twilio numbers chunks in "chunk" and
+	// carries the audio in "payload"
 	var base64Chunk string
-	if media.Chunk != "" {
-		base64Chunk = media.Chunk
-	} else if media.Payload != "" {
+	if media.Payload != "" {
 		base64Chunk = media.Payload
+	} else if media.Chunk != "" {
+		base64Chunk = media.Chunk
 	} else {
 		log.Printf("Media event missing chunk/payload")
 		return
 	}
+	s.handleMediaPayload(media.Track, []byte(media.Timestamp), []byte(base64Chunk))
+}
+
+// handleMediaPayload decodes base64 caller audio into a pooled frame and
+// queues it for processing; the arguments are not kept after it returns
+func (s *CallSession) handleMediaPayload(track string, timestamp, payload []byte) {
+	if chaos.DropTwilioFrame() {
+		return
+	}
 
-	// Decode base64 to binary
-	audioData, err := base64.StdEncoding.DecodeString(base64Chunk)
+	// Decode base64 into a pooled frame
+	frame := audio.GetFrame(base64.StdEncoding.DecodedLen(len(payload)))
+	n, err := base64.StdEncoding.Decode(frame.Data, payload)
 	if err != nil {
+		frame.Release()
 		log.Printf("Failed to decode base64 audio: %v", err)
 		return
 	}
+	frame.Data = frame.Data[:n]
+	audioData := frame.Data
 
-	// Send decoded audio to processing channel
+	if track != "" && track != TrackInbound {
+		// With both tracks streamed, the outbound track is the agent's own
+		// audio, already recorded and tapped as it was sent
+		frame.Release()
+		return
+	}
+	s.recordCallerAudio(timestamp, audioData)
+	s.tapSupervisor(TrackInbound, audioData)
+
+	// Send decoded audio to processing channel, which takes ownership of the frame
 	select {
-	case s.audioIn <- audioData:
+	case s.audioIn <- frame:
 		// Successfully queued
 	default:
 		// Channel is full, log warning but don't block
+		frame.Release()
 		log.Printf("Warning: audioIn channel full, dropping audio chunk")
 	}
 }
@@ -393,39 +854,9 @@ func (s *CallSession) processIncomingAudio() {
 
 	for {
 		select {
-		case audioChunk := <-s.audioIn:
-			// Record audio bytes
-			if s.metrics != nil {
-				s.metrics.RecordAudioBytes("in", int64(len(audioChunk)))
-			}
-
-			// Check if user is speaking (interrupt TTS if active)
-			s.mu.Lock()
-			if s.isTalking {
-				// User is speaking - stop any active TTS
-				if s.ttsClient != nil && s.ttsClient.IsActive() {
-					s.logger.Info().Msg("User speaking detected, stopping TTS")
-					if err := s.ttsClient.Stop(); err != nil {
-						s.logger.Error().Err(err).Msg("Error stopping TTS")
-					}
-				}
-			}
-			s.mu.Unlock()
-
-			// Record STT start on first audio chunk
-			if s.metrics != nil && !s.isTalking {
-				s.metrics.RecordSTTStart()
-			}
-
-			// Send audio chunk to Deepgram streaming API
-			if err := s.sttClient.SendAudio(audioChunk); err != nil {
-				s.logger.Error().Err(err).Msg("Error sending audio to Deepgram")
-				if s.metrics != nil {
-					s.metrics.RecordError("stt_send_error", "deepgram")
-				}
-				// Continue processing - don't break the call flow
-				// The STT client should handle reconnection internally
-			}
+		case frame := <-s.audioIn:
+			s.handleInboundAudio(frame.Data)
+			frame.Release()
 
 		case <-s.done:
 			log.Printf("Audio processing goroutine stopping for call %s", s.callSid)
@@ -434,15 +865,22 @@ func (s *CallSession) processIncomingAudio() {
 	}
 }
 
+// handleInboundAudio runs a chunk of caller audio through the inbound
+// pipeline; the chunk is only valid until it returns
+func (s *CallSession) handleInboundAudio(audioChunk []byte) {
+	s.inboundPacket.Reset(audioChunk)
+	s.inbound.Process(&s.inboundPacket)
+}
+
 // processTranscriptions processes transcription results from Deepgram
-// and queues complete sentences for the Orchestrator
-func (s *CallSession) processTranscriptions() {
+// and queues complete sentences for the Orchestrator, until STT is stopped
+// and closes transcriptChan
+func (s *CallSession) processTranscriptions(settings *firm.Settings, transcriptChan <-chan *stt.TranscriptionResult) {
 	log.Printf("Starting transcription processing goroutine for call %s", s.callSid)
 
-	transcriptChan := s.sttClient.GetTranscription()
-	
-	// Buffer for accumulating interim results
-	var currentSentence strings.Builder
+	clarify := newClarifier(settings.Clarification)
+	escalation := newEscalationMonitor(settings)
+
 	var lastFinalText string
 
 	for {
@@ -455,44 +893,116 @@ func (s *CallSession) processTranscriptions() {
 			}
 
 			if result.IsFinal {
+				// The final covers the segment the interims were building
+				s.partial.Reset()
+				if result.Language != "" {
+					s.noteDetectedLanguage(result.Language)
+				}
+
 				// Final transcription - queue for Orchestrator
 				finalText := result.Text
-				
+				if finalText != "" && s.isSelfEcho(finalText) {
+					continue
+				}
+				s.endpoints.heardFinal(finalText)
+
+				// On a translated call, operators and the Orchestrator get the
+				// caller's speech in the firm's language. A code being collected
+				// for verify_caller is never sent out for translation
+				firmText, spoken := finalText, ""
+				if finalText != "" && s.pendingVerification() == nil {
+					if translated := s.translateForFirm(finalText); translated != finalText {
+						firmText, spoken = translated, finalText
+					}
+				}
+				if finalText != "" {
+					s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerCaller, Text: s.redactVerification(firmText), Spoken: spoken, Final: true, Confidence: result.Confidence, Words: s.liveWords(result.Words)})
+				}
+
 				// Only queue if it's different from the last final text
 				// (Deepgram may send duplicates)
 				if finalText != "" && finalText != lastFinalText {
+					// Consent answers never reach the Orchestrator
+					if c := s.pendingConsent(); c != nil {
+						s.answerConsentSpeech(c, finalText)
+						lastFinalText = finalText
+						continue
+					}
+
+					// Nor do answers to a tool confirmation; the tool returns them
+					if c := s.pendingConfirmation(); c != nil {
+						c.answerSpeech(finalText)
+						lastFinalText = finalText
+						continue
+					}
+
+					// Nor does a PIN or code being collected for verify_caller
+					if v := s.pendingVerification(); v != nil {
+						v.answerSpeech(finalText)
+						lastFinalText = finalText
+						continue
+					}
+
+					// Voicemail calls never reach the Orchestrator
+					if vm := s.inVoicemail(); vm != nil {
+						vm.addTranscript(finalText)
+						lastFinalText = finalText
+						continue
+					}
+
 					log.Printf("Final transcription ready for Orchestrator: %s", finalText)
-					
+
 					// Stop TTS if user is speaking (interrupt handling)
 					s.mu.Lock()
 					if s.ttsClient != nil && s.ttsClient.IsActive() {
 						log.Printf("User speech detected, interrupting TTS")
-						if err := s.ttsClient.Stop(); err != nil {
-							log.Printf("Error stopping TTS: %v", err)
+						s.ttsClient.CancelAll()
+						if s.metrics != nil {
+							s.metrics.RecordInterruption()
 						}
 					}
 					s.mu.Unlock()
-					
+
+					// An operator has the call; the AI hears about it on hand-back
+					if s.aiPaused() {
+						s.noteTakeoverSpeech(firmText)
+						lastFinalText = finalText
+						continue
+					}
+
+					// Ask the caller to repeat rather than send a garbled turn to the LLM
+					if clarify.shouldClarify(result.Confidence) {
+						s.requestClarification(finalText, result.Confidence, clarify.prompt)
+						lastFinalText = finalText
+						continue
+					}
+
+					// Watch for callers asking for a human or losing patience
+					if s.handleEscalation(escalation, firmText) {
+						lastFinalText = finalText
+						continue
+					}
+
 					// Queue for Orchestrator
 					select {
-					case s.transcriptionQueue <- finalText:
+					case s.transcriptionQueue <- firmText:
 						// Successfully queued
 						lastFinalText = finalText
+						s.awaitFirstResponse()
+						if s.metrics != nil {
+							s.metrics.RecordUtterance(len(strings.Fields(finalText)))
+						}
 					default:
 						log.Printf("Warning: transcription queue full, dropping: %s", finalText)
 					}
-					
-					// Clear current sentence buffer
-					currentSentence.Reset()
 				}
 			} else {
-				// Interim result - update current sentence
-				// For now, we just log it. In a full implementation,
-				// we might want to show interim results in a UI
+				// Interim result - merge it into the segment's partial transcript
 				if result.Text != "" {
-					currentSentence.Reset()
-					currentSentence.WriteString(result.Text)
-					log.Printf("Interim transcription: %s", result.Text)
+					partial := s.partial.Update(result)
+					s.endpoints.heardPartial(partial.Text)
+					s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerCaller, Text: s.redactVerification(partial.Text), Stable: partial.Stable, Confidence: partial.Confidence, Words: s.liveWords(partial.Words)})
+					log.Printf("Interim transcription: %s", s.redactVerification(result.Text))
 				}
 			}
 
@@ -524,83 +1034,26 @@ func (s *CallSession) processOrchestratorRequests() {
 			firmID := s.firmID
 			s.mu.RUnlock()
 
-			// Create context for this request
-			ctx := context.Background()
-
 			// Send transcription to Orchestrator
 			s.logger.Info().
 				Str("text", transcription).
 				Str("conversation_id", conversationID).
 				Msg("Sending transcription to Orchestrator")
-			
+
 			// Record Orchestrator start
 			if s.metrics != nil {
 				s.metrics.RecordOrchestratorStart()
 			}
-			
-			responseChan, err := s.orchestratorClient.ProcessTextStream(ctx, conversationID, transcription, userID, firmID)
-			if err != nil {
-				s.logger.Error().Err(err).Msg("Error sending transcription to Orchestrator")
-				if s.metrics != nil {
-					s.metrics.RecordOrchestratorEnd(false)
-					s.metrics.RecordError("orchestrator_send_error", "orchestrator")
-				}
-				continue
+			s.publishLive(live.Event{Type: live.TypeTurnStarted, Speaker: live.SpeakerCaller, Text: transcription})
+
+			// Process responses in a separate goroutine to avoid blocking; a retry
+			// after a stall resends the same turn
+			metadata := s.turnMetadata()
+			turn := s.startTurn()
+			open := func(ctx context.Context) (<-chan *orchestrator.OrchestratorResponse, error) {
+				return s.orchestratorClient.ProcessTextStream(ctx, conversationID, transcription, userID, firmID, metadata)
 			}
-
-			// Process responses in a separate goroutine to avoid blocking
-			go func() {
-				for response := range responseChan {
-					if response.Error != nil {
-						s.logger.Error().
-							Str("code", response.Error.Code).
-							Str("message", response.Error.Message).
-							Msg("Orchestrator error")
-						if s.metrics != nil {
-							s.metrics.RecordError("orchestrator_error", "orchestrator")
-						}
-						continue
-					}
-
-					// Queue text chunks for TTS
-					if response.TextChunk != "" {
-						select {
-						case s.orchestratorResponseQueue <- response.TextChunk:
-							s.logger.Debug().
-								Str("chunk", response.TextChunk).
-								Msg("Queued Orchestrator response for TTS")
-						default:
-							s.logger.Warn().
-								Str("chunk", response.TextChunk).
-								Msg("Orchestrator response queue full, dropping")
-						}
-					}
-
-					// Log tool calls and results for observability
-					if response.ToolCall != nil {
-						s.logger.Info().
-							Str("tool_name", response.ToolCall.ToolName).
-							Str("call_id", response.ToolCall.CallID).
-							Msg("Orchestrator tool call")
-					}
-					if response.ToolResult != nil {
-						s.logger.Info().
-							Str("call_id", response.ToolResult.CallID).
-							Bool("success", response.ToolResult.Success).
-							Msg("Orchestrator tool result")
-					}
-
-					if response.IsDone {
-						s.logger.Info().
-							Str("conversation_id", conversationID).
-							Msg("Orchestrator response stream completed")
-						if s.metrics != nil {
-							s.metrics.RecordOrchestratorEnd(true)
-						}
-						break
-					}
-				}
-			}()
+			s.goSafe("orchestrator_turn", func() { s.runOrchestratorTurn(open, conversationID, turn) })
 
 		case <-s.done:
 			s.logger.Debug().Msg("Orchestrator request processing goroutine stopping")
@@ -613,8 +1066,9 @@ func (s *CallSession) processOrchestratorRequests() {
 func (s *CallSession) processOrchestratorResponses() {
 	s.logger.Debug().Msg("Starting Orchestrator response processing goroutine")
 
-	// Buffer for accumulating text chunks until we have a complete sentence or pause
-	var textBuffer strings.Builder
+	// Buffer for accumulating the latest turn's text chunks until we have a
+	// complete sentence or pause
+	var textBuffer turnText
 	var lastChunkTime time.Time
 	sentenceTimeout := 500 * time.Millisecond // Wait 500ms for more chunks before synthesizing
 
@@ -623,30 +1077,64 @@ func (s *CallSession) processOrchestratorResponses() {
 
 	for {
 		select {
-		case textChunk := <-s.orchestratorResponseQueue:
-			// Accumulate text chunks
-			textBuffer.WriteString(textChunk)
+		case chunk := <-s.orchestratorResponseQueue:
+			// Drop AI output while an operator has the call
+			if s.aiPaused() {
+				continue
+			}
+
+			// Accumulate text chunks, in order and for the latest turn only
+			if !textBuffer.add(chunk) {
+				continue
+			}
 			lastChunkTime = time.Now()
-			log.Printf("Accumulated Orchestrator response: %s", textBuffer.String())
+			log.Printf("Accumulated Orchestrator response: %s", textBuffer.text.String())
 
 		case <-ticker.C:
 			// Check if we should synthesize (timeout or buffer size)
-			if textBuffer.Len() > 0 && time.Since(lastChunkTime) > sentenceTimeout {
-				textToSynthesize := textBuffer.String()
-				textBuffer.Reset()
+			if textBuffer.text.Len() > 0 && time.Since(lastChunkTime) > sentenceTimeout {
+				textToSynthesize := textBuffer.take()
+
+				// The caller spoke again while the text was gathering
+				if s.superseded(textBuffer.turn) {
+					observability.RecordOrchestratorChunkDropped(chunkSuperseded)
+					continue
+				}
 
 				// Send to TTS
 				if s.ttsClient != nil {
+					// The transcript gets the whole response; a long one is
+					// spoken only up to the maximum length
+					event := live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerAgent, Text: textToSynthesize, Final: true}
+					var truncated bool
+					textToSynthesize, truncated = textBuffer.limit(textToSynthesize, s.responseWordLimit())
+					if textToSynthesize == "" && !truncated {
+						s.publishLive(event)
+						continue
+					}
+
+					// On a translated call the reply is spoken in the caller's language
+					if translated := s.translateForCaller(textToSynthesize); translated != textToSynthesize {
+						event.Spoken = translated
+						textToSynthesize = translated
+					}
+					if truncated {
+						textToSynthesize = s.continuePrompt(textToSynthesize)
+					}
 					s.logger.Info().
 						Str("text", textToSynthesize).
 						Msg("Sending text to TTS")
-					
+					s.publishLive(event)
+					s.stopAudio(true) // Hold music stops when the agent speaks
+
 					// Record TTS start
 					if s.metrics != nil {
 						s.metrics.RecordTTSStart()
 					}
-					
-					audioChan, err := s.ttsClient.Synthesize(textToSynthesize)
+
+					turn := textBuffer.turn
+					synthStart := time.Now()
+					audioChan, err := s.synthesize(s.pauseAfterQuestions(textToSynthesize))
 					if err != nil {
 						s.logger.Error().Err(err).Msg("Error synthesizing text with TTS")
 						if s.metrics != nil {
@@ -654,10 +1142,16 @@ func (s *CallSession) processOrchestratorResponses() {
 						}
 						continue
 					}
+					s.observeLatency(signalTTS, time.Since(synthStart))
 
 					// Stream audio chunks to Twilio
-					go func() {
+					s.goSafe("response_audio", func() {
 						for audioChunk := range audioChan {
+							// The caller spoke again; drain the rest unplayed
+							if s.superseded(turn) {
+								continue
+							}
+
 							// Send audio to Twilio via audioOut channel
 							select {
 							case s.audioOut <- audioChunk.Data:
@@ -666,25 +1160,26 @@ func (s *CallSession) processOrchestratorResponses() {
 								log.Printf("Warning: audioOut channel full, dropping TTS audio")
 							}
 						}
-					}()
+						s.markUtterance(markResponse)
+					})
 				}
 			}
 
 		case <-s.done:
 			// Synthesize any remaining text before stopping
-			if textBuffer.Len() > 0 && s.ttsClient != nil {
-				textToSynthesize := textBuffer.String()
+			if textBuffer.text.Len() > 0 && s.ttsClient != nil {
+				textToSynthesize := textBuffer.take()
 				log.Printf("Synthesizing final text before stopping: %s", textToSynthesize)
-				audioChan, err := s.ttsClient.Synthesize(textToSynthesize)
+				audioChan, err := s.synthesize(textToSynthesize)
 				if err == nil {
-					go func() {
+					s.goSafe("response_audio_final", func() {
 						for audioChunk := range audioChan {
 							select {
 							case s.audioOut <- audioChunk.Data:
 							default:
 							}
 						}
-					}()
+					})
 				}
 			}
 			log.Printf("Orchestrator response processing goroutine stopping for call %s", s.callSid)
@@ -700,30 +1195,12 @@ func (s *CallSession) processOutgoingAudio() {
 	for {
 		select {
 		case audioChunk := <-s.audioOut:
-			// Write to ring buffer for smooth playback
-			written := s.audioOutBuffer.Write(audioChunk)
-			if written < len(audioChunk) {
-				log.Printf("Warning: audioOut buffer overflow, dropped %d bytes", len(audioChunk)-written)
-			}
-
-			// Read from buffer and send to Twilio (helps with smooth playback)
-			bufferData := make([]byte, len(audioChunk))
-			read := s.audioOutBuffer.Read(bufferData)
-			if read > 0 {
-				// Send audio to Twilio via WebSocket
-				// Audio is already in PCMU format and ready to send
-				if err := s.SendAudioToTwilio(bufferData[:read]); err != nil {
-					s.logger.Error().Err(err).Msg("Error sending audio to Twilio")
-					if s.metrics != nil {
-						s.metrics.RecordError("twilio_send_error", "telephony")
-					}
-					// Continue processing - don't break the call flow
-				} else {
-					s.logger.Debug().
-						Int("bytes", read).
-						Msg("Sent TTS audio to Twilio")
-				}
-			}
+			s.sendOutgoingChunk(audioChunk)
+
+		case name := <-s.markOut:
+			// The utterance's audio was queued ahead of its mark; send what is buffered first
+			s.drainAudioOut()
+			s.sendMark(name)
 
 		case <-s.done:
 			log.Printf("Outgoing audio processing goroutine stopping for call %s", s.callSid)
@@ -732,7 +1209,13 @@ func (s *CallSession) processOutgoingAudio() {
 	}
 }
 
-// SendAudioToTwilio sends audio data to Twilio in the correct format
+// sendOutgoingChunk runs a chunk of agent audio through the outbound pipeline
+func (s *CallSession) sendOutgoingChunk(audioChunk []byte) {
+	s.outboundPacket.Reset(audioChunk)
+	s.outbound.Process(&s.outboundPacket)
+}
+
+// SendAudioToTwilio queues audio data for Twilio in the correct format
 func (s *CallSession) SendAudioToTwilio(audioData []byte) error {
 	s.mu.RLock()
 	streamSid := s.streamSid
@@ -755,8 +1238,78 @@ func (s *CallSession) SendAudioToTwilio(audioData []byte) error {
 		},
 	}
 
-	// Send via WebSocket
-	return s.conn.WriteJSON(mediaMsg)
+	// Queue for the WebSocket writer
+	return s.writer.enqueue(outboundMedia, mediaMsg)
+}
+
+// outboundError reports a failed write to Twilio
+func (s *CallSession) outboundError(kind outboundKind, err error) {
+	// Writes to a dropped connection fail until the stream reconnects
+	if s.reconnecting.Load() {
+		return
+	}
+	s.logger.Error().Err(err).Str("message", string(kind)).Msg("Error writing to Twilio")
+	if s.metrics != nil {
+		s.metrics.RecordError("twilio_send_error", "telephony")
+	}
+}
+
+// recordCallEnd finalizes per-call metrics and emits the call detail record
+func (s *CallSession) recordCallEnd() {
+	var billed, total float64
+	if s.metrics != nil {
+		s.metrics.RecordCallEnd()
+		billed, total = s.metrics.STTUsage()
+	}
+
+	s.mu.RLock()
+	inVoicemail := s.voicemail != nil
+	s.mu.RUnlock()
+
+	s.recordTalkOver()
+	s.cdr.Update(func(r *cdr.Record) {
+		r.STTBilledSecs = billed
+		r.CallAudioSecs = total
+		if r.Disposition == "" {
+			r.Disposition = "completed"
+			if inVoicemail {
+				r.Disposition = "voicemail"
+			}
+		}
+		r.Outcome = classifyOutcome(r, int(s.technicalFailures.Load()))
+	})
+	record := s.cdr.Finish(time.Now())
+	observability.RecordCallOutcome(record.FirmID, record.Outcome)
+	s.reportAbandonment(&record)
+	s.meterUsage(&record)
+	record.RecordingURL = s.storeCallRecording(record.FirmID, record.CallSid)
+	record.Retention = retention(s.firmSettings(), firm.DataRecordings, record.EndedAt)
+	s.endSupervision()
+	if s.services != nil && s.services.Calls != nil {
+		s.services.Calls.remove(record.CallSid, s)
+	}
+	s.unregisterCall()
+	s.releaseAdmission()
+	s.closeLiveFeed(record.Disposition)
+
+	s.logger.Info().
+		Float64("stt_billed_seconds", billed).
+		Float64("call_audio_seconds", total).
+		Bool("silence_suppression", s.suppressor != nil).
+		Str("routing_action", record.Routing.Action).
+		Str("disposition", record.Disposition).
+		Str("outcome", record.Outcome).
+		Msg("Call ended")
+
+	if s.services == nil || s.services.Events == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cdrPublishTimeout)
+	defer cancel()
+	event := events.NewEvent(events.TypeCallCompleted, record.CallSid, record.FirmID, record)
+	if err := s.services.Events.Publish(ctx, event); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to publish call detail record")
+	}
 }
 
 // GetCallSid returns the call SID
@@ -0,0 +1,334 @@
+package telephony
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/live"
+)
+
+// Supervisor modes
+const (
+	SupervisorListen   = "listen"   // Operator hears the call; the AI carries on
+	SupervisorWhisper  = "whisper"  // Operator hears the call and sends guidance to the AI
+	SupervisorTakeover = "takeover" // Operator speaks to the caller; the AI is paused
+)
+
+// Supervisor audio tracks, named after Twilio's
+const (
+	TrackInbound  = "inbound"  // Caller audio
+	TrackOutbound = "outbound" // Audio played to the caller
+)
+
+// supervisorGuidanceKey carries whispered guidance in orchestrator request metadata
+const supervisorGuidanceKey = "supervisor_guidance"
+
+// supervisorFrameBuffer is how many 20ms frames an operator may fall behind
+// before audio is dropped for them
+const supervisorFrameBuffer = 100
+
+var (
+	// ErrCallNotFound is returned for calls not active on this instance
+	ErrCallNotFound = errors.New("call not active on this instance")
+
+	// ErrSupervised is returned when the call already has an operator
+	ErrSupervised = errors.New("call already has a supervisor")
+)
+
+// ValidSupervisorMode returns whether mode is a known supervisor mode
+func ValidSupervisorMode(mode string) bool {
+	switch mode {
+	case SupervisorListen, SupervisorWhisper, SupervisorTakeover:
+		return true
+	}
+	return false
+}
+
+// CallRegistry tracks the call sessions active on this instance by call SID
+type CallRegistry struct {
+	mu    sync.RWMutex
+	calls map[string]*CallSession
+}
+
+// NewCallRegistry creates an empty registry
+func NewCallRegistry() *CallRegistry {
+	return &CallRegistry{calls: make(map[string]*CallSession)}
+}
+
+func (r *CallRegistry) add(callSid string, s *CallSession) {
+	r.mu.Lock()
+	r.calls[callSid] = s
+	r.mu.Unlock()
+}
+
+func (r *CallRegistry) remove(callSid string, s *CallSession) {
+	r.mu.Lock()
+	if r.calls[callSid] == s {
+		delete(r.calls, callSid)
+	}
+	r.mu.Unlock()
+}
+
+// Supervise attaches an operator to a call in the given mode
+func (r *CallRegistry) Supervise(callSid, mode string) (*SupervisorLeg, error) {
+	r.mu.RLock()
+	s, ok := r.calls[callSid]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrCallNotFound
+	}
+	return s.attachSupervisor(mode)
+}
+
+// SupervisorFrame is a chunk of call audio (PCMU, 8kHz) sent to the operator
+type SupervisorFrame struct {
+	Track string
+	Audio []byte
+}
+
+// SupervisorLeg is an operator's connection to a live call: a second audio
+// leg carrying both sides of the call, plus controls for whispering guidance
+// and taking over from the AI
+type SupervisorLeg struct {
+	session *CallSession
+	frames  chan SupervisorFrame
+
+	// Guarded by session.supervisorMu
+	mode   string
+	closed bool
+}
+
+// Frames returns call audio for the operator; closed when the leg is detached or the call ends
+func (l *SupervisorLeg) Frames() <-chan SupervisorFrame {
+	return l.frames
+}
+
+// Mode returns the current supervisor mode
+func (l *SupervisorLeg) Mode() string {
+	l.session.supervisorMu.Lock()
+	defer l.session.supervisorMu.Unlock()
+	return l.mode
+}
+
+// SetMode switches between listening, whispering, and taking over the call
+func (l *SupervisorLeg) SetMode(mode string) error {
+	if !ValidSupervisorMode(mode) {
+		return fmt.Errorf("invalid supervisor mode %q", mode)
+	}
+
+	s := l.session
+	s.supervisorMu.Lock()
+	if l.closed {
+		s.supervisorMu.Unlock()
+		return ErrCallNotFound
+	}
+	previous := l.mode
+	l.mode = mode
+	s.supervisorMu.Unlock()
+
+	if previous != mode {
+		s.supervisorModeChanged(previous, mode)
+	}
+	return nil
+}
+
+// Whisper queues guidance for the AI, delivered with the caller's next turn
+func (l *SupervisorLeg) Whisper(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("guidance is empty")
+	}
+
+	s := l.session
+	s.supervisorMu.Lock()
+	defer s.supervisorMu.Unlock()
+	if l.closed {
+		return ErrCallNotFound
+	}
+	if l.mode != SupervisorWhisper {
+		return fmt.Errorf("whisper requires %s mode", SupervisorWhisper)
+	}
+	s.supervisorGuidance = append(s.supervisorGuidance, text)
+	s.logger.Info().Str("guidance", text).Msg("Supervisor whispered guidance")
+	return nil
+}
+
+// Speak plays operator audio (PCMU, 8kHz) to the caller; only allowed during takeover
+func (l *SupervisorLeg) Speak(pcmu []byte) error {
+	s := l.session
+	s.supervisorMu.Lock()
+	closed, mode := l.closed, l.mode
+	s.supervisorMu.Unlock()
+	if closed {
+		return ErrCallNotFound
+	}
+	if mode != SupervisorTakeover {
+		return fmt.Errorf("speaking requires %s mode", SupervisorTakeover)
+	}
+
+	select {
+	case s.audioOut <- pcmu:
+		return nil
+	case <-s.done:
+		return ErrCallNotFound
+	default:
+		return fmt.Errorf("audio output queue full")
+	}
+}
+
+// Close detaches the operator, handing the call back to the AI
+func (l *SupervisorLeg) Close() {
+	l.session.detachSupervisor(l)
+}
+
+// attachSupervisor connects an operator to the session
+func (s *CallSession) attachSupervisor(mode string) (*SupervisorLeg, error) {
+	if !ValidSupervisorMode(mode) {
+		return nil, fmt.Errorf("invalid supervisor mode %q", mode)
+	}
+
+	s.supervisorMu.Lock()
+	if s.supervisor != nil {
+		s.supervisorMu.Unlock()
+		return nil, ErrSupervised
+	}
+	leg := &SupervisorLeg{
+		session: s,
+		frames:  make(chan SupervisorFrame, supervisorFrameBuffer),
+		mode:    SupervisorListen,
+	}
+	s.supervisor = leg
+	s.supervisorMu.Unlock()
+
+	s.logger.Info().Str("mode", mode).Msg("Supervisor joined call")
+	s.cdr.Update(func(r *cdr.Record) { r.SupervisorJoined = true })
+	s.publishLive(live.Event{Type: live.TypeSupervisorJoined, Reason: mode})
+
+	if mode != SupervisorListen {
+		if err := leg.SetMode(mode); err != nil {
+			leg.Close()
+			return nil, err
+		}
+	}
+	return leg, nil
+}
+
+// detachSupervisor disconnects the operator; safe to call more than once
+func (s *CallSession) detachSupervisor(leg *SupervisorLeg) {
+	s.supervisorMu.Lock()
+	if leg.closed {
+		s.supervisorMu.Unlock()
+		return
+	}
+	leg.closed = true
+	close(leg.frames)
+	previous := leg.mode
+	if s.supervisor == leg {
+		s.supervisor = nil
+	}
+	s.supervisorMu.Unlock()
+
+	if previous == SupervisorTakeover {
+		s.supervisorModeChanged(previous, "")
+	}
+	s.logger.Info().Msg("Supervisor left call")
+	s.publishLive(live.Event{Type: live.TypeSupervisorLeft})
+}
+
+// endSupervision disconnects any operator when the call ends
+func (s *CallSession) endSupervision() {
+	s.supervisorMu.Lock()
+	leg := s.supervisor
+	s.supervisorMu.Unlock()
+	if leg != nil {
+		s.detachSupervisor(leg)
+	}
+}
+
+// supervisorModeChanged pauses the AI on takeover, and on hand-back tells it
+// what the caller said in the meantime; mode is "" when the operator left
+func (s *CallSession) supervisorModeChanged(previous, mode string) {
+	s.logger.Info().Str("from", previous).Str("to", mode).Msg("Supervisor mode changed")
+	if mode != "" {
+		s.publishLive(live.Event{Type: live.TypeSupervisorMode, Reason: mode})
+	}
+
+	if mode == SupervisorTakeover {
+		s.cdr.Update(func(r *cdr.Record) { r.SupervisorTakeover = true })
+
+		// Cut the AI off mid-sentence so the operator can speak
+		s.mu.Lock()
+		if s.ttsClient != nil {
+			s.ttsClient.CancelAll()
+		}
+		s.mu.Unlock()
+		return
+	}
+
+	if previous == SupervisorTakeover {
+		s.supervisorMu.Lock()
+		said := strings.Join(s.takeoverTranscript, " ")
+		s.takeoverTranscript = nil
+		note := "A member of the firm's staff spoke with the caller directly and has handed the call back to you."
+		if said != "" {
+			note += " While they were speaking, the caller said: \"" + said + "\""
+		}
+		s.supervisorGuidance = append(s.supervisorGuidance, note)
+		s.supervisorMu.Unlock()
+	}
+}
+
+// aiPaused returns whether an operator has taken over the call
+func (s *CallSession) aiPaused() bool {
+	s.supervisorMu.Lock()
+	defer s.supervisorMu.Unlock()
+	return s.supervisor != nil && s.supervisor.mode == SupervisorTakeover
+}
+
+// noteTakeoverSpeech keeps caller speech heard while the AI is paused, so the
+// AI can be told about it when the call is handed back
+func (s *CallSession) noteTakeoverSpeech(text string) {
+	s.supervisorMu.Lock()
+	s.takeoverTranscript = append(s.takeoverTranscript, text)
+	s.supervisorMu.Unlock()
+}
+
+// tapSupervisor copies call audio to the operator, dropping frames if they fall behind
+func (s *CallSession) tapSupervisor(track string, chunk []byte) {
+	s.supervisorMu.Lock()
+	defer s.supervisorMu.Unlock()
+	if s.supervisor == nil {
+		return
+	}
+
+	frame := SupervisorFrame{Track: track, Audio: append([]byte(nil), chunk...)}
+	select {
+	case s.supervisor.frames <- frame:
+	default:
+	}
+}
+
+// takeSupervisorGuidance returns and clears guidance queued for the next turn
+func (s *CallSession) takeSupervisorGuidance() string {
+	s.supervisorMu.Lock()
+	defer s.supervisorMu.Unlock()
+	guidance := strings.Join(s.supervisorGuidance, "\n")
+	s.supervisorGuidance = nil
+	return guidance
+}
+
+// turnMetadata returns metadata for an orchestrator request: call context on
+// the first turn, plus any supervisor guidance queued since the last turn
+func (s *CallSession) turnMetadata() map[string]string {
+	metadata := s.firstTurnMetadata()
+	if guidance := s.takeSupervisorGuidance(); guidance != "" {
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+		metadata[supervisorGuidanceKey] = guidance
+	}
+	return metadata
+}
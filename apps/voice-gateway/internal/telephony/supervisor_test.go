@@ -0,0 +1,112 @@
+package telephony
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/rs/zerolog"
+)
+
+func newSupervisorTestSession() *CallSession {
+	return &CallSession{
+		audioOut: make(chan []byte, 4),
+		done:     make(chan struct{}),
+		cdr:      cdr.NewBuilder("conv-1", time.Now()),
+		logger:   zerolog.Nop(),
+	}
+}
+
+func TestCallRegistry_Supervise(t *testing.T) {
+	registry := NewCallRegistry()
+	s := newSupervisorTestSession()
+
+	if _, err := registry.Supervise("CA1", SupervisorListen); !errors.Is(err, ErrCallNotFound) {
+		t.Errorf("Expected ErrCallNotFound, got %v", err)
+	}
+
+	registry.add("CA1", s)
+	leg, err := registry.Supervise("CA1", SupervisorListen)
+	if err != nil {
+		t.Fatalf("Supervise() failed: %v", err)
+	}
+	if _, err := registry.Supervise("CA1", SupervisorListen); !errors.Is(err, ErrSupervised) {
+		t.Errorf("Expected ErrSupervised for a second operator, got %v", err)
+	}
+
+	s.tapSupervisor(TrackInbound, []byte{1, 2, 3})
+	if frame := <-leg.Frames(); frame.Track != TrackInbound || len(frame.Audio) != 3 {
+		t.Errorf("Unexpected frame: %+v", frame)
+	}
+
+	s.endSupervision()
+	if _, open := <-leg.Frames(); open {
+		t.Error("Expected frames to close when the call ends")
+	}
+	if !s.cdr.Snapshot().SupervisorJoined {
+		t.Error("Expected CDR to record the supervisor")
+	}
+}
+
+func TestSupervisorLeg_Whisper(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.metadataSent = true // First-turn context already delivered
+
+	leg, err := s.attachSupervisor(SupervisorListen)
+	if err != nil {
+		t.Fatalf("attachSupervisor() failed: %v", err)
+	}
+	if err := leg.Whisper("Offer a consultation"); err == nil {
+		t.Error("Expected whisper to require whisper mode")
+	}
+
+	if err := leg.SetMode(SupervisorWhisper); err != nil {
+		t.Fatalf("SetMode() failed: %v", err)
+	}
+	if err := leg.Whisper("Offer a consultation"); err != nil {
+		t.Fatalf("Whisper() failed: %v", err)
+	}
+
+	metadata := s.turnMetadata()
+	if metadata[supervisorGuidanceKey] != "Offer a consultation" {
+		t.Errorf("Expected guidance in turn metadata, got %v", metadata)
+	}
+	if metadata := s.turnMetadata(); metadata != nil {
+		t.Errorf("Expected guidance to be sent once, got %v", metadata)
+	}
+}
+
+func TestSupervisorLeg_Takeover(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.metadataSent = true
+
+	leg, err := s.attachSupervisor(SupervisorTakeover)
+	if err != nil {
+		t.Fatalf("attachSupervisor() failed: %v", err)
+	}
+	if !s.aiPaused() {
+		t.Error("Expected AI to be paused during takeover")
+	}
+	if err := leg.Speak([]byte{0xff}); err != nil {
+		t.Errorf("Speak() failed: %v", err)
+	}
+	if len(s.audioOut) != 1 {
+		t.Error("Expected operator audio to be queued for the caller")
+	}
+
+	s.noteTakeoverSpeech("I'd like to reschedule")
+	leg.Close()
+
+	if s.aiPaused() {
+		t.Error("Expected AI to resume when the operator leaves")
+	}
+	if !s.cdr.Snapshot().SupervisorTakeover {
+		t.Error("Expected CDR to record the takeover")
+	}
+	guidance := s.turnMetadata()[supervisorGuidanceKey]
+	if !strings.Contains(guidance, "I'd like to reschedule") {
+		t.Errorf("Expected hand-back note with the caller's words, got %q", guidance)
+	}
+}
@@ -0,0 +1,125 @@
+package telephony
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Who started an overlap by talking second
+const (
+	talkOverCaller = "caller" // The caller spoke over the agent (barge-in)
+	talkOverAgent  = "agent"  // Agent audio started while the caller was speaking
+)
+
+// talkOverMin is the shortest overlap counted; shorter ones are a cough or
+// a VAD flicker, not talking over
+const talkOverMin = 100 * time.Millisecond
+
+// talkOverTracker measures overlap between caller speech, as local VAD hears
+// it, and agent audio, placed on a timeline as the caller hears it: each
+// chunk plays once the audio sent before it has. It is safe for concurrent use
+type talkOverTracker struct {
+	mu          sync.Mutex
+	agentSince  time.Time // When the agent audio playing now started
+	agentUntil  time.Time // When the agent audio sent so far finishes playing
+	callerSince time.Time // When the caller started speaking; zero while silent
+
+	overlap   time.Duration // The overlap in progress
+	startedBy string
+	stats     cdr.TalkOver
+}
+
+// played places n bytes of PCMU agent audio sent at now on the timeline
+func (t *talkOverTracker) played(n int, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	start := t.agentUntil
+	if now.After(start) {
+		start = now
+		t.agentSince = now // The line was quiet; the audio plays from now
+	}
+	t.agentUntil = start.Add(time.Duration(n) * time.Second / 8000)
+}
+
+// flushed stops the timeline at now, for when the caller's queued audio is cleared
+func (t *talkOverTracker) flushed(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.agentUntil.After(now) {
+		t.agentUntil = now
+	}
+}
+
+// heard records d of caller audio ending at now, and whether VAD heard speech
+// in it. It returns an overlap that just ended, if any
+func (t *talkOverTracker) heard(speaking bool, d time.Duration, now time.Time) (ended time.Duration, startedBy string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case !speaking:
+		t.callerSince = time.Time{}
+	case t.callerSince.IsZero():
+		t.callerSince = now
+	}
+
+	if speaking && now.Add(-d).Before(t.agentUntil) {
+		if t.overlap == 0 {
+			t.startedBy = talkOverCaller
+			if t.callerSince.Before(t.agentSince) {
+				t.startedBy = talkOverAgent
+			}
+		}
+		t.overlap += d
+		return 0, ""
+	}
+	return t.end()
+}
+
+// end closes the overlap in progress, counting it when it is long enough.
+// Callers hold mu
+func (t *talkOverTracker) end() (time.Duration, string) {
+	overlap, startedBy := t.overlap, t.startedBy
+	t.overlap = 0
+	if overlap < talkOverMin {
+		return 0, ""
+	}
+
+	t.stats.Overlaps++
+	if startedBy == talkOverAgent {
+		t.stats.AgentTalkOvers++
+	} else {
+		t.stats.CallerBargeIns++
+	}
+	t.stats.OverlapSecs += overlap.Seconds()
+	t.stats.LongestOverlapSecs = max(t.stats.LongestOverlapSecs, overlap.Seconds())
+	return overlap, startedBy
+}
+
+// finish closes any overlap in progress and returns the call's totals
+func (t *talkOverTracker) finish() (cdr.TalkOver, time.Duration, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ended, startedBy := t.end()
+	return t.stats, ended, startedBy
+}
+
+// trackTalkOver feeds a caller audio chunk to talk-over analytics
+func (s *CallSession) trackTalkOver(samples int, speaking bool) {
+	ended, startedBy := s.talkOver.heard(speaking, time.Duration(samples)*time.Second/8000, time.Now())
+	if ended > 0 {
+		observability.RecordTalkOver(startedBy, ended.Seconds())
+	}
+}
+
+// recordTalkOver adds the call's talk-over totals to its CDR
+func (s *CallSession) recordTalkOver() {
+	stats, ended, startedBy := s.talkOver.finish()
+	if ended > 0 {
+		observability.RecordTalkOver(startedBy, ended.Seconds())
+	}
+	s.cdr.Update(func(r *cdr.Record) { r.TalkOver = stats })
+}
@@ -0,0 +1,70 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+)
+
+// hear feeds the tracker n 20ms caller frames from *now on, advancing *now
+func hear(t *talkOverTracker, now *time.Time, frames int, speaking bool) (time.Duration, string) {
+	var ended time.Duration
+	var startedBy string
+	for i := 0; i < frames; i++ {
+		*now = now.Add(20 * time.Millisecond)
+		if d, by := t.heard(speaking, 20*time.Millisecond, *now); d > 0 {
+			ended, startedBy = d, by
+		}
+	}
+	return ended, startedBy
+}
+
+func TestTalkOverTracker(t *testing.T) {
+	var tracker talkOverTracker
+	now := time.Now()
+
+	// The caller barges in 200ms into a second of agent audio, and the agent
+	// audio is cleared 300ms later
+	tracker.played(8000, now)
+	hear(&tracker, &now, 10, false)
+	hear(&tracker, &now, 15, true)
+	tracker.flushed(now)
+	if d, by := hear(&tracker, &now, 1, true); d != 300*time.Millisecond || by != talkOverCaller {
+		t.Errorf("Expected a 300ms caller barge-in, got %v by %q", d, by)
+	}
+	hear(&tracker, &now, 10, false)
+
+	// Agent audio starts while the caller is still talking
+	hear(&tracker, &now, 5, true)
+	tracker.played(1600, now) // 200ms
+	if d, by := hear(&tracker, &now, 15, true); d != 200*time.Millisecond || by != talkOverAgent {
+		t.Errorf("Expected a 200ms agent talk-over, got %v by %q", d, by)
+	}
+
+	// A blip shorter than talkOverMin is not counted
+	tracker.played(8000, now)
+	hear(&tracker, &now, 2, true)
+	hear(&tracker, &now, 5, false)
+
+	stats, _, _ := tracker.finish()
+	want := cdr.TalkOver{Overlaps: 2, CallerBargeIns: 1, AgentTalkOvers: 1, OverlapSecs: 0.5, LongestOverlapSecs: 0.3}
+	if stats.Overlaps != want.Overlaps || stats.CallerBargeIns != want.CallerBargeIns || stats.AgentTalkOvers != want.AgentTalkOvers ||
+		stats.OverlapSecs < want.OverlapSecs-0.001 || stats.OverlapSecs > want.OverlapSecs+0.001 ||
+		stats.LongestOverlapSecs < want.LongestOverlapSecs-0.001 || stats.LongestOverlapSecs > want.LongestOverlapSecs+0.001 {
+		t.Errorf("finish() = %+v, want %+v", stats, want)
+	}
+}
+
+func TestCallSession_RecordTalkOver(t *testing.T) {
+	s := newSupervisorTestSession()
+	now := time.Now()
+	s.talkOver.played(8000, now)
+	hear(&s.talkOver, &now, 20, true) // Still overlapping when the call ends
+
+	s.recordTalkOver()
+	record := s.cdr.Finish(time.Now())
+	if record.TalkOver.Overlaps != 1 || record.TalkOver.CallerBargeIns != 1 {
+		t.Errorf("Expected the open overlap in the CDR, got %+v", record.TalkOver)
+	}
+}
@@ -0,0 +1,132 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+)
+
+// Tool progress outcomes, for metrics
+const (
+	progressSpoken     = "spoken"     // The phrase filled the wait
+	progressSkipped    = "skipped"    // The delay passed while the caller or agent was talking
+	progressSuppressed = "suppressed" // Results or text arrived before the delay
+)
+
+// toolProgress watches one turn's tool calls run by the orchestrator and says
+// when a progress phrase is due. Only the goroutine consuming the turn's
+// stream uses it; a nil toolProgress never fires
+type toolProgress struct {
+	delay   time.Duration
+	phrases map[string]string
+	phrase  string
+
+	timer   *time.Timer
+	pending map[string]string // Tool name by call ID, until its result arrives
+	tool    string            // Tool whose call started the timer
+	settled bool              // The turn spoke, or a phrase was due; no more phrases this turn
+}
+
+// newToolProgress returns the turn's tracker, or nil when the firm disables
+// progress phrases
+func (s *CallSession) newToolProgress() *toolProgress {
+	settings := s.firmSettings()
+	if settings == nil || settings.ToolProgress.DelayMs <= 0 {
+		return nil
+	}
+	return &toolProgress{
+		delay:   time.Duration(settings.ToolProgress.DelayMs) * time.Millisecond,
+		phrases: settings.ToolProgress.Phrases,
+		phrase:  settings.ToolProgress.Phrase,
+		pending: map[string]string{},
+	}
+}
+
+// due fires once the pending tools have run past the delay
+func (p *toolProgress) due() <-chan time.Time {
+	if p == nil || p.timer == nil {
+		return nil
+	}
+	return p.timer.C
+}
+
+// observe tracks a streamed response; spoke reports whether the turn has
+// queued any text, since a model that already said "let me check" needs no filler
+func (p *toolProgress) observe(response *orchestrator.OrchestratorResponse, spoke bool) {
+	if p == nil || p.settled {
+		return
+	}
+	if spoke || response.IsDone {
+		p.settled = true
+		p.stop()
+		return
+	}
+
+	// Telephony tools are run by the gateway, which speaks for them itself
+	if call := response.ToolCall; call != nil && !isTelephonyTool(call.ToolName) {
+		p.pending[call.CallID] = call.ToolName
+		if p.timer == nil {
+			p.tool = call.ToolName
+			p.timer = time.NewTimer(p.delay)
+		}
+	}
+	if result := response.ToolResult; result != nil {
+		delete(p.pending, result.CallID)
+		if len(p.pending) == 0 {
+			p.stop()
+		}
+	}
+}
+
+// stop cancels a running timer, counting the tool run as fast enough
+func (p *toolProgress) stop() {
+	if p.timer == nil {
+		return
+	}
+	p.timer.Stop()
+	p.timer = nil
+	observability.RecordToolProgress(progressSuppressed)
+}
+
+// close stops the timer when the turn ends
+func (p *toolProgress) close() {
+	if p != nil && p.timer != nil {
+		p.timer.Stop()
+	}
+}
+
+// speakToolProgress fills a slow tool run with the progress phrase, unless
+// the caller is talking or the agent is still audible
+func (s *CallSession) speakToolProgress(p *toolProgress) {
+	p.timer = nil
+	p.settled = true
+
+	s.mu.RLock()
+	callerTalking := s.isTalking
+	s.mu.RUnlock()
+	if callerTalking || s.agentAudible() || s.aiPaused() {
+		observability.RecordToolProgress(progressSkipped)
+		return
+	}
+
+	// Per-tool phrases are English; other languages hear the translated default
+	phrase := p.phrases[p.tool]
+	if phrase == "" || !i18n.IsEnglish(s.callLanguage()) {
+		phrase = s.localize(i18n.ToolProgress, p.phrase)
+	}
+	if phrase == "" {
+		return
+	}
+
+	s.logger.Info().
+		Str("tool_name", p.tool).
+		Dur("delay", p.delay).
+		Msg("Tool running long, speaking progress phrase")
+	if err := s.speak(phrase); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to speak tool progress phrase")
+		return
+	}
+	observability.RecordToolProgress(progressSpoken)
+}
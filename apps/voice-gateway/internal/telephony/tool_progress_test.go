@@ -0,0 +1,114 @@
+package telephony
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// phraseTTS records the text it is asked to synthesize
+type phraseTTS struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (p *phraseTTS) Enqueue(text string) (<-chan *tts.AudioChunk, error) {
+	p.mu.Lock()
+	p.texts = append(p.texts, text)
+	p.mu.Unlock()
+	ch := make(chan *tts.AudioChunk)
+	close(ch)
+	return ch, nil
+}
+func (p *phraseTTS) Flush(context.Context) error { return nil }
+func (p *phraseTTS) CancelAll()                  {}
+func (p *phraseTTS) Close() error                { return nil }
+func (p *phraseTTS) IsActive() bool              { return false }
+
+func (p *phraseTTS) spoken() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.texts...)
+}
+
+const toolProgressFirms = `{"firms": {"acme": {"tool_progress": {"delay_ms": 30, "phrases": {"check_calendar": "Let me check the calendar."}}}}}`
+
+// runToolTurn feeds responses to a turn's stream, waiting wait after each
+// tool call, and returns what the gateway spoke on its own
+func runToolTurn(t *testing.T, s *CallSession, wait time.Duration, responses ...*orchestrator.OrchestratorResponse) []string {
+	t.Helper()
+	voice := &phraseTTS{}
+	s.ttsClient = voice
+	s.orchestratorResponseQueue = make(chan responseChunk, 8)
+
+	ch := make(chan *orchestrator.OrchestratorResponse)
+	go func() {
+		defer close(ch)
+		for _, r := range responses {
+			ch <- r
+			if r.ToolCall != nil {
+				time.Sleep(wait)
+			}
+		}
+	}()
+	if outcome, _ := s.consumeOrchestratorStream(ch, time.Now(), 0, "conv-1", s.startTurn()); outcome != streamDone {
+		t.Fatalf("Expected the turn to finish, got outcome %d", outcome)
+	}
+	return voice.spoken()
+}
+
+func calendarCall() *orchestrator.OrchestratorResponse {
+	return &orchestrator.OrchestratorResponse{ToolCall: &orchestrator.ToolCall{ToolName: "check_calendar", CallID: "tc-1"}}
+}
+
+func calendarResult() *orchestrator.OrchestratorResponse {
+	return &orchestrator.OrchestratorResponse{ToolResult: &orchestrator.ToolResult{CallID: "tc-1", Success: true}}
+}
+
+func TestToolProgress_SpeaksForSlowTool(t *testing.T) {
+	s := newLanguageTestSession(t, toolProgressFirms)
+
+	spoken := runToolTurn(t, s, 150*time.Millisecond,
+		calendarCall(), calendarResult(), &orchestrator.OrchestratorResponse{TextChunk: "You're booked."}, &orchestrator.OrchestratorResponse{IsDone: true})
+
+	if len(spoken) != 1 || spoken[0] != "Let me check the calendar." {
+		t.Errorf("Expected the calendar progress phrase, got %v", spoken)
+	}
+}
+
+func TestToolProgress_SuppressedForFastTool(t *testing.T) {
+	s := newLanguageTestSession(t, toolProgressFirms)
+
+	spoken := runToolTurn(t, s, 0,
+		calendarCall(), calendarResult(), &orchestrator.OrchestratorResponse{TextChunk: "You're booked."}, &orchestrator.OrchestratorResponse{IsDone: true})
+
+	if len(spoken) != 0 {
+		t.Errorf("Expected no progress phrase for a fast tool, got %v", spoken)
+	}
+}
+
+func TestToolProgress_NotAfterAgentSpoke(t *testing.T) {
+	s := newLanguageTestSession(t, toolProgressFirms)
+
+	spoken := runToolTurn(t, s, 150*time.Millisecond,
+		&orchestrator.OrchestratorResponse{TextChunk: "Let me look at the schedule."}, calendarCall(), calendarResult(), &orchestrator.OrchestratorResponse{IsDone: true})
+
+	if len(spoken) != 0 {
+		t.Errorf("Expected no progress phrase once the agent spoke, got %v", spoken)
+	}
+}
+
+func TestToolProgress_TranslatedDefault(t *testing.T) {
+	s := newLanguageTestSession(t, toolProgressFirms)
+	s.language = "es"
+
+	spoken := runToolTurn(t, s, 150*time.Millisecond, calendarCall(), calendarResult(), &orchestrator.OrchestratorResponse{IsDone: true})
+
+	if len(spoken) != 1 || spoken[0] != "Un momento, por favor, mientras lo reviso." {
+		t.Errorf("Expected the Spanish progress phrase, got %v", spoken)
+	}
+}
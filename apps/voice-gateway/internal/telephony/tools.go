@@ -0,0 +1,367 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+)
+
+// Telephony tools executed by the gateway rather than the orchestrator
+const (
+	ToolTransferCall = "transfer_call"
+	ToolSendSMS      = "send_sms"
+	ToolPlayAudio    = "play_audio"
+	ToolHangup       = "hangup"
+	ToolCollectDTMF  = "collect_dtmf"
+	ToolConfirm      = "confirm"
+	ToolReadBack     = "read_back"
+
+	ToolScheduleCallback = "schedule_callback"
+	ToolVerifyCaller     = "verify_caller"
+)
+
+const (
+	// toolTimeout bounds a single tool execution
+	toolTimeout = 60 * time.Second
+
+	// defaultDTMFTimeout is how long collect_dtmf waits between digits
+	defaultDTMFTimeout = 10 * time.Second
+)
+
+// toolHandler executes a telephony tool and returns a JSON-encodable result
+type toolHandler func(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error)
+
+var telephonyTools = map[string]toolHandler{
+	ToolTransferCall: transferCallTool,
+	ToolSendSMS:      sendSMSTool,
+	ToolPlayAudio:    playAudioTool,
+	ToolHangup:       hangupTool,
+	ToolCollectDTMF:  collectDTMFTool,
+	ToolConfirm:      confirmTool,
+	ToolReadBack:     readBackTool,
+
+	ToolScheduleCallback: scheduleCallbackTool,
+	ToolVerifyCaller:     verifyCallerTool,
+}
+
+// isTelephonyTool returns whether the gateway executes the named tool
+func isTelephonyTool(name string) bool {
+	_, ok := telephonyTools[name]
+	return ok
+}
+
+// executeTool runs a telephony tool call and streams its result back to the orchestrator
+func (s *CallSession) executeTool(call *orchestrator.ToolCall) {
+	handler := telephonyTools[call.ToolName]
+
+	timeout := toolTimeout
+	if call.ToolName == ToolVerifyCaller {
+		timeout = verificationToolTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	s.goSafe("tool_cancel", func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	})
+
+	params := json.RawMessage(call.ParametersJSON)
+	if len(strings.TrimSpace(call.ParametersJSON)) == 0 {
+		params = json.RawMessage("{}")
+	}
+
+	start := time.Now()
+	output, err := handler(ctx, s, params)
+
+	result := &orchestrator.ToolResult{CallID: call.CallID, Success: err == nil}
+	if err != nil {
+		result.ErrorMessage = err.Error()
+	} else if encoded, encErr := json.Marshal(output); encErr != nil {
+		result.Success = false
+		result.ErrorMessage = fmt.Sprintf("failed to encode result: %v", encErr)
+	} else {
+		result.ResultJSON = string(encoded)
+	}
+
+	observability.RecordToolExecution(call.ToolName, result.Success)
+	s.logger.Info().
+		Str("tool_name", call.ToolName).
+		Str("call_id", call.CallID).
+		Bool("success", result.Success).
+		Str("error", result.ErrorMessage).
+		Dur("duration", time.Since(start)).
+		Msg("Telephony tool executed")
+
+	s.submitToolResult(call.ToolName, result)
+}
+
+// submitToolResult sends a result on the call's tool result stream, opening it on first use
+func (s *CallSession) submitToolResult(toolName string, result *orchestrator.ToolResult) {
+	if s.orchestratorClient == nil {
+		return
+	}
+
+	s.toolMu.Lock()
+	defer s.toolMu.Unlock()
+
+	if s.toolResults == nil {
+		stream, err := s.orchestratorClient.OpenToolResultStream(s.GetConversationID())
+		if errors.Is(err, orchestrator.ErrUnsupported) {
+			// An older Orchestrator; the tool still ran, it just never hears the result
+			s.logger.Debug().Str("tool_name", toolName).Msg("Orchestrator does not accept tool results")
+			return
+		}
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to open tool result stream")
+			return
+		}
+		s.toolResults = stream
+	}
+
+	if err := s.toolResults.Send(toolName, result); err != nil {
+		s.logger.Error().Err(err).Str("call_id", result.CallID).Msg("Failed to submit tool result")
+		// The stream is broken; reopen on the next result
+		s.toolResults = nil
+	}
+}
+
+// closeToolResults closes the tool result stream at call end
+func (s *CallSession) closeToolResults() {
+	s.toolMu.Lock()
+	defer s.toolMu.Unlock()
+
+	if s.toolResults == nil {
+		return
+	}
+	received, err := s.toolResults.Close()
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Error closing tool result stream")
+	} else {
+		s.logger.Debug().Int32("received", received).Msg("Tool result stream closed")
+	}
+	s.toolResults = nil
+}
+
+func transferCallTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Number  string `json:"number"`
+		Summary string `json:"summary"` // Read to the staff member on a warm transfer
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	// Without a number the firm's transfer_number (or hunt group) is rung;
+	// the model may only pick among the firm's own transfer numbers
+	if p.Number != "" {
+		if settings := s.firmSettings(); settings == nil || !settings.IsTransferNumber(p.Number) {
+			return nil, fmt.Errorf("%s is not one of the firm's transfer numbers", p.Number)
+		}
+	}
+	if err := s.transferCall(p.Number, p.Summary); err != nil {
+		return nil, err
+	}
+	if p.Number == "" {
+		return map[string]string{"status": "transferred"}, nil
+	}
+	return map[string]string{"status": "transferred", "number": p.Number}, nil
+}
+
+func sendSMSTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		To       string            `json:"to"`
+		Body     string            `json:"body"`
+		Template string            `json:"template"`
+		Data     map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if s.services.SMS == nil {
+		return nil, fmt.Errorf("sms not configured")
+	}
+
+	s.mu.RLock()
+	firmID := s.firmID
+	req := sms.Request{
+		FirmID:   firmID,
+		To:       p.To,
+		Template: p.Template,
+		Data:     p.Data,
+		Body:     p.Body,
+	}
+	if req.To == "" {
+		req.To = s.callerNumber
+	}
+	allowed := firm.NormalizeNumber(req.To) == firm.NormalizeNumber(s.callerNumber) ||
+		(s.contact != nil && firm.NormalizeNumber(req.To) == firm.NormalizeNumber(s.contact.Phone))
+	calledNumber := s.calledNumber
+	s.mu.RUnlock()
+
+	// Texts go to the caller, or to the client the CRM matched them to
+	if req.To == "" || !allowed {
+		return nil, fmt.Errorf("texts can only be sent to the caller's own number or their CRM contact number")
+	}
+
+	// Reply from the number the caller dialed unless the firm pins one
+	settings := s.services.Firms.Get(firmID)
+	if settings.SMS.FromNumber == "" {
+		req.From = calledNumber
+	}
+
+	result, err := s.services.SMS.Send(ctx, settings, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.SMS = append(r.SMS, cdr.SMS{
+			SID:      result.SID,
+			To:       result.To,
+			Template: result.Template,
+			SentAt:   result.SentAt,
+		})
+	})
+	return map[string]string{"status": "sent", "message_sid": result.SID}, nil
+}
+
+func playAudioTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		URL  string `json:"url"`
+		File string `json:"file"` // Storage key (local disk or S3)
+		Loop bool   `json:"loop"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	switch {
+	case p.URL != "" || p.File != "":
+		ref := p.URL
+		if ref == "" {
+			key, err := firmAudioKey(s.GetFirmID(), p.File)
+			if err != nil {
+				return nil, err
+			}
+			ref = "storage://" + key
+		}
+		duration, err := s.playAudio(ctx, ref, p.Loop)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"status": "playing", "duration_seconds": duration.Seconds(), "loop": p.Loop}, nil
+	case p.Text != "":
+		if err := s.speak(p.Text); err != nil {
+			return nil, err
+		}
+		return map[string]string{"status": "playing"}, nil
+	default:
+		return nil, fmt.Errorf("url, file, or text is required")
+	}
+}
+
+// firmAudioKey checks that a storage key the model asked to play is one of
+// the firm's own audio files, under "audio/<firm>/", so a call can't play
+// another firm's audio or anyone's recordings and voicemail
+func firmAudioKey(firmID, key string) (string, error) {
+	if firmID == "" {
+		return "", fmt.Errorf("no firm to play audio files for")
+	}
+	prefix := "audio/" + firmID + "/"
+	// A clean key has no "..", "." or empty parts to climb out of the prefix
+	if !strings.HasPrefix(key, prefix) || path.Clean(key) != key {
+		return "", fmt.Errorf("file must be an audio file under %s", prefix)
+	}
+	return key, nil
+}
+
+func hangupTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if p.Reason == "" {
+		p.Reason = "agent hangup"
+	}
+
+	// Let any goodbye already queued finish before dropping the line
+	s.goSafe("tool_hangup", func() {
+		s.waitForPlayback(hangupDrainTimeout)
+		s.hangup(p.Reason)
+	})
+	return map[string]string{"status": "hanging_up"}, nil
+}
+
+func collectDTMFTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		NumDigits      int    `json:"num_digits"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+		FinishOnKey    string `json:"finish_on_key"`
+		Prompt         string `json:"prompt"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	if p.NumDigits <= 0 && p.FinishOnKey == "" {
+		p.NumDigits = 1
+	}
+	timeout := defaultDTMFTimeout
+	if p.TimeoutSeconds > 0 {
+		timeout = time.Duration(p.TimeoutSeconds) * time.Second
+	}
+
+	// Discard keypresses made before the request
+	for drained := false; !drained; {
+		select {
+		case <-s.dtmfDigits:
+		default:
+			drained = true
+		}
+	}
+
+	if p.Prompt != "" {
+		if err := s.speak(p.Prompt); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to play DTMF prompt")
+		}
+	}
+
+	var digits strings.Builder
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case digit := <-s.dtmfDigits:
+			if p.FinishOnKey != "" && digit == p.FinishOnKey {
+				return map[string]interface{}{"digits": digits.String(), "timed_out": false}, nil
+			}
+			digits.WriteString(digit)
+			if p.NumDigits > 0 && digits.Len() >= p.NumDigits {
+				return map[string]interface{}{"digits": digits.String(), "timed_out": false}, nil
+			}
+			// Inter-digit timeout restarts with each keypress
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+		case <-timer.C:
+			return map[string]interface{}{"digits": digits.String(), "timed_out": true}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/contacts"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+	"github.com/rs/zerolog"
+)
+
+func newToolTestSession() *CallSession {
+	return &CallSession{
+		dtmfDigits: make(chan string, 32),
+		done:       make(chan struct{}),
+		logger:     zerolog.Nop(),
+	}
+}
+
+// collectDigits runs collect_dtmf and keys in digits once it is listening
+func collectDigits(t *testing.T, s *CallSession, params string, digits ...string) map[string]interface{} {
+	t.Helper()
+
+	done := make(chan map[string]interface{}, 1)
+	go func() {
+		result, err := collectDTMFTool(context.Background(), s, json.RawMessage(params))
+		if err != nil {
+			t.Errorf("collectDTMFTool() failed: %v", err)
+		}
+		done <- result.(map[string]interface{})
+	}()
+
+	// Keypresses made before the tool runs are discarded, so wait for it to start
+	time.Sleep(50 * time.Millisecond)
+	for _, d := range digits {
+		s.dtmfDigits <- d
+	}
+	return <-done
+}
+
+func TestCollectDTMFTool_NumDigits(t *testing.T) {
+	s := newToolTestSession()
+	s.dtmfDigits <- "9" // stale digit from before the request
+
+	got := collectDigits(t, s, `{"num_digits":3,"timeout_seconds":2}`, "1", "2", "3", "4")
+	if got["digits"] != "123" || got["timed_out"] != false {
+		t.Errorf("Expected digits 123, got %v", got)
+	}
+}
+
+func TestCollectDTMFTool_FinishOnKey(t *testing.T) {
+	s := newToolTestSession()
+
+	got := collectDigits(t, s, `{"finish_on_key":"#","timeout_seconds":2}`, "4", "2", "#")
+	if got["digits"] != "42" || got["timed_out"] != false {
+		t.Errorf("Expected digits 42, got %v", got)
+	}
+}
+
+func TestCollectDTMFTool_Timeout(t *testing.T) {
+	s := newToolTestSession()
+
+	result, err := collectDTMFTool(context.Background(), s, json.RawMessage(`{"num_digits":4,"timeout_seconds":1}`))
+	if err != nil {
+		t.Fatalf("collectDTMFTool() failed: %v", err)
+	}
+	got := result.(map[string]interface{})
+	if !got["timed_out"].(bool) || got["digits"].(string) != "" {
+		t.Errorf("Expected empty timed-out result, got %v", got)
+	}
+}
+
+func TestFirmAudioKey(t *testing.T) {
+	if key, err := firmAudioKey("acme", "audio/acme/hold.wav"); err != nil || key != "audio/acme/hold.wav" {
+		t.Errorf("Expected the firm's own audio file, got %q (%v)", key, err)
+	}
+	for _, key := range []string{
+		"audio/other/hold.wav",
+		"recordings/acme/CA1.wav",
+		"audio/acme/../../recordings/acme/CA1.wav",
+		"/audio/acme/hold.wav",
+		"audio/acme/",
+		"hold.wav",
+	} {
+		if _, err := firmAudioKey("acme", key); err == nil {
+			t.Errorf("Expected %q to be refused", key)
+		}
+	}
+	if _, err := firmAudioKey("", "audio//hold.wav"); err == nil {
+		t.Error("Expected file playback refused without a firm")
+	}
+}
+
+// smsTransport records texts instead of sending them
+type smsTransport struct{ to []string }
+
+func (f *smsTransport) SendSMS(ctx context.Context, from, to, body string) (string, error) {
+	f.to = append(f.to, to)
+	return "SM1", nil
+}
+
+func TestSendSMSTool_OnlyTextsTheCallerOrTheirContact(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+	transport := &smsTransport{}
+	s.services.SMS = sms.NewSender(transport)
+	s.callerNumber = "+15551110000"
+	s.calledNumber = "+15559990000"
+	s.contact = &contacts.Contact{ID: "c1", Phone: "+1 555 222 0000"}
+
+	for _, to := range []string{"", "+15551110000", "+15552220000"} {
+		if _, err := sendSMSTool(context.Background(), s, json.RawMessage(`{"to":"`+to+`","body":"Your appointment is confirmed"}`)); err != nil {
+			t.Errorf("Expected a text to %q to be sent, got %v", to, err)
+		}
+	}
+	if _, err := sendSMSTool(context.Background(), s, json.RawMessage(`{"to":"+19005550199","body":"Hi"}`)); err == nil {
+		t.Error("Expected a text to another number to be refused")
+	}
+	if len(transport.to) != 3 {
+		t.Errorf("Expected three texts sent, got %v", transport.to)
+	}
+}
+
+func TestTransferCallTool_OnlyTransfersToFirmNumbers(t *testing.T) {
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"routing": {"transfer_number": "+15550000001"}}}}`)
+	_, err := transferCallTool(context.Background(), s, json.RawMessage(`{"number":"+19005550199"}`))
+	if err == nil || !strings.Contains(err.Error(), "not one of the firm's transfer numbers") {
+		t.Errorf("Expected a transfer to another number to be refused, got %v", err)
+	}
+}
+
+func TestIsTelephonyTool(t *testing.T) {
+	for _, name := range []string{ToolTransferCall, ToolSendSMS, ToolPlayAudio, ToolHangup, ToolCollectDTMF, ToolVerifyCaller} {
+		if !isTelephonyTool(name) {
+			t.Errorf("Expected %s to be a telephony tool", name)
+		}
+	}
+	if isTelephonyTool("search_documents") {
+		t.Error("Expected search_documents to be handled by the orchestrator")
+	}
+}
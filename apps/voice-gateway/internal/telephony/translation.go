@@ -0,0 +1,70 @@
+package telephony
+
+import (
+	"context"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Translation directions, for the translations metric
+const (
+	translateCaller = "caller" // Caller speech into the firm's language
+	translateAgent  = "agent"  // Assistant replies into the caller's language
+)
+
+// translationLanguages returns the caller's and the firm's languages when the
+// firm relays the call through translation and the two differ
+func (s *CallSession) translationLanguages() (caller, firm string, ok bool) {
+	if s.services == nil || s.services.Translator == nil {
+		return "", "", false
+	}
+	settings := s.firmSettings()
+	if settings == nil || !settings.Translation.Enabled {
+		return "", "", false
+	}
+	firm = settings.Translation.Language
+	if firm == "" {
+		firm = "en"
+	}
+	caller = s.callLanguage()
+	if i18n.Base(caller) == "" || i18n.Base(caller) == i18n.Base(firm) {
+		return "", "", false
+	}
+	return caller, firm, true
+}
+
+// translateForFirm returns caller speech in the firm's language, or the text
+// unchanged when the call needs no translation or it fails
+func (s *CallSession) translateForFirm(text string) string {
+	caller, firm, ok := s.translationLanguages()
+	if !ok {
+		return text
+	}
+	return s.translate(translateCaller, text, caller, firm)
+}
+
+// translateForCaller returns an assistant reply in the caller's language, or
+// the text unchanged when the call needs no translation or it fails
+func (s *CallSession) translateForCaller(text string) string {
+	caller, firm, ok := s.translationLanguages()
+	if !ok || text == "" {
+		return text
+	}
+	return s.translate(translateAgent, text, firm, caller)
+}
+
+// translate runs one translation within TRANSLATION_TIMEOUT_MS. Untranslated
+// text keeps the call going: the assistant and callers often cope with it
+func (s *CallSession) translate(direction, text, from, to string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.config.TranslationTimeoutMs)*time.Millisecond)
+	defer cancel()
+	translated, err := s.services.Translator.Translate(ctx, text, from, to)
+	observability.RecordTranslation(direction, err == nil)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("direction", direction).Str("from", from).Str("to", to).Msg("Translation failed, using the text untranslated")
+		return text
+	}
+	return translated
+}
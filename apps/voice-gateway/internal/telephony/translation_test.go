@@ -0,0 +1,88 @@
+package telephony
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/live"
+)
+
+// fakeTranslator tags text with the languages it was translated between
+type fakeTranslator struct {
+	calls int
+	err   error
+}
+
+func (f *fakeTranslator) Translate(ctx context.Context, text, from, to string) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return "[" + from + ">" + to + "] " + text, nil
+}
+
+func newTranslationTestSession(t *testing.T, firms string) (*CallSession, *fakeTranslator) {
+	t.Helper()
+	s := newLanguageTestSession(t, firms)
+	s.config.TranslationTimeoutMs = 1000
+	translator := &fakeTranslator{}
+	s.services.Translator = translator
+	return s, translator
+}
+
+func TestCallSession_Translation(t *testing.T) {
+	s, translator := newTranslationTestSession(t, `{"firms": {"acme": {"translation": {"enabled": true}}}}`)
+
+	// An English caller needs no translation
+	if got := s.translateForFirm("I need a lawyer"); got != "I need a lawyer" || translator.calls != 0 {
+		t.Errorf("Expected English speech untranslated, got %q", got)
+	}
+
+	s.language = "es-MX"
+	if got := s.translateForFirm("Necesito un abogado"); got != "[es-MX>en] Necesito un abogado" {
+		t.Errorf("Expected caller speech in the firm's language, got %q", got)
+	}
+	if got := s.translateForCaller("How can I help?"); got != "[en>es-MX] How can I help?" {
+		t.Errorf("Expected the reply in the caller's language, got %q", got)
+	}
+
+	// A failed translation keeps the call going untranslated
+	translator.err = errors.New("unavailable")
+	if got := s.translateForFirm("Necesito un abogado"); got != "Necesito un abogado" {
+		t.Errorf("Expected the text untranslated when translation fails, got %q", got)
+	}
+}
+
+func TestCallSession_TranslationOff(t *testing.T) {
+	s, translator := newTranslationTestSession(t, `{"firms": {"acme": {"translation": {"language": "es"}}}}`)
+	s.language = "fr"
+	if got := s.translateForFirm("Bonjour"); got != "Bonjour" || translator.calls != 0 {
+		t.Errorf("Expected no translation unless the firm enables it, got %q", got)
+	}
+
+	// A caller speaking the firm's language needs none either
+	s, translator = newTranslationTestSession(t, `{"firms": {"acme": {"translation": {"enabled": true, "language": "es"}}}}`)
+	s.language = "es-MX"
+	if got := s.translateForCaller("¿En qué puedo ayudarle?"); translator.calls != 0 || got != "¿En qué puedo ayudarle?" {
+		t.Errorf("Expected no translation within the firm's language, got %q", got)
+	}
+}
+
+func TestCallTranscript_KeepsSpokenSpeech(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerCaller, Text: "Hello.", Final: true})
+	s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerCaller, Text: "I need a lawyer.", Spoken: "Necesito un abogado.", Final: true})
+	s.publishLive(live.Event{Type: live.TypeTranscript, Speaker: live.SpeakerAgent, Text: "How can I help?", Spoken: "¿Cómo puedo ayudarle?", Final: true})
+
+	turns := s.transcript.snapshot()
+	if len(turns) != 2 {
+		t.Fatalf("Expected a caller and an agent turn, got %+v", turns)
+	}
+	if turns[0].Text != "Hello. I need a lawyer." || turns[0].Spoken != "Hello. Necesito un abogado." {
+		t.Errorf("Expected both transcripts of the caller's turn, got %+v", turns[0])
+	}
+	if turns[1].Spoken != "¿Cómo puedo ayudarle?" {
+		t.Errorf("Expected the reply as spoken, got %+v", turns[1])
+	}
+}
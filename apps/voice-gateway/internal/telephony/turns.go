@@ -0,0 +1,148 @@
+package telephony
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// Reasons an orchestrator text chunk is dropped before synthesis
+const (
+	chunkSuperseded = "superseded" // The caller has spoken again since the chunk's turn
+	chunkDuplicate  = "duplicate"  // The chunk was already queued
+)
+
+// orchestratorTurn is one caller turn sent to the orchestrator; its text
+// chunks are numbered in the order the stream sent them. Its context is
+// cancelled once a newer turn supersedes it
+type orchestratorTurn struct {
+	id         uint64
+	chunks     int
+	characters int // Reply text queued for speech
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+// responseChunk is orchestrator text queued for synthesis
+type responseChunk struct {
+	turn uint64
+	seq  int
+	text string
+}
+
+// startTurn numbers a caller turn sent to the orchestrator. A response to an
+// earlier turn still streaming is superseded: its stream is cancelled and
+// what it queued for the caller is flushed, so only the latest turn is answered
+func (s *CallSession) startTurn() *orchestratorTurn {
+	ctx, cancel := context.WithCancel(context.Background())
+	turn := &orchestratorTurn{id: s.turn.Add(1), ctx: ctx, cancel: cancel}
+
+	s.turnMu.Lock()
+	previous := s.activeTurn
+	s.activeTurn = turn
+	s.turnMu.Unlock()
+
+	if previous != nil {
+		previous.cancel()
+		observability.RecordOrchestratorTurnSuperseded()
+		s.logger.Info().Uint64("turn", previous.id).Msg("Caller spoke again, superseding the orchestrator turn in flight")
+		s.flushResponseAudio()
+	}
+	return turn
+}
+
+// finishTurn marks a turn's stream as finished; the caller speaking again
+// after this no longer flushes its response
+func (s *CallSession) finishTurn(turn *orchestratorTurn) {
+	turn.cancel()
+	s.turnMu.Lock()
+	if s.activeTurn == turn {
+		s.activeTurn = nil
+	}
+	s.turnMu.Unlock()
+}
+
+// flushResponseAudio stops a superseded response: synthesis in progress,
+// audio waiting to be sent, and audio Twilio has buffered but not yet played
+func (s *CallSession) flushResponseAudio() {
+	s.mu.Lock()
+	if s.ttsClient != nil {
+		s.ttsClient.CancelAll()
+	}
+	streamSid := s.streamSid
+	s.mu.Unlock()
+
+	s.discardAudioOut()
+	now := time.Now()
+	if s.echo != nil {
+		s.echo.Flush(now)
+	}
+	s.talkOver.flushed(now)
+	if s.writer != nil && streamSid != "" {
+		clearMsg := map[string]interface{}{
+			"event":     "clear",
+			"streamSid": streamSid,
+		}
+		if err := s.writer.enqueue(outboundClear, clearMsg); err != nil {
+			s.logger.Warn().Err(err).Msg("Failed to clear superseded response audio")
+		}
+	}
+}
+
+// discardAudioOut drops the audio queued on audioOut without sending it
+func (s *CallSession) discardAudioOut() {
+	for {
+		select {
+		case <-s.audioOut:
+		default:
+			return
+		}
+	}
+}
+
+// superseded reports whether the caller has started a newer turn
+func (s *CallSession) superseded(turn uint64) bool {
+	return turn < s.turn.Load()
+}
+
+// turnText accumulates one turn's chunks for synthesis, so that responses
+// to turns the caller talked over never interleave with the latest one
+type turnText struct {
+	turn  uint64
+	seq   int
+	text  strings.Builder
+	words int  // Words of the turn sent for speech
+	cut   bool // The turn's response was cut short at the maximum length
+}
+
+// add appends a chunk, reporting whether it was kept. A chunk from a newer
+// turn discards the older turn's unspoken text
+func (t *turnText) add(chunk responseChunk) bool {
+	switch {
+	case chunk.turn < t.turn:
+		observability.RecordOrchestratorChunkDropped(chunkSuperseded)
+		return false
+	case chunk.turn > t.turn:
+		if t.text.Len() > 0 {
+			observability.RecordOrchestratorChunkDropped(chunkSuperseded)
+			t.text.Reset()
+		}
+		t.turn, t.seq = chunk.turn, 0
+		t.words, t.cut = 0, false
+	case chunk.seq <= t.seq:
+		observability.RecordOrchestratorChunkDropped(chunkDuplicate)
+		return false
+	}
+	t.seq = chunk.seq
+	t.text.WriteString(chunk.text)
+	return true
+}
+
+// take returns the accumulated text and empties the buffer
+func (t *turnText) take() string {
+	text := t.text.String()
+	t.text.Reset()
+	return text
+}
@@ -0,0 +1,92 @@
+package telephony
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/orchestrator"
+)
+
+func TestTurnText_OrdersChunksByTurn(t *testing.T) {
+	var text turnText
+
+	if !text.add(responseChunk{turn: 1, seq: 1, text: "Sure, "}) {
+		t.Fatal("Expected the first chunk to be kept")
+	}
+	if text.add(responseChunk{turn: 1, seq: 1, text: "Sure, "}) {
+		t.Error("Expected a duplicate chunk to be dropped")
+	}
+
+	// The caller spoke again: the first turn's unspoken text is discarded
+	text.add(responseChunk{turn: 2, seq: 1, text: "Of course. "})
+	if text.add(responseChunk{turn: 1, seq: 2, text: "let me check."}) {
+		t.Error("Expected a chunk from a superseded turn to be dropped")
+	}
+	text.add(responseChunk{turn: 2, seq: 2, text: "Tuesday works."})
+
+	if got := text.take(); got != "Of course. Tuesday works." {
+		t.Errorf("Expected only the latest turn's text, got %q", got)
+	}
+	if text.text.Len() != 0 {
+		t.Error("Expected take to empty the buffer")
+	}
+}
+
+func TestHandleOrchestratorResponse_DropsSupersededTurn(t *testing.T) {
+	s := newStreamTestSession()
+	first := s.startTurn()
+	second := s.startTurn()
+
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{TextChunk: "Let me check"}, "conv-1", first)
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{TextChunk: "Sure"}, "conv-1", second)
+	s.handleOrchestratorResponse(&orchestrator.OrchestratorResponse{TextChunk: ", one moment"}, "conv-1", second)
+
+	if len(s.orchestratorResponseQueue) != 2 {
+		t.Fatalf("Expected only the latest turn's 2 chunks to be queued, got %d", len(s.orchestratorResponseQueue))
+	}
+	for seq := 1; seq <= 2; seq++ {
+		chunk := <-s.orchestratorResponseQueue
+		if chunk.turn != second.id || chunk.seq != seq {
+			t.Errorf("Expected chunk %d of turn %d, got %+v", seq, second.id, chunk)
+		}
+	}
+}
+
+func TestStartTurn_CancelsTurnInFlight(t *testing.T) {
+	s := newStreamTestSession()
+	s.config.OrchestratorStallTimeoutSeconds = 5
+	attempts := 0
+	open := scriptedOpener(&attempts, func(int) ([]*orchestrator.OrchestratorResponse, bool) {
+		return []*orchestrator.OrchestratorResponse{{TextChunk: "Let me check"}}, true
+	})
+
+	first := s.startTurn()
+	finished := make(chan struct{})
+	go func() {
+		s.runOrchestratorTurn(open, "conv-1", first)
+		close(finished)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	s.audioOut <- []byte{0xFF}
+
+	second := s.startTurn()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the superseded turn's stream to be cancelled")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a superseded turn not to be retried, got %d attempts", attempts)
+	}
+	if len(s.audioOut) != 0 {
+		t.Error("Expected the superseded response's queued audio to be flushed")
+	}
+
+	// A turn that finished streaming is not superseded mid-response
+	s.finishTurn(second)
+	s.audioOut <- []byte{0xFF}
+	s.startTurn()
+	if len(s.audioOut) != 1 {
+		t.Error("Expected audio to be kept when no turn was in flight")
+	}
+}
@@ -0,0 +1,139 @@
+package telephony
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// twilioWriteTimeout bounds one WebSocket write to Twilio, unless
+	// TWILIO_WRITE_TIMEOUT_MS says otherwise
+	twilioWriteTimeout = 5 * time.Second
+
+	// maxOutboundQueue caps media and marks waiting to be written; about two
+	// seconds of 20ms media frames
+	maxOutboundQueue = 100
+)
+
+// errOutboundQueueFull is returned when Twilio is not keeping up with outbound audio
+var errOutboundQueueFull = errors.New("outbound queue full")
+
+// outboundKind is the type of a message sent to Twilio
+type outboundKind string
+
+const (
+	outboundMedia outboundKind = "media"
+	outboundMark  outboundKind = "mark"
+	outboundClear outboundKind = "clear"
+)
+
+// outboundMessage is a message waiting for the Twilio writer
+type outboundMessage struct {
+	kind    outboundKind
+	payload interface{}
+}
+
+// jsonWriter is the part of the WebSocket connection the writer needs
+type jsonWriter interface {
+	WriteJSON(v interface{}) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// twilioWriter is the only writer on the Twilio WebSocket, which does not allow
+// concurrent writes. Media and marks are written in the order queued, since a
+// mark reports when the audio before it has played; clear jumps the queue and
+// drops media queued ahead of it, which would otherwise play after the clear
+type twilioWriter struct {
+	conn    jsonWriter
+	onError func(kind outboundKind, err error)
+	timeout time.Duration // Bounds each write
+
+	mu      sync.Mutex
+	control []outboundMessage
+	stream  []outboundMessage
+	wake    chan struct{}
+}
+
+func newTwilioWriter(conn jsonWriter, onError func(kind outboundKind, err error)) *twilioWriter {
+	return &twilioWriter{
+		conn:    conn,
+		onError: onError,
+		timeout: twilioWriteTimeout,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// enqueue queues a message for the writer goroutine
+func (w *twilioWriter) enqueue(kind outboundKind, payload interface{}) error {
+	msg := outboundMessage{kind: kind, payload: payload}
+
+	w.mu.Lock()
+	if kind == outboundClear {
+		w.control = append(w.control, msg)
+		stream := w.stream[:0]
+		for _, queued := range w.stream {
+			if queued.kind != outboundMedia {
+				stream = append(stream, queued)
+			}
+		}
+		w.stream = stream
+	} else {
+		if len(w.stream) >= maxOutboundQueue {
+			w.mu.Unlock()
+			return errOutboundQueueFull
+		}
+		w.stream = append(w.stream, msg)
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// next pops the next message to write, clears first, with the connection to write it on
+func (w *twilioWriter) next() (outboundMessage, jsonWriter, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.control) > 0 {
+		msg := w.control[0]
+		w.control = w.control[1:]
+		return msg, w.conn, true
+	}
+	if len(w.stream) > 0 {
+		msg := w.stream[0]
+		w.stream = w.stream[1:]
+		return msg, w.conn, true
+	}
+	return outboundMessage{}, w.conn, false
+}
+
+// setConn moves writing onto a new connection, when a dropped stream reconnects
+func (w *twilioWriter) setConn(conn jsonWriter) {
+	w.mu.Lock()
+	w.conn = conn
+	w.mu.Unlock()
+}
+
+// run writes queued messages until done closes
+func (w *twilioWriter) run(done <-chan struct{}) {
+	for {
+		msg, conn, ok := w.next()
+		if !ok {
+			select {
+			case <-w.wake:
+				continue
+			case <-done:
+				return
+			}
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(w.timeout))
+		if err := conn.WriteJSON(msg.payload); err != nil && w.onError != nil {
+			w.onError(msg.kind, err)
+		}
+	}
+}
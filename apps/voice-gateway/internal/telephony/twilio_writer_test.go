@@ -0,0 +1,104 @@
+package telephony
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTwilioConn records the messages written to it
+type fakeTwilioConn struct {
+	mu      sync.Mutex
+	written []interface{}
+	err     error
+}
+
+func (c *fakeTwilioConn) WriteJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = append(c.written, v)
+	return c.err
+}
+
+func (c *fakeTwilioConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func (c *fakeTwilioConn) messages() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]interface{}(nil), c.written...)
+}
+
+func TestTwilioWriter_ClearJumpsQueue(t *testing.T) {
+	conn := &fakeTwilioConn{}
+	w := newTwilioWriter(conn, nil)
+
+	for _, msg := range []outboundMessage{
+		{outboundMedia, "media-1"},
+		{outboundMark, "mark-1"},
+		{outboundMedia, "media-2"},
+		{outboundClear, "clear"},
+		{outboundMedia, "media-3"},
+	} {
+		if err := w.enqueue(msg.kind, msg.payload); err != nil {
+			t.Fatalf("enqueue(%s) failed: %v", msg.kind, err)
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go w.run(done)
+
+	want := []interface{}{"clear", "mark-1", "media-3"}
+	deadline := time.Now().Add(time.Second)
+	for len(conn.messages()) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	got := conn.messages()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestTwilioWriter_QueueFull(t *testing.T) {
+	w := newTwilioWriter(&fakeTwilioConn{}, nil)
+	for i := 0; i < maxOutboundQueue; i++ {
+		if err := w.enqueue(outboundMedia, i); err != nil {
+			t.Fatalf("enqueue %d failed: %v", i, err)
+		}
+	}
+	if err := w.enqueue(outboundMedia, "overflow"); !errors.Is(err, errOutboundQueueFull) {
+		t.Errorf("Expected errOutboundQueueFull, got %v", err)
+	}
+	if err := w.enqueue(outboundClear, "clear"); err != nil {
+		t.Errorf("Expected clear to be accepted on a full queue, got %v", err)
+	}
+}
+
+func TestTwilioWriter_ReportsWriteErrors(t *testing.T) {
+	conn := &fakeTwilioConn{err: errors.New("broken pipe")}
+	failed := make(chan outboundKind, 1)
+	w := newTwilioWriter(conn, func(kind outboundKind, err error) { failed <- kind })
+
+	done := make(chan struct{})
+	defer close(done)
+	go w.run(done)
+
+	if err := w.enqueue(outboundMark, "mark-1"); err != nil {
+		t.Fatalf("enqueue failed: %v", err)
+	}
+	select {
+	case kind := <-failed:
+		if kind != outboundMark {
+			t.Errorf("Expected mark write error, got %s", kind)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected write error to be reported")
+	}
+}
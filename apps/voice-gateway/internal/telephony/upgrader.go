@@ -0,0 +1,38 @@
+package telephony
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/cors"
+)
+
+// newUpgrader accepts Twilio, which sends no Origin header, and browsers only
+// from origins the policy allows. Buffer sizes and compression come from the
+// WS_* settings
+func newUpgrader(cfg *config.Config, origins *cors.Policy) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		CheckOrigin:       origins.CheckOrigin,
+		ReadBufferSize:    cfg.WSReadBufferSize,
+		WriteBufferSize:   cfg.WSWriteBufferSize,
+		EnableCompression: cfg.WSCompression,
+	}
+}
+
+// tuneConn applies the configured compression level to a connection whose
+// peer negotiated permessage-deflate; it is a no-op otherwise
+func tuneConn(conn *websocket.Conn, cfg *config.Config) {
+	if !cfg.WSCompression {
+		return
+	}
+	_ = conn.SetCompressionLevel(cfg.WSCompressionLevel)
+}
+
+// writeTimeout bounds one write to Twilio, data or control frame
+func (s *CallSession) writeTimeout() time.Duration {
+	if s.config == nil || s.config.TwilioWriteTimeoutMs <= 0 {
+		return twilioWriteTimeout
+	}
+	return time.Duration(s.config.TwilioWriteTimeoutMs) * time.Millisecond
+}
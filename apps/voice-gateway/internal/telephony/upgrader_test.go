@@ -0,0 +1,112 @@
+package telephony
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// newEchoServer serves the media stream upgrader, echoing every message back
+// as the gateway answers media with media
+func newEchoServer(tb testing.TB, cfg *config.Config) string {
+	tb.Helper()
+	upgrader := newUpgrader(cfg, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tuneConn(conn, cfg)
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, message); err != nil {
+				return
+			}
+		}
+	}))
+	tb.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestNewUpgrader_Compression(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		url := newEchoServer(t, &config.Config{WSCompression: enabled, WSCompressionLevel: 1})
+		dialer := websocket.Dialer{EnableCompression: true}
+		conn, resp, err := dialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("Dial failed: %v", err)
+		}
+		negotiated := strings.Contains(resp.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+		if negotiated != enabled {
+			t.Errorf("WSCompression=%v: expected negotiated=%v, got %v", enabled, enabled, negotiated)
+		}
+
+		// Media still round-trips either way
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(twilioMediaMessage)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if _, message, err := conn.ReadMessage(); err != nil || string(message) != twilioMediaMessage {
+			t.Errorf("WSCompression=%v: expected the media event echoed, got %q (%v)", enabled, message, err)
+		}
+		conn.Close()
+	}
+}
+
+// BenchmarkUpgrader_Concurrency round-trips 20ms media frames over many
+// concurrent streams for each buffer size, with and without
+// permessage-deflate. Run with -cpu to vary the stream count, which is
+// 64 per CPU
+func BenchmarkUpgrader_Concurrency(b *testing.B) {
+	// A 160-byte mu-law frame, base64 encoded, as Twilio sends every 20ms
+	frame := []byte(`{"event":"media","sequenceNumber":"4","media":{"track":"inbound","chunk":"2","timestamp":"40","payload":"` +
+		strings.Repeat("/v7+fn5+fv7+/n5+", 13) + `//79"},"streamSid":"MZ18ad3ab5a668481ce02b83e7395059f0"}`)
+
+	for _, size := range []int{1024, 4096, 16384} {
+		for _, compression := range []bool{false, true} {
+			cfg := &config.Config{
+				WSReadBufferSize:   size,
+				WSWriteBufferSize:  size,
+				WSCompression:      compression,
+				WSCompressionLevel: 1,
+			}
+			b.Run(fmt.Sprintf("buffer=%d/compression=%v", size, compression), func(b *testing.B) {
+				url := newEchoServer(b, cfg)
+				dialer := websocket.Dialer{
+					ReadBufferSize:    size,
+					WriteBufferSize:   size,
+					EnableCompression: compression,
+				}
+				b.SetBytes(int64(len(frame)))
+				b.SetParallelism(64)
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					conn, _, err := dialer.Dial(url, nil)
+					if err != nil {
+						b.Error(err)
+						return
+					}
+					defer conn.Close()
+					for pb.Next() {
+						if err := conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+							b.Error(err)
+							return
+						}
+						if _, _, err := conn.ReadMessage(); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				})
+			})
+		}
+	}
+}
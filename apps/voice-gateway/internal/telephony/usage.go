@@ -0,0 +1,57 @@
+package telephony
+
+import (
+	"context"
+	"math"
+	"unicode/utf8"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/tts"
+)
+
+// synthesize queues text on the TTS client, its numbers spelled out and in
+// the firm's pronunciations, metering the characters billed and remembering
+// the text to recognize it if STT hears it back
+func (s *CallSession) synthesize(text string) (<-chan *tts.AudioChunk, error) {
+	s.noteSpoken(text)
+	if s.verbalizer != nil && i18n.IsEnglish(s.callLanguage()) {
+		text = s.verbalizer.Apply(text)
+	}
+	text = s.lexicon.Apply(text)
+	audioChan, err := s.ttsClient.Enqueue(text)
+	if err != nil {
+		return nil, err
+	}
+	characters := int64(utf8.RuneCountInString(text))
+	s.cdr.Update(func(r *cdr.Record) { r.Usage.TTSCharacters += characters })
+	return audioChan, nil
+}
+
+// meterTokens adds the tokens the orchestrator reported for a turn
+func (s *CallSession) meterTokens(tokens int32) {
+	if tokens <= 0 {
+		return
+	}
+	s.cdr.Update(func(r *cdr.Record) { r.Usage.OrchestratorTokens += int64(tokens) })
+}
+
+// meterUsage completes a finished call's usage and attributes it to the firm
+func (s *CallSession) meterUsage(record *cdr.Record) {
+	record.Usage.STTSeconds = record.STTBilledSecs
+	record.Usage.TelephonyMinutes = math.Ceil(record.DurationSecs / 60)
+
+	observability.RecordUsage(record.FirmID, record.Usage.STTSeconds, record.Usage.TTSCharacters,
+		record.Usage.OrchestratorTokens, record.Usage.TelephonyMinutes)
+	if s.services != nil && s.services.Usage != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), usageStoreTimeout)
+		defer cancel()
+		month, err := s.services.Usage.Add(ctx, record.FirmID, record.Usage)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to record usage against the firm's month")
+			return
+		}
+		s.checkBudgetAlerts(record.FirmID, record.Usage, month)
+	}
+}
@@ -0,0 +1,31 @@
+package telephony
+
+import (
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/usage"
+)
+
+func TestCallSession_MeterUsage(t *testing.T) {
+	s := newSupervisorTestSession()
+	s.services = &Services{Usage: usage.NewLedger()}
+	s.meterTokens(120)
+	s.meterTokens(0)
+	s.meterTokens(30)
+
+	record := s.cdr.Snapshot()
+	record.FirmID = "firm-1"
+	record.STTBilledSecs = 42.5
+	record.DurationSecs = 61
+	s.meterUsage(&record)
+
+	want := cdr.Usage{STTSeconds: 42.5, OrchestratorTokens: 150, TelephonyMinutes: 2}
+	if record.Usage != want {
+		t.Errorf("Expected usage %+v, got %+v", want, record.Usage)
+	}
+	total, ok := s.services.Usage.Firm("firm-1")
+	if !ok || total.Calls != 1 || total.Usage != want {
+		t.Errorf("Expected one call attributed to firm-1, got %+v", total)
+	}
+}
@@ -0,0 +1,443 @@
+package telephony
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+)
+
+const (
+	// verificationToolTimeout bounds verify_caller, which waits on the caller
+	// reading a text message and may ask several times
+	verificationToolTimeout = 3 * time.Minute
+
+	// verificationPlaybackTimeout bounds waiting for a verification prompt to play
+	verificationPlaybackTimeout = 30 * time.Second
+
+	// keypadPause submits a keypad entry of unknown length (a PIN) once the
+	// caller stops pressing keys
+	keypadPause = 3 * time.Second
+
+	// redactedCode replaces a caller's spoken PIN or code in transcripts and logs
+	redactedCode = "[verification code]"
+)
+
+// Verification methods
+const (
+	VerifyPIN     = "pin"
+	VerifySMSCode = "sms_code"
+)
+
+// Verification outcomes returned to the orchestrator
+const (
+	VerificationVerified   = "verified"
+	VerificationFailed     = "failed"      // Wrong answers, attempts left for another check
+	VerificationLocked     = "locked"      // Wrong answers reached the firm's limit for the caller
+	VerificationExpired    = "expired"     // The texted code ran out before the caller gave it
+	VerificationNoResponse = "no_response" // Silence until the timeout
+)
+
+// verificationState collects the caller's answer to a PIN or code prompt,
+// by keypad or voice
+type verificationState struct {
+	length  int // Submit keypad entry at this many digits (0: on # or a pause)
+	answers chan string
+
+	mu     sync.Mutex
+	digits strings.Builder
+	pause  *time.Timer
+}
+
+func newVerificationState(length int) *verificationState {
+	return &verificationState{length: length, answers: make(chan string, 1)}
+}
+
+// answerDigit adds a keypress; # ends the entry
+func (v *verificationState) answerDigit(digit string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.pause != nil {
+		v.pause.Stop()
+	}
+	if digit == "#" {
+		v.submitLocked()
+		return
+	}
+	if len(digit) != 1 || digit[0] < '0' || digit[0] > '9' {
+		return
+	}
+	v.digits.WriteString(digit)
+	if v.length > 0 && v.digits.Len() >= v.length {
+		v.submitLocked()
+		return
+	}
+	if v.length == 0 {
+		v.pause = time.AfterFunc(keypadPause, func() {
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			v.submitLocked()
+		})
+	}
+}
+
+// answerSpeech submits the digits the caller read out; speech without any
+// (e.g. "hold on") is ignored
+func (v *verificationState) answerSpeech(text string) {
+	digits := spokenDigits(text)
+	if digits == "" {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.digits.Reset()
+	v.digits.WriteString(digits)
+	v.submitLocked()
+}
+
+// submitLocked delivers the entry so far, if any; v.mu must be held
+func (v *verificationState) submitLocked() {
+	if v.digits.Len() == 0 {
+		return
+	}
+	select {
+	case v.answers <- v.digits.String():
+	default:
+	}
+	v.digits.Reset()
+}
+
+// stop cancels a pending keypad pause
+func (v *verificationState) stop() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.pause != nil {
+		v.pause.Stop()
+	}
+}
+
+// digitWords maps spoken digits (English and Spanish) to numerals
+var digitWords = map[string]byte{
+	"zero": '0', "oh": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"cero": '0', "uno": '1', "dos": '2', "tres": '3', "cuatro": '4',
+	"cinco": '5', "seis": '6', "siete": '7', "ocho": '8', "nueve": '9',
+}
+
+// spokenDigits returns the digits in a transcript, whether transcribed as
+// numerals ("4 7 2") or words ("four seven two"); other words are skipped
+func spokenDigits(text string) string {
+	var digits strings.Builder
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if digit, ok := digitWords[word]; ok {
+			digits.WriteByte(digit)
+			continue
+		}
+		for _, r := range word {
+			if r >= '0' && r <= '9' {
+				digits.WriteRune(r)
+			}
+		}
+	}
+	return digits.String()
+}
+
+// verifyCallerTool confirms who the caller is, by the PIN on file or a code
+// texted to the number on file, so the assistant only discusses a matter
+// with its client. The code goes only to the CRM contact's number, never to
+// caller ID (which can be spoofed) or a number the caller names
+func verifyCallerTool(ctx context.Context, s *CallSession, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Method string `json:"method"` // pin or sms_code
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	v := firm.DefaultSettings().Verification
+	if settings := s.firmSettings(); settings != nil {
+		v = settings.Verification
+	}
+
+	s.mu.RLock()
+	verifiedBy := s.verifiedBy
+	s.mu.RUnlock()
+	if verifiedBy != "" {
+		return map[string]interface{}{"verified": true, "status": VerificationVerified, "method": verifiedBy}, nil
+	}
+
+	// Wrong answers count against who the caller claims to be, across calls
+	// and instances, so calling back or spoofing caller ID earns no more guesses
+	counter := s.services.VerifyFailures
+	if counter == nil {
+		return nil, fmt.Errorf("caller verification not configured")
+	}
+	key := s.lockoutKey()
+	if key == "" {
+		return nil, fmt.Errorf("no contact or caller number to verify")
+	}
+	failures, err := counter.Failures(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if failures >= v.MaxAttempts {
+		return map[string]interface{}{"verified": false, "status": VerificationLocked, "method": p.Method}, nil
+	}
+
+	var (
+		check  func(answer string) bool
+		prompt string
+		length int
+	)
+	expires := time.Time{}
+	switch p.Method {
+	case VerifyPIN:
+		secret := s.config.VerificationPINSecret
+		if secret == "" {
+			return nil, fmt.Errorf("PIN verification not configured")
+		}
+		digest := s.pinDigest(v)
+		if digest == "" {
+			return nil, fmt.Errorf("no PIN on file for the caller")
+		}
+		check = func(answer string) bool {
+			return subtle.ConstantTimeCompare([]byte(firm.HashPIN(secret, answer)), []byte(digest)) == 1
+		}
+		prompt = s.localize(i18n.VerificationPINPrompt, v.PINPrompt)
+
+	case VerifySMSCode:
+		code, err := s.sendVerificationCode(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		expires = time.Now().Add(time.Duration(v.CodeTTLSeconds) * time.Second)
+		check = func(answer string) bool {
+			return subtle.ConstantTimeCompare([]byte(answer), []byte(code)) == 1
+		}
+		length = v.CodeLength
+		prompt = strings.ReplaceAll(s.localize(i18n.VerificationCodePrompt, v.CodePrompt), "{length}", strconv.Itoa(length))
+
+	default:
+		return nil, fmt.Errorf("method must be %q or %q", VerifyPIN, VerifySMSCode)
+	}
+
+	state := newVerificationState(length)
+	s.mu.Lock()
+	s.verification = state
+	s.mu.Unlock()
+	defer func() {
+		state.stop()
+		s.mu.Lock()
+		s.verification = nil
+		s.mu.Unlock()
+	}()
+
+	timeout := time.Duration(v.TimeoutSeconds) * time.Second
+	status, attempts := VerificationNoResponse, 0
+	for text := prompt; ; text = s.localize(i18n.VerificationRetry, v.RetryPrompt) {
+		answer, err := s.awaitVerification(ctx, state, text, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			break
+		}
+		attempts++
+		if !expires.IsZero() && time.Now().After(expires) {
+			status = VerificationExpired
+			break
+		}
+		if check(answer) {
+			status = VerificationVerified
+			if err := counter.Reset(ctx, key); err != nil {
+				s.logger.Warn().Err(err).Msg("Failed to reset verification failures")
+			}
+			break
+		}
+		if failures, err = counter.Fail(ctx, key); err != nil {
+			return nil, err
+		}
+		status = VerificationFailed
+		if failures >= v.MaxAttempts {
+			status = VerificationLocked
+			break
+		}
+	}
+
+	s.recordVerification(p.Method, status, attempts)
+	result := map[string]interface{}{
+		"verified": status == VerificationVerified,
+		"status":   status,
+		"method":   p.Method,
+		"attempts": attempts,
+	}
+	if status == VerificationFailed || status == VerificationNoResponse || status == VerificationExpired {
+		result["attempts_remaining"] = v.MaxAttempts - failures
+	}
+	return result, nil
+}
+
+// lockoutKey identifies the caller for counting wrong answers: the firm's CRM
+// contact, else the caller number; "" when there is neither
+func (s *CallSession) lockoutKey() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.contact != nil && s.contact.ID != "" {
+		return s.firmID + ":contact:" + s.contact.ID
+	}
+	if number := firm.NormalizeNumber(s.callerNumber); number != "" {
+		return s.firmID + ":number:" + number
+	}
+	return ""
+}
+
+// pinDigest returns the caller's PIN digest: by CRM contact, then caller ID
+func (s *CallSession) pinDigest(v firm.VerificationSettings) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	contactID := ""
+	if s.contact != nil {
+		contactID = s.contact.ID
+	}
+	return v.PINDigest(contactID, s.callerNumber)
+}
+
+// sendVerificationCode texts a new code to the CRM contact's number on file
+// and returns it; a caller without one cannot be verified by text
+func (s *CallSession) sendVerificationCode(ctx context.Context, v firm.VerificationSettings) (string, error) {
+	if s.services.SMS == nil {
+		return "", fmt.Errorf("sms not configured")
+	}
+
+	s.mu.RLock()
+	to := ""
+	if s.contact != nil {
+		to = s.contact.Phone
+	}
+	firmID, calledNumber := s.firmID, s.calledNumber
+	s.mu.RUnlock()
+	if to == "" {
+		return "", fmt.Errorf("no CRM contact number on file to text a code to")
+	}
+
+	code, err := randomCode(v.CodeLength)
+	if err != nil {
+		return "", err
+	}
+	settings := s.services.Firms.Get(firmID)
+	req := sms.Request{FirmID: firmID, To: to, Body: strings.ReplaceAll(v.CodeMessage, "{code}", code)}
+	if settings.SMS.FromNumber == "" {
+		req.From = calledNumber
+	}
+	result, err := s.services.SMS.Send(ctx, settings, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to text the code: %w", err)
+	}
+
+	s.cdr.Update(func(r *cdr.Record) {
+		r.SMS = append(r.SMS, cdr.SMS{SID: result.SID, To: result.To, Template: "verification_code", SentAt: result.SentAt})
+	})
+	return code, nil
+}
+
+// randomCode returns length random decimal digits
+func randomCode(length int) (string, error) {
+	var code strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		code.WriteByte(byte('0' + n.Int64()))
+	}
+	return code.String(), nil
+}
+
+// awaitVerification speaks prompt and waits for an answer; the timeout
+// starts once the prompt has played, though the caller may answer over it.
+// Silence returns ""
+func (s *CallSession) awaitVerification(ctx context.Context, state *verificationState, prompt string, timeout time.Duration) (string, error) {
+	if err := s.speak(prompt); err != nil {
+		return "", fmt.Errorf("failed to play verification prompt: %w", err)
+	}
+
+	waitDone := make(chan struct{})
+	s.goSafe("verification_wait", func() {
+		s.waitForPlayback(verificationPlaybackTimeout)
+		close(waitDone)
+	})
+	select {
+	case answer := <-state.answers:
+		return answer, nil
+	case <-waitDone:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case answer := <-state.answers:
+		return answer, nil
+	case <-timer.C:
+		return "", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// recordVerification notes the check's outcome on the session and call record
+func (s *CallSession) recordVerification(method, status string, attempts int) {
+	observability.RecordCallerVerification(method, status)
+	s.logger.Info().
+		Str("method", method).
+		Str("status", status).
+		Int("attempts", attempts).
+		Msg("Caller verification resolved")
+
+	var verifiedAt *time.Time
+	if status == VerificationVerified {
+		now := time.Now().UTC()
+		verifiedAt = &now
+		s.mu.Lock()
+		s.verifiedBy = method
+		s.mu.Unlock()
+	}
+	s.cdr.Update(func(r *cdr.Record) {
+		if r.Verification != nil {
+			attempts += r.Verification.Attempts
+		}
+		r.Verification = &cdr.Verification{Method: method, Status: status, Attempts: attempts, VerifiedAt: verifiedAt}
+	})
+}
+
+// pendingVerification returns the open verification prompt, or nil when none is asked
+func (s *CallSession) pendingVerification() *verificationState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.verification
+}
+
+// redactVerification hides caller speech while a PIN or code is being
+// collected, so it never reaches transcripts, supervisors or logs
+func (s *CallSession) redactVerification(text string) string {
+	if s.pendingVerification() != nil {
+		return redactedCode
+	}
+	return text
+}
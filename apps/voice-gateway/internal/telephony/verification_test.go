@@ -0,0 +1,245 @@
+package telephony
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/contacts"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/lockout"
+	"github.com/lexiqai/voice-gateway/internal/sms"
+)
+
+// pinSecret keys the test firm's PIN digests
+const pinSecret = "test-verification-pin-secret-0123456789"
+
+// pin1234 is the firm settings digest of the PIN 1234
+var pin1234 = firm.HashPIN(pinSecret, "1234")
+
+// runVerify runs the verify_caller tool, feeding answer to each prompt it speaks
+func runVerify(t *testing.T, s *CallSession, params string, answer func(attempt int, v *verificationState)) (map[string]interface{}, []string) {
+	t.Helper()
+	voice := &phraseTTS{}
+	s.ttsClient = voice
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := verifyCallerTool(context.Background(), s, json.RawMessage(params))
+		done <- outcome{result, err}
+	}()
+
+	asked := 0
+	for {
+		select {
+		case o := <-done:
+			if o.err != nil {
+				t.Fatalf("verifyCallerTool failed: %v", o.err)
+			}
+			return o.result.(map[string]interface{}), voice.spoken()
+		case <-time.After(5 * time.Millisecond):
+		}
+		if v := s.pendingVerification(); v != nil && len(voice.spoken()) > asked {
+			asked++
+			if answer != nil {
+				answer(asked, v)
+			}
+		}
+	}
+}
+
+func newVerificationTestSession(t *testing.T) *CallSession {
+	t.Helper()
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"verification": {"pins": {"+15551234567": "`+pin1234+`"}, "max_attempts": 2}}}}`)
+	s.config.VerificationPINSecret = pinSecret
+	s.services.VerifyFailures = lockout.NewMemoryCounter(time.Hour)
+	s.callerNumber = "+15551234567"
+	return s
+}
+
+func TestVerifyCallerTool_PINByKeypad(t *testing.T) {
+	s := newVerificationTestSession(t)
+
+	result, spoken := runVerify(t, s, `{"method": "pin"}`, func(_ int, v *verificationState) {
+		for _, digit := range []string{"1", "2", "3", "4", "#"} {
+			v.answerDigit(digit)
+		}
+	})
+
+	if result["verified"] != true || result["status"] != VerificationVerified || result["attempts"] != 1 {
+		t.Errorf("Expected the caller to be verified, got %v", result)
+	}
+	if len(spoken) != 1 || !strings.Contains(spoken[0], "PIN") {
+		t.Errorf("Expected the PIN prompt, got %v", spoken)
+	}
+	if s.pendingVerification() != nil {
+		t.Error("Expected the prompt to be closed")
+	}
+	if record := s.cdr.Snapshot(); record.Verification == nil || record.Verification.Status != VerificationVerified || record.Verification.VerifiedAt == nil {
+		t.Errorf("Expected the verification on the call record, got %+v", record.Verification)
+	}
+
+	// Asking again doesn't repeat the check
+	result, spoken = runVerify(t, s, `{"method": "sms_code"}`, nil)
+	if result["verified"] != true || result["method"] != VerifyPIN || len(spoken) != 0 {
+		t.Errorf("Expected the earlier verification to stand, got %v %v", result, spoken)
+	}
+}
+
+func TestVerifyCallerTool_LocksAfterWrongAnswers(t *testing.T) {
+	s := newVerificationTestSession(t)
+
+	result, spoken := runVerify(t, s, `{"method": "pin"}`, func(_ int, v *verificationState) {
+		v.answerSpeech("it's nine nine nine nine")
+	})
+
+	if result["verified"] != false || result["status"] != VerificationLocked || result["attempts"] != 2 {
+		t.Errorf("Expected the caller to be locked out after two wrong answers, got %v", result)
+	}
+	if len(spoken) != 2 || !strings.HasPrefix(spoken[1], "Sorry, that didn't match.") {
+		t.Errorf("Expected the retry prompt, got %v", spoken)
+	}
+
+	result, spoken = runVerify(t, s, `{"method": "pin"}`, nil)
+	if result["status"] != VerificationLocked || len(spoken) != 0 {
+		t.Errorf("Expected further checks to stay locked, got %v %v", result, spoken)
+	}
+	record := s.cdr.Snapshot()
+	if record.Verification == nil || record.Verification.Attempts != 2 {
+		t.Errorf("Expected two attempts on the call record, got %+v", record.Verification)
+	}
+
+	// Calling back, even on another instance sharing the counter, stays locked
+	again := newVerificationTestSession(t)
+	again.services.VerifyFailures = s.services.VerifyFailures
+	result, spoken = runVerify(t, again, `{"method": "pin"}`, nil)
+	if result["status"] != VerificationLocked || len(spoken) != 0 {
+		t.Errorf("Expected the lockout to outlast the call, got %v %v", result, spoken)
+	}
+}
+
+func TestVerifyCallerTool_SuccessResetsFailures(t *testing.T) {
+	s := newVerificationTestSession(t)
+
+	result, _ := runVerify(t, s, `{"method": "pin"}`, func(attempt int, v *verificationState) {
+		if attempt == 1 {
+			v.answerSpeech("nine nine nine nine")
+			return
+		}
+		v.answerSpeech("one two three four")
+	})
+	if result["status"] != VerificationVerified || result["attempts"] != 2 {
+		t.Fatalf("Expected the second answer to verify, got %v", result)
+	}
+	if failures, _ := s.services.VerifyFailures.Failures(context.Background(), "acme:number:+15551234567"); failures != 0 {
+		t.Errorf("Expected a successful check to clear the caller's failures, got %d", failures)
+	}
+}
+
+func TestVerifyCallerTool_RequiresPINOnFile(t *testing.T) {
+	s := newVerificationTestSession(t)
+	s.callerNumber = "+15550000000"
+
+	if _, err := verifyCallerTool(context.Background(), s, json.RawMessage(`{"method": "pin"}`)); err == nil {
+		t.Error("Expected an error for a caller with no PIN on file")
+	}
+	if _, err := verifyCallerTool(context.Background(), s, json.RawMessage(`{"method": "birthday"}`)); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+
+	s.callerNumber = "+15551234567"
+	s.config.VerificationPINSecret = ""
+	if _, err := verifyCallerTool(context.Background(), s, json.RawMessage(`{"method": "pin"}`)); err == nil {
+		t.Error("Expected an error when no PIN secret is configured")
+	}
+}
+
+func TestSendVerificationCode_OnlyToTheCRMContact(t *testing.T) {
+	s := newVerificationTestSession(t)
+	transport := &smsTransport{}
+	s.services.SMS = sms.NewSender(transport)
+	s.calledNumber = "+15559990000"
+	v := firm.DefaultSettings().Verification
+
+	if _, err := s.sendVerificationCode(context.Background(), v); err == nil {
+		t.Error("Expected an error for a caller without a CRM contact")
+	}
+	s.contact = &contacts.Contact{ID: "c1"}
+	if _, err := s.sendVerificationCode(context.Background(), v); err == nil {
+		t.Error("Expected an error for a CRM contact without a number")
+	}
+	if len(transport.to) != 0 {
+		t.Fatalf("Expected no code texted to caller ID, got %v", transport.to)
+	}
+
+	s.contact.Phone = "+15552220000"
+	code, err := s.sendVerificationCode(context.Background(), v)
+	if err != nil {
+		t.Fatalf("sendVerificationCode failed: %v", err)
+	}
+	if len(code) != v.CodeLength || len(transport.to) != 1 || transport.to[0] != "+15552220000" {
+		t.Errorf("Expected a %d-digit code texted to the contact, got %q to %v", v.CodeLength, code, transport.to)
+	}
+}
+
+func TestVerificationState_KeypadLength(t *testing.T) {
+	v := newVerificationState(4)
+	for _, digit := range []string{"1", "*", "2", "3", "4"} {
+		v.answerDigit(digit)
+	}
+	select {
+	case got := <-v.answers:
+		if got != "1234" {
+			t.Errorf("Expected 1234, got %q", got)
+		}
+	default:
+		t.Fatal("Expected the entry to be submitted at four digits")
+	}
+
+	v.answerDigit("#")
+	select {
+	case got := <-v.answers:
+		t.Errorf("Expected an empty entry to be ignored, got %q", got)
+	default:
+	}
+}
+
+func TestVerificationState_SpeechWithoutDigitsIgnored(t *testing.T) {
+	v := newVerificationState(6)
+	v.answerSpeech("hold on, let me find it")
+	select {
+	case got := <-v.answers:
+		t.Fatalf("Expected no answer, got %q", got)
+	default:
+	}
+	v.answerSpeech("It's 48 one 5 zero 2.")
+	if got := <-v.answers; got != "481502" {
+		t.Errorf("Expected 481502, got %q", got)
+	}
+}
+
+func TestSpokenDigits(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"4 7 2 9", "4729"},
+		{"four seven two nine", "4729"},
+		{"oh one, two three", "0123"},
+		{"cinco cuatro tres", "543"},
+		{"my PIN is 1-2-3-4", "1234"},
+		{"one moment please", "1"},
+		{"hold on", ""},
+	}
+	for _, tt := range tests {
+		if got := spokenDigits(tt.text); got != tt.want {
+			t.Errorf("spokenDigits(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
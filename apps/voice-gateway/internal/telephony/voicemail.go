@@ -0,0 +1,184 @@
+package telephony
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/events"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/recording"
+)
+
+// voicemailTranscriptGrace is how long to wait for Deepgram's last final
+// transcription after the caller stops
+const voicemailTranscriptGrace = 1500 * time.Millisecond
+
+// voicemailState tracks an in-progress voicemail
+type voicemailState struct {
+	settings   firm.VoicemailSettings
+	recorder   *recording.Recorder
+	timer      *time.Timer
+	finishOnce sync.Once
+
+	mu         sync.Mutex
+	transcript []string
+}
+
+// VoicemailMessage is the payload of the voicemail.received event
+type VoicemailMessage struct {
+	CallSid         string  `json:"call_sid"`
+	FirmID          string  `json:"firm_id"`
+	CallerNumber    string  `json:"caller_number,omitempty"`
+	RecordingURL    string  `json:"recording_url,omitempty"`
+	Transcript      string  `json:"transcript"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	ReceivedAt      string  `json:"received_at"`
+
+	// Retention is when the firm's policy requires the recording and transcript to be deleted
+	Retention *cdr.Retention `json:"retention,omitempty"`
+}
+
+// startVoicemail switches the session into voicemail mode: the orchestrator
+// loop is bypassed, the greeting is played, and caller audio is recorded
+func (s *CallSession) startVoicemail(settings *firm.Settings) {
+	maxDuration := time.Duration(settings.Voicemail.MaxDurationSeconds) * time.Second
+	vm := &voicemailState{
+		settings: settings.Voicemail,
+		recorder: recording.NewRecorder(maxDuration),
+	}
+
+	s.mu.Lock()
+	s.voicemail = vm
+	s.mu.Unlock()
+
+	s.logger.Info().
+		Str("voicemail_mode", settings.Voicemail.Mode).
+		Dur("max_duration", maxDuration).
+		Msg("Taking voicemail")
+
+	s.playGreeting(i18n.VoicemailGreeting, settings.Voicemail.GreetingAudio, settings.Voicemail.Greeting)
+
+	if maxDuration > 0 {
+		vm.timer = time.AfterFunc(maxDuration, func() {
+			s.endCall("voicemail max duration reached")
+		})
+	}
+}
+
+// inVoicemail returns the active voicemail state, or nil
+func (s *CallSession) inVoicemail() *voicemailState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.voicemail
+}
+
+// recordAudio appends caller audio to the voicemail recording
+func (vm *voicemailState) recordAudio(chunk []byte) {
+	vm.recorder.WritePCMU(chunk)
+}
+
+// addTranscript appends a final transcription to the voicemail transcript
+func (vm *voicemailState) addTranscript(text string) {
+	vm.mu.Lock()
+	vm.transcript = append(vm.transcript, text)
+	vm.mu.Unlock()
+}
+
+// transcriptText returns the full transcript so far
+func (vm *voicemailState) transcriptText() string {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	return strings.Join(vm.transcript, " ")
+}
+
+// finishVoicemail stops recording and delivers the message in the background
+// Safe to call more than once
+func (s *CallSession) finishVoicemail() {
+	vm := s.inVoicemail()
+	if vm == nil {
+		return
+	}
+
+	vm.finishOnce.Do(func() {
+		if vm.timer != nil {
+			vm.timer.Stop()
+		}
+		vm.recorder.Stop()
+
+		// Flush the last words and give Deepgram a moment to return them
+		if err := s.sttClient.Finalize(); err == nil {
+			time.Sleep(voicemailTranscriptGrace)
+		}
+
+		s.mu.RLock()
+		msg := &VoicemailMessage{
+			CallSid:         s.callSid,
+			FirmID:          s.firmID,
+			CallerNumber:    s.callerNumber,
+			Transcript:      vm.transcriptText(),
+			DurationSeconds: vm.recorder.Duration().Seconds(),
+			ReceivedAt:      time.Now().UTC().Format(time.RFC3339),
+		}
+		s.mu.RUnlock()
+		msg.Retention = retention(s.firmSettings(), firm.DataVoicemail, time.Now())
+
+		s.goSafe("voicemail_delivery", func() { s.deliverVoicemail(vm, msg) })
+	})
+}
+
+// deliverVoicemail stores the recording, publishes the event, and emails the firm
+func (s *CallSession) deliverVoicemail(vm *voicemailState, msg *VoicemailMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	firmKey := msg.FirmID
+	if firmKey == "" {
+		firmKey = "unattributed"
+	}
+
+	key := fmt.Sprintf("voicemail/%s/%s.wav", firmKey, msg.CallSid)
+	location, err := s.services.Storage.Put(ctx, key, bytes.NewReader(vm.recorder.WAV()), "audio/wav")
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to store voicemail recording")
+		if s.metrics != nil {
+			s.metrics.RecordError("voicemail_store_error", "storage")
+		}
+	}
+	msg.RecordingURL = location
+
+	event := events.NewEvent(events.TypeVoicemailReceived, msg.CallSid, msg.FirmID, msg)
+	publisher := s.services.Events
+	if vm.settings.WebhookURL != "" {
+		publisher = events.MultiPublisher{publisher, s.services.webhookPublisher(vm.settings.WebhookURL, s.config.EventsWebhookSecret)}
+	}
+	if err := publisher.Publish(ctx, event); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to publish voicemail event")
+		if s.metrics != nil {
+			s.metrics.RecordError("voicemail_publish_error", "events")
+		}
+	}
+
+	if s.services.Email != nil && len(vm.settings.NotifyEmails) > 0 {
+		caller := msg.CallerNumber
+		if caller == "" {
+			caller = "unknown caller"
+		}
+		subject := fmt.Sprintf("New voicemail from %s", caller)
+		body := fmt.Sprintf("Call: %s\nReceived: %s\nDuration: %.0f seconds\nRecording: %s\n\nTranscript:\n%s\n",
+			msg.CallSid, msg.ReceivedAt, msg.DurationSeconds, msg.RecordingURL, msg.Transcript)
+		if err := s.services.Email.Send(vm.settings.NotifyEmails, subject, body); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to send voicemail email")
+		}
+	}
+
+	s.logger.Info().
+		Str("recording_url", msg.RecordingURL).
+		Float64("duration_seconds", msg.DurationSeconds).
+		Msg("Voicemail delivered")
+}
@@ -0,0 +1,95 @@
+package telephony
+
+import (
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+)
+
+// maxWhisperQuote caps the caller's words quoted when the assistant gave no summary
+const maxWhisperQuote = 200
+
+// languageNames names the languages a whisper may mention; others are read as their tag
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"de": "German",
+	"es": "Spanish",
+	"fr": "French",
+	"hi": "Hindi",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"tl": "Tagalog",
+	"vi": "Vietnamese",
+	"zh": "Chinese",
+}
+
+// transferSummary describes the call to the staff member answering a warm
+// transfer: who is calling, why (the assistant's own summary when it gave
+// one, else the caller's latest words), and their language when not English
+func (s *CallSession) transferSummary(summary string) string {
+	s.mu.RLock()
+	caller := spokenNumber(s.callerNumber)
+	if s.contact != nil && s.contact.Name != "" {
+		caller = s.contact.Name + ", an existing client"
+	}
+	s.mu.RUnlock()
+
+	var parts []string
+	if caller != "" {
+		parts = append(parts, "Caller "+caller+".")
+	}
+	if summary = strings.TrimSpace(summary); summary != "" {
+		parts = append(parts, sentence(summary))
+	} else if said := s.lastCallerTurn(); said != "" {
+		parts = append(parts, `They said: "`+transfer.Truncate(said, maxWhisperQuote)+`"`)
+	}
+	if language := s.callLanguage(); !i18n.IsEnglish(language) {
+		name, ok := languageNames[i18n.Base(language)]
+		if !ok {
+			name = language
+		}
+		parts = append(parts, "Speaks "+name+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// whisperText is the full whisper: the firm's intro, then the call summary
+func whisperText(intro, summary string) string {
+	return strings.TrimSpace(sentence(strings.TrimSpace(intro)) + " " + summary)
+}
+
+// lastCallerTurn returns the caller's most recent final speech
+func (s *CallSession) lastCallerTurn() string {
+	turns := s.transcript.snapshot()
+	for i := len(turns) - 1; i >= 0; i-- {
+		if turns[i].Speaker == live.SpeakerCaller {
+			return turns[i].Text
+		}
+	}
+	return ""
+}
+
+// sentence ends text with a full stop unless it already has punctuation
+func sentence(text string) string {
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".!?") {
+		return text
+	}
+	return text + "."
+}
+
+// spokenNumber spaces out a phone number's digits so it is read digit by digit
+func spokenNumber(number string) string {
+	digits := make([]string, 0, len(number))
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, string(r))
+		}
+	}
+	return strings.Join(digits, " ")
+}
@@ -0,0 +1,73 @@
+package telephony
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/contacts"
+	"github.com/lexiqai/voice-gateway/internal/live"
+	"github.com/lexiqai/voice-gateway/internal/transfer"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+func TestCallSession_TransferSummary(t *testing.T) {
+	s := newLanguageTestSession(t, "")
+	s.callerNumber = "+15551234567"
+	s.transcript.add(live.SpeakerCaller, "I was rear-ended yesterday.", "")
+	s.transcript.add(live.SpeakerAgent, "I'm sorry to hear that.", "")
+	s.transcript.add(live.SpeakerCaller, "I need to talk to a lawyer.", "")
+
+	if got, want := s.transferSummary(""), `Caller 1 5 5 5 1 2 3 4 5 6 7. They said: "I need to talk to a lawyer."`; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	s.contact = &contacts.Contact{Name: "John Doe"}
+	s.language = "es-MX"
+	got := s.transferSummary("Car accident intake, wants a Spanish speaker")
+	if want := "Caller John Doe, an existing client. Car accident intake, wants a Spanish speaker. Speaks Spanish."; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCallSession_TransferCallWhispers(t *testing.T) {
+	var gotTwiml string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotTwiml = r.PostForm.Get("Twiml")
+		w.Write([]byte(`{"sid":"CA1"}`))
+	}))
+	defer server.Close()
+
+	s := newLanguageTestSession(t, `{"firms": {"acme": {"transfer": {"whisper": true}}, "cold": {}}}`)
+	s.callSid = "CA1"
+	s.callerNumber = "+15551234567"
+	s.services.Twilio = twilio.NewClient("AC1", "token", server.URL)
+	s.services.Transfers = transfer.NewWebhooks("https://gateway.example.com", "token", zerolog.Nop())
+
+	if err := s.transferCall("+15557654321", "Wants to book a consultation"); err != nil {
+		t.Fatalf("transferCall() failed: %v", err)
+	}
+	if !strings.Contains(gotTwiml, `<Number url="https://gateway.example.com/twilio/transfer/whisper?text=`) {
+		t.Fatalf("Expected a whisper dial, got %s", gotTwiml)
+	}
+	whisper := s.cdr.Snapshot().TransferWhisper
+	want := "Incoming transfer from the virtual receptionist. Caller 1 5 5 5 1 2 3 4 5 6 7. Wants to book a consultation."
+	if whisper != want {
+		t.Errorf("Expected whisper %q, got %q", want, whisper)
+	}
+	if !strings.Contains(gotTwiml, url.Values{"text": {want}}.Encode()) {
+		t.Errorf("Whisper URL does not carry the summary: %s", gotTwiml)
+	}
+
+	s.firmID = "cold"
+	if err := s.transferCall("+15557654321", "ignored"); err != nil {
+		t.Fatalf("transferCall() failed: %v", err)
+	}
+	if gotTwiml != twilio.DialTwiML("+15557654321") {
+		t.Errorf("Expected a cold transfer, got %s", gotTwiml)
+	}
+}
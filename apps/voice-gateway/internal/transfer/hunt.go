@@ -0,0 +1,161 @@
+package transfer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+)
+
+// nextPath serves a hunt group's next step once a dial ends
+const nextPath = "/twilio/transfer/next"
+
+// streamParamPrefix marks the stream parameters carried in a step URL
+const streamParamPrefix = "s."
+
+// Target is one number a hunt group rings
+type Target struct {
+	Number  string
+	Timeout time.Duration
+}
+
+// Plan is a hunt group transfer: the targets ring in order (or all at once),
+// and if nobody answers the caller is reconnected to the gateway
+type Plan struct {
+	Targets      []Target
+	Simultaneous bool
+
+	// Whisper is read to whoever answers; empty makes the transfer cold
+	Whisper string
+
+	// Fallback is what the reconnected caller gets: twilio.FallbackAI (the
+	// default) or twilio.FallbackVoicemail
+	Fallback string
+
+	// Stream holds the stream parameters the caller reconnects with (firm,
+	// caller and dialed numbers...), so the new session picks up the same call
+	Stream map[string]string
+}
+
+// Hunt returns TwiML that starts the plan: all targets at once, or the first in turn
+func (h *Webhooks) Hunt(plan Plan) string {
+	return h.dial(plan, 0)
+}
+
+// dial returns TwiML ringing the plan's targets from step on; the dial's
+// action continues the plan when nobody answers
+func (h *Webhooks) dial(plan Plan, step int) string {
+	d := twilio.Dial{}
+	if plan.Whisper != "" {
+		d.WhisperURL = h.WhisperURL(plan.Whisper)
+	}
+	if plan.Simultaneous {
+		for _, target := range plan.Targets {
+			d.Numbers = append(d.Numbers, target.Number)
+			d.Timeout = max(d.Timeout, target.Timeout)
+		}
+		d.Action = h.stepURL(plan, len(plan.Targets))
+		return d.TwiML()
+	}
+	target := plan.Targets[step]
+	d.Numbers = []string{target.Number}
+	d.Timeout = target.Timeout
+	d.Action = h.stepURL(plan, step+1)
+	return d.TwiML()
+}
+
+// next continues a hunt group once a dial ends: done if someone answered,
+// otherwise the next target, then the fallback
+func (h *Webhooks) next(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	plan, step, err := decodeStep(r.URL.Query())
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("Malformed transfer step, hanging up")
+		writeTwiML(w, twilio.HangupTwiML)
+		return
+	}
+
+	callSid := r.PostForm.Get("CallSid")
+	status := r.PostForm.Get("DialCallStatus")
+	logger := h.logger.With().Str("call_sid", callSid).Str("dial_status", status).Logger()
+	switch {
+	case status == "completed" || status == "answered":
+		// A target took the call and it has ended
+		logger.Info().Msg("Transfer answered")
+		writeTwiML(w, twilio.HangupTwiML)
+	case step < len(plan.Targets):
+		logger.Info().Str("target", plan.Targets[step].Number).Int("step", step).Msg("Transfer target did not answer, trying the next")
+		writeTwiML(w, h.dial(plan, step))
+	default:
+		params := make(map[string]string, len(plan.Stream)+1)
+		for name, value := range plan.Stream {
+			params[name] = value
+		}
+		params[twilio.ParamTransferFallback] = plan.Fallback
+		logger.Info().Str("fallback", plan.Fallback).Msg("Nobody answered the transfer, reconnecting the caller")
+		writeTwiML(w, twilio.StreamTwiML(twilio.StreamURL(h.publicURL), params))
+	}
+}
+
+// stepURL returns the dial action continuing plan at step
+func (h *Webhooks) stepURL(plan Plan, step int) string {
+	q := url.Values{"step": {strconv.Itoa(step)}}
+	for _, target := range plan.Targets {
+		q.Add("n", target.Number)
+		q.Add("t", strconv.Itoa(int(target.Timeout.Seconds())))
+	}
+	if plan.Simultaneous {
+		q.Set("ring", "simultaneous")
+	}
+	if plan.Whisper != "" {
+		q.Set("w", Truncate(plan.Whisper, MaxWhisperLength))
+	}
+	q.Set("fallback", plan.Fallback)
+	for name, value := range plan.Stream {
+		if value != "" {
+			q.Set(streamParamPrefix+name, value)
+		}
+	}
+	return h.publicURL + nextPath + "?" + q.Encode()
+}
+
+// decodeStep reads the plan and step back from a step URL's query
+func decodeStep(q url.Values) (Plan, int, error) {
+	step, err := strconv.Atoi(q.Get("step"))
+	if err != nil || step < 0 {
+		return Plan{}, 0, fmt.Errorf("invalid step %q", q.Get("step"))
+	}
+	numbers, timeouts := q["n"], q["t"]
+	if len(numbers) == 0 || len(numbers) != len(timeouts) {
+		return Plan{}, 0, fmt.Errorf("%d targets with %d timeouts", len(numbers), len(timeouts))
+	}
+
+	plan := Plan{
+		Simultaneous: q.Get("ring") == "simultaneous",
+		Whisper:      q.Get("w"),
+		Fallback:     q.Get("fallback"),
+		Stream:       make(map[string]string),
+	}
+	for i, number := range numbers {
+		seconds, err := strconv.Atoi(timeouts[i])
+		if err != nil {
+			return Plan{}, 0, fmt.Errorf("invalid timeout %q", timeouts[i])
+		}
+		plan.Targets = append(plan.Targets, Target{Number: number, Timeout: time.Duration(seconds) * time.Second})
+	}
+	for name := range q {
+		if param, ok := strings.CutPrefix(name, streamParamPrefix); ok {
+			plan.Stream[param] = q.Get(name)
+		}
+	}
+	if plan.Fallback != twilio.FallbackVoicemail {
+		plan.Fallback = twilio.FallbackAI
+	}
+	return plan, step, nil
+}
@@ -0,0 +1,128 @@
+package transfer
+
+import (
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+var actionPattern = regexp.MustCompile(`action="([^"]+)"`)
+
+// dialAction returns the action URL of a <Dial>
+func dialAction(t *testing.T, twiml string) string {
+	t.Helper()
+	m := actionPattern.FindStringSubmatch(twiml)
+	if m == nil {
+		t.Fatalf("No dial action in %s", twiml)
+	}
+	return html.UnescapeString(m[1])
+}
+
+// postStep sends Twilio's signed dial action callback
+func postStep(t *testing.T, mux *http.ServeMux, action, dialStatus string) string {
+	t.Helper()
+	parsed, err := url.Parse(action)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	form := url.Values{"CallSid": {"CA1"}, "DialCallStatus": {dialStatus}}
+	req := httptest.NewRequest(http.MethodPost, parsed.RequestURI(), strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(twilio.SignatureHeader, twilio.Sign("token", action, form))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d %s", rec.Code, rec.Body.String())
+	}
+	return rec.Body.String()
+}
+
+func newTestWebhooks() (*Webhooks, *http.ServeMux) {
+	transfers := NewWebhooks("https://gateway.example.com", "token", zerolog.Nop())
+	mux := http.NewServeMux()
+	transfers.Register(mux)
+	return transfers, mux
+}
+
+func TestWebhooks_HuntSequential(t *testing.T) {
+	transfers, mux := newTestWebhooks()
+	plan := Plan{
+		Targets: []Target{
+			{Number: "+15550000001", Timeout: 15 * time.Second},
+			{Number: "+15550000002", Timeout: 25 * time.Second},
+		},
+		Whisper:  "Caller Jane Doe.",
+		Fallback: twilio.FallbackVoicemail,
+		Stream:   map[string]string{twilio.ParamFirmID: "acme", twilio.ParamFrom: "+15551234567"},
+	}
+
+	twiml := transfers.Hunt(plan)
+	if !strings.Contains(twiml, `<Dial timeout="15"`) || !strings.Contains(twiml, ">+15550000001</Number>") || strings.Contains(twiml, "+15550000002<") {
+		t.Fatalf("Expected only the first target to ring, got %s", twiml)
+	}
+	if !strings.Contains(twiml, `<Number url="https://gateway.example.com/twilio/transfer/whisper?text=Caller+Jane+Doe.">`) {
+		t.Errorf("Expected the whisper on the target, got %s", twiml)
+	}
+
+	twiml = postStep(t, mux, dialAction(t, twiml), "no-answer")
+	if !strings.Contains(twiml, `<Dial timeout="25"`) || !strings.Contains(twiml, ">+15550000002</Number>") {
+		t.Fatalf("Expected the second target next, got %s", twiml)
+	}
+
+	twiml = postStep(t, mux, dialAction(t, twiml), "busy")
+	for _, want := range []string{
+		`<Stream url="wss://gateway.example.com/streams/twilio">`,
+		`<Parameter name="firm_id" value="acme"/>`,
+		`<Parameter name="from" value="+15551234567"/>`,
+		`<Parameter name="transfer_fallback" value="voicemail"/>`,
+	} {
+		if !strings.Contains(twiml, want) {
+			t.Errorf("Expected the fallback stream to contain %s, got %s", want, twiml)
+		}
+	}
+}
+
+func TestWebhooks_HuntSimultaneous(t *testing.T) {
+	transfers, mux := newTestWebhooks()
+	twiml := transfers.Hunt(Plan{
+		Targets: []Target{
+			{Number: "+15550000001", Timeout: 15 * time.Second},
+			{Number: "+15550000002", Timeout: 25 * time.Second},
+		},
+		Simultaneous: true,
+	})
+	if !strings.Contains(twiml, `<Dial timeout="25"`) || !strings.Contains(twiml, "<Number>+15550000001</Number><Number>+15550000002</Number>") {
+		t.Fatalf("Expected both targets to ring for the longest timeout, got %s", twiml)
+	}
+	action := dialAction(t, twiml)
+
+	if got := postStep(t, mux, action, "completed"); got != twilio.HangupTwiML {
+		t.Errorf("Expected hangup after an answered transfer, got %s", got)
+	}
+	if got := postStep(t, mux, action, "no-answer"); !strings.Contains(got, `<Parameter name="transfer_fallback" value="ai"/>`) {
+		t.Errorf("Expected the default AI fallback, got %s", got)
+	}
+}
+
+func TestWebhooks_HuntRequiresSignature(t *testing.T) {
+	transfers, mux := newTestWebhooks()
+	action := dialAction(t, transfers.Hunt(Plan{Targets: []Target{{Number: "+15550000001", Timeout: 15 * time.Second}}}))
+	parsed, _ := url.Parse(action)
+
+	req := httptest.NewRequest(http.MethodPost, parsed.RequestURI(), strings.NewReader("DialCallStatus=no-answer"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(twilio.SignatureHeader, "forged")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a bad signature, got %d", rec.Code)
+	}
+}
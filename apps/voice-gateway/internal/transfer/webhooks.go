@@ -0,0 +1,51 @@
+package transfer
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+// Webhooks serves the TwiML Twilio fetches while transferring a call: the
+// whisper read to the staff member who answers, and the next step of a hunt
+// group. Each request's state rides in its URL, which Twilio signs when it
+// fetches it, so any instance can serve it
+type Webhooks struct {
+	publicURL string
+	authToken string
+	logger    zerolog.Logger
+}
+
+// NewWebhooks creates the transfer handlers; publicURL is the gateway's
+// public base URL and authToken checks Twilio's signature
+func NewWebhooks(publicURL, authToken string, logger zerolog.Logger) *Webhooks {
+	return &Webhooks{
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		authToken: authToken,
+		logger:    logger.With().Str("component", "transfer").Logger(),
+	}
+}
+
+// Register mounts the handlers on mux under /twilio/transfer/
+func (h *Webhooks) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+whisperPath, h.whisper)
+	mux.HandleFunc("POST "+nextPath, h.next)
+}
+
+// verify rejects requests Twilio did not sign
+func (h *Webhooks) verify(w http.ResponseWriter, r *http.Request) bool {
+	if !twilio.ValidRequest(r, h.authToken, h.publicURL) {
+		h.logger.Warn().Str("path", r.URL.Path).Msg("Rejecting transfer callback with an invalid Twilio signature")
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// writeTwiML sends a TwiML response
+func writeTwiML(w http.ResponseWriter, twiml string) {
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write([]byte(twiml))
+}
@@ -0,0 +1,48 @@
+package transfer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+)
+
+// MaxWhisperLength caps the summary read to the staff member; the text rides
+// in the whisper URL, which Twilio limits in length
+const MaxWhisperLength = 600
+
+// whisperPath serves the whisper TwiML
+const whisperPath = "/twilio/transfer/whisper"
+
+// WhisperURL returns the URL of TwiML reading summary, truncated to
+// MaxWhisperLength, to whoever answers a warm transfer
+func (h *Webhooks) WhisperURL(summary string) string {
+	return h.publicURL + whisperPath + "?" + url.Values{"text": {Truncate(summary, MaxWhisperLength)}}.Encode()
+}
+
+// whisper returns TwiML reading the summary to the answering staff member
+func (h *Webhooks) whisper(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	writeTwiML(w, twilio.SayTwiML(r.URL.Query().Get("text")))
+}
+
+// Truncate shortens text to at most limit bytes, cutting at a word boundary
+func Truncate(text string, limit int) string {
+	text = strings.TrimSpace(text)
+	if len(text) <= limit {
+		return text
+	}
+	cut := strings.LastIndexByte(text[:limit+1], ' ')
+	if cut <= 0 {
+		cut = limit
+		// Do not split a multi-byte character
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+	}
+	return strings.TrimSpace(text[:cut])
+}
@@ -0,0 +1,66 @@
+package transfer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+	"github.com/rs/zerolog"
+)
+
+func TestWhisper_ServesSignedSummary(t *testing.T) {
+	transfers := NewWebhooks("https://gateway.example.com/", "token", zerolog.Nop())
+	mux := http.NewServeMux()
+	transfers.Register(mux)
+
+	whisperURL := transfers.WhisperURL("Caller Jane Doe. Car accident intake & wants a Spanish speaker.")
+	if !strings.HasPrefix(whisperURL, "https://gateway.example.com/twilio/transfer/whisper?text=") {
+		t.Fatalf("Unexpected whisper URL %s", whisperURL)
+	}
+	parsed, err := url.Parse(whisperURL)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	form := url.Values{"CallSid": {"CA2"}}
+	post := func(signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, parsed.RequestURI(), strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set(twilio.SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("forged"); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a bad signature, got %d", rec.Code)
+	}
+
+	rec := post(twilio.Sign("token", whisperURL, form))
+	want := "<Response><Say>Caller Jane Doe. Car accident intake &amp; wants a Spanish speaker.</Say></Response>"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("Expected %s, got %d %s", want, rec.Code, rec.Body.String())
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text  string
+		limit int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"cut at a word boundary", 12, "cut at a"},
+		{"unbrokenword", 5, "unbro"},
+		{"añoñoño", 4, "año"},
+		{"añoñoño", 5, "año"}, // Never splits a character
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.text, tt.limit); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package translate relays a call between the caller's language and the
+// firm's: caller speech is translated before it reaches the orchestrator, and
+// the assistant's replies are translated back before they are synthesized
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Translator translates text between languages, given as BCP 47 tags
+type Translator interface {
+	Translate(ctx context.Context, text, from, to string) (string, error)
+}
+
+// HTTPTranslator calls a translation API with POST <url> and a JSON body of
+// {"text", "source", "target"}. The API must return JSON containing the
+// translated "text"
+type HTTPTranslator struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTPTranslator creates a translator for the given endpoint
+func NewHTTPTranslator(endpoint, apiKey string, timeout time.Duration) *HTTPTranslator {
+	return &HTTPTranslator{
+		url:        endpoint,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type request struct {
+	Text   string `json:"text"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type response struct {
+	Text string `json:"text"`
+}
+
+// Translate implements Translator
+func (h *HTTPTranslator) Translate(ctx context.Context, text, from, to string) (string, error) {
+	body, err := json.Marshal(request{Text: text, Source: from, Target: to})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode translation request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("translation failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("translation returned status %d", resp.StatusCode)
+	}
+
+	var translated response
+	if err := json.NewDecoder(resp.Body).Decode(&translated); err != nil {
+		return "", fmt.Errorf("failed to decode translation: %w", err)
+	}
+	if translated.Text == "" {
+		return "", fmt.Errorf("translation returned no text")
+	}
+	return translated.Text, nil
+}
@@ -0,0 +1,42 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPTranslator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.Text != "Necesito un abogado" || req.Source != "es" || req.Target != "en" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		json.NewEncoder(w).Encode(response{Text: "I need a lawyer"})
+	}))
+	defer server.Close()
+
+	translator := NewHTTPTranslator(server.URL, "secret", time.Second)
+	got, err := translator.Translate(context.Background(), "Necesito un abogado", "es", "en")
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if got != "I need a lawyer" {
+		t.Errorf("Expected the translation, got %q", got)
+	}
+
+	if _, err := NewHTTPTranslator(server.URL, "wrong", time.Second).Translate(context.Background(), "Hola", "es", "en"); err == nil {
+		t.Error("Expected an error for a rejected request")
+	}
+}
@@ -5,29 +5,51 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/chaos"
 	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/observability"
+	"github.com/lexiqai/voice-gateway/internal/resilience"
 )
 
+// Synthesis requests from every call share one bulkhead
+var (
+	bulkheadOnce sync.Once
+	bulkhead     *resilience.Bulkhead
+)
+
+// sharedBulkhead returns the bulkhead bounding in-flight Cartesia requests
+func sharedBulkhead(cfg *config.Config) *resilience.Bulkhead {
+	bulkheadOnce.Do(func() {
+		bulkhead = resilience.NewBulkhead("cartesia", cfg.CartesiaMaxConcurrent, cfg.CartesiaBulkheadQueue,
+			time.Duration(cfg.BulkheadQueueTimeoutMs)*time.Millisecond)
+		observability.RegisterBulkhead(bulkhead)
+	})
+	return bulkhead
+}
+
 // CartesiaClient implements TTSClient using Cartesia's TTS API
+// Up to CARTESIA_CALL_CONCURRENCY texts are synthesized at once; each waits
+// for the one enqueued before it to be delivered before sending its audio
 type CartesiaClient struct {
+	*queue
 	config     *config.Config
 	apiKey     string
 	apiURL     string
-	voiceID    string
 	httpClient *http.Client
-	mu         sync.RWMutex
-	isActive   bool
+
+	circuitBreaker *resilience.CircuitBreaker
 }
 
 // CartesiaRequest represents the request payload for Cartesia TTS API
 type CartesiaRequest struct {
 	Text            string  `json:"text"`
 	VoiceID         string  `json:"voice_id"`
+	Language        string  `json:"language,omitempty"`
 	ModelID         string  `json:"model_id,omitempty"`
 	OutputFormat    string  `json:"output_format,omitempty"`
 	SampleRate      int     `json:"sample_rate,omitempty"`
@@ -38,52 +60,66 @@ type CartesiaRequest struct {
 
 // NewCartesiaClient creates a new Cartesia TTS client
 func NewCartesiaClient(cfg *config.Config) *CartesiaClient {
-	return &CartesiaClient{
+	c := &CartesiaClient{
 		config:     cfg,
 		apiKey:     cfg.CartesiaAPIKey,
-		apiURL:     "https://api.cartesia.ai/v1/tts", // Cartesia TTS API endpoint
-		voiceID:    cfg.CartesiaVoiceID,              // Voice ID from config
-		httpClient: &http.Client{},
-		isActive:   false,
+		apiURL:     cartesiaURL(cfg),
+		httpClient: &http.Client{Transport: chaos.Transport(nil)},
+
+		circuitBreaker: resilience.Breakers.Get("cartesia"), // Shared by every call
 	}
+	c.queue = newQueue("Cartesia", cfg.CartesiaCallConcurrency, cfg.CartesiaVoiceID, c.synthesize)
+	return c
 }
 
-// Synthesize converts text to audio and streams it
-func (c *CartesiaClient) Synthesize(text string) (<-chan *AudioChunk, error) {
-	c.mu.Lock()
-	if c.isActive {
-		c.mu.Unlock()
-		return nil, fmt.Errorf("cartesia client is already synthesizing")
+// cartesiaURL returns the configured TTS endpoint, which may be regional
+func cartesiaURL(cfg *config.Config) string {
+	if cfg.CartesiaAPIURL == "" {
+		return "https://api.cartesia.ai/v1/tts" // Cartesia TTS API endpoint
 	}
-	c.isActive = true
-	c.mu.Unlock()
+	return cfg.CartesiaAPIURL
+}
+
+// NewCartesiaClientWithKey creates a client billed to a firm's own Cartesia
+// account, with a circuit breaker of its own
+func NewCartesiaClientWithKey(cfg *config.Config, apiKey string) *CartesiaClient {
+	client := NewCartesiaClient(cfg)
+	client.apiKey = apiKey
+	client.circuitBreaker = resilience.Breakers.Get(resilience.KeyedName("cartesia", apiKey))
+	return client
+}
+
+// synthesize requests one text's audio from Cartesia, holding a shared
+// bulkhead slot while it does
+func (c *CartesiaClient) synthesize(s *synthesis) (*AudioChunk, error) {
+	// Hold a bulkhead slot until the response body is read
+	release, err := sharedBulkhead(c.config).Acquire(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	// Create request payload
 	reqBody := CartesiaRequest{
-		Text:            text,
-		VoiceID:         c.voiceID,
+		Text:            s.text,
+		VoiceID:         s.voiceID,
+		Language:        s.language,
 		ModelID:         c.config.CartesiaModelID, // Model ID from config (default: sonic)
 		OutputFormat:    "pcm",                    // PCM format for easier conversion
 		SampleRate:      24000,                    // Cartesia typically outputs at 24kHz
-		Speed:           1.0,
+		Speed:           s.speed,
 		Stability:       0.5,
 		SimilarityBoost: 0.75,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		c.mu.Lock()
-		c.isActive = false
-		c.mu.Unlock()
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequest("POST", c.apiURL, bytes.NewBuffer(jsonData))
+	// Create HTTP request; cancelling the synthesis aborts it
+	req, err := http.NewRequestWithContext(s.ctx, "POST", c.apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		c.mu.Lock()
-		c.isActive = false
-		c.mu.Unlock()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -92,93 +128,58 @@ func (c *CartesiaClient) Synthesize(text string) (<-chan *AudioChunk, error) {
 	req.Header.Set("x-api-key", c.apiKey)
 
 	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		c.mu.Lock()
-		c.isActive = false
-		c.mu.Unlock()
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		c.mu.Lock()
-		c.isActive = false
-		c.mu.Unlock()
-		return nil, fmt.Errorf("cartesia API returned status %d", resp.StatusCode)
-	}
-
-	// Create channel for audio chunks
-	audioChan := make(chan *AudioChunk, 10)
-
-	// Read audio data in a goroutine
-	go func() {
-		defer func() {
-			resp.Body.Close()
-			close(audioChan)
-			c.mu.Lock()
-			c.isActive = false
-			c.mu.Unlock()
-		}()
-
-		// Read audio data (PCM format from Cartesia)
-		audioData, err := io.ReadAll(resp.Body)
+	// Only outages count against the breaker; a rejected or cancelled request is not one
+	var resp *http.Response
+	var rejected error
+	err = c.circuitBreaker.Call(func() error {
+		var err error
+		resp, err = c.httpClient.Do(req)
 		if err != nil {
-			log.Printf("Error reading Cartesia audio response: %v", err)
-			return
-		}
-
-		if len(audioData) == 0 {
-			log.Printf("Warning: Cartesia returned empty audio data")
-			return
+			if s.ctx.Err() != nil {
+				rejected = err
+				return nil
+			}
+			return fmt.Errorf("failed to make request: %w", err)
 		}
-
-		// Convert PCM to PCMU (G.711 μ-law) for Twilio
-		// Cartesia outputs PCM at 24kHz, we need PCMU at 8kHz
-		pcmuData, err := audio.ConvertPCMToPCMU(audioData, 24000, 8000)
-		if err != nil {
-			log.Printf("Error converting audio format: %v", err)
-			return
-		}
-
-		// Send audio chunk
-		select {
-		case audioChan <- &AudioChunk{
-			Data:       pcmuData,
-			SampleRate: 8000,
-			Channels:   1,
-		}:
-			log.Printf("Sent %d bytes of TTS audio (converted from %d bytes PCM)", len(pcmuData), len(audioData))
-		default:
-			log.Printf("Warning: audio channel full, dropping audio chunk")
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			statusErr := fmt.Errorf("cartesia API returned status %d", resp.StatusCode)
+			if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+				return statusErr
+			}
+			rejected = statusErr
 		}
-	}()
-
-	return audioChan, nil
-}
-
-// Stop stops any ongoing synthesis
-func (c *CartesiaClient) Stop() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if !c.isActive {
 		return nil
+	})
+	observability.UpdateCircuitBreakerState(c.circuitBreaker.Name(), int(c.circuitBreaker.GetState()))
+	if err != nil {
+		observability.IncrementCircuitBreakerFailures(c.circuitBreaker.Name())
+	} else {
+		err = rejected
 	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	c.isActive = false
-	log.Printf("Cartesia TTS synthesis stopped")
-	return nil
-}
-
-// Close closes the client and cleans up resources
-func (c *CartesiaClient) Close() error {
-	return c.Stop()
-}
+	// Read audio data (PCM format from Cartesia)
+	audioData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio response: %w", err)
+	}
+	if len(audioData) == 0 {
+		return nil, fmt.Errorf("cartesia returned empty audio data")
+	}
 
-// IsActive returns whether the client is currently synthesizing
-func (c *CartesiaClient) IsActive() bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.isActive
+	// Convert PCM to PCMU (G.711 μ-law) for Twilio
+	// Cartesia outputs PCM at 24kHz, we need PCMU at 8kHz
+	pcmuData, err := audio.ConvertPCMToPCMU(audioData, 24000, 8000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert audio format: %w", err)
+	}
+	return &AudioChunk{
+		Data:       pcmuData,
+		SampleRate: 8000,
+		Channels:   1,
+	}, nil
 }
@@ -0,0 +1,124 @@
+package tts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// newTestClient returns a client against a fake Cartesia that answers each
+// text after the delay delays gives it, or once the request is cancelled
+// when the delay is negative
+func newTestClient(t *testing.T, delays map[string]time.Duration) (*CartesiaClient, *atomic.Int32) {
+	t.Helper()
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+				break
+			}
+		}
+
+		var req CartesiaRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if delay := delays[req.Text]; delay < 0 {
+			<-r.Context().Done()
+			return
+		} else {
+			time.Sleep(delay)
+		}
+		_, _ = w.Write(make([]byte, 4800)) // 100ms of 24kHz PCM
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCartesiaClient(&config.Config{CartesiaCallConcurrency: 2})
+	client.apiURL = server.URL
+	return client, &maxInFlight
+}
+
+func TestCartesiaClient_DeliversInOrder(t *testing.T) {
+	client, maxInFlight := newTestClient(t, map[string]time.Duration{
+		"First sentence.":  150 * time.Millisecond,
+		"Second sentence.": 50 * time.Millisecond,
+	})
+	defer client.Close()
+
+	first, err := client.Enqueue("First sentence.")
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	second, err := client.Enqueue("Second sentence.")
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// The second sentence is ready first, but waits for the first's audio
+	select {
+	case <-second:
+		t.Fatal("Expected the second sentence to wait for the first")
+	case <-time.After(300 * time.Millisecond):
+	}
+	if chunk := <-first; chunk == nil || len(chunk.Data) == 0 {
+		t.Error("Expected the first sentence's audio")
+	}
+	if chunk := <-second; chunk == nil || len(chunk.Data) == 0 {
+		t.Error("Expected the second sentence's audio")
+	}
+
+	if maxInFlight.Load() != 2 {
+		t.Errorf("Expected both sentences to be synthesized at once, got %d", maxInFlight.Load())
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Errorf("Flush failed: %v", err)
+	}
+	if client.IsActive() {
+		t.Error("Expected the client to be idle once flushed")
+	}
+}
+
+func TestCartesiaClient_CancelAll(t *testing.T) {
+	client, _ := newTestClient(t, map[string]time.Duration{"Hanging sentence.": -1})
+	defer client.Close()
+
+	ch, _ := client.Enqueue("Hanging sentence.")
+	time.Sleep(50 * time.Millisecond)
+	if !client.IsActive() {
+		t.Error("Expected the client to be active while synthesizing")
+	}
+	client.CancelAll()
+
+	select {
+	case chunk, ok := <-ch:
+		if ok {
+			t.Errorf("Expected no audio from a cancelled synthesis, got %d bytes", len(chunk.Data))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the cancelled synthesis to end")
+	}
+
+	// Text enqueued after CancelAll is synthesized as usual
+	next, err := client.Enqueue("Next sentence.")
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if chunk := <-next; chunk == nil || len(chunk.Data) == 0 {
+		t.Error("Expected audio after CancelAll")
+	}
+}
+
+func TestCartesiaClient_Close(t *testing.T) {
+	client, _ := newTestClient(t, nil)
+	_ = client.Close()
+	if _, err := client.Enqueue("Hello."); err == nil {
+		t.Error("Expected an error enqueuing on a closed client")
+	}
+}
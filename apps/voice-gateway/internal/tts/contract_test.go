@@ -0,0 +1,41 @@
+package tts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/contract"
+)
+
+// cartesiaIgnored are fields of Cartesia's voice objects the gateway does not read
+var cartesiaIgnored = []string{"user_id", "is_public", "created_at"}
+
+// TestCartesiaContract replays recorded voice responses through Clone
+func TestCartesiaContract(t *testing.T) {
+	for _, fixture := range contract.Load(t, "testdata/cartesia") {
+		t.Run(fixture.Name, func(t *testing.T) {
+			voices := []*Voice{}
+			for _, message := range fixture.Messages {
+				contract.RequireModelled(t, message, Voice{}, cartesiaIgnored...)
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write(message)
+				}))
+				voice, err := NewCartesiaVoices("test-key", server.URL).Clone(context.Background(), "", CloneRequest{
+					Name:     "Front desk",
+					Filename: "sample.wav",
+					Sample:   strings.NewReader("RIFF"),
+				})
+				server.Close()
+				if err != nil {
+					t.Fatalf("Clone failed: %v", err)
+				}
+				voices = append(voices, voice)
+			}
+			contract.Golden(t, fixture.Golden, voices)
+		})
+	}
+}
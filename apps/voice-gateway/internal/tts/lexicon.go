@@ -0,0 +1,123 @@
+package tts
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LexiconEntry says how TTS should pronounce a term: respelled (e.g. "voir
+// dire" as "vwahr deer"), or as IPA in an SSML <phoneme> tag when IPA is set
+type LexiconEntry struct {
+	Term string
+	Say  string
+	IPA  string
+}
+
+// Lexicon rewrites terms the TTS voice mispronounces (attorney surnames,
+// Latin legal terms) before text is synthesized
+type Lexicon struct {
+	pattern *regexp.Regexp
+	entries map[string]LexiconEntry // By normalized term
+}
+
+// NewLexicon compiles entries into a lexicon. Terms match whole words,
+// ignoring case and spacing; without entries the lexicon is nil, which
+// leaves text unchanged
+func NewLexicon(entries []LexiconEntry) (*Lexicon, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	l := &Lexicon{entries: make(map[string]LexiconEntry, len(entries))}
+	for _, entry := range entries {
+		term := normalizeTerm(entry.Term)
+		if term == "" {
+			return nil, fmt.Errorf("lexicon entry has an empty term")
+		}
+		if entry.Say == "" && entry.IPA == "" {
+			return nil, fmt.Errorf("lexicon entry %q needs say or ipa", entry.Term)
+		}
+		l.entries[term] = entry
+	}
+
+	// Longer terms first, so "Van der Berg" wins over "Berg"
+	terms := make([]string, 0, len(l.entries))
+	for term := range l.entries {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+	for i, term := range terms {
+		terms[i] = strings.ReplaceAll(regexp.QuoteMeta(term), " ", `\s+`)
+	}
+	pattern, err := regexp.Compile(`(?i)(?:` + strings.Join(terms, "|") + `)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile lexicon: %w", err)
+	}
+	l.pattern = pattern
+	return l, nil
+}
+
+// Apply returns text with the lexicon's terms rewritten
+func (l *Lexicon) Apply(text string) string {
+	if l == nil {
+		return text
+	}
+	var out strings.Builder
+	start, from := 0, 0
+	for from < len(text) {
+		loc := l.pattern.FindStringIndex(text[from:])
+		if loc == nil {
+			break
+		}
+		begin, end := from+loc[0], from+loc[1]
+		if !wordBoundary(text, begin, end) {
+			// Part of a longer word; look again from the next character
+			_, size := utf8.DecodeRuneInString(text[begin:])
+			from = begin + size
+			continue
+		}
+		match := text[begin:end]
+		out.WriteString(text[start:begin])
+		entry := l.entries[normalizeTerm(match)]
+		if entry.IPA != "" {
+			fmt.Fprintf(&out, `<phoneme alphabet="ipa" ph="%s">%s</phoneme>`, html.EscapeString(entry.IPA), match)
+		} else {
+			out.WriteString(entry.Say)
+		}
+		start, from = end, end
+	}
+	if start == 0 {
+		return text
+	}
+	out.WriteString(text[start:])
+	return out.String()
+}
+
+// normalizeTerm lowercases a term and collapses its spacing
+func normalizeTerm(term string) string {
+	return strings.ToLower(strings.Join(strings.Fields(term), " "))
+}
+
+// wordBoundary reports whether text[begin:end] is not part of a longer word
+func wordBoundary(text string, begin, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:begin]); begin > 0 && isWordRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,45 @@
+package tts
+
+import "testing"
+
+func TestLexicon_Apply(t *testing.T) {
+	lexicon, err := NewLexicon([]LexiconEntry{
+		{Term: "voir dire", Say: "vwahr deer"},
+		{Term: "Nguyen", IPA: "wɪn"},
+		{Term: "Van der Berg", Say: "van der burg"},
+		{Term: "Berg", Say: "burg"},
+		{Term: "José", Say: "ho-zay"},
+	})
+	if err != nil {
+		t.Fatalf("NewLexicon failed: %v", err)
+	}
+
+	tests := []struct {
+		text, expected string
+	}{
+		{"The Voir  Dire is on Monday.", "The vwahr deer is on Monday."},
+		{"Ms. Nguyen will call you.", `Ms. <phoneme alphabet="ipa" ph="wɪn">Nguyen</phoneme> will call you.`},
+		{"Ask for Van der Berg or Berg.", "Ask for van der burg or burg."},
+		{"Bergman and Josélito are not terms, but José is.", "Bergman and Josélito are not terms, but ho-zay is."},
+		{"Nothing to rewrite.", "Nothing to rewrite."},
+	}
+	for _, tt := range tests {
+		if got := lexicon.Apply(tt.text); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestLexicon_Empty(t *testing.T) {
+	lexicon, err := NewLexicon(nil)
+	if err != nil || lexicon != nil {
+		t.Fatalf("Expected a nil lexicon without entries, got %v, %v", lexicon, err)
+	}
+	if got := lexicon.Apply("voir dire"); got != "voir dire" {
+		t.Errorf("Expected a nil lexicon to leave text unchanged, got %q", got)
+	}
+
+	if _, err := NewLexicon([]LexiconEntry{{Term: "voir dire"}}); err == nil {
+		t.Error("Expected error for an entry without a pronunciation")
+	}
+}
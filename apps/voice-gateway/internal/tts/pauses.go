@@ -0,0 +1,20 @@
+package tts
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// questionEnd matches a question mark ending a sentence, with the space after it
+var questionEnd = regexp.MustCompile(`\?(\s+|$)`)
+
+// PauseAfterQuestions inserts an SSML <break> after each question in text,
+// giving the caller a moment to answer before the agent goes on
+func PauseAfterQuestions(text string, pause time.Duration) string {
+	if pause <= 0 {
+		return text
+	}
+	tag := fmt.Sprintf(`<break time="%dms"/>`, pause.Milliseconds())
+	return questionEnd.ReplaceAllString(text, "?"+tag+"${1}")
+}
@@ -0,0 +1,24 @@
+package tts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseAfterQuestions(t *testing.T) {
+	tests := []struct {
+		text     string
+		pause    time.Duration
+		expected string
+	}{
+		{"Is Tuesday good? I also have Wednesday.", 500 * time.Millisecond, `Is Tuesday good?<break time="500ms"/> I also have Wednesday.`},
+		{"What is your name?", 300 * time.Millisecond, `What is your name?<break time="300ms"/>`},
+		{"The case no. is 12?3 on file.", 300 * time.Millisecond, "The case no. is 12?3 on file."},
+		{"Is Tuesday good?", 0, "Is Tuesday good?"},
+	}
+	for _, tt := range tests {
+		if got := PauseAfterQuestions(tt.text, tt.pause); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/lexiqai/voice-gateway/internal/audio"
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// Providers accepted by TTS_PROVIDER and firm settings
+const (
+	ProviderCartesia = "cartesia"
+	ProviderPiper    = "piper" // Self-hosted Piper server; text never leaves the firm's network
+)
+
+// NewClient creates the TTS client for cfg's provider
+func NewClient(cfg *config.Config) TTSClient {
+	if cfg.TTSProvider == ProviderPiper {
+		return NewPiperClient(cfg)
+	}
+	return NewCartesiaClient(cfg)
+}
+
+// DefaultVoice is the voice used when neither the firm nor TTS_VOICES names
+// one for the call's language
+func DefaultVoice(cfg *config.Config) string {
+	if cfg.TTSProvider == ProviderPiper {
+		return cfg.PiperVoice
+	}
+	return cfg.CartesiaVoiceID
+}
+
+// PiperRequest is the request payload for Piper's HTTP server
+type PiperRequest struct {
+	Text        string  `json:"text"`
+	Voice       string  `json:"voice,omitempty"`        // Empty uses the server's default voice
+	LengthScale float64 `json:"length_scale,omitempty"` // Phoneme length: below 1 is faster
+}
+
+// PiperClient implements TTSClient against a self-hosted Piper HTTP server,
+// for firms that cannot send text to a cloud provider. Piper answers with a
+// WAV file (or raw 16-bit PCM at PIPER_SAMPLE_RATE), which goes through the
+// same conversion to 8kHz μ-law as Cartesia's audio. Voices are the
+// server's voice names
+type PiperClient struct {
+	*queue
+	config     *config.Config
+	httpClient *http.Client
+}
+
+// NewPiperClient creates a client for the Piper server at PIPER_URL
+func NewPiperClient(cfg *config.Config) *PiperClient {
+	p := &PiperClient{
+		config:     cfg,
+		httpClient: &http.Client{},
+	}
+	p.queue = newQueue("Piper", cfg.CartesiaCallConcurrency, cfg.PiperVoice, p.synthesize)
+	return p
+}
+
+// synthesize requests one text's audio from Piper
+func (p *PiperClient) synthesize(s *synthesis) (*AudioChunk, error) {
+	reqBody := PiperRequest{Text: s.text, Voice: s.voiceID}
+	if s.speed > 0 && s.speed != 1 {
+		reqBody.LengthScale = 1 / s.speed
+	}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Cancelling the synthesis aborts the request
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, p.config.PiperURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("piper returned status %d", resp.StatusCode)
+	}
+
+	audioData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio response: %w", err)
+	}
+	pcm, sampleRate, err := piperPCM(audioData, p.config.PiperSampleRate)
+	if err != nil {
+		return nil, err
+	}
+	if len(pcm) == 0 {
+		return nil, fmt.Errorf("piper returned empty audio data")
+	}
+
+	pcmuData, err := audio.ConvertPCMToPCMU(pcm, sampleRate, 8000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert audio format: %w", err)
+	}
+	return &AudioChunk{
+		Data:       pcmuData,
+		SampleRate: 8000,
+		Channels:   1,
+	}, nil
+}
+
+// piperPCM returns the 16-bit mono PCM samples of a Piper response and their
+// sample rate: a WAV file's own, or rawRate for headerless PCM
+func piperPCM(data []byte, rawRate int) ([]byte, int, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return data, rawRate, nil
+	}
+
+	sampleRate := 0
+	for chunk := data[12:]; len(chunk) >= 8; {
+		id, size := string(chunk[0:4]), int(binary.LittleEndian.Uint32(chunk[4:8]))
+		body := chunk[8:]
+		if size > len(body) {
+			size = len(body) // Streamed WAVs may leave the size unset
+		}
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, fmt.Errorf("piper returned a malformed WAV header")
+			}
+			format, channels := binary.LittleEndian.Uint16(body[0:2]), binary.LittleEndian.Uint16(body[2:4])
+			bits := binary.LittleEndian.Uint16(body[14:16])
+			if format != 1 || channels != 1 || bits != 16 {
+				return nil, 0, fmt.Errorf("piper returned unsupported audio (format %d, %d channels, %d bits)", format, channels, bits)
+			}
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+		case "data":
+			if sampleRate == 0 {
+				return nil, 0, fmt.Errorf("piper returned a WAV file without a format")
+			}
+			return body[:size], sampleRate, nil
+		}
+		// Chunks are padded to an even size
+		chunk = body[min(size+size%2, len(body)):]
+	}
+	return nil, 0, fmt.Errorf("piper returned a WAV file without audio")
+}
+
+// VerifyPiper checks that the Piper server at url answers, listing its voices
+func VerifyPiper(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/voices", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Piper: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("piper returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package tts
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+)
+
+// wav wraps 16-bit mono PCM in a WAV file at sampleRate
+func wav(pcm []byte, sampleRate int) []byte {
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+len(pcm)))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1)
+	binary.LittleEndian.PutUint16(header[22:], 1)
+	binary.LittleEndian.PutUint32(header[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(sampleRate*2))
+	binary.LittleEndian.PutUint16(header[32:], 2)
+	binary.LittleEndian.PutUint16(header[34:], 16)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(pcm)))
+	return append(header, pcm...)
+}
+
+func TestPiperClient_SynthesizesWAV(t *testing.T) {
+	requests := make(chan PiperRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PiperRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests <- req
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write(wav(make([]byte, 3200), 16000)) // 100ms at 16kHz
+	}))
+	defer server.Close()
+
+	client := NewPiperClient(&config.Config{PiperURL: server.URL, PiperVoice: "en_US-lessac-medium", PiperSampleRate: 22050, CartesiaCallConcurrency: 2})
+	defer client.Close()
+	client.SetSpeed(2)
+
+	audio, err := client.Enqueue("Hello there.")
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	chunk := <-audio
+	if chunk == nil || chunk.SampleRate != 8000 || len(chunk.Data) != 800 {
+		t.Fatalf("Expected 100ms of 8kHz μ-law, got %+v", chunk)
+	}
+
+	req := <-requests
+	if req.Text != "Hello there." || req.Voice != "en_US-lessac-medium" || req.LengthScale != 0.5 {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+}
+
+func TestPiperPCM(t *testing.T) {
+	pcm, rate, err := piperPCM(wav(make([]byte, 100), 22050), 16000)
+	if err != nil || rate != 22050 || len(pcm) != 100 {
+		t.Errorf("Expected the WAV's 100 bytes at 22050Hz, got %d bytes at %d (%v)", len(pcm), rate, err)
+	}
+
+	// Headerless audio is taken as raw PCM at the configured rate
+	pcm, rate, err = piperPCM(make([]byte, 64), 16000)
+	if err != nil || rate != 16000 || len(pcm) != 64 {
+		t.Errorf("Expected raw PCM at 16000Hz, got %d bytes at %d (%v)", len(pcm), rate, err)
+	}
+
+	stereo := wav(make([]byte, 100), 22050)
+	binary.LittleEndian.PutUint16(stereo[22:], 2)
+	if _, _, err := piperPCM(stereo, 16000); err == nil {
+		t.Error("Expected error for stereo audio")
+	}
+}
+
+func TestVerifyPiper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/voices" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	if err := VerifyPiper(context.Background(), server.URL); err != nil {
+		t.Errorf("Expected Piper to verify, got %v", err)
+	}
+	if err := VerifyPiper(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("Expected error for a server without voices")
+	}
+}
+
+func TestNewClient_ChoosesProvider(t *testing.T) {
+	if _, ok := NewClient(&config.Config{TTSProvider: ProviderPiper}).(*PiperClient); !ok {
+		t.Error("Expected a Piper client for the piper provider")
+	}
+	if _, ok := NewClient(&config.Config{TTSProvider: ProviderCartesia}).(*CartesiaClient); !ok {
+		t.Error("Expected a Cartesia client for the cartesia provider")
+	}
+}
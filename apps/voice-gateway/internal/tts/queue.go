@@ -0,0 +1,194 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/lexiqai/voice-gateway/internal/observability"
+)
+
+// errClientClosed is returned for text enqueued after Close
+var errClientClosed = errors.New("tts client is closed")
+
+// queue orders a client's syntheses: up to its concurrency are synthesized at
+// once, and each waits for the one enqueued before it to be delivered before
+// sending its audio. Clients embed it and provide synthesize
+type queue struct {
+	provider   string        // For logs
+	slots      chan struct{} // Syntheses this client may run at once
+	synthesize func(s *synthesis) (*AudioChunk, error)
+
+	mu       sync.RWMutex
+	voiceID  string
+	language string // Empty lets the provider use the voice's language
+	speed    float64
+	ctx      context.Context // Cancelled by CancelAll, then replaced
+	cancel   context.CancelFunc
+	last     *synthesis // Most recently enqueued, for ordering and Flush
+	pending  int        // Enqueued texts not yet complete
+	closed   bool
+}
+
+// synthesis is one enqueued text
+type synthesis struct {
+	text     string
+	voiceID  string
+	language string
+	speed    float64
+	ctx      context.Context
+	prev     *synthesis       // Delivered before this one; nil when first
+	out      chan *AudioChunk // Unbuffered, so delivery completes when the audio is taken
+	done     chan struct{}    // Closed once delivered or cancelled
+}
+
+func newQueue(provider string, concurrency int, voiceID string, synthesize func(s *synthesis) (*AudioChunk, error)) *queue {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &queue{
+		provider:   provider,
+		slots:      make(chan struct{}, max(concurrency, 1)),
+		synthesize: synthesize,
+		voiceID:    voiceID,
+		speed:      1.0,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// SetVoice switches the voice and language used for text enqueued from now on
+func (q *queue) SetVoice(voiceID, language string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.voiceID = voiceID
+	q.language = language
+}
+
+// SetSpeed changes the speaking rate for text enqueued from now on
+func (q *queue) SetSpeed(speed float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.speed = speed
+}
+
+// Enqueue queues text for synthesis and returns a channel for its audio
+func (q *queue) Enqueue(text string) (<-chan *AudioChunk, error) {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil, errClientClosed
+	}
+	s := &synthesis{
+		text:     text,
+		voiceID:  q.voiceID,
+		language: q.language,
+		speed:    q.speed,
+		ctx:      q.ctx,
+		prev:     q.last,
+		out:      make(chan *AudioChunk),
+		done:     make(chan struct{}),
+	}
+	q.last = s
+	q.pending++
+	q.mu.Unlock()
+
+	go q.run(s)
+	return s.out, nil
+}
+
+// run synthesizes one text and delivers its audio in turn
+func (q *queue) run(s *synthesis) {
+	defer func() {
+		q.mu.Lock()
+		q.pending--
+		q.mu.Unlock()
+		close(s.out)
+		close(s.done)
+	}()
+	defer observability.RecoverPanic("tts", "synthesize", nil)
+
+	// Only the latest synthesis stays reachable from the client
+	prev := s.prev
+	s.prev = nil
+
+	chunk, err := q.acquire(s)
+	if err != nil {
+		if s.ctx.Err() == nil {
+			log.Printf("%s synthesis failed: %v", q.provider, err)
+		}
+		chunk = nil
+	}
+
+	// Audio is delivered in the order the text was enqueued
+	if prev != nil {
+		select {
+		case <-prev.done:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+	if chunk == nil || s.ctx.Err() != nil {
+		return
+	}
+
+	select {
+	case s.out <- chunk:
+		log.Printf("Sent %d bytes of TTS audio", len(chunk.Data))
+	case <-s.ctx.Done():
+	}
+}
+
+// acquire synthesizes s holding one of the client's slots
+func (q *queue) acquire(s *synthesis) (*AudioChunk, error) {
+	select {
+	case q.slots <- struct{}{}:
+		defer func() { <-q.slots }()
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+	return q.synthesize(s)
+}
+
+// Flush waits until every text enqueued so far is complete, or ctx ends
+func (q *queue) Flush(ctx context.Context) error {
+	q.mu.RLock()
+	last := q.last
+	q.mu.RUnlock()
+	if last == nil {
+		return nil
+	}
+	select {
+	case <-last.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CancelAll abandons every queued and in-progress synthesis; text enqueued
+// afterwards is synthesized as usual
+func (q *queue) CancelAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending > 0 {
+		log.Printf("%s TTS synthesis cancelled (%d pending)", q.provider, q.pending)
+	}
+	q.cancel()
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+}
+
+// Close cancels all synthesis; the client accepts no more text
+func (q *queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cancel()
+	return nil
+}
+
+// IsActive returns whether any enqueued text is still being synthesized
+func (q *queue) IsActive() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.pending > 0
+}
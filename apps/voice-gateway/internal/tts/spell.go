@@ -0,0 +1,105 @@
+package tts
+
+import (
+	"strings"
+	"unicode"
+)
+
+// spokenSymbols are the characters spelled out as words
+var spokenSymbols = map[rune]string{
+	'.': "dot", '@': "at", '_': "underscore", '-': "dash", '+': "plus",
+	'/': "slash", '&': "and", '#': "number", '\'': "apostrophe",
+}
+
+// emailWords are address parts read as words rather than spelled
+var emailWords = map[string]bool{
+	"gmail": true, "yahoo": true, "hotmail": true, "outlook": true, "icloud": true, "aol": true,
+	"com": true, "org": true, "net": true, "edu": true, "gov": true, "info": true,
+}
+
+// SpellOut reads text a character at a time: "Doe-2" is "D, O, E, dash,
+// two". Spaces become a longer pause. Characters it cannot say are skipped
+func SpellOut(text string) string {
+	var words []string
+	for _, part := range strings.Fields(text) {
+		var said []string
+		for _, r := range part {
+			if c := spellRune(r); c != "" {
+				said = append(said, c)
+			}
+		}
+		if len(said) > 0 {
+			words = append(words, strings.Join(said, ", "))
+		}
+	}
+	return strings.Join(words, `, <break time="400ms"/> `)
+}
+
+// spellRune says one character, or "" when it has no spoken form
+func spellRune(r rune) string {
+	switch {
+	case r >= '0' && r <= '9':
+		return ones[r-'0']
+	case unicode.IsLetter(r):
+		return string(unicode.ToUpper(r))
+	default:
+		return spokenSymbols[r]
+	}
+}
+
+// SayPhoneDigits reads a phone number digit by digit in its usual groups:
+// 5551234567 is "five five five, one two three, four five six seven".
+// Numbers of other lengths keep the groups they were written in
+func SayPhoneDigits(number string) string {
+	var digits strings.Builder
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	d := digits.String()
+
+	var groups []string
+	switch {
+	case len(d) == 11 && d[0] == '1':
+		groups = []string{d[:1], d[1:4], d[4:7], d[7:]}
+	case len(d) == 10:
+		groups = []string{d[:3], d[3:6], d[6:]}
+	case len(d) == 7:
+		groups = []string{d[:3], d[3:]}
+	default:
+		return sayPhoneNumber(number)
+	}
+	return sayPhoneNumber(strings.Join(groups, " "))
+}
+
+// SayEmail reads an email address: common providers and domain endings as
+// words, everything else spelled, "jdoe@gmail.com" is "J, D, O, E, at,
+// gmail, dot, com"
+func SayEmail(address string) string {
+	var said []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		if part := word.String(); emailWords[strings.ToLower(part)] {
+			said = append(said, strings.ToLower(part))
+		} else {
+			said = append(said, SpellOut(part))
+		}
+		word.Reset()
+	}
+	for _, r := range strings.TrimSpace(address) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word.WriteRune(r)
+			continue
+		}
+		flush()
+		if symbol := spokenSymbols[r]; symbol != "" {
+			said = append(said, symbol)
+		}
+	}
+	flush()
+	return strings.Join(said, ", ")
+}
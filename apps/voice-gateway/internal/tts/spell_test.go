@@ -0,0 +1,49 @@
+package tts
+
+import "testing"
+
+func TestSpellOut(t *testing.T) {
+	tests := []struct {
+		text, expected string
+	}{
+		{"Doe", "D, O, E"},
+		{"Mary-Jo O'Neil", `M, A, R, Y, dash, J, O, <break time="400ms"/> O, apostrophe, N, E, I, L`},
+		{"CV-42", "C, V, dash, four, two"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		if got := SpellOut(tt.text); got != tt.expected {
+			t.Errorf("SpellOut(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestSayPhoneDigits(t *testing.T) {
+	tests := []struct {
+		number, expected string
+	}{
+		{"5551234567", "five five five, one two three, four five six seven"},
+		{"+1 (555) 123-4567", "one, five five five, one two three, four five six seven"},
+		{"123-4567", "one two three, four five six seven"},
+		{"+44 20 7946 0958", "four four, two zero, seven nine four six, zero nine five eight"},
+	}
+	for _, tt := range tests {
+		if got := SayPhoneDigits(tt.number); got != tt.expected {
+			t.Errorf("SayPhoneDigits(%q) = %q, want %q", tt.number, got, tt.expected)
+		}
+	}
+}
+
+func TestSayEmail(t *testing.T) {
+	tests := []struct {
+		address, expected string
+	}{
+		{"jdoe@gmail.com", "J, D, O, E, at, gmail, dot, com"},
+		{"Mary.Smith_2@Acme-Law.co.uk", "M, A, R, Y, dot, S, M, I, T, H, underscore, two, at, A, C, M, E, dash, L, A, W, dot, C, O, dot, U, K"},
+	}
+	for _, tt := range tests {
+		if got := SayEmail(tt.address); got != tt.expected {
+			t.Errorf("SayEmail(%q) = %q, want %q", tt.address, got, tt.expected)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package tts
 
+import "context"
+
 // AudioChunk represents a chunk of audio data ready for streaming
 type AudioChunk struct {
 	Data     []byte // Raw audio data (PCMU format for Twilio)
@@ -7,18 +9,38 @@ type AudioChunk struct {
 	Channels   int  // Number of channels (1 for mono)
 }
 
+// VoiceSetter is implemented by clients that can switch voice and language
+// mid-call, for callers speaking a language other than the default voice's
+type VoiceSetter interface {
+	// SetVoice applies to the next synthesis; an empty language leaves it to the provider
+	SetVoice(voiceID, language string)
+}
+
+// SpeedSetter is implemented by clients that can change the speaking rate
+type SpeedSetter interface {
+	// SetSpeed applies to the next synthesis; 1 is the voice's natural pace
+	SetSpeed(speed float64)
+}
+
 // TTSClient defines the interface for a Text-to-Speech client
+// Each call session has its own client, which queues the text it is given:
+// several texts may be synthesized at once, but their audio is delivered in
+// the order they were enqueued
 type TTSClient interface {
-	// Synthesize converts text to audio and streams it
-	Synthesize(text string) (<-chan *AudioChunk, error)
-	
-	// Stop stops any ongoing synthesis
-	Stop() error
-	
-	// Close closes the client and cleans up resources
+	// Enqueue queues text for synthesis and returns a channel for its audio,
+	// closed once the text's audio is complete or its synthesis is cancelled.
+	// Nothing is sent on it until the texts enqueued before it are complete
+	Enqueue(text string) (<-chan *AudioChunk, error)
+
+	// Flush waits until every text enqueued so far is complete, or ctx ends
+	Flush(ctx context.Context) error
+
+	// CancelAll abandons every queued and in-progress synthesis
+	CancelAll()
+
+	// Close cancels all synthesis; the client accepts no more text
 	Close() error
-	
-	// IsActive returns whether the client is currently synthesizing
+
+	// IsActive returns whether any enqueued text is still being synthesized
 	IsActive() bool
 }
-
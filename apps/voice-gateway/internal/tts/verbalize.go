@@ -0,0 +1,359 @@
+package tts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Verbalization locales: how dates are ordered and read
+const (
+	LocaleUS = "en-US" // 3/14 is March fourteenth
+	LocaleGB = "en-GB" // 14/3 is the fourteenth of March
+	LocaleAU = "en-AU" // As en-GB
+	LocaleCA = "en-CA" // As en-US
+)
+
+var (
+	// ssmlMarkup matches tags added for synthesis, which are never rewritten
+	ssmlMarkup = regexp.MustCompile(`<[^>]*>`)
+
+	// phoneNumber matches North American numbers: 555-123-4567, (555) 123-4567,
+	// +1 555.123.4567, and the local 123-4567
+	phoneNumber = regexp.MustCompile(`(?:(?:\+?1[\s.-]?)?(?:\(\d{3}\)\s?|\b\d{3}[\s.-]))?\b\d{3}[.-]\d{4}\b`)
+	digitGroups = regexp.MustCompile(`\d+`)
+
+	isoDate   = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	slashDate = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})(?:/(\d{4}|\d{2}))?\b`)
+
+	// clockTime matches 2:30pm, 2 PM, 9:05 a.m.; plainTime matches 14:30
+	clockTime = regexp.MustCompile(`(?i)\b(\d{1,2})(?::(\d{2}))?\s?([ap])(\.?)m\b(\.?)`)
+	plainTime = regexp.MustCompile(`\b(\d{1,2}):(\d{2})\b`)
+
+	money     = regexp.MustCompile(`(?i)([$£€])(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?(?:\s?(thousand|million|billion)\b)?`)
+	percent   = regexp.MustCompile(`\b(\d+(?:\.\d+)?)\s?%`)
+	ordinal   = regexp.MustCompile(`(?i)\b(\d+)(st|nd|rd|th)\b`)
+	groupedNo = regexp.MustCompile(`\b\d{1,3}(?:,\d{3})+(?:\.\d+)?\b`)
+)
+
+// currency names the units of a currency symbol, singular then plural
+type currency struct {
+	unit, units, minor, minors string
+}
+
+var currencies = map[string]currency{
+	"$": {"dollar", "dollars", "cent", "cents"},
+	"£": {"pound", "pounds", "penny", "pence"},
+	"€": {"euro", "euros", "cent", "cents"},
+}
+
+var months = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// Verbalizer spells out numbers the way a person reads them aloud (dates,
+// times, money, percentages, phone numbers digit by digit) before synthesis,
+// since TTS voices read raw formats inconsistently. A nil verbalizer leaves
+// text unchanged
+type Verbalizer struct {
+	dayFirst bool
+}
+
+// NewVerbalizer returns a verbalizer for an English locale; an empty locale
+// returns nil, which leaves text unchanged
+func NewVerbalizer(locale string) (*Verbalizer, error) {
+	switch locale {
+	case "":
+		return nil, nil
+	case LocaleUS, LocaleCA:
+		return &Verbalizer{}, nil
+	case LocaleGB, LocaleAU:
+		return &Verbalizer{dayFirst: true}, nil
+	default:
+		return nil, fmt.Errorf("unsupported verbalization locale %q", locale)
+	}
+}
+
+// Apply returns text with its numbers spelled out, leaving SSML tags alone
+func (v *Verbalizer) Apply(text string) string {
+	if v == nil || !strings.ContainsAny(text, "0123456789") {
+		return text
+	}
+	var out strings.Builder
+	start := 0
+	for _, loc := range ssmlMarkup.FindAllStringIndex(text, -1) {
+		out.WriteString(v.verbalize(text[start:loc[0]]))
+		out.WriteString(text[loc[0]:loc[1]])
+		start = loc[1]
+	}
+	out.WriteString(v.verbalize(text[start:]))
+	return out.String()
+}
+
+// verbalize spells out the numbers in text without markup. Each pass leaves
+// words only, so later passes never see what earlier ones rewrote
+func (v *Verbalizer) verbalize(text string) string {
+	text = phoneNumber.ReplaceAllStringFunc(text, sayPhoneNumber)
+	text = replaceSubmatches(isoDate, text, func(m []string) string {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return v.sayDate(month, day, year, m[0])
+	})
+	text = replaceSubmatches(slashDate, text, func(m []string) string {
+		first, _ := strconv.Atoi(m[1])
+		second, _ := strconv.Atoi(m[2])
+		month, day := first, second
+		if v.dayFirst {
+			month, day = second, first
+		}
+		year := 0
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			if len(m[3]) == 2 {
+				year += 2000
+			}
+		}
+		return v.sayDate(month, day, year, m[0])
+	})
+	text = replaceSubmatches(clockTime, text, func(m []string) string {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour < 1 || hour > 12 || minute > 59 {
+			return m[0]
+		}
+		said := sayTime(hour, minute, strings.ToLower(m[3])+" m")
+		if m[4] == "" && m[5] != "" {
+			said += m[5] // The stop after "AM" ends a sentence
+		}
+		return said
+	})
+	text = replaceSubmatches(plainTime, text, func(m []string) string {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		switch {
+		case hour > 23 || minute > 59:
+			return m[0]
+		case hour == 0:
+			return sayTime(12, minute, "a m")
+		case hour > 12:
+			return sayTime(hour-12, minute, "p m")
+		default:
+			return sayTime(hour, minute, "")
+		}
+	})
+	text = replaceSubmatches(money, text, sayMoney)
+	text = replaceSubmatches(percent, text, func(m []string) string {
+		return sayDecimal(m[1]) + " percent"
+	})
+	text = replaceSubmatches(ordinal, text, func(m []string) string {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return m[0]
+		}
+		return ordinalWords(n)
+	})
+	return groupedNo.ReplaceAllStringFunc(text, func(s string) string {
+		return sayDecimal(strings.ReplaceAll(s, ",", ""))
+	})
+}
+
+// replaceSubmatches replaces each match of re in text with fn of its submatches
+func replaceSubmatches(re *regexp.Regexp, text string, fn func(m []string) string) string {
+	return re.ReplaceAllStringFunc(text, func(s string) string {
+		return fn(re.FindStringSubmatch(s))
+	})
+}
+
+// sayPhoneNumber reads a phone number digit by digit, pausing between groups
+func sayPhoneNumber(number string) string {
+	groups := digitGroups.FindAllString(number, -1)
+	for i, group := range groups {
+		digits := make([]string, len(group))
+		for j, d := range group {
+			digits[j] = ones[d-'0']
+		}
+		groups[i] = strings.Join(digits, " ")
+	}
+	return strings.Join(groups, ", ")
+}
+
+// sayDate reads a date in the verbalizer's order; an impossible date is left
+// as written
+func (v *Verbalizer) sayDate(month, day, year int, written string) string {
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return written
+	}
+	said := months[month-1] + " " + ordinalWords(int64(day))
+	if v.dayFirst {
+		said = "the " + ordinalWords(int64(day)) + " of " + months[month-1]
+	}
+	if year > 0 {
+		said += ", " + yearWords(year)
+	}
+	return said
+}
+
+// sayTime reads a clock time: "two", "two thirty", "two oh five", with its
+// "a m" or "p m" suffix when there is one
+func sayTime(hour, minute int, suffix string) string {
+	said := cardinalWords(int64(hour))
+	switch {
+	case minute == 0 && suffix == "":
+		said += " o'clock"
+	case minute > 0 && minute < 10:
+		said += " oh " + ones[minute]
+	case minute >= 10:
+		said += " " + cardinalWords(int64(minute))
+	}
+	if suffix != "" {
+		said += " " + suffix
+	}
+	return said
+}
+
+// sayMoney reads an amount of money: "$1,500.50" is "one thousand five
+// hundred dollars and fifty cents", "$2.5 million" is "two point five
+// million dollars"
+func sayMoney(m []string) string {
+	c := currencies[m[1]]
+	whole := strings.ReplaceAll(m[2], ",", "")
+	if scale := strings.ToLower(m[4]); scale != "" {
+		amount := whole
+		if m[3] != "" {
+			amount += "." + m[3]
+		}
+		return sayDecimal(amount) + " " + scale + " " + c.units
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return m[0]
+	}
+	minors := 0
+	if m[3] != "" {
+		minors, _ = strconv.Atoi(m[3])
+		if len(m[3]) == 1 {
+			minors *= 10
+		}
+	}
+
+	said := cardinalWords(units) + " " + plural(units, c.unit, c.units)
+	switch {
+	case minors > 0 && units == 0:
+		return cardinalWords(int64(minors)) + " " + plural(int64(minors), c.minor, c.minors)
+	case minors > 0:
+		said += " and " + cardinalWords(int64(minors)) + " " + plural(int64(minors), c.minor, c.minors)
+	}
+	return said
+}
+
+// plural returns one or many by n
+func plural(n int64, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// sayDecimal reads a number with an optional fraction: "2.5" is "two point five"
+func sayDecimal(number string) string {
+	whole, fraction, _ := strings.Cut(number, ".")
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return number
+	}
+	said := cardinalWords(n)
+	if fraction != "" {
+		digits := make([]string, len(fraction))
+		for i, d := range fraction {
+			digits[i] = ones[d-'0']
+		}
+		said += " point " + strings.Join(digits, " ")
+	}
+	return said
+}
+
+// yearWords reads a year the way it is spoken: "nineteen eighty-four",
+// "two thousand five", "twenty twenty-five"
+func yearWords(year int) string {
+	switch {
+	case year >= 2000 && year < 2010:
+		return cardinalWords(int64(year))
+	case year < 1100 || year > 9999:
+		return cardinalWords(int64(year))
+	case year%100 == 0:
+		return cardinalWords(int64(year/100)) + " hundred"
+	case year%100 < 10:
+		return cardinalWords(int64(year/100)) + " oh " + ones[year%100]
+	default:
+		return cardinalWords(int64(year/100)) + " " + cardinalWords(int64(year%100))
+	}
+}
+
+var ones = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+
+var tens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+var scales = []struct {
+	value int64
+	name  string
+}{
+	{1_000_000_000_000, "trillion"},
+	{1_000_000_000, "billion"},
+	{1_000_000, "million"},
+	{1_000, "thousand"},
+}
+
+// cardinalWords spells out a whole number: 1500 is "one thousand five hundred"
+func cardinalWords(n int64) string {
+	if n < 0 {
+		return "minus " + cardinalWords(-n)
+	}
+	if n < 20 {
+		return ones[n]
+	}
+	var words []string
+	for _, scale := range scales {
+		if n >= scale.value {
+			words = append(words, cardinalWords(n/scale.value), scale.name)
+			n %= scale.value
+		}
+	}
+	if n >= 100 {
+		words = append(words, ones[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		words = append(words, tens[n/10]+"-"+ones[n%10])
+	case n >= 20:
+		words = append(words, tens[n/10])
+	case n > 0:
+		words = append(words, ones[n])
+	}
+	return strings.Join(words, " ")
+}
+
+// irregularOrdinals are the ordinals not formed by adding "th"
+var irregularOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// ordinalWords spells out an ordinal: 21 is "twenty-first"
+func ordinalWords(n int64) string {
+	said := cardinalWords(n)
+	cut := strings.LastIndexAny(said, " -") + 1
+	last := said[cut:]
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return said[:cut] + last
+}
@@ -0,0 +1,83 @@
+package tts
+
+import "testing"
+
+func TestVerbalizer_Apply(t *testing.T) {
+	us, err := NewVerbalizer(LocaleUS)
+	if err != nil {
+		t.Fatalf("NewVerbalizer failed: %v", err)
+	}
+
+	tests := []struct {
+		text, expected string
+	}{
+		{"Can you come in on 3/14 2:30pm?", "Can you come in on March fourteenth two thirty p m?"},
+		{"The retainer is $1,500.", "The retainer is one thousand five hundred dollars."},
+		{"That's $1,500.50 or $1.", "That's one thousand five hundred dollars and fifty cents or one dollar."},
+		{"The verdict was $2.5 million.", "The verdict was two point five million dollars."},
+		{"Call us at (555) 123-4567.", "Call us at five five five, one two three, four five six seven."},
+		{"Or +1 555.123.4567.", "Or one, five five five, one two three, four five six seven."},
+		{"The hearing is on 2025-03-21 at 9:05 AM.", "The hearing is on March twenty-first, twenty twenty-five at nine oh five a m."},
+		{"Filed 12/1/24 at 14:00.", "Filed December first, twenty twenty-four at two p m."},
+		{"Open until 5 p.m. on the 22nd.", "Open until five p m on the twenty-second."},
+		{"A 33% contingency on 12,000 claims.", "A thirty-three percent contingency on twelve thousand claims."},
+		{`Is that right?<break time="500ms"/> 3/14.`, `Is that right?<break time="500ms"/> March fourteenth.`},
+		{"Case 13/45 stays as written.", "Case 13/45 stays as written."},
+		{"No numbers here.", "No numbers here."},
+	}
+	for _, tt := range tests {
+		if got := us.Apply(tt.text); got != tt.expected {
+			t.Errorf("Apply(%q)\n got %q\nwant %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestVerbalizer_Locale(t *testing.T) {
+	gb, err := NewVerbalizer(LocaleGB)
+	if err != nil {
+		t.Fatalf("NewVerbalizer failed: %v", err)
+	}
+	if got := gb.Apply("Come in on 14/3."); got != "Come in on the fourteenth of March." {
+		t.Errorf("Expected a day-first date, got %q", got)
+	}
+	if got := gb.Apply("It costs £20.05."); got != "It costs twenty pounds and five pence." {
+		t.Errorf("Unexpected amount %q", got)
+	}
+
+	if v, err := NewVerbalizer(""); v != nil || err != nil {
+		t.Errorf("Expected no verbalizer without a locale, got %v, %v", v, err)
+	}
+	var off *Verbalizer
+	if got := off.Apply("$5"); got != "$5" {
+		t.Errorf("Expected a nil verbalizer to leave text unchanged, got %q", got)
+	}
+	if _, err := NewVerbalizer("fr-FR"); err == nil {
+		t.Error("Expected error for an unsupported locale")
+	}
+}
+
+func TestNumberWords(t *testing.T) {
+	cardinals := map[int64]string{
+		0: "zero", 15: "fifteen", 40: "forty", 99: "ninety-nine", 101: "one hundred one",
+		1500: "one thousand five hundred", 2_000_045: "two million forty-five",
+	}
+	for n, want := range cardinals {
+		if got := cardinalWords(n); got != want {
+			t.Errorf("cardinalWords(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	ordinals := map[int64]string{1: "first", 3: "third", 12: "twelfth", 20: "twentieth", 21: "twenty-first", 100: "one hundredth"}
+	for n, want := range ordinals {
+		if got := ordinalWords(n); got != want {
+			t.Errorf("ordinalWords(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	years := map[int]string{1984: "nineteen eighty-four", 2005: "two thousand five", 2025: "twenty twenty-five", 1900: "nineteen hundred", 1905: "nineteen oh five"}
+	for year, want := range years {
+		if got := yearWords(year); got != want {
+			t.Errorf("yearWords(%d) = %q, want %q", year, got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// CartesiaVoicesURL is Cartesia's voice management endpoint
+const CartesiaVoicesURL = "https://api.cartesia.ai/voices"
+
+// cartesiaVersion is the API version voice cloning is requested with
+const cartesiaVersion = "2024-11-13"
+
+// Voice is a voice created on the TTS provider's account
+type Voice struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Language    string `json:"language"`
+	Description string `json:"description,omitempty"`
+}
+
+// CloneRequest describes a voice to clone from a recorded sample
+type CloneRequest struct {
+	Name        string
+	Language    string
+	Description string
+	Filename    string    // The sample's file name, whose extension tells its format
+	Sample      io.Reader // A clean recording of the speaker, 10-20 seconds is plenty
+}
+
+// CartesiaVoices clones voices on Cartesia accounts, for onboarding a firm's
+// receptionist voice without manual API calls
+type CartesiaVoices struct {
+	apiKey     string // Shared account, for firms without their own
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewCartesiaVoices creates a voice manager for the Cartesia API at apiURL
+func NewCartesiaVoices(apiKey, apiURL string) *CartesiaVoices {
+	return &CartesiaVoices{apiKey: apiKey, apiURL: apiURL, httpClient: &http.Client{}}
+}
+
+// Verify checks that the shared API key is accepted, listing the account's
+// voices, which costs nothing
+func (v *CartesiaVoices) Verify(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, v.apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("X-API-Key", v.apiKey)
+	httpReq.Header.Set("Cartesia-Version", cartesiaVersion)
+
+	resp, err := v.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("cartesia rejected the API key (status %d)", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("cartesia API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Clone creates a voice from req's sample on the account apiKey belongs to;
+// an empty apiKey uses the shared account
+func (v *CartesiaVoices) Clone(ctx context.Context, apiKey string, req CloneRequest) (*Voice, error) {
+	if apiKey == "" {
+		apiKey = v.apiKey
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	clip, err := form.CreateFormFile("clip", req.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build clone request: %w", err)
+	}
+	if _, err := io.Copy(clip, req.Sample); err != nil {
+		return nil, fmt.Errorf("failed to read voice sample: %w", err)
+	}
+	for field, value := range map[string]string{
+		"name":        req.Name,
+		"language":    req.Language,
+		"description": req.Description,
+		"mode":        "similarity",
+	} {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(field, value); err != nil {
+			return nil, fmt.Errorf("failed to build clone request: %w", err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build clone request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.apiURL+"/clone", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+	httpReq.Header.Set("X-API-Key", apiKey)
+	httpReq.Header.Set("Cartesia-Version", cartesiaVersion)
+
+	resp, err := v.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("cartesia API returned status %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	var voice Voice
+	if err := json.NewDecoder(resp.Body).Decode(&voice); err != nil {
+		return nil, fmt.Errorf("failed to decode cloned voice: %w", err)
+	}
+	if voice.ID == "" {
+		return nil, fmt.Errorf("cartesia returned a voice without an ID")
+	}
+	return &voice, nil
+}
@@ -0,0 +1,285 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Twilio REST API host
+const DefaultBaseURL = "https://api.twilio.com"
+
+// Client is a minimal Twilio REST API client for in-call control and outbound calls
+type Client struct {
+	accountSID string
+	authToken  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// APIError is returned when Twilio responds with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("twilio API error %d (code %d): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// NewClient creates a Twilio REST client; an empty baseURL uses DefaultBaseURL
+func NewClient(accountSID, authToken, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		accountSID: accountSID,
+		authToken:  authToken,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// UpdateCall modifies a live call (e.g. new TwiML or status=completed)
+func (c *Client) UpdateCall(ctx context.Context, callSid string, params url.Values) error {
+	if callSid == "" {
+		return fmt.Errorf("call SID is required")
+	}
+	path := fmt.Sprintf("/2010-04-01/Accounts/%s/Calls/%s.json", c.accountSID, callSid)
+	return c.post(ctx, path, params, nil)
+}
+
+// RedirectCall replaces the call's TwiML, ending the media stream
+func (c *Client) RedirectCall(ctx context.Context, callSid, twiml string) error {
+	return c.UpdateCall(ctx, callSid, url.Values{"Twiml": {twiml}})
+}
+
+// HangupCall terminates the call
+func (c *Client) HangupCall(ctx context.Context, callSid string) error {
+	return c.UpdateCall(ctx, callSid, url.Values{"Status": {"completed"}})
+}
+
+// TransferCall dials number, bridging the caller out of the AI conversation
+func (c *Client) TransferCall(ctx context.Context, callSid, number string) error {
+	return c.RedirectCall(ctx, callSid, DialTwiML(number))
+}
+
+// WarmTransferCall dials number like TransferCall, but first plays the
+// TwiML at whisperURL to whoever answers, bridging the caller once it ends
+func (c *Client) WarmTransferCall(ctx context.Context, callSid, number, whisperURL string) error {
+	return c.RedirectCall(ctx, callSid, WhisperDialTwiML(number, whisperURL))
+}
+
+// CallRequest describes an outbound call
+type CallRequest struct {
+	From string // Caller ID: a number on the Twilio account (E.164)
+	To   string // Number to dial (E.164)
+
+	// URL is fetched (POST) for the call's TwiML once it is answered and, with
+	// MachineDetection, once Twilio has decided who answered (AnsweredBy)
+	URL string
+
+	// StatusCallback receives the call's progress and final CallStatus
+	StatusCallback string
+
+	// MachineDetection turns on answering machine detection
+	MachineDetection bool
+
+	// RingTimeout is how long the call may ring unanswered (0 uses Twilio's default)
+	RingTimeout time.Duration
+}
+
+// CreateCall places an outbound call and returns its call SID
+func (c *Client) CreateCall(ctx context.Context, call CallRequest) (string, error) {
+	if call.From == "" || call.To == "" || call.URL == "" {
+		return "", fmt.Errorf("from, to and url are required")
+	}
+	params := url.Values{"From": {call.From}, "To": {call.To}, "Url": {call.URL}, "Method": {http.MethodPost}}
+	if call.StatusCallback != "" {
+		params.Set("StatusCallback", call.StatusCallback)
+		params.Set("StatusCallbackMethod", http.MethodPost)
+		params["StatusCallbackEvent"] = []string{"initiated", "ringing", "answered", "completed"}
+	}
+	if call.MachineDetection {
+		params.Set("MachineDetection", "Enable")
+	}
+	if call.RingTimeout > 0 {
+		params.Set("Timeout", strconv.Itoa(int(call.RingTimeout.Seconds())))
+	}
+	path := fmt.Sprintf("/2010-04-01/Accounts/%s/Calls.json", c.accountSID)
+
+	var created struct {
+		SID string `json:"sid"`
+	}
+	if err := c.post(ctx, path, params, &created); err != nil {
+		return "", err
+	}
+	return created.SID, nil
+}
+
+// SendSMS sends a text message and returns its message SID
+func (c *Client) SendSMS(ctx context.Context, from, to, body string) (string, error) {
+	if from == "" || to == "" {
+		return "", fmt.Errorf("from and to numbers are required")
+	}
+	path := fmt.Sprintf("/2010-04-01/Accounts/%s/Messages.json", c.accountSID)
+
+	var message struct {
+		SID string `json:"sid"`
+	}
+	err := c.post(ctx, path, url.Values{"From": {from}, "To": {to}, "Body": {body}}, &message)
+	if err != nil {
+		return "", err
+	}
+	return message.SID, nil
+}
+
+// DialTwiML returns TwiML that dials number
+func DialTwiML(number string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(number))
+	return "<Response><Dial>" + escaped.String() + "</Dial></Response>"
+}
+
+// WhisperDialTwiML returns TwiML that dials number and, once answered, runs
+// the TwiML at whisperURL on the answering party's leg before connecting them
+func WhisperDialTwiML(number, whisperURL string) string {
+	return Dial{Numbers: []string{number}, WhisperURL: whisperURL}.TwiML()
+}
+
+// Dial describes a <Dial> ringing one or more numbers at once; the first to
+// answer is connected to the caller
+type Dial struct {
+	Numbers []string
+
+	// Timeout is how long the numbers ring before the dial gives up (0 uses Twilio's default)
+	Timeout time.Duration
+
+	// Action is fetched (POST) with DialCallStatus when the dial ends, for the
+	// TwiML that follows; empty continues with the next verb (none: the call ends)
+	Action string
+
+	// WhisperURL is TwiML run on the answering party's leg before connecting them
+	WhisperURL string
+}
+
+// TwiML returns the dial as a TwiML response
+func (d Dial) TwiML() string {
+	var b strings.Builder
+	b.WriteString("<Response><Dial")
+	if d.Timeout > 0 {
+		b.WriteString(` timeout="` + strconv.Itoa(int(d.Timeout.Seconds())) + `"`)
+	}
+	if d.Action != "" {
+		b.WriteString(` action="`)
+		_ = xml.EscapeText(&b, []byte(d.Action))
+		b.WriteString(`" method="POST"`)
+	}
+	b.WriteString(">")
+	for _, number := range d.Numbers {
+		b.WriteString("<Number")
+		if d.WhisperURL != "" {
+			b.WriteString(` url="`)
+			_ = xml.EscapeText(&b, []byte(d.WhisperURL))
+			b.WriteString(`"`)
+		}
+		b.WriteString(">")
+		_ = xml.EscapeText(&b, []byte(number))
+		b.WriteString("</Number>")
+	}
+	b.WriteString("</Dial></Response>")
+	return b.String()
+}
+
+// SayTwiML returns TwiML that reads text aloud
+func SayTwiML(text string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return "<Response><Say>" + escaped.String() + "</Say></Response>"
+}
+
+// StreamTwiML returns TwiML connecting the call to the media stream at
+// streamURL, passing params as stream <Parameter>s
+func StreamTwiML(streamURL string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name, value := range params {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(`<Response><Connect><Stream url="`)
+	_ = xml.EscapeText(&b, []byte(streamURL))
+	b.WriteString(`">`)
+	for _, name := range names {
+		b.WriteString(`<Parameter name="`)
+		_ = xml.EscapeText(&b, []byte(name))
+		b.WriteString(`" value="`)
+		_ = xml.EscapeText(&b, []byte(params[name]))
+		b.WriteString(`"/>`)
+	}
+	b.WriteString("</Stream></Connect></Response>")
+	return b.String()
+}
+
+// StreamURL returns the media stream endpoint for the gateway at publicURL
+// (its https:// base becomes wss://)
+func StreamURL(publicURL string) string {
+	base := strings.TrimSuffix(publicURL, "/")
+	switch {
+	case strings.HasPrefix(base, "https://"):
+		base = "wss://" + base[len("https://"):]
+	case strings.HasPrefix(base, "http://"):
+		base = "ws://" + base[len("http://"):]
+	}
+	return base + "/streams/twilio"
+}
+
+// HangupTwiML is TwiML that ends the call
+const HangupTwiML = "<Response><Hangup/></Response>"
+
+// post sends a form-encoded POST, decoding the response into out (if non-nil)
+// or a Twilio error into APIError
+func (c *Client) post(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.accountSID, c.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if out == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode twilio response: %w", err)
+		}
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
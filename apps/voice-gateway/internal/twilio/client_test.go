@@ -0,0 +1,184 @@
+package twilio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestClient_TransferCall(t *testing.T) {
+	var gotPath, gotTwiml, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, _, _ = r.BasicAuth()
+		r.ParseForm()
+		gotTwiml = r.PostForm.Get("Twiml")
+		w.Write([]byte(`{"sid":"CA123"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("AC123", "token", server.URL)
+	if err := client.TransferCall(context.Background(), "CA123", "+15551234567"); err != nil {
+		t.Fatalf("TransferCall() failed: %v", err)
+	}
+
+	if gotPath != "/2010-04-01/Accounts/AC123/Calls/CA123.json" {
+		t.Errorf("Unexpected path: %s", gotPath)
+	}
+	if gotUser != "AC123" {
+		t.Errorf("Expected basic auth user AC123, got '%s'", gotUser)
+	}
+	if gotTwiml != "<Response><Dial>+15551234567</Dial></Response>" {
+		t.Errorf("Unexpected TwiML: %s", gotTwiml)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":20404,"message":"The requested resource was not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("AC123", "token", server.URL)
+	err := client.HangupCall(context.Background(), "CA404")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != 20404 {
+		t.Errorf("Unexpected error fields: %+v", apiErr)
+	}
+}
+
+func TestDialTwiML_Escapes(t *testing.T) {
+	got := DialTwiML("<sip:a&b>")
+	want := "<Response><Dial>&lt;sip:a&amp;b&gt;</Dial></Response>"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestWhisperDialTwiML(t *testing.T) {
+	got := WhisperDialTwiML("+15551234567", "https://gw.example.com/twilio/transfer/whisper?text=a&b=c")
+	want := `<Response><Dial><Number url="https://gw.example.com/twilio/transfer/whisper?text=a&amp;b=c">+15551234567</Number></Dial></Response>`
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestDial_TwiML(t *testing.T) {
+	got := Dial{Numbers: []string{"+15550000001", "+15550000002"}, Timeout: 20 * time.Second, Action: "https://gw.example.com/next?a=1&b=2"}.TwiML()
+	want := `<Response><Dial timeout="20" action="https://gw.example.com/next?a=1&amp;b=2" method="POST"><Number>+15550000001</Number><Number>+15550000002</Number></Dial></Response>`
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestStreamURL(t *testing.T) {
+	for base, want := range map[string]string{
+		"https://gw.example.com/": "wss://gw.example.com/streams/twilio",
+		"http://localhost:8080":   "ws://localhost:8080/streams/twilio",
+	} {
+		if got := StreamURL(base); got != want {
+			t.Errorf("StreamURL(%q) = %s, want %s", base, got, want)
+		}
+	}
+}
+
+func TestClient_SendSMS(t *testing.T) {
+	var gotPath, gotTo, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		r.ParseForm()
+		gotTo = r.PostForm.Get("To")
+		gotBody = r.PostForm.Get("Body")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"SM123","status":"queued"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("AC123", "token", server.URL)
+	sid, err := client.SendSMS(context.Background(), "+15550001111", "+15551234567", "See you at 3pm")
+	if err != nil {
+		t.Fatalf("SendSMS() failed: %v", err)
+	}
+
+	if sid != "SM123" {
+		t.Errorf("Expected SID SM123, got '%s'", sid)
+	}
+	if gotPath != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Errorf("Unexpected path: %s", gotPath)
+	}
+	if gotTo != "+15551234567" || gotBody != "See you at 3pm" {
+		t.Errorf("Unexpected form: To=%s Body=%s", gotTo, gotBody)
+	}
+}
+
+func TestClient_CreateCall(t *testing.T) {
+	var gotPath string
+	var gotForm url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		r.ParseForm()
+		gotForm = r.PostForm
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"CA789"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("AC123", "token", server.URL)
+	sid, err := client.CreateCall(context.Background(), CallRequest{
+		From:             "+15550001111",
+		To:               "+15551234567",
+		URL:              "https://gw.example.com/answer",
+		StatusCallback:   "https://gw.example.com/status",
+		MachineDetection: true,
+		RingTimeout:      25 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("CreateCall() failed: %v", err)
+	}
+	if sid != "CA789" || gotPath != "/2010-04-01/Accounts/AC123/Calls.json" {
+		t.Errorf("Unexpected sid %q or path %s", sid, gotPath)
+	}
+	if gotForm.Get("MachineDetection") != "Enable" || gotForm.Get("Timeout") != "25" || gotForm.Get("Url") != "https://gw.example.com/answer" {
+		t.Errorf("Unexpected form: %v", gotForm)
+	}
+	if len(gotForm["StatusCallbackEvent"]) != 4 {
+		t.Errorf("Expected status callback events, got %v", gotForm["StatusCallbackEvent"])
+	}
+}
+
+func TestStreamTwiML(t *testing.T) {
+	got := StreamTwiML("wss://gw.example.com/streams/twilio", map[string]string{"to": "+1555", "firm_id": "acme", "script": `Say "hi" & <wave>`, "campaign": ""})
+	want := `<Response><Connect><Stream url="wss://gw.example.com/streams/twilio">` +
+		`<Parameter name="firm_id" value="acme"/><Parameter name="script" value="Say &#34;hi&#34; &amp; &lt;wave&gt;"/>` +
+		`<Parameter name="to" value="+1555"/></Stream></Connect></Response>`
+	if got != want {
+		t.Errorf("StreamTwiML() = %s", got)
+	}
+}
+
+func TestValidSignature(t *testing.T) {
+	// Example from Twilio's webhook security documentation
+	params := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+	fullURL := "https://mycompany.com/myapp.php?foo=1&bar=2"
+	if !ValidSignature("12345", fullURL, params, "0/KCTR6DLpKmkAf8muzZqo1nDgQ=") {
+		t.Errorf("Expected the documented signature to validate, computed %s", Sign("12345", fullURL, params))
+	}
+	if ValidSignature("12345", fullURL, params, "forged") || ValidSignature("", fullURL, params, Sign("", fullURL, params)) {
+		t.Error("Expected forged signatures and an empty token to fail")
+	}
+}
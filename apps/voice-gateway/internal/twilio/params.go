@@ -0,0 +1,170 @@
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Stream parameter names, passed by TwiML as <Parameter name="..." value="..."/>
+// inside <Stream> and delivered on the media stream's start event
+const (
+	ParamFirmID   = "firm_id"
+	ParamUserID   = "user_id"
+	ParamCallID   = "call_id"
+	ParamLanguage = "language"
+	ParamCampaign = "campaign"
+	ParamFrom     = "from"
+	ParamTo       = "to"
+	ParamScript   = "script"
+
+	ParamTransferFallback = "transfer_fallback"
+)
+
+// Transfer fallbacks: what a caller reconnected after an unanswered transfer gets
+const (
+	FallbackAI        = "ai"
+	FallbackVoicemail = "voicemail"
+)
+
+// MaxScriptLength caps the script context an outbound call passes to the assistant
+const MaxScriptLength = 1000
+
+var (
+	// identifierPattern matches firm, user and call IDs (slugs, UUIDs,
+	// database keys); unrendered templates such as "{{FirmId}}" fail it
+	identifierPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]{0,127}$`)
+
+	// languagePattern matches BCP 47 style tags (en, en-US, pt-BR) and "multi"
+	languagePattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+	// campaignPattern matches short campaign labels
+	campaignPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ._:/-]{0,63}$`)
+)
+
+// StreamParameters are the custom parameters of a media stream
+type StreamParameters struct {
+	FirmID   string // Firm the call belongs to
+	UserID   string // Firm user (attorney) the line belongs to
+	CallID   string // Call record ID in the platform database
+	Language string // Speech recognition language, overriding DEEPGRAM_LANGUAGE
+	Campaign string // Marketing campaign or tracking number label
+	From     string // Caller's number
+	To       string // Dialed firm number
+	Script   string // Outbound campaign script context for the assistant
+
+	// TransferFallback marks a caller reconnected after nobody answered a
+	// transfer: "ai" resumes the assistant, "voicemail" takes a message
+	TransferFallback string
+
+	// Unknown lists parameters the gateway does not use, sorted, so typos show up in logs
+	Unknown []string
+
+	// notString lists known parameters sent with a non-string value
+	notString []string
+}
+
+// UnmarshalJSON decodes Twilio's customParameters object; unknown names are
+// collected rather than rejected, and malformed values are reported by Check
+func (p *StreamParameters) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = StreamParameters{}
+	for name, value := range raw {
+		field := p.field(name)
+		if field == nil {
+			p.Unknown = append(p.Unknown, name)
+			continue
+		}
+		if err := json.Unmarshal(value, field); err != nil {
+			p.notString = append(p.notString, name)
+		}
+	}
+	sort.Strings(p.Unknown)
+	sort.Strings(p.notString)
+	return nil
+}
+
+// field returns the value for a parameter name, or nil for an unknown one
+func (p *StreamParameters) field(name string) *string {
+	switch name {
+	case ParamFirmID:
+		return &p.FirmID
+	case ParamUserID:
+		return &p.UserID
+	case ParamCallID:
+		return &p.CallID
+	case ParamLanguage:
+		return &p.Language
+	case ParamCampaign:
+		return &p.Campaign
+	case ParamFrom:
+		return &p.From
+	case ParamTo:
+		return &p.To
+	case ParamScript:
+		return &p.Script
+	case ParamTransferFallback:
+		return &p.TransferFallback
+	}
+	return nil
+}
+
+// ValidateRequired checks that names are all stream parameters
+func ValidateRequired(names []string) error {
+	var p StreamParameters
+	for _, name := range names {
+		if p.field(strings.TrimSpace(name)) == nil {
+			return fmt.Errorf("unknown stream parameter %q", name)
+		}
+	}
+	return nil
+}
+
+// Check applies a deployment's parameter policy: any required parameter that
+// is missing, and any parameter that is malformed, is an error naming each problem
+func (p *StreamParameters) Check(required []string) error {
+	var problems []string
+	for _, name := range p.notString {
+		problems = append(problems, name+" is not a string")
+	}
+	for _, name := range required {
+		name = strings.TrimSpace(name)
+		if field := p.field(name); field != nil && *field == "" {
+			problems = append(problems, name+" is missing")
+		}
+	}
+	for _, rule := range []struct {
+		name    string
+		value   string
+		pattern *regexp.Regexp
+	}{
+		{ParamFirmID, p.FirmID, identifierPattern},
+		{ParamUserID, p.UserID, identifierPattern},
+		{ParamCallID, p.CallID, identifierPattern},
+		{ParamLanguage, p.Language, languagePattern},
+		{ParamCampaign, p.Campaign, campaignPattern},
+	} {
+		if rule.value != "" && !rule.pattern.MatchString(rule.value) {
+			problems = append(problems, fmt.Sprintf("%s %q is malformed", rule.name, rule.value))
+		}
+	}
+	switch p.TransferFallback {
+	case "", FallbackAI, FallbackVoicemail:
+	default:
+		problems = append(problems, fmt.Sprintf("%s %q is not %s or %s", ParamTransferFallback, p.TransferFallback, FallbackAI, FallbackVoicemail))
+	}
+	if len(p.Script) > MaxScriptLength {
+		problems = append(problems, fmt.Sprintf("%s is longer than %d characters", ParamScript, MaxScriptLength))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid stream parameters: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package twilio
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decodeParams(t *testing.T, data string) StreamParameters {
+	t.Helper()
+	var p StreamParameters
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	return p
+}
+
+func TestStreamParameters_Unmarshal(t *testing.T) {
+	p := decodeParams(t, `{"firm_id":"acme-law","user_id":"u-42","call_id":"c-7","language":"es","campaign":"Spring Radio","from":"+15551234567","to":"+15557654321","firmid":"typo","utm":"x"}`)
+
+	if p.FirmID != "acme-law" || p.UserID != "u-42" || p.CallID != "c-7" {
+		t.Errorf("Expected IDs decoded, got %+v", p)
+	}
+	if p.Language != "es" || p.Campaign != "Spring Radio" || p.From != "+15551234567" || p.To != "+15557654321" {
+		t.Errorf("Expected optional fields decoded, got %+v", p)
+	}
+	if strings.Join(p.Unknown, ",") != "firmid,utm" {
+		t.Errorf("Expected unknown parameters firmid,utm, got %v", p.Unknown)
+	}
+	if err := p.Check([]string{ParamFirmID, ParamUserID}); err != nil {
+		t.Errorf("Expected valid parameters, got %v", err)
+	}
+}
+
+func TestStreamParameters_CheckRequired(t *testing.T) {
+	p := decodeParams(t, `{"firm_id":"acme"}`)
+	if err := p.Check([]string{ParamUserID, ParamCampaign}); err == nil ||
+		!strings.Contains(err.Error(), "user_id is missing") || !strings.Contains(err.Error(), "campaign is missing") {
+		t.Errorf("Expected missing user_id and campaign errors, got %v", err)
+	}
+	if err := p.Check(nil); err != nil {
+		t.Errorf("Expected no error without required parameters, got %v", err)
+	}
+}
+
+func TestStreamParameters_CheckMalformed(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"firm_id":"{{FirmId}}"}`, "firm_id"},
+		{`{"firm_id":"acme law"}`, "firm_id"},
+		{`{"firm_id":42}`, "firm_id is not a string"},
+		{`{"firm_id":"acme","language":"english (US)"}`, "language"},
+		{`{"firm_id":"acme","campaign":"<script>"}`, "campaign"},
+		{`{"firm_id":"acme","call_id":""}`, ""},
+		{`{"firm_id":"acme","language":"multi"}`, ""},
+		{`{"firm_id":"acme","transfer_fallback":"voicemail"}`, ""},
+		{`{"firm_id":"acme","transfer_fallback":"hangup"}`, "transfer_fallback"},
+	}
+	for _, tt := range tests {
+		p := decodeParams(t, tt.data)
+		err := p.Check(nil)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: expected valid, got %v", tt.data, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: expected error mentioning %q, got %v", tt.data, tt.want, err)
+		}
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	if err := ValidateRequired([]string{"firm_id", " user_id", "campaign"}); err != nil {
+		t.Errorf("Expected valid names, got %v", err)
+	}
+	if err := ValidateRequired([]string{"firmid"}); err == nil {
+		t.Error("Expected error for an unknown parameter name")
+	}
+}
+
+func TestStreamParameters_Script(t *testing.T) {
+	p := decodeParams(t, `{"firm_id":"acme","script":"Confirm tomorrow's 10am consultation"}`)
+	if p.Script != "Confirm tomorrow's 10am consultation" || len(p.Unknown) != 0 {
+		t.Errorf("Expected script decoded, got %+v", p)
+	}
+	p.Script = strings.Repeat("x", MaxScriptLength+1)
+	if err := p.Check(nil); err == nil || !strings.Contains(err.Error(), "script") {
+		t.Errorf("Expected an overlong script to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SignatureHeader carries Twilio's signature of a webhook request
+const SignatureHeader = "X-Twilio-Signature"
+
+// Sign computes a webhook signature: the base64 HMAC-SHA1, keyed by the
+// account's auth token, of the full request URL followed by each POST
+// parameter's name and value in name order
+func Sign(authToken, fullURL string, params url.Values) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var data strings.Builder
+	data.WriteString(fullURL)
+	for _, name := range names {
+		for _, value := range params[name] {
+			data.WriteString(name)
+			data.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature checks a webhook signature against Sign
+func ValidSignature(authToken, fullURL string, params url.Values, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(authToken, fullURL, params)), []byte(signature))
+}
+
+// ValidRequest checks the signature of a webhook request Twilio sent to
+// publicBaseURL (the gateway's public URL, as Twilio dialed it)
+func ValidRequest(r *http.Request, authToken, publicBaseURL string) bool {
+	if err := r.ParseForm(); err != nil {
+		return false
+	}
+	fullURL := strings.TrimSuffix(publicBaseURL, "/") + r.URL.RequestURI()
+	return ValidSignature(authToken, fullURL, r.PostForm, r.Header.Get(SignatureHeader))
+}
@@ -0,0 +1,38 @@
+package usage
+
+import "github.com/lexiqai/voice-gateway/internal/cdr"
+
+// AlertThresholds are the fractions of a monthly cap that raise a budget alert
+var AlertThresholds = []float64{0.8, 1.0}
+
+// Rates price usage in USD
+type Rates struct {
+	STTPerMinute       float64
+	TTSPer1KCharacters float64
+	TokensPer1K        float64
+	TelephonyPerMinute float64
+}
+
+// Cost prices u at the rates
+func (r Rates) Cost(u cdr.Usage) float64 {
+	return u.STTSeconds/60*r.STTPerMinute +
+		float64(u.TTSCharacters)/1000*r.TTSPer1KCharacters +
+		float64(u.OrchestratorTokens)/1000*r.TokensPer1K +
+		u.TelephonyMinutes*r.TelephonyPerMinute
+}
+
+// CrossedThresholds returns the alert thresholds a spend change from before
+// to after passes for monthlyCap; a cap of 0 or less has none
+func CrossedThresholds(before, after, monthlyCap float64) []float64 {
+	if monthlyCap <= 0 {
+		return nil
+	}
+	var crossed []float64
+	for _, threshold := range AlertThresholds {
+		limit := threshold * monthlyCap
+		if before < limit && after >= limit {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,103 @@
+package usage
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+)
+
+func TestRates_Cost(t *testing.T) {
+	rates := Rates{STTPerMinute: 0.01, TTSPer1KCharacters: 0.03, TokensPer1K: 0.002, TelephonyPerMinute: 0.0085}
+	cost := rates.Cost(cdr.Usage{STTSeconds: 120, TTSCharacters: 2000, OrchestratorTokens: 5000, TelephonyMinutes: 3})
+	if want := 0.02 + 0.06 + 0.01 + 0.0255; math.Abs(cost-want) > 1e-9 {
+		t.Errorf("Expected cost %v, got %v", want, cost)
+	}
+}
+
+func TestCrossedThresholds(t *testing.T) {
+	tests := []struct {
+		before, after float64
+		want          []float64
+	}{
+		{before: 10, after: 70, want: nil},
+		{before: 70, after: 85, want: []float64{0.8}},
+		{before: 70, after: 120, want: []float64{0.8, 1.0}},
+		{before: 85, after: 100, want: []float64{1.0}},
+		{before: 100, after: 130, want: nil},
+	}
+	for _, tt := range tests {
+		got := CrossedThresholds(tt.before, tt.after, 100)
+		if len(got) != len(tt.want) {
+			t.Errorf("CrossedThresholds(%v, %v) = %v, expected %v", tt.before, tt.after, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("CrossedThresholds(%v, %v) = %v, expected %v", tt.before, tt.after, got, tt.want)
+			}
+		}
+	}
+	if got := CrossedThresholds(0, 1000, 0); got != nil {
+		t.Errorf("Expected no thresholds without a cap, got %v", got)
+	}
+}
+
+func TestMemoryMonths_RollsOver(t *testing.T) {
+	ctx := context.Background()
+	months := NewMemoryMonths()
+	january := time.Date(2025, time.January, 31, 23, 0, 0, 0, time.UTC)
+	february := january.Add(2 * time.Hour)
+
+	months.Add(ctx, "firm-1", cdr.Usage{STTSeconds: 60}, january)
+	month, _ := months.Add(ctx, "firm-1", cdr.Usage{STTSeconds: 30}, january)
+	if month.STTSeconds != 90 {
+		t.Errorf("Expected 90 STT seconds in January, got %v", month.STTSeconds)
+	}
+
+	if got, _ := months.Month(ctx, "firm-1", february); got != (cdr.Usage{}) {
+		t.Errorf("Expected no usage in February yet, got %+v", got)
+	}
+	month, _ = months.Add(ctx, "firm-1", cdr.Usage{STTSeconds: 15}, february)
+	if month.STTSeconds != 15 {
+		t.Errorf("Expected February to start from zero, got %v", month.STTSeconds)
+	}
+}
+
+func TestLedger_SharesMonthsAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	months := NewMemoryMonths()
+	a, b := NewSharedLedger(months), NewSharedLedger(months)
+
+	a.Add(ctx, "firm-1", cdr.Usage{STTSeconds: 60})
+	month, err := b.Add(ctx, "firm-1", cdr.Usage{STTSeconds: 30})
+	if err != nil || month.STTSeconds != 90 {
+		t.Errorf("Expected both instances' calls in the month, got %+v (%v)", month, err)
+	}
+	if got, _ := a.Month(ctx, "firm-1"); got.STTSeconds != 90 {
+		t.Errorf("Expected 90 STT seconds this month, got %v", got.STTSeconds)
+	}
+
+	total, _ := a.Firm("firm-1")
+	if total.Calls != 1 || total.STTSeconds != 60 {
+		t.Errorf("Expected lifetime totals per instance, got %+v", total)
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	got, err := parseMonth([]interface{}{"90.5", "1200", nil, "3"})
+	if err != nil {
+		t.Fatalf("parseMonth failed: %v", err)
+	}
+	if want := (cdr.Usage{STTSeconds: 90.5, TTSCharacters: 1200, TelephonyMinutes: 3}); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if _, err := parseMonth([]interface{}{"x", "0", "0", "0"}); err == nil {
+		t.Error("Expected an error for a malformed field")
+	}
+	if _, err := parseMonth(nil); err == nil {
+		t.Error("Expected an error for a missing reply")
+	}
+}
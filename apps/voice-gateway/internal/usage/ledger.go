@@ -0,0 +1,105 @@
+package usage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+)
+
+// FirmUsage is a firm's accumulated billable usage
+type FirmUsage struct {
+	FirmID string `json:"firm_id"`
+	Calls  int64  `json:"calls"`
+	cdr.Usage
+}
+
+// Ledger totals call usage per firm since the process started, and keeps
+// each firm's usage for the current calendar month (UTC) in a MonthStore to
+// enforce budgets. The lifetime totals are per instance and reset on restart;
+// the CDRs carried by call.completed events remain the durable source for billing
+type Ledger struct {
+	mu     sync.RWMutex
+	since  time.Time
+	byFirm map[string]*FirmUsage
+	months MonthStore
+}
+
+// NewLedger creates an empty ledger whose monthly usage is kept in memory,
+// per instance
+func NewLedger() *Ledger {
+	return NewSharedLedger(NewMemoryMonths())
+}
+
+// NewSharedLedger creates an empty ledger whose monthly usage is kept in
+// months, e.g. Redis shared by every instance so budgets see all their calls
+func NewSharedLedger(months MonthStore) *Ledger {
+	return &Ledger{
+		since:  time.Now().UTC(),
+		byFirm: make(map[string]*FirmUsage),
+		months: months,
+	}
+}
+
+// Add records one finished call's usage against firmID and returns the
+// firm's usage so far this month, including the call
+func (l *Ledger) Add(ctx context.Context, firmID string, u cdr.Usage) (cdr.Usage, error) {
+	l.mu.Lock()
+	total, ok := l.byFirm[firmID]
+	if !ok {
+		total = &FirmUsage{FirmID: firmID}
+		l.byFirm[firmID] = total
+	}
+	total.Calls++
+	addUsage(&total.Usage, u)
+	l.mu.Unlock()
+
+	return l.months.Add(ctx, firmID, u, time.Now())
+}
+
+// Month returns the firm's usage so far this calendar month
+func (l *Ledger) Month(ctx context.Context, firmID string) (cdr.Usage, error) {
+	return l.months.Month(ctx, firmID, time.Now())
+}
+
+// Firm returns a firm's totals; ok is false when it has no calls yet
+func (l *Ledger) Firm(firmID string) (FirmUsage, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	total, ok := l.byFirm[firmID]
+	if !ok {
+		return FirmUsage{FirmID: firmID}, false
+	}
+	return *total, true
+}
+
+// All returns every firm's totals ordered by firm ID
+func (l *Ledger) All() []FirmUsage {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	totals := make([]FirmUsage, 0, len(l.byFirm))
+	for _, total := range l.byFirm {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].FirmID < totals[j].FirmID })
+	return totals
+}
+
+// Since returns when the ledger started counting
+func (l *Ledger) Since() time.Time {
+	return l.since
+}
+
+func addUsage(total *cdr.Usage, u cdr.Usage) {
+	total.STTSeconds += u.STTSeconds
+	total.TTSCharacters += u.TTSCharacters
+	total.OrchestratorTokens += u.OrchestratorTokens
+	total.TelephonyMinutes += u.TelephonyMinutes
+}
+
+// monthKey is the UTC calendar month containing t
+func monthKey(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
@@ -0,0 +1,64 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+)
+
+// MonthStore keeps each firm's usage per calendar month (UTC), which budget
+// caps and alerts are checked against
+type MonthStore interface {
+	// Add adds u to the firm's usage in the month containing now and returns
+	// that month's total, including u
+	Add(ctx context.Context, firmID string, u cdr.Usage, now time.Time) (cdr.Usage, error)
+
+	// Month returns the firm's usage in the month containing now
+	Month(ctx context.Context, firmID string, now time.Time) (cdr.Usage, error)
+}
+
+// MemoryMonths keeps monthly usage in process memory; each instance sees only
+// its own calls and restarts start from zero, so it suits single-instance
+// deployments only
+type MemoryMonths struct {
+	mu      sync.Mutex
+	monthly map[string]*monthUsage
+}
+
+// monthUsage is a firm's usage within one calendar month
+type monthUsage struct {
+	month string // "2006-01"
+	usage cdr.Usage
+}
+
+// NewMemoryMonths creates an empty in-memory store
+func NewMemoryMonths() *MemoryMonths {
+	return &MemoryMonths{monthly: make(map[string]*monthUsage)}
+}
+
+// Add adds u to the firm's usage in the month containing now
+func (m *MemoryMonths) Add(ctx context.Context, firmID string, u cdr.Usage, now time.Time) (cdr.Usage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	month := monthKey(now)
+	current, ok := m.monthly[firmID]
+	if !ok || current.month != month {
+		current = &monthUsage{month: month}
+		m.monthly[firmID] = current
+	}
+	addUsage(&current.usage, u)
+	return current.usage, nil
+}
+
+// Month returns the firm's usage in the month containing now
+func (m *MemoryMonths) Month(ctx context.Context, firmID string, now time.Time) (cdr.Usage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.monthly[firmID]
+	if !ok || current.month != monthKey(now) {
+		return cdr.Usage{}, nil
+	}
+	return current.usage, nil
+}
@@ -0,0 +1,101 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/cdr"
+	"github.com/lexiqai/voice-gateway/internal/cluster"
+)
+
+const (
+	// redisKeyPrefix namespaces usage keys in a shared Redis
+	redisKeyPrefix = "voice-gateway:usage:"
+
+	// redisMonthTTL keeps a month's hash past its end, then lets Redis drop it
+	redisMonthTTL = 62 * 24 * time.Hour
+)
+
+// addScript adds a call's usage to a firm's month and returns the new totals
+// KEYS: month hash
+// ARGV: stt seconds, tts characters, orchestrator tokens, telephony minutes, ttl seconds
+const addScript = `
+redis.call('HINCRBYFLOAT', KEYS[1], 'stt_seconds', ARGV[1])
+redis.call('HINCRBY', KEYS[1], 'tts_characters', ARGV[2])
+redis.call('HINCRBY', KEYS[1], 'orchestrator_tokens', ARGV[3])
+redis.call('HINCRBYFLOAT', KEYS[1], 'telephony_minutes', ARGV[4])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+return redis.call('HMGET', KEYS[1], 'stt_seconds', 'tts_characters', 'orchestrator_tokens', 'telephony_minutes')
+`
+
+// RedisMonths keeps monthly usage in Redis, shared by every gateway instance:
+// one hash per firm and month, incremented as calls finish
+type RedisMonths struct {
+	client *cluster.RedisClient
+}
+
+// NewRedisMonths creates a store on the given client
+func NewRedisMonths(client *cluster.RedisClient) *RedisMonths {
+	return &RedisMonths{client: client}
+}
+
+// Add adds u to the firm's usage in the month containing now
+func (r *RedisMonths) Add(ctx context.Context, firmID string, u cdr.Usage, now time.Time) (cdr.Usage, error) {
+	reply, err := r.client.Do(ctx, "EVAL", addScript, "1", monthHashKey(firmID, now),
+		strconv.FormatFloat(u.STTSeconds, 'f', -1, 64),
+		strconv.FormatInt(u.TTSCharacters, 10),
+		strconv.FormatInt(u.OrchestratorTokens, 10),
+		strconv.FormatFloat(u.TelephonyMinutes, 'f', -1, 64),
+		strconv.FormatInt(int64(redisMonthTTL/time.Second), 10))
+	if err != nil {
+		return cdr.Usage{}, fmt.Errorf("failed to add usage: %w", err)
+	}
+	return parseMonth(reply)
+}
+
+// Month returns the firm's usage in the month containing now
+func (r *RedisMonths) Month(ctx context.Context, firmID string, now time.Time) (cdr.Usage, error) {
+	reply, err := r.client.Do(ctx, "HMGET", monthHashKey(firmID, now),
+		"stt_seconds", "tts_characters", "orchestrator_tokens", "telephony_minutes")
+	if err != nil {
+		return cdr.Usage{}, fmt.Errorf("failed to load usage: %w", err)
+	}
+	return parseMonth(reply)
+}
+
+// parseMonth reads an HMGET reply of the month's fields; missing fields are zero
+func parseMonth(reply interface{}) (cdr.Usage, error) {
+	values, _ := reply.([]interface{})
+	if len(values) != 4 {
+		return cdr.Usage{}, fmt.Errorf("unexpected usage reply %v", reply)
+	}
+	field := func(i int) string {
+		value, _ := values[i].(string)
+		if value == "" {
+			return "0"
+		}
+		return value
+	}
+
+	var u cdr.Usage
+	var err error
+	if u.STTSeconds, err = strconv.ParseFloat(field(0), 64); err != nil {
+		return cdr.Usage{}, fmt.Errorf("invalid stt_seconds: %w", err)
+	}
+	if u.TTSCharacters, err = strconv.ParseInt(field(1), 10, 64); err != nil {
+		return cdr.Usage{}, fmt.Errorf("invalid tts_characters: %w", err)
+	}
+	if u.OrchestratorTokens, err = strconv.ParseInt(field(2), 10, 64); err != nil {
+		return cdr.Usage{}, fmt.Errorf("invalid orchestrator_tokens: %w", err)
+	}
+	if u.TelephonyMinutes, err = strconv.ParseFloat(field(3), 64); err != nil {
+		return cdr.Usage{}, fmt.Errorf("invalid telephony_minutes: %w", err)
+	}
+	return u, nil
+}
+
+func monthHashKey(firmID string, now time.Time) string {
+	return redisKeyPrefix + monthKey(now) + ":" + firmID
+}
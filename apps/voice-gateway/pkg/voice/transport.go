@@ -0,0 +1,200 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+	"github.com/lexiqai/voice-gateway/internal/twilio"
+)
+
+// EventType is the kind of an event a transport receives from its client
+type EventType string
+
+const (
+	EventStart EventType = "start" // The conversation begins; the first event
+	EventAudio EventType = "media" // Caller audio
+	EventMark  EventType = "mark"  // The agent audio before a mark has played
+	EventDigit EventType = "dtmf"  // A keypad press
+	EventStop  EventType = "stop"  // The caller hung up
+)
+
+// Event is one message from a transport's client. Audio, both ways, is
+// 8kHz μ-law, ideally in 20ms (160 byte) frames
+type Event struct {
+	Type  EventType
+	Start *Start // EventStart
+	Audio []byte // EventAudio
+	Mark  string // EventMark: the name passed to SendMark
+	Digit string // EventDigit: 0-9, * or #
+}
+
+// Start describes the conversation, as a Twilio call's stream parameters do
+type Start struct {
+	SessionID string // Unique per conversation; the call's ID in logs and CDRs
+	FirmID    string // Firm the conversation belongs to
+	UserID    string // Firm user (attorney) the line belongs to
+	CallID    string // Call record ID in the platform database
+	Language  string // Speech recognition language, overriding DEEPGRAM_LANGUAGE
+	From      string // Caller's number, when there is one
+	To        string // Number or channel the caller reached
+}
+
+// Transport carries one conversation between the engine and a client. The
+// engine calls Receive from one goroutine and the Send methods and Clear from
+// another; Close may be called from either, and must unblock Receive
+type Transport interface {
+	// Receive blocks for the client's next event; an error ends the conversation
+	Receive() (Event, error)
+
+	// SendAudio plays agent audio to the client, after the audio already sent
+	SendAudio(audio []byte) error
+
+	// SendMark asks the client to report, with an EventMark of the same name,
+	// when the audio sent so far has played. Clear reports pending marks too
+	SendMark(name string) error
+
+	// Clear stops the agent's audio: the client drops what it has not played yet
+	Clear() error
+
+	Close() error
+}
+
+// transportConn presents a Transport as the Twilio media stream a call
+// session reads and writes, so embedded conversations run the same pipeline
+// as phone calls
+type transportConn struct {
+	transport Transport
+	closeOnce sync.Once
+	closeErr  error
+
+	// Only the session's reader goroutine uses these
+	sessionID string
+	received  int // Caller audio bytes received, for media timestamps
+}
+
+func newTransportConn(transport Transport) *transportConn {
+	return &transportConn{transport: transport}
+}
+
+// NextReader receives the transport's next event as a Twilio message
+func (c *transportConn) NextReader() (int, io.Reader, error) {
+	event, err := c.transport.Receive()
+	if err != nil {
+		return 0, nil, err
+	}
+	message, err := c.encode(event)
+	if err != nil {
+		return 0, nil, err
+	}
+	return websocket.TextMessage, bytes.NewReader(message), nil
+}
+
+// encode translates an event into the Twilio message it stands for
+func (c *transportConn) encode(event Event) ([]byte, error) {
+	msg := map[string]interface{}{"event": string(event.Type)}
+	switch event.Type {
+	case EventStart:
+		if event.Start == nil || event.Start.SessionID == "" {
+			return nil, fmt.Errorf("start event needs a session ID")
+		}
+		c.sessionID = event.Start.SessionID
+		msg["start"] = map[string]interface{}{
+			"callSid":          c.sessionID,
+			"streamSid":        c.sessionID,
+			"tracks":           []string{telephony.TrackInbound},
+			"customParameters": event.Start.parameters(),
+		}
+	case EventAudio:
+		msg["media"] = map[string]interface{}{
+			"track":     telephony.TrackInbound,
+			"timestamp": fmt.Sprint(c.received / 8), // Milliseconds of audio before this
+			"payload":   base64.StdEncoding.EncodeToString(event.Audio),
+		}
+		c.received += len(event.Audio)
+	case EventMark:
+		msg["mark"] = map[string]interface{}{"name": event.Mark}
+	case EventDigit:
+		msg["dtmf"] = map[string]interface{}{"track": telephony.TrackInbound, "digit": event.Digit}
+	case EventStop:
+	default:
+		return nil, fmt.Errorf("unknown event type %q", event.Type)
+	}
+	msg["callSid"] = c.sessionID
+	msg["streamSid"] = c.sessionID
+	return json.Marshal(msg)
+}
+
+// parameters returns the start's fields as Twilio stream parameters
+func (s *Start) parameters() map[string]string {
+	params := make(map[string]string)
+	for name, value := range map[string]string{
+		twilio.ParamFirmID:   s.FirmID,
+		twilio.ParamUserID:   s.UserID,
+		twilio.ParamCallID:   s.CallID,
+		twilio.ParamLanguage: s.Language,
+		twilio.ParamFrom:     s.From,
+		twilio.ParamTo:       s.To,
+	} {
+		if value != "" {
+			params[name] = value
+		}
+	}
+	return params
+}
+
+// WriteJSON sends a Twilio message from the session (media, mark or clear)
+// through the transport
+func (c *transportConn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var msg telephony.TwilioMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+
+	switch msg.Event {
+	case "media":
+		if msg.Media == nil {
+			return fmt.Errorf("media message without media")
+		}
+		audio, err := base64.StdEncoding.DecodeString(msg.Media.Payload)
+		if err != nil {
+			return err
+		}
+		return c.transport.SendAudio(audio)
+	case "mark":
+		if msg.Mark == nil {
+			return fmt.Errorf("mark message without a mark")
+		}
+		return c.transport.SendMark(msg.Mark.Name)
+	case "clear":
+		return c.transport.Clear()
+	}
+	return fmt.Errorf("unexpected %q message", msg.Event)
+}
+
+// WriteControl drops the session's keepalive pings; a transport keeps its
+// own connection alive
+func (c *transportConn) WriteControl(int, []byte, time.Time) error {
+	return nil
+}
+
+// SetReadDeadline and SetWriteDeadline are no-ops; a transport bounds its own I/O
+func (c *transportConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *transportConn) SetWriteDeadline(time.Time) error { return nil }
+
+// Close closes the transport once; the session closes its connection both
+// when the agent ends the conversation and when the session finishes
+func (c *transportConn) Close() error {
+	c.closeOnce.Do(func() { c.closeErr = c.transport.Close() })
+	return c.closeErr
+}
@@ -0,0 +1,157 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+)
+
+// fakeTransport replays events and records what the engine sends
+type fakeTransport struct {
+	events []Event
+	audio  [][]byte
+	marks  []string
+	clears int
+	closes int
+}
+
+func (f *fakeTransport) Receive() (Event, error) {
+	if len(f.events) == 0 {
+		return Event{}, io.EOF
+	}
+	event := f.events[0]
+	f.events = f.events[1:]
+	return event, nil
+}
+
+func (f *fakeTransport) SendAudio(audio []byte) error { f.audio = append(f.audio, audio); return nil }
+func (f *fakeTransport) SendMark(name string) error   { f.marks = append(f.marks, name); return nil }
+func (f *fakeTransport) Clear() error                 { f.clears++; return nil }
+func (f *fakeTransport) Close() error                 { f.closes++; return nil }
+
+// readTwilio reads the conn's next message as the session decodes it
+func readTwilio(t *testing.T, c *transportConn) telephony.TwilioMessage {
+	t.Helper()
+	_, r, err := c.NextReader()
+	if err != nil {
+		t.Fatalf("NextReader failed: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	var msg telephony.TwilioMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("Failed to decode %s: %v", data, err)
+	}
+	return msg
+}
+
+func TestTransportConn_ReadsEventsAsTwilioMessages(t *testing.T) {
+	transport := &fakeTransport{events: []Event{
+		{Type: EventStart, Start: &Start{SessionID: "web-1", FirmID: "firm-a", Language: "es"}},
+		{Type: EventAudio, Audio: make([]byte, 160)},
+		{Type: EventAudio, Audio: []byte{0xff, 0x7f}},
+		{Type: EventMark, Mark: "utterance-1"},
+		{Type: EventDigit, Digit: "5"},
+		{Type: EventStop},
+	}}
+	c := newTransportConn(transport)
+
+	start := readTwilio(t, c)
+	if start.Event != "start" || start.CallSid != "web-1" || start.Start == nil || start.Start.StreamSid != "web-1" {
+		t.Fatalf("Expected a start event for web-1, got %+v", start)
+	}
+	params := start.Start.CustomParameters
+	if params.FirmID != "firm-a" || params.Language != "es" || len(params.Unknown) > 0 {
+		t.Errorf("Expected firm-a's stream parameters, got %+v", params)
+	}
+	if err := params.Check(nil); err != nil {
+		t.Errorf("Expected valid stream parameters, got %v", err)
+	}
+
+	if media := readTwilio(t, c); media.Media == nil || media.Media.Timestamp != "0" || media.Media.Track != telephony.TrackInbound {
+		t.Errorf("Expected inbound media at 0ms, got %+v", media.Media)
+	}
+	if media := readTwilio(t, c); media.Media == nil || media.Media.Timestamp != "20" || media.Media.Payload != "/38=" {
+		t.Errorf("Expected the second frame at 20ms, got %+v", media.Media)
+	}
+	if mark := readTwilio(t, c); mark.Mark == nil || mark.Mark.Name != "utterance-1" {
+		t.Errorf("Expected mark utterance-1, got %+v", mark)
+	}
+	if dtmf := readTwilio(t, c); dtmf.DTMF == nil || dtmf.DTMF.Digit != "5" {
+		t.Errorf("Expected digit 5, got %+v", dtmf)
+	}
+	if stop := readTwilio(t, c); stop.Event != "stop" || stop.StreamSid != "web-1" {
+		t.Errorf("Expected stop for web-1, got %+v", stop)
+	}
+	if _, _, err := c.NextReader(); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the transport's error, got %v", err)
+	}
+}
+
+func TestTransportConn_RejectsBadEvents(t *testing.T) {
+	for name, event := range map[string]Event{
+		"start without session": {Type: EventStart, Start: &Start{FirmID: "firm-a"}},
+		"unknown type":          {Type: "hangup"},
+	} {
+		c := newTransportConn(&fakeTransport{events: []Event{event}})
+		if _, _, err := c.NextReader(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTransportConn_WritesTwilioMessagesToTransport(t *testing.T) {
+	transport := &fakeTransport{}
+	c := newTransportConn(transport)
+
+	// As the session builds its outbound messages
+	messages := []map[string]interface{}{
+		{"event": "media", "streamSid": "web-1", "media": map[string]interface{}{"payload": "/38="}},
+		{"event": "mark", "streamSid": "web-1", "mark": map[string]interface{}{"name": "utterance-1"}},
+		{"event": "clear", "streamSid": "web-1"},
+	}
+	for _, msg := range messages {
+		if err := c.WriteJSON(msg); err != nil {
+			t.Fatalf("WriteJSON(%v) failed: %v", msg, err)
+		}
+	}
+	if len(transport.audio) != 1 || !bytes.Equal(transport.audio[0], []byte{0xff, 0x7f}) {
+		t.Errorf("Expected the decoded audio, got %v", transport.audio)
+	}
+	if len(transport.marks) != 1 || transport.marks[0] != "utterance-1" || transport.clears != 1 {
+		t.Errorf("Expected one mark and one clear, got %v and %d", transport.marks, transport.clears)
+	}
+
+	if err := c.WriteJSON(map[string]interface{}{"event": "hangup"}); err == nil {
+		t.Error("Expected an unknown message to fail")
+	}
+}
+
+func TestEngine_ServeClosesTransport(t *testing.T) {
+	engine, err := New(&config.Config{})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	// A client gone before the conversation starts ends it
+	transport := &fakeTransport{}
+	done := make(chan struct{})
+	go func() {
+		engine.Serve(context.Background(), transport)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Serve to return once the transport failed")
+	}
+	if transport.closes != 1 {
+		t.Errorf("Expected the transport closed once, got %d", transport.closes)
+	}
+}
@@ -0,0 +1,71 @@
+// Package voice embeds the gateway's conversation engine (STT, turn taking,
+// the orchestrator, TTS) in other services, behind their own transport: a
+// web voice widget's WebSocket, a SIP bridge, or a test harness. Twilio calls
+// keep using the gateway's own handler; an embedder supplies a Transport
+package voice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lexiqai/voice-gateway/internal/config"
+	"github.com/lexiqai/voice-gateway/internal/firm"
+	"github.com/lexiqai/voice-gateway/internal/i18n"
+	"github.com/lexiqai/voice-gateway/internal/lockout"
+	"github.com/lexiqai/voice-gateway/internal/routing"
+	"github.com/lexiqai/voice-gateway/internal/telephony"
+)
+
+// Config is the gateway's configuration; the engine reads the same
+// environment variables (DEEPGRAM_*, TTS_*, ORCHESTRATOR_*, ...) as the gateway
+type Config = config.Config
+
+// LoadConfig reads and validates configuration as the gateway does
+func LoadConfig() (*Config, error) {
+	return config.Load()
+}
+
+// Engine runs conversations over embedders' transports. It is safe for
+// concurrent use; each Serve is one conversation
+type Engine struct {
+	config   *Config
+	services *telephony.Services
+}
+
+// New creates an engine from cfg, loading the firm settings and message
+// catalog it names. Gateway services an embedder has no use for (Twilio call
+// control, recording storage, event sinks, supervisor dashboards) are off
+func New(cfg *Config) (*Engine, error) {
+	firms, err := firm.LoadRegistry(cfg.FirmConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load firm configuration: %w", err)
+	}
+	messages, err := i18n.LoadCatalog(cfg.I18nCatalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load i18n catalog: %w", err)
+	}
+	voices, err := i18n.ParseVoices(cfg.TTSVoices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TTS_VOICES: %w", err)
+	}
+
+	return &Engine{
+		config: cfg,
+		services: &telephony.Services{
+			Firms:    firms,
+			Router:   routing.NewEngine(),
+			Messages: messages,
+			Voices:   voices,
+
+			VerifyFailures: lockout.NewMemoryCounter(time.Duration(cfg.VerificationLockoutMinutes) * time.Minute),
+		},
+	}, nil
+}
+
+// Serve runs one conversation over transport until it ends: the transport
+// sends a stop event or fails to receive, or the agent ends the conversation.
+// The first event transport receives must be EventStart. Serve closes transport
+func (e *Engine) Serve(ctx context.Context, transport Transport) {
+	telephony.ServeConn(ctx, newTransportConn(transport), e.config, e.services)
+}
@@ -83,10 +83,10 @@ resource "azurerm_container_app" "voice_gateway" {
         value = "true"
       }
 
-      # Health probe
+      # Health probes: liveness restarts a wedged process, readiness stops new calls
       liveness_probe {
         transport        = "HTTP"
-        path             = "/health"
+        path             = "/health/live"
         port             = 8080
         interval_seconds = 30
         timeout          = 10
@@ -94,7 +94,7 @@ resource "azurerm_container_app" "voice_gateway" {
 
       readiness_probe {
         transport        = "HTTP"
-        path             = "/health"
+        path             = "/health/ready"
         port             = 8080
         interval_seconds = 10
         timeout          = 10